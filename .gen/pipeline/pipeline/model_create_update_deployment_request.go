@@ -39,4 +39,6 @@ type CreateUpdateDeploymentRequest struct {
 
 	// current values of the deployment
 	Values map[string]interface{} `json:"values,omitempty"`
+
+	Verify DeploymentVerification `json:"verify,omitempty"`
 }
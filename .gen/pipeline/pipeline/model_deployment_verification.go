@@ -0,0 +1,23 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type DeploymentVerification struct {
+
+	// if set, the resources of the deployment have to become ready after install or upgrade, otherwise the deployment is rolled back
+	Enabled bool `json:"enabled,omitempty"`
+
+	// verification window in seconds (default 300)
+	Timeout int64 `json:"timeout,omitempty"`
+
+	// if set, the tests of the chart are run once the resources of the deployment are ready
+	RunTests bool `json:"runTests,omitempty"`
+}
@@ -0,0 +1,29 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+package pipeline
+
+type HelmChartPolicy struct {
+
+	// repositories charts can be deployed from (repository names or oci:// registry locations); all repositories are allowed when empty
+	AllowedRepositories []string `json:"allowedRepositories,omitempty"`
+
+	// patterns (eg. stable/*) charts must match to be deployed; all charts are allowed when empty
+	AllowedCharts []string `json:"allowedCharts,omitempty"`
+
+	// patterns of charts that must not be deployed
+	DeniedCharts []string `json:"deniedCharts,omitempty"`
+
+	VersionConstraints []HelmChartVersionConstraint `json:"versionConstraints,omitempty"`
+
+	// if set, charts must be signed and their provenance is verified against the keyring configured in Pipeline
+	RequireProvenance bool `json:"requireProvenance,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+package pipeline
+
+type HelmChartVersionConstraint struct {
+
+	// pattern of the charts the constraint applies to
+	Chart string `json:"chart"`
+
+	// semantic version constraint (eg. >= 1.2.0, < 2.0.0)
+	Constraint string `json:"constraint"`
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/banzaicloud/pipeline/internal/app/frontend"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/process"
 	"github.com/banzaicloud/pipeline/internal/cmd"
 	"github.com/banzaicloud/pipeline/internal/platform/gin/auditlog/auditlogdriver"
 	"github.com/banzaicloud/pipeline/src/auth"
@@ -51,6 +52,9 @@ type configuration struct {
 
 	Pipeline PipelineConfig
 
+	// Process configuration
+	Processes process.Config
+
 	SpotMetrics struct {
 		Enabled            bool
 		CollectionInterval time.Duration
@@ -59,7 +63,7 @@ type configuration struct {
 
 // Validate validates the configuration.
 func (c configuration) Validate() error {
-	return errors.Combine(c.Auth.Validate(), c.Config.Validate(), c.Frontend.Validate())
+	return errors.Combine(c.Auth.Validate(), c.Config.Validate(), c.Frontend.Validate(), c.Processes.Validate())
 }
 
 // Process post-processes the configuration after loading (before validation).
@@ -185,6 +189,11 @@ func configure(v *viper.Viper, p *pflag.FlagSet) {
 	v.SetDefault("cors::allowOrigins", []string{})
 	v.SetDefault("cors::allowOriginsRegexp", "")
 
+	v.SetDefault("processes::eventPollInterval", 2*time.Second)
+	v.SetDefault("processes::retention::enabled", false)
+	v.SetDefault("processes::retention::maxAge", 30*24*time.Hour)
+	v.SetDefault("processes::retention::interval", time.Hour)
+
 	v.SetDefault("spotmetrics::enabled", false)
 	v.SetDefault("spotmetrics::collectionInterval", 30*time.Second)
 }
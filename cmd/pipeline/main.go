@@ -66,13 +66,21 @@ import (
 	cloudinfoapi "github.com/banzaicloud/pipeline/.gen/cloudinfo"
 	anchore2 "github.com/banzaicloud/pipeline/internal/anchore"
 	"github.com/banzaicloud/pipeline/internal/app/frontend"
+	"github.com/banzaicloud/pipeline/internal/app/frontend/notification"
+	"github.com/banzaicloud/pipeline/internal/app/frontend/notification/notificationadapter"
+	"github.com/banzaicloud/pipeline/internal/app/frontend/notification/notificationdriver"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/role"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/role/roleadapter"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/role/roledriver"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/token"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/token/tokenadapter"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/token/tokendriver"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/cap/capdriver"
 	googleproject "github.com/banzaicloud/pipeline/internal/app/pipeline/cloud/google/project"
 	googleprojectdriver "github.com/banzaicloud/pipeline/internal/app/pipeline/cloud/google/project/projectdriver"
+	processdomain "github.com/banzaicloud/pipeline/internal/app/pipeline/process"
 	process "github.com/banzaicloud/pipeline/internal/app/pipeline/process/app"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/process/processadapter"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/secrettype"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/secrettype/secrettypedriver"
 	arkClusterManager "github.com/banzaicloud/pipeline/internal/ark/clustermanager"
@@ -84,10 +92,21 @@ import (
 	"github.com/banzaicloud/pipeline/internal/cluster/clusterdriver"
 	"github.com/banzaicloud/pipeline/internal/cluster/clustersecret"
 	"github.com/banzaicloud/pipeline/internal/cluster/clustersecret/clustersecretadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/aks"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/aks/aksadapter"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksadapter"
 	eksDriver "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/driver"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/workflow"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke/gkeadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws/pkeawsadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeazure"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeazure/pkeazureadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulatedadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulateddriver"
 	"github.com/banzaicloud/pipeline/internal/cluster/endpoints"
 	prometheusMetrics "github.com/banzaicloud/pipeline/internal/cluster/metrics/adapters/prometheus"
 	"github.com/banzaicloud/pipeline/internal/clustergroup"
@@ -108,6 +127,7 @@ import (
 	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedservicesdriver"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/certmanager"
 	integratedServiceDNS "github.com/banzaicloud/pipeline/internal/integratedservices/services/dns"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/dns/dnsadapter"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/expiry"
@@ -140,11 +160,17 @@ import (
 	azurePKEDriver "github.com/banzaicloud/pipeline/internal/providers/azure/pke/driver"
 	"github.com/banzaicloud/pipeline/internal/providers/google"
 	"github.com/banzaicloud/pipeline/internal/providers/google/googleadapter"
+	kubernetesprovider "github.com/banzaicloud/pipeline/internal/providers/kubernetes"
+	kubernetesprovideradapter "github.com/banzaicloud/pipeline/internal/providers/kubernetes/kubernetesadapter"
 	vspherePKEAdapter "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/adapter"
 	vspherePKEDriver "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/driver"
+	"github.com/banzaicloud/pipeline/internal/secret/certexpiry/certexpiryadapter"
 	"github.com/banzaicloud/pipeline/internal/secret/pkesecret"
 	"github.com/banzaicloud/pipeline/internal/secret/restricted"
-	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding/secretbindingadapter"
+	"github.com/banzaicloud/pipeline/internal/secret/secretrotation"
+	"github.com/banzaicloud/pipeline/internal/secret/secretrotation/secretrotationadapter"
 	"github.com/banzaicloud/pipeline/internal/secret/types"
 	anchore "github.com/banzaicloud/pipeline/internal/security"
 	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
@@ -158,6 +184,7 @@ import (
 	"github.com/banzaicloud/pipeline/src/api/ark/backups"
 	"github.com/banzaicloud/pipeline/src/api/ark/backupservice"
 	"github.com/banzaicloud/pipeline/src/api/ark/buckets"
+	"github.com/banzaicloud/pipeline/src/api/ark/migrations"
 	"github.com/banzaicloud/pipeline/src/api/ark/restores"
 	"github.com/banzaicloud/pipeline/src/api/ark/schedules"
 	"github.com/banzaicloud/pipeline/src/api/cluster/namespace"
@@ -275,7 +302,14 @@ func main() {
 	emperror.Panic(err)
 	global.SetVault(vaultClient)
 
-	secretStore := secretadapter.NewVaultStore(vaultClient, "secret")
+	// Connect to database
+	db, err := database.Connect(config.Database.Config)
+	emperror.Panic(errors.WithMessage(err, "failed to initialize db"))
+	global.SetDB(db)
+
+	secretStore, err := cmd.CreateSecretStore(config.Secret.Backend, config.Secret.Database, db, vaultClient)
+	emperror.Panic(errors.WithMessage(err, "failed to create secret store"))
+
 	pkeSecreter := pkesecret.NewPkeSecreter(vaultClient, commonLogger)
 	secretTypes := types.NewDefaultTypeList(types.DefaultTypeListConfig{
 		AmazonRegion:       config.Cloud.Amazon.DefaultRegion,
@@ -285,11 +319,6 @@ func main() {
 	secret.InitSecretStore(secretStore, secretTypes)
 	restricted.InitSecretStore(secret.Store)
 
-	// Connect to database
-	db, err := database.Connect(config.Database.Config)
-	emperror.Panic(errors.WithMessage(err, "failed to initialize db"))
-	global.SetDB(db)
-
 	publisher, subscriber := watermill.NewPubSub(logger)
 	defer publisher.Close()
 	defer subscriber.Close()
@@ -367,6 +396,7 @@ func main() {
 	}
 
 	prometheus.MustRegister(cluster.NewExporter())
+	prometheus.MustRegister(certexpiryadapter.NewPrometheusCollector(certexpiryadapter.NewGormStore(db, commonLogger), commonLogger))
 
 	clusterEventBus := evbus.New()
 	clusterEvents := cluster.NewClusterEvents(clusterEventBus)
@@ -416,6 +446,11 @@ func main() {
 		errorHandler.Handle(errors.WrapIf(err, "Failed to configure Cadence client"))
 	}
 
+	workflowServiceClient, err := cadence.NewServiceClient(config.Cadence, zaplog.New(logur.WithFields(logger, map[string]interface{}{"component": "cadence-service-client"})))
+	if err != nil {
+		errorHandler.Handle(errors.WrapIf(err, "Failed to configure Cadence service client"))
+	}
+
 	releaseDeleter := cmd.CreateReleaseDeleter(config.Helm, db, commonSecretStore, commonLogger)
 
 	clusterManager := cluster.NewManager(clusters, secretValidator, clusterEvents, statusChangeDurationMetric, clusterTotalMetric, workflowClient, logrusLogger, errorHandler, clusteradapter.NewStore(db, clusters), releaseDeleter)
@@ -443,6 +478,27 @@ func main() {
 		)
 	}
 
+	if config.Processes.Retention.Enabled {
+		ctx, cancel := context.WithCancel(context.Background())
+		job := processdomain.NewRetentionJob(
+			processadapter.NewGormStore(db),
+			config.Processes.Retention.MaxAge,
+			commonLogger.WithFields(map[string]interface{}{"subsystem": "process-retention"}),
+			commonErrorHandler,
+		)
+
+		group.Add(
+			func() error {
+				job.Run(ctx, config.Processes.Retention.Interval)
+
+				return nil
+			},
+			func(err error) {
+				cancel()
+			},
+		)
+	}
+
 	cloudinfoClient := cloudinfo.NewClient(cloudinfoapi.NewAPIClient(&cloudinfoapi.Configuration{
 		BasePath:      config.Cloudinfo.Endpoint,
 		DefaultHeader: make(map[string]string),
@@ -488,6 +544,16 @@ func main() {
 			workflowClient,
 		),
 	}
+	if config.Distribution.Simulated.Enabled {
+		simulatedClusterCreator := simulateddriver.MakeClusterCreator(
+			commonLogger,
+			authdriver.NewOrganizationGetter(db),
+			secret.Store,
+			simulatedadapter.NewClusterStore(db),
+			workflowClient,
+		)
+		clusterCreators.Simulated = &simulatedClusterCreator
+	}
 
 	orgService := helmadapter.NewOrgService(commonLogger)
 
@@ -651,7 +717,14 @@ func main() {
 	auth.Install(engine)
 	auth.StartTokenStoreGC(tokenStore)
 
-	enforcer := auth.NewRbacEnforcer(organizationStore, serviceAccountService, commonLogger)
+	roleStore := roleadapter.NewGormStore(db)
+	enforcer := auth.NewRbacEnforcer(
+		organizationStore,
+		roleStore,
+		roleadapter.NewGormClusterSource(db),
+		serviceAccountService,
+		commonLogger,
+	)
 	authorizationMiddleware := ginauth.NewMiddleware(enforcer, basePath, errorHandler)
 
 	clusterSecretStore := clustersecret.NewStore(
@@ -682,6 +755,26 @@ func main() {
 
 	networkAPI := api.NewNetworkAPI(logrusLogger)
 
+	secretBindingService := secretbinding.NewService(
+		secretbindingadapter.NewGormStore(db, commonLogger),
+		secretbindingadapter.NewSecretStore(secret.Store),
+		clusteradapter.NewStore(db, clusters),
+		secretbindingadapter.NewKubeSecretManager(clusterSvc),
+		commonLogger,
+	)
+	secretbindingadapter.SubscribeEvents(clusterEventBus, secretBindingService, commonLogger)
+	secret.Store.SetEvents(secret.NewSecretEvents(clusterEventBus))
+	secretBindingAPI := api.NewSecretBindingAPI(secretBindingService, errorHandler)
+
+	secretRotationService := secretrotation.NewService(
+		secretrotationadapter.NewGormStore(db, commonLogger),
+		secretrotationadapter.NewSecretStore(secret.Store),
+		commonLogger,
+	)
+	secretrotationadapter.SubscribeEvents(clusterEventBus, secretRotationService, commonLogger)
+	integratedserviceadapter.SubscribeSecretEvents(clusterEventBus, workflowClient, commonLogger)
+	secretRotationAPI := api.NewSecretRotationAPI(secretRotationService, errorHandler)
+
 	{
 		// cancel cancel shared spotguides sync workflow
 		err = workflowClient.CancelWorkflow(context.Background(), "scrape-shared-spotguides", "")
@@ -690,6 +783,12 @@ func main() {
 		}
 	}
 
+	importedClusterHealthChecker := kubernetesprovider.NewHealthChecker(
+		clusteradapter.NewStore(db, clusters),
+		kubernetesprovideradapter.NewClientHealthProber(kubernetes.NewClientFactory(configFactory), config.Cluster.Imported.HealthCheck.Timeout),
+		commonLogger,
+	)
+
 	clusterAPI := api.NewClusterAPI(
 		clusterManager,
 		commonClusterGetter,
@@ -704,6 +803,7 @@ func main() {
 		unifiedHelmReleaser,
 		config.Auth,
 		clusterAuthService,
+		importedClusterHealthChecker,
 	)
 
 	v1 := base.Group("api/v1")
@@ -756,6 +856,9 @@ func main() {
 				cRouter.PUT("", clusterAPI.UpdateCluster)
 
 				cRouter.PUT("/posthooks", clusterAPI.ReRunPostHooks)
+				cRouter.GET("/health", clusterAPI.GetClusterHealth)
+				cRouter.PUT("/credentials", clusterAPI.RotateClusterCredentials)
+				cRouter.POST("/detach", clusterAPI.DetachCluster)
 				cRouter.POST("/secrets", api.InstallSecretsToCluster)
 				cRouter.POST("/secrets/:secretName", api.InstallSecretToCluster)
 				cRouter.PATCH("/secrets/:secretName", api.MergeSecretInCluster)
@@ -796,6 +899,9 @@ func main() {
 					deploymentsRouter.HEAD(":name", gin.WrapH(router))
 					deploymentsRouter.DELETE(":name", gin.WrapH(router))
 					deploymentsRouter.GET(":name/resources", gin.WrapH(router))
+					deploymentsRouter.GET(":name/history", gin.WrapH(router))
+					deploymentsRouter.POST(":name/rollback", gin.WrapH(router))
+					deploymentsRouter.POST(":name/diff", gin.WrapH(router))
 
 					// other version dependant operations
 					cRouter.GET("/endpoints", api.MakeEndpointLister(cs, helmFacade, logger).ListEndpoints)
@@ -839,6 +945,102 @@ func main() {
 					// Used by legacy cluster create and update code
 					globaleks.SetImageSelector(eks.NewDefaultImageSelector())
 
+					pkeAWSImageSelector := pkeaws.NewImageSelectorChain(commonLogger, errorHandler)
+
+					pkeAWSImageSelector.AddSelector("gpu", pkeaws.NewGPUImageSelector(pkeaws.GPUImages()))
+					pkeAWSImageSelector.AddSelector("cloudinfo", pkeawsadapter.NewCloudinfoImageSelector(cloudinfoapi.NewAPIClient(&cloudinfoapi.Configuration{
+						BasePath:      config.Cloudinfo.Endpoint,
+						DefaultHeader: make(map[string]string),
+						UserAgent:     fmt.Sprintf("Pipeline/%s", version),
+					})))
+					if len(config.Distribution.PKE.Amazon.DefaultImages) > 0 {
+						pkeAWSImageSelector.AddSelector("defaults", pkeaws.RegionMapImageSelector(config.Distribution.PKE.Amazon.DefaultImages))
+					} else {
+						pkeAWSImageSelector.AddSelector("defaults", pkeaws.DefaultImages())
+					}
+
+					getUserID := func(ctx context.Context) uint {
+						if currentUser := ctx.Value(auth2.CurrentUser); currentUser != nil {
+							return currentUser.(*auth.User).ID
+						}
+
+						return 0
+					}
+
+					aksService := clusteradapter.NewAKSService(aks.NewService(
+						clusterStore,
+						aksadapter.NewNodePoolStore(db),
+						aksadapter.NewNodePoolManager(
+							db,
+							aksadapter.NewNodePoolStore(db),
+							dynamicClientFactory,
+							config.Cluster.Namespace,
+							workflowClient,
+							getUserID,
+						),
+					))
+
+					pkeAWSService := clusteradapter.NewPKEAWSService(pkeaws.NewService(
+						clusterStore,
+						pkeawsadapter.NewNodePoolStore(db),
+						pkeawsadapter.NewNodePoolManager(
+							db,
+							pkeawsadapter.NewNodePoolStore(db),
+							dynamicClientFactory,
+							config.Cluster.Namespace,
+							workflowClient,
+							externalBaseURL,
+							externalURLInsecure,
+							getUserID,
+						),
+					))
+
+					pkeAzureService := clusteradapter.NewPKEAzureService(pkeazure.NewService(
+						clusterStore,
+						pkeazureadapter.NewNodePoolStore(azurePKEClusterStore),
+						pkeazureadapter.NewNodePoolManager(
+							azurePKEClusterStore,
+							azurePKEDriver.MakeNodePoolManager(
+								logrusLogger,
+								externalBaseURL,
+								externalURLInsecure,
+								secret.Store,
+								azurePKEClusterStore,
+							),
+							dynamicClientFactory,
+							config.Cluster.Namespace,
+							workflowClient,
+							getUserID,
+						),
+					))
+
+					gkeService := clusteradapter.NewGKEService(gke.NewService(
+						clusterStore,
+						gkeadapter.NewNodePoolStore(db),
+						gkeadapter.NewNodePoolManager(
+							db,
+							gkeadapter.NewNodePoolStore(db),
+							dynamicClientFactory,
+							config.Cluster.Namespace,
+							workflowClient,
+							getUserID,
+						),
+					))
+
+					simulatedService := clusteradapter.NewSimulatedService(simulated.NewService(
+						clusterStore,
+						simulatedadapter.NewNodePoolStore(db),
+						simulateddriver.NewNodePoolManager(
+							simulatedadapter.NewNodePoolStore(db),
+							dynamicClientFactory,
+							config.Cluster.Namespace,
+							workflowClient,
+							getUserID,
+						),
+					))
+
+					genericNodePoolManager := clusteradapter.NewNodePoolManager(workflowClient, getUserID)
+
 					service := intCluster.NewService(
 						clusterStore,
 						clusteradapter.NewCadenceClusterManager(workflowClient),
@@ -857,30 +1059,51 @@ func main() {
 									workflowClient,
 								),
 							)),
+							"pke": clusteradapter.NewPKEService(clusterStore, map[string]intCluster.Service{
+								providers.Amazon: pkeAWSService,
+								providers.Azure:  pkeAzureService,
+							}),
+							"gke":       gkeService,
+							"aks":       aksService,
+							"simulated": simulatedService,
 						},
 						clusteradapter.NewNodePoolStore(db, clusterStore),
 						intCluster.NodePoolValidators{
 							intCluster.NewCommonNodePoolValidator(labelValidator),
 							intCluster.NewDistributionNodePoolValidator(map[string]intCluster.NodePoolValidator{
 								"eks": eksadapter.NewNodePoolValidator(db),
+								"pke": intCluster.NewCloudNodePoolValidator(map[string]intCluster.NodePoolValidator{
+									providers.Amazon: pkeawsadapter.NewNodePoolValidator(db),
+									providers.Azure:  pkeazureadapter.NewNodePoolValidator(),
+								}),
+								"gke":       gkeadapter.NewNodePoolValidator(),
+								"aks":       aksadapter.NewNodePoolValidator(),
+								"simulated": simulatedadapter.NewNodePoolValidator(),
 							}),
 						},
 						intCluster.NodePoolProcessors{
 							intCluster.NewCommonNodePoolProcessor(labelSource),
 							intCluster.NewDistributionNodePoolProcessor(map[string]intCluster.NodePoolProcessor{
 								"eks": eksadapter.NewNodePoolProcessor(db, eks.NewDefaultImageSelector()),
+								"pke": intCluster.NewCloudNodePoolProcessor(map[string]intCluster.NodePoolProcessor{
+									providers.Amazon: pkeawsadapter.NewNodePoolProcessor(db, pkeAWSImageSelector),
+									providers.Azure:  pkeazureadapter.NewNodePoolProcessor(),
+								}),
+								"gke":       gkeadapter.NewNodePoolProcessor(),
+								"aks":       aksadapter.NewNodePoolProcessor(),
+								"simulated": simulatedadapter.NewNodePoolProcessor(),
 							}),
 						},
-						clusteradapter.NewNodePoolManager(
-							workflowClient,
-							func(ctx context.Context) uint {
-								if currentUser := ctx.Value(auth2.CurrentUser); currentUser != nil {
-									return currentUser.(*auth.User).ID
-								}
-
-								return 0
-							},
-						),
+						intCluster.NewDistributionNodePoolManager(clusterStore, map[string]intCluster.NodePoolManager{
+							"eks": genericNodePoolManager,
+							"pke": intCluster.NewCloudNodePoolManager(clusterStore, map[string]intCluster.NodePoolManager{
+								providers.Amazon: clusteradapter.NewServiceNodePoolManager(pkeAWSService),
+								providers.Azure:  clusteradapter.NewServiceNodePoolManager(pkeAzureService),
+							}),
+							"gke":       clusteradapter.NewServiceNodePoolManager(gkeService),
+							"aks":       clusteradapter.NewServiceNodePoolManager(aksService),
+							"simulated": clusteradapter.NewServiceNodePoolManager(simulatedService),
+						}),
 					)
 
 					endpoints := clusterdriver.MakeEndpoints(
@@ -905,7 +1128,7 @@ func main() {
 			// Cluster IntegratedService API
 			var integratedServicesService integratedservices.Service
 			{
-				featureRepository := integratedserviceadapter.NewGormIntegratedServiceRepository(db, commonLogger)
+				featureRepository := integratedserviceadapter.NewGormIntegratedServiceRepository(db, auth.UserExtractor{}, commonLogger)
 				clusterGetter := integratedserviceadapter.MakeClusterGetter(clusterManager)
 				clusterPropertyGetter := dnsadapter.NewClusterPropertyGetter(clusterManager)
 				endpointManager := endpoints.NewEndpointManager(commonLogger)
@@ -990,6 +1213,14 @@ func main() {
 					))
 				}
 
+				if config.Cluster.CertManager.Enabled {
+					integratedServiceManagers = append(integratedServiceManagers, certmanager.NewManager(
+						config.Cluster.CertManager.Config,
+						unifiedHelmReleaser,
+						commonLogger,
+					))
+				}
+
 				integratedServiceManagerRegistry := integratedservices.MakeIntegratedServiceManagerRegistry(integratedServiceManagers)
 				integratedServiceOperationDispatcher := integratedserviceadapter.MakeCadenceIntegratedServiceOperationDispatcher(workflowClient, commonLogger)
 				integratedServicesService = integratedservices.MakeIntegratedServiceService(integratedServiceOperationDispatcher, integratedServiceManagerRegistry, featureRepository, commonLogger)
@@ -1007,6 +1238,8 @@ func main() {
 
 					cRouter.Any("/services", gin.WrapH(router))
 					cRouter.Any("/services/:serviceName", gin.WrapH(router))
+					cRouter.GET("/services/:serviceName/revisions", gin.WrapH(router))
+					cRouter.POST("/services/:serviceName/rollback", gin.WrapH(router))
 				}
 
 				// set up legacy endpoint
@@ -1086,6 +1319,10 @@ func main() {
 
 				// TODO using "chart" instead of  "charts" for backwards compatibility
 				orgs.GET("/:orgid/helm/chart/:reponame/:name", gin.WrapH(router))
+
+				orgs.GET("/:orgid/helm/policy", gin.WrapH(router))
+				orgs.PUT("/:orgid/helm/policy", gin.WrapH(router))
+				orgs.DELETE("/:orgid/helm/policy", gin.WrapH(router))
 			}
 			orgs.GET("/:orgid/secrets", api.ListSecrets)
 			orgs.GET("/:orgid/secrets/:id", api.GetSecret)
@@ -1096,6 +1333,19 @@ func main() {
 			orgs.GET("/:orgid/secrets/:id/tags", api.GetSecretTags)
 			orgs.PUT("/:orgid/secrets/:id/tags/*tag", api.AddSecretTag)
 			orgs.DELETE("/:orgid/secrets/:id/tags/*tag", api.DeleteSecretTag)
+			orgs.GET("/:orgid/secrets/:id/versions", api.ListSecretVersions)
+			orgs.GET("/:orgid/secrets/:id/versions/:version", api.GetSecretVersion)
+			orgs.POST("/:orgid/secrets/:id/versions/:version/restore", api.RestoreSecretVersion)
+			orgs.GET("/:orgid/secretbindings", secretBindingAPI.ListSecretBindings)
+			orgs.POST("/:orgid/secretbindings", secretBindingAPI.CreateSecretBinding)
+			orgs.GET("/:orgid/secretbindings/:bindingId", secretBindingAPI.GetSecretBinding)
+			orgs.DELETE("/:orgid/secretbindings/:bindingId", secretBindingAPI.DeleteSecretBinding)
+			orgs.POST("/:orgid/secretbindings/:bindingId/sync", secretBindingAPI.SyncSecretBinding)
+			orgs.GET("/:orgid/secretrotations", secretRotationAPI.ListSecretRotationPolicies)
+			orgs.GET("/:orgid/secrets/:id/rotation", secretRotationAPI.GetSecretRotationPolicy)
+			orgs.PUT("/:orgid/secrets/:id/rotation", secretRotationAPI.SetSecretRotationPolicy)
+			orgs.DELETE("/:orgid/secrets/:id/rotation", secretRotationAPI.DeleteSecretRotationPolicy)
+			orgs.POST("/:orgid/secrets/:id/rotate", secretRotationAPI.RotateSecret)
 			orgs.GET("/:orgid/users", userAPI.GetUsers)
 			orgs.GET("/:orgid/users/:id", userAPI.GetUsers)
 
@@ -1131,19 +1381,53 @@ func main() {
 				orgs.Any("/:orgid/cloud/google/projects", gin.WrapH(router))
 			}
 
+			{
+				service := role.NewService(roleStore)
+				service = roledriver.AuthorizationMiddleware(auth.NewAuthorizer(db, enforcer))(service)
+				endpoints := roledriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				roledriver.RegisterHTTPHandlers(
+					endpoints,
+					orgRouter.PathPrefix("/roles").Subrouter(),
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.Any("/:orgid/roles", gin.WrapH(router))
+				orgs.Any("/:orgid/roles/:roleName", gin.WrapH(router))
+			}
+
+			{
+				service := notification.NewService(notificationadapter.NewGormStore(db))
+				endpoints := notificationdriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				notificationdriver.RegisterOrganizationHTTPHandlers(
+					endpoints,
+					orgRouter.PathPrefix("/notifications").Subrouter(),
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.GET("/:orgid/notifications", gin.WrapH(router))
+			}
+
 			orgs.GET("/:orgid", organizationAPI.GetOrganizations)
 			orgs.DELETE("/:orgid", organizationAPI.DeleteOrganization)
 		}
-		v1.GET("/orgs", organizationAPI.GetOrganizations)
-		v1.PUT("/orgs", organizationAPI.SyncOrganizations)
+		v1.GET("/orgs", authorizationMiddleware, organizationAPI.GetOrganizations)
+		v1.PUT("/orgs", authorizationMiddleware, organizationAPI.SyncOrganizations)
 
 		{
 			service := token.NewService(
 				auth.UserExtractor{},
-				tokenadapter.NewBankVaultsStore(tokenStore),
+				tokenadapter.NewScopedStore(tokenadapter.NewBankVaultsStore(tokenStore), db),
 				tokenGenerator,
 			)
-			service = tokendriver.AuthorizationMiddleware(auth.NewAuthorizer(db, organizationStore))(service)
+			service = tokendriver.AuthorizationMiddleware(auth.NewAuthorizer(db, enforcer))(service)
 
 			endpoints := tokendriver.MakeEndpoints(
 				service,
@@ -1156,8 +1440,8 @@ func main() {
 				kitxhttp.ServerOptions(httpServerOptions),
 			)
 
-			v1.Any("/tokens", gin.WrapH(router))
-			v1.Any("/tokens/*path", gin.WrapH(router))
+			v1.Any("/tokens", authorizationMiddleware, gin.WrapH(router))
+			v1.Any("/tokens/*path", authorizationMiddleware, gin.WrapH(router))
 		}
 
 		{
@@ -1182,6 +1466,8 @@ func main() {
 				orgRouter,
 				db,
 				workflowClient,
+				processadapter.NewCadenceWorkflowResetter(workflowServiceClient, config.Cadence.Domain),
+				config.Processes,
 				commonLogger,
 				commonErrorHandler,
 			)
@@ -1194,6 +1480,7 @@ func main() {
 		backups.AddRoutes(orgs.Group("/:orgid/clusters/:id/backups"))
 		backupservice.AddRoutes(orgs.Group("/:orgid/clusters/:id/backupservice"), unifiedHelmReleaser)
 		restores.AddRoutes(orgs.Group("/:orgid/clusters/:id/restores"))
+		migrations.AddRoutes(orgs.Group("/:orgid/clusters/:id/migrations"), workflowClient)
 		schedules.AddRoutes(orgs.Group("/:orgid/clusters/:id/schedules"))
 		buckets.AddRoutes(orgs.Group("/:orgid/backupbuckets"))
 		backups.AddOrgRoutes(orgs.Group("/:orgid/backups"), clusterManager)
@@ -19,10 +19,13 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/banzaicloud/pipeline/internal/app/frontend/notification/notificationadapter"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/role/roleadapter"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/token/tokenadapter"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/process/processadapter"
 	"github.com/banzaicloud/pipeline/internal/ark"
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter/clustermodel"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksmodel"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulatedadapter"
 	"github.com/banzaicloud/pipeline/internal/clustergroup"
 	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
 	"github.com/banzaicloud/pipeline/internal/common"
@@ -33,6 +36,10 @@ import (
 	"github.com/banzaicloud/pipeline/internal/providers/alibaba/alibabaadapter"
 	"github.com/banzaicloud/pipeline/internal/providers/azure/azureadapter"
 	"github.com/banzaicloud/pipeline/internal/providers/kubernetes/kubernetesadapter"
+	"github.com/banzaicloud/pipeline/internal/secret/certexpiry/certexpiryadapter"
+	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding/secretbindingadapter"
+	"github.com/banzaicloud/pipeline/internal/secret/secretrotation/secretrotationadapter"
 	"github.com/banzaicloud/pipeline/src/auth"
 	route53model "github.com/banzaicloud/pipeline/src/dns/route53/model"
 	"github.com/banzaicloud/pipeline/src/model"
@@ -52,6 +59,10 @@ func Migrate(db *gorm.DB, logger logrus.FieldLogger, commonLogger common.Logger)
 		return err
 	}
 
+	if err := simulatedadapter.Migrate(db, logger); err != nil {
+		return err
+	}
+
 	if err := azureadapter.Migrate(db, logger); err != nil {
 		return err
 	}
@@ -108,5 +119,29 @@ func Migrate(db *gorm.DB, logger logrus.FieldLogger, commonLogger common.Logger)
 		return err
 	}
 
+	if err := roleadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := tokenadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := secretbindingadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := secretrotationadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := certexpiryadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := secretadapter.MigrateDatabaseStore(db, commonLogger); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,44 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/ark"
+	"github.com/banzaicloud/pipeline/internal/ark/migration"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+func registerARKWorkflows(clusters migration.ClusterGetter, db *gorm.DB, helmService ark.HelmService, logger logrus.FieldLogger) {
+	migration.NewMigrateClusterWorkflow(processlog.New()).Register()
+
+	waitForClusterActivity := migration.NewWaitForClusterActivity(clusters)
+	activity.RegisterWithOptions(waitForClusterActivity.Execute, activity.RegisterOptions{Name: migration.WaitForClusterActivityName})
+
+	createBackupActivity := migration.NewCreateBackupActivity(clusters, db, logger)
+	activity.RegisterWithOptions(createBackupActivity.Execute, activity.RegisterOptions{Name: migration.CreateBackupActivityName})
+
+	deployRestoreServiceActivity := migration.NewDeployRestoreServiceActivity(clusters, db, helmService, logger)
+	activity.RegisterWithOptions(deployRestoreServiceActivity.Execute, activity.RegisterOptions{Name: migration.DeployRestoreServiceActivityName})
+
+	restoreBackupActivity := migration.NewRestoreBackupActivity(clusters, db, logger)
+	activity.RegisterWithOptions(restoreBackupActivity.Execute, activity.RegisterOptions{Name: migration.RestoreBackupActivityName})
+
+	removeRestoreServiceActivity := migration.NewRemoveRestoreServiceActivity(clusters, db, helmService, logger)
+	activity.RegisterWithOptions(removeRestoreServiceActivity.Execute, activity.RegisterOptions{Name: migration.RemoveRestoreServiceActivityName})
+}
@@ -15,12 +15,17 @@
 package main
 
 import (
+	"emperror.dev/errors"
 	"go.uber.org/cadence/activity"
 	"go.uber.org/cadence/workflow"
 
+	"github.com/banzaicloud/pipeline/internal/cloudformation"
+	eksworkflow "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/workflow"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws/pkeawsworkflow"
 	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow"
 	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow/pkeworkflowadapter"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
 )
 
 func registerAwsWorkflows(
@@ -112,3 +117,21 @@ func registerAwsWorkflows(
 	selectVolumeSizeActivity := pkeworkflow.NewSelectVolumeSizeActivity(awsClientFactory, ec2Factory)
 	activity.RegisterWithOptions(selectVolumeSizeActivity.Execute, activity.RegisterOptions{Name: pkeworkflow.SelectVolumeSizeActivityName})
 }
+
+func registerPKEAWSNodePoolWorkflows(secretStore eksworkflow.SecretStore, nodePools pkeaws.NodePoolStore) error {
+	workerTemplate, err := cloudformation.GetCloudFormationTemplate(pkeworkflow.PKECloudFormationTemplateBasePath, pkeworkflow.WorkerCloudFormationTemplate)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get CloudFormation template for worker node pools")
+	}
+
+	awsSessionFactory := eksworkflow.NewAWSSessionFactory(secretStore)
+
+	pkeawsworkflow.NewCreateNodePoolWorkflow(processlog.New()).Register()
+	pkeawsworkflow.NewUpdateNodePoolWorkflow(processlog.New()).Register()
+	pkeawsworkflow.NewDeleteNodePoolWorkflow(processlog.New()).Register()
+	pkeawsworkflow.NewUpdateNodePoolStackActivity(awsSessionFactory, workerTemplate).Register()
+	pkeawsworkflow.NewSaveNodePoolImageActivity(nodePools).Register()
+	pkeawsworkflow.NewDeleteStoredNodePoolActivity(nodePools).Register()
+
+	return nil
+}
@@ -18,10 +18,15 @@ import (
 	"go.uber.org/cadence/activity"
 	"go.uber.org/cadence/workflow"
 
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/aks"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/aks/aksworkflow"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeazure"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeazure/pkeazureworkflow"
 	"github.com/banzaicloud/pipeline/internal/providers/azure/pke"
 	azurepkeworkflow "github.com/banzaicloud/pipeline/internal/providers/azure/pke/workflow"
 	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow"
 	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow/pkeworkflowadapter"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
 )
 
 func registerAzureWorkflows(secretStore pkeworkflow.SecretStore, tokenGenerator pkeworkflowadapter.TokenGenerator, store pke.ClusterStore) {
@@ -98,3 +103,28 @@ func registerAzureWorkflows(secretStore pkeworkflow.SecretStore, tokenGenerator
 	updateClusterAccessPointsActivity := azurepkeworkflow.MakeUpdateClusterAccessPointsActivity(store)
 	activity.RegisterWithOptions(updateClusterAccessPointsActivity.Execute, activity.RegisterOptions{Name: azurepkeworkflow.UpdateClusterAccessPointsActivityName})
 }
+
+func registerAKSNodePoolWorkflows(secretStore pkeworkflow.SecretStore, nodePools aks.NodePoolStore) {
+	azureClientFactory := azurepkeworkflow.NewAzureClientFactory(secretStore)
+
+	aksworkflow.NewCreateNodePoolWorkflow(processlog.New()).Register()
+	aksworkflow.NewUpdateNodePoolWorkflow(processlog.New()).Register()
+	aksworkflow.NewDeleteNodePoolWorkflow(processlog.New()).Register()
+	aksworkflow.NewCreateAgentPoolActivity(azureClientFactory).Register()
+	aksworkflow.NewResizeAgentPoolActivity(azureClientFactory).Register()
+	aksworkflow.NewDeleteAgentPoolActivity(azureClientFactory).Register()
+	aksworkflow.NewSaveNodePoolActivity(nodePools).Register()
+	aksworkflow.NewDeleteStoredNodePoolActivity(nodePools).Register()
+}
+
+func registerPKEAzureNodePoolWorkflows(secretStore pkeworkflow.SecretStore, nodePools pkeazure.NodePoolStore) {
+	azureClientFactory := azurepkeworkflow.NewAzureClientFactory(secretStore)
+
+	pkeazureworkflow.NewCreateNodePoolWorkflow(processlog.New()).Register()
+	pkeazureworkflow.NewUpdateNodePoolWorkflow(processlog.New()).Register()
+	pkeazureworkflow.NewDeleteNodePoolWorkflow(processlog.New()).Register()
+	pkeazureworkflow.NewUpdateVMSSInstanceTypeActivity(azureClientFactory).Register()
+	pkeazureworkflow.NewListOutdatedVMSSInstancesActivity(azureClientFactory).Register()
+	pkeazureworkflow.NewUpdateVMSSInstancesActivity(azureClientFactory).Register()
+	pkeazureworkflow.NewSaveNodePoolActivity(nodePools).Register()
+}
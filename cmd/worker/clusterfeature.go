@@ -19,11 +19,20 @@ import (
 	"go.uber.org/cadence/workflow"
 
 	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter"
 	clusterfeatureworkflow "github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter/workflow"
 )
 
-func registerClusterFeatureWorkflows(featureOperatorRegistry integratedservices.IntegratedServiceOperatorRegistry, featureRepository integratedservices.IntegratedServiceRepository) {
+func registerClusterFeatureWorkflows(
+	featureOperatorRegistry integratedservices.IntegratedServiceOperatorRegistry,
+	featureRepository integratedserviceadapter.GORMIntegratedServiceRepository,
+	featureDriftDetector integratedservices.IntegratedServiceDriftDetector,
+	featureOperationDispatcher integratedservices.IntegratedServiceOperationDispatcher,
+) {
 	workflow.RegisterWithOptions(clusterfeatureworkflow.IntegratedServiceJobWorkflow, workflow.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceJobWorkflowName})
+	workflow.RegisterWithOptions(clusterfeatureworkflow.IntegratedServiceDryRunWorkflow, workflow.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceDryRunWorkflowName})
+	workflow.RegisterWithOptions(clusterfeatureworkflow.IntegratedServiceDriftDetectionWorkflow, workflow.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceDriftDetectionWorkflowName})
+	workflow.RegisterWithOptions(clusterfeatureworkflow.IntegratedServiceSecretRotatedWorkflow, workflow.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceSecretRotatedWorkflowName})
 
 	{
 		a := clusterfeatureworkflow.MakeIntegratedServicesApplyActivity(featureOperatorRegistry)
@@ -40,6 +49,11 @@ func registerClusterFeatureWorkflows(featureOperatorRegistry integratedservices.
 		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceDeactivateActivityName})
 	}
 
+	{
+		a := clusterfeatureworkflow.MakeIntegratedServiceDryRunActivity(featureOperatorRegistry)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceDryRunActivityName})
+	}
+
 	{
 		a := clusterfeatureworkflow.MakeIntegratedServiceSetSpecActivity(featureRepository)
 		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceSetSpecActivityName})
@@ -49,4 +63,24 @@ func registerClusterFeatureWorkflows(featureOperatorRegistry integratedservices.
 		a := clusterfeatureworkflow.MakeIntegratedServiceSetStatusActivity(featureRepository)
 		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceSetStatusActivityName})
 	}
+
+	{
+		a := clusterfeatureworkflow.MakeIntegratedServiceListDriftTargetsActivity(featureRepository)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceListDriftTargetsActivityName})
+	}
+
+	{
+		a := clusterfeatureworkflow.MakeIntegratedServiceDetectDriftActivity(featureRepository, featureDriftDetector)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceDetectDriftActivityName})
+	}
+
+	{
+		a := clusterfeatureworkflow.MakeIntegratedServiceReapplyActivity(featureRepository, featureOperationDispatcher)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceReapplyActivityName})
+	}
+
+	{
+		a := clusterfeatureworkflow.MakeIntegratedServiceListSecretReferencesActivity(featureRepository)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: clusterfeatureworkflow.IntegratedServiceListSecretReferencesActivityName})
+	}
 }
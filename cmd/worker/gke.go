@@ -0,0 +1,38 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke/gkeworkflow"
+	"github.com/banzaicloud/pipeline/internal/providers/google"
+	"github.com/banzaicloud/pipeline/internal/providers/google/googleadapter"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+func registerGKENodePoolWorkflows(secretStore googleadapter.OrganizationSecretStore, nodePools gke.NodePoolStore) {
+	gkeSecretStore := googleadapter.NewBRNSecretStore(secretStore)
+	clientFactory := google.NewClientFactory(gkeSecretStore)
+
+	gkeworkflow.NewCreateNodePoolWorkflow(processlog.New()).Register()
+	gkeworkflow.NewUpdateNodePoolWorkflow(processlog.New()).Register()
+	gkeworkflow.NewDeleteNodePoolWorkflow(processlog.New()).Register()
+	gkeworkflow.NewCreateNodePoolActivity(clientFactory, gkeSecretStore).Register()
+	gkeworkflow.NewUpdateNodePoolAutoscalingActivity(clientFactory, gkeSecretStore).Register()
+	gkeworkflow.NewResizeNodePoolActivity(clientFactory, gkeSecretStore).Register()
+	gkeworkflow.NewDeleteNodePoolActivity(clientFactory, gkeSecretStore).Register()
+	gkeworkflow.NewSaveNodePoolSizeActivity(nodePools).Register()
+	gkeworkflow.NewDeleteStoredNodePoolActivity(nodePools).Register()
+}
@@ -0,0 +1,50 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/providers/kubernetes"
+	"github.com/banzaicloud/pipeline/internal/providers/kubernetes/kubernetesadapter"
+	kubernetesworkflow "github.com/banzaicloud/pipeline/internal/providers/kubernetes/workflow"
+)
+
+func registerKubernetesWorkflows(
+	db *gorm.DB,
+	clusters kubernetes.ClusterStore,
+	clientFactory kubernetesadapter.KubeClientFactory,
+	healthCheckTimeout time.Duration,
+	logger common.Logger,
+) {
+	workflow.RegisterWithOptions(kubernetesworkflow.HealthCheckWorkflow, workflow.RegisterOptions{Name: kubernetesworkflow.HealthCheckWorkflowName})
+
+	{
+		a := kubernetesworkflow.MakeListImportedClustersActivity(kubernetesadapter.NewGORMImportedClusterLister(db))
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: kubernetesworkflow.ListImportedClustersActivityName})
+	}
+
+	{
+		healthChecker := kubernetes.NewHealthChecker(clusters, kubernetesadapter.NewClientHealthProber(clientFactory, healthCheckTimeout), logger)
+
+		a := kubernetesworkflow.MakeCheckClusterHealthActivity(healthChecker)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: kubernetesworkflow.CheckClusterHealthActivityName})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"emperror.dev/emperror"
 	"emperror.dev/errors"
 	"emperror.dev/errors/match"
+	evbus "github.com/asaskevich/EventBus"
 	bauth "github.com/banzaicloud/bank-vaults/pkg/sdk/auth"
 	"github.com/banzaicloud/bank-vaults/pkg/sdk/vault"
 	"github.com/mitchellh/mapstructure"
@@ -39,8 +40,10 @@ import (
 
 	cloudinfoapi "github.com/banzaicloud/pipeline/.gen/cloudinfo"
 	anchore2 "github.com/banzaicloud/pipeline/internal/anchore"
+	"github.com/banzaicloud/pipeline/internal/app/frontend/notification/notificationadapter"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/process"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/process/processadapter"
+	arkClusterManager "github.com/banzaicloud/pipeline/internal/ark/clustermanager"
 	cluster2 "github.com/banzaicloud/pipeline/internal/cluster"
 	intClusterAuth "github.com/banzaicloud/pipeline/internal/cluster/auth"
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter"
@@ -48,12 +51,17 @@ import (
 	"github.com/banzaicloud/pipeline/internal/cluster/clustersecret/clustersecretadapter"
 	"github.com/banzaicloud/pipeline/internal/cluster/clustersetup"
 	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/aks/aksadapter"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksadapter"
 	eksClusterAdapter "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/adapter"
 	eksClusterDriver "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/driver"
 	eksworkflow "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/workflow"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke/gkeadapter"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws/pkeawsadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeazure/pkeazureadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulatedadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulateddriver"
 	intClusterDNS "github.com/banzaicloud/pipeline/internal/cluster/dns"
 	"github.com/banzaicloud/pipeline/internal/cluster/endpoints"
 	intClusterK8s "github.com/banzaicloud/pipeline/internal/cluster/kubernetes"
@@ -69,7 +77,9 @@ import (
 	"github.com/banzaicloud/pipeline/internal/helm/helmadapter"
 	"github.com/banzaicloud/pipeline/internal/integratedservices"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter"
+	clusterfeatureworkflow "github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter/workflow"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/certmanager"
 	integratedServiceDNS "github.com/banzaicloud/pipeline/internal/integratedservices/services/dns"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/dns/dnsadapter"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/expiry"
@@ -94,14 +104,24 @@ import (
 	"github.com/banzaicloud/pipeline/internal/platform/log"
 	azurePKEAdapter "github.com/banzaicloud/pipeline/internal/providers/azure/pke/adapter"
 	azurepkedriver "github.com/banzaicloud/pipeline/internal/providers/azure/pke/driver"
+	kubernetesprovideradapter "github.com/banzaicloud/pipeline/internal/providers/kubernetes/kubernetesadapter"
+	kubernetesworkflow "github.com/banzaicloud/pipeline/internal/providers/kubernetes/workflow"
 	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow"
 	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow/pkeworkflowadapter"
 	vsphereadapter "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/adapter"
 	vspheredriver "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/driver"
+	"github.com/banzaicloud/pipeline/internal/secret/certexpiry"
+	"github.com/banzaicloud/pipeline/internal/secret/certexpiry/certexpiryadapter"
+	certexpiryworkflow "github.com/banzaicloud/pipeline/internal/secret/certexpiry/workflow"
 	"github.com/banzaicloud/pipeline/internal/secret/kubesecret"
 	"github.com/banzaicloud/pipeline/internal/secret/pkesecret"
 	"github.com/banzaicloud/pipeline/internal/secret/restricted"
-	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding/secretbindingadapter"
+	secretbindingworkflow "github.com/banzaicloud/pipeline/internal/secret/secretbinding/workflow"
+	"github.com/banzaicloud/pipeline/internal/secret/secretrotation"
+	"github.com/banzaicloud/pipeline/internal/secret/secretrotation/secretrotationadapter"
+	secretrotationworkflow "github.com/banzaicloud/pipeline/internal/secret/secretrotation/workflow"
 	"github.com/banzaicloud/pipeline/internal/secret/types"
 	anchore "github.com/banzaicloud/pipeline/internal/security"
 	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
@@ -210,7 +230,15 @@ func main() {
 	emperror.Panic(err)
 	global.SetVault(vaultClient)
 
-	secretStore := secretadapter.NewVaultStore(vaultClient, "secret")
+	db, err := database.Connect(config.Database.Config)
+	if err != nil {
+		emperror.Panic(err)
+	}
+	global.SetDB(db)
+
+	secretStore, err := cmd.CreateSecretStore(config.Secret.Backend, config.Secret.Database, db, vaultClient)
+	emperror.Panic(errors.WithMessage(err, "failed to create secret store"))
+
 	pkeSecreter := pkesecret.NewPkeSecreter(vaultClient, commonLogger)
 	secretTypes := types.NewDefaultTypeList(types.DefaultTypeListConfig{
 		AmazonRegion:       config.Cloud.Amazon.DefaultRegion,
@@ -228,15 +256,14 @@ func main() {
 		worker, err := cadence.NewWorker(config.Cadence, taskList, zaplog.New(logur.WithFields(logger, map[string]interface{}{"component": "cadence-worker"})))
 		emperror.Panic(err)
 
-		db, err := database.Connect(config.Database.Config)
+		workflowClient, err := cadence.NewClient(config.Cadence, zaplog.New(logur.WithFields(logger, map[string]interface{}{"component": "cadence-client"})))
 		if err != nil {
-			emperror.Panic(err)
+			errorHandler.Handle(errors.WrapIf(err, "Failed to configure Cadence client"))
 		}
-		global.SetDB(db)
 
-		workflowClient, err := cadence.NewClient(config.Cadence, zaplog.New(logur.WithFields(logger, map[string]interface{}{"component": "cadence-client"})))
+		workflowServiceClient, err := cadence.NewServiceClient(config.Cadence, zaplog.New(logur.WithFields(logger, map[string]interface{}{"component": "cadence-service-client"})))
 		if err != nil {
-			errorHandler.Handle(errors.WrapIf(err, "Failed to configure Cadence client"))
+			errorHandler.Handle(errors.WrapIf(err, "Failed to configure Cadence service client"))
 		}
 
 		commonSecretStore := commonadapter.NewSecretStore(secret.Store, commonadapter.OrgIDContextExtractorFunc(auth.GetCurrentOrganizationID))
@@ -293,7 +320,11 @@ func main() {
 
 		configFactory := kubernetes.NewConfigFactory(commonSecretStore)
 
-		processService := process.NewService(processadapter.NewGormStore(db), workflowClient)
+		processService := process.NewService(
+			processadapter.NewGormStore(db),
+			workflowClient,
+			processadapter.NewCadenceWorkflowResetter(workflowServiceClient, config.Cadence.Domain),
+		)
 		processActivity := process.NewProcessActivity(processService)
 
 		activity.RegisterWithOptions(processActivity.ExecuteProcess, activity.RegisterOptions{Name: process.ProcessActivityName})
@@ -389,15 +420,121 @@ func main() {
 		// Register azure specific workflows
 		registerAzureWorkflows(secretStore, tokenGenerator, azurePKEClusterStore)
 
+		// Register AKS and PKE on Azure node pool workflows
+		registerAKSNodePoolWorkflows(secretStore, aksadapter.NewNodePoolStore(db))
+		registerPKEAzureNodePoolWorkflows(secretStore, pkeazureadapter.NewNodePoolStore(azurePKEClusterStore))
+
+		// Register simulated distribution workflows
+		if config.Distribution.Simulated.Enabled {
+			registerSimulatedWorkflows(
+				simulatedadapter.NewClusterStore(db),
+				simulatedadapter.NewNodePoolStore(db),
+				kubernetes.NewClientFactory(configFactory),
+				config.Distribution.Simulated.ProvisioningDelay,
+			)
+		}
+
 		// Register EKS specific workflows
 		err = registerEKSWorkflows(config, secret.Store, eksClusters)
 		if err != nil {
 			emperror.Panic(errors.WrapIf(err, "failed to register EKS workflows"))
 		}
 
+		// Register PKE on AWS node pool workflows
+		err = registerPKEAWSNodePoolWorkflows(secret.Store, pkeawsadapter.NewNodePoolStore(db))
+		if err != nil {
+			emperror.Panic(errors.WrapIf(err, "failed to register PKE on AWS node pool workflows"))
+		}
+
+		// Register GKE node pool workflows
+		registerGKENodePoolWorkflows(secret.Store, gkeadapter.NewNodePoolStore(db))
+
+		registerARKWorkflows(arkClusterManager.New(clusterManager), db, unifiedHelmReleaser, logrusLogger.WithField("subsystem", "ark"))
+
 		clusterStore := clusteradapter.NewStore(db, clusteradapter.NewClusters(db))
 		vsphereClusterStore := vsphereadapter.NewClusterStore(db)
 
+		// Secret changes made by the worker (eg. rotations) are delivered to the subscribers running in the worker,
+		// subscribers reacting to rotations start Cadence workflows to reach every Pipeline process
+		secretEventBus := evbus.New()
+		secret.Store.SetEvents(secret.NewSecretEvents(secretEventBus))
+
+		// Register secret binding workflows
+		{
+			secretBindingStore := secretbindingadapter.NewGormStore(db, commonLogger)
+			secretBindingService := secretbinding.NewService(
+				secretBindingStore,
+				secretbindingadapter.NewSecretStore(secret.Store),
+				clusterStore,
+				secretbindingadapter.NewKubeSecretManager(clusterSvc),
+				commonLogger,
+			)
+
+			secretbindingadapter.SubscribeEvents(secretEventBus, secretBindingService, commonLogger)
+
+			registerSecretBindingWorkflows(secretBindingStore, secretBindingService)
+
+			if c := config.Secret.Bindings.Reconcile; c.Enabled {
+				err := secretbindingadapter.ScheduleCadenceReconcile(context.Background(), workflowClient, c.Schedule, commonLogger)
+				emperror.Panic(errors.WrapIf(err, "failed to schedule secret binding reconciliation"))
+			} else {
+				err := cadence.UnscheduleCronWorkflow(context.Background(), workflowClient, secretbindingworkflow.ReconcileWorkflowID)
+				emperror.Panic(errors.WrapIf(err, "failed to unschedule secret binding reconciliation"))
+			}
+		}
+
+		// Register secret rotation workflows
+		{
+			secretRotationStore := secretrotationadapter.NewGormStore(db, commonLogger)
+			secretRotationService := secretrotation.NewService(
+				secretRotationStore,
+				secretrotationadapter.NewSecretStore(secret.Store),
+				commonLogger,
+			)
+
+			registerSecretRotationWorkflows(secretRotationStore, secretRotationService)
+
+			if c := config.Secret.Rotation; c.Enabled {
+				err := secretrotationadapter.ScheduleCadenceRotation(context.Background(), workflowClient, c.Schedule, commonLogger)
+				emperror.Panic(errors.WrapIf(err, "failed to schedule secret rotation"))
+			} else {
+				err := cadence.UnscheduleCronWorkflow(context.Background(), workflowClient, secretrotationworkflow.RotationWorkflowID)
+				emperror.Panic(errors.WrapIf(err, "failed to unschedule secret rotation"))
+			}
+		}
+
+		// Register secret certificate expiry workflows
+		{
+			certificateExpiryService := certexpiry.NewService(
+				config.Secret.CertificateExpiry.WarningPeriod,
+				certexpiryadapter.NewGormStore(db, commonLogger),
+				certexpiryadapter.NewSecretStore(secret.Store),
+				certexpiryadapter.NewNotificationPublisher(notificationadapter.NewGormStore(db)),
+				commonLogger,
+			)
+
+			registerSecretCertificateExpiryWorkflows(certexpiryadapter.NewGormOrganizationLister(db), certificateExpiryService)
+
+			if c := config.Secret.CertificateExpiry; c.Enabled {
+				err := certexpiryadapter.ScheduleCadenceCheck(context.Background(), workflowClient, c.Schedule, commonLogger)
+				emperror.Panic(errors.WrapIf(err, "failed to schedule secret certificate expiry check"))
+			} else {
+				err := cadence.UnscheduleCronWorkflow(context.Background(), workflowClient, certexpiryworkflow.CheckWorkflowID)
+				emperror.Panic(errors.WrapIf(err, "failed to unschedule secret certificate expiry check"))
+			}
+		}
+
+		// Register imported cluster workflows
+		registerKubernetesWorkflows(db, clusterStore, kubernetes.NewClientFactory(configFactory), config.Cluster.Imported.HealthCheck.Timeout, commonLogger)
+
+		if c := config.Cluster.Imported.HealthCheck; c.Enabled {
+			err := kubernetesprovideradapter.ScheduleCadenceHealthCheck(context.Background(), workflowClient, c.Schedule, commonLogger)
+			emperror.Panic(errors.WrapIf(err, "failed to schedule imported cluster health check"))
+		} else {
+			err := cadence.UnscheduleCronWorkflow(context.Background(), workflowClient, kubernetesworkflow.HealthCheckWorkflowID)
+			emperror.Panic(errors.WrapIf(err, "failed to unschedule imported cluster health check"))
+		}
+
 		cgroupAdapter := cgroupAdapter.NewClusterGetter(clusterManager)
 		clusterGroupManager := clustergroup.NewManager(cgroupAdapter, clustergroup.NewClusterGroupRepository(db, logrusLogger), logrusLogger, errorHandler)
 		{
@@ -480,6 +617,16 @@ func main() {
 							workflowClient,
 						),
 					},
+					clusteradapter.ClusterDeleterEntry{
+						Key: clusteradapter.MakeClusterDeleterKey(pkgCluster.Simulated, pkgCluster.Simulated),
+						Deleter: simulateddriver.MakeClusterDeleter(
+							nil,
+							clusterManager.GetKubeProxyCache(),
+							commonLogger,
+							simulatedadapter.NewClusterStore(db),
+							workflowClient,
+						),
+					},
 				),
 			)
 			activity.RegisterWithOptions(deleteClusterActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.DeleteClusterActivityName})
@@ -582,7 +729,7 @@ func main() {
 			orgGetter := authdriver.NewOrganizationGetter(db)
 
 			logger := commonadapter.NewLogger(logger) // TODO: make this a context aware logger
-			featureRepository := integratedserviceadapter.NewGormIntegratedServiceRepository(db, logger)
+			featureRepository := integratedserviceadapter.NewGormIntegratedServiceRepository(db, auth.UserExtractor{}, logger)
 			kubernetesService := kubernetes.NewService(
 				kubernetesadapter.NewConfigSecretGetter(clusteradapter.NewClusters(db)),
 				kubernetes.NewConfigFactory(commonSecretStore),
@@ -687,9 +834,33 @@ func main() {
 					unifiedHelmReleaser,
 					intsvcingressadapter.NewOrgDomainService(config.Cluster.DNS.BaseDomain, orgGetter),
 				),
+				certmanager.NewOperator(
+					clusterGetter,
+					clusterService,
+					unifiedHelmReleaser,
+					kubernetesService,
+					commonSecretStore,
+					config.Cluster.CertManager.Config,
+					logger,
+				),
 			})
 
-			registerClusterFeatureWorkflows(featureOperatorRegistry, featureRepository)
+			registerClusterFeatureWorkflows(
+				featureOperatorRegistry,
+				featureRepository,
+				services.NewHelmReleaseDriftDetector(featureOperatorRegistry, unifiedHelmReleaser, kubernetesService),
+				integratedserviceadapter.MakeCadenceIntegratedServiceOperationDispatcher(workflowClient, logger),
+			)
+
+			integratedserviceadapter.SubscribeSecretEvents(secretEventBus, workflowClient, logger)
+
+			if c := config.Cluster.IntegratedServices.DriftDetection; c.Enabled {
+				err := integratedserviceadapter.ScheduleCadenceDriftDetection(context.Background(), workflowClient, c.Schedule, c.AutoReapply, logger)
+				emperror.Panic(errors.WrapIf(err, "failed to schedule integrated service drift detection"))
+			} else {
+				err := cadence.UnscheduleCronWorkflow(context.Background(), workflowClient, clusterfeatureworkflow.IntegratedServiceDriftDetectionWorkflowID)
+				emperror.Panic(errors.WrapIf(err, "failed to unschedule integrated service drift detection"))
+			}
 		}
 
 		group.Add(appkitrun.CadenceWorkerRun(worker))
@@ -0,0 +1,67 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/workflow"
+
+	certexpiryworkflow "github.com/banzaicloud/pipeline/internal/secret/certexpiry/workflow"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding"
+	secretbindingworkflow "github.com/banzaicloud/pipeline/internal/secret/secretbinding/workflow"
+	secretrotationworkflow "github.com/banzaicloud/pipeline/internal/secret/secretrotation/workflow"
+)
+
+func registerSecretBindingWorkflows(bindings secretbindingworkflow.BindingLister, service secretbinding.Service) {
+	workflow.RegisterWithOptions(secretbindingworkflow.ReconcileWorkflow, workflow.RegisterOptions{Name: secretbindingworkflow.ReconcileWorkflowName})
+
+	{
+		a := secretbindingworkflow.MakeListBindingsActivity(bindings)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: secretbindingworkflow.ListBindingsActivityName})
+	}
+
+	{
+		a := secretbindingworkflow.MakeSyncBindingActivity(service)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: secretbindingworkflow.SyncBindingActivityName})
+	}
+}
+
+func registerSecretRotationWorkflows(policies secretrotationworkflow.PolicyLister, secrets secretrotationworkflow.SecretRotator) {
+	workflow.RegisterWithOptions(secretrotationworkflow.RotationWorkflow, workflow.RegisterOptions{Name: secretrotationworkflow.RotationWorkflowName})
+
+	{
+		a := secretrotationworkflow.MakeListDueSecretsActivity(policies)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: secretrotationworkflow.ListDueSecretsActivityName})
+	}
+
+	{
+		a := secretrotationworkflow.MakeRotateSecretActivity(secrets)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: secretrotationworkflow.RotateSecretActivityName})
+	}
+}
+
+func registerSecretCertificateExpiryWorkflows(organizations certexpiryworkflow.OrganizationLister, checker certexpiryworkflow.OrganizationChecker) {
+	workflow.RegisterWithOptions(certexpiryworkflow.CheckWorkflow, workflow.RegisterOptions{Name: certexpiryworkflow.CheckWorkflowName})
+
+	{
+		a := certexpiryworkflow.MakeListOrganizationsActivity(organizations)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: certexpiryworkflow.ListOrganizationsActivityName})
+	}
+
+	{
+		a := certexpiryworkflow.MakeCheckOrganizationActivity(checker)
+		activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: certexpiryworkflow.CheckOrganizationActivityName})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulatedworkflow"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+func registerSimulatedWorkflows(
+	clusters simulated.ClusterStore,
+	nodePools simulated.NodePoolStore,
+	clientFactory simulatedworkflow.KubeClientFactory,
+	provisioningDelay time.Duration,
+) {
+	simulatedworkflow.NewCreateClusterWorkflow(provisioningDelay).Register()
+	simulatedworkflow.NewDeleteClusterWorkflow().Register()
+	simulatedworkflow.NewCreateNodePoolWorkflow(processlog.New()).Register()
+	simulatedworkflow.NewUpdateNodePoolWorkflow(processlog.New()).Register()
+	simulatedworkflow.NewDeleteNodePoolWorkflow(processlog.New()).Register()
+	simulatedworkflow.NewCreateNodesActivity(clientFactory).Register()
+	simulatedworkflow.NewDeleteNodesActivity(clientFactory).Register()
+	simulatedworkflow.NewSaveNodePoolActivity(nodePools).Register()
+	simulatedworkflow.NewDeleteStoredNodePoolActivity(nodePools).Register()
+	simulatedworkflow.NewDeleteClusterFromStoreActivity(clusters).Register()
+}
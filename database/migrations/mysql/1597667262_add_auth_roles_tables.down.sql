@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `auth_role_bindings`;
+DROP TABLE IF EXISTS `auth_roles`;
@@ -0,0 +1,21 @@
+CREATE TABLE `auth_roles` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `organization_id` int(10) unsigned NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `description` varchar(255) DEFAULT NULL,
+  `permissions` text,
+  `created_at` timestamp NULL DEFAULT NULL,
+  `updated_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_auth_roles_org_id_name` (`organization_id`,`name`)
+);
+
+CREATE TABLE `auth_role_bindings` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `role_id` int(10) unsigned NOT NULL,
+  `user_id` int(10) unsigned NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_auth_role_bindings_role_id_user_id` (`role_id`,`user_id`),
+  KEY `idx_auth_role_bindings_user_id` (`user_id`),
+  CONSTRAINT `auth_role_bindings_role_id_auth_roles_id_foreign` FOREIGN KEY (`role_id`) REFERENCES `auth_roles` (`id`)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE `auth_token_scopes` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` varchar(255) NOT NULL,
+  `token_id` varchar(255) NOT NULL,
+  `organization_id` int(10) unsigned DEFAULT NULL,
+  `clusters` text,
+  `access` varchar(255) DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_auth_token_scopes_user_id_token_id` (`user_id`,`token_id`)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE `cluster_feature_revisions` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `created_at` timestamp NULL DEFAULT NULL,
+  `updated_at` timestamp NULL DEFAULT NULL,
+  `cluster_id` int(10) unsigned NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `revision` int(10) unsigned NOT NULL,
+  `spec` text,
+  `status` varchar(255) DEFAULT NULL,
+  `created_by` int(10) unsigned DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_cluster_feature_revision_cluster_id_name_revision` (`cluster_id`,`name`,`revision`)
+);
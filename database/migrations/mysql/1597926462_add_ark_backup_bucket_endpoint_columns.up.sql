@@ -0,0 +1,2 @@
+ALTER TABLE `ark_backup_buckets` ADD COLUMN `endpoint` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL;
+ALTER TABLE `ark_backup_buckets` ADD COLUMN `ca_cert` text COLLATE utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `simulated_node_pools`;
+DROP TABLE IF EXISTS `simulated_clusters`;
@@ -0,0 +1,3 @@
+DROP INDEX `idx_notifications_organization_source` ON `notifications`;
+ALTER TABLE `notifications` DROP COLUMN `source`;
+ALTER TABLE `notifications` DROP COLUMN `organization_id`;
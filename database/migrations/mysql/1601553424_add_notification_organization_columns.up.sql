@@ -0,0 +1,3 @@
+ALTER TABLE `notifications` ADD COLUMN `organization_id` int(10) unsigned NOT NULL DEFAULT 0;
+ALTER TABLE `notifications` ADD COLUMN `source` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '';
+CREATE INDEX `idx_notifications_organization_source` ON `notifications` (`organization_id`, `source`);
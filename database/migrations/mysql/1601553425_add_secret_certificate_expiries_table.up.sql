@@ -0,0 +1,11 @@
+CREATE TABLE `secret_certificate_expiries` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `organization_id` int(10) unsigned DEFAULT NULL,
+  `secret_id` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `secret_name` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `secret_type` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `not_after` timestamp NULL DEFAULT NULL,
+  `checked_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_secret_certificate_expiries_org_secret` (`organization_id`,`secret_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,11 @@
+CREATE TABLE `encrypted_secrets` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `created_at` timestamp NULL DEFAULT NULL,
+  `organization_id` int(10) unsigned NOT NULL,
+  `secret_id` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `version` int(11) NOT NULL,
+  `encrypted_data_key` longblob NOT NULL,
+  `ciphertext` longblob NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_encrypted_secrets_org_secret_version` (`organization_id`,`secret_id`,`version`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1 @@
+ALTER TABLE `clustergroup_deployments` ADD COLUMN `verify` text COLLATE utf8mb4_unicode_ci;
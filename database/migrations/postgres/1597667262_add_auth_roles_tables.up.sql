@@ -0,0 +1,24 @@
+CREATE TABLE "public"."auth_roles" (
+    "id" serial,
+    "organization_id" int4 NOT NULL,
+    "name" text NOT NULL,
+    "description" text,
+    "permissions" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_auth_roles_org_id_name ON auth_roles USING btree (organization_id, name);
+
+CREATE TABLE "public"."auth_role_bindings" (
+    "id" serial,
+    "role_id" int4 NOT NULL,
+    "user_id" int4 NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "auth_role_bindings_role_id_auth_roles_id_foreign" FOREIGN KEY ("role_id") REFERENCES "public"."auth_roles"("id") ON DELETE RESTRICT ON UPDATE RESTRICT
+);
+
+CREATE UNIQUE INDEX idx_auth_role_bindings_role_id_user_id ON auth_role_bindings USING btree (role_id, user_id);
+
+CREATE INDEX idx_auth_role_bindings_user_id ON auth_role_bindings USING btree (user_id);
@@ -0,0 +1,12 @@
+CREATE TABLE "public"."auth_token_scopes" (
+    "id" serial,
+    "user_id" text NOT NULL,
+    "token_id" text NOT NULL,
+    "organization_id" int4,
+    "clusters" text,
+    "access" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_auth_token_scopes_user_id_token_id ON auth_token_scopes USING btree (user_id, token_id);
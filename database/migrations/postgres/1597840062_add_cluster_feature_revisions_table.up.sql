@@ -0,0 +1,14 @@
+CREATE TABLE "public"."cluster_feature_revisions" (
+    "id" serial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "cluster_id" int4 NOT NULL,
+    "name" text NOT NULL,
+    "revision" int4 NOT NULL,
+    "spec" text,
+    "status" text,
+    "created_by" int4,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_cluster_feature_revision_cluster_id_name_revision ON cluster_feature_revisions USING btree (cluster_id, name, revision);
@@ -0,0 +1,2 @@
+ALTER TABLE "ark_backup_buckets" DROP COLUMN "ca_cert";
+ALTER TABLE "ark_backup_buckets" DROP COLUMN "endpoint";
@@ -0,0 +1,2 @@
+ALTER TABLE "ark_backup_buckets" ADD COLUMN "endpoint" TEXT;
+ALTER TABLE "ark_backup_buckets" ADD COLUMN "ca_cert" TEXT;
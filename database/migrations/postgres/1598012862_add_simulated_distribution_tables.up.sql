@@ -0,0 +1,21 @@
+CREATE TABLE "public"."simulated_clusters" (
+    "id" serial,
+    "cluster_id" int4,
+    "kubernetes_version" text,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_simulated_clusters_cluster_id ON simulated_clusters USING btree (cluster_id);
+
+CREATE TABLE "public"."simulated_node_pools" (
+    "id" serial,
+    "created_at" timestamptz,
+    "created_by" int4,
+    "cluster_id" int4,
+    "name" text,
+    "size" int4,
+    "instance_type" text,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_simulated_node_pools_cluster_id_name ON simulated_node_pools USING btree (cluster_id, name);
@@ -0,0 +1,11 @@
+CREATE TABLE "helm_chart_policies"
+(
+    "id"              serial,
+    "created_at"      timestamp with time zone,
+    "updated_at"      timestamp with time zone,
+    "organization_id" integer,
+    "policy"          text,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_helm_chart_policies_org ON "helm_chart_policies" (organization_id);
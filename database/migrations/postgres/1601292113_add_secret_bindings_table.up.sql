@@ -0,0 +1,12 @@
+CREATE TABLE "secret_bindings"
+(
+    "id"              serial,
+    "created_at"      timestamp with time zone,
+    "updated_at"      timestamp with time zone,
+    "organization_id" integer,
+    "spec"            text,
+    "status"          text,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_secret_bindings_org ON "secret_bindings" (organization_id);
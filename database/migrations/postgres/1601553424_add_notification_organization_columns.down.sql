@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notifications_organization_source;
+ALTER TABLE "notifications" DROP COLUMN "source";
+ALTER TABLE "notifications" DROP COLUMN "organization_id";
@@ -0,0 +1,3 @@
+ALTER TABLE "notifications" ADD COLUMN "organization_id" integer NOT NULL DEFAULT 0;
+ALTER TABLE "notifications" ADD COLUMN "source" varchar(255) NOT NULL DEFAULT '';
+CREATE INDEX idx_notifications_organization_source ON "notifications" (organization_id, source);
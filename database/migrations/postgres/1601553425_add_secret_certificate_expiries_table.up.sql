@@ -0,0 +1,13 @@
+CREATE TABLE "secret_certificate_expiries"
+(
+    "id"              serial,
+    "organization_id" integer,
+    "secret_id"       varchar(255),
+    "secret_name"     varchar(255),
+    "secret_type"     varchar(255),
+    "not_after"       timestamp with time zone,
+    "checked_at"      timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_secret_certificate_expiries_org_secret ON "secret_certificate_expiries" (organization_id, secret_id);
@@ -0,0 +1,13 @@
+CREATE TABLE "encrypted_secrets"
+(
+    "id"                 serial,
+    "created_at"         timestamp with time zone,
+    "organization_id"    integer      NOT NULL,
+    "secret_id"          varchar(255) NOT NULL,
+    "version"            integer      NOT NULL,
+    "encrypted_data_key" bytea        NOT NULL,
+    "ciphertext"         bytea        NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_encrypted_secrets_org_secret_version ON "encrypted_secrets" (organization_id, secret_id, version);
@@ -0,0 +1 @@
+ALTER TABLE "clustergroup_deployments" DROP COLUMN "verify";
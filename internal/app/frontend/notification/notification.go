@@ -37,6 +37,9 @@ type Notification struct {
 type Service interface {
 	// GetNotifications returns the list of notifications.
 	GetNotifications(ctx context.Context) (notifications Notifications, err error)
+
+	// GetOrganizationNotifications returns the list of notifications including the ones published to an organization.
+	GetOrganizationNotifications(ctx context.Context, organizationID uint) (notifications Notifications, err error)
 }
 
 type service struct {
@@ -56,6 +59,9 @@ func NewService(store Store) Service {
 type Store interface {
 	// GetActiveNotifications returns the list of active notifications.
 	GetActiveNotifications(ctx context.Context) ([]Notification, error)
+
+	// GetActiveOrganizationNotifications returns the list of active notifications published to an organization.
+	GetActiveOrganizationNotifications(ctx context.Context, organizationID uint) ([]Notification, error)
 }
 
 // GetActiveNotifications returns the list of active notifications.
@@ -72,3 +78,23 @@ func (s *service) GetNotifications(ctx context.Context) (Notifications, error) {
 
 	return Notifications{Messages: notifications}, nil
 }
+
+// GetOrganizationNotifications returns the list of notifications including the ones published to an organization.
+func (s *service) GetOrganizationNotifications(ctx context.Context, organizationID uint) (Notifications, error) {
+	notifications, err := s.store.GetActiveNotifications(ctx)
+	if err != nil {
+		return Notifications{}, err
+	}
+
+	organizationNotifications, err := s.store.GetActiveOrganizationNotifications(ctx, organizationID)
+	if err != nil {
+		return Notifications{}, err
+	}
+
+	// The response is not nillable
+	messages := make([]Notification, 0, len(notifications)+len(organizationNotifications))
+	messages = append(messages, notifications...)
+	messages = append(messages, organizationNotifications...)
+
+	return Notifications{Messages: messages}, nil
+}
@@ -52,3 +52,43 @@ func TestService_GetNotifications(t *testing.T) {
 
 	store.AssertExpectations(t)
 }
+
+func TestService_GetOrganizationNotifications(t *testing.T) {
+	store := &MockStore{}
+
+	ctx := context.Background()
+
+	notifications := []Notification{
+		{
+			ID:       1,
+			Message:  "message",
+			Priority: 100,
+		},
+	}
+
+	organizationNotifications := []Notification{
+		{
+			ID:       2,
+			Message:  "organization message",
+			Priority: 50,
+		},
+	}
+
+	store.On("GetActiveNotifications", ctx).Return(notifications, nil)
+	store.On("GetActiveOrganizationNotifications", ctx, uint(1)).Return(organizationNotifications, nil)
+
+	service := NewService(store)
+
+	activeNotifications, err := service.GetOrganizationNotifications(ctx, 1)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		Notifications{
+			Messages: append(notifications, organizationNotifications...),
+		},
+		activeNotifications,
+	)
+
+	store.AssertExpectations(t)
+}
@@ -30,11 +30,13 @@ const (
 )
 
 type notificationModel struct {
-	ID          uint      `gorm:"primary_key"`
-	Message     string    `gorm:"not null" sql:"type:text;"`
-	InitialTime time.Time `gorm:"index:idx_initial_time_end_time;default:current_timestamp;not null"`
-	EndTime     time.Time `gorm:"index:idx_initial_time_end_time;default:'1970-01-01 00:00:01';not null"`
-	Priority    int8      `gorm:"not null"`
+	ID             uint      `gorm:"primary_key"`
+	OrganizationID uint      `gorm:"index:idx_notifications_organization_source;default:0;not null"`
+	Source         string    `gorm:"index:idx_notifications_organization_source;default:'';not null"`
+	Message        string    `gorm:"not null" sql:"type:text;"`
+	InitialTime    time.Time `gorm:"index:idx_initial_time_end_time;default:current_timestamp;not null"`
+	EndTime        time.Time `gorm:"index:idx_initial_time_end_time;default:'1970-01-01 00:00:01';not null"`
+	Priority       int8      `gorm:"not null"`
 }
 
 // TableName changes the default table name.
@@ -54,11 +56,86 @@ func NewGormStore(db *gorm.DB) *GormStore {
 	}
 }
 
-// GetActiveNotifications returns the list of active notifications.
+// GetActiveNotifications returns the list of active global notifications.
 func (s *GormStore) GetActiveNotifications(ctx context.Context) ([]notification.Notification, error) {
+	return s.getActiveNotifications(0)
+}
+
+// GetActiveOrganizationNotifications returns the list of active notifications of an organization.
+func (s *GormStore) GetActiveOrganizationNotifications(ctx context.Context, organizationID uint) ([]notification.Notification, error) {
+	return s.getActiveNotifications(organizationID)
+}
+
+// ReplaceOrganizationNotifications replaces the notifications published by a source for an organization.
+func (s *GormStore) ReplaceOrganizationNotifications(
+	ctx context.Context,
+	organizationID uint,
+	source string,
+	notifications []notification.Notification,
+	endTime time.Time,
+) error {
+	return s.transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Where("organization_id = ? AND source = ?", organizationID, source).
+			Delete(notificationModel{}).
+			Error
+		if err != nil {
+			return errors.WrapIfWithDetails(
+				err, "failed to delete notifications",
+				"organizationId", organizationID,
+				"source", source,
+			)
+		}
+
+		now := time.Now()
+
+		for _, n := range notifications {
+			model := notificationModel{
+				OrganizationID: organizationID,
+				Source:         source,
+				Message:        n.Message,
+				InitialTime:    now,
+				EndTime:        endTime,
+				Priority:       n.Priority,
+			}
+
+			err := tx.Create(&model).Error
+			if err != nil {
+				return errors.WrapIfWithDetails(
+					err, "failed to create notification",
+					"organizationId", organizationID,
+					"source", source,
+				)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *GormStore) transaction(fn func(tx *gorm.DB) error) error {
+	tx := s.db.Begin()
+	if err := tx.Error; err != nil {
+		return errors.WrapIf(err, "failed to begin transaction")
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	return errors.WrapIf(tx.Commit().Error, "failed to commit transaction")
+}
+
+func (s *GormStore) getActiveNotifications(organizationID uint) ([]notification.Notification, error) {
 	var notifications []notificationModel
 
-	err := s.db.Where("? BETWEEN initial_time AND end_time", time.Now()).Find(&notifications).Error
+	err := s.db.
+		Where("organization_id = ?", organizationID).
+		Where("? BETWEEN initial_time AND end_time", time.Now()).
+		Find(&notifications).
+		Error
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to find notifications")
 	}
@@ -76,3 +76,58 @@ func testGormStoreGetActiveNotifications(t *testing.T) {
 		notifications,
 	)
 }
+
+func testGormStoreOrganizationNotifications(t *testing.T) {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, notification.NoopLogger{})
+	require.NoError(t, err)
+
+	global := &notificationModel{
+		Message:     "global",
+		InitialTime: time.Now().Add(-time.Hour),
+		EndTime:     time.Now().Add(time.Hour),
+		Priority:    1,
+	}
+
+	err = db.Save(global).Error
+	require.NoError(t, err)
+
+	store := NewGormStore(db)
+
+	err = store.ReplaceOrganizationNotifications(
+		context.Background(),
+		1,
+		"source",
+		[]notification.Notification{{Message: "old", Priority: 50}},
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+
+	err = store.ReplaceOrganizationNotifications(
+		context.Background(),
+		1,
+		"source",
+		[]notification.Notification{{Message: "new", Priority: 100}},
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+
+	notifications, err := store.GetActiveOrganizationNotifications(context.Background(), 1)
+	require.NoError(t, err)
+
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "new", notifications[0].Message)
+	assert.Equal(t, int8(100), notifications[0].Priority)
+
+	notifications, err = store.GetActiveOrganizationNotifications(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+
+	notifications, err = store.GetActiveNotifications(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "global", notifications[0].Message)
+}
@@ -28,4 +28,5 @@ func TestIntegration(t *testing.T) {
 	t.Parallel()
 
 	t.Run("GormStore_GetActiveNotifications", testGormStoreGetActiveNotifications)
+	t.Run("GormStore_OrganizationNotifications", testGormStoreOrganizationNotifications)
 }
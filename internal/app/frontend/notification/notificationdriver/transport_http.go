@@ -17,7 +17,9 @@ package notificationdriver
 import (
 	"context"
 	"net/http"
+	"strconv"
 
+	"emperror.dev/errors"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
 	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
@@ -37,8 +39,42 @@ func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...ki
 	))
 }
 
+// RegisterOrganizationHTTPHandlers mounts the organization scoped service endpoints into an http.Handler.
+func RegisterOrganizationHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodGet).Path("").Handler(kithttp.NewServer(
+		endpoints.GetOrganizationNotifications,
+		decodeGetOrganizationNotificationsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetOrganizationNotificationsHTTPResponse, errorEncoder),
+		options...,
+	))
+}
+
 func encodeGetNotificationsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
 	resp := response.(GetNotificationsResponse)
 
 	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Notifications)
 }
+
+func decodeGetOrganizationNotificationsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+
+	orgID, ok := vars["orgId"]
+	if !ok || orgID == "" {
+		return nil, errors.NewWithDetails("missing parameter from the URL", "param", "orgId")
+	}
+
+	organizationID, err := strconv.ParseUint(orgID, 10, 32)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "invalid parameter in the URL", "param", "orgId")
+	}
+
+	return GetOrganizationNotificationsRequest{OrganizationID: uint(organizationID)}, nil
+}
+
+func encodeGetOrganizationNotificationsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetOrganizationNotificationsResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Notifications)
+}
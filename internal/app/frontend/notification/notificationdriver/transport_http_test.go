@@ -65,3 +65,46 @@ func TestMakeHTTPHandler_GetNotifications(t *testing.T) {
 
 	assert.Equal(t, notifications, notificationResp)
 }
+
+func TestMakeHTTPHandler_GetOrganizationNotifications(t *testing.T) {
+	notifications := notification.Notifications{
+		Messages: []notification.Notification{
+			{
+				ID:       1,
+				Message:  "message",
+				Priority: 100,
+			},
+		},
+	}
+
+	handler := mux.NewRouter()
+	RegisterOrganizationHTTPHandlers(
+		Endpoints{
+			GetOrganizationNotifications: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				req := request.(GetOrganizationNotificationsRequest)
+				if req.OrganizationID != 1 {
+					return GetOrganizationNotificationsResponse{}, nil
+				}
+
+				return GetOrganizationNotificationsResponse{Notifications: notifications}, nil
+			},
+		},
+		handler.PathPrefix("/orgs/{orgId}/notifications").Subrouter(),
+	)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(ts.URL + "/orgs/1/notifications")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var notificationResp notification.Notifications
+
+	err = json.NewDecoder(resp.Body).Decode(&notificationResp)
+	require.NoError(t, err)
+
+	assert.Equal(t, notifications, notificationResp)
+}
@@ -26,7 +26,8 @@ type serviceError interface {
 // meant to be used as a helper struct, to collect all of the endpoints into a
 // single parameter.
 type Endpoints struct {
-	GetNotifications endpoint.Endpoint
+	GetNotifications             endpoint.Endpoint
+	GetOrganizationNotifications endpoint.Endpoint
 }
 
 // MakeEndpoints returns a(n) Endpoints struct where each endpoint invokes
@@ -34,7 +35,10 @@ type Endpoints struct {
 func MakeEndpoints(service notification.Service, middleware ...endpoint.Middleware) Endpoints {
 	mw := kitxendpoint.Combine(middleware...)
 
-	return Endpoints{GetNotifications: kitxendpoint.OperationNameMiddleware("notification.GetNotifications")(mw(MakeGetNotificationsEndpoint(service)))}
+	return Endpoints{
+		GetNotifications:             kitxendpoint.OperationNameMiddleware("notification.GetNotifications")(mw(MakeGetNotificationsEndpoint(service))),
+		GetOrganizationNotifications: kitxendpoint.OperationNameMiddleware("notification.GetOrganizationNotifications")(mw(MakeGetOrganizationNotificationsEndpoint(service))),
+	}
 }
 
 // GetNotificationsRequest is a request struct for GetNotifications endpoint.
@@ -72,3 +76,43 @@ func MakeGetNotificationsEndpoint(service notification.Service) endpoint.Endpoin
 		return GetNotificationsResponse{Notifications: notifications}, nil
 	}
 }
+
+// GetOrganizationNotificationsRequest is a request struct for GetOrganizationNotifications endpoint.
+type GetOrganizationNotificationsRequest struct {
+	OrganizationID uint
+}
+
+// GetOrganizationNotificationsResponse is a response struct for GetOrganizationNotifications endpoint.
+type GetOrganizationNotificationsResponse struct {
+	Notifications notification.Notifications
+	Err           error
+}
+
+func (r GetOrganizationNotificationsResponse) Failed() error {
+	return r.Err
+}
+
+// MakeGetOrganizationNotificationsEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeGetOrganizationNotificationsEndpoint(service notification.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetOrganizationNotificationsRequest)
+
+		notifications, err := service.GetOrganizationNotifications(ctx, req.OrganizationID)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return GetOrganizationNotificationsResponse{
+					Err:           err,
+					Notifications: notifications,
+				}, nil
+			}
+
+			return GetOrganizationNotificationsResponse{
+				Err:           err,
+				Notifications: notifications,
+			}, err
+		}
+
+		return GetOrganizationNotificationsResponse{Notifications: notifications}, nil
+	}
+}
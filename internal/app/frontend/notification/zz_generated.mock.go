@@ -34,3 +34,24 @@ func (_m *MockService) GetNotifications(ctx context.Context) (notifications Noti
 
 	return r0, r1
 }
+
+// GetOrganizationNotifications provides a mock function.
+func (_m *MockService) GetOrganizationNotifications(ctx context.Context, organizationID uint) (notifications Notifications, err error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 Notifications
+	if rf, ok := ret.Get(0).(func(context.Context, uint) Notifications); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		r0 = ret.Get(0).(Notifications)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -36,3 +36,26 @@ func (_m *MockStore) GetActiveNotifications(ctx context.Context) (_result_0 []No
 
 	return r0, r1
 }
+
+// GetActiveOrganizationNotifications provides a mock function.
+func (_m *MockStore) GetActiveOrganizationNotifications(ctx context.Context, organizationID uint) (_result_0 []Notification, _result_1 error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 []Notification
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []Notification); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Notification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,260 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/banzaicloud/pipeline/pkg/brn"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// Permission grants an action on a resource type, optionally restricted to a set of clusters.
+type Permission = auth.Permission
+
+// ResourceSelector restricts a permission to a set of clusters.
+type ResourceSelector = auth.ResourceSelector
+
+// Role is a custom, organization level set of permissions bound to users.
+type Role struct {
+	ID          uint         `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Permissions []Permission `json:"permissions"`
+	UserIDs     []uint       `json:"userIds"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+}
+
+// RoleSpec contains the user supplied attributes of a role.
+type RoleSpec struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Permissions []Permission `json:"permissions"`
+	UserIDs     []uint       `json:"userIds,omitempty"`
+}
+
+// +kit:endpoint:errorStrategy=service
+// +testify:mock
+
+// Service manages custom roles in an organization.
+type Service interface {
+	// CreateRole creates a new custom role.
+	CreateRole(ctx context.Context, orgID uint, spec RoleSpec) (role Role, err error)
+
+	// ListRoles lists custom roles in an organization.
+	ListRoles(ctx context.Context, orgID uint) (roles []Role, err error)
+
+	// GetRole returns a single custom role.
+	GetRole(ctx context.Context, orgID uint, roleName string) (role Role, err error)
+
+	// UpdateRole replaces the permissions and bindings of a custom role.
+	UpdateRole(ctx context.Context, orgID uint, roleName string, spec RoleSpec) (role Role, err error)
+
+	// DeleteRole deletes a custom role.
+	DeleteRole(ctx context.Context, orgID uint, roleName string) error
+}
+
+// NewService returns a new Service.
+func NewService(store Store) Service {
+	return service{
+		store: store,
+	}
+}
+
+type service struct {
+	store Store
+}
+
+// +testify:mock:testOnly=true
+
+// Store persists custom roles.
+type Store interface {
+	// Create persists a new role.
+	Create(ctx context.Context, orgID uint, spec RoleSpec) (Role, error)
+
+	// List lists the roles of an organization.
+	List(ctx context.Context, orgID uint) ([]Role, error)
+
+	// Get returns a role by its name.
+	Get(ctx context.Context, orgID uint, name string) (Role, error)
+
+	// Update updates an existing role.
+	Update(ctx context.Context, orgID uint, name string, spec RoleSpec) (Role, error)
+
+	// Delete deletes a role and its bindings.
+	Delete(ctx context.Context, orgID uint, name string) error
+}
+
+// NotFoundError is returned if a role cannot be found.
+type NotFoundError struct {
+	Name string
+}
+
+// Error implements the error interface.
+func (NotFoundError) Error() string {
+	return "role not found"
+}
+
+// Details returns error details.
+func (e NotFoundError) Details() []interface{} {
+	return []interface{}{"roleName", e.Name}
+}
+
+// NotFound tells a client that this error is related to a resource being not found.
+// Can be used to translate the error to eg. status code.
+func (NotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (NotFoundError) ServiceError() bool {
+	return true
+}
+
+// AlreadyExistsError is returned when a role with the same name already exists in the organization.
+type AlreadyExistsError struct {
+	Name string
+}
+
+// Error implements the error interface.
+func (AlreadyExistsError) Error() string {
+	return "role already exists"
+}
+
+// Details returns error details.
+func (e AlreadyExistsError) Details() []interface{} {
+	return []interface{}{"roleName", e.Name}
+}
+
+// Conflict tells a client that this error is related to a conflicting request.
+// Can be used to translate the error to eg. status code.
+func (AlreadyExistsError) Conflict() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (AlreadyExistsError) ServiceError() bool {
+	return true
+}
+
+// ValidationError is returned when a request is semantically invalid.
+type ValidationError struct {
+	violations []string
+}
+
+// Error implements the error interface.
+func (ValidationError) Error() string {
+	return "invalid role"
+}
+
+// Violations returns details of the failed validation.
+func (e ValidationError) Violations() []string {
+	return e.violations[:]
+}
+
+// Validation tells a client that this error is related to a semantic validation of the request.
+// Can be used to translate the error to status codes for example.
+func (ValidationError) Validation() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (ValidationError) ServiceError() bool {
+	return true
+}
+
+// nolint: gochecknoglobals
+var roleNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Validate validates a role spec.
+func (s RoleSpec) Validate() error {
+	var violations []string
+
+	if !roleNameRegexp.MatchString(s.Name) {
+		violations = append(violations, "name must consist of lower case alphanumeric characters or '-'")
+	}
+
+	if _, builtin := auth.BuiltinRolePermissions(s.Name); builtin {
+		violations = append(violations, fmt.Sprintf("%q is a built-in role", s.Name))
+	}
+
+	if len(s.Permissions) == 0 {
+		violations = append(violations, "at least one permission is required")
+	}
+
+	for i, permission := range s.Permissions {
+		if !auth.IsValidResourceType(permission.Resource) {
+			violations = append(violations, fmt.Sprintf("permissions[%d]: unknown resource %q", i, permission.Resource))
+		}
+
+		if !auth.IsValidAction(permission.Action) {
+			violations = append(violations, fmt.Sprintf("permissions[%d]: unknown action %q", i, permission.Action))
+		}
+
+		if permission.Selector == nil {
+			continue
+		}
+
+		for _, rawBRN := range permission.Selector.BRNs {
+			if _, err := brn.ParseAs(rawBRN, brn.ClusterResourceType); err != nil {
+				violations = append(violations, fmt.Sprintf("permissions[%d]: invalid cluster BRN %q", i, rawBRN))
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return ValidationError{violations: violations}
+	}
+
+	return nil
+}
+
+func (s service) CreateRole(ctx context.Context, orgID uint, spec RoleSpec) (Role, error) {
+	if err := spec.Validate(); err != nil {
+		return Role{}, err
+	}
+
+	return s.store.Create(ctx, orgID, spec)
+}
+
+func (s service) ListRoles(ctx context.Context, orgID uint) ([]Role, error) {
+	return s.store.List(ctx, orgID)
+}
+
+func (s service) GetRole(ctx context.Context, orgID uint, roleName string) (Role, error) {
+	return s.store.Get(ctx, orgID, roleName)
+}
+
+func (s service) UpdateRole(ctx context.Context, orgID uint, roleName string, spec RoleSpec) (Role, error) {
+	// Roles cannot be renamed
+	spec.Name = roleName
+
+	if err := spec.Validate(); err != nil {
+		return Role{}, err
+	}
+
+	return s.store.Update(ctx, orgID, roleName, spec)
+}
+
+func (s service) DeleteRole(ctx context.Context, orgID uint, roleName string) error {
+	return s.store.Delete(ctx, orgID, roleName)
+}
@@ -0,0 +1,126 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_CreateRole(t *testing.T) {
+	ctx := context.Background()
+
+	spec := RoleSpec{
+		Name: "cluster-operator",
+		Permissions: []Permission{
+			{
+				Resource: "nodepool",
+				Action:   "*",
+				Selector: &ResourceSelector{
+					BRNs: []string{"brn:1:cluster:1"},
+				},
+			},
+		},
+		UserIDs: []uint{1},
+	}
+
+	store := new(MockStore)
+	store.On("Create", ctx, uint(1), spec).Return(Role{ID: 1, Name: spec.Name, Permissions: spec.Permissions}, nil)
+
+	service := NewService(store)
+
+	role, err := service.CreateRole(ctx, 1, spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, "cluster-operator", role.Name)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_CreateRole_Invalid(t *testing.T) {
+	tests := map[string]RoleSpec{
+		"builtin": {
+			Name:        "admin",
+			Permissions: []Permission{{Resource: "cluster", Action: "read"}},
+		},
+		"invalidName": {
+			Name:        "Cluster Operator",
+			Permissions: []Permission{{Resource: "cluster", Action: "read"}},
+		},
+		"noPermissions": {
+			Name: "empty",
+		},
+		"unknownResource": {
+			Name:        "operator",
+			Permissions: []Permission{{Resource: "spaceship", Action: "read"}},
+		},
+		"unknownAction": {
+			Name:        "operator",
+			Permissions: []Permission{{Resource: "cluster", Action: "launch"}},
+		},
+		"invalidBRN": {
+			Name: "operator",
+			Permissions: []Permission{
+				{
+					Resource: "cluster",
+					Action:   "read",
+					Selector: &ResourceSelector{BRNs: []string{"brn:1:secret:1"}},
+				},
+			},
+		},
+	}
+
+	for name, spec := range tests {
+		name, spec := name, spec
+
+		t.Run(name, func(t *testing.T) {
+			service := NewService(new(MockStore))
+
+			_, err := service.CreateRole(context.Background(), 1, spec)
+			require.Error(t, err)
+
+			var verr ValidationError
+			assert.True(t, errors.As(err, &verr))
+		})
+	}
+}
+
+func TestService_UpdateRole_KeepsName(t *testing.T) {
+	ctx := context.Background()
+
+	spec := RoleSpec{
+		Name:        "other-name",
+		Permissions: []Permission{{Resource: "deployment", Action: "create"}},
+	}
+
+	expectedSpec := spec
+	expectedSpec.Name = "helm-deployer"
+
+	store := new(MockStore)
+	store.On("Update", ctx, uint(1), "helm-deployer", expectedSpec).Return(Role{ID: 1, Name: "helm-deployer"}, nil)
+
+	service := NewService(store)
+
+	role, err := service.UpdateRole(ctx, 1, "helm-deployer", spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, "helm-deployer", role.Name)
+
+	store.AssertExpectations(t)
+}
@@ -0,0 +1,68 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roleadapter
+
+import (
+	"context"
+	"strconv"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter/clustermodel"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// GormClusterSource looks up cluster attributes for resource selectors using Gorm.
+type GormClusterSource struct {
+	db *gorm.DB
+}
+
+// NewGormClusterSource returns a new GormClusterSource.
+func NewGormClusterSource(db *gorm.DB) GormClusterSource {
+	return GormClusterSource{
+		db: db,
+	}
+}
+
+// FindCluster returns a cluster by its reference (ID or name) in a given organization.
+func (s GormClusterSource) FindCluster(ctx context.Context, orgID uint, clusterRef string) (auth.ClusterResource, bool, error) {
+	query := clustermodel.ClusterModel{OrganizationID: orgID}
+
+	if clusterID, err := strconv.ParseUint(clusterRef, 10, 64); err == nil {
+		query.ID = uint(clusterID)
+	} else {
+		query.Name = clusterRef
+	}
+
+	var model clustermodel.ClusterModel
+
+	err := s.db.Where(query).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return auth.ClusterResource{}, false, nil
+	} else if err != nil {
+		return auth.ClusterResource{}, false, errors.WrapIfWithDetails(
+			err, "failed to find cluster",
+			"organizationId", orgID,
+			"cluster", clusterRef,
+		)
+	}
+
+	return auth.ClusterResource{
+		ID:   model.ID,
+		Name: model.Name,
+		Tags: model.Tags,
+	}, true, nil
+}
@@ -0,0 +1,282 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roleadapter
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/role"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// TableName constants
+const (
+	roleTableName        = "auth_roles"
+	roleBindingTableName = "auth_role_bindings"
+)
+
+type roleModel struct {
+	ID             uint   `gorm:"primary_key"`
+	OrganizationID uint   `gorm:"unique_index:idx_auth_roles_org_id_name;not null"`
+	Name           string `gorm:"unique_index:idx_auth_roles_org_id_name;not null"`
+	Description    string
+	Permissions    string             `gorm:"type:text"`
+	Bindings       []roleBindingModel `gorm:"foreignkey:RoleID"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (roleModel) TableName() string {
+	return roleTableName
+}
+
+type roleBindingModel struct {
+	ID     uint `gorm:"primary_key"`
+	RoleID uint `gorm:"unique_index:idx_auth_role_bindings_role_id_user_id;not null"`
+	UserID uint `gorm:"unique_index:idx_auth_role_bindings_role_id_user_id;index;not null"`
+}
+
+// TableName changes the default table name.
+func (roleBindingModel) TableName() string {
+	return roleBindingTableName
+}
+
+// GormStore is a role store using Gorm for data persistence.
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new GormStore.
+func NewGormStore(db *gorm.DB) GormStore {
+	return GormStore{
+		db: db,
+	}
+}
+
+// Create persists a new role.
+func (s GormStore) Create(ctx context.Context, orgID uint, spec role.RoleSpec) (role.Role, error) {
+	var count int
+
+	err := s.db.Model(&roleModel{}).Where(roleModel{OrganizationID: orgID, Name: spec.Name}).Count(&count).Error
+	if err != nil {
+		return role.Role{}, errors.WrapIfWithDetails(err, "failed to check role existence", "organizationId", orgID, "roleName", spec.Name)
+	}
+
+	if count > 0 {
+		return role.Role{}, errors.WithStack(role.AlreadyExistsError{Name: spec.Name})
+	}
+
+	model, err := s.toModel(orgID, spec)
+	if err != nil {
+		return role.Role{}, err
+	}
+
+	err = s.db.Create(&model).Error
+	if err != nil {
+		return role.Role{}, errors.WrapIfWithDetails(err, "failed to create role", "organizationId", orgID, "roleName", spec.Name)
+	}
+
+	return s.fromModel(model)
+}
+
+// List lists the roles of an organization.
+func (s GormStore) List(ctx context.Context, orgID uint) ([]role.Role, error) {
+	var models []roleModel
+
+	err := s.db.Preload("Bindings").Where(roleModel{OrganizationID: orgID}).Order("name").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list roles", "organizationId", orgID)
+	}
+
+	roles := make([]role.Role, 0, len(models))
+	for _, model := range models {
+		r, err := s.fromModel(model)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, r)
+	}
+
+	return roles, nil
+}
+
+// Get returns a role by its name.
+func (s GormStore) Get(ctx context.Context, orgID uint, name string) (role.Role, error) {
+	model, err := s.find(orgID, name)
+	if err != nil {
+		return role.Role{}, err
+	}
+
+	return s.fromModel(model)
+}
+
+// Update updates an existing role.
+func (s GormStore) Update(ctx context.Context, orgID uint, name string, spec role.RoleSpec) (role.Role, error) {
+	model, err := s.find(orgID, name)
+	if err != nil {
+		return role.Role{}, err
+	}
+
+	updated, err := s.toModel(orgID, spec)
+	if err != nil {
+		return role.Role{}, err
+	}
+
+	updated.ID = model.ID
+	updated.CreatedAt = model.CreatedAt
+
+	err = s.transaction(func(tx *gorm.DB) error {
+		err := tx.Where(roleBindingModel{RoleID: model.ID}).Delete(roleBindingModel{}).Error
+		if err != nil {
+			return errors.WrapIf(err, "failed to delete role bindings")
+		}
+
+		return errors.WrapIf(tx.Save(&updated).Error, "failed to save role")
+	})
+	if err != nil {
+		return role.Role{}, errors.WithDetails(err, "organizationId", orgID, "roleName", name)
+	}
+
+	return s.fromModel(updated)
+}
+
+// Delete deletes a role and its bindings.
+func (s GormStore) Delete(ctx context.Context, orgID uint, name string) error {
+	model, err := s.find(orgID, name)
+	if err != nil {
+		return err
+	}
+
+	err = s.transaction(func(tx *gorm.DB) error {
+		err := tx.Where(roleBindingModel{RoleID: model.ID}).Delete(roleBindingModel{}).Error
+		if err != nil {
+			return errors.WrapIf(err, "failed to delete role bindings")
+		}
+
+		return errors.WrapIf(tx.Delete(&model).Error, "failed to delete role")
+	})
+
+	return errors.WithDetails(err, "organizationId", orgID, "roleName", name)
+}
+
+// FindUserPermissions returns the permissions granted to a user in a given organization by custom roles.
+func (s GormStore) FindUserPermissions(ctx context.Context, orgID uint, userID uint) ([]auth.Permission, error) {
+	var models []roleModel
+
+	err := s.db.
+		Joins("JOIN "+roleBindingTableName+" ON "+roleBindingTableName+".role_id = "+roleTableName+".id").
+		Where(roleTableName+".organization_id = ? AND "+roleBindingTableName+".user_id = ?", orgID, userID).
+		Find(&models).
+		Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to find user roles", "organizationId", orgID, "userId", userID)
+	}
+
+	var permissions []auth.Permission
+	for _, model := range models {
+		var p []auth.Permission
+
+		if err := json.Unmarshal([]byte(model.Permissions), &p); err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to decode role permissions", "roleId", model.ID)
+		}
+
+		permissions = append(permissions, p...)
+	}
+
+	return permissions, nil
+}
+
+func (s GormStore) transaction(fn func(tx *gorm.DB) error) error {
+	tx := s.db.Begin()
+	if err := tx.Error; err != nil {
+		return errors.WrapIf(err, "failed to begin transaction")
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	return errors.WrapIf(tx.Commit().Error, "failed to commit transaction")
+}
+
+func (s GormStore) find(orgID uint, name string) (roleModel, error) {
+	var model roleModel
+
+	err := s.db.Preload("Bindings").Where(roleModel{OrganizationID: orgID, Name: name}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return model, errors.WithStack(role.NotFoundError{Name: name})
+	} else if err != nil {
+		return model, errors.WrapIfWithDetails(err, "failed to find role", "organizationId", orgID, "roleName", name)
+	}
+
+	return model, nil
+}
+
+func (s GormStore) toModel(orgID uint, spec role.RoleSpec) (roleModel, error) {
+	permissions, err := json.Marshal(spec.Permissions)
+	if err != nil {
+		return roleModel{}, errors.WrapIf(err, "failed to encode role permissions")
+	}
+
+	model := roleModel{
+		OrganizationID: orgID,
+		Name:           spec.Name,
+		Description:    spec.Description,
+		Permissions:    string(permissions),
+	}
+
+	seen := make(map[uint]bool, len(spec.UserIDs))
+	for _, userID := range spec.UserIDs {
+		if seen[userID] {
+			continue
+		}
+
+		seen[userID] = true
+
+		model.Bindings = append(model.Bindings, roleBindingModel{UserID: userID})
+	}
+
+	return model, nil
+}
+
+func (s GormStore) fromModel(model roleModel) (role.Role, error) {
+	r := role.Role{
+		ID:          model.ID,
+		Name:        model.Name,
+		Description: model.Description,
+		UserIDs:     []uint{},
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+
+	if err := json.Unmarshal([]byte(model.Permissions), &r.Permissions); err != nil {
+		return role.Role{}, errors.WrapIfWithDetails(err, "failed to decode role permissions", "roleId", model.ID)
+	}
+
+	for _, binding := range model.Bindings {
+		r.UserIDs = append(r.UserIDs, binding.UserID)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,107 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roledriver
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/role"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// Middleware describes a service middleware.
+type Middleware func(role.Service) role.Service
+
+// AuthorizationMiddleware makes sure a user cannot grant permissions to a role that they do not have themselves.
+func AuthorizationMiddleware(authorizer Authorizer) Middleware {
+	return func(next role.Service) role.Service {
+		return authorizationMiddleware{
+			next: next,
+
+			authorizer: authorizer,
+		}
+	}
+}
+
+// +testify:mock:testOnly=true
+
+// Authorizer checks if a context has permission to execute an action.
+type Authorizer interface {
+	// Authorize authorizes a context to execute an action on an object.
+	Authorize(ctx context.Context, action string, object interface{}) (bool, error)
+}
+
+type authorizationMiddleware struct {
+	next role.Service
+
+	authorizer Authorizer
+}
+
+type sentinel string
+
+func (e sentinel) Error() string {
+	return string(e)
+}
+
+func (e sentinel) ServiceError() bool {
+	return true
+}
+
+// CannotGrantPermissions is returned when a user tries to grant permissions to a role that exceed their own.
+const CannotGrantPermissions = sentinel("cannot grant permissions exceeding your own")
+
+func (m authorizationMiddleware) authorizeGrant(ctx context.Context, orgID uint, spec role.RoleSpec) error {
+	ok, err := m.authorizer.Authorize(ctx, "role.grant", auth.PermissionGrant{
+		OrganizationID: orgID,
+		Permissions:    spec.Permissions,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return CannotGrantPermissions
+	}
+
+	return nil
+}
+
+func (m authorizationMiddleware) CreateRole(ctx context.Context, orgID uint, spec role.RoleSpec) (role.Role, error) {
+	if err := m.authorizeGrant(ctx, orgID, spec); err != nil {
+		return role.Role{}, err
+	}
+
+	return m.next.CreateRole(ctx, orgID, spec)
+}
+
+func (m authorizationMiddleware) ListRoles(ctx context.Context, orgID uint) ([]role.Role, error) {
+	return m.next.ListRoles(ctx, orgID)
+}
+
+func (m authorizationMiddleware) GetRole(ctx context.Context, orgID uint, roleName string) (role.Role, error) {
+	return m.next.GetRole(ctx, orgID, roleName)
+}
+
+func (m authorizationMiddleware) UpdateRole(ctx context.Context, orgID uint, roleName string, spec role.RoleSpec) (role.Role, error) {
+	if err := m.authorizeGrant(ctx, orgID, spec); err != nil {
+		return role.Role{}, err
+	}
+
+	return m.next.UpdateRole(ctx, orgID, roleName, spec)
+}
+
+func (m authorizationMiddleware) DeleteRole(ctx context.Context, orgID uint, roleName string) error {
+	return m.next.DeleteRole(ctx, orgID, roleName)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roledriver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/role"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+func TestAuthorizationMiddleware_CreateRole(t *testing.T) {
+	ctx := context.Background()
+
+	spec := role.RoleSpec{
+		Name:        "developer",
+		Permissions: []role.Permission{{Resource: auth.ResourceDeployment, Action: auth.ActionAny}},
+	}
+
+	expectedRole := role.Role{ID: 1, Name: spec.Name, Permissions: spec.Permissions}
+
+	service := new(role.MockService)
+	service.On("CreateRole", ctx, uint(1), spec).Return(expectedRole, nil)
+
+	authorizer := new(MockAuthorizer)
+	authorizer.On("Authorize", ctx, "role.grant", auth.PermissionGrant{OrganizationID: 1, Permissions: spec.Permissions}).Return(true, nil)
+
+	middleware := AuthorizationMiddleware(authorizer)(service)
+
+	r, err := middleware.CreateRole(ctx, 1, spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedRole, r)
+
+	service.AssertExpectations(t)
+	authorizer.AssertExpectations(t)
+}
+
+func TestAuthorizationMiddleware_UpdateRole_CannotGrantPermissions(t *testing.T) {
+	ctx := context.Background()
+
+	spec := role.RoleSpec{
+		Name:        "developer",
+		Permissions: []role.Permission{{Resource: auth.ResourceAny, Action: auth.ActionAny}},
+	}
+
+	service := new(role.MockService)
+
+	authorizer := new(MockAuthorizer)
+	authorizer.On("Authorize", ctx, "role.grant", auth.PermissionGrant{OrganizationID: 1, Permissions: spec.Permissions}).Return(false, nil)
+
+	middleware := AuthorizationMiddleware(authorizer)(service)
+
+	_, err := middleware.UpdateRole(ctx, 1, "developer", spec)
+	require.Error(t, err)
+
+	assert.Equal(t, CannotGrantPermissions, err)
+
+	service.AssertExpectations(t)
+	authorizer.AssertExpectations(t)
+}
@@ -0,0 +1,194 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roledriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"emperror.dev/errors"
+	"emperror.dev/errors/match"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	appkithttp "github.com/sagikazarmark/appkit/transport/http"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/role"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+// RegisterHTTPHandlers mounts all of the service endpoints into an http.Handler.
+func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter(
+		appkithttp.WithProblemMatchers(
+			appkithttp.NewStatusProblemMatcher(http.StatusForbidden, match.Is(CannotGrantPermissions).MatchError),
+		),
+	))
+
+	router.Methods(http.MethodPost).Path("").Handler(kithttp.NewServer(
+		endpoints.CreateRole,
+		decodeCreateRoleHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeCreateRoleHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("").Handler(kithttp.NewServer(
+		endpoints.ListRoles,
+		decodeListRolesHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListRolesHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/{roleName}").Handler(kithttp.NewServer(
+		endpoints.GetRole,
+		decodeGetRoleHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetRoleHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPut).Path("/{roleName}").Handler(kithttp.NewServer(
+		endpoints.UpdateRole,
+		decodeUpdateRoleHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeUpdateRoleHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodDelete).Path("/{roleName}").Handler(kithttp.NewServer(
+		endpoints.DeleteRole,
+		decodeDeleteRoleHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
+}
+
+func decodeCreateRoleHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := getOrgID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec role.RoleSpec
+
+	err = json.NewDecoder(r.Body).Decode(&spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return CreateRoleRequest{OrgID: orgID, Spec: spec}, nil
+}
+
+func encodeCreateRoleHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(CreateRoleResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(resp.Role, http.StatusCreated))
+}
+
+func decodeListRolesHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := getOrgID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListRolesRequest{OrgID: orgID}, nil
+}
+
+func encodeListRolesHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListRolesResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Roles)
+}
+
+func decodeGetRoleHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := getOrgID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	roleName, err := getRoleName(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetRoleRequest{OrgID: orgID, RoleName: roleName}, nil
+}
+
+func encodeGetRoleHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetRoleResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Role)
+}
+
+func decodeUpdateRoleHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := getOrgID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	roleName, err := getRoleName(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec role.RoleSpec
+
+	err = json.NewDecoder(r.Body).Decode(&spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return UpdateRoleRequest{OrgID: orgID, RoleName: roleName, Spec: spec}, nil
+}
+
+func encodeUpdateRoleHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(UpdateRoleResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Role)
+}
+
+func decodeDeleteRoleHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := getOrgID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	roleName, err := getRoleName(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeleteRoleRequest{OrgID: orgID, RoleName: roleName}, nil
+}
+
+func getOrgID(r *http.Request) (uint, error) {
+	orgIDStr, ok := mux.Vars(r)["orgId"]
+	if !ok || orgIDStr == "" {
+		return 0, errors.NewWithDetails("missing parameter from the URL", "param", "orgId")
+	}
+
+	orgID, err := strconv.ParseUint(orgIDStr, 10, 32)
+
+	return uint(orgID), errors.WrapIf(err, "invalid organization ID format")
+}
+
+func getRoleName(r *http.Request) (string, error) {
+	roleName, ok := mux.Vars(r)["roleName"]
+	if !ok || roleName == "" {
+		return "", errors.NewWithDetails("missing parameter from the URL", "param", "roleName")
+	}
+
+	return roleName, nil
+}
@@ -20,6 +20,8 @@ import (
 	"time"
 
 	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/auth"
 )
 
 // Token represents an access token.
@@ -28,8 +30,14 @@ type Token struct {
 	Name      string     `json:"name"`
 	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 	CreatedAt time.Time  `json:"createdAt,omitempty"`
+
+	// Scopes restrict what the token can be used for (nil means unrestricted).
+	Scopes *Scopes `json:"scopes,omitempty"`
 }
 
+// Scopes restrict an access token to an organization, a set of clusters and read-only or deploy-only actions.
+type Scopes = auth.TokenScopes
+
 // +kit:endpoint:errorStrategy=service
 // +testify:mock
 
@@ -53,12 +61,14 @@ type NewTokenRequest struct {
 	Name        string     `json:"name,omitempty"`
 	VirtualUser string     `json:"virtualUser,omitempty"`
 	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	Scopes      *Scopes    `json:"scopes,omitempty"`
 }
 
 // NewToken contains a generated token.
 type NewToken struct {
-	ID    string `json:"id,omitempty"`
-	Token string `json:"token,omitempty"`
+	ID     string  `json:"id,omitempty"`
+	Token  string  `json:"token,omitempty"`
+	Scopes *Scopes `json:"scopes,omitempty"`
 }
 
 // NewService returns a new Service.
@@ -98,7 +108,7 @@ type UserExtractor interface {
 // Store persists access tokens in a secret store.
 type Store interface {
 	// Store stores a token in the persistent secret store.
-	Store(ctx context.Context, userID string, tokenID string, name string, expiresAt *time.Time) error
+	Store(ctx context.Context, userID string, tokenID string, name string, expiresAt *time.Time, scopes *Scopes) error
 
 	// List lists the tokens in the store.
 	List(ctx context.Context, userID string) ([]Token, error)
@@ -137,12 +147,39 @@ func (NotFoundError) ServiceError() bool {
 	return true
 }
 
+// ValidationError is returned when a request is semantically invalid.
+type ValidationError struct {
+	violations []string
+}
+
+// Error implements the error interface.
+func (ValidationError) Error() string {
+	return "invalid token request"
+}
+
+// Violations returns details of the failed validation.
+func (e ValidationError) Violations() []string {
+	return e.violations[:]
+}
+
+// Validation tells a client that this error is related to a semantic validation of the request.
+// Can be used to translate the error to status codes for example.
+func (ValidationError) Validation() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (ValidationError) ServiceError() bool {
+	return true
+}
+
 // +testify:mock:testOnly=true
 
 // Generator generates a token.
 type Generator interface {
-	// GenerateToken generates a token.
-	GenerateToken(sub string, expiresAt int64, tokenType string, value string) (string, string, error)
+	// GenerateScopedToken generates a token restricted by scopes.
+	GenerateScopedToken(sub string, expiresAt int64, tokenType string, value string, scopes Scopes) (string, string, error)
 }
 
 const (
@@ -184,19 +221,34 @@ func (s service) CreateToken(ctx context.Context, tokenRequest NewTokenRequest)
 		expiresAt = tokenRequest.ExpiresAt.Unix()
 	}
 
-	tokenID, signedToken, err := s.generator.GenerateToken(sub, expiresAt, tokenType, userLogin)
+	var scopes Scopes
+	if tokenRequest.Scopes != nil {
+		scopes = *tokenRequest.Scopes
+	}
+
+	if err := scopes.Validate(); err != nil {
+		return NewToken{}, errors.WithStack(ValidationError{violations: []string{err.Error()}})
+	}
+
+	// Empty scopes are equivalent to an unrestricted token
+	if scopes.IsZero() {
+		tokenRequest.Scopes = nil
+	}
+
+	tokenID, signedToken, err := s.generator.GenerateScopedToken(sub, expiresAt, tokenType, userLogin, scopes)
 	if err != nil {
 		return NewToken{}, err
 	}
 
-	err = s.store.Store(ctx, sub, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt)
+	err = s.store.Store(ctx, sub, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt, tokenRequest.Scopes)
 	if err != nil {
 		return NewToken{}, err
 	}
 
 	return NewToken{
-		ID:    tokenID,
-		Token: signedToken,
+		ID:     tokenID,
+		Token:  signedToken,
+		Scopes: tokenRequest.Scopes,
 	}, nil
 }
 
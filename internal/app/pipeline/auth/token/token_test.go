@@ -48,10 +48,10 @@ func TestService_CreateToken(t *testing.T) {
 	}
 
 	store := new(MockStore)
-	store.On("Store", ctx, userIDString, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt).Return(nil)
+	store.On("Store", ctx, userIDString, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt, (*Scopes)(nil)).Return(nil)
 
 	generator := new(MockGenerator)
-	generator.On("GenerateToken", userIDString, int64(0), UserTokenType, userLogin).Return(tokenID, tokenValue, nil)
+	generator.On("GenerateScopedToken", userIDString, int64(0), UserTokenType, userLogin, Scopes{}).Return(tokenID, tokenValue, nil)
 
 	service := NewService(userExtractor, store, generator)
 
@@ -88,10 +88,10 @@ func TestService_CreateToken_DefaultName(t *testing.T) {
 	}
 
 	store := new(MockStore)
-	store.On("Store", ctx, userIDString, tokenID, "generated", tokenRequest.ExpiresAt).Return(nil)
+	store.On("Store", ctx, userIDString, tokenID, "generated", tokenRequest.ExpiresAt, (*Scopes)(nil)).Return(nil)
 
 	generator := new(MockGenerator)
-	generator.On("GenerateToken", userIDString, int64(0), UserTokenType, userLogin).Return(tokenID, tokenValue, nil)
+	generator.On("GenerateScopedToken", userIDString, int64(0), UserTokenType, userLogin, Scopes{}).Return(tokenID, tokenValue, nil)
 
 	service := NewService(userExtractor, store, generator)
 
@@ -128,10 +128,10 @@ func TestService_VirtualUser(t *testing.T) {
 	}
 
 	store := new(MockStore)
-	store.On("Store", ctx, userID, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt).Return(nil)
+	store.On("Store", ctx, userID, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt, (*Scopes)(nil)).Return(nil)
 
 	generator := new(MockGenerator)
-	generator.On("GenerateToken", "virtualUser", int64(0), VirtualUserTokenType, "virtualUser").Return(tokenID, tokenValue, nil)
+	generator.On("GenerateScopedToken", "virtualUser", int64(0), VirtualUserTokenType, "virtualUser", Scopes{}).Return(tokenID, tokenValue, nil)
 
 	service := NewService(userExtractor, store, generator)
 
@@ -145,6 +145,74 @@ func TestService_VirtualUser(t *testing.T) {
 	generator.AssertExpectations(t)
 }
 
+func TestService_CreateToken_Scoped(t *testing.T) {
+	ctx := context.Background()
+	userID := uint(1)
+	userIDString := fmt.Sprint(userID)
+	userLogin := "john.doe"
+	tokenID := "id"
+	tokenValue := "token"
+
+	scopes := Scopes{
+		OrganizationID: 1,
+		Clusters:       []string{"prod-*"},
+		Access:         "deploy",
+	}
+
+	tokenRequest := NewTokenRequest{
+		Name:   "ci",
+		Scopes: &scopes,
+	}
+
+	userExtractor := new(MockUserExtractor)
+	userExtractor.On("GetUserID", ctx).Return(userID, true)
+	userExtractor.On("GetUserLogin", ctx).Return(userLogin, true)
+
+	expectedToken := NewToken{
+		ID:     tokenID,
+		Token:  tokenValue,
+		Scopes: &scopes,
+	}
+
+	store := new(MockStore)
+	store.On("Store", ctx, userIDString, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt, &scopes).Return(nil)
+
+	generator := new(MockGenerator)
+	generator.On("GenerateScopedToken", userIDString, int64(0), UserTokenType, userLogin, scopes).Return(tokenID, tokenValue, nil)
+
+	service := NewService(userExtractor, store, generator)
+
+	newToken, err := service.CreateToken(ctx, tokenRequest)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedToken, newToken)
+
+	userExtractor.AssertExpectations(t)
+	store.AssertExpectations(t)
+	generator.AssertExpectations(t)
+}
+
+func TestService_CreateToken_InvalidScopes(t *testing.T) {
+	ctx := context.Background()
+
+	tokenRequest := NewTokenRequest{
+		Name:   "ci",
+		Scopes: &Scopes{Access: "write"},
+	}
+
+	userExtractor := new(MockUserExtractor)
+	userExtractor.On("GetUserID", ctx).Return(uint(1), true)
+	userExtractor.On("GetUserLogin", ctx).Return("john.doe", true)
+
+	service := NewService(userExtractor, new(MockStore), new(MockGenerator))
+
+	_, err := service.CreateToken(ctx, tokenRequest)
+	require.Error(t, err)
+
+	var verr ValidationError
+	assert.True(t, errors.As(err, &verr))
+}
+
 func TestService_ListTokens(t *testing.T) {
 	ctx := context.Background()
 	userID := uint(1)
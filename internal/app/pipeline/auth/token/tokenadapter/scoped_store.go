@@ -0,0 +1,187 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenadapter
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/token"
+)
+
+// TableName constants
+const (
+	tokenScopeTableName = "auth_token_scopes"
+)
+
+type tokenScopeModel struct {
+	ID             uint   `gorm:"primary_key"`
+	UserID         string `gorm:"unique_index:idx_auth_token_scopes_user_id_token_id;not null"`
+	TokenID        string `gorm:"unique_index:idx_auth_token_scopes_user_id_token_id;not null"`
+	OrganizationID uint
+	Clusters       string `gorm:"type:text"`
+	Access         string
+	CreatedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (tokenScopeModel) TableName() string {
+	return tokenScopeTableName
+}
+
+// ScopedStore stores user tokens in a Bank-Vaults store and their scopes in a database using Gorm.
+type ScopedStore struct {
+	tokens BankVaultsStore
+	db     *gorm.DB
+}
+
+// NewScopedStore returns a new ScopedStore.
+func NewScopedStore(tokens BankVaultsStore, db *gorm.DB) ScopedStore {
+	return ScopedStore{
+		tokens: tokens,
+		db:     db,
+	}
+}
+
+// Store stores a token in the persistent secret store.
+func (s ScopedStore) Store(
+	ctx context.Context,
+	userID string,
+	tokenID string,
+	name string,
+	expiresAt *time.Time,
+	scopes *token.Scopes,
+) error {
+	if scopes != nil {
+		clusters, err := json.Marshal(scopes.Clusters)
+		if err != nil {
+			return errors.WrapIf(err, "failed to encode token cluster scopes")
+		}
+
+		model := tokenScopeModel{
+			UserID:         userID,
+			TokenID:        tokenID,
+			OrganizationID: scopes.OrganizationID,
+			Clusters:       string(clusters),
+			Access:         scopes.Access,
+		}
+
+		err = s.db.Create(&model).Error
+		if err != nil {
+			return errors.WrapIfWithDetails(
+				err, "failed to save user access token scopes",
+				"userId", userID,
+				"tokenId", tokenID,
+			)
+		}
+	}
+
+	return s.tokens.Store(ctx, userID, tokenID, name, expiresAt)
+}
+
+// List lists the tokens in the store.
+func (s ScopedStore) List(ctx context.Context, userID string) ([]token.Token, error) {
+	tokens, err := s.tokens.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []tokenScopeModel
+
+	err = s.db.Where(tokenScopeModel{UserID: userID}).Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list user token scopes", "userId", userID)
+	}
+
+	scopes := make(map[string]*token.Scopes, len(models))
+	for _, model := range models {
+		sc, err := s.fromModel(model)
+		if err != nil {
+			return nil, err
+		}
+
+		scopes[model.TokenID] = sc
+	}
+
+	for i := range tokens {
+		tokens[i].Scopes = scopes[tokens[i].ID]
+	}
+
+	return tokens, nil
+}
+
+// Lookup finds a user token.
+func (s ScopedStore) Lookup(ctx context.Context, userID string, tokenID string) (token.Token, error) {
+	t, err := s.tokens.Lookup(ctx, userID, tokenID)
+	if err != nil {
+		return token.Token{}, err
+	}
+
+	var model tokenScopeModel
+
+	err = s.db.Where(tokenScopeModel{UserID: userID, TokenID: tokenID}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return t, nil
+	} else if err != nil {
+		return token.Token{}, errors.WrapIfWithDetails(
+			err, "failed to lookup user token scopes",
+			"userId", userID,
+			"tokenId", tokenID,
+		)
+	}
+
+	t.Scopes, err = s.fromModel(model)
+	if err != nil {
+		return token.Token{}, err
+	}
+
+	return t, nil
+}
+
+// Revoke revokes an access token.
+func (s ScopedStore) Revoke(ctx context.Context, userID string, tokenID string) error {
+	err := s.tokens.Revoke(ctx, userID, tokenID)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Where(tokenScopeModel{UserID: userID, TokenID: tokenID}).Delete(tokenScopeModel{}).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to delete user token scopes",
+			"userId", userID,
+			"tokenId", tokenID,
+		)
+	}
+
+	return nil
+}
+
+func (s ScopedStore) fromModel(model tokenScopeModel) (*token.Scopes, error) {
+	scopes := token.Scopes{
+		OrganizationID: model.OrganizationID,
+		Access:         model.Access,
+	}
+
+	if err := json.Unmarshal([]byte(model.Clusters), &scopes.Clusters); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to decode token cluster scopes", "tokenId", model.TokenID)
+	}
+
+	return &scopes, nil
+}
@@ -0,0 +1,95 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/banzaicloud/bank-vaults/pkg/sdk/auth"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/token"
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestScopedStore(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.Close()
+
+	store := NewScopedStore(NewBankVaultsStore(auth.NewInMemoryTokenStore()), db)
+
+	ctx := context.Background()
+	userID := "1"
+	expiresAt := time.Date(2019, time.September, 30, 15, 15, 00, 00, time.UTC)
+
+	scopes := &token.Scopes{
+		OrganizationID: 1,
+		Clusters:       []string{"42", "prod-*"},
+		Access:         "read",
+	}
+
+	err := store.Store(ctx, userID, "scoped", "ci", &expiresAt, scopes)
+	require.NoError(t, err)
+
+	err = store.Store(ctx, userID, "unscoped", "personal", nil, nil)
+	require.NoError(t, err)
+
+	tt, err := store.Lookup(ctx, userID, "scoped")
+	require.NoError(t, err)
+
+	assert.Equal(t, scopes, tt.Scopes)
+
+	tt, err = store.Lookup(ctx, userID, "unscoped")
+	require.NoError(t, err)
+
+	assert.Nil(t, tt.Scopes)
+
+	tokens, err := store.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+
+	for _, tt := range tokens {
+		if tt.ID == "scoped" {
+			assert.Equal(t, scopes, tt.Scopes)
+		} else {
+			assert.Nil(t, tt.Scopes)
+		}
+	}
+
+	err = store.Revoke(ctx, userID, "scoped")
+	require.NoError(t, err)
+
+	var count int
+
+	err = db.Model(&tokenScopeModel{}).Count(&count).Error
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, count)
+}
@@ -42,6 +42,7 @@ func TestAuthorizationMiddleware_CreateToken(t *testing.T) {
 	service.On("CreateToken", ctx, tokenRequest).Return(expectedNewToken, nil)
 
 	authorizer := new(MockAuthorizer)
+	authorizer.On("Authorize", ctx, "token.create", tokenRequest).Return(true, nil)
 
 	middleware := AuthorizationMiddleware(authorizer)(service)
 
@@ -72,6 +73,7 @@ func TestAuthorizationMiddleware_CreateToken_VirtualUser(t *testing.T) {
 	service.On("CreateToken", ctx, tokenRequest).Return(expectedNewToken, nil)
 
 	authorizer := new(MockAuthorizer)
+	authorizer.On("Authorize", ctx, "token.create", tokenRequest).Return(true, nil)
 	authorizer.On("Authorize", ctx, "virtualUser.create", "example").Return(true, nil)
 
 	middleware := AuthorizationMiddleware(authorizer)(service)
@@ -97,6 +99,7 @@ func TestAuthorizationMiddleware_CreateToken_VirtualUserDenied(t *testing.T) {
 	service := new(token.MockService)
 
 	authorizer := new(MockAuthorizer)
+	authorizer.On("Authorize", ctx, "token.create", tokenRequest).Return(true, nil)
 	authorizer.On("Authorize", ctx, "virtualUser.create", "example").Return(false, nil)
 
 	middleware := AuthorizationMiddleware(authorizer)(service)
@@ -109,3 +112,27 @@ func TestAuthorizationMiddleware_CreateToken_VirtualUserDenied(t *testing.T) {
 	service.AssertExpectations(t)
 	authorizer.AssertExpectations(t)
 }
+
+func TestAuthorizationMiddleware_CreateToken_Denied(t *testing.T) {
+	ctx := context.Background()
+
+	tokenRequest := token.NewTokenRequest{
+		Name:      "token",
+		ExpiresAt: nil,
+	}
+
+	service := new(token.MockService)
+
+	authorizer := new(MockAuthorizer)
+	authorizer.On("Authorize", ctx, "token.create", tokenRequest).Return(false, nil)
+
+	middleware := AuthorizationMiddleware(authorizer)(service)
+
+	_, err := middleware.CreateToken(ctx, tokenRequest)
+	require.Error(t, err)
+
+	assert.Equal(t, CannotCreateToken, err)
+
+	service.AssertExpectations(t)
+	authorizer.AssertExpectations(t)
+}
@@ -35,6 +35,7 @@ func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...ki
 	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter(
 		appkithttp.WithProblemMatchers(
 			appkithttp.NewStatusProblemMatcher(http.StatusForbidden, match.Is(CannotCreateVirtualUser).MatchError),
+			appkithttp.NewStatusProblemMatcher(http.StatusForbidden, match.Is(CannotCreateToken).MatchError),
 		),
 	))
 
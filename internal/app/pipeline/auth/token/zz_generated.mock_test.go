@@ -121,12 +121,12 @@ func (_m *MockStore) Revoke(ctx context.Context, userID string, tokenID string)
 }
 
 // Store provides a mock function.
-func (_m *MockStore) Store(ctx context.Context, userID string, tokenID string, name string, expiresAt *time.Time) (_result_0 error) {
-	ret := _m.Called(ctx, userID, tokenID, name, expiresAt)
+func (_m *MockStore) Store(ctx context.Context, userID string, tokenID string, name string, expiresAt *time.Time, scopes *Scopes) (_result_0 error) {
+	ret := _m.Called(ctx, userID, tokenID, name, expiresAt, scopes)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *time.Time) error); ok {
-		r0 = rf(ctx, userID, tokenID, name, expiresAt)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *time.Time, *Scopes) error); ok {
+		r0 = rf(ctx, userID, tokenID, name, expiresAt, scopes)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -139,27 +139,27 @@ type MockGenerator struct {
 	mock.Mock
 }
 
-// GenerateToken provides a mock function.
-func (_m *MockGenerator) GenerateToken(sub string, expiresAt int64, tokenType string, value string) (_result_0 string, _result_1 string, _result_2 error) {
-	ret := _m.Called(sub, expiresAt, tokenType, value)
+// GenerateScopedToken provides a mock function.
+func (_m *MockGenerator) GenerateScopedToken(sub string, expiresAt int64, tokenType string, value string, scopes Scopes) (_result_0 string, _result_1 string, _result_2 error) {
+	ret := _m.Called(sub, expiresAt, tokenType, value, scopes)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, int64, string, string) string); ok {
-		r0 = rf(sub, expiresAt, tokenType, value)
+	if rf, ok := ret.Get(0).(func(string, int64, string, string, Scopes) string); ok {
+		r0 = rf(sub, expiresAt, tokenType, value, scopes)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 string
-	if rf, ok := ret.Get(1).(func(string, int64, string, string) string); ok {
-		r1 = rf(sub, expiresAt, tokenType, value)
+	if rf, ok := ret.Get(1).(func(string, int64, string, string, Scopes) string); ok {
+		r1 = rf(sub, expiresAt, tokenType, value, scopes)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(string, int64, string, string) error); ok {
-		r2 = rf(sub, expiresAt, tokenType, value)
+	if rf, ok := ret.Get(2).(func(string, int64, string, string, Scopes) error); ok {
+		r2 = rf(sub, expiresAt, tokenType, value, scopes)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -40,6 +40,8 @@ func RegisterApp(
 	router *mux.Router,
 	db *gorm.DB,
 	cadenceClient cadence.Client,
+	workflowResetter process.WorkflowResetter,
+	config process.Config,
 	logger process.Logger,
 	errorHandler process.ErrorHandler,
 ) error {
@@ -53,7 +55,8 @@ func RegisterApp(
 		appkitendpoint.LoggingMiddleware(logger),
 	}
 
-	service := process.NewService(processadapter.NewGormStore(db), cadenceClient)
+	store := processadapter.NewGormStore(db)
+	service := process.NewService(store, cadenceClient, workflowResetter)
 
 	endpoints := processdriver.MakeEndpoints(
 		service,
@@ -66,11 +69,19 @@ func RegisterApp(
 		kithttp.ServerBefore(correlation.HTTPToContext()),
 	}
 
+	processRouter := router.PathPrefix("/processes").Subrouter()
+
 	processdriver.RegisterHTTPHandlers(
 		endpoints,
-		router.PathPrefix("/processes").Subrouter(),
+		processRouter,
 		kitxhttp.ServerOptions(httpServerOptions),
 	)
 
+	processdriver.RegisterEventStreamHTTPHandler(
+		process.NewEventStream(store, config.EventPollInterval),
+		processRouter,
+		errorHandler,
+	)
+
 	return nil
 }
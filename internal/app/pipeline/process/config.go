@@ -0,0 +1,61 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// Config contains configuration for the process module.
+type Config struct {
+	// EventPollInterval is the interval of checking for new events while streaming process events.
+	EventPollInterval time.Duration
+
+	Retention RetentionConfig
+}
+
+// RetentionConfig contains configuration for pruning finished processes.
+type RetentionConfig struct {
+	Enabled bool
+
+	// MaxAge is the time after finished processes are deleted.
+	MaxAge time.Duration
+
+	// Interval is the time between two pruning runs.
+	Interval time.Duration
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	var err error
+
+	if c.EventPollInterval <= 0 {
+		err = errors.Append(err, errors.New("process event poll interval must be positive"))
+	}
+
+	if c.Retention.Enabled {
+		if c.Retention.MaxAge <= 0 {
+			err = errors.Append(err, errors.New("process retention max age must be positive"))
+		}
+
+		if c.Retention.Interval <= 0 {
+			err = errors.Append(err, errors.New("process retention interval must be positive"))
+		}
+	}
+
+	return err
+}
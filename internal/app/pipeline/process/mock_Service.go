@@ -49,13 +49,13 @@ func (_m *MockService) GetProcess(ctx context.Context, id string) (pipeline.Proc
 	return r0, r1
 }
 
-// ListProcesses provides a mock function with given fields: ctx, query
-func (_m *MockService) ListProcesses(ctx context.Context, query pipeline.Process) ([]pipeline.Process, error) {
-	ret := _m.Called(ctx, query)
+// ListProcesses provides a mock function with given fields: ctx, query, options
+func (_m *MockService) ListProcesses(ctx context.Context, query pipeline.Process, options ListOptions) ([]pipeline.Process, error) {
+	ret := _m.Called(ctx, query, options)
 
 	var r0 []pipeline.Process
-	if rf, ok := ret.Get(0).(func(context.Context, pipeline.Process) []pipeline.Process); ok {
-		r0 = rf(ctx, query)
+	if rf, ok := ret.Get(0).(func(context.Context, pipeline.Process, ListOptions) []pipeline.Process); ok {
+		r0 = rf(ctx, query, options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]pipeline.Process)
@@ -63,8 +63,8 @@ func (_m *MockService) ListProcesses(ctx context.Context, query pipeline.Process
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, pipeline.Process) error); ok {
-		r1 = rf(ctx, query)
+	if rf, ok := ret.Get(1).(func(context.Context, pipeline.Process, ListOptions) error); ok {
+		r1 = rf(ctx, query, options)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -113,3 +113,24 @@ func (_m *MockService) LogProcessEvent(ctx context.Context, proc pipeline.Proces
 
 	return r0, r1
 }
+
+// RetryProcess provides a mock function with given fields: ctx, id
+func (_m *MockService) RetryProcess(ctx context.Context, id string) (pipeline.Process, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 pipeline.Process
+	if rf, ok := ret.Get(0).(func(context.Context, string) pipeline.Process); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(pipeline.Process)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -16,11 +16,14 @@ package process
 
 import (
 	"context"
+	"time"
 
+	"emperror.dev/errors"
 	"go.uber.org/cadence/.gen/go/shared"
 	cadence "go.uber.org/cadence/client"
 
 	"github.com/banzaicloud/pipeline/.gen/pipeline/pipeline"
+	"github.com/banzaicloud/pipeline/src/auth"
 )
 
 // Process represents an pipeline process.
@@ -44,29 +47,63 @@ type Service interface {
 	LogProcessEvent(ctx context.Context, proc ProcessEvent) (processEvent ProcessEvent, err error)
 
 	// ListProcesses lists access processes visible for a user.
-	ListProcesses(ctx context.Context, query Process) (processes []Process, err error)
+	ListProcesses(ctx context.Context, query Process, options ListOptions) (processes []Process, err error)
 
 	// GetProcess returns a single process.
 	GetProcess(ctx context.Context, id string) (process Process, err error)
 
 	// CancelProcess cancels a single process.
 	CancelProcess(ctx context.Context, id string) (err error)
+
+	// RetryProcess restarts a failed process from the failed step.
+	RetryProcess(ctx context.Context, id string) (process Process, err error)
 }
 
 // NewService returns a new Service.
-func NewService(store Store, cadenceClient cadence.Client) Service {
-	return service{store: store, cadenceClient: cadenceClient}
+func NewService(store Store, cadenceClient cadence.Client, workflowResetter WorkflowResetter) Service {
+	return service{store: store, cadenceClient: cadenceClient, workflowResetter: workflowResetter}
 }
 
 type service struct {
-	store         Store
-	cadenceClient cadence.Client
+	store            Store
+	cadenceClient    cadence.Client
+	workflowResetter WorkflowResetter
+}
+
+// RetryProcessType is the type of processes recording process retries.
+const RetryProcessType = "retry"
+
+// +testify:mock:testOnly=true
+
+// WorkflowResetter restarts failed workflows.
+type WorkflowResetter interface {
+	// ResetWorkflow restarts a failed workflow from the decision that scheduled the failed step
+	// (or from the beginning with the original input if the failed step cannot be determined).
+	// It returns the run ID of the new workflow run.
+	ResetWorkflow(ctx context.Context, workflowID string, reason string) (runID string, err error)
+}
+
+// ListOptions contains pagination and time range filters for listing processes.
+type ListOptions struct {
+	// StartedAfter filters processes started at or after a given time.
+	StartedAfter *time.Time
+
+	// StartedBefore filters processes started before a given time.
+	StartedBefore *time.Time
+
+	// Limit limits the number of returned processes (0 means no limit).
+	Limit int
+
+	// Offset skips a number of processes (ordered by start time, most recent first).
+	Offset int
 }
 
+// +testify:mock:testOnly=true
+
 // Store persists access processes in a persistent store.
 type Store interface {
 	// ListProcesses lists the process in the for a given organization.
-	ListProcesses(ctx context.Context, query Process) ([]Process, error)
+	ListProcesses(ctx context.Context, query Process, options ListOptions) ([]Process, error)
 
 	// LogProcess adds a process entry.
 	LogProcess(ctx context.Context, p Process) error
@@ -76,6 +113,13 @@ type Store interface {
 
 	// LogProcessEvent adds a process event to a process.
 	LogProcessEvent(ctx context.Context, p ProcessEvent) error
+
+	// ListProcessEvents lists the events of a process logged after the event with the given ID.
+	ListProcessEvents(ctx context.Context, processID string, afterID int32) ([]ProcessEvent, error)
+
+	// DeleteFinishedProcesses deletes the processes (and their events) finished before a given time.
+	// It returns the number of deleted processes.
+	DeleteFinishedProcesses(ctx context.Context, finishedBefore time.Time) (int, error)
 }
 
 // NotFoundError is returned if a process cannot be found.
@@ -105,8 +149,71 @@ func (NotFoundError) ServiceError() bool {
 	return true
 }
 
-func (s service) ListProcesses(ctx context.Context, query Process) ([]Process, error) {
-	return s.store.ListProcesses(ctx, query)
+// ValidationError is returned when a request is semantically invalid.
+type ValidationError struct {
+	violations []string
+}
+
+// Error implements the error interface.
+func (ValidationError) Error() string {
+	return "invalid process query"
+}
+
+// Violations returns details of the failed validation.
+func (e ValidationError) Violations() []string {
+	return e.violations[:]
+}
+
+// Validation tells a client that this error is related to a semantic validation of the request.
+// Can be used to translate the error to status codes for example.
+func (ValidationError) Validation() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (ValidationError) ServiceError() bool {
+	return true
+}
+
+// NotRetryableError is returned when a process cannot be retried.
+type NotRetryableError struct {
+	ID     string
+	Status ProcessStatus
+}
+
+// Error implements the error interface.
+func (NotRetryableError) Error() string {
+	return "only failed processes can be retried"
+}
+
+// Details returns error details.
+func (e NotRetryableError) Details() []interface{} {
+	return []interface{}{"processId", e.ID, "status", e.Status}
+}
+
+// Conflict tells a client that this error is related to a conflicting request.
+// Can be used to translate the error to eg. status code.
+func (NotRetryableError) Conflict() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (NotRetryableError) ServiceError() bool {
+	return true
+}
+
+func (s service) ListProcesses(ctx context.Context, query Process, options ListOptions) ([]Process, error) {
+	if options.Limit < 0 || options.Offset < 0 {
+		return nil, errors.WithStack(ValidationError{violations: []string{"limit and offset must not be negative"}})
+	}
+
+	if options.StartedAfter != nil && options.StartedBefore != nil && !options.StartedAfter.Before(*options.StartedBefore) {
+		return nil, errors.WithStack(ValidationError{violations: []string{"startedAfter must be before startedBefore"}})
+	}
+
+	return s.store.ListProcesses(ctx, query, options)
 }
 
 func (s service) GetProcess(ctx context.Context, id string) (Process, error) {
@@ -129,3 +236,65 @@ func (s service) CancelProcess(ctx context.Context, id string) error {
 
 	return err
 }
+
+func (s service) RetryProcess(ctx context.Context, id string) (Process, error) {
+	proc, err := s.store.GetProcess(ctx, id)
+	if err != nil {
+		return Process{}, err
+	}
+
+	// Processes of other organizations are hidden from the caller
+	if orgID, ok := auth.GetCurrentOrganizationID(ctx); !ok || uint(proc.OrgId) != orgID {
+		return Process{}, errors.WithStack(NotFoundError{ID: id})
+	}
+
+	if proc.Status != pipeline.FAILED {
+		return Process{}, errors.WithStack(NotRetryableError{ID: id, Status: proc.Status})
+	}
+
+	runID, err := s.workflowResetter.ResetWorkflow(ctx, id, "retried by user")
+	if _, ok := errors.Cause(err).(*shared.EntityNotExistsError); ok {
+		return Process{}, errors.WithStack(NotFoundError{ID: id})
+	} else if err != nil {
+		return Process{}, errors.WrapIfWithDetails(err, "failed to reset workflow", "processId", id)
+	}
+
+	now := time.Now()
+
+	// The workflow records its own end status when the new run completes
+	proc.Status = pipeline.RUNNING
+	proc.FinishedAt = nil
+
+	if err := s.store.LogProcess(ctx, proc); err != nil {
+		return Process{}, err
+	}
+
+	err = s.store.LogProcessEvent(ctx, ProcessEvent{
+		ProcessId: id,
+		Type:      RetryProcessType,
+		Log:       "process retried in run " + runID,
+		Status:    pipeline.RUNNING,
+		Timestamp: now,
+	})
+	if err != nil {
+		return Process{}, err
+	}
+
+	// The store finishes the retry together with the retried process
+	retry := Process{
+		Id:         runID,
+		ParentId:   id,
+		OrgId:      proc.OrgId,
+		Type:       RetryProcessType,
+		Log:        "retry of process " + id,
+		ResourceId: proc.ResourceId,
+		Status:     pipeline.RUNNING,
+		StartedAt:  now,
+	}
+
+	if err := s.store.LogProcess(ctx, retry); err != nil {
+		return Process{}, err
+	}
+
+	return retry, nil
+}
@@ -0,0 +1,191 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+func TestEventStream_Follow(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("GetProcess", ctx, "id").Return(Process{Id: "id", OrgId: 1, Status: ProcessStatus(Running)}, nil).Once()
+	store.On("ListProcessEvents", ctx, "id", int32(0)).Return([]ProcessEvent{{Id: 1}, {Id: 2}}, nil).Once()
+	store.On("GetProcess", ctx, "id").Return(Process{Id: "id", OrgId: 1, Status: ProcessStatus(Finished)}, nil).Once()
+	store.On("ListProcessEvents", ctx, "id", int32(2)).Return([]ProcessEvent{{Id: 3}}, nil).Once()
+
+	stream := NewEventStream(store, time.Millisecond)
+
+	var ids []int32
+
+	p, err := stream.Follow(ctx, 1, "id", 0, func(event ProcessEvent) error {
+		ids = append(ids, event.Id)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, ProcessStatus(Finished), p.Status)
+	assert.Equal(t, []int32{1, 2, 3}, ids)
+
+	store.AssertExpectations(t)
+}
+
+func TestEventStream_Follow_OtherOrganization(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("GetProcess", ctx, "id").Return(Process{Id: "id", OrgId: 2, Status: ProcessStatus(Running)}, nil)
+
+	stream := NewEventStream(store, time.Millisecond)
+
+	_, err := stream.Follow(ctx, 1, "id", 0, func(event ProcessEvent) error { return nil })
+	require.Error(t, err)
+
+	var nerr NotFoundError
+	assert.True(t, errors.As(err, &nerr))
+
+	store.AssertExpectations(t)
+}
+
+func TestRetentionJob_Prune(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("DeleteFinishedProcesses", ctx, mock.AnythingOfType("time.Time")).
+		Return(2, nil).
+		Run(func(args mock.Arguments) {
+			finishedBefore := args.Get(1).(time.Time)
+
+			assert.WithinDuration(t, time.Now().Add(-24*time.Hour), finishedBefore, time.Minute)
+		})
+
+	job := NewRetentionJob(store, 24*time.Hour, NoopLogger{}, nil)
+
+	err := job.Prune(ctx)
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_ListProcesses_InvalidOptions(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := map[string]ListOptions{
+		"negativeLimit":  {Limit: -1},
+		"negativeOffset": {Offset: -1},
+		"invalidRange":   {StartedAfter: &now, StartedBefore: &earlier},
+	}
+
+	for name, options := range tests {
+		name, options := name, options
+
+		t.Run(name, func(t *testing.T) {
+			service := NewService(new(MockStore), nil, nil)
+
+			_, err := service.ListProcesses(context.Background(), Process{OrgId: 1}, options)
+			require.Error(t, err)
+
+			var verr ValidationError
+			assert.True(t, errors.As(err, &verr))
+		})
+	}
+}
+
+func TestService_RetryProcess(t *testing.T) {
+	ctx := auth.SetCurrentOrganizationID(context.Background(), 1)
+
+	failed := Process{Id: "id", OrgId: 1, Type: "cluster", ResourceId: "42", Status: ProcessStatus(Failed)}
+
+	store := new(MockStore)
+	store.On("GetProcess", ctx, "id").Return(failed, nil)
+	store.On("LogProcess", ctx, mock.MatchedBy(func(p Process) bool {
+		return p.Id == "id" && p.Status == ProcessStatus(Running) && p.FinishedAt == nil
+	})).Return(nil).Once()
+	store.On("LogProcessEvent", ctx, mock.MatchedBy(func(e ProcessEvent) bool {
+		return e.ProcessId == "id" && e.Type == RetryProcessType
+	})).Return(nil).Once()
+	store.On("LogProcess", ctx, mock.MatchedBy(func(p Process) bool {
+		return p.Id == "run-id" && p.ParentId == "id"
+	})).Return(nil).Once()
+
+	resetter := new(MockWorkflowResetter)
+	resetter.On("ResetWorkflow", ctx, "id", mock.Anything).Return("run-id", nil)
+
+	service := NewService(store, nil, resetter)
+
+	retry, err := service.RetryProcess(ctx, "id")
+	require.NoError(t, err)
+
+	assert.Equal(t, "run-id", retry.Id)
+	assert.Equal(t, "id", retry.ParentId)
+	assert.Equal(t, int32(1), retry.OrgId)
+	assert.Equal(t, "42", retry.ResourceId)
+	assert.Equal(t, RetryProcessType, retry.Type)
+	assert.Equal(t, ProcessStatus(Running), retry.Status)
+	assert.Nil(t, retry.FinishedAt)
+
+	store.AssertExpectations(t)
+	resetter.AssertExpectations(t)
+}
+
+func TestService_RetryProcess_OtherOrganization(t *testing.T) {
+	ctx := auth.SetCurrentOrganizationID(context.Background(), 2)
+
+	store := new(MockStore)
+	store.On("GetProcess", ctx, "id").Return(Process{Id: "id", OrgId: 1, Status: ProcessStatus(Failed)}, nil)
+
+	resetter := new(MockWorkflowResetter)
+
+	service := NewService(store, nil, resetter)
+
+	_, err := service.RetryProcess(ctx, "id")
+	require.Error(t, err)
+
+	var notFoundErr NotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+
+	resetter.AssertNotCalled(t, "ResetWorkflow", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_RetryProcess_NotFailed(t *testing.T) {
+	ctx := auth.SetCurrentOrganizationID(context.Background(), 1)
+
+	store := new(MockStore)
+	store.On("GetProcess", ctx, "id").Return(Process{Id: "id", OrgId: 1, Status: ProcessStatus(Running)}, nil)
+
+	resetter := new(MockWorkflowResetter)
+
+	service := NewService(store, nil, resetter)
+
+	_, err := service.RetryProcess(ctx, "id")
+	require.Error(t, err)
+
+	var notRetryableErr NotRetryableError
+	assert.True(t, errors.As(err, &notRetryableErr))
+
+	resetter.AssertNotCalled(t, "ResetWorkflow", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,153 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/gofrs/uuid"
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
+	"go.uber.org/cadence/.gen/go/shared"
+)
+
+// CadenceWorkflowResetter resets failed workflows using the Cadence service API.
+type CadenceWorkflowResetter struct {
+	serviceClient workflowserviceclient.Interface
+	domain        string
+}
+
+// NewCadenceWorkflowResetter returns a new CadenceWorkflowResetter.
+func NewCadenceWorkflowResetter(serviceClient workflowserviceclient.Interface, domain string) CadenceWorkflowResetter {
+	return CadenceWorkflowResetter{
+		serviceClient: serviceClient,
+		domain:        domain,
+	}
+}
+
+// ResetWorkflow resets the latest run of a workflow to the decision that scheduled the failed activity
+// (or child workflow). If the failed step cannot be determined, the workflow is reset to its first decision,
+// which is equivalent to re-running it with the original input.
+func (r CadenceWorkflowResetter) ResetWorkflow(ctx context.Context, workflowID string, reason string) (string, error) {
+	describeResp, err := r.serviceClient.DescribeWorkflowExecution(ctx, &shared.DescribeWorkflowExecutionRequest{
+		Domain:    &r.domain,
+		Execution: &shared.WorkflowExecution{WorkflowId: &workflowID},
+	})
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to describe workflow")
+	}
+
+	info := describeResp.WorkflowExecutionInfo
+	if info == nil || info.Execution == nil {
+		return "", errors.NewWithDetails("workflow execution info is missing", "workflowId", workflowID)
+	}
+
+	if info.CloseStatus == nil {
+		return "", errors.NewWithDetails("workflow is still running", "workflowId", workflowID)
+	}
+
+	execution := info.Execution
+
+	resetEventID, err := r.findResetEventID(ctx, execution)
+	if err != nil {
+		return "", err
+	}
+
+	requestID, err := uuid.NewV4()
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to generate request ID")
+	}
+
+	requestIDString := requestID.String()
+
+	resetResp, err := r.serviceClient.ResetWorkflowExecution(ctx, &shared.ResetWorkflowExecutionRequest{
+		Domain:                &r.domain,
+		WorkflowExecution:     execution,
+		Reason:                &reason,
+		DecisionFinishEventId: &resetEventID,
+		RequestId:             &requestIDString,
+	})
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to reset workflow", "workflowId", workflowID, "eventId", resetEventID)
+	}
+
+	return resetResp.GetRunId(), nil
+}
+
+func (r CadenceWorkflowResetter) findResetEventID(ctx context.Context, execution *shared.WorkflowExecution) (int64, error) {
+	var firstDecisionEventID, failedDecisionEventID int64
+
+	// scheduling event ID -> ID of the decision task completed event that scheduled it
+	decisionEventIDs := make(map[int64]int64)
+
+	var nextPageToken []byte
+
+	for {
+		resp, err := r.serviceClient.GetWorkflowExecutionHistory(ctx, &shared.GetWorkflowExecutionHistoryRequest{
+			Domain:        &r.domain,
+			Execution:     execution,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return 0, errors.WrapIf(err, "failed to get workflow history")
+		}
+
+		if resp.History == nil {
+			break
+		}
+
+		for _, event := range resp.History.Events {
+			switch {
+			case event.GetEventType() == shared.EventTypeDecisionTaskCompleted:
+				if firstDecisionEventID == 0 {
+					firstDecisionEventID = event.GetEventId()
+				}
+
+			case event.ActivityTaskScheduledEventAttributes != nil:
+				decisionEventIDs[event.GetEventId()] = event.ActivityTaskScheduledEventAttributes.GetDecisionTaskCompletedEventId()
+
+			case event.StartChildWorkflowExecutionInitiatedEventAttributes != nil:
+				decisionEventIDs[event.GetEventId()] = event.StartChildWorkflowExecutionInitiatedEventAttributes.GetDecisionTaskCompletedEventId()
+
+			case event.ActivityTaskFailedEventAttributes != nil:
+				failedDecisionEventID = decisionEventIDs[event.ActivityTaskFailedEventAttributes.GetScheduledEventId()]
+
+			case event.ActivityTaskTimedOutEventAttributes != nil:
+				failedDecisionEventID = decisionEventIDs[event.ActivityTaskTimedOutEventAttributes.GetScheduledEventId()]
+
+			case event.ChildWorkflowExecutionFailedEventAttributes != nil:
+				failedDecisionEventID = decisionEventIDs[event.ChildWorkflowExecutionFailedEventAttributes.GetInitiatedEventId()]
+
+			case event.ChildWorkflowExecutionTimedOutEventAttributes != nil:
+				failedDecisionEventID = decisionEventIDs[event.ChildWorkflowExecutionTimedOutEventAttributes.GetInitiatedEventId()]
+			}
+		}
+
+		nextPageToken = resp.NextPageToken
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+
+	if failedDecisionEventID != 0 {
+		return failedDecisionEventID, nil
+	}
+
+	if firstDecisionEventID != 0 {
+		return firstDecisionEventID, nil
+	}
+
+	return 0, errors.NewWithDetails("no decision to reset the workflow to", "workflowId", execution.GetWorkflowId())
+}
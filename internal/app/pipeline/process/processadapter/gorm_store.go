@@ -16,11 +16,13 @@ package processadapter
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"emperror.dev/errors"
 	"github.com/jinzhu/gorm"
 
+	"github.com/banzaicloud/pipeline/.gen/pipeline/pipeline"
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/process"
 )
 
@@ -122,10 +124,33 @@ func (s *GormStore) GetProcess(ctx context.Context, id string) (process.Process,
 }
 
 // ListProcesses returns the list of active processes.
-func (s *GormStore) ListProcesses(ctx context.Context, query process.Process) ([]process.Process, error) {
+func (s *GormStore) ListProcesses(ctx context.Context, query process.Process, options process.ListOptions) ([]process.Process, error) {
 	var processes []processModel
 
-	err := s.db.Find(&processes, query).Error
+	db := s.db.Order("started_at DESC")
+
+	if options.StartedAfter != nil {
+		db = db.Where("started_at >= ?", *options.StartedAfter)
+	}
+
+	if options.StartedBefore != nil {
+		db = db.Where("started_at < ?", *options.StartedBefore)
+	}
+
+	if options.Limit > 0 {
+		db = db.Limit(options.Limit)
+	}
+
+	if options.Offset > 0 {
+		// Some dialects do not support offset without limit
+		if options.Limit == 0 {
+			db = db.Limit(math.MaxInt32)
+		}
+
+		db = db.Offset(options.Offset)
+	}
+
+	err := db.Find(&processes, query).Error
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to find processes")
 	}
@@ -154,6 +179,7 @@ func (s *GormStore) ListProcesses(ctx context.Context, query process.Process) ([
 
 		for _, em := range processEvents {
 			p.Events = append(p.Events, process.ProcessEvent{
+				Id:        int32(em.ID),
 				ProcessId: em.ProcessID,
 				Type:      em.Type,
 				Log:       em.Log,
@@ -184,6 +210,7 @@ func (s *GormStore) LogProcess(ctx context.Context, p process.Process) error {
 				ResourceID: p.ResourceId,
 				Status:     string(p.Status),
 				StartedAt:  p.StartedAt,
+				FinishedAt: p.FinishedAt,
 			}
 
 			err := s.db.Create(&pm).Error
@@ -202,6 +229,16 @@ func (s *GormStore) LogProcess(ctx context.Context, p process.Process) error {
 		return errors.Wrap(err, "failed to update process")
 	}
 
+	// Retries end together with the retried workflow
+	if p.FinishedAt != nil {
+		err = s.db.Model(&processModel{}).
+			Where("parent_id = ? AND type = ? AND status = ?", p.Id, process.RetryProcessType, string(pipeline.RUNNING)).
+			Updates(map[string]interface{}{"status": string(p.Status), "finished_at": p.FinishedAt}).Error
+		if err != nil {
+			return errors.Wrap(err, "failed to update process retries")
+		}
+	}
+
 	return nil
 }
 
@@ -218,3 +255,75 @@ func (s *GormStore) LogProcessEvent(ctx context.Context, p process.ProcessEvent)
 	err := s.db.Create(&pem).Error
 	return errors.Wrap(err, "failed to create process event")
 }
+
+// ListProcessEvents lists the events of a process logged after the event with the given ID.
+func (s *GormStore) ListProcessEvents(ctx context.Context, processID string, afterID int32) ([]process.ProcessEvent, error) {
+	var processEvents []processEventModel
+
+	err := s.db.
+		Where("process_id = ? AND id > ?", processID, afterID).
+		Order("id").
+		Find(&processEvents).
+		Error
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "failed to find process events", "processId", processID)
+	}
+
+	events := make([]process.ProcessEvent, 0, len(processEvents))
+	for _, em := range processEvents {
+		events = append(events, process.ProcessEvent{
+			Id:        int32(em.ID),
+			ProcessId: em.ProcessID,
+			Type:      em.Type,
+			Log:       em.Log,
+			Status:    process.ProcessStatus(em.Status),
+			Timestamp: em.Timestamp,
+		})
+	}
+
+	return events, nil
+}
+
+// DeleteFinishedProcesses deletes the processes (and their events) finished before a given time.
+func (s *GormStore) DeleteFinishedProcesses(ctx context.Context, finishedBefore time.Time) (int, error) {
+	var ids []string
+
+	err := s.db.
+		Model(&processModel{}).
+		Where("finished_at < ? AND status <> ?", finishedBefore, string(process.Running)).
+		Pluck("id", &ids).
+		Error
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find finished processes")
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	tx := s.db.Begin()
+	if err := tx.Error; err != nil {
+		return 0, errors.Wrap(err, "failed to begin transaction")
+	}
+
+	err = tx.Where("process_id IN (?)", ids).Delete(processEventModel{}).Error
+	if err != nil {
+		tx.Rollback()
+
+		return 0, errors.Wrap(err, "failed to delete process events")
+	}
+
+	err = tx.Where("id IN (?)", ids).Delete(processModel{}).Error
+	if err != nil {
+		tx.Rollback()
+
+		return 0, errors.Wrap(err, "failed to delete processes")
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return len(ids), nil
+}
@@ -0,0 +1,186 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/process"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	// Foreign keys cannot be added to SQLite tables after creation
+	err = db.AutoMigrate(&processModel{}, &processEventModel{}).Error
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore_ListProcesses(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.Close()
+
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	start := time.Date(2020, time.August, 17, 12, 0, 0, 0, time.UTC)
+
+	for i, id := range []string{"p1", "p2", "p3", "p4"} {
+		err := store.LogProcess(ctx, process.Process{
+			Id:         id,
+			OrgId:      1,
+			Type:       "cluster",
+			ResourceId: "1",
+			Status:     process.ProcessStatus(process.Running),
+			StartedAt:  start.Add(time.Duration(i) * time.Hour),
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("Pagination", func(t *testing.T) {
+		processes, err := store.ListProcesses(ctx, process.Process{OrgId: 1}, process.ListOptions{Limit: 2, Offset: 1})
+		require.NoError(t, err)
+
+		require.Len(t, processes, 2)
+		assert.Equal(t, "p3", processes[0].Id)
+		assert.Equal(t, "p2", processes[1].Id)
+	})
+
+	t.Run("TimeRange", func(t *testing.T) {
+		startedAfter := start.Add(time.Hour)
+		startedBefore := start.Add(3 * time.Hour)
+
+		processes, err := store.ListProcesses(ctx, process.Process{OrgId: 1}, process.ListOptions{
+			StartedAfter:  &startedAfter,
+			StartedBefore: &startedBefore,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, processes, 2)
+		assert.Equal(t, "p3", processes[0].Id)
+		assert.Equal(t, "p2", processes[1].Id)
+	})
+}
+
+func TestGormStore_ListProcessEvents(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.Close()
+
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	err := store.LogProcess(ctx, process.Process{Id: "p1", OrgId: 1, Type: "cluster", ResourceId: "1", Status: "running"})
+	require.NoError(t, err)
+
+	for _, eventType := range []string{"first", "second", "third"} {
+		err := store.LogProcessEvent(ctx, process.ProcessEvent{ProcessId: "p1", Type: eventType, Status: "finished"})
+		require.NoError(t, err)
+	}
+
+	events, err := store.ListProcessEvents(ctx, "p1", 1)
+	require.NoError(t, err)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "second", events[0].Type)
+	assert.Equal(t, "third", events[1].Type)
+}
+
+func TestGormStore_LogProcess_FinishesRetries(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.Close()
+
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	err := store.LogProcess(ctx, process.Process{Id: "p1", OrgId: 1, Type: "cluster", ResourceId: "1", Status: "running"})
+	require.NoError(t, err)
+
+	err = store.LogProcess(ctx, process.Process{Id: "r1", ParentId: "p1", OrgId: 1, Type: process.RetryProcessType, ResourceId: "1", Status: "running"})
+	require.NoError(t, err)
+
+	finishedAt := time.Now()
+
+	err = store.LogProcess(ctx, process.Process{Id: "p1", OrgId: 1, Type: "cluster", ResourceId: "1", Status: "finished", FinishedAt: &finishedAt})
+	require.NoError(t, err)
+
+	retry, err := store.GetProcess(ctx, "r1")
+	require.NoError(t, err)
+
+	assert.Equal(t, process.ProcessStatus("finished"), retry.Status)
+	assert.NotNil(t, retry.FinishedAt)
+}
+
+func TestGormStore_DeleteFinishedProcesses(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.Close()
+
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+
+	processes := []process.Process{
+		{Id: "old", Status: "finished", FinishedAt: &old},
+		{Id: "recent", Status: "finished", FinishedAt: &now},
+		{Id: "running", Status: "running"},
+	}
+
+	for _, p := range processes {
+		p.OrgId = 1
+		p.Type = "cluster"
+		p.ResourceId = "1"
+
+		// Processes are finished by logging them again
+		err := store.LogProcess(ctx, p)
+		require.NoError(t, err)
+
+		err = store.LogProcess(ctx, p)
+		require.NoError(t, err)
+
+		err = store.LogProcessEvent(ctx, process.ProcessEvent{ProcessId: p.Id, Type: "step", Status: "finished"})
+		require.NoError(t, err)
+	}
+
+	count, err := store.DeleteFinishedProcesses(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, count)
+
+	_, err = store.GetProcess(ctx, "old")
+	assert.Equal(t, process.NotFoundError{ID: "old"}, err)
+
+	var eventCount int
+
+	err = db.Model(&processEventModel{}).Where("process_id = ?", "old").Count(&eventCount).Error
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, eventCount)
+
+	remaining, err := store.ListProcesses(ctx, process.Process{OrgId: 1}, process.ListOptions{})
+	require.NoError(t, err)
+
+	assert.Len(t, remaining, 2)
+}
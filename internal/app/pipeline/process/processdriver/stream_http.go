@@ -0,0 +1,181 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processdriver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/process"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// Server-sent event names
+const (
+	processEventName = "processEvent"
+	processDoneName  = "done"
+)
+
+// +testify:mock:testOnly=true
+
+// EventFollower follows the events of a process as they are logged.
+type EventFollower interface {
+	// Follow calls fn for every event of a process logged after the event with the given ID
+	// until the process is finished or the context is cancelled.
+	Follow(
+		ctx context.Context,
+		orgID uint,
+		processID string,
+		afterID int32,
+		fn func(event process.ProcessEvent) error,
+	) (process.Process, error)
+}
+
+// RegisterEventStreamHTTPHandler mounts the process event stream (using server-sent events) into an http.Handler.
+func RegisterEventStreamHTTPHandler(follower EventFollower, router *mux.Router, errorHandler process.ErrorHandler) {
+	router.Methods(http.MethodGet).Path("/{id}/events").Handler(eventStreamHandler{
+		follower:     follower,
+		errorEncoder: kitxhttp.NewJSONProblemErrorEncoder(apphttp.NewDefaultProblemConverter()),
+		errorHandler: errorHandler,
+	})
+}
+
+type eventStreamHandler struct {
+	follower     EventFollower
+	errorEncoder kithttp.ErrorEncoder
+	errorHandler process.ErrorHandler
+}
+
+func (h eventStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		h.errorEncoder(ctx, errors.NewWithDetails("missing parameter from the URL", "param", "id"), w)
+
+		return
+	}
+
+	org := auth.GetCurrentOrganization(r)
+	if org == nil {
+		h.errorEncoder(ctx, errors.New("organization not found in the context"), w)
+
+		return
+	}
+
+	var afterID int32
+
+	// Resume the stream after the last event received by the client
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 32)
+		if err != nil {
+			h.errorEncoder(ctx, errors.WithStack(invalidParameterError{param: "Last-Event-ID", err: err}), w)
+
+			return
+		}
+
+		afterID = int32(id)
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.errorEncoder(ctx, errors.New("streaming is not supported by the response writer"), w)
+
+		return
+	}
+
+	var started bool
+
+	start := func() {
+		if started {
+			return
+		}
+
+		started = true
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+	}
+
+	p, err := h.follower.Follow(ctx, org.ID, id, afterID, func(event process.ProcessEvent) error {
+		start()
+
+		if err := writeEvent(w, fmt.Sprint(event.Id), processEventName, event); err != nil {
+			return err
+		}
+
+		flusher.Flush()
+
+		return nil
+	})
+	if err != nil {
+		// The client went away
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+
+		if !started {
+			h.errorEncoder(ctx, err, w)
+
+			return
+		}
+
+		h.errorHandler.HandleContext(ctx, err)
+
+		return
+	}
+
+	start()
+
+	// The events have already been sent
+	p.Events = nil
+
+	if err := writeEvent(w, "", processDoneName, p); err != nil {
+		h.errorHandler.HandleContext(ctx, err)
+
+		return
+	}
+
+	flusher.Flush()
+}
+
+func writeEvent(w http.ResponseWriter, id string, name string, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return errors.WrapIf(err, "failed to encode event")
+	}
+
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return errors.WrapIf(err, "failed to write event")
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, body)
+
+	return errors.WrapIf(err, "failed to write event")
+}
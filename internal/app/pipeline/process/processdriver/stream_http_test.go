@@ -0,0 +1,88 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processdriver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/process"
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+func TestEventStreamHandler(t *testing.T) {
+	timestamp := time.Date(2020, time.August, 17, 12, 0, 0, 0, time.UTC)
+
+	follower := new(MockEventFollower)
+	follower.On("Follow", mock.Anything, uint(1), "process-id", int32(3), mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(4).(func(event process.ProcessEvent) error)
+
+			_ = fn(process.ProcessEvent{Id: 4, ProcessId: "process-id", Type: "step", Status: "finished", Timestamp: timestamp})
+		}).
+		Return(process.Process{Id: "process-id", OrgId: 1, Status: "finished", StartedAt: timestamp}, nil)
+
+	router := mux.NewRouter()
+	RegisterEventStreamHTTPHandler(follower, router.PathPrefix("/processes").Subrouter(), common.NoopErrorHandler{})
+
+	req := httptest.NewRequest(http.MethodGet, "/processes/process-id/events", nil)
+	req.Header.Set("Last-Event-ID", "3")
+	req = req.WithContext(context.WithValue(req.Context(), auth.CurrentOrganization, &auth.Organization{ID: 1}))
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+
+	expectedBody := "id: 4\n" +
+		"event: processEvent\n" +
+		`data: {"id":4,"processId":"process-id","type":"step","status":"finished","timestamp":"2020-08-17T12:00:00Z"}` + "\n\n" +
+		"event: done\n" +
+		`data: {"id":"process-id","orgId":1,"status":"finished","startedAt":"2020-08-17T12:00:00Z"}` + "\n\n"
+
+	assert.Equal(t, expectedBody, w.Body.String())
+
+	follower.AssertExpectations(t)
+}
+
+func TestEventStreamHandler_NotFound(t *testing.T) {
+	follower := new(MockEventFollower)
+	follower.On("Follow", mock.Anything, uint(1), "process-id", int32(0), mock.Anything).
+		Return(process.Process{}, process.NotFoundError{ID: "process-id"})
+
+	router := mux.NewRouter()
+	RegisterEventStreamHTTPHandler(follower, router.PathPrefix("/processes").Subrouter(), common.NoopErrorHandler{})
+
+	req := httptest.NewRequest(http.MethodGet, "/processes/process-id/events", nil)
+	req = req.WithContext(context.WithValue(req.Context(), auth.CurrentOrganization, &auth.Organization{ID: 1}))
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	follower.AssertExpectations(t)
+}
@@ -16,7 +16,10 @@ package processdriver
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"emperror.dev/errors"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -53,6 +56,13 @@ func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...ki
 		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusAccepted), errorEncoder),
 		options...,
 	))
+
+	router.Methods(http.MethodPost).Path("/{id}/retry").Handler(kithttp.NewServer(
+		endpoints.RetryProcess,
+		decodeRetryProcessHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeRetryProcessHTTPResponse, errorEncoder),
+		options...,
+	))
 }
 
 func encodeListProcessesHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
@@ -83,6 +93,23 @@ func decodeCancelProcessHTTPRequest(_ context.Context, r *http.Request) (interfa
 	return CancelProcessRequest{Id: id}, nil
 }
 
+func decodeRetryProcessHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+
+	id, ok := vars["id"]
+	if !ok || id == "" {
+		return nil, errors.NewWithDetails("missing parameter from the URL", "param", "id")
+	}
+
+	return RetryProcessRequest{Id: id}, nil
+}
+
+func encodeRetryProcessHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(RetryProcessResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(resp.Process, http.StatusAccepted))
+}
+
 func encodeGetProcessHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
 	resp := response.(GetProcessResponse)
 
@@ -114,5 +141,64 @@ func decodeListProcessesHTTPRequest(_ context.Context, r *http.Request) (interfa
 		query.Status = pipeline.ProcessStatus(rt[0])
 	}
 
-	return ListProcessesRequest{Query: query}, nil
+	var options process.ListOptions
+
+	if rt := values.Get("startedAfter"); rt != "" {
+		startedAfter, err := time.Parse(time.RFC3339, rt)
+		if err != nil {
+			return nil, errors.WithStack(invalidParameterError{param: "startedAfter", err: err})
+		}
+
+		options.StartedAfter = &startedAfter
+	}
+
+	if rt := values.Get("startedBefore"); rt != "" {
+		startedBefore, err := time.Parse(time.RFC3339, rt)
+		if err != nil {
+			return nil, errors.WithStack(invalidParameterError{param: "startedBefore", err: err})
+		}
+
+		options.StartedBefore = &startedBefore
+	}
+
+	if rt := values.Get("limit"); rt != "" {
+		limit, err := strconv.Atoi(rt)
+		if err != nil {
+			return nil, errors.WithStack(invalidParameterError{param: "limit", err: err})
+		}
+
+		options.Limit = limit
+	}
+
+	if rt := values.Get("offset"); rt != "" {
+		offset, err := strconv.Atoi(rt)
+		if err != nil {
+			return nil, errors.WithStack(invalidParameterError{param: "offset", err: err})
+		}
+
+		options.Offset = offset
+	}
+
+	return ListProcessesRequest{Query: query, Options: options}, nil
+}
+
+// invalidParameterError is returned when a request parameter cannot be parsed.
+type invalidParameterError struct {
+	param string
+	err   error
+}
+
+// Error implements the error interface.
+func (e invalidParameterError) Error() string {
+	return fmt.Sprintf("invalid %s parameter: %s", e.param, e.err)
+}
+
+// Unwrap returns the underlying parse error.
+func (e invalidParameterError) Unwrap() error {
+	return e.err
+}
+
+// BadRequest tells a client that this error is related to a malformed request.
+func (invalidParameterError) BadRequest() bool {
+	return true
 }
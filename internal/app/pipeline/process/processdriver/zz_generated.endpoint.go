@@ -32,6 +32,7 @@ type Endpoints struct {
 	ListProcesses   endpoint.Endpoint
 	LogProcess      endpoint.Endpoint
 	LogProcessEvent endpoint.Endpoint
+	RetryProcess    endpoint.Endpoint
 }
 
 // MakeEndpoints returns a(n) Endpoints struct where each endpoint invokes
@@ -45,6 +46,7 @@ func MakeEndpoints(service process.Service, middleware ...endpoint.Middleware) E
 		ListProcesses:   kitxendpoint.OperationNameMiddleware("process.ListProcesses")(mw(MakeListProcessesEndpoint(service))),
 		LogProcess:      kitxendpoint.OperationNameMiddleware("process.LogProcess")(mw(MakeLogProcessEndpoint(service))),
 		LogProcessEvent: kitxendpoint.OperationNameMiddleware("process.LogProcessEvent")(mw(MakeLogProcessEventEndpoint(service))),
+		RetryProcess:    kitxendpoint.OperationNameMiddleware("process.RetryProcess")(mw(MakeRetryProcessEndpoint(service))),
 	}
 }
 
@@ -123,7 +125,8 @@ func MakeGetProcessEndpoint(service process.Service) endpoint.Endpoint {
 
 // ListProcessesRequest is a request struct for ListProcesses endpoint.
 type ListProcessesRequest struct {
-	Query pipeline.Process
+	Query   pipeline.Process
+	Options process.ListOptions
 }
 
 // ListProcessesResponse is a response struct for ListProcesses endpoint.
@@ -141,7 +144,7 @@ func MakeListProcessesEndpoint(service process.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(ListProcessesRequest)
 
-		processes, err := service.ListProcesses(ctx, req.Query)
+		processes, err := service.ListProcesses(ctx, req.Query, req.Options)
 
 		if err != nil {
 			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
@@ -240,3 +243,43 @@ func MakeLogProcessEventEndpoint(service process.Service) endpoint.Endpoint {
 		return LogProcessEventResponse{ProcessEvent: processEvent}, nil
 	}
 }
+
+// RetryProcessRequest is a request struct for RetryProcess endpoint.
+type RetryProcessRequest struct {
+	Id string
+}
+
+// RetryProcessResponse is a response struct for RetryProcess endpoint.
+type RetryProcessResponse struct {
+	Process pipeline.Process
+	Err     error
+}
+
+func (r RetryProcessResponse) Failed() error {
+	return r.Err
+}
+
+// MakeRetryProcessEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeRetryProcessEndpoint(service process.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RetryProcessRequest)
+
+		process, err := service.RetryProcess(ctx, req.Id)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return RetryProcessResponse{
+					Err:     err,
+					Process: process,
+				}, nil
+			}
+
+			return RetryProcessResponse{
+				Err:     err,
+				Process: process,
+			}, err
+		}
+
+		return RetryProcessResponse{Process: process}, nil
+	}
+}
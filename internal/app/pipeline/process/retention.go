@@ -0,0 +1,76 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"time"
+)
+
+// RetentionJob prunes finished processes from the store.
+type RetentionJob struct {
+	store  Store
+	maxAge time.Duration
+
+	logger       Logger
+	errorHandler ErrorHandler
+}
+
+// NewRetentionJob returns a new RetentionJob.
+func NewRetentionJob(store Store, maxAge time.Duration, logger Logger, errorHandler ErrorHandler) RetentionJob {
+	return RetentionJob{
+		store:  store,
+		maxAge: maxAge,
+
+		logger:       logger,
+		errorHandler: errorHandler,
+	}
+}
+
+// Prune deletes processes finished earlier than the configured max age.
+func (j RetentionJob) Prune(ctx context.Context) error {
+	finishedBefore := time.Now().Add(-j.maxAge)
+
+	count, err := j.store.DeleteFinishedProcesses(ctx, finishedBefore)
+	if err != nil {
+		return err
+	}
+
+	j.logger.Info("pruned finished processes", map[string]interface{}{
+		"count":          count,
+		"finishedBefore": finishedBefore,
+	})
+
+	return nil
+}
+
+// Run prunes finished processes periodically until the context is cancelled.
+func (j RetentionJob) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.Prune(ctx); err != nil {
+			j.errorHandler.HandleContext(ctx, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// EventStream follows the events of a process as they are logged.
+//
+// Process events are logged by workflow activities (potentially running in a different process),
+// so the stream polls the store for new events until the process is finished.
+type EventStream struct {
+	store        Store
+	pollInterval time.Duration
+}
+
+// NewEventStream returns a new EventStream.
+func NewEventStream(store Store, pollInterval time.Duration) EventStream {
+	return EventStream{
+		store:        store,
+		pollInterval: pollInterval,
+	}
+}
+
+// Follow calls fn for every event of a process logged after the event with the given ID
+// until the process is finished or the context is cancelled.
+// It returns the process in its final state.
+func (s EventStream) Follow(
+	ctx context.Context,
+	orgID uint,
+	processID string,
+	afterID int32,
+	fn func(event ProcessEvent) error,
+) (Process, error) {
+	for {
+		// Fetch the process before the events, so that no event logged before finishing the process is lost
+		p, err := s.store.GetProcess(ctx, processID)
+		if err != nil {
+			return Process{}, err
+		}
+
+		if uint(p.OrgId) != orgID {
+			return Process{}, errors.WithStack(NotFoundError{ID: processID})
+		}
+
+		events, err := s.store.ListProcessEvents(ctx, processID, afterID)
+		if err != nil {
+			return Process{}, err
+		}
+
+		for _, event := range events {
+			if err := fn(event); err != nil {
+				return Process{}, err
+			}
+
+			afterID = event.Id
+		}
+
+		if p.Status != ProcessStatus(Running) {
+			return p, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return Process{}, ctx.Err()
+
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/banzaicloud/pipeline/internal/app/pipelinectl/cli/commands/drain"
+	"github.com/banzaicloud/pipeline/internal/app/pipelinectl/cli/commands/secret"
 	"github.com/banzaicloud/pipeline/internal/app/pipelinectl/cli/commands/telemetry"
 )
 
@@ -25,6 +26,7 @@ import (
 func AddCommands(cmd *cobra.Command) {
 	cmd.AddCommand(
 		drain.NewDrainCommand(),
+		secret.NewSecretCommand(),
 		telemetry.NewTelemetryCommand(),
 		telemetry.NewPendingClustersCommand(),
 	)
@@ -0,0 +1,114 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	intSecret "github.com/banzaicloud/pipeline/internal/secret"
+)
+
+// copyResult summarizes the secrets copied for an organization.
+type copyResult struct {
+	copied  int
+	skipped int
+}
+
+// copySecrets copies the secrets of an organization (including their version history) between stores.
+//
+// Version numbers and timestamps are preserved if the destination store implements intSecret.VersionImporter.
+// Otherwise the versions are written one by one: their content and order is kept,
+// but the destination store assigns new version numbers and timestamps.
+//
+// Secrets already existing in the destination store are skipped, so that an interrupted copy can be resumed.
+func copySecrets(ctx context.Context, from intSecret.Store, to intSecret.Store, organizationID uint) (copyResult, error) {
+	var result copyResult
+
+	models, err := from.List(ctx, organizationID)
+	if err != nil {
+		return result, errors.WrapIfWithDetails(err, "failed to list secrets", "organizationId", organizationID)
+	}
+
+	for _, model := range models {
+		_, err := to.Get(ctx, organizationID, model.ID)
+		if err == nil {
+			result.skipped++
+
+			continue
+		} else if !errors.As(err, &intSecret.NotFoundError{}) {
+			return result, errors.WrapIfWithDetails(err, "failed to check if secret exists", "organizationId", organizationID, "secretId", model.ID)
+		}
+
+		versions, err := readVersions(ctx, from, organizationID, model.ID)
+		if err != nil {
+			return result, err
+		}
+
+		// Make sure the latest version is copied last even if older versions are not available
+		if len(versions) == 0 || versions[len(versions)-1].Version != model.Version {
+			versions = append(versions, model)
+		}
+
+		if importer, ok := to.(intSecret.VersionImporter); ok {
+			err := importer.ImportVersions(ctx, organizationID, versions)
+			if errors.As(err, &intSecret.AlreadyExistsError{}) {
+				result.skipped++
+
+				continue
+			} else if err != nil {
+				return result, errors.WrapIfWithDetails(err, "failed to copy secret", "organizationId", organizationID, "secretId", model.ID)
+			}
+
+			result.copied++
+
+			continue
+		}
+
+		for _, version := range versions {
+			if err := to.Put(ctx, organizationID, version); err != nil {
+				return result, errors.WrapIfWithDetails(err, "failed to copy secret", "organizationId", organizationID, "secretId", model.ID, "version", version.Version)
+			}
+		}
+
+		result.copied++
+	}
+
+	return result, nil
+}
+
+// readVersions reads the content of every available version of a secret (the oldest first).
+func readVersions(ctx context.Context, store intSecret.Store, organizationID uint, id string) ([]intSecret.Model, error) {
+	versions, err := store.ListVersions(ctx, organizationID, id)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list secret versions", "organizationId", organizationID, "secretId", id)
+	}
+
+	models := make([]intSecret.Model, 0, len(versions))
+
+	for _, version := range versions {
+		model, err := store.GetVersion(ctx, organizationID, id, version.Version)
+		if errors.As(err, &intSecret.VersionNotFoundError{}) { // Version was removed?
+			continue
+		} else if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to read secret version", "organizationId", organizationID, "secretId", id, "version", version.Version)
+		}
+
+		models = append(models, model)
+	}
+
+	return models, nil
+}
@@ -0,0 +1,127 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	intSecret "github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
+)
+
+func newDatabaseStore(t *testing.T) intSecret.Store {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	err = secretadapter.MigrateDatabaseStore(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	keys, err := secretadapter.NewLocalKeyProvider([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	return secretadapter.NewDatabaseStore(db, keys)
+}
+
+func TestCopySecrets(t *testing.T) {
+	ctx := context.Background()
+
+	from := newDatabaseStore(t)
+	to := newDatabaseStore(t)
+
+	for _, value := range []string{"first", "second"} {
+		err := from.Put(ctx, 1, intSecret.Model{
+			ID:        "versioned",
+			Name:      "versioned",
+			Type:      "generic",
+			Values:    map[string]string{"key": value},
+			UpdatedBy: "user",
+		})
+		require.NoError(t, err)
+	}
+
+	err := from.Put(ctx, 1, intSecret.Model{ID: "existing", Name: "existing", Type: "generic", Values: map[string]string{"key": "source"}})
+	require.NoError(t, err)
+
+	err = to.Put(ctx, 1, intSecret.Model{ID: "existing", Name: "existing", Type: "generic", Values: map[string]string{"key": "destination"}})
+	require.NoError(t, err)
+
+	err = from.Put(ctx, 2, intSecret.Model{ID: "other", Name: "other", Type: "generic"})
+	require.NoError(t, err)
+
+	result, err := copySecrets(ctx, from, to, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, copyResult{copied: 1, skipped: 1}, result)
+
+	versions, err := to.ListVersions(ctx, 1, "versioned")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+
+	sourceVersions, err := from.ListVersions(ctx, 1, "versioned")
+	require.NoError(t, err)
+
+	assert.Equal(t, sourceVersions, versions)
+	assert.Equal(t, "first", versions[0].Values["key"])
+	assert.Equal(t, "second", versions[1].Values["key"])
+	assert.Equal(t, "user", versions[1].UpdatedBy)
+
+	existing, err := to.Get(ctx, 1, "existing")
+	require.NoError(t, err)
+
+	assert.Equal(t, "destination", existing.Values["key"])
+
+	models, err := to.List(ctx, 2)
+	require.NoError(t, err)
+
+	assert.Empty(t, models)
+}
+
+// storeWithoutImport hides the version importer of a store.
+type storeWithoutImport struct {
+	intSecret.Store
+}
+
+func TestCopySecrets_WithoutImport(t *testing.T) {
+	ctx := context.Background()
+
+	from := newDatabaseStore(t)
+	to := storeWithoutImport{newDatabaseStore(t)}
+
+	for _, value := range []string{"first", "second"} {
+		err := from.Put(ctx, 1, intSecret.Model{ID: "versioned", Name: "versioned", Type: "generic", Values: map[string]string{"key": value}})
+		require.NoError(t, err)
+	}
+
+	result, err := copySecrets(ctx, from, to, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, copyResult{copied: 1}, result)
+
+	versions, err := to.ListVersions(ctx, 1, "versioned")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+
+	assert.Equal(t, "first", versions[0].Values["key"])
+	assert.Equal(t, "second", versions[1].Values["key"])
+}
@@ -0,0 +1,165 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/banzaicloud/bank-vaults/pkg/sdk/vault"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/banzaicloud/pipeline/internal/cmd"
+	"github.com/banzaicloud/pipeline/internal/platform/database"
+	"github.com/banzaicloud/pipeline/pkg/hook"
+)
+
+type migrateOptions struct {
+	configFile      string
+	from            string
+	to              string
+	organizationIDs []uint
+}
+
+// migrateConfig contains the parts of the Pipeline configuration required for copying secrets.
+type migrateConfig struct {
+	Database struct {
+		database.Config `mapstructure:",squash"`
+	}
+
+	Secret cmd.SecretConfig
+}
+
+// NewMigrateCommand creates a new cobra.Command for `pipelinectl secret migrate`.
+func NewMigrateCommand() *cobra.Command {
+	options := migrateOptions{}
+
+	c := &cobra.Command{
+		Use:   "migrate",
+		Short: "Copy secrets between secret backends",
+		Long: "Copy the secrets of every organization (including their version history) between secret backends.\n\n" +
+			"The command connects to the database and the secret backends using the Pipeline configuration. " +
+			"Secrets already existing in the destination backend are skipped.\n\n" +
+			"Version numbers and timestamps are preserved when copying to the database backend. " +
+			"Vault assigns new version numbers and timestamps to the copied versions.",
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			c.SilenceErrors = true
+			c.SilenceUsage = true
+
+			return runMigrate(options)
+		},
+	}
+
+	flags := c.Flags()
+
+	flags.StringVarP(&options.configFile, "config", "c", "", "Pipeline configuration file")
+	flags.StringVar(&options.from, "from", cmd.SecretBackendVault, "Source secret backend (vault or database)")
+	flags.StringVar(&options.to, "to", cmd.SecretBackendDatabase, "Destination secret backend (vault or database)")
+	flags.UintSliceVar(&options.organizationIDs, "organization", nil, "Organizations to copy the secrets of (defaults to every organization)")
+
+	return c
+}
+
+func runMigrate(options migrateOptions) error {
+	if options.from == options.to {
+		return errors.New("source and destination secret backends must be different")
+	}
+
+	config, err := loadMigrateConfig(options.configFile)
+	if err != nil {
+		return err
+	}
+
+	if err := config.Database.Validate(); err != nil {
+		return errors.WrapIf(err, "invalid database configuration")
+	}
+
+	db, err := database.Connect(config.Database.Config)
+	if err != nil {
+		return errors.WrapIf(err, "failed to connect to database")
+	}
+	defer db.Close()
+
+	var vaultClient *vault.Client
+	if options.from == cmd.SecretBackendVault || options.to == cmd.SecretBackendVault {
+		vaultClient, err = vault.NewClient("pipeline")
+		if err != nil {
+			return errors.WrapIf(err, "failed to create vault client")
+		}
+		defer vaultClient.Close()
+	}
+
+	from, err := cmd.CreateSecretStore(options.from, config.Secret.Database, db, vaultClient)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create source secret store")
+	}
+
+	to, err := cmd.CreateSecretStore(options.to, config.Secret.Database, db, vaultClient)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create destination secret store")
+	}
+
+	organizationIDs := options.organizationIDs
+	if len(organizationIDs) == 0 {
+		err := db.Table("organizations").Order("id").Pluck("id", &organizationIDs).Error
+		if err != nil {
+			return errors.WrapIf(err, "failed to list organizations")
+		}
+	}
+
+	for _, organizationID := range organizationIDs {
+		result, err := copySecrets(context.Background(), from, to, organizationID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Organization %d: copied %d secrets, skipped %d existing secrets.\n", organizationID, result.copied, result.skipped)
+	}
+
+	return nil
+}
+
+func loadMigrateConfig(configFile string) (migrateConfig, error) {
+	v := viper.NewWithOptions(
+		viper.KeyDelimiter("::"),
+	)
+
+	v.AllowEmptyEnv(true)
+	v.SetEnvPrefix("pipeline")
+	v.SetEnvKeyReplacer(strings.NewReplacer("::", "_", ".", "_", "-", "_"))
+	v.AutomaticEnv()
+
+	cmd.Configure(v, pflag.NewFlagSet("pipeline", pflag.ContinueOnError))
+
+	if configFile != "" {
+		v.SetConfigFile(configFile)
+
+		if err := v.ReadInConfig(); err != nil {
+			return migrateConfig{}, errors.WrapIf(err, "failed to read configuration")
+		}
+	}
+
+	var config migrateConfig
+	if err := v.Unmarshal(&config, hook.DecodeHookWithDefaults()); err != nil {
+		return config, errors.WrapIf(err, "failed to unmarshal configuration")
+	}
+
+	return config, nil
+}
@@ -21,7 +21,9 @@ type CreateBucketRequest struct {
 	SecretID   string `json:"secretId" binding:"required"`
 	Location   string `json:"location"`
 
-	AzureBucketProperties `json:"azure"`
+	AzureBucketProperties  `json:"azure"`
+	OracleBucketProperties `json:"oracle"`
+	S3BucketProperties     `json:"s3"`
 }
 
 // AzureObjectStoreBucketProperties describes bucket properties for an Azure ObjectStore Container
@@ -30,6 +32,17 @@ type AzureBucketProperties struct {
 	ResourceGroup  string `json:"resourceGroup,omitempty"`
 }
 
+// OracleBucketProperties describes bucket properties for an Oracle bucket accessed through the Amazon S3 Compatibility API
+type OracleBucketProperties struct {
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// S3BucketProperties describes bucket properties for an S3 compatible object store (eg. MinIO)
+type S3BucketProperties struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	CACert   string `json:"caCert,omitempty"`
+}
+
 // FindBucketRequest describes a find bucket request
 type FindBucketRequest struct {
 	Cloud      string
@@ -45,6 +58,7 @@ type Bucket struct {
 	SecretID string `json:"secretId"`
 	Location string `json:"location,omitempty"`
 	AzureBucketProperties
+	S3BucketProperties
 	Status              string `json:"status"`
 	InUse               bool   `json:"inUse"`
 	DeploymentID        uint   `json:"deploymentId,omitempty"`
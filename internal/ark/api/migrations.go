@@ -0,0 +1,39 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+// CreateMigrationRequest describes a request for migrating workloads of a cluster to another cluster
+type CreateMigrationRequest struct {
+	// TargetClusterID is the ID of the cluster the workloads are migrated to.
+	// The migration does not provision the cluster, it has to be created beforehand;
+	// the cluster might still be under provisioning, the migration waits for it to become ready.
+	TargetClusterID uint `json:"targetClusterId" binding:"required"`
+
+	IncludedNamespaces []string `json:"includedNamespaces,omitempty"`
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+
+	// NamespaceMapping is a map of source namespace names to target namespace names to restore into
+	NamespaceMapping map[string]string `json:"namespaceMapping,omitempty"`
+
+	// StorageClassMapping is a map of source storage class names to target storage class names
+	StorageClassMapping map[string]string `json:"storageClassMapping,omitempty"`
+}
+
+// CreateMigrationResponse describes a create migration response
+type CreateMigrationResponse struct {
+	ProcessID  string `json:"processId"`
+	BackupName string `json:"backupName"`
+	Status     int    `json:"status"`
+}
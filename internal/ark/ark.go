@@ -17,6 +17,8 @@ package ark
 import (
 	"emperror.dev/errors"
 
+	"github.com/banzaicloud/pipeline/internal/ark/providers/s3compatible"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
 	"github.com/banzaicloud/pipeline/pkg/providers"
 	"github.com/banzaicloud/pipeline/src/secret"
@@ -25,7 +27,7 @@ import (
 // IsProviderSupported checks whether the given provider is supported
 func IsProviderSupported(provider string) error {
 	switch provider {
-	case providers.Amazon, providers.Azure, providers.Google:
+	case providers.Alibaba, providers.Amazon, providers.Azure, providers.Google, providers.Oracle, s3compatible.Provider:
 		return nil
 	default:
 		return pkgErrors.ErrorNotSupportedCloudType
@@ -39,9 +41,20 @@ func GetSecretWithValidation(secretID string, orgID uint, provider string) (*sec
 		return nil, errors.WrapIf(err, "error validating create bucket request")
 	}
 
-	if err := secret.ValidateSecretType(s, provider); err != nil {
+	if err := secret.ValidateSecretType(s, getSecretType(provider)); err != nil {
 		return nil, errors.WrapIf(err, "error validating create bucket request")
 	}
 
 	return s, nil
 }
+
+// getSecretType returns the secret type used for accessing buckets of a provider.
+// Oracle and S3 compatible buckets are accessed through the S3 API with Amazon type credentials.
+func getSecretType(provider string) string {
+	switch provider {
+	case providers.Oracle, s3compatible.Provider:
+		return secrettype.Amazon
+	default:
+		return provider
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"emperror.dev/errors"
 
 	"github.com/banzaicloud/pipeline/internal/ark/api"
+	"github.com/banzaicloud/pipeline/internal/ark/providers/s3compatible"
 	"github.com/banzaicloud/pipeline/internal/providers"
 	pkgProviders "github.com/banzaicloud/pipeline/pkg/providers"
 	"github.com/banzaicloud/pipeline/src/auth"
@@ -30,13 +31,25 @@ func ValidateCreateBucketRequest(req *api.CreateBucketRequest, org *auth.Organiz
 		return errors.WrapIf(err, req.Cloud)
 	}
 
-	if req.Cloud == pkgProviders.Azure {
+	switch req.Cloud {
+	case pkgProviders.Azure:
 		if req.ResourceGroup == "" {
 			return errors.New("error validating create bucket request: resourceGroup must not be empty")
 		}
 		if req.StorageAccount == "" {
 			return errors.New("error validating create bucket request: storageAccount must not be empty")
 		}
+	case pkgProviders.Oracle:
+		if req.Namespace == "" {
+			return errors.New("error validating create bucket request: namespace must not be empty")
+		}
+		if req.Location == "" {
+			return errors.New("error validating create bucket request: location must not be empty")
+		}
+	case s3compatible.Provider:
+		if err := s3compatible.ValidateEndpoint(req.Endpoint, req.CACert); err != nil {
+			return errors.WrapIf(err, "error validating create bucket request")
+		}
 	}
 
 	secret, err := GetSecretWithValidation(req.SecretID, org.ID, req.Cloud)
@@ -50,6 +63,8 @@ func ValidateCreateBucketRequest(req *api.CreateBucketRequest, org *auth.Organiz
 		Location:       req.Location,
 		ResourceGroup:  req.ResourceGroup,
 		StorageAccount: req.StorageAccount,
+		Endpoint:       req.Endpoint,
+		CACert:         req.CACert,
 	}
 
 	os, err := NewObjectStore(ctx)
@@ -33,6 +33,8 @@ type ClusterBackupBucketsModel struct {
 	Location       string
 	StorageAccount string
 	ResourceGroup  string
+	Endpoint       string
+	CACert         string `sql:"type:text;"`
 
 	Status        string
 	StatusMessage string `sql:"type:text;"`
@@ -75,6 +77,10 @@ func (m *ClusterBackupBucketsModel) ConvertModelToEntity() *api.Bucket {
 			StorageAccount: m.StorageAccount,
 			ResourceGroup:  m.ResourceGroup,
 		},
+		S3BucketProperties: api.S3BucketProperties{
+			Endpoint: m.Endpoint,
+			CACert:   m.CACert,
+		},
 		Status: m.Status,
 		InUse:  inUse,
 
@@ -124,6 +124,7 @@ func (s *BucketsRepository) FindOneOrCreateByRequest(req *api.CreateBucketReques
 		Location:       req.Location,
 		StorageAccount: req.StorageAccount,
 		ResourceGroup:  req.ResourceGroup,
+		Endpoint:       req.Endpoint,
 
 		OrganizationID: s.org.ID,
 	}).Error
@@ -132,6 +133,7 @@ func (s *BucketsRepository) FindOneOrCreateByRequest(req *api.CreateBucketReques
 	}
 
 	bucket.SecretID = req.SecretID
+	bucket.CACert = req.CACert
 
 	err = s.db.Save(&bucket).Error
 	if err != nil {
@@ -33,7 +33,9 @@ import (
 	"k8s.io/kubernetes/pkg/apis/core"
 
 	"github.com/banzaicloud/pipeline/internal/ark/api"
+	"github.com/banzaicloud/pipeline/internal/ark/providers/oracle"
 	"github.com/banzaicloud/pipeline/internal/providers"
+	pkgProviders "github.com/banzaicloud/pipeline/pkg/providers"
 	"github.com/banzaicloud/pipeline/src/auth"
 )
 
@@ -79,6 +81,8 @@ func (s *BucketsService) GetObjectStoreForBucket(bucket *api.Bucket) (cloudprovi
 		Location:       bucket.Location,
 		StorageAccount: bucket.StorageAccount,
 		ResourceGroup:  bucket.ResourceGroup,
+		Endpoint:       bucket.Endpoint,
+		CACert:         bucket.CACert,
 	}
 
 	os, err := NewObjectStore(ctx)
@@ -198,6 +202,10 @@ func (s *BucketsService) IsBucketInUse(bucket *ClusterBackupBucketsModel) error
 
 // FindOrCreateBucket finds or create a new ClusterBackupBucketsModel by a CreateBucketRequest
 func (s *BucketsService) FindOrCreateBucket(req *api.CreateBucketRequest) (*ClusterBackupBucketsModel, error) {
+	if req.Cloud == pkgProviders.Oracle {
+		req.Endpoint = oracle.GetEndpoint(req.Namespace, req.Location)
+	}
+
 	err := ValidateCreateBucketRequest(req, s.org)
 	if err != nil {
 		return nil, err
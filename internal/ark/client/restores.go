@@ -47,6 +47,7 @@ func (c *Client) CreateRestore(req api.CreateRestoreRequest) (*arkAPI.Restore, e
 			IncludeClusterResources: req.Options.IncludeClusterResources,
 			LabelSelector:           req.Options.LabelSelector,
 			RestorePVs:              req.Options.RestorePVs,
+			NamespaceMapping:        req.Options.NamespaceMapping,
 		},
 	}
 
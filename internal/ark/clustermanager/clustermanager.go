@@ -45,3 +45,7 @@ func (cm *ClusterManager) GetClusters(ctx context.Context, organizationID uint)
 
 	return apiClusters, nil
 }
+
+func (cm *ClusterManager) GetClusterByID(ctx context.Context, organizationID uint, clusterID uint) (api.Cluster, error) {
+	return cm.clusterManager.GetClusterByID(ctx, organizationID, clusterID)
+}
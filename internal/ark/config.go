@@ -15,9 +15,15 @@
 package ark
 
 import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/banzaicloud/pipeline/internal/ark/providers/alibaba"
 	"github.com/banzaicloud/pipeline/internal/ark/providers/amazon"
 	"github.com/banzaicloud/pipeline/internal/ark/providers/azure"
 	"github.com/banzaicloud/pipeline/internal/ark/providers/google"
+	"github.com/banzaicloud/pipeline/internal/ark/providers/oracle"
+	"github.com/banzaicloud/pipeline/internal/ark/providers/s3compatible"
 	"github.com/banzaicloud/pipeline/internal/global"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
 	"github.com/banzaicloud/pipeline/pkg/providers"
@@ -39,6 +45,20 @@ type ValueOverrides struct {
 	Credentials   credentials   `json:"credentials"`
 	Image         image         `json:"image"`
 	RBAC          rbac          `json:"rbac"`
+
+	InitContainers []initContainer `json:"initContainers,omitempty"`
+}
+
+// initContainer installs an ARK plugin into the shared plugins volume
+type initContainer struct {
+	Name         string        `json:"name"`
+	Image        string        `json:"image"`
+	VolumeMounts []volumeMount `json:"volumeMounts"`
+}
+
+type volumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
 }
 
 type rbac struct {
@@ -62,9 +82,9 @@ type secretContents struct {
 }
 
 type configuration struct {
-	PersistentVolumeProvider persistentVolumeProvider `json:"persistentVolumeProvider"`
-	BackupStorageProvider    backupStorageProvider    `json:"backupStorageProvider"`
-	RestoreOnlyMode          bool                     `json:"restoreOnlyMode"`
+	PersistentVolumeProvider *persistentVolumeProvider `json:"persistentVolumeProvider,omitempty"`
+	BackupStorageProvider    backupStorageProvider     `json:"backupStorageProvider"`
+	RestoreOnlyMode          bool                      `json:"restoreOnlyMode"`
 }
 
 type persistentVolumeProvider struct {
@@ -81,6 +101,7 @@ type backupStorageProvider struct {
 	Name   string                      `json:"name"`
 	Bucket string                      `json:"bucket"`
 	Config backupStorageProviderConfig `json:"config,omitempty"`
+	CACert string                      `json:"caCert,omitempty"`
 }
 
 type backupStorageProviderConfig struct {
@@ -119,6 +140,7 @@ type bucketConfig struct {
 	Location string
 
 	azureBucketConfig
+	s3BucketConfig
 }
 
 type azureBucketConfig struct {
@@ -126,6 +148,11 @@ type azureBucketConfig struct {
 	ResourceGroup  string
 }
 
+type s3BucketConfig struct {
+	Endpoint string
+	CACert   string
+}
+
 // GetChartConfig get a ChartConfig
 func GetChartConfig() ChartConfig {
 	return ChartConfig{
@@ -168,25 +195,31 @@ func (req ConfigRequest) Get() (values ValueOverrides, err error) {
 			Tag:        global.Config.Cluster.DisasterRecovery.Charts.Ark.Values.Image.Tag,
 			PullPolicy: global.Config.Cluster.DisasterRecovery.Charts.Ark.Values.Image.PullPolicy,
 		},
+		InitContainers: req.getPluginContainers(),
 	}, nil
 }
 
-func (req ConfigRequest) getPVPConfig() (persistentVolumeProvider, error) {
-	var config persistentVolumeProvider
+// getPVPConfig returns the persistent volume provider config for the cluster.
+// Volume snapshots are disabled on providers without persistent volume support.
+func (req ConfigRequest) getPVPConfig() (*persistentVolumeProvider, error) {
 	var pvc string
 
 	switch req.Cluster.Provider {
+	case providers.Alibaba:
+		pvc = alibaba.PersistentVolumeProvider
 	case providers.Amazon:
 		pvc = amazon.PersistentVolumeProvider
 	case providers.Azure:
 		pvc = azure.PersistentVolumeProvider
 	case providers.Google:
 		pvc = google.PersistentVolumeProvider
+	case providers.Oracle, s3compatible.Provider:
+		return nil, nil
 	default:
-		return config, pkgErrors.ErrorNotSupportedCloudType
+		return nil, pkgErrors.ErrorNotSupportedCloudType
 	}
 
-	return persistentVolumeProvider{
+	return &persistentVolumeProvider{
 		Name: pvc,
 		Config: persistentVolumeProviderConfig{
 			Region:     req.Cluster.Location,
@@ -206,6 +239,12 @@ func (req ConfigRequest) getBSPConfig() (backupStorageProvider, error) {
 		bsp = azure.BackupStorageProvider
 	case providers.Google:
 		bsp = google.BackupStorageProvider
+	case providers.Alibaba:
+		bsp = alibaba.BackupStorageProvider
+	case providers.Oracle:
+		bsp = oracle.BackupStorageProvider
+	case s3compatible.Provider:
+		bsp = s3compatible.BackupStorageProvider
 	default:
 		return config, pkgErrors.ErrorNotSupportedCloudType
 	}
@@ -221,9 +260,44 @@ func (req ConfigRequest) getBSPConfig() (backupStorageProvider, error) {
 		}
 	}
 
+	switch req.Bucket.Provider {
+	case providers.Oracle, s3compatible.Provider:
+		config.Config = backupStorageProviderConfig{
+			Region:           s3compatible.GetRegion(req.Bucket.Location),
+			S3ForcePathStyle: "true",
+			S3Url:            req.Bucket.Endpoint,
+		}
+
+		if req.Bucket.CACert != "" {
+			config.CACert = base64.StdEncoding.EncodeToString([]byte(req.Bucket.CACert))
+		}
+	}
+
 	return config, nil
 }
 
+// getPluginContainers returns the init containers installing the ARK plugins required by the cluster and the bucket.
+func (req ConfigRequest) getPluginContainers() []initContainer {
+	var containers []initContainer
+
+	if req.Cluster.Provider == providers.Alibaba || req.Bucket.Provider == providers.Alibaba {
+		plugin := global.Config.Cluster.DisasterRecovery.Charts.Ark.Values.Plugins.Alibaba
+
+		containers = append(containers, initContainer{
+			Name:  "ark-plugin-alibabacloud",
+			Image: fmt.Sprintf("%s:%s", plugin.Repository, plugin.Tag),
+			VolumeMounts: []volumeMount{
+				{
+					Name:      "plugins",
+					MountPath: "/target",
+				},
+			},
+		})
+	}
+
+	return containers
+}
+
 func (req ConfigRequest) getCredentials() (credentials, error) {
 	var config credentials
 	var azureSecret azure.Secret
@@ -231,6 +305,11 @@ func (req ConfigRequest) getCredentials() (credentials, error) {
 	var err error
 
 	switch req.Cluster.Provider {
+	case providers.Alibaba:
+		ClusterSecretContents, err = alibaba.GetSecret(req.ClusterSecret)
+		if err != nil {
+			return config, err
+		}
 	case providers.Amazon:
 		ClusterSecretContents, err = amazon.GetSecret(req.ClusterSecret)
 		if err != nil {
@@ -246,12 +325,15 @@ func (req ConfigRequest) getCredentials() (credentials, error) {
 		if err != nil {
 			return config, err
 		}
-	default:
-		return config, pkgErrors.ErrorNotSupportedCloudType
 	}
 
 	switch req.Bucket.Provider {
-	case providers.Amazon:
+	case providers.Alibaba:
+		BucketSecretContents, err = alibaba.GetSecret(req.BucketSecret)
+		if err != nil {
+			return config, err
+		}
+	case providers.Amazon, providers.Oracle, s3compatible.Provider:
 		BucketSecretContents, err = amazon.GetSecret(req.BucketSecret)
 		if err != nil {
 			return config, err
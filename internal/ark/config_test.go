@@ -0,0 +1,80 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ark
+
+import (
+	"testing"
+
+	"github.com/banzaicloud/pipeline/internal/ark/providers/s3compatible"
+	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
+	"github.com/banzaicloud/pipeline/pkg/providers"
+)
+
+func TestConfigRequest_getPVPConfig(t *testing.T) {
+	tests := map[string]struct {
+		provider string
+		expected string
+		err      error
+	}{
+		"alibaba": {
+			provider: providers.Alibaba,
+			expected: "alibabacloud",
+		},
+		"amazon": {
+			provider: providers.Amazon,
+			expected: "aws",
+		},
+		"oracle": {
+			provider: providers.Oracle,
+		},
+		"s3compatible": {
+			provider: s3compatible.Provider,
+		},
+		"unknown": {
+			provider: "unknown",
+			err:      pkgErrors.ErrorNotSupportedCloudType,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			req := ConfigRequest{
+				Cluster: clusterConfig{
+					Provider: test.provider,
+					Location: "region",
+				},
+			}
+
+			pvp, err := req.getPVPConfig()
+			if err != test.err {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+
+			if test.expected == "" {
+				if pvp != nil {
+					t.Errorf("expected no persistent volume provider, got %q", pvp.Name)
+				}
+
+				return
+			}
+
+			if pvp == nil || pvp.Name != test.expected {
+				t.Errorf("expected persistent volume provider %q, got %v", test.expected, pvp)
+			}
+		})
+	}
+}
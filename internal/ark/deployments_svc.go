@@ -146,6 +146,10 @@ func (s *DeploymentsService) Deploy(helmService HelmService, bucket *ClusterBack
 				StorageAccount: bucket.StorageAccount,
 				ResourceGroup:  bucket.ResourceGroup,
 			},
+			s3BucketConfig: s3BucketConfig{
+				Endpoint: bucket.Endpoint,
+				CACert:   bucket.CACert,
+			},
 		},
 		BucketSecret: bucketSecret,
 
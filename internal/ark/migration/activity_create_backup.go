@@ -0,0 +1,127 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	arkAPI "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/banzaicloud/pipeline/internal/ark/api"
+	"github.com/banzaicloud/pipeline/internal/ark/sync"
+)
+
+const CreateBackupActivityName = "ark-migration-create-backup"
+
+// backupTTL is the time migration backups are kept for
+const backupTTL = 30 * 24 * time.Hour
+
+// CreateBackupActivity takes a backup of the source cluster and waits for it to complete.
+type CreateBackupActivity struct {
+	activities
+}
+
+type CreateBackupActivityInput struct {
+	OrganizationID uint
+	ClusterID      uint
+	BackupName     string
+	Options        Options
+}
+
+func NewCreateBackupActivity(clusters ClusterGetter, db *gorm.DB, logger logrus.FieldLogger) CreateBackupActivity {
+	return CreateBackupActivity{
+		activities: activities{
+			clusters: clusters,
+			db:       db,
+			logger:   logger,
+		},
+	}
+}
+
+func (a CreateBackupActivity) Execute(ctx context.Context, input CreateBackupActivityInput) error {
+	svc, org, err := a.getARKService(ctx, input.OrganizationID, input.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := svc.GetDeploymentsService().GetActiveDeployment(); gorm.IsRecordNotFoundError(err) {
+		return newFailedError("backup service is not enabled on the source cluster")
+	} else if err != nil {
+		return errors.WrapIf(err, "failed to get backup service deployment")
+	}
+
+	client, err := svc.GetDeploymentsService().GetClient()
+	if err != nil {
+		return errors.WrapIf(err, "failed to get ARK client")
+	}
+
+	// the backup might have been created by a previous attempt
+	_, err = client.GetBackupByName(input.BackupName)
+	if k8serrors.IsNotFound(err) {
+		snapshotVolumes := true
+		includeClusterResources := true
+
+		err = svc.GetClusterBackupsService().Create(api.CreateBackupRequest{
+			Name:   input.BackupName,
+			TTL:    metav1.Duration{Duration: backupTTL},
+			Labels: labels.Set{migratedByLabelKey: migratedByLabelValue},
+			Options: api.BackupOptions{
+				IncludedNamespaces:      input.Options.IncludedNamespaces,
+				ExcludedNamespaces:      input.Options.ExcludedNamespaces,
+				SnapshotVolumes:         &snapshotVolumes,
+				IncludeClusterResources: &includeClusterResources,
+			},
+		})
+	}
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create backup", "backup", input.BackupName)
+	}
+
+	err = poll(ctx, func() (bool, string, error) {
+		backup, err := client.GetBackupByName(input.BackupName)
+		if err != nil {
+			return false, "", errors.WrapIfWithDetails(err, "failed to get backup", "backup", input.BackupName)
+		}
+
+		switch backup.Status.Phase {
+		case arkAPI.BackupPhaseCompleted:
+			return true, string(backup.Status.Phase), nil
+		case arkAPI.BackupPhaseFailed, arkAPI.BackupPhaseFailedValidation:
+			return false, "", newFailedError(
+				"backup %s failed: %s %s",
+				input.BackupName,
+				backup.Status.Phase,
+				strings.Join(backup.Status.ValidationErrors, ", "),
+			)
+		default:
+			return false, string(backup.Status.Phase), nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = sync.NewBackupsSyncService(org, a.db, a.logger).SyncBackupsForCluster(svc.GetCluster())
+
+	return errors.WrapIf(err, "failed to sync backups of the source cluster")
+}
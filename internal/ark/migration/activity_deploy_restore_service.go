@@ -0,0 +1,148 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/internal/ark"
+	"github.com/banzaicloud/pipeline/internal/ark/api"
+	"github.com/banzaicloud/pipeline/pkg/k8sclient"
+)
+
+const DeployRestoreServiceActivityName = "ark-migration-deploy-restore-service"
+
+// Storage class remapping is configured through the change-storage-class restore item action plugin
+const (
+	storageClassConfigMapName = "change-storage-class-config"
+	pluginConfigLabelKey      = "velero.io/plugin-config"
+	storageClassPluginKey     = "velero.io/change-storage-class"
+	restoreItemActionKind     = "RestoreItemAction"
+)
+
+// DeployRestoreServiceActivity deploys ARK in restore mode to the target cluster using the bucket of the source cluster.
+type DeployRestoreServiceActivity struct {
+	activities
+
+	helmService ark.HelmService
+}
+
+type DeployRestoreServiceActivityInput struct {
+	OrganizationID  uint
+	SourceClusterID uint
+	TargetClusterID uint
+
+	StorageClassMapping map[string]string
+}
+
+func NewDeployRestoreServiceActivity(
+	clusters ClusterGetter,
+	db *gorm.DB,
+	helmService ark.HelmService,
+	logger logrus.FieldLogger,
+) DeployRestoreServiceActivity {
+	return DeployRestoreServiceActivity{
+		activities: activities{
+			clusters: clusters,
+			db:       db,
+			logger:   logger,
+		},
+		helmService: helmService,
+	}
+}
+
+func (a DeployRestoreServiceActivity) Execute(ctx context.Context, input DeployRestoreServiceActivityInput) error {
+	sourceSvc, org, err := a.getARKService(ctx, input.OrganizationID, input.SourceClusterID)
+	if err != nil {
+		return err
+	}
+
+	sourceDeployment, err := sourceSvc.GetDeploymentsService().GetActiveDeployment()
+	if err != nil {
+		return errors.WrapIf(err, "failed to get backup service deployment of the source cluster")
+	}
+
+	bucket, err := ark.NewBucketsRepository(org, a.db, a.logger).FindOneByID(sourceDeployment.BucketID)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get backup bucket", "bucketId", sourceDeployment.BucketID)
+	}
+
+	targetSvc, _, err := a.getARKService(ctx, input.OrganizationID, input.TargetClusterID)
+	if err != nil {
+		return err
+	}
+
+	targetDeployment, err := targetSvc.GetDeploymentsService().GetActiveDeployment()
+	switch {
+	case gorm.IsRecordNotFoundError(err):
+		err = targetSvc.GetDeploymentsService().Deploy(a.helmService, bucket, true)
+		if err != nil {
+			return errors.WrapIf(err, "failed to deploy restore service to the target cluster")
+		}
+
+	case err != nil:
+		return errors.WrapIf(err, "failed to get backup service deployment of the target cluster")
+
+	// the restore service might have been deployed by a previous attempt
+	case !targetDeployment.RestoreMode || targetDeployment.BucketID != bucket.ID:
+		return newFailedError("backup service is already enabled on the target cluster")
+	}
+
+	if len(input.StorageClassMapping) == 0 {
+		return nil
+	}
+
+	return a.configureStorageClassMapping(ctx, targetSvc.GetCluster(), input.StorageClassMapping)
+}
+
+func (a DeployRestoreServiceActivity) configureStorageClassMapping(ctx context.Context, cluster api.Cluster, mapping map[string]string) error {
+	kubeConfig, err := cluster.GetK8sConfig()
+	if err != nil {
+		return errors.WrapIf(err, "failed to get target cluster config")
+	}
+
+	client, err := k8sclient.NewClientFromKubeConfig(kubeConfig)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create target cluster client")
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      storageClassConfigMapName,
+			Namespace: ark.GetChartConfig().Namespace,
+			Labels: map[string]string{
+				pluginConfigLabelKey:  "",
+				storageClassPluginKey: restoreItemActionKind,
+			},
+		},
+		Data: mapping,
+	}
+
+	configMaps := client.CoreV1().ConfigMaps(configMap.Namespace)
+
+	_, err = configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+
+	return errors.WrapIf(err, "failed to configure storage class mapping")
+}
@@ -0,0 +1,79 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/ark"
+)
+
+const RemoveRestoreServiceActivityName = "ark-migration-remove-restore-service"
+
+// RemoveRestoreServiceActivity removes ARK deployed in restore mode from the target cluster.
+type RemoveRestoreServiceActivity struct {
+	activities
+
+	helmService ark.HelmService
+}
+
+type RemoveRestoreServiceActivityInput struct {
+	OrganizationID uint
+	ClusterID      uint
+}
+
+func NewRemoveRestoreServiceActivity(
+	clusters ClusterGetter,
+	db *gorm.DB,
+	helmService ark.HelmService,
+	logger logrus.FieldLogger,
+) RemoveRestoreServiceActivity {
+	return RemoveRestoreServiceActivity{
+		activities: activities{
+			clusters: clusters,
+			db:       db,
+			logger:   logger,
+		},
+		helmService: helmService,
+	}
+}
+
+func (a RemoveRestoreServiceActivity) Execute(ctx context.Context, input RemoveRestoreServiceActivityInput) error {
+	svc, _, err := a.getARKService(ctx, input.OrganizationID, input.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	deployment, err := svc.GetDeploymentsService().GetActiveDeployment()
+	if gorm.IsRecordNotFoundError(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.WrapIf(err, "failed to get backup service deployment of the target cluster")
+	}
+
+	// never remove a regular backup service
+	if !deployment.RestoreMode {
+		return nil
+	}
+
+	err = svc.GetDeploymentsService().Remove(a.helmService)
+
+	return errors.WrapIf(err, "failed to remove restore service from the target cluster")
+}
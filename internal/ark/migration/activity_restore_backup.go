@@ -0,0 +1,140 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"strings"
+
+	"emperror.dev/errors"
+	arkAPI "github.com/heptio/ark/pkg/apis/ark/v1"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/banzaicloud/pipeline/internal/ark/api"
+	"github.com/banzaicloud/pipeline/internal/ark/sync"
+)
+
+const RestoreBackupActivityName = "ark-migration-restore-backup"
+
+// RestoreBackupActivity restores the migration backup to the target cluster and waits for it to complete.
+type RestoreBackupActivity struct {
+	activities
+}
+
+type RestoreBackupActivityInput struct {
+	OrganizationID uint
+	ClusterID      uint
+	BackupName     string
+	Options        Options
+}
+
+func NewRestoreBackupActivity(clusters ClusterGetter, db *gorm.DB, logger logrus.FieldLogger) RestoreBackupActivity {
+	return RestoreBackupActivity{
+		activities: activities{
+			clusters: clusters,
+			db:       db,
+			logger:   logger,
+		},
+	}
+}
+
+func (a RestoreBackupActivity) Execute(ctx context.Context, input RestoreBackupActivityInput) error {
+	svc, org, err := a.getARKService(ctx, input.OrganizationID, input.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	client, err := svc.GetDeploymentsService().GetClient()
+	if err != nil {
+		return errors.WrapIf(err, "failed to get ARK client")
+	}
+
+	restoreName, err := a.findRestore(client.ListRestores, input.BackupName)
+	if err != nil {
+		return err
+	}
+
+	// the restore might have been created by a previous attempt
+	if restoreName == "" {
+		restore, err := svc.GetRestoresService().Create(api.CreateRestoreRequest{
+			BackupName: input.BackupName,
+			Labels:     labels.Set{migratedByLabelKey: migratedByLabelValue},
+			Options: api.RestoreOptions{
+				IncludedNamespaces: input.Options.IncludedNamespaces,
+				ExcludedNamespaces: append([]string{restoreExcludedNamespace}, input.Options.ExcludedNamespaces...),
+				NamespaceMapping:   input.Options.NamespaceMapping,
+			},
+		})
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to create restore", "backup", input.BackupName)
+		}
+
+		restoreName = restore.Name
+	}
+
+	var restore *arkAPI.Restore
+
+	err = poll(ctx, func() (bool, string, error) {
+		restore, err = client.GetRestoreByName(restoreName)
+		if err != nil {
+			return false, "", errors.WrapIfWithDetails(err, "failed to get restore", "restore", restoreName)
+		}
+
+		switch restore.Status.Phase {
+		case arkAPI.RestorePhaseCompleted:
+			return true, string(restore.Status.Phase), nil
+		case arkAPI.RestorePhaseFailedValidation:
+			return false, "", newFailedError(
+				"restore %s failed validation: %s",
+				restoreName,
+				strings.Join(restore.Status.ValidationErrors, ", "),
+			)
+		default:
+			return false, string(restore.Status.Phase), nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = sync.NewRestoresSyncService(org, a.db, a.logger).SyncRestoresForCluster(svc.GetCluster())
+	if err != nil {
+		return errors.WrapIf(err, "failed to sync restores of the target cluster")
+	}
+
+	if restore.Status.Errors > 0 {
+		return newFailedError("restore %s completed with %d errors", restoreName, restore.Status.Errors)
+	}
+
+	return nil
+}
+
+// findRestore returns the name of the restore created for a backup (if any).
+func (a RestoreBackupActivity) findRestore(listRestores func() (*arkAPI.RestoreList, error), backupName string) (string, error) {
+	restores, err := listRestores()
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to list restores")
+	}
+
+	for _, restore := range restores.Items {
+		if restore.Spec.BackupName == backupName && restore.Labels[migratedByLabelKey] == migratedByLabelValue {
+			return restore.Name, nil
+		}
+	}
+
+	return "", nil
+}
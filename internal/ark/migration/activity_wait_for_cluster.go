@@ -0,0 +1,66 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+const WaitForClusterActivityName = "ark-migration-wait-for-cluster"
+
+// WaitForClusterActivity waits for the target cluster to be ready, so a migration can target a cluster that is still being provisioned.
+type WaitForClusterActivity struct {
+	activities
+}
+
+type WaitForClusterActivityInput struct {
+	OrganizationID uint
+	ClusterID      uint
+}
+
+func NewWaitForClusterActivity(clusters ClusterGetter) WaitForClusterActivity {
+	return WaitForClusterActivity{
+		activities: activities{
+			clusters: clusters,
+		},
+	}
+}
+
+func (a WaitForClusterActivity) Execute(ctx context.Context, input WaitForClusterActivityInput) error {
+	return poll(ctx, func() (bool, string, error) {
+		cluster, err := a.clusters.GetClusterByID(ctx, input.OrganizationID, input.ClusterID)
+		if err != nil {
+			return false, "", errors.WrapIfWithDetails(err, "failed to get target cluster", "clusterId", input.ClusterID)
+		}
+
+		status, err := cluster.GetStatus()
+		if err != nil {
+			return false, "", errors.WrapIfWithDetails(err, "failed to get target cluster status", "clusterId", input.ClusterID)
+		}
+
+		switch status.Status {
+		case pkgCluster.Running:
+			return true, status.Status, nil
+		case pkgCluster.Error, pkgCluster.Deleting:
+			return false, "", newFailedError("target cluster is in %s state: %s", status.Status, status.StatusMessage)
+		default:
+			return false, status.Status, nil
+		}
+	})
+}
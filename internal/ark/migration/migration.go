@@ -0,0 +1,126 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/ark"
+	"github.com/banzaicloud/pipeline/internal/ark/api"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+const (
+	// pollInterval is the time between two status checks of long running operations
+	pollInterval = 15 * time.Second
+
+	// restoreExcludedNamespace is never restored to the target cluster
+	restoreExcludedNamespace = "kube-system"
+
+	// migratedByLabelKey marks backups and restores created by a migration
+	migratedByLabelKey   = "migrated-by"
+	migratedByLabelValue = "pipeline"
+
+	// failedErrorReason marks migration errors which should not be retried
+	failedErrorReason = "ark-migration-failed"
+)
+
+// ClusterGetter returns clusters of an organization.
+type ClusterGetter interface {
+	GetClusterByID(ctx context.Context, organizationID uint, clusterID uint) (api.Cluster, error)
+}
+
+// activities contains the dependencies shared by the migration activities.
+type activities struct {
+	clusters ClusterGetter
+	db       *gorm.DB
+	logger   logrus.FieldLogger
+}
+
+// getARKService returns the ARK service of a cluster along with the cluster's organization.
+func (a activities) getARKService(ctx context.Context, organizationID uint, clusterID uint) (*ark.Service, *auth.Organization, error) {
+	org, err := auth.GetOrganizationById(organizationID)
+	if err != nil {
+		return nil, nil, errors.WrapIfWithDetails(err, "failed to get organization", "organizationId", organizationID)
+	}
+
+	cluster, err := a.clusters.GetClusterByID(ctx, organizationID, clusterID)
+	if err != nil {
+		return nil, nil, errors.WrapIfWithDetails(err, "failed to get cluster", "clusterId", clusterID)
+	}
+
+	logger := a.logger.WithFields(logrus.Fields{
+		"organization": organizationID,
+		"cluster":      clusterID,
+	})
+
+	return ark.NewARKService(org, cluster, a.db, logger), org, nil
+}
+
+// poll calls check periodically until it reports completion or fails, recording heartbeats in between.
+func poll(ctx context.Context, check func() (bool, string, error)) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		done, status, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		activity.RecordHeartbeat(ctx, status)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// newFailedError returns an error that fails the migration without retries.
+func newFailedError(message string, details ...interface{}) error {
+	return cadence.NewCustomError(failedErrorReason, fmt.Sprintf(message, details...))
+}
+
+// GetBackupName returns the name of the backup taken for a migration.
+func GetBackupName(sourceClusterID uint, targetClusterID uint, now time.Time) string {
+	return fmt.Sprintf("migration-%d-to-%d-%s", sourceClusterID, targetClusterID, now.Format("20060102150405"))
+}
+
+// StartMigration starts a cluster migration workflow and returns its ID which is also the ID of the migration process.
+func StartMigration(ctx context.Context, workflowClient client.Client, input MigrateClusterWorkflowInput) (string, error) {
+	workflowOptions := client.StartWorkflowOptions{
+		ID:                           input.BackupName,
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 6 * time.Hour,
+	}
+
+	exec, err := workflowClient.StartWorkflow(ctx, workflowOptions, MigrateClusterWorkflowName, input)
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to start cluster migration workflow", "backup", input.BackupName)
+	}
+
+	return exec.ID, nil
+}
@@ -0,0 +1,178 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+// MigrateClusterWorkflowName is the name the cluster migration workflow is registered under
+const MigrateClusterWorkflowName = "ark-migrate-cluster"
+
+// MigrateClusterWorkflowInput describes a migration of workloads from a source cluster to a target cluster
+type MigrateClusterWorkflowInput struct {
+	OrganizationID  uint
+	SourceClusterID uint
+	TargetClusterID uint
+
+	BackupName string
+	Options    Options
+}
+
+// Options narrows down and remaps the migrated resources
+type Options struct {
+	IncludedNamespaces []string
+	ExcludedNamespaces []string
+
+	// NamespaceMapping maps source namespaces to target namespaces
+	NamespaceMapping map[string]string
+
+	// StorageClassMapping maps source storage classes to target storage classes
+	StorageClassMapping map[string]string
+}
+
+// MigrateClusterWorkflow takes a backup of a source cluster and restores it to a target cluster
+type MigrateClusterWorkflow struct {
+	processLogger processlog.ProcessLogger
+}
+
+// NewMigrateClusterWorkflow returns a new MigrateClusterWorkflow.
+func NewMigrateClusterWorkflow(processLogger processlog.ProcessLogger) MigrateClusterWorkflow {
+	return MigrateClusterWorkflow{
+		processLogger: processLogger,
+	}
+}
+
+func (w MigrateClusterWorkflow) Register() {
+	workflow.RegisterWithOptions(w.Execute, workflow.RegisterOptions{Name: MigrateClusterWorkflowName})
+}
+
+func (w MigrateClusterWorkflow) Execute(ctx workflow.Context, input MigrateClusterWorkflowInput) (err error) {
+	activityOptions := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 1.5,
+			MaximumInterval:    2 * time.Minute,
+			MaximumAttempts:    5,
+
+			NonRetriableErrorReasons: []string{failedErrorReason},
+		},
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, activityOptions)
+
+	// waiting activities report their progress through heartbeats
+	waitCtx := workflow.WithStartToCloseTimeout(ctx, 2*time.Hour)
+	waitCtx = workflow.WithHeartbeatTimeout(waitCtx, 2*pollInterval)
+
+	sourceClusterID := brn.New(input.OrganizationID, brn.ClusterResourceType, fmt.Sprint(input.SourceClusterID))
+
+	process := w.processLogger.StartProcess(ctx, sourceClusterID.String())
+	defer func() {
+		process.Finish(ctx, err)
+	}()
+
+	{
+		activityInput := WaitForClusterActivityInput{
+			OrganizationID: input.OrganizationID,
+			ClusterID:      input.TargetClusterID,
+		}
+
+		processActivity := process.StartActivity(waitCtx, WaitForClusterActivityName)
+		err = workflow.ExecuteActivity(waitCtx, WaitForClusterActivityName, activityInput).Get(ctx, nil)
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		activityInput := CreateBackupActivityInput{
+			OrganizationID: input.OrganizationID,
+			ClusterID:      input.SourceClusterID,
+			BackupName:     input.BackupName,
+			Options:        input.Options,
+		}
+
+		processActivity := process.StartActivity(waitCtx, CreateBackupActivityName)
+		err = workflow.ExecuteActivity(waitCtx, CreateBackupActivityName, activityInput).Get(ctx, nil)
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		activityInput := DeployRestoreServiceActivityInput{
+			OrganizationID:      input.OrganizationID,
+			SourceClusterID:     input.SourceClusterID,
+			TargetClusterID:     input.TargetClusterID,
+			StorageClassMapping: input.Options.StorageClassMapping,
+		}
+
+		processActivity := process.StartActivity(ctx, DeployRestoreServiceActivityName)
+		err = workflow.ExecuteActivity(ctx, DeployRestoreServiceActivityName, activityInput).Get(ctx, nil)
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			return err
+		}
+	}
+
+	defer func() {
+		ctx := ctx
+		if cadence.IsCanceledError(err) {
+			ctx, _ = workflow.NewDisconnectedContext(ctx)
+		}
+
+		activityInput := RemoveRestoreServiceActivityInput{
+			OrganizationID: input.OrganizationID,
+			ClusterID:      input.TargetClusterID,
+		}
+
+		processActivity := process.StartActivity(ctx, RemoveRestoreServiceActivityName)
+		removeErr := workflow.ExecuteActivity(ctx, RemoveRestoreServiceActivityName, activityInput).Get(ctx, nil)
+		processActivity.Finish(ctx, removeErr)
+		if err == nil {
+			err = removeErr
+		}
+	}()
+
+	{
+		activityInput := RestoreBackupActivityInput{
+			OrganizationID: input.OrganizationID,
+			ClusterID:      input.TargetClusterID,
+			BackupName:     input.BackupName,
+			Options:        input.Options,
+		}
+
+		processActivity := process.StartActivity(waitCtx, RestoreBackupActivityName)
+		err = workflow.ExecuteActivity(waitCtx, RestoreBackupActivityName, activityInput).Get(ctx, nil)
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
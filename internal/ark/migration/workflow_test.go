@@ -0,0 +1,188 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+// nolint: gochecknoinits
+func init() {
+	activity.RegisterWithOptions(WaitForClusterActivity{}.Execute, activity.RegisterOptions{Name: WaitForClusterActivityName})
+	activity.RegisterWithOptions(CreateBackupActivity{}.Execute, activity.RegisterOptions{Name: CreateBackupActivityName})
+	activity.RegisterWithOptions(DeployRestoreServiceActivity{}.Execute, activity.RegisterOptions{Name: DeployRestoreServiceActivityName})
+	activity.RegisterWithOptions(RestoreBackupActivity{}.Execute, activity.RegisterOptions{Name: RestoreBackupActivityName})
+	activity.RegisterWithOptions(RemoveRestoreServiceActivity{}.Execute, activity.RegisterOptions{Name: RemoveRestoreServiceActivityName})
+}
+
+type nopProcessLogger struct{}
+
+func (nopProcessLogger) StartProcess(ctx workflow.Context, resourceID string) processlog.Process {
+	return nopProcess{}
+}
+
+type nopProcess struct{}
+
+func (nopProcess) Finish(ctx workflow.Context, err error) {}
+
+func (nopProcess) StartActivity(ctx workflow.Context, typ string) processlog.Activity {
+	return nopProcess{}
+}
+
+// nolint: gochecknoglobals
+var testMigrationInput = MigrateClusterWorkflowInput{
+	OrganizationID:  1,
+	SourceClusterID: 2,
+	TargetClusterID: 3,
+	BackupName:      "migration-2-to-3-20200901123045",
+	Options: Options{
+		IncludedNamespaces:  []string{"app"},
+		NamespaceMapping:    map[string]string{"app": "app-migrated"},
+		StorageClassMapping: map[string]string{"gp2": "standard"},
+	},
+}
+
+type MigrateClusterWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestMigrateClusterWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrateClusterWorkflowTestSuite))
+}
+
+func (s *MigrateClusterWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+
+	wf := NewMigrateClusterWorkflow(nopProcessLogger{})
+	workflow.RegisterWithOptions(wf.Execute, workflow.RegisterOptions{Name: s.T().Name()})
+}
+
+func (s *MigrateClusterWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+func (s *MigrateClusterWorkflowTestSuite) onWaitForCluster() *testsuite.MockCallWrapper {
+	return s.env.OnActivity(WaitForClusterActivityName, mock.Anything, WaitForClusterActivityInput{
+		OrganizationID: 1,
+		ClusterID:      3,
+	})
+}
+
+func (s *MigrateClusterWorkflowTestSuite) onCreateBackup() *testsuite.MockCallWrapper {
+	return s.env.OnActivity(CreateBackupActivityName, mock.Anything, CreateBackupActivityInput{
+		OrganizationID: 1,
+		ClusterID:      2,
+		BackupName:     testMigrationInput.BackupName,
+		Options:        testMigrationInput.Options,
+	})
+}
+
+func (s *MigrateClusterWorkflowTestSuite) onDeployRestoreService() *testsuite.MockCallWrapper {
+	return s.env.OnActivity(DeployRestoreServiceActivityName, mock.Anything, DeployRestoreServiceActivityInput{
+		OrganizationID:      1,
+		SourceClusterID:     2,
+		TargetClusterID:     3,
+		StorageClassMapping: testMigrationInput.Options.StorageClassMapping,
+	})
+}
+
+func (s *MigrateClusterWorkflowTestSuite) onRestoreBackup() *testsuite.MockCallWrapper {
+	return s.env.OnActivity(RestoreBackupActivityName, mock.Anything, RestoreBackupActivityInput{
+		OrganizationID: 1,
+		ClusterID:      3,
+		BackupName:     testMigrationInput.BackupName,
+		Options:        testMigrationInput.Options,
+	})
+}
+
+func (s *MigrateClusterWorkflowTestSuite) onRemoveRestoreService() *testsuite.MockCallWrapper {
+	return s.env.OnActivity(RemoveRestoreServiceActivityName, mock.Anything, RemoveRestoreServiceActivityInput{
+		OrganizationID: 1,
+		ClusterID:      3,
+	})
+}
+
+func (s *MigrateClusterWorkflowTestSuite) Test_Success() {
+	s.onWaitForCluster().Return(nil).Once()
+	s.onCreateBackup().Return(nil).Once()
+	s.onDeployRestoreService().Return(nil).Once()
+	s.onRestoreBackup().Return(nil).Once()
+	s.onRemoveRestoreService().Return(nil).Once()
+
+	s.env.ExecuteWorkflow(s.T().Name(), testMigrationInput)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+func (s *MigrateClusterWorkflowTestSuite) Test_RestoreFailure_RemovesRestoreService() {
+	s.onWaitForCluster().Return(nil).Once()
+	s.onCreateBackup().Return(nil).Once()
+	s.onDeployRestoreService().Return(nil).Once()
+	s.onRestoreBackup().Return(newFailedError("restore %s finished in phase %s", testMigrationInput.BackupName, "Failed")).Once()
+	s.onRemoveRestoreService().Return(nil).Once()
+
+	s.env.ExecuteWorkflow(s.T().Name(), testMigrationInput)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+}
+
+func (s *MigrateClusterWorkflowTestSuite) Test_RemoveRestoreServiceFailure() {
+	s.onWaitForCluster().Return(nil).Once()
+	s.onCreateBackup().Return(nil).Once()
+	s.onDeployRestoreService().Return(nil).Once()
+	s.onRestoreBackup().Return(nil).Once()
+	s.onRemoveRestoreService().Return(newFailedError("failed to remove restore service")).Once()
+
+	s.env.ExecuteWorkflow(s.T().Name(), testMigrationInput)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+}
+
+func (s *MigrateClusterWorkflowTestSuite) Test_BackupFailure() {
+	s.onWaitForCluster().Return(nil).Once()
+	s.onCreateBackup().Return(newFailedError("backup %s finished in phase %s", testMigrationInput.BackupName, "Failed")).Once()
+
+	s.env.ExecuteWorkflow(s.T().Name(), testMigrationInput)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+	s.env.AssertNotCalled(s.T(), DeployRestoreServiceActivityName, mock.Anything, mock.Anything)
+	s.env.AssertNotCalled(s.T(), RestoreBackupActivityName, mock.Anything, mock.Anything)
+	s.env.AssertNotCalled(s.T(), RemoveRestoreServiceActivityName, mock.Anything, mock.Anything)
+}
+
+func (s *MigrateClusterWorkflowTestSuite) Test_TargetClusterNotReady() {
+	s.onWaitForCluster().Return(newFailedError("target cluster is in %s state", "ERROR")).Once()
+
+	s.env.ExecuteWorkflow(s.T().Name(), testMigrationInput)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+	s.env.AssertNotCalled(s.T(), CreateBackupActivityName, mock.Anything, mock.Anything)
+}
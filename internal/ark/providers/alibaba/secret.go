@@ -0,0 +1,31 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"fmt"
+
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// GetSecret returns the credentials file contents expected by the Alibaba Cloud plugin.
+func GetSecret(secret *secret.SecretItemResponse) (string, error) {
+	return fmt.Sprintf(
+		"ALIBABA_CLOUD_ACCESS_KEY_ID=%s\nALIBABA_CLOUD_ACCESS_KEY_SECRET=%s\n",
+		secret.Values[secrettype.AlibabaAccessKeyId],
+		secret.Values[secrettype.AlibabaSecretAccessKey],
+	), nil
+}
@@ -0,0 +1,41 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"testing"
+
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+func TestGetSecret(t *testing.T) {
+	s := &secret.SecretItemResponse{
+		Values: map[string]string{
+			secrettype.AlibabaAccessKeyId:     "access-key-id",
+			secrettype.AlibabaSecretAccessKey: "secret-access-key",
+		},
+	}
+
+	contents, err := GetSecret(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "ALIBABA_CLOUD_ACCESS_KEY_ID=access-key-id\nALIBABA_CLOUD_ACCESS_KEY_SECRET=secret-access-key\n"
+	if contents != expected {
+		t.Errorf("expected %q, got %q", expected, contents)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"fmt"
+)
+
+// Oracle buckets are accessed through the Amazon S3 Compatibility API,
+// which authenticates with Customer Secret Keys stored in Amazon type secrets.
+const (
+	// BackupStorageProvider is a config value for ARK
+	BackupStorageProvider = "aws"
+)
+
+const endpointTemplate = "https://%s.compat.objectstorage.%s.oraclecloud.com"
+
+// GetEndpoint returns the Amazon S3 Compatibility API endpoint of an object storage namespace in a region.
+func GetEndpoint(namespace string, region string) string {
+	return fmt.Sprintf(endpointTemplate, namespace, region)
+}
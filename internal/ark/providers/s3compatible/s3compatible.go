@@ -0,0 +1,64 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3compatible
+
+import (
+	"crypto/x509"
+	"net/url"
+
+	"emperror.dev/errors"
+)
+
+// Provider identifies buckets in S3 compatible object stores (eg. MinIO)
+const Provider = "s3compatible"
+
+const (
+	// BackupStorageProvider is a config value for ARK
+	BackupStorageProvider = "aws"
+
+	// DefaultRegion is used for buckets without a location
+	DefaultRegion = "us-east-1"
+)
+
+// GetRegion returns the region of a bucket in an S3 compatible object store.
+func GetRegion(location string) string {
+	if location == "" {
+		return DefaultRegion
+	}
+
+	return location
+}
+
+// ValidateEndpoint checks the endpoint URL and the optional PEM encoded CA certificate of an S3 compatible object store.
+func ValidateEndpoint(endpoint string, caCert string) error {
+	if endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "invalid endpoint", "endpoint", endpoint)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NewWithDetails("endpoint must be an absolute http(s) URL", "endpoint", endpoint)
+	}
+
+	if caCert != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(caCert)) {
+		return errors.New("CA certificate must contain at least one PEM encoded certificate")
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3compatible
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestValidateEndpoint(t *testing.T) {
+	caCert := generateCACert(t)
+
+	tests := map[string]struct {
+		endpoint string
+		caCert   string
+		valid    bool
+	}{
+		"https": {
+			endpoint: "https://minio.example.com:9000",
+			valid:    true,
+		},
+		"http": {
+			endpoint: "http://10.0.0.1:9000",
+			valid:    true,
+		},
+		"with CA": {
+			endpoint: "https://minio.example.com",
+			caCert:   caCert,
+			valid:    true,
+		},
+		"empty": {
+			endpoint: "",
+		},
+		"relative": {
+			endpoint: "minio.example.com",
+		},
+		"unsupported scheme": {
+			endpoint: "ftp://minio.example.com",
+		},
+		"invalid CA": {
+			endpoint: "https://minio.example.com",
+			caCert:   "not a certificate",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			err := ValidateEndpoint(test.endpoint, test.caCert)
+			if test.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestGetRegion(t *testing.T) {
+	if got := GetRegion(""); got != DefaultRegion {
+		t.Errorf("expected default region %q, got %q", DefaultRegion, got)
+	}
+
+	if got := GetRegion("eu-west-1"); got != "eu-west-1" {
+		t.Errorf("expected region %q, got %q", "eu-west-1", got)
+	}
+}
+
+func generateCACert(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
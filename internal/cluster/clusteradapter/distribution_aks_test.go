@@ -0,0 +1,132 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusteradapter
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/aks"
+)
+
+func TestAKSService_CreateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	nodePool := aks.NewNodePool{
+		Name:         "pool0",
+		Size:         2,
+		InstanceType: "Standard_D2s_v3",
+	}
+
+	aksService := new(aks.MockService)
+	aksService.On("CreateNodePool", ctx, uint(1), nodePool).Return(nil)
+
+	service := NewAKSService(aksService)
+
+	err := service.CreateNodePool(ctx, 1, cluster.NewRawNodePool{
+		"name":         "pool0",
+		"size":         2,
+		"instanceType": "Standard_D2s_v3",
+	})
+	require.NoError(t, err)
+
+	aksService.AssertExpectations(t)
+}
+
+func TestAKSService_UpdateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	size := 3
+	instanceType := "Standard_D4s_v3"
+
+	aksService := new(aks.MockService)
+	aksService.On(
+		"UpdateNodePool",
+		ctx,
+		uint(1),
+		"pool0",
+		aks.NodePoolUpdate{
+			Size:         &size,
+			InstanceType: &instanceType,
+		},
+	).Return("process-id", nil)
+
+	service := NewAKSService(aksService)
+
+	processID, err := service.UpdateNodePool(ctx, 1, "pool0", cluster.RawNodePoolUpdate{
+		"size":         3,
+		"instanceType": "Standard_D4s_v3",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "process-id", processID)
+
+	aksService.AssertExpectations(t)
+}
+
+func TestAKSService_DeleteNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	aksService := new(aks.MockService)
+	aksService.On("DeleteNodePool", ctx, uint(1), "pool0").Return(nil)
+
+	service := NewAKSService(aksService)
+
+	deleted, err := service.DeleteNodePool(ctx, 1, "pool0")
+	require.NoError(t, err)
+
+	assert.False(t, deleted)
+
+	aksService.AssertExpectations(t)
+}
+
+func TestAKSService_ListNodePools(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		nodePools := []aks.NodePool{
+			{
+				Name:         "pool0",
+				Size:         2,
+				InstanceType: "Standard_D2s_v3",
+			},
+		}
+
+		aksService := new(aks.MockService)
+		aksService.On("ListNodePools", ctx, uint(1)).Return(nodePools, nil)
+
+		service := NewAKSService(aksService)
+
+		nodePoolList, err := service.ListNodePools(ctx, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, cluster.RawNodePoolList{nodePools[0]}, nodePoolList)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		aksService := new(aks.MockService)
+		aksService.On("ListNodePools", ctx, uint(1)).Return(nil, errors.NewPlain("error"))
+
+		service := NewAKSService(aksService)
+
+		_, err := service.ListNodePools(ctx, 1)
+		require.Error(t, err)
+	})
+}
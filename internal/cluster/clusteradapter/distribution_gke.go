@@ -0,0 +1,92 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusteradapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke"
+)
+
+// NewGKEService returns a new GKE distribution service.
+func NewGKEService(service gke.Service) cluster.Service {
+	return gkeService{
+		service: service,
+	}
+}
+
+type gkeService struct {
+	service gke.Service
+}
+
+func (s gkeService) UpdateCluster(ctx context.Context, clusterIdentifier cluster.Identifier, rawUpdate cluster.ClusterUpdate) error {
+	return errors.WithStack(cluster.NotSupportedDistributionError{
+		ID:           clusterIdentifier.ClusterID,
+		Cloud:        "google",
+		Distribution: "gke",
+
+		Message: "the cluster API does not support this distribution yet",
+	})
+}
+
+func (s gkeService) DeleteCluster(ctx context.Context, clusterIdentifier cluster.Identifier, options cluster.DeleteClusterOptions) (deleted bool, err error) {
+	panic("implement me")
+}
+
+func (s gkeService) CreateNodePool(ctx context.Context, clusterID uint, rawNodePool cluster.NewRawNodePool) error {
+	var nodePool gke.NewNodePool
+
+	err := mapstructure.Decode(rawNodePool, &nodePool)
+	if err != nil {
+		return errors.WithStack(cluster.NewValidationError("invalid node pool creation request", []string{err.Error()}))
+	}
+
+	return s.service.CreateNodePool(ctx, clusterID, nodePool)
+}
+
+func (s gkeService) UpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, rawNodePoolUpdate cluster.RawNodePoolUpdate) (string, error) {
+	var nodePoolUpdate gke.NodePoolUpdate
+
+	err := mapstructure.Decode(rawNodePoolUpdate, &nodePoolUpdate)
+	if err != nil {
+		return "", errors.WithStack(cluster.NewValidationError("invalid node pool update request", []string{err.Error()}))
+	}
+
+	return s.service.UpdateNodePool(ctx, clusterID, nodePoolName, nodePoolUpdate)
+}
+
+// DeleteNodePool deletes a node pool asynchronously, so it never reports the node pool as deleted.
+func (s gkeService) DeleteNodePool(ctx context.Context, clusterID uint, name string) (deleted bool, err error) {
+	return false, s.service.DeleteNodePool(ctx, clusterID, name)
+}
+
+// ListNodePools lists node pools from a cluster.
+func (s gkeService) ListNodePools(ctx context.Context, clusterID uint) (nodePoolList cluster.RawNodePoolList, err error) {
+	nodePools, err := s.service.ListNodePools(ctx, clusterID)
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "listing node pools through GKE service failed", "clusterID", clusterID)
+	}
+
+	nodePoolList = make([]interface{}, 0, len(nodePools))
+	for _, nodePool := range nodePools {
+		nodePoolList = append(nodePoolList, nodePool)
+	}
+
+	return nodePoolList, nil
+}
@@ -0,0 +1,139 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusteradapter
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke"
+)
+
+func TestGKEService_CreateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	nodePool := gke.NewNodePool{
+		Name:         "pool0",
+		Size:         2,
+		InstanceType: "n1-standard-2",
+	}
+
+	gkeService := new(gke.MockService)
+	gkeService.On("CreateNodePool", ctx, uint(1), nodePool).Return(nil)
+
+	service := NewGKEService(gkeService)
+
+	err := service.CreateNodePool(ctx, 1, cluster.NewRawNodePool{
+		"name":         "pool0",
+		"size":         2,
+		"instanceType": "n1-standard-2",
+	})
+	require.NoError(t, err)
+
+	gkeService.AssertExpectations(t)
+}
+
+func TestGKEService_UpdateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	size := 3
+
+	gkeService := new(gke.MockService)
+	gkeService.On(
+		"UpdateNodePool",
+		ctx,
+		uint(1),
+		"pool0",
+		gke.NodePoolUpdate{
+			Size: &size,
+			Autoscaling: &gke.Autoscaling{
+				Enabled: true,
+				MinSize: 1,
+				MaxSize: 5,
+			},
+		},
+	).Return("process-id", nil)
+
+	service := NewGKEService(gkeService)
+
+	processID, err := service.UpdateNodePool(ctx, 1, "pool0", cluster.RawNodePoolUpdate{
+		"size": 3,
+		"autoscaling": map[string]interface{}{
+			"enabled": true,
+			"minSize": 1,
+			"maxSize": 5,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "process-id", processID)
+
+	gkeService.AssertExpectations(t)
+}
+
+func TestGKEService_DeleteNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	gkeService := new(gke.MockService)
+	gkeService.On("DeleteNodePool", ctx, uint(1), "pool0").Return(nil)
+
+	service := NewGKEService(gkeService)
+
+	deleted, err := service.DeleteNodePool(ctx, 1, "pool0")
+	require.NoError(t, err)
+
+	assert.False(t, deleted)
+
+	gkeService.AssertExpectations(t)
+}
+
+func TestGKEService_ListNodePools(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		nodePools := []gke.NodePool{
+			{
+				Name:         "pool0",
+				Size:         2,
+				InstanceType: "n1-standard-2",
+			},
+		}
+
+		gkeService := new(gke.MockService)
+		gkeService.On("ListNodePools", ctx, uint(1)).Return(nodePools, nil)
+
+		service := NewGKEService(gkeService)
+
+		nodePoolList, err := service.ListNodePools(ctx, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, cluster.RawNodePoolList{nodePools[0]}, nodePoolList)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		gkeService := new(gke.MockService)
+		gkeService.On("ListNodePools", ctx, uint(1)).Return(nil, errors.NewPlain("error"))
+
+		service := NewGKEService(gkeService)
+
+		_, err := service.ListNodePools(ctx, 1)
+		require.Error(t, err)
+	})
+}
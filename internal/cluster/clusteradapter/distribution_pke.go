@@ -0,0 +1,134 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusteradapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// NewPKEService returns a new PKE distribution service
+// that delegates requests to cloud specific PKE services.
+func NewPKEService(clusters cluster.Store, services map[string]cluster.Service) cluster.Service {
+	return pkeService{
+		clusters: clusters,
+		services: services,
+	}
+}
+
+type pkeService struct {
+	clusters cluster.Store
+	services map[string]cluster.Service
+}
+
+func (s pkeService) getClusterByIdentifier(ctx context.Context, clusterIdentifier cluster.Identifier) (cluster.Cluster, error) {
+	if clusterIdentifier.ClusterName != "" {
+		return s.clusters.GetClusterByName(ctx, clusterIdentifier.OrganizationID, clusterIdentifier.ClusterName)
+	}
+
+	return s.clusters.GetCluster(ctx, clusterIdentifier.ClusterID)
+}
+
+func (s pkeService) getCloudService(ctx context.Context, clusterID uint) (cluster.Service, error) {
+	c, err := s.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getService(c)
+}
+
+func (s pkeService) getService(c cluster.Cluster) (cluster.Service, error) {
+	service, ok := s.services[c.Cloud]
+	if !ok {
+		return nil, errors.WithStack(cluster.NotSupportedDistributionError{
+			ID:           c.ID,
+			Cloud:        c.Cloud,
+			Distribution: c.Distribution,
+
+			Message: "the distribution is not supported on this cloud yet",
+		})
+	}
+
+	return service, nil
+}
+
+func (s pkeService) UpdateCluster(ctx context.Context, clusterIdentifier cluster.Identifier, rawUpdate cluster.ClusterUpdate) error {
+	c, err := s.getClusterByIdentifier(ctx, clusterIdentifier)
+	if err != nil {
+		return err
+	}
+
+	service, err := s.getService(c)
+	if err != nil {
+		return err
+	}
+
+	return service.UpdateCluster(ctx, clusterIdentifier, rawUpdate)
+}
+
+func (s pkeService) DeleteCluster(ctx context.Context, clusterIdentifier cluster.Identifier, options cluster.DeleteClusterOptions) (bool, error) {
+	c, err := s.getClusterByIdentifier(ctx, clusterIdentifier)
+	if err != nil {
+		return false, err
+	}
+
+	service, err := s.getService(c)
+	if err != nil {
+		return false, err
+	}
+
+	return service.DeleteCluster(ctx, clusterIdentifier, options)
+}
+
+func (s pkeService) CreateNodePool(ctx context.Context, clusterID uint, rawNodePool cluster.NewRawNodePool) error {
+	service, err := s.getCloudService(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return service.CreateNodePool(ctx, clusterID, rawNodePool)
+}
+
+func (s pkeService) UpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, rawNodePoolUpdate cluster.RawNodePoolUpdate) (string, error) {
+	service, err := s.getCloudService(ctx, clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	return service.UpdateNodePool(ctx, clusterID, nodePoolName, rawNodePoolUpdate)
+}
+
+func (s pkeService) DeleteNodePool(ctx context.Context, clusterID uint, name string) (bool, error) {
+	service, err := s.getCloudService(ctx, clusterID)
+	if err != nil {
+		return false, err
+	}
+
+	return service.DeleteNodePool(ctx, clusterID, name)
+}
+
+// ListNodePools lists node pools from a cluster.
+func (s pkeService) ListNodePools(ctx context.Context, clusterID uint) (cluster.RawNodePoolList, error) {
+	service, err := s.getCloudService(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.ListNodePools(ctx, clusterID)
+}
@@ -0,0 +1,139 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusteradapter
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
+)
+
+func TestPKEAWSService_CreateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	nodePool := pkeaws.NewNodePool{
+		Name:         "pool0",
+		Size:         2,
+		InstanceType: "t2.medium",
+		Image:        "ami-xxx",
+	}
+
+	pkeAWSService := new(pkeaws.MockService)
+	pkeAWSService.On("CreateNodePool", ctx, uint(1), nodePool).Return(nil)
+
+	service := NewPKEAWSService(pkeAWSService)
+
+	err := service.CreateNodePool(ctx, 1, cluster.NewRawNodePool{
+		"name":         "pool0",
+		"size":         2,
+		"instanceType": "t2.medium",
+		"image":        "ami-xxx",
+	})
+	require.NoError(t, err)
+
+	pkeAWSService.AssertExpectations(t)
+}
+
+func TestPKEAWSService_UpdateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	pkeAWSService := new(pkeaws.MockService)
+	pkeAWSService.On(
+		"UpdateNodePool",
+		ctx,
+		uint(1),
+		"pool0",
+		pkeaws.NodePoolUpdate{
+			VolumeSize: 60,
+			Image:      "ami-xxx",
+			Options: pkeaws.NodePoolUpdateOptions{
+				MaxBatchSize: 2,
+			},
+		},
+	).Return("process-id", nil)
+
+	service := NewPKEAWSService(pkeAWSService)
+
+	processID, err := service.UpdateNodePool(ctx, 1, "pool0", cluster.RawNodePoolUpdate{
+		"volumeSize": 60,
+		"image":      "ami-xxx",
+		"options": map[string]interface{}{
+			"maxBatchSize": 2,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "process-id", processID)
+
+	pkeAWSService.AssertExpectations(t)
+}
+
+func TestPKEAWSService_DeleteNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	pkeAWSService := new(pkeaws.MockService)
+	pkeAWSService.On("DeleteNodePool", ctx, uint(1), "pool0").Return(nil)
+
+	service := NewPKEAWSService(pkeAWSService)
+
+	deleted, err := service.DeleteNodePool(ctx, 1, "pool0")
+	require.NoError(t, err)
+
+	assert.False(t, deleted)
+
+	pkeAWSService.AssertExpectations(t)
+}
+
+func TestPKEAWSService_ListNodePools(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		nodePools := []pkeaws.NodePool{
+			{
+				Name:         "pool0",
+				Size:         2,
+				VolumeSize:   50,
+				InstanceType: "t2.medium",
+				Image:        "ami-xxx",
+			},
+		}
+
+		pkeAWSService := new(pkeaws.MockService)
+		pkeAWSService.On("ListNodePools", ctx, uint(1)).Return(nodePools, nil)
+
+		service := NewPKEAWSService(pkeAWSService)
+
+		nodePoolList, err := service.ListNodePools(ctx, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, cluster.RawNodePoolList{nodePools[0]}, nodePoolList)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		pkeAWSService := new(pkeaws.MockService)
+		pkeAWSService.On("ListNodePools", ctx, uint(1)).Return(nil, errors.NewPlain("error"))
+
+		service := NewPKEAWSService(pkeAWSService)
+
+		_, err := service.ListNodePools(ctx, 1)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusteradapter
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeazure"
+)
+
+func TestPKEAzureService_CreateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	nodePool := pkeazure.NewNodePool{
+		Name:         "pool0",
+		Size:         2,
+		InstanceType: "Standard_D2s_v3",
+		Zones:        []string{"1"},
+	}
+
+	pkeazureService := new(pkeazure.MockService)
+	pkeazureService.On("CreateNodePool", ctx, uint(1), nodePool).Return(nil)
+
+	service := NewPKEAzureService(pkeazureService)
+
+	err := service.CreateNodePool(ctx, 1, cluster.NewRawNodePool{
+		"name":         "pool0",
+		"size":         2,
+		"instanceType": "Standard_D2s_v3",
+		"zones":        []interface{}{"1"},
+	})
+	require.NoError(t, err)
+
+	pkeazureService.AssertExpectations(t)
+}
+
+func TestPKEAzureService_UpdateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	size := 3
+	instanceType := "Standard_D4s_v3"
+
+	pkeazureService := new(pkeazure.MockService)
+	pkeazureService.On(
+		"UpdateNodePool",
+		ctx,
+		uint(1),
+		"pool0",
+		pkeazure.NodePoolUpdate{
+			Size:         &size,
+			InstanceType: &instanceType,
+			Options: pkeazure.NodePoolUpdateOptions{
+				MaxBatchSize: 2,
+			},
+		},
+	).Return("process-id", nil)
+
+	service := NewPKEAzureService(pkeazureService)
+
+	processID, err := service.UpdateNodePool(ctx, 1, "pool0", cluster.RawNodePoolUpdate{
+		"size":         3,
+		"instanceType": "Standard_D4s_v3",
+		"options": map[string]interface{}{
+			"maxBatchSize": 2,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "process-id", processID)
+
+	pkeazureService.AssertExpectations(t)
+}
+
+func TestPKEAzureService_DeleteNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	pkeazureService := new(pkeazure.MockService)
+	pkeazureService.On("DeleteNodePool", ctx, uint(1), "pool0").Return(nil)
+
+	service := NewPKEAzureService(pkeazureService)
+
+	deleted, err := service.DeleteNodePool(ctx, 1, "pool0")
+	require.NoError(t, err)
+
+	assert.False(t, deleted)
+
+	pkeazureService.AssertExpectations(t)
+}
+
+func TestPKEAzureService_ListNodePools(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		nodePools := []pkeazure.NodePool{
+			{
+				Name:         "pool0",
+				Size:         2,
+				InstanceType: "Standard_D2s_v3",
+			},
+		}
+
+		pkeazureService := new(pkeazure.MockService)
+		pkeazureService.On("ListNodePools", ctx, uint(1)).Return(nodePools, nil)
+
+		service := NewPKEAzureService(pkeazureService)
+
+		nodePoolList, err := service.ListNodePools(ctx, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, cluster.RawNodePoolList{nodePools[0]}, nodePoolList)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		pkeazureService := new(pkeazure.MockService)
+		pkeazureService.On("ListNodePools", ctx, uint(1)).Return(nil, errors.NewPlain("error"))
+
+		service := NewPKEAzureService(pkeazureService)
+
+		_, err := service.ListNodePools(ctx, 1)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusteradapter
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+)
+
+func TestSimulatedService_CreateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	nodePool := simulated.NewNodePool{
+		Name:         "pool0",
+		Size:         2,
+		InstanceType: "small",
+	}
+
+	simulatedService := new(simulated.MockService)
+	simulatedService.On("CreateNodePool", ctx, uint(1), nodePool).Return(nil)
+
+	service := NewSimulatedService(simulatedService)
+
+	err := service.CreateNodePool(ctx, 1, cluster.NewRawNodePool{
+		"name":         "pool0",
+		"size":         2,
+		"instanceType": "small",
+	})
+	require.NoError(t, err)
+
+	simulatedService.AssertExpectations(t)
+}
+
+func TestSimulatedService_UpdateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	size := 3
+
+	simulatedService := new(simulated.MockService)
+	simulatedService.On(
+		"UpdateNodePool",
+		ctx,
+		uint(1),
+		"pool0",
+		simulated.NodePoolUpdate{
+			Size: &size,
+		},
+	).Return("process-id", nil)
+
+	service := NewSimulatedService(simulatedService)
+
+	processID, err := service.UpdateNodePool(ctx, 1, "pool0", cluster.RawNodePoolUpdate{
+		"size": 3,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "process-id", processID)
+
+	simulatedService.AssertExpectations(t)
+}
+
+func TestSimulatedService_DeleteNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	simulatedService := new(simulated.MockService)
+	simulatedService.On("DeleteNodePool", ctx, uint(1), "pool0").Return(nil)
+
+	service := NewSimulatedService(simulatedService)
+
+	deleted, err := service.DeleteNodePool(ctx, 1, "pool0")
+	require.NoError(t, err)
+
+	assert.False(t, deleted)
+
+	simulatedService.AssertExpectations(t)
+}
+
+func TestSimulatedService_ListNodePools(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		nodePools := []simulated.NodePool{
+			{
+				Name:         "pool0",
+				Size:         2,
+				InstanceType: "small",
+			},
+		}
+
+		simulatedService := new(simulated.MockService)
+		simulatedService.On("ListNodePools", ctx, uint(1)).Return(nodePools, nil)
+
+		service := NewSimulatedService(simulatedService)
+
+		nodePoolList, err := service.ListNodePools(ctx, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, cluster.RawNodePoolList{nodePools[0]}, nodePoolList)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		simulatedService := new(simulated.MockService)
+		simulatedService.On("ListNodePools", ctx, uint(1)).Return(nil, errors.NewPlain("error"))
+
+		service := NewSimulatedService(simulatedService)
+
+		_, err := service.ListNodePools(ctx, 1)
+		require.Error(t, err)
+	})
+}
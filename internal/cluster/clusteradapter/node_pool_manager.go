@@ -81,3 +81,29 @@ func (n nodePoolManager) DeleteNodePool(ctx context.Context, clusterID uint, nam
 
 	return nil
 }
+
+type serviceNodePoolManager struct {
+	service cluster.Service
+}
+
+// NewServiceNodePoolManager returns a new cluster.NodePoolManager
+// that delegates node pool management to a distribution service.
+func NewServiceNodePoolManager(service cluster.Service) cluster.NodePoolManager {
+	return serviceNodePoolManager{
+		service: service,
+	}
+}
+
+func (n serviceNodePoolManager) CreateNodePool(
+	ctx context.Context,
+	clusterID uint,
+	rawNodePool cluster.NewRawNodePool,
+) error {
+	return n.service.CreateNodePool(ctx, clusterID, rawNodePool)
+}
+
+func (n serviceNodePoolManager) DeleteNodePool(ctx context.Context, clusterID uint, name string) error {
+	_, err := n.service.DeleteNodePool(ctx, clusterID, name)
+
+	return err
+}
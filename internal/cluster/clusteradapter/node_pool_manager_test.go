@@ -80,3 +80,27 @@ func TestNodePoolManager_DeleteNodePool(t *testing.T) {
 
 	client.AssertExpectations(t)
 }
+
+func TestServiceNodePoolManager(t *testing.T) {
+	ctx := context.Background()
+	const clusterID = uint(1)
+	const nodePoolName = "pool0"
+
+	rawNewNodePool := cluster.NewRawNodePool{
+		"name": nodePoolName,
+	}
+
+	service := new(cluster.MockService)
+	service.On("CreateNodePool", ctx, clusterID, rawNewNodePool).Return(nil)
+	service.On("DeleteNodePool", ctx, clusterID, nodePoolName).Return(false, nil)
+
+	manager := NewServiceNodePoolManager(service)
+
+	err := manager.CreateNodePool(ctx, clusterID, rawNewNodePool)
+	require.NoError(t, err)
+
+	err = manager.DeleteNodePool(ctx, clusterID, nodePoolName)
+	require.NoError(t, err)
+
+	service.AssertExpectations(t)
+}
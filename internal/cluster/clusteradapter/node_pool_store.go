@@ -22,6 +22,11 @@ import (
 
 	"github.com/banzaicloud/pipeline/internal/cluster"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksmodel"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulatedadapter"
+	"github.com/banzaicloud/pipeline/internal/providers/azure/azureadapter"
+	azurePKEAdapter "github.com/banzaicloud/pipeline/internal/providers/azure/pke/adapter"
+	"github.com/banzaicloud/pipeline/internal/providers/google"
+	"github.com/banzaicloud/pipeline/internal/providers/pke"
 	"github.com/banzaicloud/pipeline/pkg/providers"
 )
 
@@ -72,6 +77,98 @@ func (s nodePoolStore) NodePoolExists(ctx context.Context, clusterID uint, name
 		}
 
 		storedName = eksCluster.NodePools[0].Name
+	case c.Cloud == providers.Amazon && c.Distribution == "pke":
+		var nodePool pke.NodePool
+
+		err := s.db.Where(pke.NodePool{ClusterID: clusterID, Name: name}).First(&nodePool).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return false, "", nil
+		}
+		if err != nil {
+			return false, "", errors.WrapWithDetails(
+				err, "failed to check if node pool exists",
+				"clusterId", clusterID,
+				"nodePoolName", name,
+			)
+		}
+
+		storedName = nodePool.Name
+	case c.Cloud == providers.Google && c.Distribution == "gke":
+		var nodePool google.GKENodePoolModel
+
+		err := s.db.Where(google.GKENodePoolModel{ClusterID: clusterID, Name: name}).First(&nodePool).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return false, "", nil
+		}
+		if err != nil {
+			return false, "", errors.WrapWithDetails(
+				err, "failed to check if node pool exists",
+				"clusterId", clusterID,
+				"nodePoolName", name,
+			)
+		}
+
+		storedName = nodePool.Name
+	case c.Cloud == providers.Azure && c.Distribution == "aks":
+		var nodePool azureadapter.AKSNodePoolModel
+
+		err := s.db.Where(azureadapter.AKSNodePoolModel{ClusterID: clusterID, Name: name}).First(&nodePool).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return false, "", nil
+		}
+		if err != nil {
+			return false, "", errors.WrapWithDetails(
+				err, "failed to check if node pool exists",
+				"clusterId", clusterID,
+				"nodePoolName", name,
+			)
+		}
+
+		storedName = nodePool.Name
+	case c.Cloud == providers.Azure && c.Distribution == "pke":
+		var nodePools []struct {
+			Name string
+		}
+
+		err := s.db.
+			Table(azurePKEAdapter.NodePoolsTableName).
+			Where("cluster_id = ? AND name = ? AND deleted_at IS NULL", clusterID, name).
+			Scan(&nodePools).Error
+		if err != nil {
+			return false, "", errors.WrapWithDetails(
+				err, "failed to check if node pool exists",
+				"clusterId", clusterID,
+				"nodePoolName", name,
+			)
+		}
+
+		if len(nodePools) == 0 {
+			return false, "", nil
+		}
+
+		storedName = nodePools[0].Name
+	case c.Cloud == "simulated" && c.Distribution == "simulated":
+		var nodePools []struct {
+			Name string
+		}
+
+		err := s.db.
+			Table(simulatedadapter.NodePoolsTableName).
+			Where("cluster_id = ? AND name = ?", clusterID, name).
+			Scan(&nodePools).Error
+		if err != nil {
+			return false, "", errors.WrapWithDetails(
+				err, "failed to check if node pool exists",
+				"clusterId", clusterID,
+				"nodePoolName", name,
+			)
+		}
+
+		if len(nodePools) == 0 {
+			return false, "", nil
+		}
+
+		storedName = nodePools[0].Name
 	default:
 		return false, "", errors.WithStack(cluster.NotSupportedDistributionError{
 			ID:           c.ID,
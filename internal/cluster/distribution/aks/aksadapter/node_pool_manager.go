@@ -0,0 +1,181 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aksadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/aks"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/aks/aksworkflow"
+	"github.com/banzaicloud/pipeline/internal/providers/azure/azureadapter"
+	"github.com/banzaicloud/pipeline/pkg/kubernetes/custom/npls"
+)
+
+type nodePoolManager struct {
+	db                   *gorm.DB
+	nodePools            aks.NodePoolStore
+	dynamicClientFactory cluster.DynamicKubeClientFactory
+	namespace            string
+	workflowClient       client.Client
+	getUserID            func(ctx context.Context) uint
+}
+
+// NewNodePoolManager returns a new aks.NodePoolManager
+// that manages node pools asynchronously via Cadence workflows.
+func NewNodePoolManager(
+	db *gorm.DB,
+	nodePools aks.NodePoolStore,
+	dynamicClientFactory cluster.DynamicKubeClientFactory,
+	namespace string,
+	workflowClient client.Client,
+	getUserID func(ctx context.Context) uint,
+) aks.NodePoolManager {
+	return nodePoolManager{
+		db:                   db,
+		nodePools:            nodePools,
+		dynamicClientFactory: dynamicClientFactory,
+		namespace:            namespace,
+		workflowClient:       workflowClient,
+		getUserID:            getUserID,
+	}
+}
+
+func (n nodePoolManager) getResourceGroup(c cluster.Cluster) (string, error) {
+	var aksCluster azureadapter.AKSClusterModel
+
+	err := n.db.Where(azureadapter.AKSClusterModel{ID: c.ID}).First(&aksCluster).Error
+	if err != nil {
+		return "", errors.WrapWithDetails(err, "failed to get cluster info", "clusterId", c.ID)
+	}
+
+	return aksCluster.ResourceGroup, nil
+}
+
+func (n nodePoolManager) startWorkflow(ctx context.Context, workflowName string, input interface{}) (string, error) {
+	workflowOptions := client.StartWorkflowOptions{
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 30 * 24 * 60 * time.Minute,
+	}
+
+	e, err := n.workflowClient.StartWorkflow(ctx, workflowOptions, workflowName, input)
+	if err != nil {
+		return "", errors.WrapWithDetails(err, "failed to start workflow", "workflow", workflowName)
+	}
+
+	return e.ID, nil
+}
+
+func (n nodePoolManager) CreateNodePool(ctx context.Context, c cluster.Cluster, nodePool aks.NewNodePool) error {
+	resourceGroup, err := n.getResourceGroup(c)
+	if err != nil {
+		return err
+	}
+
+	err = n.nodePools.CreateNodePool(ctx, c.ID, n.getUserID(ctx), nodePool)
+	if err != nil {
+		return err
+	}
+
+	input := aksworkflow.CreateNodePoolWorkflowInput{
+		OrganizationID:    c.OrganizationID,
+		SecretID:          c.SecretID.ResourceID,
+		ResourceGroupName: resourceGroup,
+
+		ClusterID:   c.ID,
+		ClusterName: c.Name,
+
+		NodePoolName:   nodePool.Name,
+		NodePoolLabels: nodePool.Labels,
+		InstanceType:   nodePool.InstanceType,
+		Size:           nodePool.Size,
+		VNetSubnetID:   nodePool.VNetSubnetID,
+	}
+
+	_, err = n.startWorkflow(ctx, aksworkflow.CreateNodePoolWorkflowName, input)
+
+	return err
+}
+
+func (n nodePoolManager) UpdateNodePool(
+	ctx context.Context,
+	c cluster.Cluster,
+	nodePool aks.NodePool,
+	nodePoolUpdate aks.NodePoolUpdate,
+) (string, error) {
+	resourceGroup, err := n.getResourceGroup(c)
+	if err != nil {
+		return "", err
+	}
+
+	input := aksworkflow.UpdateNodePoolWorkflowInput{
+		OrganizationID:    c.OrganizationID,
+		SecretID:          c.SecretID.ResourceID,
+		ResourceGroupName: resourceGroup,
+
+		ClusterID:   c.ID,
+		ClusterName: c.Name,
+
+		CurrentNodePool: nodePool,
+		NodePool:        nodePool.ApplyUpdate(nodePoolUpdate),
+	}
+
+	return n.startWorkflow(ctx, aksworkflow.UpdateNodePoolWorkflowName, input)
+}
+
+func (n nodePoolManager) DeleteNodePool(ctx context.Context, c cluster.Cluster, nodePoolName string) error {
+	resourceGroup, err := n.getResourceGroup(c)
+	if err != nil {
+		return err
+	}
+
+	input := aksworkflow.DeleteNodePoolWorkflowInput{
+		OrganizationID:    c.OrganizationID,
+		SecretID:          c.SecretID.ResourceID,
+		ResourceGroupName: resourceGroup,
+
+		ClusterID:   c.ID,
+		ClusterName: c.Name,
+
+		NodePoolName: nodePoolName,
+	}
+
+	_, err = n.startWorkflow(ctx, aksworkflow.DeleteNodePoolWorkflowName, input)
+
+	return err
+}
+
+// ListNodePoolLabels lists the labels of the node pools in a cluster keyed by node pool names.
+func (n nodePoolManager) ListNodePoolLabels(ctx context.Context, c cluster.Cluster) (map[string]map[string]string, error) {
+	clusterClient, err := n.dynamicClientFactory.FromSecret(ctx, c.ConfigSecretID.String())
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "creating dynamic Kubernetes client factory failed", "cluster", c)
+	}
+
+	labelSets, err := npls.NewManager(clusterClient, n.namespace).GetAll(ctx)
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "retrieving node pool label sets failed",
+			"cluster", c,
+			"namespace", n.namespace,
+		)
+	}
+
+	return labelSets, nil
+}
@@ -0,0 +1,122 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aksadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/aks"
+	"github.com/banzaicloud/pipeline/internal/providers/azure/azureadapter"
+)
+
+type nodePoolStore struct {
+	db *gorm.DB
+}
+
+// NewNodePoolStore returns a new aks.NodePoolStore
+// that provides an interface to AKS node pool persistence.
+func NewNodePoolStore(db *gorm.DB) aks.NodePoolStore {
+	return nodePoolStore{
+		db: db,
+	}
+}
+
+func (s nodePoolStore) CreateNodePool(
+	_ context.Context,
+	clusterID uint,
+	createdBy uint,
+	nodePool aks.NewNodePool,
+) error {
+	nodePoolModel := &azureadapter.AKSNodePoolModel{
+		ClusterID:        clusterID,
+		CreatedBy:        createdBy,
+		Name:             nodePool.Name,
+		Autoscaling:      nodePool.Autoscaling.Enabled,
+		NodeMinCount:     nodePool.Autoscaling.MinSize,
+		NodeMaxCount:     nodePool.Autoscaling.MaxSize,
+		Count:            nodePool.Size,
+		NodeInstanceType: nodePool.InstanceType,
+		VNetSubnetID:     nodePool.VNetSubnetID,
+	}
+
+	err := s.db.Save(nodePoolModel).Error
+	if err != nil {
+		return errors.Wrap(err, "failed to save node pool")
+	}
+
+	return nil
+}
+
+func (s nodePoolStore) DeleteNodePool(_ context.Context, clusterID uint, nodePoolName string) error {
+	err := s.db.
+		Where(azureadapter.AKSNodePoolModel{ClusterID: clusterID, Name: nodePoolName}).
+		Delete(azureadapter.AKSNodePoolModel{}).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to delete node pool", "clusterID", clusterID, "nodePool", nodePoolName)
+	}
+
+	return nil
+}
+
+func (s nodePoolStore) ListNodePools(_ context.Context, clusterID uint) ([]aks.NodePool, error) {
+	var nodePoolModels []azureadapter.AKSNodePoolModel
+
+	err := s.db.Where(azureadapter.AKSNodePoolModel{ClusterID: clusterID}).Find(&nodePoolModels).Error
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "fetching node pools from database failed", "clusterID", clusterID)
+	}
+
+	nodePools := make([]aks.NodePool, 0, len(nodePoolModels))
+	for _, nodePoolModel := range nodePoolModels {
+		nodePools = append(nodePools, aks.NodePool{
+			Name: nodePoolModel.Name,
+			Size: nodePoolModel.Count,
+			Autoscaling: aks.Autoscaling{
+				Enabled: nodePoolModel.Autoscaling,
+				MinSize: nodePoolModel.NodeMinCount,
+				MaxSize: nodePoolModel.NodeMaxCount,
+			},
+			InstanceType: nodePoolModel.NodeInstanceType,
+			VNetSubnetID: nodePoolModel.VNetSubnetID,
+		})
+	}
+
+	return nodePools, nil
+}
+
+func (s nodePoolStore) UpdateNodePool(_ context.Context, clusterID uint, nodePool aks.NodePool) error {
+	var nodePoolModel azureadapter.AKSNodePoolModel
+
+	err := s.db.Where(azureadapter.AKSNodePoolModel{ClusterID: clusterID, Name: nodePool.Name}).First(&nodePoolModel).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to get node pool", "clusterID", clusterID, "nodePool", nodePool.Name)
+	}
+
+	err = s.db.Model(&nodePoolModel).Updates(map[string]interface{}{
+		"count":              nodePool.Size,
+		"autoscaling":        nodePool.Autoscaling.Enabled,
+		"node_min_count":     nodePool.Autoscaling.MinSize,
+		"node_max_count":     nodePool.Autoscaling.MaxSize,
+		"node_instance_type": nodePool.InstanceType,
+	}).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to save node pool", "clusterID", clusterID, "nodePool", nodePool.Name)
+	}
+
+	return nil
+}
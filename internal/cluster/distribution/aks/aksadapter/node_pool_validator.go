@@ -0,0 +1,48 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aksadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/aks"
+)
+
+type nodePoolValidator struct{}
+
+// NewNodePoolValidator returns a new cluster.NodePoolValidator
+// that validates an AKS node pool request.
+func NewNodePoolValidator() cluster.NodePoolValidator {
+	return nodePoolValidator{}
+}
+
+func (v nodePoolValidator) ValidateNew(
+	_ context.Context,
+	_ cluster.Cluster,
+	rawNodePool cluster.NewRawNodePool,
+) error {
+	var nodePool aks.NewNodePool
+
+	err := mapstructure.Decode(rawNodePool, &nodePool)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode node pool")
+	}
+
+	return nodePool.Validate()
+}
@@ -0,0 +1,98 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aksworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2020-02-01/containerservice"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/cadence/activity"
+
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+)
+
+const CreateAgentPoolActivityName = "aks-create-agent-pool"
+
+// CreateAgentPoolActivity creates (or updates) a virtual machine scale set backed agent pool in an AKS cluster.
+type CreateAgentPoolActivity struct {
+	azureClientFactory AzureClientFactory
+}
+
+// CreateAgentPoolActivityInput holds the parameters of the agent pool creation.
+type CreateAgentPoolActivityInput struct {
+	AgentPoolActivityInput
+
+	// NodePoolName is the name of the node pool the nodes of the agent pool belong to.
+	// It is different from the agent pool name while replacing the instances of a node pool.
+	NodePoolName string
+	InstanceType string
+	Size         int
+	VNetSubnetID string
+}
+
+// NewCreateAgentPoolActivity creates a new CreateAgentPoolActivity instance.
+func NewCreateAgentPoolActivity(azureClientFactory AzureClientFactory) CreateAgentPoolActivity {
+	return CreateAgentPoolActivity{
+		azureClientFactory: azureClientFactory,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a CreateAgentPoolActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: CreateAgentPoolActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a CreateAgentPoolActivity) Execute(ctx context.Context, input CreateAgentPoolActivityInput) error {
+	cc, err := a.azureClientFactory.New(input.OrganizationID, input.SecretID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create cloud connection")
+	}
+
+	agentPool := containerservice.AgentPool{
+		ManagedClusterAgentPoolProfileProperties: &containerservice.ManagedClusterAgentPoolProfileProperties{
+			Count:  to.Int32Ptr(int32(input.Size)),
+			VMSize: containerservice.VMSizeTypes(input.InstanceType),
+			OsType: containerservice.Linux,
+			Type:   containerservice.VirtualMachineScaleSets,
+			NodeLabels: map[string]*string{
+				pkgCommon.LabelKey: to.StringPtr(input.NodePoolName),
+			},
+		},
+	}
+
+	if input.VNetSubnetID != "" {
+		agentPool.VnetSubnetID = to.StringPtr(input.VNetSubnetID)
+	}
+
+	_, err = cc.GetAgentPoolsClient().CreateOrUpdateAndWaitForIt(
+		ctx,
+		input.ResourceGroupName,
+		input.ClusterName,
+		input.AgentPoolName,
+		agentPool,
+	)
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to create agent pool",
+			"cluster", input.ClusterName,
+			"agentPool", input.AgentPoolName,
+		)
+	}
+
+	return nil
+}
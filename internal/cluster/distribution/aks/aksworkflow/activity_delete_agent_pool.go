@@ -0,0 +1,70 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aksworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+)
+
+const DeleteAgentPoolActivityName = "aks-delete-agent-pool"
+
+// DeleteAgentPoolActivity deletes an agent pool from an AKS cluster.
+type DeleteAgentPoolActivity struct {
+	azureClientFactory AzureClientFactory
+}
+
+// DeleteAgentPoolActivityInput holds the parameters of the agent pool deletion.
+type DeleteAgentPoolActivityInput struct {
+	AgentPoolActivityInput
+}
+
+// NewDeleteAgentPoolActivity creates a new DeleteAgentPoolActivity instance.
+func NewDeleteAgentPoolActivity(azureClientFactory AzureClientFactory) DeleteAgentPoolActivity {
+	return DeleteAgentPoolActivity{
+		azureClientFactory: azureClientFactory,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a DeleteAgentPoolActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: DeleteAgentPoolActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a DeleteAgentPoolActivity) Execute(ctx context.Context, input DeleteAgentPoolActivityInput) error {
+	cc, err := a.azureClientFactory.New(input.OrganizationID, input.SecretID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create cloud connection")
+	}
+
+	err = cc.GetAgentPoolsClient().DeleteAndWaitForIt(ctx, input.ResourceGroupName, input.ClusterName, input.AgentPoolName)
+	if isNotFoundError(err) {
+		activity.GetLogger(ctx).Sugar().Warnw("agent pool not found", "agentPool", input.AgentPoolName)
+
+		return nil
+	}
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to delete agent pool",
+			"cluster", input.ClusterName,
+			"agentPool", input.AgentPoolName,
+		)
+	}
+
+	return nil
+}
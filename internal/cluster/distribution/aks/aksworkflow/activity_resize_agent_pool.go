@@ -0,0 +1,85 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aksworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/cadence/activity"
+)
+
+const ResizeAgentPoolActivityName = "aks-resize-agent-pool"
+
+// ResizeAgentPoolActivity changes the number of nodes in an agent pool.
+type ResizeAgentPoolActivity struct {
+	azureClientFactory AzureClientFactory
+}
+
+// ResizeAgentPoolActivityInput holds the parameters of the agent pool resize.
+type ResizeAgentPoolActivityInput struct {
+	AgentPoolActivityInput
+
+	Size int
+}
+
+// NewResizeAgentPoolActivity creates a new ResizeAgentPoolActivity instance.
+func NewResizeAgentPoolActivity(azureClientFactory AzureClientFactory) ResizeAgentPoolActivity {
+	return ResizeAgentPoolActivity{
+		azureClientFactory: azureClientFactory,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a ResizeAgentPoolActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: ResizeAgentPoolActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a ResizeAgentPoolActivity) Execute(ctx context.Context, input ResizeAgentPoolActivityInput) error {
+	cc, err := a.azureClientFactory.New(input.OrganizationID, input.SecretID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create cloud connection")
+	}
+
+	client := cc.GetAgentPoolsClient()
+
+	agentPool, err := client.Get(ctx, input.ResourceGroupName, input.ClusterName, input.AgentPoolName)
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to get agent pool",
+			"cluster", input.ClusterName,
+			"agentPool", input.AgentPoolName,
+		)
+	}
+
+	if agentPool.Count != nil && int(*agentPool.Count) == input.Size {
+		return nil
+	}
+
+	agentPool.Count = to.Int32Ptr(int32(input.Size))
+
+	_, err = client.CreateOrUpdateAndWaitForIt(ctx, input.ResourceGroupName, input.ClusterName, input.AgentPoolName, agentPool)
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to resize agent pool",
+			"cluster", input.ClusterName,
+			"agentPool", input.AgentPoolName,
+		)
+	}
+
+	return nil
+}
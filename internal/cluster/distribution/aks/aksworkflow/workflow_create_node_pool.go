@@ -0,0 +1,117 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aksworkflow
+
+import (
+	"fmt"
+
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+const CreateNodePoolWorkflowName = "aks-create-node-pool"
+
+type CreateNodePoolWorkflow struct {
+	processLogger processlog.ProcessLogger
+}
+
+// NewCreateNodePoolWorkflow returns a new CreateNodePoolWorkflow.
+func NewCreateNodePoolWorkflow(processLogger processlog.ProcessLogger) CreateNodePoolWorkflow {
+	return CreateNodePoolWorkflow{
+		processLogger: processLogger,
+	}
+}
+
+type CreateNodePoolWorkflowInput struct {
+	OrganizationID    uint
+	SecretID          string
+	ResourceGroupName string
+
+	ClusterID   uint
+	ClusterName string
+
+	NodePoolName   string
+	NodePoolLabels map[string]string
+	InstanceType   string
+	Size           int
+	VNetSubnetID   string
+}
+
+func (w CreateNodePoolWorkflow) Register() {
+	workflow.RegisterWithOptions(w.Execute, workflow.RegisterOptions{Name: CreateNodePoolWorkflowName})
+}
+
+func (w CreateNodePoolWorkflow) Execute(ctx workflow.Context, input CreateNodePoolWorkflowInput) (err error) {
+	ctx = workflow.WithActivityOptions(ctx, activityOptions(ctx))
+
+	clusterID := brn.New(input.OrganizationID, brn.ClusterResourceType, fmt.Sprint(input.ClusterID))
+
+	process := w.processLogger.StartProcess(ctx, clusterID.String())
+	defer func() {
+		process.Finish(ctx, err)
+	}()
+	defer func() {
+		finishClusterUpdate(ctx, input.ClusterID, "failed to create node pool", err)
+	}()
+
+	{
+		activityInput := clusterworkflow.CreateNodePoolLabelSetActivityInput{
+			ClusterID: input.ClusterID,
+			RawNodePool: cluster.NewRawNodePool{
+				"name":   input.NodePoolName,
+				"labels": input.NodePoolLabels,
+			},
+		}
+
+		err = executeProcessActivity(ctx, process, clusterworkflow.CreateNodePoolLabelSetActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		activityInput := CreateAgentPoolActivityInput{
+			AgentPoolActivityInput: AgentPoolActivityInput{
+				OrganizationID:    input.OrganizationID,
+				SecretID:          input.SecretID,
+				ResourceGroupName: input.ResourceGroupName,
+				ClusterName:       input.ClusterName,
+				AgentPoolName:     input.NodePoolName,
+			},
+			NodePoolName: input.NodePoolName,
+			InstanceType: input.InstanceType,
+			Size:         input.Size,
+			VNetSubnetID: input.VNetSubnetID,
+		}
+
+		err = executeProcessActivity(ctx, process, CreateAgentPoolActivityName, activityInput)
+		if err != nil {
+			activityInput := DeleteStoredNodePoolActivityInput{
+				ClusterID:    input.ClusterID,
+				NodePoolName: input.NodePoolName,
+			}
+
+			_ = workflow.ExecuteActivity(ctx, DeleteStoredNodePoolActivityName, activityInput).Get(ctx, nil)
+
+			return err
+		}
+	}
+
+	return installClusterAutoscaler(ctx, process, input.ClusterID)
+}
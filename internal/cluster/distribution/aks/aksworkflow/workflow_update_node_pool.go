@@ -0,0 +1,158 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aksworkflow
+
+import (
+	"fmt"
+
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/aks"
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+const UpdateNodePoolWorkflowName = "aks-update-node-pool"
+
+type UpdateNodePoolWorkflow struct {
+	processLogger processlog.ProcessLogger
+}
+
+// NewUpdateNodePoolWorkflow returns a new UpdateNodePoolWorkflow.
+func NewUpdateNodePoolWorkflow(processLogger processlog.ProcessLogger) UpdateNodePoolWorkflow {
+	return UpdateNodePoolWorkflow{
+		processLogger: processLogger,
+	}
+}
+
+type UpdateNodePoolWorkflowInput struct {
+	OrganizationID    uint
+	SecretID          string
+	ResourceGroupName string
+
+	ClusterID   uint
+	ClusterName string
+
+	CurrentNodePool aks.NodePool
+	NodePool        aks.NodePool
+}
+
+func (w UpdateNodePoolWorkflow) Register() {
+	workflow.RegisterWithOptions(w.Execute, workflow.RegisterOptions{Name: UpdateNodePoolWorkflowName})
+}
+
+func (w UpdateNodePoolWorkflow) Execute(ctx workflow.Context, input UpdateNodePoolWorkflowInput) (err error) {
+	ctx = workflow.WithActivityOptions(ctx, activityOptions(ctx))
+
+	clusterID := brn.New(input.OrganizationID, brn.ClusterResourceType, fmt.Sprint(input.ClusterID))
+
+	process := w.processLogger.StartProcess(ctx, clusterID.String())
+	defer func() {
+		process.Finish(ctx, err)
+	}()
+	defer func() {
+		finishClusterUpdate(ctx, input.ClusterID, "failed to update node pool", err)
+	}()
+
+	nodePool := input.NodePool
+
+	agentPoolActivityInput := AgentPoolActivityInput{
+		OrganizationID:    input.OrganizationID,
+		SecretID:          input.SecretID,
+		ResourceGroupName: input.ResourceGroupName,
+		ClusterName:       input.ClusterName,
+		AgentPoolName:     nodePool.Name,
+	}
+
+	if nodePool.InstanceType != input.CurrentNodePool.InstanceType {
+		// AKS cannot change the instance type of an agent pool,
+		// so a temporary pool takes over the workload while the original pool is recreated.
+		temporaryAgentPoolActivityInput := agentPoolActivityInput
+		temporaryAgentPoolActivityInput.AgentPoolName = aks.TemporaryAgentPoolName(nodePool.Name)
+
+		steps := []struct {
+			activityName  string
+			activityInput interface{}
+		}{
+			{
+				activityName: CreateAgentPoolActivityName,
+				activityInput: CreateAgentPoolActivityInput{
+					AgentPoolActivityInput: temporaryAgentPoolActivityInput,
+					NodePoolName:           nodePool.Name,
+					InstanceType:           nodePool.InstanceType,
+					Size:                   nodePool.Size,
+					VNetSubnetID:           nodePool.VNetSubnetID,
+				},
+			},
+			{
+				activityName: DeleteAgentPoolActivityName,
+				activityInput: DeleteAgentPoolActivityInput{
+					AgentPoolActivityInput: agentPoolActivityInput,
+				},
+			},
+			{
+				activityName: CreateAgentPoolActivityName,
+				activityInput: CreateAgentPoolActivityInput{
+					AgentPoolActivityInput: agentPoolActivityInput,
+					NodePoolName:           nodePool.Name,
+					InstanceType:           nodePool.InstanceType,
+					Size:                   nodePool.Size,
+					VNetSubnetID:           nodePool.VNetSubnetID,
+				},
+			},
+			{
+				activityName: DeleteAgentPoolActivityName,
+				activityInput: DeleteAgentPoolActivityInput{
+					AgentPoolActivityInput: temporaryAgentPoolActivityInput,
+				},
+			},
+		}
+
+		for _, step := range steps {
+			err = executeProcessActivity(ctx, process, step.activityName, step.activityInput)
+			if err != nil {
+				return err
+			}
+		}
+	} else if nodePool.Size != input.CurrentNodePool.Size {
+		activityInput := ResizeAgentPoolActivityInput{
+			AgentPoolActivityInput: agentPoolActivityInput,
+			Size:                   nodePool.Size,
+		}
+
+		err = executeProcessActivity(ctx, process, ResizeAgentPoolActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		activityInput := SaveNodePoolActivityInput{
+			ClusterID: input.ClusterID,
+			NodePool:  nodePool,
+		}
+
+		err = executeProcessActivity(ctx, process, SaveNodePoolActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	if nodePool.Autoscaling != input.CurrentNodePool.Autoscaling {
+		return installClusterAutoscaler(ctx, process, input.ClusterID)
+	}
+
+	return nil
+}
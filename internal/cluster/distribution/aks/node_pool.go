@@ -0,0 +1,116 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// NewNodePool describes a new Kubernetes node pool in an AKS cluster.
+type NewNodePool struct {
+	Name        string            `mapstructure:"name"`
+	Labels      map[string]string `mapstructure:"labels"`
+	Size        int               `mapstructure:"size"`
+	Autoscaling struct {
+		Enabled bool `mapstructure:"enabled"`
+		MinSize int  `mapstructure:"minSize"`
+		MaxSize int  `mapstructure:"maxSize"`
+	} `mapstructure:"autoscaling"`
+	InstanceType string `mapstructure:"instanceType"`
+	VNetSubnetID string `mapstructure:"vnetSubnetID"`
+}
+
+// nodePoolNameRegexp matches the agent pool names accepted by AKS.
+var nodePoolNameRegexp = regexp.MustCompile("^[a-z][a-z0-9]{0,11}$")
+
+// Validate semantically validates the new node pool.
+func (n NewNodePool) Validate() error {
+	var violations []string
+
+	if !nodePoolNameRegexp.MatchString(n.Name) {
+		violations = append(violations, "name must start with a lowercase letter and contain at most 12 lowercase alphanumeric characters")
+	}
+
+	if n.Autoscaling.Enabled {
+		violations = append(violations, validateAutoscaling(n.Size, n.Autoscaling.MinSize, n.Autoscaling.MaxSize)...)
+	} else if n.Size < 1 {
+		violations = append(violations, "size cannot be lower than one")
+	}
+
+	if n.InstanceType == "" {
+		violations = append(violations, "instance type cannot be empty")
+	}
+
+	if len(violations) > 0 {
+		return cluster.NewValidationError("invalid node pool creation request", violations)
+	}
+
+	return nil
+}
+
+func validateAutoscaling(size int, minSize int, maxSize int) []string {
+	var violations []string
+
+	if minSize < 1 {
+		violations = append(violations, "minimum autoscaling size cannot be lower than one")
+	}
+
+	if maxSize < minSize {
+		violations = append(violations, "maximum autoscaling size cannot be lower than the minimum")
+	}
+
+	if size < minSize {
+		violations = append(violations, "node pool size cannot be lower than the autoscaling minimum size")
+	}
+
+	if size > maxSize {
+		violations = append(violations, "node pool size cannot be higher than the autoscaling maximum size")
+	}
+
+	return violations
+}
+
+// TemporaryAgentPoolName returns the name of the agent pool
+// that replaces a node pool while its instance type is changed.
+func TemporaryAgentPoolName(nodePoolName string) string {
+	const suffix = "tmp"
+	const maxLength = 12
+
+	if len(nodePoolName) > maxLength-len(suffix) {
+		nodePoolName = nodePoolName[:maxLength-len(suffix)]
+	}
+
+	return nodePoolName + suffix
+}
+
+// +testify:mock:testOnly=true
+
+// NodePoolStore provides an interface for AKS node pool persistence.
+type NodePoolStore interface {
+	// CreateNodePool saves a new node pool.
+	CreateNodePool(ctx context.Context, clusterID uint, createdBy uint, nodePool NewNodePool) error
+
+	// DeleteNodePool deletes a node pool.
+	DeleteNodePool(ctx context.Context, clusterID uint, nodePoolName string) error
+
+	// ListNodePools retrieves the node pools of the cluster specified by its cluster ID.
+	ListNodePools(ctx context.Context, clusterID uint) ([]NodePool, error)
+
+	// UpdateNodePool saves the size, autoscaling settings and instance type of an existing node pool.
+	UpdateNodePool(ctx context.Context, clusterID uint, nodePool NodePool) error
+}
@@ -0,0 +1,252 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aks
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// +testify:mock
+
+// Service provides an interface to AKS clusters.
+type Service interface {
+	// CreateNodePool creates a new node pool in a cluster.
+	CreateNodePool(ctx context.Context, clusterID uint, nodePool NewNodePool) error
+
+	// UpdateNodePool updates an existing node pool in a cluster.
+	//
+	// This method accepts a partial body representation.
+	UpdateNodePool(ctx context.Context, clusterID uint, nodePoolName string, nodePoolUpdate NodePoolUpdate) (string, error)
+
+	// DeleteNodePool deletes a node pool from a cluster.
+	DeleteNodePool(ctx context.Context, clusterID uint, nodePoolName string) error
+
+	// ListNodePools lists node pools from a cluster.
+	ListNodePools(ctx context.Context, clusterID uint) ([]NodePool, error)
+}
+
+// NodePoolUpdate describes a node pool update request.
+//
+// A node pool update contains a partial representation of the node pool resource,
+// updating only the changed values.
+//
+// Changing the instance type replaces the agent pool:
+// a temporary pool takes over the workload while the original one is recreated.
+type NodePoolUpdate struct {
+	Size *int `mapstructure:"size"`
+
+	Autoscaling *Autoscaling `mapstructure:"autoscaling"`
+
+	InstanceType *string `mapstructure:"instanceType"`
+}
+
+// NodePool encapsulates information about a cluster node pool.
+type NodePool struct {
+	Name         string            `mapstructure:"name"`
+	Labels       map[string]string `mapstructure:"labels"`
+	Size         int               `mapstructure:"size"`
+	Autoscaling  Autoscaling       `mapstructure:"autoscaling"`
+	InstanceType string            `mapstructure:"instanceType"`
+	VNetSubnetID string            `mapstructure:"vnetSubnetID"`
+}
+
+// Autoscaling describes the node pool's autoscaling settings.
+type Autoscaling struct {
+	Enabled bool `mapstructure:"enabled"`
+	MinSize int  `mapstructure:"minSize"`
+	MaxSize int  `mapstructure:"maxSize"`
+}
+
+// ApplyUpdate returns the node pool with the changes of a node pool update applied.
+func (n NodePool) ApplyUpdate(nodePoolUpdate NodePoolUpdate) NodePool {
+	if nodePoolUpdate.Size != nil {
+		n.Size = *nodePoolUpdate.Size
+	}
+
+	if nodePoolUpdate.Autoscaling != nil {
+		n.Autoscaling = *nodePoolUpdate.Autoscaling
+	}
+
+	if nodePoolUpdate.InstanceType != nil {
+		n.InstanceType = *nodePoolUpdate.InstanceType
+	}
+
+	return n
+}
+
+// NewService returns a new Service instance.
+func NewService(
+	genericClusters Store,
+	nodePools NodePoolStore,
+	nodePoolManager NodePoolManager,
+) Service {
+	return service{
+		genericClusters: genericClusters,
+		nodePools:       nodePools,
+		nodePoolManager: nodePoolManager,
+	}
+}
+
+type service struct {
+	genericClusters Store
+	nodePools       NodePoolStore
+	nodePoolManager NodePoolManager
+}
+
+// +testify:mock:testOnly=true
+
+// NodePoolManager is responsible for managing node pools.
+type NodePoolManager interface {
+	// CreateNodePool creates a new node pool in a cluster.
+	CreateNodePool(ctx context.Context, c cluster.Cluster, nodePool NewNodePool) error
+
+	// UpdateNodePool updates an existing node pool in a cluster.
+	UpdateNodePool(ctx context.Context, c cluster.Cluster, nodePool NodePool, nodePoolUpdate NodePoolUpdate) (string, error)
+
+	// DeleteNodePool deletes a node pool from a cluster.
+	DeleteNodePool(ctx context.Context, c cluster.Cluster, nodePoolName string) error
+
+	// ListNodePoolLabels lists the labels of the node pools in a cluster keyed by node pool names.
+	ListNodePoolLabels(ctx context.Context, c cluster.Cluster) (map[string]map[string]string, error)
+}
+
+func (s service) CreateNodePool(ctx context.Context, clusterID uint, nodePool NewNodePool) error {
+	c, err := s.genericClusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return s.nodePoolManager.CreateNodePool(ctx, c, nodePool)
+}
+
+func (s service) UpdateNodePool(
+	ctx context.Context,
+	clusterID uint,
+	nodePoolName string,
+	nodePoolUpdate NodePoolUpdate,
+) (string, error) {
+	c, err := s.genericClusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	nodePools, err := s.nodePools.ListNodePools(ctx, clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	var nodePool *NodePool
+	for i := range nodePools {
+		if nodePools[i].Name == nodePoolName {
+			nodePool = &nodePools[i]
+
+			break
+		}
+	}
+
+	if nodePool == nil {
+		return "", errors.WithStack(cluster.NodePoolNotFoundError{
+			ClusterID: clusterID,
+			NodePool:  nodePoolName,
+		})
+	}
+
+	updatedNodePool := nodePool.ApplyUpdate(nodePoolUpdate)
+
+	var violations []string
+
+	if updatedNodePool.Autoscaling.Enabled {
+		violations = validateAutoscaling(
+			updatedNodePool.Size,
+			updatedNodePool.Autoscaling.MinSize,
+			updatedNodePool.Autoscaling.MaxSize,
+		)
+	} else if updatedNodePool.Size < 1 {
+		violations = append(violations, "size cannot be lower than one")
+	}
+
+	if updatedNodePool.InstanceType == "" {
+		violations = append(violations, "instance type cannot be empty")
+	} else if updatedNodePool.InstanceType != nodePool.InstanceType {
+		temporaryName := TemporaryAgentPoolName(nodePool.Name)
+
+		for _, np := range nodePools {
+			if np.Name == temporaryName {
+				violations = append(violations, fmt.Sprintf("cannot change instance type: node pool %q is in the way of the replacement", temporaryName))
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return "", errors.WithStack(cluster.NewValidationError("invalid node pool update request", violations))
+	}
+
+	err = s.genericClusters.SetStatus(ctx, clusterID, cluster.Updating, "updating node pool")
+	if err != nil {
+		return "", err
+	}
+
+	return s.nodePoolManager.UpdateNodePool(ctx, c, *nodePool, nodePoolUpdate)
+}
+
+func (s service) DeleteNodePool(ctx context.Context, clusterID uint, nodePoolName string) error {
+	c, err := s.genericClusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return s.nodePoolManager.DeleteNodePool(ctx, c, nodePoolName)
+}
+
+// ListNodePools lists node pools from a cluster.
+func (s service) ListNodePools(ctx context.Context, clusterID uint) ([]NodePool, error) {
+	c, err := s.genericClusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "retrieving cluster failed", "clusterID", clusterID)
+	}
+
+	nodePools, err := s.nodePools.ListNodePools(ctx, clusterID)
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "listing node pools failed", "clusterID", clusterID)
+	}
+
+	labels, err := s.nodePoolManager.ListNodePoolLabels(ctx, c)
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "listing node pool labels failed", "clusterID", clusterID)
+	}
+
+	for i := range nodePools {
+		nodePools[i].Labels = labels[nodePools[i].Name]
+	}
+
+	return nodePools, nil
+}
+
+// +testify:mock:testOnly=true
+
+// Store provides an interface to the generic Cluster model persistence.
+type Store interface {
+	// GetCluster returns a generic Cluster.
+	// Returns an error with the NotFound behavior when the cluster cannot be found.
+	GetCluster(ctx context.Context, id uint) (cluster.Cluster, error)
+
+	// SetStatus sets the cluster status.
+	SetStatus(ctx context.Context, id uint, status string, statusMessage string) error
+}
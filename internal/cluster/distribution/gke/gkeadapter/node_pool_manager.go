@@ -0,0 +1,195 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkeadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke/gkeworkflow"
+	"github.com/banzaicloud/pipeline/internal/providers/google"
+	"github.com/banzaicloud/pipeline/pkg/kubernetes/custom/npls"
+)
+
+type nodePoolManager struct {
+	db                   *gorm.DB
+	nodePools            gke.NodePoolStore
+	dynamicClientFactory cluster.DynamicKubeClientFactory
+	namespace            string
+	workflowClient       client.Client
+	getUserID            func(ctx context.Context) uint
+}
+
+// NewNodePoolManager returns a new gke.NodePoolManager
+// that manages node pools asynchronously via Cadence workflows.
+func NewNodePoolManager(
+	db *gorm.DB,
+	nodePools gke.NodePoolStore,
+	dynamicClientFactory cluster.DynamicKubeClientFactory,
+	namespace string,
+	workflowClient client.Client,
+	getUserID func(ctx context.Context) uint,
+) gke.NodePoolManager {
+	return nodePoolManager{
+		db:                   db,
+		nodePools:            nodePools,
+		dynamicClientFactory: dynamicClientFactory,
+		namespace:            namespace,
+		workflowClient:       workflowClient,
+		getUserID:            getUserID,
+	}
+}
+
+func (n nodePoolManager) getClusterModel(c cluster.Cluster) (google.GKEClusterModel, error) {
+	var gkeCluster google.GKEClusterModel
+
+	err := n.db.Where(google.GKEClusterModel{ClusterID: c.ID}).First(&gkeCluster).Error
+	if err != nil {
+		return gkeCluster, errors.WrapWithDetails(err, "failed to get cluster info", "clusterId", c.ID)
+	}
+
+	return gkeCluster, nil
+}
+
+func (n nodePoolManager) startWorkflow(ctx context.Context, workflowName string, input interface{}) (string, error) {
+	workflowOptions := client.StartWorkflowOptions{
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 30 * 24 * 60 * time.Minute,
+	}
+
+	e, err := n.workflowClient.StartWorkflow(ctx, workflowOptions, workflowName, input)
+	if err != nil {
+		return "", errors.WrapWithDetails(err, "failed to start workflow", "workflow", workflowName)
+	}
+
+	return e.ID, nil
+}
+
+func (n nodePoolManager) CreateNodePool(ctx context.Context, c cluster.Cluster, nodePool gke.NewNodePool) error {
+	gkeCluster, err := n.getClusterModel(c)
+	if err != nil {
+		return err
+	}
+
+	err = n.nodePools.CreateNodePool(ctx, c.ID, n.getUserID(ctx), nodePool)
+	if err != nil {
+		return err
+	}
+
+	input := gkeworkflow.CreateNodePoolWorkflowInput{
+		ProviderSecretID: c.SecretID.String(),
+		ProjectID:        gkeCluster.ProjectId,
+		Zone:             c.Location,
+
+		OrganizationID: c.OrganizationID,
+		ClusterID:      c.ID,
+		ClusterName:    c.Name,
+		NodeVersion:    gkeCluster.NodeVersion,
+
+		NodePoolName:   nodePool.Name,
+		NodePoolLabels: nodePool.Labels,
+		Size:           nodePool.Size,
+		Autoscaling: gke.Autoscaling{
+			Enabled: nodePool.Autoscaling.Enabled,
+			MinSize: nodePool.Autoscaling.MinSize,
+			MaxSize: nodePool.Autoscaling.MaxSize,
+		},
+		InstanceType: nodePool.InstanceType,
+		Preemptible:  nodePool.Preemptible,
+	}
+
+	_, err = n.startWorkflow(ctx, gkeworkflow.CreateNodePoolWorkflowName, input)
+
+	return err
+}
+
+func (n nodePoolManager) UpdateNodePool(
+	ctx context.Context,
+	c cluster.Cluster,
+	nodePool gke.NodePool,
+	nodePoolUpdate gke.NodePoolUpdate,
+) (string, error) {
+	gkeCluster, err := n.getClusterModel(c)
+	if err != nil {
+		return "", err
+	}
+
+	updatedNodePool := nodePool.ApplyUpdate(nodePoolUpdate)
+
+	input := gkeworkflow.UpdateNodePoolWorkflowInput{
+		ProviderSecretID: c.SecretID.String(),
+		ProjectID:        gkeCluster.ProjectId,
+		Zone:             c.Location,
+
+		OrganizationID: c.OrganizationID,
+		ClusterID:      c.ID,
+		ClusterName:    c.Name,
+		NodePoolName:   nodePool.Name,
+
+		CurrentSize:        nodePool.Size,
+		CurrentAutoscaling: nodePool.Autoscaling,
+
+		Size:        updatedNodePool.Size,
+		Autoscaling: updatedNodePool.Autoscaling,
+	}
+
+	return n.startWorkflow(ctx, gkeworkflow.UpdateNodePoolWorkflowName, input)
+}
+
+func (n nodePoolManager) DeleteNodePool(ctx context.Context, c cluster.Cluster, nodePoolName string) error {
+	gkeCluster, err := n.getClusterModel(c)
+	if err != nil {
+		return err
+	}
+
+	input := gkeworkflow.DeleteNodePoolWorkflowInput{
+		ProviderSecretID: c.SecretID.String(),
+		ProjectID:        gkeCluster.ProjectId,
+		Zone:             c.Location,
+
+		OrganizationID: c.OrganizationID,
+		ClusterID:      c.ID,
+		ClusterName:    c.Name,
+		NodePoolName:   nodePoolName,
+	}
+
+	_, err = n.startWorkflow(ctx, gkeworkflow.DeleteNodePoolWorkflowName, input)
+
+	return err
+}
+
+// ListNodePoolLabels lists the labels of the node pools in a cluster keyed by node pool names.
+func (n nodePoolManager) ListNodePoolLabels(ctx context.Context, c cluster.Cluster) (map[string]map[string]string, error) {
+	clusterClient, err := n.dynamicClientFactory.FromSecret(ctx, c.ConfigSecretID.String())
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "creating dynamic Kubernetes client factory failed", "cluster", c)
+	}
+
+	labelSets, err := npls.NewManager(clusterClient, n.namespace).GetAll(ctx)
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "retrieving node pool label sets failed",
+			"cluster", c,
+			"namespace", n.namespace,
+		)
+	}
+
+	return labelSets, nil
+}
@@ -0,0 +1,125 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkeadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke"
+	"github.com/banzaicloud/pipeline/internal/providers/google"
+)
+
+type nodePoolStore struct {
+	db *gorm.DB
+}
+
+// NewNodePoolStore returns a new gke.NodePoolStore
+// that provides an interface to GKE node pool persistence.
+func NewNodePoolStore(db *gorm.DB) gke.NodePoolStore {
+	return nodePoolStore{
+		db: db,
+	}
+}
+
+func (s nodePoolStore) CreateNodePool(
+	_ context.Context,
+	clusterID uint,
+	createdBy uint,
+	nodePool gke.NewNodePool,
+) error {
+	nodePoolModel := &google.GKENodePoolModel{
+		ClusterID:        clusterID,
+		CreatedBy:        createdBy,
+		Name:             nodePool.Name,
+		Autoscaling:      nodePool.Autoscaling.Enabled,
+		Preemptible:      nodePool.Preemptible,
+		NodeMinCount:     nodePool.Autoscaling.MinSize,
+		NodeMaxCount:     nodePool.Autoscaling.MaxSize,
+		NodeCount:        nodePool.Size,
+		NodeInstanceType: nodePool.InstanceType,
+	}
+
+	err := s.db.Save(nodePoolModel).Error
+	if err != nil {
+		return errors.Wrap(err, "failed to save node pool")
+	}
+
+	return nil
+}
+
+func (s nodePoolStore) ListNodePools(_ context.Context, clusterID uint) ([]gke.NodePool, error) {
+	var nodePoolModels []google.GKENodePoolModel
+
+	err := s.db.Where(google.GKENodePoolModel{ClusterID: clusterID}).Find(&nodePoolModels).Error
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "fetching node pools from database failed", "clusterID", clusterID)
+	}
+
+	nodePools := make([]gke.NodePool, 0, len(nodePoolModels))
+	for _, nodePoolModel := range nodePoolModels {
+		nodePools = append(nodePools, gke.NodePool{
+			Name: nodePoolModel.Name,
+			Size: nodePoolModel.NodeCount,
+			Autoscaling: gke.Autoscaling{
+				Enabled: nodePoolModel.Autoscaling,
+				MinSize: nodePoolModel.NodeMinCount,
+				MaxSize: nodePoolModel.NodeMaxCount,
+			},
+			InstanceType: nodePoolModel.NodeInstanceType,
+			Preemptible:  nodePoolModel.Preemptible,
+		})
+	}
+
+	return nodePools, nil
+}
+
+func (s nodePoolStore) UpdateNodePoolSize(
+	_ context.Context,
+	clusterID uint,
+	nodePoolName string,
+	size int,
+	autoscaling gke.Autoscaling,
+) error {
+	var nodePoolModel google.GKENodePoolModel
+
+	err := s.db.Where(google.GKENodePoolModel{ClusterID: clusterID, Name: nodePoolName}).First(&nodePoolModel).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to get node pool", "clusterID", clusterID, "nodePool", nodePoolName)
+	}
+
+	err = s.db.Model(&nodePoolModel).Updates(map[string]interface{}{
+		"node_count":     size,
+		"autoscaling":    autoscaling.Enabled,
+		"node_min_count": autoscaling.MinSize,
+		"node_max_count": autoscaling.MaxSize,
+	}).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to save node pool", "clusterID", clusterID, "nodePool", nodePoolName)
+	}
+
+	return nil
+}
+
+func (s nodePoolStore) DeleteNodePool(_ context.Context, clusterID uint, nodePoolName string) error {
+	err := s.db.Where(google.GKENodePoolModel{ClusterID: clusterID, Name: nodePoolName}).Delete(&google.GKENodePoolModel{}).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to delete node pool", "clusterID", clusterID, "nodePool", nodePoolName)
+	}
+
+	return nil
+}
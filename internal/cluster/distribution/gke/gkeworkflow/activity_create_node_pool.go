@@ -0,0 +1,101 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkeworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+	"google.golang.org/api/container/v1"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke"
+	"github.com/banzaicloud/pipeline/internal/providers/google"
+)
+
+const CreateNodePoolActivityName = "gke-create-node-pool"
+
+// CreateNodePoolActivity creates a GKE node pool.
+type CreateNodePoolActivity struct {
+	clientFactory google.ClientFactory
+	secretStore   google.SecretStore
+}
+
+// CreateNodePoolActivityInput holds the parameters for creating the node pool.
+type CreateNodePoolActivityInput struct {
+	NodePoolActivityInput
+
+	NodeVersion  string
+	Size         int
+	Autoscaling  gke.Autoscaling
+	InstanceType string
+	Preemptible  bool
+}
+
+// NewCreateNodePoolActivity creates a new CreateNodePoolActivity instance.
+func NewCreateNodePoolActivity(
+	clientFactory google.ClientFactory,
+	secretStore google.SecretStore,
+) CreateNodePoolActivity {
+	return CreateNodePoolActivity{
+		clientFactory: clientFactory,
+		secretStore:   secretStore,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a CreateNodePoolActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: CreateNodePoolActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a CreateNodePoolActivity) Execute(ctx context.Context, input CreateNodePoolActivityInput) error {
+	projectID, err := google.GKEProjectID(ctx, a.secretStore, input.SecretID, input.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.clientFactory.CreateContainerService(ctx, input.SecretID)
+	if err != nil {
+		return err
+	}
+
+	nodePool := google.NewGKENodePool(
+		google.GKENodePoolModel{
+			Name:             input.NodePoolName,
+			Autoscaling:      input.Autoscaling.Enabled,
+			Preemptible:      input.Preemptible,
+			NodeMinCount:     input.Autoscaling.MinSize,
+			NodeMaxCount:     input.Autoscaling.MaxSize,
+			NodeCount:        input.Size,
+			NodeInstanceType: input.InstanceType,
+		},
+		input.NodeVersion,
+	)
+
+	operation, err := svc.Projects.Zones.Clusters.NodePools.
+		Create(projectID, input.Zone, input.ClusterName, &container.CreateNodePoolRequest{NodePool: nodePool}).
+		Context(ctx).
+		Do()
+	if isConflictError(err) {
+		// The node pool has been created by a previous attempt
+		return nil
+	}
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create node pool", "nodePool", input.NodePoolName)
+	}
+
+	return google.WaitForGKEOperation(ctx, svc, projectID, input.Zone, operation)
+}
@@ -0,0 +1,80 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkeworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/providers/google"
+)
+
+const DeleteNodePoolActivityName = "gke-delete-node-pool"
+
+// DeleteNodePoolActivity deletes a GKE node pool.
+type DeleteNodePoolActivity struct {
+	clientFactory google.ClientFactory
+	secretStore   google.SecretStore
+}
+
+// DeleteNodePoolActivityInput holds the parameters for deleting the node pool.
+type DeleteNodePoolActivityInput struct {
+	NodePoolActivityInput
+}
+
+// NewDeleteNodePoolActivity creates a new DeleteNodePoolActivity instance.
+func NewDeleteNodePoolActivity(
+	clientFactory google.ClientFactory,
+	secretStore google.SecretStore,
+) DeleteNodePoolActivity {
+	return DeleteNodePoolActivity{
+		clientFactory: clientFactory,
+		secretStore:   secretStore,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a DeleteNodePoolActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: DeleteNodePoolActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a DeleteNodePoolActivity) Execute(ctx context.Context, input DeleteNodePoolActivityInput) error {
+	projectID, err := google.GKEProjectID(ctx, a.secretStore, input.SecretID, input.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.clientFactory.CreateContainerService(ctx, input.SecretID)
+	if err != nil {
+		return err
+	}
+
+	operation, err := svc.Projects.Zones.Clusters.NodePools.
+		Delete(projectID, input.Zone, input.ClusterName, input.NodePoolName).
+		Context(ctx).
+		Do()
+	if isNotFoundError(err) {
+		// The node pool has already been deleted
+		return nil
+	}
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to delete node pool", "nodePool", input.NodePoolName)
+	}
+
+	return google.WaitForGKEOperation(ctx, svc, projectID, input.Zone, operation)
+}
@@ -0,0 +1,83 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkeworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+	"google.golang.org/api/container/v1"
+
+	"github.com/banzaicloud/pipeline/internal/providers/google"
+)
+
+const ResizeNodePoolActivityName = "gke-resize-node-pool"
+
+// ResizeNodePoolActivity sets the size of a GKE node pool.
+type ResizeNodePoolActivity struct {
+	clientFactory google.ClientFactory
+	secretStore   google.SecretStore
+}
+
+// ResizeNodePoolActivityInput holds the parameters for resizing the node pool.
+type ResizeNodePoolActivityInput struct {
+	NodePoolActivityInput
+
+	Size int
+}
+
+// NewResizeNodePoolActivity creates a new ResizeNodePoolActivity instance.
+func NewResizeNodePoolActivity(
+	clientFactory google.ClientFactory,
+	secretStore google.SecretStore,
+) ResizeNodePoolActivity {
+	return ResizeNodePoolActivity{
+		clientFactory: clientFactory,
+		secretStore:   secretStore,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a ResizeNodePoolActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: ResizeNodePoolActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a ResizeNodePoolActivity) Execute(ctx context.Context, input ResizeNodePoolActivityInput) error {
+	projectID, err := google.GKEProjectID(ctx, a.secretStore, input.SecretID, input.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.clientFactory.CreateContainerService(ctx, input.SecretID)
+	if err != nil {
+		return err
+	}
+
+	request := &container.SetNodePoolSizeRequest{
+		NodeCount: int64(input.Size),
+	}
+
+	operation, err := svc.Projects.Zones.Clusters.NodePools.
+		SetSize(projectID, input.Zone, input.ClusterName, input.NodePoolName, request).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to resize node pool", "nodePool", input.NodePoolName)
+	}
+
+	return google.WaitForGKEOperation(ctx, svc, projectID, input.Zone, operation)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkeworkflow
+
+import (
+	"context"
+
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke"
+)
+
+const SaveNodePoolSizeActivityName = "gke-save-node-pool-size"
+
+// SaveNodePoolSizeActivity saves the size and autoscaling settings of an updated node pool.
+type SaveNodePoolSizeActivity struct {
+	nodePools gke.NodePoolStore
+}
+
+// SaveNodePoolSizeActivityInput holds the parameters for saving the node pool.
+type SaveNodePoolSizeActivityInput struct {
+	ClusterID    uint
+	NodePoolName string
+
+	Size        int
+	Autoscaling gke.Autoscaling
+}
+
+// NewSaveNodePoolSizeActivity creates a new SaveNodePoolSizeActivity instance.
+func NewSaveNodePoolSizeActivity(nodePools gke.NodePoolStore) SaveNodePoolSizeActivity {
+	return SaveNodePoolSizeActivity{
+		nodePools: nodePools,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a SaveNodePoolSizeActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: SaveNodePoolSizeActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a SaveNodePoolSizeActivity) Execute(ctx context.Context, input SaveNodePoolSizeActivityInput) error {
+	return a.nodePools.UpdateNodePoolSize(ctx, input.ClusterID, input.NodePoolName, input.Size, input.Autoscaling)
+}
@@ -0,0 +1,88 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkeworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+	"google.golang.org/api/container/v1"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke"
+	"github.com/banzaicloud/pipeline/internal/providers/google"
+)
+
+const UpdateNodePoolAutoscalingActivityName = "gke-update-node-pool-autoscaling"
+
+// UpdateNodePoolAutoscalingActivity updates the autoscaling settings of a GKE node pool.
+type UpdateNodePoolAutoscalingActivity struct {
+	clientFactory google.ClientFactory
+	secretStore   google.SecretStore
+}
+
+// UpdateNodePoolAutoscalingActivityInput holds the parameters for updating the node pool autoscaling settings.
+type UpdateNodePoolAutoscalingActivityInput struct {
+	NodePoolActivityInput
+
+	Autoscaling gke.Autoscaling
+}
+
+// NewUpdateNodePoolAutoscalingActivity creates a new UpdateNodePoolAutoscalingActivity instance.
+func NewUpdateNodePoolAutoscalingActivity(
+	clientFactory google.ClientFactory,
+	secretStore google.SecretStore,
+) UpdateNodePoolAutoscalingActivity {
+	return UpdateNodePoolAutoscalingActivity{
+		clientFactory: clientFactory,
+		secretStore:   secretStore,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a UpdateNodePoolAutoscalingActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: UpdateNodePoolAutoscalingActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a UpdateNodePoolAutoscalingActivity) Execute(ctx context.Context, input UpdateNodePoolAutoscalingActivityInput) error {
+	projectID, err := google.GKEProjectID(ctx, a.secretStore, input.SecretID, input.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	svc, err := a.clientFactory.CreateContainerService(ctx, input.SecretID)
+	if err != nil {
+		return err
+	}
+
+	request := &container.SetNodePoolAutoscalingRequest{
+		Autoscaling: google.NewGKENodePoolAutoscaling(
+			input.Autoscaling.Enabled,
+			input.Autoscaling.MinSize,
+			input.Autoscaling.MaxSize,
+		),
+	}
+
+	operation, err := svc.Projects.Zones.Clusters.NodePools.
+		Autoscaling(projectID, input.Zone, input.ClusterName, input.NodePoolName, request).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update node pool autoscaling", "nodePool", input.NodePoolName)
+	}
+
+	return google.WaitForGKEOperation(ctx, svc, projectID, input.Zone, operation)
+}
@@ -0,0 +1,121 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gkeworkflow
+
+import (
+	"fmt"
+
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/gke"
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+const UpdateNodePoolWorkflowName = "gke-update-node-pool"
+
+type UpdateNodePoolWorkflow struct {
+	processLogger processlog.ProcessLogger
+}
+
+// NewUpdateNodePoolWorkflow returns a new UpdateNodePoolWorkflow.
+func NewUpdateNodePoolWorkflow(processLogger processlog.ProcessLogger) UpdateNodePoolWorkflow {
+	return UpdateNodePoolWorkflow{
+		processLogger: processLogger,
+	}
+}
+
+type UpdateNodePoolWorkflowInput struct {
+	ProviderSecretID string
+	ProjectID        string
+	Zone             string
+
+	OrganizationID uint
+	ClusterID      uint
+	ClusterName    string
+	NodePoolName   string
+
+	CurrentSize        int
+	CurrentAutoscaling gke.Autoscaling
+
+	Size        int
+	Autoscaling gke.Autoscaling
+}
+
+func (w UpdateNodePoolWorkflow) Register() {
+	workflow.RegisterWithOptions(w.Execute, workflow.RegisterOptions{Name: UpdateNodePoolWorkflowName})
+}
+
+func (w UpdateNodePoolWorkflow) Execute(ctx workflow.Context, input UpdateNodePoolWorkflowInput) (err error) {
+	ctx = workflow.WithActivityOptions(ctx, activityOptions(ctx))
+
+	clusterID := brn.New(input.OrganizationID, brn.ClusterResourceType, fmt.Sprint(input.ClusterID))
+
+	process := w.processLogger.StartProcess(ctx, clusterID.String())
+	defer func() {
+		process.Finish(ctx, err)
+	}()
+	defer func() {
+		finishClusterUpdate(ctx, input.ClusterID, "failed to update node pool", err)
+	}()
+
+	nodePoolActivityInput := NodePoolActivityInput{
+		SecretID:     input.ProviderSecretID,
+		ProjectID:    input.ProjectID,
+		Zone:         input.Zone,
+		ClusterName:  input.ClusterName,
+		NodePoolName: input.NodePoolName,
+	}
+
+	if input.Autoscaling != input.CurrentAutoscaling {
+		activityInput := UpdateNodePoolAutoscalingActivityInput{
+			NodePoolActivityInput: nodePoolActivityInput,
+			Autoscaling:           input.Autoscaling,
+		}
+
+		err = executeProcessActivity(ctx, process, UpdateNodePoolAutoscalingActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	if input.Size != input.CurrentSize {
+		activityInput := ResizeNodePoolActivityInput{
+			NodePoolActivityInput: nodePoolActivityInput,
+			Size:                  input.Size,
+		}
+
+		err = executeProcessActivity(ctx, process, ResizeNodePoolActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		activityInput := SaveNodePoolSizeActivityInput{
+			ClusterID:    input.ClusterID,
+			NodePoolName: input.NodePoolName,
+			Size:         input.Size,
+			Autoscaling:  input.Autoscaling,
+		}
+
+		err = executeProcessActivity(ctx, process, SaveNodePoolSizeActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gke
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// NewNodePool describes a new Kubernetes node pool in a GKE cluster.
+type NewNodePool struct {
+	Name        string            `mapstructure:"name"`
+	Labels      map[string]string `mapstructure:"labels"`
+	Size        int               `mapstructure:"size"`
+	Autoscaling struct {
+		Enabled bool `mapstructure:"enabled"`
+		MinSize int  `mapstructure:"minSize"`
+		MaxSize int  `mapstructure:"maxSize"`
+	} `mapstructure:"autoscaling"`
+	InstanceType string `mapstructure:"instanceType"`
+	Preemptible  bool   `mapstructure:"preemptible"`
+}
+
+// Validate semantically validates the new node pool.
+func (n NewNodePool) Validate() error {
+	var violations []string
+
+	if n.Autoscaling.Enabled {
+		violations = append(violations, validateAutoscaling(n.Size, n.Autoscaling.MinSize, n.Autoscaling.MaxSize)...)
+	} else if n.Size < 1 {
+		violations = append(violations, "size cannot be lower than one")
+	}
+
+	if n.InstanceType == "" {
+		violations = append(violations, "instance type cannot be empty")
+	}
+
+	if len(violations) > 0 {
+		return cluster.NewValidationError("invalid node pool creation request", violations)
+	}
+
+	return nil
+}
+
+func validateAutoscaling(size int, minSize int, maxSize int) []string {
+	var violations []string
+
+	if minSize < 0 {
+		violations = append(violations, "minimum autoscaling size cannot be lower than zero")
+	}
+
+	if maxSize < 1 {
+		violations = append(violations, "maximum autoscaling size cannot be lower than one")
+	}
+
+	if maxSize < minSize {
+		violations = append(violations, "maximum autoscaling size cannot be lower than the minimum")
+	}
+
+	if size < minSize {
+		violations = append(violations, "node pool size cannot be lower than the autoscaling minimum size")
+	}
+
+	if size > maxSize {
+		violations = append(violations, "node pool size cannot be higher than the autoscaling maximum size")
+	}
+
+	return violations
+}
+
+// +testify:mock:testOnly=true
+
+// NodePoolStore provides an interface for GKE node pool persistence.
+type NodePoolStore interface {
+	// CreateNodePool saves a new node pool.
+	CreateNodePool(ctx context.Context, clusterID uint, createdBy uint, nodePool NewNodePool) error
+
+	// ListNodePools retrieves the node pools of the cluster specified by its cluster ID.
+	ListNodePools(ctx context.Context, clusterID uint) ([]NodePool, error)
+
+	// UpdateNodePoolSize saves the size and autoscaling settings of an existing node pool.
+	UpdateNodePoolSize(ctx context.Context, clusterID uint, nodePoolName string, size int, autoscaling Autoscaling) error
+
+	// DeleteNodePool deletes a node pool.
+	DeleteNodePool(ctx context.Context, clusterID uint, nodePoolName string) error
+}
@@ -31,14 +31,15 @@ func NewGPUImageSelector(imageSelector ImageSelector) ImageSelector {
 }
 
 func (s gpuImageSelector) SelectImage(ctx context.Context, criteria ImageSelectionCriteria) (string, error) {
-	if !isGPUInstance(criteria.InstanceType) || criteria.ContainerRuntime != "docker" {
+	if !IsGPUInstance(criteria.InstanceType) || criteria.ContainerRuntime != "docker" {
 		return "", errors.WithStack(ImageNotFoundError)
 	}
 
 	return s.imageSelector.SelectImage(ctx, criteria)
 }
 
-func isGPUInstance(instanceType string) bool {
+// IsGPUInstance determines whether an instance type has GPUs.
+func IsGPUInstance(instanceType string) bool {
 	return strings.HasPrefix(instanceType, "p2.") || strings.HasPrefix(instanceType, "p3.") ||
 		strings.HasPrefix(instanceType, "g3.") || strings.HasPrefix(instanceType, "g4.")
 }
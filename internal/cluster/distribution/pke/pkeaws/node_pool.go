@@ -0,0 +1,96 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeaws
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// NewNodePool describes a new Kubernetes node pool in a PKE cluster on Amazon.
+type NewNodePool struct {
+	Name        string            `mapstructure:"name"`
+	Labels      map[string]string `mapstructure:"labels"`
+	Size        int               `mapstructure:"size"`
+	Autoscaling struct {
+		Enabled bool `mapstructure:"enabled"`
+		MinSize int  `mapstructure:"minSize"`
+		MaxSize int  `mapstructure:"maxSize"`
+	} `mapstructure:"autoscaling"`
+	VolumeSize   int    `mapstructure:"volumeSize"`
+	InstanceType string `mapstructure:"instanceType"`
+	Image        string `mapstructure:"image"`
+	SpotPrice    string `mapstructure:"spotPrice"`
+	SubnetID     string `mapstructure:"subnetId"`
+}
+
+// Validate semantically validates the new node pool.
+//
+// Some cluster specific compatibility information (eg. subnet settings) should be validated by an external validator.
+func (n NewNodePool) Validate() error {
+	var violations []string
+
+	if n.Name == "master" {
+		violations = append(violations, "node pool name \"master\" is reserved")
+	}
+
+	if n.Autoscaling.Enabled {
+		if n.Autoscaling.MinSize < 0 {
+			violations = append(violations, "minimum autoscaling size cannot be lower than zero")
+		}
+
+		if n.Autoscaling.MaxSize <= n.Autoscaling.MinSize {
+			violations = append(violations, "maximum autoscaling size cannot be lower than the minimum")
+		}
+
+		if n.Size < n.Autoscaling.MinSize {
+			violations = append(violations, "node pool size cannot be lower than the autoscaling minimum size")
+		}
+
+		if n.Size > n.Autoscaling.MaxSize {
+			violations = append(violations, "node pool size cannot be higher than the autoscaling maximum size")
+		}
+	} else if n.Size < 1 {
+		violations = append(violations, "size cannot be lower than one")
+	}
+
+	if n.InstanceType == "" {
+		violations = append(violations, "instance type cannot be empty")
+	}
+
+	if len(violations) > 0 {
+		return cluster.NewValidationError("invalid node pool creation request", violations)
+	}
+
+	return nil
+}
+
+// +testify:mock:testOnly=true
+
+// NodePoolStore provides an interface for PKE on Amazon node pool persistence.
+type NodePoolStore interface {
+	// CreateNodePool saves a new node pool.
+	CreateNodePool(ctx context.Context, clusterID uint, createdBy uint, nodePool NewNodePool) error
+
+	// ListNodePools retrieves the worker node pools of the cluster specified by its cluster ID.
+	ListNodePools(ctx context.Context, clusterID uint) ([]NodePool, error)
+
+	// UpdateNodePoolImage saves the image and volume size of an existing node pool.
+	UpdateNodePoolImage(ctx context.Context, clusterID uint, nodePoolName string, image string, volumeSize int) error
+
+	// DeleteNodePool deletes a node pool.
+	DeleteNodePool(ctx context.Context, clusterID uint, nodePoolName string) error
+}
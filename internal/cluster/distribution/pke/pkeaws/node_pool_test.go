@@ -0,0 +1,75 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeaws
+
+import (
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+func TestNewNodePool_Validate(t *testing.T) {
+	base := NewNodePool{
+		Name:         "pool",
+		Size:         1,
+		InstanceType: "c5.large",
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, base.Validate())
+	})
+
+	t.Run("ReservedName", func(t *testing.T) {
+		pool := base
+		pool.Name = "master"
+
+		err := pool.Validate()
+		assert.IsType(t, cluster.ValidationError{}, errors.Cause(err))
+	})
+
+	t.Run("PoolZeroSize", func(t *testing.T) {
+		pool := base
+		pool.Size = 0
+
+		err := pool.Validate()
+		assert.IsType(t, cluster.ValidationError{}, errors.Cause(err))
+	})
+
+	t.Run("PoolSizeOverMax", func(t *testing.T) {
+		pool := base
+		pool.Size = 3
+		pool.Autoscaling.Enabled = true
+		pool.Autoscaling.MinSize = 1
+		pool.Autoscaling.MaxSize = 2
+
+		err := pool.Validate()
+		assert.IsType(t, cluster.ValidationError{}, errors.Cause(err))
+	})
+
+	t.Run("MissingInstanceType", func(t *testing.T) {
+		pool := base
+		pool.InstanceType = ""
+
+		err := pool.Validate()
+		assert.IsType(t, cluster.ValidationError{}, errors.Cause(err))
+	})
+}
+
+func TestNodePoolStackName(t *testing.T) {
+	assert.Equal(t, "pke-pool-cluster-worker-pool1", NodePoolStackName("cluster", "pool1"))
+}
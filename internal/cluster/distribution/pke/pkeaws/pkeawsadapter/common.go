@@ -0,0 +1,87 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeawsadapter
+
+import (
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/banzaicloud/pipeline/internal/providers/pke"
+)
+
+// getClusterModel loads a PKE on Amazon cluster model with its network and node pool settings.
+func getClusterModel(db *gorm.DB, clusterID uint) (pke.EC2PKEClusterModel, error) {
+	var ec2Cluster pke.EC2PKEClusterModel
+
+	err := db.
+		Where(pke.EC2PKEClusterModel{ClusterID: clusterID}).
+		Preload("Network").
+		Preload("NodePools").
+		Preload("Kubernetes").
+		Preload("CRI").
+		First(&ec2Cluster).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return ec2Cluster, errors.NewWithDetails(
+			"cluster model is inconsistent",
+			"clusterId", clusterID,
+		)
+	}
+	if err != nil {
+		return ec2Cluster, errors.WrapWithDetails(
+			err, "failed to get cluster info",
+			"clusterId", clusterID,
+		)
+	}
+
+	return ec2Cluster, nil
+}
+
+// getClusterNetworkConfig returns the Amazon specific settings of the cluster network.
+func getClusterNetworkConfig(ec2Cluster pke.EC2PKEClusterModel) (pke.NetworkCloudProviderConfigAmazon, error) {
+	var networkConfig pke.NetworkCloudProviderConfigAmazon
+
+	err := mapstructure.Decode(ec2Cluster.Network.CloudProviderConfig, &networkConfig)
+	if err != nil {
+		return networkConfig, errors.WrapWithDetails(err, "failed to decode cluster network config", "clusterId", ec2Cluster.ClusterID)
+	}
+
+	return networkConfig, nil
+}
+
+// getClusterSubnets returns the subnets of the cluster network.
+func getClusterSubnets(ec2Cluster pke.EC2PKEClusterModel) ([]string, error) {
+	networkConfig, err := getClusterNetworkConfig(ec2Cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	subnets := make([]string, 0, len(networkConfig.Subnets))
+	for _, subnet := range networkConfig.Subnets {
+		subnets = append(subnets, string(subnet))
+	}
+
+	return subnets, nil
+}
+
+func isWorkerNodePool(nodePool pke.NodePool) bool {
+	for _, role := range nodePool.Roles {
+		if role == pke.RoleMaster {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,197 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeawsadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws/pkeawsworkflow"
+	"github.com/banzaicloud/pipeline/pkg/kubernetes/custom/npls"
+)
+
+type nodePoolManager struct {
+	db                      *gorm.DB
+	nodePools               pkeaws.NodePoolStore
+	dynamicClientFactory    cluster.DynamicKubeClientFactory
+	namespace               string
+	workflowClient          client.Client
+	externalBaseURL         string
+	externalBaseURLInsecure bool
+	getUserID               func(ctx context.Context) uint
+}
+
+// NewNodePoolManager returns a new pkeaws.NodePoolManager
+// that manages node pools asynchronously via Cadence workflows.
+func NewNodePoolManager(
+	db *gorm.DB,
+	nodePools pkeaws.NodePoolStore,
+	dynamicClientFactory cluster.DynamicKubeClientFactory,
+	namespace string,
+	workflowClient client.Client,
+	externalBaseURL string,
+	externalBaseURLInsecure bool,
+	getUserID func(ctx context.Context) uint,
+) pkeaws.NodePoolManager {
+	return nodePoolManager{
+		db:                      db,
+		nodePools:               nodePools,
+		dynamicClientFactory:    dynamicClientFactory,
+		namespace:               namespace,
+		workflowClient:          workflowClient,
+		externalBaseURL:         externalBaseURL,
+		externalBaseURLInsecure: externalBaseURLInsecure,
+		getUserID:               getUserID,
+	}
+}
+
+func (n nodePoolManager) startWorkflow(ctx context.Context, workflowName string, input interface{}) (string, error) {
+	workflowOptions := client.StartWorkflowOptions{
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 30 * 24 * 60 * time.Minute,
+	}
+
+	e, err := n.workflowClient.StartWorkflow(ctx, workflowOptions, workflowName, input)
+	if err != nil {
+		return "", errors.WrapWithDetails(err, "failed to start workflow", "workflow", workflowName)
+	}
+
+	return e.ID, nil
+}
+
+func (n nodePoolManager) CreateNodePool(ctx context.Context, c cluster.Cluster, nodePool pkeaws.NewNodePool) error {
+	ec2Cluster, err := getClusterModel(n.db, c.ID)
+	if err != nil {
+		return err
+	}
+
+	networkConfig, err := getClusterNetworkConfig(ec2Cluster)
+	if err != nil {
+		return err
+	}
+
+	subnetIDs := []string{nodePool.SubnetID}
+	if nodePool.SubnetID == "" {
+		subnetIDs, err = getClusterSubnets(ec2Cluster)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = n.nodePools.CreateNodePool(ctx, c.ID, n.getUserID(ctx), nodePool)
+	if err != nil {
+		return err
+	}
+
+	input := pkeawsworkflow.CreateNodePoolWorkflowInput{
+		OrganizationID: c.OrganizationID,
+		SecretID:       c.SecretID.ResourceID, // TODO: the underlying secret store is the legacy one
+		Region:         c.Location,
+
+		ClusterID:   c.ID,
+		ClusterName: c.Name,
+
+		PipelineExternalURL:         n.externalBaseURL,
+		PipelineExternalURLInsecure: n.externalBaseURLInsecure,
+
+		VPCID:     networkConfig.VPCID,
+		SubnetIDs: subnetIDs,
+
+		NodePoolName:   nodePool.Name,
+		NodePoolLabels: nodePool.Labels,
+		Size:           nodePool.Size,
+		Autoscaling: pkeaws.Autoscaling{
+			Enabled: nodePool.Autoscaling.Enabled,
+			MinSize: nodePool.Autoscaling.MinSize,
+			MaxSize: nodePool.Autoscaling.MaxSize,
+		},
+		VolumeSize:   nodePool.VolumeSize,
+		InstanceType: nodePool.InstanceType,
+		Image:        nodePool.Image,
+		SpotPrice:    nodePool.SpotPrice,
+	}
+
+	_, err = n.startWorkflow(ctx, pkeawsworkflow.CreateNodePoolWorkflowName, input)
+
+	return err
+}
+
+func (n nodePoolManager) UpdateNodePool(
+	ctx context.Context,
+	c cluster.Cluster,
+	nodePool pkeaws.NodePool,
+	nodePoolUpdate pkeaws.NodePoolUpdate,
+) (string, error) {
+	input := pkeawsworkflow.UpdateNodePoolWorkflowInput{
+		ProviderSecretID: c.SecretID.String(),
+		Region:           c.Location,
+
+		StackName: pkeaws.NodePoolStackName(c.Name, nodePool.Name),
+
+		OrganizationID: c.OrganizationID,
+		ClusterID:      c.ID,
+		ClusterName:    c.Name,
+		NodePoolName:   nodePool.Name,
+
+		CurrentNodeImage:      nodePool.Image,
+		CurrentNodeVolumeSize: nodePool.VolumeSize,
+
+		NodeVolumeSize: nodePoolUpdate.VolumeSize,
+		NodeImage:      nodePoolUpdate.Image,
+
+		Options: nodePoolUpdate.Options,
+	}
+
+	return n.startWorkflow(ctx, pkeawsworkflow.UpdateNodePoolWorkflowName, input)
+}
+
+func (n nodePoolManager) DeleteNodePool(ctx context.Context, c cluster.Cluster, nodePoolName string) error {
+	input := pkeawsworkflow.DeleteNodePoolWorkflowInput{
+		OrganizationID: c.OrganizationID,
+
+		ClusterID:   c.ID,
+		ClusterName: c.Name,
+
+		NodePoolName: nodePoolName,
+	}
+
+	_, err := n.startWorkflow(ctx, pkeawsworkflow.DeleteNodePoolWorkflowName, input)
+
+	return err
+}
+
+// ListNodePoolLabels lists the labels of the node pools in a cluster keyed by node pool names.
+func (n nodePoolManager) ListNodePoolLabels(ctx context.Context, c cluster.Cluster) (map[string]map[string]string, error) {
+	clusterClient, err := n.dynamicClientFactory.FromSecret(ctx, c.ConfigSecretID.String())
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "creating dynamic Kubernetes client factory failed", "cluster", c)
+	}
+
+	labelSets, err := npls.NewManager(clusterClient, n.namespace).GetAll(ctx)
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "retrieving node pool label sets failed",
+			"cluster", c,
+			"namespace", n.namespace,
+		)
+	}
+
+	return labelSets, nil
+}
@@ -0,0 +1,86 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeawsadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
+)
+
+type nodePoolProcessor struct {
+	db            *gorm.DB
+	imageSelector pkeaws.ImageSelector
+}
+
+// NewNodePoolProcessor returns a new cluster.NodePoolProcessor
+// that processes a PKE on Amazon node pool request.
+func NewNodePoolProcessor(db *gorm.DB, imageSelector pkeaws.ImageSelector) cluster.NodePoolProcessor {
+	return nodePoolProcessor{
+		db:            db,
+		imageSelector: imageSelector,
+	}
+}
+
+func (p nodePoolProcessor) ProcessNew(
+	ctx context.Context,
+	cluster cluster.Cluster,
+	rawNodePool cluster.NewRawNodePool,
+) (cluster.NewRawNodePool, error) {
+	var nodePool pkeaws.NewNodePool
+
+	err := mapstructure.Decode(rawNodePool, &nodePool)
+	if err != nil {
+		return rawNodePool, errors.Wrap(err, "failed to decode node pool")
+	}
+
+	// Default node pool image
+	if nodePool.Image == "" {
+		ec2Cluster, err := getClusterModel(p.db, cluster.ID)
+		if err != nil {
+			return rawNodePool, err
+		}
+
+		containerRuntime := string(ec2Cluster.CRI.Runtime)
+
+		// GPU images are only available with Docker
+		if pkeaws.IsGPUInstance(nodePool.InstanceType) {
+			containerRuntime = "docker"
+		}
+
+		criteria := pkeaws.ImageSelectionCriteria{
+			Region:            cluster.Location,
+			InstanceType:      nodePool.InstanceType,
+			PKEVersion:        pkeaws.Version,
+			KubernetesVersion: ec2Cluster.Kubernetes.Version,
+			OperatingSystem:   "ubuntu",
+			ContainerRuntime:  containerRuntime,
+		}
+
+		image, err := p.imageSelector.SelectImage(ctx, criteria)
+		if err != nil {
+			return rawNodePool, err
+		}
+
+		rawNodePool["image"] = image
+	}
+
+	return rawNodePool, nil
+}
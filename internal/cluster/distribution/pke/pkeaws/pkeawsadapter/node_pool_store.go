@@ -0,0 +1,177 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeawsadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
+	"github.com/banzaicloud/pipeline/internal/providers/pke"
+)
+
+type nodePoolStore struct {
+	db *gorm.DB
+}
+
+// NewNodePoolStore returns a new pkeaws.NodePoolStore
+// that provides an interface to PKE on Amazon node pool persistence.
+func NewNodePoolStore(db *gorm.DB) pkeaws.NodePoolStore {
+	return nodePoolStore{
+		db: db,
+	}
+}
+
+func (s nodePoolStore) CreateNodePool(
+	_ context.Context,
+	clusterID uint,
+	createdBy uint,
+	nodePool pkeaws.NewNodePool,
+) error {
+	var providerConfig pke.NodePoolProviderConfigAmazon
+
+	providerConfig.AutoScalingGroup.Name = nodePool.Name
+	providerConfig.AutoScalingGroup.InstanceType = nodePool.InstanceType
+	providerConfig.AutoScalingGroup.LaunchConfigurationName = nodePool.Name
+	providerConfig.AutoScalingGroup.Image = nodePool.Image
+	providerConfig.AutoScalingGroup.VolumeSize = nodePool.VolumeSize
+	providerConfig.AutoScalingGroup.SpotPrice = nodePool.SpotPrice
+	providerConfig.AutoScalingGroup.Size.Desired = nodePool.Size
+
+	if nodePool.Autoscaling.Enabled {
+		providerConfig.AutoScalingGroup.Size.Min = nodePool.Autoscaling.MinSize
+		providerConfig.AutoScalingGroup.Size.Max = nodePool.Autoscaling.MaxSize
+	} else {
+		providerConfig.AutoScalingGroup.Size.Min = nodePool.Size
+		providerConfig.AutoScalingGroup.Size.Max = nodePool.Size
+	}
+
+	if nodePool.SubnetID != "" {
+		providerConfig.AutoScalingGroup.Subnets = pke.Subnets{pke.Subnet(nodePool.SubnetID)}
+	}
+
+	nodePoolModel := &pke.NodePool{
+		ClusterID:   clusterID,
+		CreatedBy:   createdBy,
+		Name:        nodePool.Name,
+		Roles:       pke.Roles{pke.RoleWorker},
+		Autoscaling: nodePool.Autoscaling.Enabled,
+		Provider:    pke.NPPAmazon,
+		ProviderConfig: pke.Config{
+			"autoScalingGroup": providerConfig.AutoScalingGroup,
+		},
+	}
+
+	err := s.db.Save(nodePoolModel).Error
+	if err != nil {
+		return errors.Wrap(err, "failed to save node pool")
+	}
+
+	return nil
+}
+
+func (s nodePoolStore) DeleteNodePool(_ context.Context, clusterID uint, nodePoolName string) error {
+	err := s.db.
+		Where(pke.NodePool{ClusterID: clusterID, Name: nodePoolName}).
+		Delete(pke.NodePool{}).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to delete node pool", "clusterID", clusterID, "nodePool", nodePoolName)
+	}
+
+	return nil
+}
+
+func (s nodePoolStore) ListNodePools(_ context.Context, clusterID uint) ([]pkeaws.NodePool, error) {
+	var nodePoolModels []pke.NodePool
+
+	err := s.db.Where(pke.NodePool{ClusterID: clusterID}).Find(&nodePoolModels).Error
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "fetching node pools from database failed", "clusterID", clusterID)
+	}
+
+	nodePools := make([]pkeaws.NodePool, 0, len(nodePoolModels))
+	for _, nodePoolModel := range nodePoolModels {
+		if !isWorkerNodePool(nodePoolModel) {
+			continue
+		}
+
+		var providerConfig pke.NodePoolProviderConfigAmazon
+
+		err := mapstructure.Decode(nodePoolModel.ProviderConfig, &providerConfig)
+		if err != nil {
+			return nil, errors.WrapWithDetails(err, "failed to decode node pool config", "clusterID", clusterID, "nodePool", nodePoolModel.Name)
+		}
+
+		asg := providerConfig.AutoScalingGroup
+
+		subnetIDs := make([]string, 0, len(asg.Subnets))
+		for _, subnet := range asg.Subnets {
+			subnetIDs = append(subnetIDs, string(subnet))
+		}
+
+		nodePools = append(nodePools, pkeaws.NodePool{
+			Name: nodePoolModel.Name,
+			Size: asg.Size.Desired,
+			Autoscaling: pkeaws.Autoscaling{
+				Enabled: nodePoolModel.Autoscaling,
+				MinSize: asg.Size.Min,
+				MaxSize: asg.Size.Max,
+			},
+			VolumeSize:   asg.VolumeSize,
+			InstanceType: asg.InstanceType,
+			Image:        asg.Image,
+			SpotPrice:    asg.SpotPrice,
+			SubnetIDs:    subnetIDs,
+		})
+	}
+
+	return nodePools, nil
+}
+
+func (s nodePoolStore) UpdateNodePoolImage(
+	_ context.Context,
+	clusterID uint,
+	nodePoolName string,
+	image string,
+	volumeSize int,
+) error {
+	var nodePoolModel pke.NodePool
+
+	err := s.db.Where(pke.NodePool{ClusterID: clusterID, Name: nodePoolName}).First(&nodePoolModel).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to get node pool", "clusterID", clusterID, "nodePool", nodePoolName)
+	}
+
+	var providerConfig pke.NodePoolProviderConfigAmazon
+
+	err = mapstructure.Decode(nodePoolModel.ProviderConfig, &providerConfig)
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to decode node pool config", "clusterID", clusterID, "nodePool", nodePoolName)
+	}
+
+	providerConfig.AutoScalingGroup.Image = image
+	providerConfig.AutoScalingGroup.VolumeSize = volumeSize
+	nodePoolModel.ProviderConfig["autoScalingGroup"] = providerConfig.AutoScalingGroup
+
+	err = s.db.Save(&nodePoolModel).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to save node pool", "clusterID", clusterID, "nodePool", nodePoolName)
+	}
+
+	return nil
+}
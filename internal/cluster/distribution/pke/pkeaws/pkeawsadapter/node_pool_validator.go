@@ -0,0 +1,92 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeawsadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
+)
+
+type nodePoolValidator struct {
+	db *gorm.DB
+}
+
+// NewNodePoolValidator returns a new cluster.NodePoolValidator
+// that validates a PKE on Amazon node pool request.
+func NewNodePoolValidator(db *gorm.DB) cluster.NodePoolValidator {
+	return nodePoolValidator{
+		db: db,
+	}
+}
+
+func (v nodePoolValidator) ValidateNew(
+	_ context.Context,
+	c cluster.Cluster,
+	rawNodePool cluster.NewRawNodePool,
+) error {
+	var nodePool pkeaws.NewNodePool
+
+	err := mapstructure.Decode(rawNodePool, &nodePool)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode node pool")
+	}
+
+	message := "invalid node pool creation request"
+	var violations []string
+
+	verr := nodePool.Validate()
+	if err, ok := verr.(cluster.ValidationError); ok {
+		message = err.Error()
+		violations = err.Violations()
+	}
+
+	if nodePool.SubnetID != "" {
+		ec2Cluster, err := getClusterModel(v.db, c.ID)
+		if err != nil {
+			return err
+		}
+
+		subnets, err := getClusterSubnets(ec2Cluster)
+		if err != nil {
+			return err
+		}
+
+		validSubnet := false
+
+		for _, s := range subnets {
+			if s == nodePool.SubnetID {
+				validSubnet = true
+
+				break
+			}
+		}
+
+		if !validSubnet {
+			violations = append(violations, "subnet cannot be found in the cluster")
+		}
+	}
+
+	if len(violations) > 0 {
+		return cluster.NewValidationError(message, violations)
+	}
+
+	return nil
+}
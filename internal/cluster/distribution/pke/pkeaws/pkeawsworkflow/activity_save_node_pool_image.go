@@ -0,0 +1,56 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeawsworkflow
+
+import (
+	"context"
+
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
+)
+
+const SaveNodePoolImageActivityName = "pke-aws-save-node-pool-image"
+
+// SaveNodePoolImageActivity saves the image and volume size of an updated node pool.
+type SaveNodePoolImageActivity struct {
+	nodePools pkeaws.NodePoolStore
+}
+
+// SaveNodePoolImageActivityInput holds the parameters for saving the node pool.
+type SaveNodePoolImageActivityInput struct {
+	ClusterID    uint
+	NodePoolName string
+
+	NodeImage      string
+	NodeVolumeSize int
+}
+
+// NewSaveNodePoolImageActivity creates a new SaveNodePoolImageActivity instance.
+func NewSaveNodePoolImageActivity(nodePools pkeaws.NodePoolStore) SaveNodePoolImageActivity {
+	return SaveNodePoolImageActivity{
+		nodePools: nodePools,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a SaveNodePoolImageActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: SaveNodePoolImageActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a SaveNodePoolImageActivity) Execute(ctx context.Context, input SaveNodePoolImageActivityInput) error {
+	return a.nodePools.UpdateNodePoolImage(ctx, input.ClusterID, input.NodePoolName, input.NodeImage, input.NodeVolumeSize)
+}
@@ -0,0 +1,150 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeawsworkflow
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/providers/amazon"
+	sdkAmazon "github.com/banzaicloud/pipeline/pkg/sdk/providers/amazon"
+	sdkCloudFormation "github.com/banzaicloud/pipeline/pkg/sdk/providers/amazon/cloudformation"
+)
+
+const awsNoUpdatesError = "No updates are to be performed."
+
+const UpdateNodePoolStackActivityName = "pke-aws-update-node-pool-stack"
+
+// UpdateNodePoolStackActivity updates the CloudFormation stack of an existing node pool.
+type UpdateNodePoolStackActivity struct {
+	sessionFactory AWSSessionFactory
+
+	// body of the cloud formation template
+	cloudFormationTemplate string
+}
+
+// UpdateNodePoolStackActivityInput holds the parameters for the node pool stack update.
+type UpdateNodePoolStackActivityInput struct {
+	SecretID string
+	Region   string
+
+	StackName string
+
+	NodeImage      string
+	NodeVolumeSize int
+
+	MaxBatchSize int
+}
+
+type UpdateNodePoolStackActivityOutput struct {
+	NodePoolChanged bool
+}
+
+// NewUpdateNodePoolStackActivity creates a new UpdateNodePoolStackActivity instance.
+func NewUpdateNodePoolStackActivity(sessionFactory AWSSessionFactory, cloudFormationTemplate string) UpdateNodePoolStackActivity {
+	return UpdateNodePoolStackActivity{
+		sessionFactory:         sessionFactory,
+		cloudFormationTemplate: cloudFormationTemplate,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a UpdateNodePoolStackActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: UpdateNodePoolStackActivityName})
+}
+
+// Execute is the main body of the activity, returns true if there was any update and that was successful.
+func (a UpdateNodePoolStackActivity) Execute(ctx context.Context, input UpdateNodePoolStackActivityInput) (UpdateNodePoolStackActivityOutput, error) {
+	sess, err := a.sessionFactory.NewSession(input.SecretID, input.Region)
+	if err = errors.WrapIf(err, "failed to create AWS session"); err != nil {
+		return UpdateNodePoolStackActivityOutput{}, err
+	}
+
+	cloudformationClient := cloudformation.New(sess)
+
+	maxBatchSize := input.MaxBatchSize
+	if maxBatchSize < 1 {
+		maxBatchSize = 1
+	}
+
+	stackParams := []*cloudformation.Parameter{
+		sdkCloudFormation.NewOptionalStackParameter("ImageId", input.NodeImage != "", input.NodeImage),
+		sdkCloudFormation.NewOptionalStackParameter("VolumeSize", input.NodeVolumeSize > 0, strconv.Itoa(input.NodeVolumeSize)),
+		{
+			// the parameter might be missing from stacks created with an earlier template
+			ParameterKey:   aws.String("NodeAutoScalingGroupMaxBatchSize"),
+			ParameterValue: aws.String(strconv.Itoa(maxBatchSize)),
+		},
+		{
+			// instances are only replaced in rolling batches by node pool updates
+			ParameterKey:   aws.String("NodeRollingUpdate"),
+			ParameterValue: aws.String("true"),
+		},
+	}
+
+	// sizes are managed on the auto scaling group directly,
+	// keeping the previous values leaves the actual capacity untouched
+	for _, key := range []string{
+		"SSHLocation",
+		"InstanceType",
+		"ClusterName",
+		"NodeGroupName",
+		"VPCId",
+		"SubnetIds",
+		"VPCDefaultSecurityGroupId",
+		"IamInstanceProfile",
+		"ClusterSecurityGroup",
+		"PkeCommand",
+		"PkeVersion",
+		"KeyName",
+		"MinSize",
+		"MaxSize",
+		"DesiredCapacity",
+		"NodeSpotPrice",
+		"ClusterAutoscalerEnabled",
+	} {
+		stackParams = append(stackParams, &cloudformation.Parameter{
+			ParameterKey:     aws.String(key),
+			UsePreviousValue: aws.Bool(true),
+		})
+	}
+
+	// we don't reuse the creation time template, since it may have changed
+	updateStackInput := &cloudformation.UpdateStackInput{
+		ClientRequestToken: aws.String(sdkAmazon.NewNormalizedClientRequestToken(activity.GetInfo(ctx).WorkflowExecution.ID)),
+		StackName:          aws.String(input.StackName),
+		Parameters:         stackParams,
+		Tags:               amazon.PipelineTags(),
+		TemplateBody:       aws.String(a.cloudFormationTemplate),
+	}
+
+	_, err = cloudformationClient.UpdateStack(updateStackInput)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "ValidationError" && strings.HasPrefix(awsErr.Message(), awsNoUpdatesError) {
+			return UpdateNodePoolStackActivityOutput{}, nil
+		}
+
+		return UpdateNodePoolStackActivityOutput{}, errors.WrapIfWithDetails(err, "failed to update node pool stack", "stackName", input.StackName)
+	}
+
+	return UpdateNodePoolStackActivityOutput{NodePoolChanged: true}, nil
+}
@@ -0,0 +1,244 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeawsworkflow
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
+	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow"
+	pkgCadence "github.com/banzaicloud/pipeline/pkg/cadence"
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+const CreateNodePoolWorkflowName = "pke-aws-create-node-pool"
+
+type CreateNodePoolWorkflow struct {
+	processLogger processlog.ProcessLogger
+}
+
+// NewCreateNodePoolWorkflow returns a new CreateNodePoolWorkflow.
+func NewCreateNodePoolWorkflow(processLogger processlog.ProcessLogger) CreateNodePoolWorkflow {
+	return CreateNodePoolWorkflow{
+		processLogger: processLogger,
+	}
+}
+
+type CreateNodePoolWorkflowInput struct {
+	OrganizationID uint
+	SecretID       string
+	Region         string
+
+	ClusterID   uint
+	ClusterName string
+
+	PipelineExternalURL         string
+	PipelineExternalURLInsecure bool
+
+	VPCID     string
+	SubnetIDs []string
+
+	NodePoolName   string
+	NodePoolLabels map[string]string
+	Size           int
+	Autoscaling    pkeaws.Autoscaling
+	VolumeSize     int
+	InstanceType   string
+	Image          string
+	SpotPrice      string
+}
+
+func (w CreateNodePoolWorkflow) Register() {
+	workflow.RegisterWithOptions(w.Execute, workflow.RegisterOptions{Name: CreateNodePoolWorkflowName})
+}
+
+func (w CreateNodePoolWorkflow) Execute(ctx workflow.Context, input CreateNodePoolWorkflowInput) (err error) {
+	ctx = workflow.WithActivityOptions(ctx, activityOptions(ctx))
+
+	clusterID := brn.New(input.OrganizationID, brn.ClusterResourceType, fmt.Sprint(input.ClusterID))
+
+	process := w.processLogger.StartProcess(ctx, clusterID.String())
+	defer func() {
+		process.Finish(ctx, err)
+	}()
+	defer func() {
+		finishClusterUpdate(ctx, input.ClusterID, "failed to create node pool", err)
+	}()
+
+	{
+		activityInput := clusterworkflow.CreateNodePoolLabelSetActivityInput{
+			ClusterID: input.ClusterID,
+			RawNodePool: cluster.NewRawNodePool{
+				"name":   input.NodePoolName,
+				"labels": input.NodePoolLabels,
+			},
+		}
+
+		err = executeProcessActivity(ctx, process, clusterworkflow.CreateNodePoolLabelSetActivityName, activityInput, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	workerPoolInput, err := w.prepareWorkerPool(ctx, process, input)
+	if err != nil {
+		// Nothing has been created in the cloud yet
+		activityInput := DeleteStoredNodePoolActivityInput{
+			ClusterID:    input.ClusterID,
+			NodePoolName: input.NodePoolName,
+		}
+
+		_ = workflow.ExecuteActivity(ctx, DeleteStoredNodePoolActivityName, activityInput).Get(ctx, nil)
+
+		return err
+	}
+
+	{
+		activityOptions := activityOptions(ctx)
+		activityOptions.StartToCloseTimeout = 60 * time.Minute
+		activityOptions.RetryPolicy.NonRetriableErrorReasons = append(
+			activityOptions.RetryPolicy.NonRetriableErrorReasons,
+			pkeworkflow.ErrReasonStackFailed,
+		)
+
+		// The node pool is kept on failure, so that its stack can be cleaned up by deleting the node pool
+		err = executeProcessActivity(
+			workflow.WithActivityOptions(ctx, activityOptions),
+			process,
+			pkeworkflow.CreateWorkerPoolActivityName,
+			workerPoolInput,
+			nil,
+		)
+		if err != nil {
+			return pkgCadence.UnwrapError(err)
+		}
+	}
+
+	return installClusterAutoscaler(ctx, process, input.ClusterID)
+}
+
+// prepareWorkerPool collects the parameters of the worker pool stack
+// and saves the volume size selected for the node pool.
+func (w CreateNodePoolWorkflow) prepareWorkerPool(
+	ctx workflow.Context,
+	process processlog.Process,
+	input CreateNodePoolWorkflowInput,
+) (pkeworkflow.CreateWorkerPoolActivityInput, error) {
+	awsActivityInput := pkeworkflow.AWSActivityInput{
+		OrganizationID: input.OrganizationID,
+		SecretID:       input.SecretID,
+		Region:         input.Region,
+	}
+
+	volumeSize := input.VolumeSize
+	{
+		activityInput := pkeworkflow.SelectVolumeSizeActivityInput{
+			AWSActivityInput: awsActivityInput,
+			ImageID:          input.Image,
+		}
+
+		var activityOutput pkeworkflow.SelectVolumeSizeActivityOutput
+
+		err := executeProcessActivity(ctx, process, pkeworkflow.SelectVolumeSizeActivityName, activityInput, &activityOutput)
+		if err != nil {
+			return pkeworkflow.CreateWorkerPoolActivityInput{}, err
+		}
+
+		volumeSize, err = pkeaws.SelectVolumeSize(activityOutput.VolumeSize, 0, input.VolumeSize)
+		if err != nil {
+			return pkeworkflow.CreateWorkerPoolActivityInput{}, err
+		}
+	}
+
+	{
+		activityInput := SaveNodePoolImageActivityInput{
+			ClusterID:      input.ClusterID,
+			NodePoolName:   input.NodePoolName,
+			NodeImage:      input.Image,
+			NodeVolumeSize: volumeSize,
+		}
+
+		err := executeProcessActivity(ctx, process, SaveNodePoolImageActivityName, activityInput, nil)
+		if err != nil {
+			return pkeworkflow.CreateWorkerPoolActivityInput{}, err
+		}
+	}
+
+	var masterOutput map[string]string
+	{
+		activityInput := pkeworkflow.WaitCFCompletionActivityInput{
+			AWSActivityInput: awsActivityInput,
+			StackID:          "pke-master-" + input.ClusterName,
+		}
+
+		err := executeProcessActivity(ctx, process, pkeworkflow.WaitCFCompletionActivityName, activityInput, &masterOutput)
+		if err != nil {
+			return pkeworkflow.CreateWorkerPoolActivityInput{}, err
+		}
+	}
+
+	var vpcDefaultSecurityGroupID string
+	{
+		activityInput := pkeworkflow.GetVpcDefaultSecurityGroupActivityInput{
+			AWSActivityInput: awsActivityInput,
+			ClusterID:        input.ClusterID,
+			VpcID:            input.VPCID,
+		}
+
+		err := executeProcessActivity(ctx, process, pkeworkflow.GetVpcDefaultSecurityGroupActivityName, activityInput, &vpcDefaultSecurityGroupID)
+		if err != nil {
+			return pkeworkflow.CreateWorkerPoolActivityInput{}, err
+		}
+	}
+
+	minSize := input.Size
+	maxSize := input.Size
+
+	if input.Autoscaling.Enabled {
+		minSize = input.Autoscaling.MinSize
+		maxSize = input.Autoscaling.MaxSize
+	}
+
+	return pkeworkflow.CreateWorkerPoolActivityInput{
+		ClusterID: input.ClusterID,
+		Pool: pkeworkflow.NodePool{
+			Name:         input.NodePoolName,
+			MinCount:     minSize,
+			MaxCount:     maxSize,
+			Count:        input.Size,
+			Autoscaling:  input.Autoscaling.Enabled,
+			Worker:       true,
+			InstanceType: input.InstanceType,
+			ImageID:      input.Image,
+			VolumeSize:   volumeSize,
+			SpotPrice:    input.SpotPrice,
+			Subnets:      input.SubnetIDs,
+		},
+		VPCID:                     input.VPCID,
+		VPCDefaultSecurityGroupID: vpcDefaultSecurityGroupID,
+		SubnetIDs:                 input.SubnetIDs,
+		WorkerInstanceProfile:     pkeworkflow.PkeGlobalStackName + "-worker-profile",
+		ClusterSecurityGroup:      masterOutput["ClusterSecurityGroup"],
+		ExternalBaseUrl:           input.PipelineExternalURL,
+		ExternalBaseUrlInsecure:   input.PipelineExternalURLInsecure,
+		SSHKeyName:                "pke-ssh-" + input.ClusterName,
+	}, nil
+}
@@ -0,0 +1,177 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeawsworkflow
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksworkflow"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
+	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow"
+	pkgCadence "github.com/banzaicloud/pipeline/pkg/cadence"
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+const UpdateNodePoolWorkflowName = "pke-aws-update-node-pool"
+
+type UpdateNodePoolWorkflow struct {
+	processLogger processlog.ProcessLogger
+}
+
+// NewUpdateNodePoolWorkflow returns a new UpdateNodePoolWorkflow.
+func NewUpdateNodePoolWorkflow(processLogger processlog.ProcessLogger) UpdateNodePoolWorkflow {
+	return UpdateNodePoolWorkflow{
+		processLogger: processLogger,
+	}
+}
+
+type UpdateNodePoolWorkflowInput struct {
+	ProviderSecretID string
+	Region           string
+
+	StackName string
+
+	OrganizationID uint
+	ClusterID      uint
+	ClusterName    string
+	NodePoolName   string
+
+	CurrentNodeImage      string
+	CurrentNodeVolumeSize int
+
+	NodeVolumeSize int
+	NodeImage      string
+
+	Options pkeaws.NodePoolUpdateOptions
+}
+
+func (w UpdateNodePoolWorkflow) Register() {
+	workflow.RegisterWithOptions(w.Execute, workflow.RegisterOptions{Name: UpdateNodePoolWorkflowName})
+}
+
+func (w UpdateNodePoolWorkflow) Execute(ctx workflow.Context, input UpdateNodePoolWorkflowInput) (err error) {
+	ctx = workflow.WithActivityOptions(ctx, activityOptions(ctx))
+
+	clusterID := brn.New(input.OrganizationID, brn.ClusterResourceType, fmt.Sprint(input.ClusterID))
+
+	process := w.processLogger.StartProcess(ctx, clusterID.String())
+	defer func() {
+		process.Finish(ctx, err)
+	}()
+	defer func() {
+		finishClusterUpdate(ctx, input.ClusterID, "failed to update node pool", err)
+	}()
+
+	providerSecretID, err := brn.Parse(input.ProviderSecretID)
+	if err != nil {
+		return err
+	}
+
+	effectiveImage := input.CurrentNodeImage
+	if input.NodeImage != "" {
+		effectiveImage = input.NodeImage
+	}
+
+	effectiveVolumeSize := input.CurrentNodeVolumeSize
+	if effectiveImage != input.CurrentNodeImage || input.NodeVolumeSize > 0 {
+		activityInput := pkeworkflow.SelectVolumeSizeActivityInput{
+			AWSActivityInput: pkeworkflow.AWSActivityInput{
+				OrganizationID: input.OrganizationID,
+				SecretID:       providerSecretID.ResourceID,
+				Region:         input.Region,
+			},
+			ImageID: effectiveImage,
+		}
+		var activityOutput pkeworkflow.SelectVolumeSizeActivityOutput
+
+		err = executeProcessActivity(ctx, process, pkeworkflow.SelectVolumeSizeActivityName, activityInput, &activityOutput)
+		if err != nil {
+			return err
+		}
+
+		effectiveVolumeSize, err = pkeaws.SelectVolumeSize(activityOutput.VolumeSize, input.CurrentNodeVolumeSize, input.NodeVolumeSize)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		activityInput := UpdateNodePoolStackActivityInput{
+			SecretID:       input.ProviderSecretID,
+			Region:         input.Region,
+			StackName:      input.StackName,
+			NodeImage:      effectiveImage,
+			NodeVolumeSize: effectiveVolumeSize,
+			MaxBatchSize:   input.Options.MaxBatchSize,
+		}
+
+		var output UpdateNodePoolStackActivityOutput
+
+		err = executeProcessActivity(ctx, process, UpdateNodePoolStackActivityName, activityInput, &output)
+		if err != nil || !output.NodePoolChanged {
+			return
+		}
+	}
+
+	{
+		activityInput := eksworkflow.WaitCloudFormationStackUpdateActivityInput{
+			SecretID:  input.ProviderSecretID,
+			Region:    input.Region,
+			StackName: input.StackName,
+		}
+
+		activityOptions := activityOptions(ctx)
+		activityOptions.StartToCloseTimeout = 100 * 10 * time.Minute // TODO: calculate based on desired node count (limited to around 100 nodes now)
+		activityOptions.HeartbeatTimeout = time.Minute
+		activityOptions.RetryPolicy = &cadence.RetryPolicy{
+			InitialInterval:          20 * time.Second,
+			BackoffCoefficient:       1.1,
+			MaximumAttempts:          20,
+			NonRetriableErrorReasons: []string{"cadenceInternal:Panic", eksworkflow.ErrReasonStackFailed},
+		}
+
+		processActivity := process.StartActivity(ctx, eksworkflow.WaitCloudFormationStackUpdateActivityName)
+		err = pkgCadence.UnwrapError(workflow.ExecuteActivity(
+			workflow.WithActivityOptions(ctx, activityOptions),
+			eksworkflow.WaitCloudFormationStackUpdateActivityName,
+			activityInput,
+		).Get(ctx, nil))
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		activityInput := SaveNodePoolImageActivityInput{
+			ClusterID:      input.ClusterID,
+			NodePoolName:   input.NodePoolName,
+			NodeImage:      effectiveImage,
+			NodeVolumeSize: effectiveVolumeSize,
+		}
+
+		err = executeProcessActivity(ctx, process, SaveNodePoolImageActivityName, activityInput, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeaws
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+func TestService_CreateNodePool(t *testing.T) {
+	ctx := context.Background()
+	c := cluster.Cluster{
+		ID:           1,
+		Name:         "cluster",
+		Cloud:        "amazon",
+		Distribution: "pke",
+	}
+	nodePool := NewNodePool{
+		Name:         "pool0",
+		Size:         1,
+		InstanceType: "t2.medium",
+	}
+
+	clusters := new(MockStore)
+	nodePoolStore := new(MockNodePoolStore)
+	nodePoolManager := new(MockNodePoolManager)
+
+	clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+	nodePoolManager.On("CreateNodePool", ctx, c, nodePool).Return(nil)
+
+	service := NewService(clusters, nodePoolStore, nodePoolManager)
+
+	err := service.CreateNodePool(ctx, c.ID, nodePool)
+	require.NoError(t, err)
+
+	clusters.AssertExpectations(t)
+	nodePoolStore.AssertExpectations(t)
+	nodePoolManager.AssertExpectations(t)
+}
+
+func TestService_UpdateNodePool(t *testing.T) {
+	ctx := context.Background()
+	c := cluster.Cluster{
+		ID:           1,
+		Name:         "cluster",
+		Cloud:        "amazon",
+		Distribution: "pke",
+	}
+	nodePools := []NodePool{
+		{
+			Name:       "pool0",
+			Size:       1,
+			VolumeSize: 50,
+			Image:      "ami-old",
+		},
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		clusters := new(MockStore)
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolManager := new(MockNodePoolManager)
+
+		update := NodePoolUpdate{
+			Image: "ami-new",
+			Options: NodePoolUpdateOptions{
+				MaxBatchSize: 2,
+			},
+		}
+
+		clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+		clusters.On("SetStatus", ctx, c.ID, cluster.Updating, "updating node pool").Return(nil)
+		nodePoolStore.On("ListNodePools", ctx, c.ID).Return(nodePools, nil)
+		nodePoolManager.On("UpdateNodePool", ctx, c, nodePools[0], update).Return("process-id", nil)
+
+		service := NewService(clusters, nodePoolStore, nodePoolManager)
+
+		processID, err := service.UpdateNodePool(ctx, c.ID, "pool0", update)
+		require.NoError(t, err)
+
+		assert.Equal(t, "process-id", processID)
+
+		clusters.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+		nodePoolManager.AssertExpectations(t)
+	})
+
+	t.Run("NodePoolNotFound", func(t *testing.T) {
+		clusters := new(MockStore)
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolManager := new(MockNodePoolManager)
+
+		clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+		nodePoolStore.On("ListNodePools", ctx, c.ID).Return(nodePools, nil)
+
+		service := NewService(clusters, nodePoolStore, nodePoolManager)
+
+		_, err := service.UpdateNodePool(ctx, c.ID, "pool1", NodePoolUpdate{})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &cluster.NodePoolNotFoundError{}))
+
+		clusters.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+		nodePoolManager.AssertExpectations(t)
+	})
+}
+
+func TestService_DeleteNodePool(t *testing.T) {
+	ctx := context.Background()
+	c := cluster.Cluster{
+		ID:           1,
+		Name:         "cluster",
+		Cloud:        "amazon",
+		Distribution: "pke",
+	}
+	nodePools := []NodePool{
+		{
+			Name:       "pool0",
+			Size:       1,
+			VolumeSize: 50,
+			Image:      "ami-xxx",
+		},
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		clusters := new(MockStore)
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolManager := new(MockNodePoolManager)
+
+		clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+		nodePoolStore.On("ListNodePools", ctx, c.ID).Return(nodePools, nil)
+		nodePoolManager.On("DeleteNodePool", ctx, c, "pool0").Return(nil)
+
+		service := NewService(clusters, nodePoolStore, nodePoolManager)
+
+		err := service.DeleteNodePool(ctx, c.ID, "pool0")
+		require.NoError(t, err)
+
+		clusters.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+		nodePoolManager.AssertExpectations(t)
+	})
+
+	t.Run("MasterNodePool", func(t *testing.T) {
+		clusters := new(MockStore)
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolManager := new(MockNodePoolManager)
+
+		clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+		nodePoolStore.On("ListNodePools", ctx, c.ID).Return(nodePools, nil)
+
+		service := NewService(clusters, nodePoolStore, nodePoolManager)
+
+		err := service.DeleteNodePool(ctx, c.ID, "master")
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &cluster.ValidationError{}))
+
+		clusters.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+		nodePoolManager.AssertExpectations(t)
+	})
+}
+
+func TestService_ListNodePools(t *testing.T) {
+	ctx := context.Background()
+	c := cluster.Cluster{
+		ID:           1,
+		Name:         "cluster",
+		Cloud:        "amazon",
+		Distribution: "pke",
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		clusters := new(MockStore)
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolManager := new(MockNodePoolManager)
+
+		clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+		nodePoolStore.On("ListNodePools", ctx, c.ID).Return(
+			[]NodePool{
+				{Name: "pool0", Size: 1},
+				{Name: "pool1", Size: 2},
+			},
+			nil,
+		)
+		nodePoolManager.On("ListNodePoolLabels", ctx, c).Return(
+			map[string]map[string]string{
+				"pool0": {"key": "value"},
+			},
+			nil,
+		)
+
+		service := NewService(clusters, nodePoolStore, nodePoolManager)
+
+		nodePools, err := service.ListNodePools(ctx, c.ID)
+		require.NoError(t, err)
+
+		expected := []NodePool{
+			{Name: "pool0", Size: 1, Labels: map[string]string{"key": "value"}},
+			{Name: "pool1", Size: 2},
+		}
+
+		assert.Equal(t, expected, nodePools)
+	})
+
+	t.Run("ClusterNotFound", func(t *testing.T) {
+		clusters := new(MockStore)
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolManager := new(MockNodePoolManager)
+
+		clusters.On("GetCluster", ctx, c.ID).Return(cluster.Cluster{}, errors.NewPlain("not found"))
+
+		service := NewService(clusters, nodePoolStore, nodePoolManager)
+
+		_, err := service.ListNodePools(ctx, c.ID)
+		require.Error(t, err)
+
+		nodePoolStore.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeaws
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// MinimalVolumeSize is the smallest volume size (in GiB) selected automatically for a node.
+const MinimalVolumeSize = 50
+
+// SelectVolumeSize returns the volume size of the nodes after an image update.
+//
+// An explicitly requested volume size must fit the image,
+// otherwise the current size is kept if it fits the image or the minimal size is used.
+func SelectVolumeSize(amiSize int, currentVolumeSize int, requestedVolumeSize int) (int, error) {
+	if requestedVolumeSize > 0 {
+		if requestedVolumeSize < amiSize {
+			return 0, cluster.NewValidationError(
+				"invalid node pool update request",
+				[]string{fmt.Sprintf("volume size of %dGB is less than the AMI image size of %dGB", requestedVolumeSize, amiSize)},
+			)
+		}
+
+		return requestedVolumeSize, nil
+	}
+
+	if currentVolumeSize >= amiSize {
+		return currentVolumeSize, nil
+	}
+
+	return int(math.Max(float64(MinimalVolumeSize), float64(amiSize))), nil
+}
@@ -0,0 +1,80 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeaws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectVolumeSize(t *testing.T) {
+	testCases := []struct {
+		caseName            string
+		amiSize             int
+		currentVolumeSize   int
+		requestedVolumeSize int
+		expectedVolumeSize  int
+		expectedError       bool
+	}{
+		{
+			caseName:            "requested size fits the image",
+			amiSize:             20,
+			currentVolumeSize:   50,
+			requestedVolumeSize: 100,
+			expectedVolumeSize:  100,
+		},
+		{
+			caseName:            "requested size is too small",
+			amiSize:             60,
+			currentVolumeSize:   50,
+			requestedVolumeSize: 40,
+			expectedError:       true,
+		},
+		{
+			caseName:           "current size is kept",
+			amiSize:            20,
+			currentVolumeSize:  80,
+			expectedVolumeSize: 80,
+		},
+		{
+			caseName:           "current size is too small for the image",
+			amiSize:            60,
+			currentVolumeSize:  50,
+			expectedVolumeSize: 60,
+		},
+		{
+			caseName:           "minimal size",
+			amiSize:            8,
+			expectedVolumeSize: MinimalVolumeSize,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.caseName, func(t *testing.T) {
+			volumeSize, err := SelectVolumeSize(testCase.amiSize, testCase.currentVolumeSize, testCase.requestedVolumeSize)
+			if testCase.expectedError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedVolumeSize, volumeSize)
+		})
+	}
+}
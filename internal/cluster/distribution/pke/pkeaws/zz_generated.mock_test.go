@@ -6,6 +6,7 @@ package pkeaws
 
 import (
 	"context"
+	"github.com/banzaicloud/pipeline/internal/cluster"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -34,3 +35,190 @@ func (_m *MockImageSelector) SelectImage(ctx context.Context, criteria ImageSele
 
 	return r0, r1
 }
+
+// MockNodePoolStore is an autogenerated mock for the NodePoolStore type.
+type MockNodePoolStore struct {
+	mock.Mock
+}
+
+// CreateNodePool provides a mock function.
+func (_m *MockNodePoolStore) CreateNodePool(ctx context.Context, clusterID uint, createdBy uint, nodePool NewNodePool) (_result_0 error) {
+	ret := _m.Called(ctx, clusterID, createdBy, nodePool)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, NewNodePool) error); ok {
+		r0 = rf(ctx, clusterID, createdBy, nodePool)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteNodePool provides a mock function.
+func (_m *MockNodePoolStore) DeleteNodePool(ctx context.Context, clusterID uint, nodePoolName string) (_result_0 error) {
+	ret := _m.Called(ctx, clusterID, nodePoolName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, clusterID, nodePoolName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListNodePools provides a mock function.
+func (_m *MockNodePoolStore) ListNodePools(ctx context.Context, clusterID uint) (_result_0 []NodePool, _result_1 error) {
+	ret := _m.Called(ctx, clusterID)
+
+	var r0 []NodePool
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []NodePool); ok {
+		r0 = rf(ctx, clusterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]NodePool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateNodePoolImage provides a mock function.
+func (_m *MockNodePoolStore) UpdateNodePoolImage(ctx context.Context, clusterID uint, nodePoolName string, image string, volumeSize int) (_result_0 error) {
+	ret := _m.Called(ctx, clusterID, nodePoolName, image, volumeSize)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string, int) error); ok {
+		r0 = rf(ctx, clusterID, nodePoolName, image, volumeSize)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNodePoolManager is an autogenerated mock for the NodePoolManager type.
+type MockNodePoolManager struct {
+	mock.Mock
+}
+
+// CreateNodePool provides a mock function.
+func (_m *MockNodePoolManager) CreateNodePool(ctx context.Context, c cluster.Cluster, nodePool NewNodePool) (_result_0 error) {
+	ret := _m.Called(ctx, c, nodePool)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.Cluster, NewNodePool) error); ok {
+		r0 = rf(ctx, c, nodePool)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteNodePool provides a mock function.
+func (_m *MockNodePoolManager) DeleteNodePool(ctx context.Context, c cluster.Cluster, nodePoolName string) (_result_0 error) {
+	ret := _m.Called(ctx, c, nodePoolName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.Cluster, string) error); ok {
+		r0 = rf(ctx, c, nodePoolName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListNodePoolLabels provides a mock function.
+func (_m *MockNodePoolManager) ListNodePoolLabels(ctx context.Context, c cluster.Cluster) (_result_0 map[string]map[string]string, _result_1 error) {
+	ret := _m.Called(ctx, c)
+
+	var r0 map[string]map[string]string
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.Cluster) map[string]map[string]string); ok {
+		r0 = rf(ctx, c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]map[string]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, cluster.Cluster) error); ok {
+		r1 = rf(ctx, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateNodePool provides a mock function.
+func (_m *MockNodePoolManager) UpdateNodePool(ctx context.Context, c cluster.Cluster, nodePool NodePool, nodePoolUpdate NodePoolUpdate) (_result_0 string, _result_1 error) {
+	ret := _m.Called(ctx, c, nodePool, nodePoolUpdate)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, cluster.Cluster, NodePool, NodePoolUpdate) string); ok {
+		r0 = rf(ctx, c, nodePool, nodePoolUpdate)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, cluster.Cluster, NodePool, NodePoolUpdate) error); ok {
+		r1 = rf(ctx, c, nodePool, nodePoolUpdate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore is an autogenerated mock for the Store type.
+type MockStore struct {
+	mock.Mock
+}
+
+// GetCluster provides a mock function.
+func (_m *MockStore) GetCluster(ctx context.Context, id uint) (_result_0 cluster.Cluster, _result_1 error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 cluster.Cluster
+	if rf, ok := ret.Get(0).(func(context.Context, uint) cluster.Cluster); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(cluster.Cluster)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetStatus provides a mock function.
+func (_m *MockStore) SetStatus(ctx context.Context, id uint, status string, statusMessage string) (_result_0 error) {
+	ret := _m.Called(ctx, id, status, statusMessage)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, string) error); ok {
+		r0 = rf(ctx, id, status, statusMessage)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
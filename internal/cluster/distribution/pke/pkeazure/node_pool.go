@@ -0,0 +1,106 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeazure
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// NewNodePool describes a new Kubernetes node pool in a PKE cluster on Azure.
+type NewNodePool struct {
+	Name        string            `mapstructure:"name"`
+	Labels      map[string]string `mapstructure:"labels"`
+	Size        int               `mapstructure:"size"`
+	Autoscaling struct {
+		Enabled bool `mapstructure:"enabled"`
+		MinSize int  `mapstructure:"minSize"`
+		MaxSize int  `mapstructure:"maxSize"`
+	} `mapstructure:"autoscaling"`
+	InstanceType string   `mapstructure:"instanceType"`
+	Zones        []string `mapstructure:"zones"`
+	Subnet       Subnet   `mapstructure:"subnet"`
+}
+
+// Subnet describes the subnet of a node pool.
+//
+// Node pools created with an empty subnet name get a subnet of their own.
+type Subnet struct {
+	Name string `mapstructure:"name"`
+	CIDR string `mapstructure:"cidr"`
+}
+
+// nodePoolNameRegexp matches node pool names that are valid as part of VMSS names and label values.
+var nodePoolNameRegexp = regexp.MustCompile("^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$")
+
+// Validate semantically validates the new node pool.
+func (n NewNodePool) Validate() error {
+	var violations []string
+
+	if !nodePoolNameRegexp.MatchString(n.Name) {
+		violations = append(violations, "name must consist of lowercase alphanumeric characters or '-', and must start and end with an alphanumeric character")
+	}
+
+	if n.Autoscaling.Enabled {
+		violations = append(violations, validateAutoscaling(n.Size, n.Autoscaling.MinSize, n.Autoscaling.MaxSize)...)
+	} else if n.Size < 1 {
+		violations = append(violations, "size cannot be lower than one")
+	}
+
+	if n.InstanceType == "" {
+		violations = append(violations, "instance type cannot be empty")
+	}
+
+	if len(violations) > 0 {
+		return cluster.NewValidationError("invalid node pool creation request", violations)
+	}
+
+	return nil
+}
+
+func validateAutoscaling(size int, minSize int, maxSize int) []string {
+	var violations []string
+
+	if minSize < 1 {
+		violations = append(violations, "minimum autoscaling size cannot be lower than one")
+	}
+
+	if maxSize < minSize {
+		violations = append(violations, "maximum autoscaling size cannot be lower than the minimum")
+	}
+
+	if size < minSize {
+		violations = append(violations, "node pool size cannot be lower than the autoscaling minimum size")
+	}
+
+	if size > maxSize {
+		violations = append(violations, "node pool size cannot be higher than the autoscaling maximum size")
+	}
+
+	return violations
+}
+
+// +testify:mock:testOnly=true
+
+// NodePoolStore provides an interface for PKE on Azure node pool persistence.
+type NodePoolStore interface {
+	// ListNodePools retrieves the node pools of the cluster specified by its cluster ID.
+	ListNodePools(ctx context.Context, clusterID uint) ([]NodePool, error)
+
+	// UpdateNodePool saves the size, autoscaling settings and instance type of an existing node pool.
+	UpdateNodePool(ctx context.Context, clusterID uint, nodePool NodePool) error
+}
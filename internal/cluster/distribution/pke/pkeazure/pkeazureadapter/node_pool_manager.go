@@ -0,0 +1,206 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeazureadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeazure"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeazure/pkeazureworkflow"
+	"github.com/banzaicloud/pipeline/internal/providers/azure/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/azure/pke/driver"
+	"github.com/banzaicloud/pipeline/pkg/kubernetes/custom/npls"
+)
+
+type nodePoolManager struct {
+	clusters             pke.ClusterStore
+	infrastructure       driver.NodePoolManager
+	dynamicClientFactory cluster.DynamicKubeClientFactory
+	namespace            string
+	workflowClient       client.Client
+	getUserID            func(ctx context.Context) uint
+}
+
+// NewNodePoolManager returns a new pkeazure.NodePoolManager
+// that manages node pools asynchronously via Cadence workflows.
+func NewNodePoolManager(
+	clusters pke.ClusterStore,
+	infrastructure driver.NodePoolManager,
+	dynamicClientFactory cluster.DynamicKubeClientFactory,
+	namespace string,
+	workflowClient client.Client,
+	getUserID func(ctx context.Context) uint,
+) pkeazure.NodePoolManager {
+	return nodePoolManager{
+		clusters:             clusters,
+		infrastructure:       infrastructure,
+		dynamicClientFactory: dynamicClientFactory,
+		namespace:            namespace,
+		workflowClient:       workflowClient,
+		getUserID:            getUserID,
+	}
+}
+
+func (n nodePoolManager) startWorkflow(ctx context.Context, workflowName string, input interface{}) (string, error) {
+	workflowOptions := client.StartWorkflowOptions{
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 30 * 24 * 60 * time.Minute,
+	}
+
+	e, err := n.workflowClient.StartWorkflow(ctx, workflowOptions, workflowName, input)
+	if err != nil {
+		return "", errors.WrapWithDetails(err, "failed to start workflow", "workflow", workflowName)
+	}
+
+	return e.ID, nil
+}
+
+func (n nodePoolManager) CreateNodePool(ctx context.Context, c cluster.Cluster, nodePool pkeazure.NewNodePool) error {
+	infrastructure, err := n.infrastructure.CreateNodePool(ctx, c.ID, driver.NodePool{
+		CreatedBy:    n.getUserID(ctx),
+		Name:         nodePool.Name,
+		InstanceType: nodePool.InstanceType,
+		Subnet: driver.Subnet{
+			Name: nodePool.Subnet.Name,
+			CIDR: nodePool.Subnet.CIDR,
+		},
+		Zones:       nodePool.Zones,
+		Roles:       []string{"worker"},
+		Labels:      nodePool.Labels,
+		Autoscaling: nodePool.Autoscaling.Enabled,
+		Count:       nodePool.Size,
+		Min:         nodePool.Autoscaling.MinSize,
+		Max:         nodePool.Autoscaling.MaxSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	input := pkeazureworkflow.CreateNodePoolWorkflowInput{
+		OrganizationID:      c.OrganizationID,
+		SecretID:            c.SecretID.ResourceID,
+		ResourceGroupName:   infrastructure.ResourceGroupName,
+		PublicIPAddressName: infrastructure.PublicIPAddressName,
+		RouteTableName:      infrastructure.RouteTableName,
+		VirtualNetworkName:  infrastructure.VirtualNetworkName,
+
+		ClusterID:   c.ID,
+		ClusterName: c.Name,
+
+		NodePoolName:   nodePool.Name,
+		NodePoolLabels: nodePool.Labels,
+
+		Subnet:          infrastructure.Subnet,
+		ScaleSet:        infrastructure.ScaleSet,
+		RoleAssignments: infrastructure.RoleAssignments,
+
+		AccessPoints:          infrastructure.AccessPoints,
+		APIServerAccessPoints: infrastructure.APIServerAccessPoints,
+	}
+
+	_, err = n.startWorkflow(ctx, pkeazureworkflow.CreateNodePoolWorkflowName, input)
+
+	return err
+}
+
+func (n nodePoolManager) UpdateNodePool(
+	ctx context.Context,
+	c cluster.Cluster,
+	nodePool pkeazure.NodePool,
+	nodePoolUpdate pkeazure.NodePoolUpdate,
+) (string, error) {
+	azureCluster, err := n.clusters.GetByID(c.ID)
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to get cluster", "clusterId", c.ID)
+	}
+
+	input := pkeazureworkflow.UpdateNodePoolWorkflowInput{
+		OrganizationID:    c.OrganizationID,
+		SecretID:          c.SecretID.ResourceID,
+		ResourceGroupName: azureCluster.ResourceGroup.Name,
+
+		ClusterID:   c.ID,
+		ClusterName: c.Name,
+
+		VMSSName:        pke.GetVMSSName(c.Name, nodePool.Name),
+		CurrentNodePool: nodePool,
+		NodePool:        nodePool.ApplyUpdate(nodePoolUpdate),
+
+		Options: nodePoolUpdate.Options,
+	}
+
+	return n.startWorkflow(ctx, pkeazureworkflow.UpdateNodePoolWorkflowName, input)
+}
+
+func (n nodePoolManager) DeleteNodePool(ctx context.Context, c cluster.Cluster, nodePoolName string) error {
+	azureCluster, err := n.clusters.GetByID(c.ID)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get cluster", "clusterId", c.ID)
+	}
+
+	// the subnet is only deleted when no other node pool uses it
+	var subnetName string
+	for _, np := range azureCluster.NodePools {
+		if np.Name == nodePoolName {
+			subnetName = np.Subnet.Name
+		}
+	}
+	for _, np := range azureCluster.NodePools {
+		if np.Name != nodePoolName && np.Subnet.Name == subnetName {
+			subnetName = ""
+		}
+	}
+
+	input := pkeazureworkflow.DeleteNodePoolWorkflowInput{
+		OrganizationID:     c.OrganizationID,
+		SecretID:           c.SecretID.ResourceID,
+		ResourceGroupName:  azureCluster.ResourceGroup.Name,
+		VirtualNetworkName: azureCluster.VirtualNetwork.Name,
+
+		ClusterID:   c.ID,
+		ClusterName: c.Name,
+
+		NodePoolName: nodePoolName,
+		VMSSName:     pke.GetVMSSName(c.Name, nodePoolName),
+		SubnetName:   subnetName,
+	}
+
+	_, err = n.startWorkflow(ctx, pkeazureworkflow.DeleteNodePoolWorkflowName, input)
+
+	return err
+}
+
+// ListNodePoolLabels lists the labels of the node pools in a cluster keyed by node pool names.
+func (n nodePoolManager) ListNodePoolLabels(ctx context.Context, c cluster.Cluster) (map[string]map[string]string, error) {
+	clusterClient, err := n.dynamicClientFactory.FromSecret(ctx, c.ConfigSecretID.String())
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "creating dynamic Kubernetes client factory failed", "cluster", c)
+	}
+
+	labelSets, err := npls.NewManager(clusterClient, n.namespace).GetAll(ctx)
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "retrieving node pool label sets failed",
+			"cluster", c,
+			"namespace", n.namespace,
+		)
+	}
+
+	return labelSets, nil
+}
@@ -0,0 +1,85 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeazureadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeazure"
+	"github.com/banzaicloud/pipeline/internal/providers/azure/pke"
+)
+
+type nodePoolStore struct {
+	clusters pke.ClusterStore
+}
+
+// NewNodePoolStore returns a new pkeazure.NodePoolStore
+// that provides an interface to the Azure PKE cluster store.
+func NewNodePoolStore(clusters pke.ClusterStore) pkeazure.NodePoolStore {
+	return nodePoolStore{
+		clusters: clusters,
+	}
+}
+
+func (s nodePoolStore) ListNodePools(ctx context.Context, clusterID uint) ([]pkeazure.NodePool, error) {
+	c, err := s.clusters.GetByID(clusterID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to get cluster", "clusterId", clusterID)
+	}
+
+	nodePools := make([]pkeazure.NodePool, 0, len(c.NodePools))
+	for _, np := range c.NodePools {
+		nodePools = append(nodePools, pkeazure.NodePool{
+			Name: np.Name,
+			Size: int(np.DesiredCount),
+			Autoscaling: pkeazure.Autoscaling{
+				Enabled: np.Autoscaling,
+				MinSize: int(np.Min),
+				MaxSize: int(np.Max),
+			},
+			InstanceType: np.InstanceType,
+			Zones:        np.Zones,
+			Subnet: pkeazure.Subnet{
+				Name: np.Subnet.Name,
+			},
+			Roles: np.Roles,
+		})
+	}
+
+	return nodePools, nil
+}
+
+func (s nodePoolStore) UpdateNodePool(ctx context.Context, clusterID uint, nodePool pkeazure.NodePool) error {
+	err := s.clusters.SetNodePoolSizes(
+		clusterID,
+		nodePool.Name,
+		uint(nodePool.Autoscaling.MinSize),
+		uint(nodePool.Autoscaling.MaxSize),
+		uint(nodePool.Size),
+		nodePool.Autoscaling.Enabled,
+	)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update node pool sizes", "clusterId", clusterID, "nodePool", nodePool.Name)
+	}
+
+	err = s.clusters.SetNodePoolInstanceType(clusterID, nodePool.Name, nodePool.InstanceType)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update node pool instance type", "clusterId", clusterID, "nodePool", nodePool.Name)
+	}
+
+	return nil
+}
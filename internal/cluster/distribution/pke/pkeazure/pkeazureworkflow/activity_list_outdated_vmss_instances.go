@@ -0,0 +1,88 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeazureworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/cadence/activity"
+)
+
+const ListOutdatedVMSSInstancesActivityName = "pke-azure-list-outdated-vmss-instances"
+
+// ListOutdatedVMSSInstancesActivity lists the instances of a scale set that do not run the latest model.
+type ListOutdatedVMSSInstancesActivity struct {
+	azureClientFactory AzureClientFactory
+}
+
+// ListOutdatedVMSSInstancesActivityInput holds the parameters of the instance listing.
+type ListOutdatedVMSSInstancesActivityInput struct {
+	VMSSActivityInput
+}
+
+// ListOutdatedVMSSInstancesActivityOutput holds the IDs of the outdated instances.
+type ListOutdatedVMSSInstancesActivityOutput struct {
+	InstanceIDs []string
+}
+
+// NewListOutdatedVMSSInstancesActivity creates a new ListOutdatedVMSSInstancesActivity instance.
+func NewListOutdatedVMSSInstancesActivity(azureClientFactory AzureClientFactory) ListOutdatedVMSSInstancesActivity {
+	return ListOutdatedVMSSInstancesActivity{
+		azureClientFactory: azureClientFactory,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a ListOutdatedVMSSInstancesActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: ListOutdatedVMSSInstancesActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a ListOutdatedVMSSInstancesActivity) Execute(
+	ctx context.Context,
+	input ListOutdatedVMSSInstancesActivityInput,
+) (ListOutdatedVMSSInstancesActivityOutput, error) {
+	var output ListOutdatedVMSSInstancesActivityOutput
+
+	cc, err := a.azureClientFactory.New(input.OrganizationID, input.SecretID)
+	if err != nil {
+		return output, errors.WrapIf(err, "failed to create cloud connection")
+	}
+
+	client := cc.GetVirtualMachineScaleSetVMsClient()
+
+	iter, err := client.ListComplete(ctx, input.ResourceGroupName, input.VMSSName, "", "", "")
+	if err != nil {
+		return output, errors.WrapIfWithDetails(err, "failed to list virtual machine scale set instances", "vmssName", input.VMSSName)
+	}
+
+	for ; iter.NotDone(); err = iter.NextWithContext(ctx) {
+		if err != nil {
+			return output, errors.WrapIfWithDetails(err, "failed to list virtual machine scale set instances", "vmssName", input.VMSSName)
+		}
+
+		vm := iter.Value()
+
+		if vm.VirtualMachineScaleSetVMProperties != nil && to.Bool(vm.LatestModelApplied) {
+			continue
+		}
+
+		output.InstanceIDs = append(output.InstanceIDs, to.String(vm.InstanceID))
+	}
+
+	return output, nil
+}
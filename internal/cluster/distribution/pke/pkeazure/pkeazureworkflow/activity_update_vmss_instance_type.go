@@ -0,0 +1,93 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeazureworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"go.uber.org/cadence/activity"
+
+	azurepkeworkflow "github.com/banzaicloud/pipeline/internal/providers/azure/pke/workflow"
+)
+
+const UpdateVMSSInstanceTypeActivityName = "pke-azure-update-vmss-instance-type"
+
+// UpdateVMSSInstanceTypeActivity changes the instance type in the model of a scale set.
+//
+// Existing instances keep running with their current size until they are updated to the latest model.
+type UpdateVMSSInstanceTypeActivity struct {
+	azureClientFactory AzureClientFactory
+}
+
+// UpdateVMSSInstanceTypeActivityInput holds the parameters of the instance type change.
+type UpdateVMSSInstanceTypeActivityInput struct {
+	VMSSActivityInput
+
+	InstanceType string
+}
+
+// NewUpdateVMSSInstanceTypeActivity creates a new UpdateVMSSInstanceTypeActivity instance.
+func NewUpdateVMSSInstanceTypeActivity(azureClientFactory AzureClientFactory) UpdateVMSSInstanceTypeActivity {
+	return UpdateVMSSInstanceTypeActivity{
+		azureClientFactory: azureClientFactory,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a UpdateVMSSInstanceTypeActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: UpdateVMSSInstanceTypeActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a UpdateVMSSInstanceTypeActivity) Execute(ctx context.Context, input UpdateVMSSInstanceTypeActivityInput) error {
+	cc, err := a.azureClientFactory.New(input.OrganizationID, input.SecretID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create cloud connection")
+	}
+
+	client := cc.GetVirtualMachineScaleSetsClient()
+
+	vmss, err := client.Get(ctx, input.ResourceGroupName, input.VMSSName)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get virtual machine scale set", "vmssName", input.VMSSName)
+	}
+
+	if !azurepkeworkflow.HasOwnedTag(input.ClusterName, to.StringMap(vmss.Tags)) {
+		return errors.NewWithDetails("virtual machine scale set is not owned by the cluster", "vmssName", input.VMSSName)
+	}
+
+	if vmss.Sku != nil && to.String(vmss.Sku.Name) == input.InstanceType {
+		return nil
+	}
+
+	future, err := client.Update(ctx, input.ResourceGroupName, input.VMSSName, compute.VirtualMachineScaleSetUpdate{
+		Sku: &compute.Sku{
+			Name: to.StringPtr(input.InstanceType),
+		},
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "sending request to update virtual machine scale set failed", "vmssName", input.VMSSName)
+	}
+
+	err = future.WaitForCompletionRef(ctx, client.Client)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "waiting for the completion of update virtual machine scale set operation failed", "vmssName", input.VMSSName)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeazureworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
+	"go.uber.org/cadence/activity"
+)
+
+const UpdateVMSSInstancesActivityName = "pke-azure-update-vmss-instances"
+
+// UpdateVMSSInstancesActivity upgrades a batch of scale set instances to the latest model.
+type UpdateVMSSInstancesActivity struct {
+	azureClientFactory AzureClientFactory
+}
+
+// UpdateVMSSInstancesActivityInput holds the parameters of the instance upgrade.
+type UpdateVMSSInstancesActivityInput struct {
+	VMSSActivityInput
+
+	InstanceIDs []string
+}
+
+// NewUpdateVMSSInstancesActivity creates a new UpdateVMSSInstancesActivity instance.
+func NewUpdateVMSSInstancesActivity(azureClientFactory AzureClientFactory) UpdateVMSSInstancesActivity {
+	return UpdateVMSSInstancesActivity{
+		azureClientFactory: azureClientFactory,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a UpdateVMSSInstancesActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: UpdateVMSSInstancesActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a UpdateVMSSInstancesActivity) Execute(ctx context.Context, input UpdateVMSSInstancesActivityInput) error {
+	cc, err := a.azureClientFactory.New(input.OrganizationID, input.SecretID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create cloud connection")
+	}
+
+	client := cc.GetVirtualMachineScaleSetsClient()
+
+	instanceIDs := input.InstanceIDs
+
+	future, err := client.UpdateInstances(ctx, input.ResourceGroupName, input.VMSSName, compute.VirtualMachineScaleSetVMInstanceRequiredIDs{
+		InstanceIds: &instanceIDs,
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "sending request to update virtual machine scale set instances failed",
+			"vmssName", input.VMSSName,
+			"instanceIDs", input.InstanceIDs,
+		)
+	}
+
+	err = future.WaitForCompletionRef(ctx, client.Client)
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "waiting for the completion of update virtual machine scale set instances operation failed",
+			"vmssName", input.VMSSName,
+			"instanceIDs", input.InstanceIDs,
+		)
+	}
+
+	return nil
+}
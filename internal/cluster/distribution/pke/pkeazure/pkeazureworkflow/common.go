@@ -0,0 +1,112 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeazureworkflow
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	pkgCadence "github.com/banzaicloud/pipeline/pkg/cadence"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	pkgAzure "github.com/banzaicloud/pipeline/pkg/providers/azure"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+	pipCluster "github.com/banzaicloud/pipeline/src/cluster"
+)
+
+// AzureClientFactory creates Azure cloud connections from organization secrets.
+type AzureClientFactory interface {
+	// New returns a new cloud connection using the specified secret.
+	New(organizationID uint, secretID string) (*pkgAzure.CloudConnection, error)
+}
+
+// VMSSActivityInput holds the common parameters of scale set activities.
+type VMSSActivityInput struct {
+	OrganizationID    uint
+	SecretID          string
+	ClusterName       string
+	ResourceGroupName string
+	VMSSName          string
+}
+
+func activityOptions(ctx workflow.Context) workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          10 * time.Second,
+			BackoffCoefficient:       1.01,
+			MaximumAttempts:          10,
+			MaximumInterval:          10 * time.Minute,
+			NonRetriableErrorReasons: []string{"cadenceInternal:Panic", pkgCadence.ClientErrorReason},
+		},
+		ScheduleToStartTimeout: time.Duration(workflow.GetInfo(ctx).ExecutionStartToCloseTimeoutSeconds) * time.Second,
+		StartToCloseTimeout:    60 * time.Minute,
+	}
+}
+
+// installClusterAutoscaler redeploys the cluster autoscaler to pick up node pool changes.
+func installClusterAutoscaler(ctx workflow.Context, process processlog.Process, clusterID uint) error {
+	activityInput := pipCluster.RunPostHookActivityInput{
+		ClusterID: clusterID,
+		HookName:  pkgCluster.InstallClusterAutoscalerPostHook,
+		Status:    cluster.Updating,
+	}
+
+	return executeProcessActivity(ctx, process, pipCluster.RunPostHookActivityName, activityInput)
+}
+
+// finishClusterUpdate sets the cluster status according to the outcome of a node pool workflow.
+func finishClusterUpdate(ctx workflow.Context, clusterID uint, failureMessage string, err error) {
+	status := cluster.Running
+	statusMessage := cluster.RunningMessage
+
+	if err != nil {
+		if cadence.IsCanceledError(err) {
+			ctx, _ = workflow.NewDisconnectedContext(ctx)
+		}
+
+		status = cluster.Warning
+		statusMessage = fmt.Sprintf("%s: %s", failureMessage, err.Error())
+	}
+
+	_ = setClusterStatus(ctx, clusterID, status, statusMessage)
+}
+
+// executeProcessActivity executes an activity and records it in the process log.
+func executeProcessActivity(ctx workflow.Context, process processlog.Process, activityName string, activityInput interface{}) error {
+	processActivity := process.StartActivity(ctx, activityName)
+	err := workflow.ExecuteActivity(ctx, activityName, activityInput).Get(ctx, nil)
+	processActivity.Finish(ctx, err)
+
+	return err
+}
+
+// setClusterStatus updates the status of a cluster through an activity.
+func setClusterStatus(ctx workflow.Context, clusterID uint, status, statusMessage string) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    2 * time.Minute,
+		WaitForCancellation:    true,
+	})
+
+	return workflow.ExecuteActivity(ctx, clusterworkflow.SetClusterStatusActivityName, clusterworkflow.SetClusterStatusActivityInput{
+		ClusterID:     clusterID,
+		Status:        status,
+		StatusMessage: statusMessage,
+	}).Get(ctx, nil)
+}
@@ -0,0 +1,237 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeazureworkflow
+
+import (
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	"github.com/banzaicloud/pipeline/internal/providers/azure/pke"
+	azurepkeworkflow "github.com/banzaicloud/pipeline/internal/providers/azure/pke/workflow"
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+const CreateNodePoolWorkflowName = "pke-azure-create-node-pool"
+
+type CreateNodePoolWorkflow struct {
+	processLogger processlog.ProcessLogger
+}
+
+// NewCreateNodePoolWorkflow returns a new CreateNodePoolWorkflow.
+func NewCreateNodePoolWorkflow(processLogger processlog.ProcessLogger) CreateNodePoolWorkflow {
+	return CreateNodePoolWorkflow{
+		processLogger: processLogger,
+	}
+}
+
+type CreateNodePoolWorkflowInput struct {
+	OrganizationID      uint
+	SecretID            string
+	ResourceGroupName   string
+	PublicIPAddressName string
+	RouteTableName      string
+	VirtualNetworkName  string
+
+	ClusterID   uint
+	ClusterName string
+
+	NodePoolName   string
+	NodePoolLabels map[string]string
+
+	// Subnet is nil when the node pool is placed in an existing subnet.
+	Subnet          *azurepkeworkflow.SubnetTemplate
+	ScaleSet        azurepkeworkflow.VirtualMachineScaleSetTemplate
+	RoleAssignments []azurepkeworkflow.RoleAssignmentTemplate
+
+	AccessPoints          pke.AccessPoints
+	APIServerAccessPoints pke.APIServerAccessPoints
+}
+
+func (w CreateNodePoolWorkflow) Register() {
+	workflow.RegisterWithOptions(w.Execute, workflow.RegisterOptions{Name: CreateNodePoolWorkflowName})
+}
+
+func (w CreateNodePoolWorkflow) Execute(ctx workflow.Context, input CreateNodePoolWorkflowInput) (err error) {
+	ctx = workflow.WithActivityOptions(ctx, activityOptions(ctx))
+
+	clusterID := brn.New(input.OrganizationID, brn.ClusterResourceType, fmt.Sprint(input.ClusterID))
+
+	process := w.processLogger.StartProcess(ctx, clusterID.String())
+	defer func() {
+		process.Finish(ctx, err)
+	}()
+	defer func() {
+		finishClusterUpdate(ctx, input.ClusterID, "failed to create node pool", err)
+	}()
+
+	var providers azurepkeworkflow.CollectUpdateClusterProvidersActivityOutput
+	{
+		activityInput := azurepkeworkflow.CollectUpdateClusterProvidersActivityInput{
+			OrganizationID:      input.OrganizationID,
+			SecretID:            input.SecretID,
+			ResourceGroupName:   input.ResourceGroupName,
+			ClusterName:         input.ClusterName,
+			PublicIPAddressName: input.PublicIPAddressName,
+			RouteTableName:      input.RouteTableName,
+			VirtualNetworkName:  input.VirtualNetworkName,
+		}
+
+		processActivity := process.StartActivity(ctx, azurepkeworkflow.CollectUpdateClusterProvidersActivityName)
+		err = workflow.ExecuteActivity(ctx, azurepkeworkflow.CollectUpdateClusterProvidersActivityName, activityInput).Get(ctx, &providers)
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			return err
+		}
+	}
+
+	if input.Subnet != nil {
+		activityInput := azurepkeworkflow.CreateSubnetActivityInput{
+			OrganizationID:     input.OrganizationID,
+			SecretID:           input.SecretID,
+			ClusterName:        input.ClusterName,
+			ResourceGroupName:  input.ResourceGroupName,
+			VirtualNetworkName: input.VirtualNetworkName,
+			Subnet:             input.Subnet.Render(providers.RouteTableIDProvider, providers.SecurityGroupIDProvider),
+		}
+
+		var activityOutput azurepkeworkflow.CreateSubnetActivityOutput
+
+		processActivity := process.StartActivity(ctx, azurepkeworkflow.CreateSubnetActivityName)
+		err = workflow.ExecuteActivity(ctx, azurepkeworkflow.CreateSubnetActivityName, activityInput).Get(ctx, &activityOutput)
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			return err
+		}
+
+		providers.SubnetIDProvider.Put(input.Subnet.Name, activityOutput.SubnetID)
+	}
+
+	{
+		activityInput := clusterworkflow.CreateNodePoolLabelSetActivityInput{
+			ClusterID: input.ClusterID,
+			RawNodePool: cluster.NewRawNodePool{
+				"name":   input.NodePoolName,
+				"labels": input.NodePoolLabels,
+			},
+		}
+
+		err = executeProcessActivity(ctx, process, clusterworkflow.CreateNodePoolLabelSetActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	var vmssOutput azurepkeworkflow.CreateVMSSActivityOutput
+	{
+		var apiServerAddressProvider azurepkeworkflow.IPAddressProvider
+		var apiServerCertSansProvider azurepkeworkflow.ConstantResourceIDProvider
+
+		apiServerAddressProvider, apiServerCertSansProvider, err = getAPIServerProviders(input.AccessPoints, input.APIServerAccessPoints)
+		if err != nil {
+			return err
+		}
+
+		backendAddressPoolIDProviders := make([]azurepkeworkflow.ResourceIDByNameProvider, len(providers.BackendAddressPoolIDProviders))
+		for i := range providers.BackendAddressPoolIDProviders {
+			backendAddressPoolIDProviders[i] = providers.BackendAddressPoolIDProviders[i]
+		}
+		inboundNATPoolIDProviders := make([]azurepkeworkflow.ResourceIDByNameProvider, len(providers.InboundNATPoolIDProviders))
+		for i := range providers.InboundNATPoolIDProviders {
+			inboundNATPoolIDProviders[i] = providers.InboundNATPoolIDProviders[i]
+		}
+
+		activityInput := azurepkeworkflow.CreateVMSSActivityInput{
+			OrganizationID:    input.OrganizationID,
+			SecretID:          input.SecretID,
+			ClusterID:         input.ClusterID,
+			ClusterName:       input.ClusterName,
+			ResourceGroupName: input.ResourceGroupName,
+			ScaleSet: input.ScaleSet.Render(
+				backendAddressPoolIDProviders,
+				inboundNATPoolIDProviders,
+				apiServerAddressProvider,
+				apiServerCertSansProvider,
+				providers.SecurityGroupIDProvider,
+				providers.SubnetIDProvider,
+			),
+		}
+
+		processActivity := process.StartActivity(ctx, azurepkeworkflow.CreateVMSSActivityName)
+		err = workflow.ExecuteActivity(ctx, azurepkeworkflow.CreateVMSSActivityName, activityInput).Get(ctx, &vmssOutput)
+		processActivity.Finish(ctx, err)
+		if err != nil {
+			activityInput := azurepkeworkflow.DeleteNodePoolFromStoreActivityInput{
+				ClusterID:     input.ClusterID,
+				NodePoolNames: []string{input.NodePoolName},
+			}
+
+			_ = workflow.ExecuteActivity(ctx, azurepkeworkflow.DeleteNodePoolFromStoreActivityName, activityInput).Get(ctx, nil)
+
+			return err
+		}
+	}
+
+	for _, roleAssignment := range input.RoleAssignments {
+		activityInput := azurepkeworkflow.AssignRoleActivityInput{
+			OrganizationID:    input.OrganizationID,
+			SecretID:          input.SecretID,
+			ClusterName:       input.ClusterName,
+			ResourceGroupName: input.ResourceGroupName,
+			RoleAssignment:    roleAssignment.Render(azurepkeworkflow.MapResourceIDByNameProvider{input.ScaleSet.Name: vmssOutput.PrincipalID}),
+		}
+
+		err = executeProcessActivity(ctx, process, azurepkeworkflow.AssignRoleActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	return installClusterAutoscaler(ctx, process, input.ClusterID)
+}
+
+// getAPIServerProviders returns the address that new nodes use to join the cluster and the API server certificate SANs.
+func getAPIServerProviders(
+	accessPoints pke.AccessPoints,
+	apiServerAccessPoints pke.APIServerAccessPoints,
+) (azurepkeworkflow.IPAddressProvider, azurepkeworkflow.ConstantResourceIDProvider, error) {
+	var apiServerAddressProvider azurepkeworkflow.IPAddressProvider
+	var apiServerCertSans []string
+
+	if apiServerAccessPoints.Exists("public") && accessPoints.Get("public").Address != "" {
+		apiServerAddressProvider = azurepkeworkflow.ConstantIPAddressProvider(accessPoints.Get("public").Address)
+		apiServerCertSans = append(apiServerCertSans, accessPoints.Get("public").Address)
+	}
+
+	if apiServerAccessPoints.Exists("private") && accessPoints.Get("private").Address != "" {
+		// nodes prefer the private address
+		apiServerAddressProvider = azurepkeworkflow.ConstantIPAddressProvider(accessPoints.Get("private").Address)
+
+		if len(apiServerCertSans) == 0 || apiServerCertSans[0] != accessPoints.Get("private").Address {
+			apiServerCertSans = append(apiServerCertSans, accessPoints.Get("private").Address)
+		}
+	}
+
+	if apiServerAddressProvider == nil {
+		return nil, "", errors.New("no API server address available")
+	}
+
+	return apiServerAddressProvider, azurepkeworkflow.ConstantResourceIDProvider(strings.Join(apiServerCertSans, ",")), nil
+}
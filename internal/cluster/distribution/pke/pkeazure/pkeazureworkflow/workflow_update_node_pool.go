@@ -0,0 +1,171 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkeazureworkflow
+
+import (
+	"fmt"
+
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeazure"
+	azurepkeworkflow "github.com/banzaicloud/pipeline/internal/providers/azure/pke/workflow"
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+const UpdateNodePoolWorkflowName = "pke-azure-update-node-pool"
+
+type UpdateNodePoolWorkflow struct {
+	processLogger processlog.ProcessLogger
+}
+
+// NewUpdateNodePoolWorkflow returns a new UpdateNodePoolWorkflow.
+func NewUpdateNodePoolWorkflow(processLogger processlog.ProcessLogger) UpdateNodePoolWorkflow {
+	return UpdateNodePoolWorkflow{
+		processLogger: processLogger,
+	}
+}
+
+type UpdateNodePoolWorkflowInput struct {
+	OrganizationID    uint
+	SecretID          string
+	ResourceGroupName string
+
+	ClusterID   uint
+	ClusterName string
+
+	VMSSName        string
+	CurrentNodePool pkeazure.NodePool
+	NodePool        pkeazure.NodePool
+
+	Options pkeazure.NodePoolUpdateOptions
+}
+
+func (w UpdateNodePoolWorkflow) Register() {
+	workflow.RegisterWithOptions(w.Execute, workflow.RegisterOptions{Name: UpdateNodePoolWorkflowName})
+}
+
+func (w UpdateNodePoolWorkflow) Execute(ctx workflow.Context, input UpdateNodePoolWorkflowInput) (err error) {
+	ctx = workflow.WithActivityOptions(ctx, activityOptions(ctx))
+
+	clusterID := brn.New(input.OrganizationID, brn.ClusterResourceType, fmt.Sprint(input.ClusterID))
+
+	process := w.processLogger.StartProcess(ctx, clusterID.String())
+	defer func() {
+		process.Finish(ctx, err)
+	}()
+	defer func() {
+		finishClusterUpdate(ctx, input.ClusterID, "failed to update node pool", err)
+	}()
+
+	nodePool := input.NodePool
+
+	vmssActivityInput := VMSSActivityInput{
+		OrganizationID:    input.OrganizationID,
+		SecretID:          input.SecretID,
+		ClusterName:       input.ClusterName,
+		ResourceGroupName: input.ResourceGroupName,
+		VMSSName:          input.VMSSName,
+	}
+
+	if nodePool.Size != input.CurrentNodePool.Size {
+		activityInput := azurepkeworkflow.UpdateVMSSActivityInput{
+			OrganizationID:    input.OrganizationID,
+			SecretID:          input.SecretID,
+			ClusterName:       input.ClusterName,
+			ResourceGroupName: input.ResourceGroupName,
+			Changes: azurepkeworkflow.VirtualMachineScaleSetChanges{
+				Name:          input.VMSSName,
+				InstanceCount: azurepkeworkflow.NewUint(uint(nodePool.Size)),
+			},
+		}
+
+		err = executeProcessActivity(ctx, process, azurepkeworkflow.UpdateVMSSActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	if nodePool.InstanceType != input.CurrentNodePool.InstanceType {
+		{
+			activityInput := UpdateVMSSInstanceTypeActivityInput{
+				VMSSActivityInput: vmssActivityInput,
+				InstanceType:      nodePool.InstanceType,
+			}
+
+			err = executeProcessActivity(ctx, process, UpdateVMSSInstanceTypeActivityName, activityInput)
+			if err != nil {
+				return err
+			}
+		}
+
+		var outdatedInstances ListOutdatedVMSSInstancesActivityOutput
+		{
+			activityInput := ListOutdatedVMSSInstancesActivityInput{
+				VMSSActivityInput: vmssActivityInput,
+			}
+
+			processActivity := process.StartActivity(ctx, ListOutdatedVMSSInstancesActivityName)
+			err = workflow.ExecuteActivity(ctx, ListOutdatedVMSSInstancesActivityName, activityInput).Get(ctx, &outdatedInstances)
+			processActivity.Finish(ctx, err)
+			if err != nil {
+				return err
+			}
+		}
+
+		// instances are resized in batches to keep the rest of the node pool available
+		batchSize := input.Options.MaxBatchSize
+		if batchSize < 1 {
+			batchSize = 1
+		}
+
+		instanceIDs := outdatedInstances.InstanceIDs
+		for len(instanceIDs) > 0 {
+			if batchSize > len(instanceIDs) {
+				batchSize = len(instanceIDs)
+			}
+
+			activityInput := UpdateVMSSInstancesActivityInput{
+				VMSSActivityInput: vmssActivityInput,
+				InstanceIDs:       instanceIDs[:batchSize],
+			}
+
+			err = executeProcessActivity(ctx, process, UpdateVMSSInstancesActivityName, activityInput)
+			if err != nil {
+				return err
+			}
+
+			instanceIDs = instanceIDs[batchSize:]
+		}
+	}
+
+	{
+		activityInput := SaveNodePoolActivityInput{
+			ClusterID: input.ClusterID,
+			NodePool:  nodePool,
+		}
+
+		err = executeProcessActivity(ctx, process, SaveNodePoolActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	if nodePool.Autoscaling != input.CurrentNodePool.Autoscaling {
+		return installClusterAutoscaler(ctx, process, input.ClusterID)
+	}
+
+	return nil
+}
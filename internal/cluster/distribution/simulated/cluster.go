@@ -0,0 +1,115 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simulated implements a cluster distribution that does not provision any cloud infrastructure.
+//
+// A simulated cluster is backed by an existing Kubernetes API server (eg. a local kind or k3s cluster)
+// accessible with the kubeconfig stored in the cluster secret. Node pools are simulated by registering
+// tainted Node objects in the backing API server, so the complete cluster lifecycle can be exercised
+// without cloud credentials.
+package simulated
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterbase"
+)
+
+const (
+	// ClusterIDLabelKey is the label key marking the nodes registered for a simulated cluster.
+	ClusterIDLabelKey = "simulated.banzaicloud.io/cluster-id"
+
+	// NodeTaintKey is the taint key keeping workloads off the nodes registered for simulated node pools.
+	NodeTaintKey = "simulated.banzaicloud.io/node"
+
+	// DefaultInstanceType is the instance type of node pools created without one.
+	DefaultInstanceType = "simulated"
+)
+
+// Cluster describes a simulated cluster.
+type Cluster struct {
+	clusterbase.ClusterBase
+
+	KubernetesVersion string
+	NodePools         []NodePool
+}
+
+// NewCluster describes a new simulated cluster.
+type NewCluster struct {
+	Name              string
+	OrganizationID    uint
+	CreatedBy         uint
+	SecretID          string
+	KubernetesVersion string
+	NodePools         []NewNodePool
+}
+
+// Validate semantically validates the new cluster.
+func (c NewCluster) Validate() error {
+	var violations []string
+
+	if c.Name == "" {
+		violations = append(violations, "name cannot be empty")
+	}
+
+	if c.SecretID == "" {
+		violations = append(violations, "secret cannot be empty")
+	}
+
+	if len(c.NodePools) == 0 {
+		violations = append(violations, "at least one node pool is required")
+	}
+
+	names := make(map[string]bool, len(c.NodePools))
+	for _, nodePool := range c.NodePools {
+		if names[nodePool.Name] {
+			violations = append(violations, fmt.Sprintf("duplicate node pool name: %s", nodePool.Name))
+		}
+		names[nodePool.Name] = true
+
+		violations = append(violations, nodePool.violations()...)
+	}
+
+	if len(violations) > 0 {
+		return cluster.NewValidationError("invalid cluster creation request", violations)
+	}
+
+	return nil
+}
+
+// NodeName returns the name of a node registered for a simulated node pool.
+func NodeName(clusterID uint, nodePoolName string, index int) string {
+	return fmt.Sprintf("simulated-%d-%s-%d", clusterID, nodePoolName, index)
+}
+
+// ClusterStore provides an interface to simulated cluster persistence.
+type ClusterStore interface {
+	// CreateCluster saves a new cluster.
+	CreateCluster(ctx context.Context, newCluster NewCluster) (Cluster, error)
+
+	// GetCluster returns a cluster with its node pools.
+	// Returns an error with the NotFound behavior when the cluster cannot be found.
+	GetCluster(ctx context.Context, clusterID uint) (Cluster, error)
+
+	// DeleteCluster deletes a cluster with its node pools.
+	DeleteCluster(ctx context.Context, clusterID uint) error
+
+	// SetStatus sets the cluster status.
+	SetStatus(ctx context.Context, clusterID uint, status string, statusMessage string) error
+
+	// SetConfigSecretID sets the ID of the secret holding the cluster kubeconfig.
+	SetConfigSecretID(ctx context.Context, clusterID uint, secretID string) error
+}
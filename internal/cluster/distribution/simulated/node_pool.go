@@ -0,0 +1,98 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulated
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// NewNodePool describes a new node pool in a simulated cluster.
+type NewNodePool struct {
+	Name         string            `mapstructure:"name"`
+	Labels       map[string]string `mapstructure:"labels"`
+	Size         int               `mapstructure:"size"`
+	InstanceType string            `mapstructure:"instanceType"`
+}
+
+// nodePoolNameRegexp matches node pool names that are valid in node names.
+var nodePoolNameRegexp = regexp.MustCompile("^[a-z0-9]([-a-z0-9]{0,40}[a-z0-9])?$")
+
+// Validate semantically validates the new node pool.
+func (n NewNodePool) Validate() error {
+	if violations := n.violations(); len(violations) > 0 {
+		return cluster.NewValidationError("invalid node pool creation request", violations)
+	}
+
+	return nil
+}
+
+func (n NewNodePool) violations() []string {
+	var violations []string
+
+	if !nodePoolNameRegexp.MatchString(n.Name) {
+		violations = append(violations, "name must consist of at most 42 lowercase alphanumeric characters or '-'")
+	}
+
+	if n.Size < 0 {
+		violations = append(violations, "size cannot be negative")
+	}
+
+	return violations
+}
+
+// NodePool encapsulates information about a simulated node pool.
+type NodePool struct {
+	Name         string            `mapstructure:"name"`
+	Labels       map[string]string `mapstructure:"labels"`
+	Size         int               `mapstructure:"size"`
+	InstanceType string            `mapstructure:"instanceType"`
+}
+
+// NodePoolUpdate describes a node pool update request.
+//
+// A node pool update contains a partial representation of the node pool resource,
+// updating only the changed values.
+type NodePoolUpdate struct {
+	Size *int `mapstructure:"size"`
+}
+
+// ApplyUpdate returns the node pool with the changes of a node pool update applied.
+func (n NodePool) ApplyUpdate(nodePoolUpdate NodePoolUpdate) NodePool {
+	if nodePoolUpdate.Size != nil {
+		n.Size = *nodePoolUpdate.Size
+	}
+
+	return n
+}
+
+// +testify:mock:testOnly=true
+
+// NodePoolStore provides an interface for simulated node pool persistence.
+type NodePoolStore interface {
+	// CreateNodePool saves a new node pool.
+	CreateNodePool(ctx context.Context, clusterID uint, createdBy uint, nodePool NewNodePool) error
+
+	// DeleteNodePool deletes a node pool.
+	DeleteNodePool(ctx context.Context, clusterID uint, nodePoolName string) error
+
+	// ListNodePools retrieves the node pools of the cluster specified by its cluster ID.
+	ListNodePools(ctx context.Context, clusterID uint) ([]NodePool, error)
+
+	// UpdateNodePool saves the size of an existing node pool.
+	UpdateNodePool(ctx context.Context, clusterID uint, nodePool NodePool) error
+}
@@ -0,0 +1,106 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulated
+
+import (
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+func TestNewNodePool_Validate(t *testing.T) {
+	base := NewNodePool{
+		Name: "pool0",
+		Size: 1,
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, base.Validate())
+	})
+
+	t.Run("ValidZeroSize", func(t *testing.T) {
+		pool := base
+		pool.Size = 0
+
+		assert.NoError(t, pool.Validate())
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		for _, name := range []string{"", "Pool0", "-pool", "pool-", "pool_0", "a-very-long-node-pool-name-that-does-not-fit"} {
+			pool := base
+			pool.Name = name
+
+			err := pool.Validate()
+			assert.IsType(t, cluster.ValidationError{}, errors.Cause(err), name)
+		}
+	})
+
+	t.Run("NegativeSize", func(t *testing.T) {
+		pool := base
+		pool.Size = -1
+
+		err := pool.Validate()
+		assert.IsType(t, cluster.ValidationError{}, errors.Cause(err))
+	})
+}
+
+func TestNewCluster_Validate(t *testing.T) {
+	base := NewCluster{
+		Name:           "cluster",
+		OrganizationID: 1,
+		SecretID:       "secret",
+		NodePools: []NewNodePool{
+			{
+				Name: "pool0",
+				Size: 1,
+			},
+		},
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, base.Validate())
+	})
+
+	t.Run("MissingSecret", func(t *testing.T) {
+		c := base
+		c.SecretID = ""
+
+		err := c.Validate()
+		assert.IsType(t, cluster.ValidationError{}, errors.Cause(err))
+	})
+
+	t.Run("NoNodePools", func(t *testing.T) {
+		c := base
+		c.NodePools = nil
+
+		err := c.Validate()
+		assert.IsType(t, cluster.ValidationError{}, errors.Cause(err))
+	})
+
+	t.Run("DuplicateNodePools", func(t *testing.T) {
+		c := base
+		c.NodePools = append([]NewNodePool{}, base.NodePools[0], base.NodePools[0])
+
+		err := c.Validate()
+		assert.IsType(t, cluster.ValidationError{}, errors.Cause(err))
+	})
+}
+
+func TestNodeName(t *testing.T) {
+	assert.Equal(t, "simulated-1-pool0-2", NodeName(1, "pool0", 2))
+}
@@ -0,0 +1,342 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedadapter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter/clustermodel"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+const (
+	ClustersTableName  = "simulated_clusters"
+	NodePoolsTableName = "simulated_node_pools"
+)
+
+type clusterModel struct {
+	ID                uint                      `gorm:"primary_key"`
+	ClusterID         uint                      `gorm:"unique_index:idx_simulated_clusters_cluster_id"`
+	Cluster           clustermodel.ClusterModel `gorm:"foreignkey:ClusterID"`
+	KubernetesVersion string
+	NodePools         []nodePoolModel `gorm:"foreignkey:ClusterID;association_foreignkey:ClusterID"`
+}
+
+func (clusterModel) TableName() string {
+	return ClustersTableName
+}
+
+type nodePoolModel struct {
+	ID           uint `gorm:"primary_key"`
+	CreatedAt    time.Time
+	CreatedBy    uint
+	ClusterID    uint   `gorm:"unique_index:idx_simulated_node_pools_cluster_id_name"`
+	Name         string `gorm:"unique_index:idx_simulated_node_pools_cluster_id_name"`
+	Size         int
+	InstanceType string
+}
+
+func (nodePoolModel) TableName() string {
+	return NodePoolsTableName
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewClusterStore returns a new simulated.ClusterStore
+// that persists simulated clusters into the database using Gorm.
+func NewClusterStore(db *gorm.DB) simulated.ClusterStore {
+	return gormStore{
+		db: db,
+	}
+}
+
+// NewNodePoolStore returns a new simulated.NodePoolStore
+// that persists simulated node pools into the database using Gorm.
+func NewNodePoolStore(db *gorm.DB) simulated.NodePoolStore {
+	return gormStore{
+		db: db,
+	}
+}
+
+func (s gormStore) CreateCluster(_ context.Context, newCluster simulated.NewCluster) (simulated.Cluster, error) {
+	nodePools := make([]nodePoolModel, 0, len(newCluster.NodePools))
+	for _, nodePool := range newCluster.NodePools {
+		nodePools = append(nodePools, newNodePoolModel(0, newCluster.CreatedBy, nodePool))
+	}
+
+	model := clusterModel{
+		Cluster: clustermodel.ClusterModel{
+			CreatedBy:      newCluster.CreatedBy,
+			Name:           newCluster.Name,
+			Cloud:          pkgCluster.Simulated,
+			Distribution:   pkgCluster.Simulated,
+			OrganizationID: newCluster.OrganizationID,
+			SecretID:       newCluster.SecretID,
+			Status:         pkgCluster.Creating,
+			StatusMessage:  pkgCluster.CreatingMessage,
+		},
+		KubernetesVersion: newCluster.KubernetesVersion,
+		NodePools:         nodePools,
+	}
+
+	err := s.db.Create(&model).Error
+	if err != nil {
+		return simulated.Cluster{}, errors.WrapWithDetails(err, "failed to save cluster", "cluster", newCluster.Name)
+	}
+
+	return clusterFromModel(model), nil
+}
+
+func (s gormStore) GetCluster(_ context.Context, clusterID uint) (simulated.Cluster, error) {
+	var model clusterModel
+
+	err := s.db.
+		Preload("Cluster").
+		Preload("NodePools").
+		Where(clusterModel{ClusterID: clusterID}).
+		First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return simulated.Cluster{}, errors.WithStack(cluster.NotFoundError{ClusterID: clusterID})
+	}
+	if err != nil {
+		return simulated.Cluster{}, errors.WrapWithDetails(err, "failed to get cluster", "clusterId", clusterID)
+	}
+
+	return clusterFromModel(model), nil
+}
+
+func (s gormStore) DeleteCluster(_ context.Context, clusterID uint) error {
+	model := clustermodel.ClusterModel{
+		ID: clusterID,
+	}
+
+	err := s.db.Where(model).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to get cluster", "clusterId", clusterID)
+	}
+
+	err = s.db.Where(nodePoolModel{ClusterID: clusterID}).Delete(nodePoolModel{}).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to delete node pools", "clusterId", clusterID)
+	}
+
+	err = s.db.Where(clusterModel{ClusterID: clusterID}).Delete(clusterModel{}).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to delete cluster", "clusterId", clusterID)
+	}
+
+	// the generic cluster model is soft-deleted like for the other distributions
+	err = s.db.Delete(model).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to delete cluster", "clusterId", clusterID)
+	}
+
+	return nil
+}
+
+func (s gormStore) SetStatus(_ context.Context, clusterID uint, status string, statusMessage string) error {
+	model := clustermodel.ClusterModel{
+		ID: clusterID,
+	}
+
+	err := s.db.Where(model).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return errors.WithStack(cluster.NotFoundError{ClusterID: clusterID})
+	}
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to get cluster", "clusterId", clusterID)
+	}
+
+	if model.Status == status && model.StatusMessage == statusMessage {
+		return nil
+	}
+
+	statusHistory := clustermodel.StatusHistoryModel{
+		ClusterID:   model.ID,
+		ClusterName: model.Name,
+
+		FromStatus:        model.Status,
+		FromStatusMessage: model.StatusMessage,
+		ToStatus:          status,
+		ToStatusMessage:   statusMessage,
+	}
+
+	err = s.db.Save(&statusHistory).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to save status history", "clusterId", clusterID)
+	}
+
+	err = s.db.Model(&model).Updates(map[string]interface{}{
+		"status":        status,
+		"statusMessage": statusMessage,
+	}).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to update cluster status", "clusterId", clusterID)
+	}
+
+	return nil
+}
+
+func (s gormStore) SetConfigSecretID(_ context.Context, clusterID uint, secretID string) error {
+	model := clustermodel.ClusterModel{
+		ID: clusterID,
+	}
+
+	err := s.db.Model(&model).Updates(map[string]interface{}{"ConfigSecretID": secretID}).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to update config secret ID", "clusterId", clusterID)
+	}
+
+	return nil
+}
+
+func (s gormStore) CreateNodePool(
+	_ context.Context,
+	clusterID uint,
+	createdBy uint,
+	nodePool simulated.NewNodePool,
+) error {
+	model := newNodePoolModel(clusterID, createdBy, nodePool)
+
+	err := s.db.Save(&model).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to save node pool", "clusterId", clusterID, "nodePool", nodePool.Name)
+	}
+
+	return nil
+}
+
+func (s gormStore) DeleteNodePool(_ context.Context, clusterID uint, nodePoolName string) error {
+	err := s.db.
+		Where(nodePoolModel{ClusterID: clusterID, Name: nodePoolName}).
+		Delete(nodePoolModel{}).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to delete node pool", "clusterId", clusterID, "nodePool", nodePoolName)
+	}
+
+	return nil
+}
+
+func (s gormStore) ListNodePools(_ context.Context, clusterID uint) ([]simulated.NodePool, error) {
+	var models []nodePoolModel
+
+	err := s.db.Where(nodePoolModel{ClusterID: clusterID}).Order("id").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "fetching node pools from database failed", "clusterId", clusterID)
+	}
+
+	nodePools := make([]simulated.NodePool, 0, len(models))
+	for _, model := range models {
+		nodePools = append(nodePools, nodePoolFromModel(model))
+	}
+
+	return nodePools, nil
+}
+
+func (s gormStore) UpdateNodePool(_ context.Context, clusterID uint, nodePool simulated.NodePool) error {
+	var model nodePoolModel
+
+	err := s.db.Where(nodePoolModel{ClusterID: clusterID, Name: nodePool.Name}).First(&model).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to get node pool", "clusterId", clusterID, "nodePool", nodePool.Name)
+	}
+
+	err = s.db.Model(&model).Updates(map[string]interface{}{"size": nodePool.Size}).Error
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to save node pool", "clusterId", clusterID, "nodePool", nodePool.Name)
+	}
+
+	return nil
+}
+
+func newNodePoolModel(clusterID uint, createdBy uint, nodePool simulated.NewNodePool) nodePoolModel {
+	instanceType := nodePool.InstanceType
+	if instanceType == "" {
+		instanceType = simulated.DefaultInstanceType
+	}
+
+	return nodePoolModel{
+		CreatedBy:    createdBy,
+		ClusterID:    clusterID,
+		Name:         nodePool.Name,
+		Size:         nodePool.Size,
+		InstanceType: instanceType,
+	}
+}
+
+func nodePoolFromModel(model nodePoolModel) simulated.NodePool {
+	return simulated.NodePool{
+		Name:         model.Name,
+		Size:         model.Size,
+		InstanceType: model.InstanceType,
+	}
+}
+
+func clusterFromModel(model clusterModel) simulated.Cluster {
+	c := simulated.Cluster{
+		KubernetesVersion: model.KubernetesVersion,
+		NodePools:         make([]simulated.NodePool, 0, len(model.NodePools)),
+	}
+
+	c.CreatedBy = model.Cluster.CreatedBy
+	c.CreationTime = model.Cluster.CreatedAt
+	c.ID = model.Cluster.ID
+	c.K8sSecretID = model.Cluster.ConfigSecretID
+	c.Name = model.Cluster.Name
+	c.OrganizationID = model.Cluster.OrganizationID
+	c.SecretID = model.Cluster.SecretID
+	c.Status = model.Cluster.Status
+	c.StatusMessage = model.Cluster.StatusMessage
+	c.UID = model.Cluster.UID
+
+	for _, nodePool := range model.NodePools {
+		c.NodePools = append(c.NodePools, nodePoolFromModel(nodePool))
+	}
+
+	return c
+}
+
+// Migrate executes the table migrations for the simulated distribution.
+func Migrate(db *gorm.DB, logger logrus.FieldLogger) error {
+	tables := []interface{}{
+		&clusterModel{},
+		&nodePoolModel{},
+	}
+
+	var tableNames string
+	for _, table := range tables {
+		tableNames += fmt.Sprintf(" %s", db.NewScope(table).TableName())
+	}
+
+	logger.WithFields(logrus.Fields{
+		"table_names": strings.TrimSpace(tableNames),
+	}).Info("migrating simulated distribution tables")
+
+	return db.AutoMigrate(tables...).Error
+}
@@ -0,0 +1,52 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+)
+
+type nodePoolProcessor struct{}
+
+// NewNodePoolProcessor returns a new cluster.NodePoolProcessor
+// that processes a simulated node pool request.
+func NewNodePoolProcessor() cluster.NodePoolProcessor {
+	return nodePoolProcessor{}
+}
+
+func (p nodePoolProcessor) ProcessNew(
+	_ context.Context,
+	_ cluster.Cluster,
+	rawNodePool cluster.NewRawNodePool,
+) (cluster.NewRawNodePool, error) {
+	var nodePool simulated.NewNodePool
+
+	err := mapstructure.Decode(rawNodePool, &nodePool)
+	if err != nil {
+		return rawNodePool, errors.Wrap(err, "failed to decode node pool")
+	}
+
+	if nodePool.InstanceType == "" {
+		rawNodePool["instanceType"] = simulated.DefaultInstanceType
+	}
+
+	return rawNodePool, nil
+}
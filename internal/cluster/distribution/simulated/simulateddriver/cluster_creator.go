@@ -0,0 +1,161 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulateddriver
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulateddriver/commoncluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulatedworkflow"
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	"github.com/banzaicloud/pipeline/src/auth"
+	pipCluster "github.com/banzaicloud/pipeline/src/cluster"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+type Logger = common.Logger
+
+type OrganizationStore interface {
+	Get(ctx context.Context, id uint) (auth.Organization, error)
+}
+
+type SecretStore interface {
+	Get(organizationID uint, secretID string) (*secret.SecretItemResponse, error)
+}
+
+// ClusterCreator creates new simulated clusters.
+type ClusterCreator struct {
+	logger         Logger
+	organizations  OrganizationStore
+	secrets        SecretStore
+	store          simulated.ClusterStore
+	workflowClient client.Client
+}
+
+func MakeClusterCreator(
+	logger Logger,
+	organizations OrganizationStore,
+	secrets SecretStore,
+	store simulated.ClusterStore,
+	workflowClient client.Client,
+) ClusterCreator {
+	return ClusterCreator{
+		logger:         logger,
+		organizations:  organizations,
+		secrets:        secrets,
+		store:          store,
+		workflowClient: workflowClient,
+	}
+}
+
+// Create stores a new simulated cluster and starts its creation workflow.
+func (cc ClusterCreator) Create(ctx context.Context, params simulated.NewCluster) (cl simulated.Cluster, err error) {
+	if err = params.Validate(); err != nil {
+		return
+	}
+
+	secretItem, err := cc.secrets.Get(params.OrganizationID, params.SecretID)
+	if err != nil {
+		return cl, errors.WrapIf(err, "failed to get secret")
+	}
+	if secretItem.Type != secrettype.Kubernetes {
+		return cl, errors.WithStack(cluster.NewValidationError(
+			"invalid cluster creation request",
+			[]string{"secret must be of type " + secrettype.Kubernetes},
+		))
+	}
+
+	org, err := cc.organizations.Get(ctx, params.OrganizationID)
+	if err != nil {
+		return cl, errors.WrapIf(err, "failed to get organization")
+	}
+
+	for i := range params.NodePools {
+		if params.NodePools[i].InstanceType == "" {
+			params.NodePools[i].InstanceType = simulated.DefaultInstanceType
+		}
+	}
+
+	cl, err = cc.store.CreateCluster(ctx, params)
+	if err != nil {
+		return
+	}
+
+	var labelsMap map[string]map[string]string
+	{
+		var commonCluster pipCluster.CommonCluster
+		commonCluster, err = commoncluster.MakeCommonClusterGetter(cc.secrets, cc.store).GetByID(cl.ID)
+		if err != nil {
+			_ = cc.handleError(ctx, cl.ID, err)
+			return
+		}
+
+		nodePoolLabels := make([]pipCluster.NodePoolLabels, 0, len(params.NodePools))
+		for _, np := range params.NodePools {
+			nodePoolLabels = append(nodePoolLabels, pipCluster.NodePoolLabels{
+				NodePoolName: np.Name,
+				Existing:     false,
+				InstanceType: np.InstanceType,
+				CustomLabels: np.Labels,
+			})
+		}
+
+		labelsMap, err = pipCluster.GetDesiredLabelsForCluster(ctx, commonCluster, nodePoolLabels)
+		if err != nil {
+			_ = cc.handleError(ctx, cl.ID, err)
+			return
+		}
+	}
+
+	input := simulatedworkflow.CreateClusterWorkflowInput{
+		OrganizationID:   cl.OrganizationID,
+		OrganizationName: org.Name,
+		SecretID:         cl.SecretID,
+		ClusterID:        cl.ID,
+		ClusterUID:       cl.UID,
+		ClusterName:      cl.Name,
+		NodePools:        cl.NodePools,
+		NodePoolLabels:   labelsMap,
+	}
+
+	workflowOptions := client.StartWorkflowOptions{
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 40 * time.Minute,
+	}
+
+	_, err = cc.workflowClient.StartWorkflow(ctx, workflowOptions, simulatedworkflow.CreateClusterWorkflowName, input)
+	if err != nil {
+		_ = cc.handleError(ctx, cl.ID, err)
+		return
+	}
+
+	return
+}
+
+func (cc ClusterCreator) handleError(ctx context.Context, clusterID uint, err error) error {
+	if err := cc.store.SetStatus(ctx, clusterID, pkgCluster.Error, err.Error()); err != nil {
+		cc.logger.Error("failed to set cluster error status: " + err.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,120 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulateddriver
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulatedworkflow"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+// ClusterDeleter deletes simulated clusters.
+type ClusterDeleter struct {
+	events         ClusterDeleterEvents
+	kubeProxyCache KubeProxyCache
+	logger         Logger
+	store          simulated.ClusterStore
+	workflowClient client.Client
+}
+
+type ClusterDeleterEvents interface {
+	ClusterDeleted(organizationID uint, clusterName string)
+}
+
+type KubeProxyCache interface {
+	Delete(clusterUID string)
+}
+
+func MakeClusterDeleter(
+	events ClusterDeleterEvents,
+	kubeProxyCache KubeProxyCache,
+	logger Logger,
+	store simulated.ClusterStore,
+	workflowClient client.Client,
+) ClusterDeleter {
+	return ClusterDeleter{
+		events:         events,
+		kubeProxyCache: kubeProxyCache,
+		logger:         logger,
+		store:          store,
+		workflowClient: workflowClient,
+	}
+}
+
+func (cd ClusterDeleter) DeleteCluster(ctx context.Context, clusterID uint, options cluster.DeleteClusterOptions) error {
+	cl, err := cd.store.GetCluster(ctx, clusterID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to load cluster from data store")
+	}
+
+	logger := cd.logger.WithFields(map[string]interface{}{"clusterName": cl.Name, "clusterID": cl.ID, "forced": options.Force})
+	logger.Info("Deleting cluster")
+
+	input := simulatedworkflow.DeleteClusterWorkflowInput{
+		OrganizationID: cl.OrganizationID,
+		SecretID:       cl.SecretID,
+		ClusterID:      cl.ID,
+		ClusterUID:     cl.UID,
+		ClusterName:    cl.Name,
+		K8sSecretID:    cl.K8sSecretID,
+		Forced:         options.Force,
+	}
+
+	retryPolicy := &cadence.RetryPolicy{
+		InitialInterval:    time.Second * 3,
+		BackoffCoefficient: 2,
+		ExpirationInterval: time.Minute * 3,
+		MaximumAttempts:    5,
+	}
+
+	workflowOptions := client.StartWorkflowOptions{
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 40 * time.Minute,
+		RetryPolicy:                  retryPolicy,
+	}
+
+	if err := cd.store.SetStatus(ctx, cl.ID, pkgCluster.Deleting, pkgCluster.DeletingMessage); err != nil {
+		return errors.WrapIf(err, "failed to set cluster status")
+	}
+
+	wfrun, err := cd.workflowClient.ExecuteWorkflow(ctx, workflowOptions, simulatedworkflow.DeleteClusterWorkflowName, input)
+	if err = errors.WrapIfWithDetails(err, "failed to start cluster deletion workflow", "cluster", cl.Name); err != nil {
+		_ = cd.store.SetStatus(ctx, cl.ID, pkgCluster.Error, err.Error())
+		return err
+	}
+
+	go func() {
+		ctx := context.Background()
+
+		if err := wfrun.Get(ctx, nil); err != nil {
+			cd.logger.Error("cluster deleting workflow failed: " + err.Error())
+			return
+		}
+		cd.kubeProxyCache.Delete(cl.UID)
+		if cd.events != nil {
+			cd.events.ClusterDeleted(cl.OrganizationID, cl.Name)
+		}
+	}()
+
+	return nil
+}
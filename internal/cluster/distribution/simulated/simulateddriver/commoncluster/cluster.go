@@ -0,0 +1,262 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commoncluster
+
+import (
+	"context"
+	"encoding/base64"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/banzaicloud/pipeline/src/auth"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// SimulatedCluster implements the legacy CommonCluster interface for simulated clusters.
+type SimulatedCluster struct {
+	model   simulated.Cluster
+	secrets SecretStore
+	store   simulated.ClusterStore
+}
+
+type SecretStore interface {
+	Get(organizationID uint, secretID string) (*secret.SecretItemResponse, error)
+}
+
+type CommonClusterGetter struct {
+	secrets SecretStore
+	store   simulated.ClusterStore
+}
+
+func MakeCommonClusterGetter(secrets SecretStore, store simulated.ClusterStore) CommonClusterGetter {
+	return CommonClusterGetter{
+		secrets: secrets,
+		store:   store,
+	}
+}
+
+func (g CommonClusterGetter) GetByID(clusterID uint) (*SimulatedCluster, error) {
+	model, err := g.store.GetCluster(context.Background(), clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := SimulatedCluster{
+		model:   model,
+		secrets: g.secrets,
+		store:   g.store,
+	}
+
+	return &cluster, nil
+}
+
+func (a *SimulatedCluster) GetID() uint {
+	return a.model.ID
+}
+
+func (a *SimulatedCluster) GetUID() string {
+	return a.model.UID
+}
+
+func (a *SimulatedCluster) GetOrganizationId() uint {
+	return a.model.OrganizationID
+}
+
+func (a *SimulatedCluster) GetName() string {
+	return a.model.Name
+}
+
+func (a *SimulatedCluster) GetCloud() string {
+	return pkgCluster.Simulated
+}
+
+func (a *SimulatedCluster) GetDistribution() string {
+	return pkgCluster.Simulated
+}
+
+func (a *SimulatedCluster) GetLocation() string {
+	return "n/a"
+}
+
+func (a *SimulatedCluster) GetCreatedBy() uint {
+	return a.model.CreatedBy
+}
+
+func (a *SimulatedCluster) GetSecretId() string {
+	return a.model.SecretID
+}
+
+func (a *SimulatedCluster) GetSshSecretId() string {
+	return a.model.SSHSecretID
+}
+
+func (a *SimulatedCluster) SaveSshSecretId(string) error {
+	return errors.New("SimulatedCluster.SaveSshSecretId is not implemented")
+}
+
+func (a *SimulatedCluster) SaveConfigSecretId(secretID string) error {
+	a.model.K8sSecretID = secretID
+	return a.store.SetConfigSecretID(context.Background(), a.model.ID, secretID)
+}
+
+func (a *SimulatedCluster) GetConfigSecretId() string {
+	return a.model.K8sSecretID
+}
+
+func (a *SimulatedCluster) GetSecretWithValidation() (*secret.SecretItemResponse, error) {
+	return a.secrets.Get(a.model.OrganizationID, a.model.SecretID)
+}
+
+func (a *SimulatedCluster) Persist() error {
+	return errors.New("SimulatedCluster.Persist is not implemented")
+}
+
+func (a *SimulatedCluster) DeleteFromDatabase() error {
+	return errors.New("SimulatedCluster.DeleteFromDatabase is not implemented")
+}
+
+func (a *SimulatedCluster) CreateCluster() error {
+	return errors.New("SimulatedCluster.CreateCluster is not implemented")
+}
+
+func (a *SimulatedCluster) ValidateCreationFields(r *pkgCluster.CreateClusterRequest) error {
+	return errors.New("SimulatedCluster.ValidateCreationFields is not implemented")
+}
+
+func (a *SimulatedCluster) UpdateCluster(*pkgCluster.UpdateClusterRequest, uint) error {
+	return errors.New("SimulatedCluster.UpdateCluster is not implemented")
+}
+
+func (a *SimulatedCluster) UpdateNodePools(*pkgCluster.UpdateNodePoolsRequest, uint) error {
+	return errors.New("SimulatedCluster.UpdateNodePools is not implemented")
+}
+
+func (a *SimulatedCluster) CheckEqualityToUpdate(*pkgCluster.UpdateClusterRequest) error {
+	return errors.New("SimulatedCluster.CheckEqualityToUpdate is not implemented")
+}
+
+func (a *SimulatedCluster) AddDefaultsToUpdate(*pkgCluster.UpdateClusterRequest) {
+}
+
+func (a *SimulatedCluster) DeleteCluster() error {
+	return errors.New("SimulatedCluster.DeleteCluster is not implemented")
+}
+
+func (a *SimulatedCluster) GetScaleOptions() *pkgCluster.ScaleOptions {
+	return nil
+}
+
+func (a *SimulatedCluster) SetScaleOptions(*pkgCluster.ScaleOptions) {
+}
+
+func (a *SimulatedCluster) GetAPIEndpoint() (string, error) {
+	config, err := a.GetK8sConfig()
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to get cluster's Kubeconfig")
+	}
+
+	return pkgCluster.GetAPIEndpointFromKubeconfig(config)
+}
+
+// DownloadK8sConfig returns the kubeconfig of the API server backing the cluster.
+func (a *SimulatedCluster) DownloadK8sConfig() ([]byte, error) {
+	return a.getConfigFromSecret(a.model.SecretID)
+}
+
+func (a *SimulatedCluster) GetK8sConfig() ([]byte, error) {
+	if a.model.K8sSecretID == "" {
+		return nil, errors.New("there is no K8s config for the cluster")
+	}
+
+	return a.getConfigFromSecret(a.model.K8sSecretID)
+}
+
+func (a *SimulatedCluster) getConfigFromSecret(secretID string) ([]byte, error) {
+	configSecret, err := a.secrets.Get(a.model.OrganizationID, secretID)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get config from Vault")
+	}
+	configStr, err := base64.StdEncoding.DecodeString(configSecret.Values[secrettype.K8SConfig])
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode Kubernetes config")
+	}
+	return configStr, nil
+}
+
+func (a *SimulatedCluster) GetK8sUserConfig() ([]byte, error) {
+	return a.GetK8sConfig()
+}
+
+func (a *SimulatedCluster) RequiresSshPublicKey() bool {
+	return false
+}
+
+func (a *SimulatedCluster) RbacEnabled() bool {
+	return true
+}
+
+func (a *SimulatedCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
+	nodePools := make(map[string]*pkgCluster.NodePoolStatus)
+	for _, np := range a.model.NodePools {
+		nodePools[np.Name] = &pkgCluster.NodePoolStatus{
+			Count:        np.Size,
+			InstanceType: np.InstanceType,
+		}
+	}
+
+	return &pkgCluster.GetClusterStatusResponse{
+		Status:        a.model.Status,
+		StatusMessage: a.model.StatusMessage,
+		Name:          a.model.Name,
+		Location:      a.GetLocation(),
+		Region:        a.GetLocation(),
+		Cloud:         a.GetCloud(),
+		Distribution:  a.GetDistribution(),
+		ResourceID:    a.model.ID,
+		Version:       a.model.KubernetesVersion,
+		NodePools:     nodePools,
+		CreatorBaseFields: pkgCommon.CreatorBaseFields{
+			CreatedAt:   a.model.CreationTime,
+			CreatorName: auth.GetUserNickNameById(a.model.CreatedBy),
+			CreatorId:   a.model.CreatedBy,
+		},
+	}, nil
+}
+
+func (a *SimulatedCluster) IsReady() (bool, error) {
+	return a.model.K8sSecretID != "", nil
+}
+
+func (a *SimulatedCluster) NodePoolExists(nodePoolName string) bool {
+	for _, np := range a.model.NodePools {
+		if np.Name == nodePoolName {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *SimulatedCluster) SetStatus(status string, statusMessage string) error {
+	return a.store.SetStatus(context.Background(), a.model.ID, status, statusMessage)
+}
+
+// GetSimulatedCluster returns the simulated cluster model.
+func (a *SimulatedCluster) GetSimulatedCluster() simulated.Cluster {
+	return a.model
+}
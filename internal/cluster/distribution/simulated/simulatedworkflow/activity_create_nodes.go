@@ -0,0 +1,105 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedworkflow
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	"github.com/banzaicloud/pipeline/pkg/common"
+)
+
+const CreateNodesActivityName = "simulated-create-nodes"
+
+// CreateNodesActivity registers the nodes of a simulated node pool in the backing API server.
+type CreateNodesActivity struct {
+	clientFactory KubeClientFactory
+}
+
+// CreateNodesActivityInput holds the parameters for registering the nodes.
+type CreateNodesActivityInput struct {
+	NodesActivityInput
+
+	InstanceType string
+	Size         int
+}
+
+// NewCreateNodesActivity creates a new CreateNodesActivity instance.
+func NewCreateNodesActivity(clientFactory KubeClientFactory) CreateNodesActivity {
+	return CreateNodesActivity{
+		clientFactory: clientFactory,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a CreateNodesActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: CreateNodesActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a CreateNodesActivity) Execute(ctx context.Context, input CreateNodesActivityInput) error {
+	client, err := a.clientFactory.FromSecret(ctx, input.secretBRN())
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < input.Size; i++ {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: simulated.NodeName(input.ClusterID, input.NodePoolName, i),
+				Labels: map[string]string{
+					common.LabelKey:                input.NodePoolName,
+					corev1.LabelInstanceTypeStable: input.InstanceType,
+					simulated.ClusterIDLabelKey:    fmt.Sprint(input.ClusterID),
+				},
+			},
+			Spec: corev1.NodeSpec{
+				Taints: []corev1.Taint{
+					{
+						Key:    simulated.NodeTaintKey,
+						Value:  "true",
+						Effect: corev1.TaintEffectNoSchedule,
+					},
+				},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:   corev1.NodeReady,
+						Status: corev1.ConditionTrue,
+						Reason: "SimulatedNodeReady",
+					},
+				},
+			},
+		}
+
+		_, err := client.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+		if k8serrors.IsAlreadyExists(err) {
+			continue
+		}
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to create node", "node", node.Name)
+		}
+	}
+
+	return nil
+}
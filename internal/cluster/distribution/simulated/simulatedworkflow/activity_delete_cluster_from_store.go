@@ -0,0 +1,52 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedworkflow
+
+import (
+	"context"
+
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+)
+
+const DeleteClusterFromStoreActivityName = "simulated-delete-cluster-from-store"
+
+// DeleteClusterFromStoreActivity deletes a cluster with its node pools from the store.
+type DeleteClusterFromStoreActivity struct {
+	clusters simulated.ClusterStore
+}
+
+// DeleteClusterFromStoreActivityInput holds the parameters for deleting the cluster.
+type DeleteClusterFromStoreActivityInput struct {
+	ClusterID uint
+}
+
+// NewDeleteClusterFromStoreActivity creates a new DeleteClusterFromStoreActivity instance.
+func NewDeleteClusterFromStoreActivity(clusters simulated.ClusterStore) DeleteClusterFromStoreActivity {
+	return DeleteClusterFromStoreActivity{
+		clusters: clusters,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a DeleteClusterFromStoreActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: DeleteClusterFromStoreActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a DeleteClusterFromStoreActivity) Execute(ctx context.Context, input DeleteClusterFromStoreActivityInput) error {
+	return a.clusters.DeleteCluster(ctx, input.ClusterID)
+}
@@ -0,0 +1,99 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedworkflow
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	"github.com/banzaicloud/pipeline/pkg/common"
+)
+
+const DeleteNodesActivityName = "simulated-delete-nodes"
+
+// DeleteNodesActivity removes the surplus nodes of a simulated cluster from the backing API server.
+//
+// Nodes of every node pool are removed when no node pool name is given.
+type DeleteNodesActivity struct {
+	clientFactory KubeClientFactory
+}
+
+// DeleteNodesActivityInput holds the parameters for removing the nodes.
+type DeleteNodesActivityInput struct {
+	NodesActivityInput
+
+	// Size is the number of nodes to keep in the node pool.
+	Size int
+}
+
+// NewDeleteNodesActivity creates a new DeleteNodesActivity instance.
+func NewDeleteNodesActivity(clientFactory KubeClientFactory) DeleteNodesActivity {
+	return DeleteNodesActivity{
+		clientFactory: clientFactory,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a DeleteNodesActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: DeleteNodesActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a DeleteNodesActivity) Execute(ctx context.Context, input DeleteNodesActivityInput) error {
+	client, err := a.clientFactory.FromSecret(ctx, input.secretBRN())
+	if err != nil {
+		return err
+	}
+
+	selector := labels.Set{simulated.ClusterIDLabelKey: fmt.Sprint(input.ClusterID)}
+	if input.NodePoolName != "" {
+		selector[common.LabelKey] = input.NodePoolName
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return errors.WrapIf(err, "failed to list nodes")
+	}
+
+	keep := make(map[string]bool, input.Size)
+	if input.NodePoolName != "" {
+		for i := 0; i < input.Size; i++ {
+			keep[simulated.NodeName(input.ClusterID, input.NodePoolName, i)] = true
+		}
+	}
+
+	for _, node := range nodes.Items {
+		if keep[node.Name] {
+			continue
+		}
+
+		err := client.CoreV1().Nodes().Delete(ctx, node.Name, metav1.DeleteOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to delete node", "node", node.Name)
+		}
+	}
+
+	return nil
+}
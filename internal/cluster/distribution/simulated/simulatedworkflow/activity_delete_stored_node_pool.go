@@ -0,0 +1,53 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedworkflow
+
+import (
+	"context"
+
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+)
+
+const DeleteStoredNodePoolActivityName = "simulated-delete-stored-node-pool"
+
+// DeleteStoredNodePoolActivity deletes a node pool from the store.
+type DeleteStoredNodePoolActivity struct {
+	nodePools simulated.NodePoolStore
+}
+
+// DeleteStoredNodePoolActivityInput holds the parameters for deleting the node pool.
+type DeleteStoredNodePoolActivityInput struct {
+	ClusterID    uint
+	NodePoolName string
+}
+
+// NewDeleteStoredNodePoolActivity creates a new DeleteStoredNodePoolActivity instance.
+func NewDeleteStoredNodePoolActivity(nodePools simulated.NodePoolStore) DeleteStoredNodePoolActivity {
+	return DeleteStoredNodePoolActivity{
+		nodePools: nodePools,
+	}
+}
+
+// Register registers the activity in the worker.
+func (a DeleteStoredNodePoolActivity) Register() {
+	activity.RegisterWithOptions(a.Execute, activity.RegisterOptions{Name: DeleteStoredNodePoolActivityName})
+}
+
+// Execute is the main body of the activity.
+func (a DeleteStoredNodePoolActivity) Execute(ctx context.Context, input DeleteStoredNodePoolActivityInput) error {
+	return a.nodePools.DeleteNodePool(ctx, input.ClusterID, input.NodePoolName)
+}
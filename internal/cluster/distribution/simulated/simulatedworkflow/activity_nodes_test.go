@@ -0,0 +1,97 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedworkflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	"github.com/banzaicloud/pipeline/pkg/common"
+)
+
+type fakeClientFactory struct {
+	client kubernetes.Interface
+}
+
+func (f fakeClientFactory) FromSecret(_ context.Context, secretID string) (kubernetes.Interface, error) {
+	return f.client, nil
+}
+
+func listNodeNames(t *testing.T, client kubernetes.Interface) []string {
+	nodes, err := client.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		names = append(names, node.Name)
+	}
+
+	return names
+}
+
+func TestNodesActivities(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset()
+	clientFactory := fakeClientFactory{client: client}
+
+	input := NodesActivityInput{
+		OrganizationID: 1,
+		SecretID:       "secret",
+		ClusterID:      2,
+		NodePoolName:   "pool0",
+	}
+
+	createNodes := NewCreateNodesActivity(clientFactory)
+
+	require.NoError(t, createNodes.Execute(ctx, CreateNodesActivityInput{NodesActivityInput: input, InstanceType: "small", Size: 2}))
+
+	// node creation is idempotent
+	require.NoError(t, createNodes.Execute(ctx, CreateNodesActivityInput{NodesActivityInput: input, InstanceType: "small", Size: 3}))
+
+	otherPoolInput := input
+	otherPoolInput.NodePoolName = "pool1"
+	require.NoError(t, createNodes.Execute(ctx, CreateNodesActivityInput{NodesActivityInput: otherPoolInput, InstanceType: "small", Size: 1}))
+
+	assert.ElementsMatch(
+		t,
+		[]string{"simulated-2-pool0-0", "simulated-2-pool0-1", "simulated-2-pool0-2", "simulated-2-pool1-0"},
+		listNodeNames(t, client),
+	)
+
+	node, err := client.CoreV1().Nodes().Get(ctx, "simulated-2-pool0-0", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "pool0", node.Labels[common.LabelKey])
+	assert.Equal(t, "2", node.Labels[simulated.ClusterIDLabelKey])
+	assert.Equal(t, simulated.NodeTaintKey, node.Spec.Taints[0].Key)
+
+	deleteNodes := NewDeleteNodesActivity(clientFactory)
+
+	require.NoError(t, deleteNodes.Execute(ctx, DeleteNodesActivityInput{NodesActivityInput: input, Size: 1}))
+
+	assert.ElementsMatch(t, []string{"simulated-2-pool0-0", "simulated-2-pool1-0"}, listNodeNames(t, client))
+
+	clusterInput := input
+	clusterInput.NodePoolName = ""
+	require.NoError(t, deleteNodes.Execute(ctx, DeleteNodesActivityInput{NodesActivityInput: clusterInput}))
+
+	assert.Empty(t, listNodeNames(t, client))
+}
@@ -0,0 +1,109 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedworkflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	pkgCadence "github.com/banzaicloud/pipeline/pkg/cadence"
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+// KubeClientFactory creates Kubernetes clients for the API servers backing simulated clusters.
+type KubeClientFactory interface {
+	// FromSecret creates a Kubernetes client for a cluster from a secret.
+	FromSecret(ctx context.Context, secretID string) (kubernetes.Interface, error)
+}
+
+// NodesActivityInput holds the common parameters of the simulated node activities.
+type NodesActivityInput struct {
+	OrganizationID uint
+	SecretID       string
+	ClusterID      uint
+	NodePoolName   string
+}
+
+// secretBRN returns the resource name of the secret holding the kubeconfig of the backing API server.
+func (i NodesActivityInput) secretBRN() string {
+	return brn.New(i.OrganizationID, brn.SecretResourceType, i.SecretID).String()
+}
+
+func activityOptions(ctx workflow.Context) workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          2 * time.Second,
+			BackoffCoefficient:       1.5,
+			MaximumAttempts:          5,
+			MaximumInterval:          30 * time.Second,
+			NonRetriableErrorReasons: []string{"cadenceInternal:Panic", pkgCadence.ClientErrorReason},
+		},
+		ScheduleToStartTimeout: time.Duration(workflow.GetInfo(ctx).ExecutionStartToCloseTimeoutSeconds) * time.Second,
+		StartToCloseTimeout:    10 * time.Minute,
+		WaitForCancellation:    true,
+	}
+}
+
+// finishClusterUpdate sets the cluster status according to the outcome of a node pool workflow.
+func finishClusterUpdate(ctx workflow.Context, clusterID uint, failureMessage string, err error) {
+	status := cluster.Running
+	statusMessage := cluster.RunningMessage
+
+	if err != nil {
+		if cadence.IsCanceledError(err) {
+			ctx, _ = workflow.NewDisconnectedContext(ctx)
+		}
+
+		status = cluster.Warning
+		statusMessage = fmt.Sprintf("%s: %s", failureMessage, err.Error())
+	}
+
+	_ = setClusterStatus(ctx, clusterID, status, statusMessage)
+}
+
+// executeProcessActivity executes an activity and records it in the process log.
+func executeProcessActivity(ctx workflow.Context, process processlog.Process, activityName string, activityInput interface{}) error {
+	processActivity := process.StartActivity(ctx, activityName)
+	err := workflow.ExecuteActivity(ctx, activityName, activityInput).Get(ctx, nil)
+	processActivity.Finish(ctx, err)
+
+	return err
+}
+
+func setClusterStatus(ctx workflow.Context, clusterID uint, status, statusMessage string) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    2 * time.Minute,
+		WaitForCancellation:    true,
+	})
+
+	return workflow.ExecuteActivity(ctx, clusterworkflow.SetClusterStatusActivityName, clusterworkflow.SetClusterStatusActivityInput{
+		ClusterID:     clusterID,
+		Status:        status,
+		StatusMessage: statusMessage,
+	}).Get(ctx, nil)
+}
+
+func setClusterErrorStatus(ctx workflow.Context, clusterID uint, err error) error {
+	return setClusterStatus(ctx, clusterID, cluster.Error, pkgCadence.UnwrapError(err).Error())
+}
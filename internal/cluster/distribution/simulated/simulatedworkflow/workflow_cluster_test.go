@@ -0,0 +1,245 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedworkflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustersetup"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	intClusterWorkflow "github.com/banzaicloud/pipeline/internal/cluster/workflow"
+	pipCluster "github.com/banzaicloud/pipeline/src/cluster"
+)
+
+// nolint: gochecknoinits
+func init() {
+	NewCreateNodesActivity(nil).Register()
+	NewDeleteNodesActivity(nil).Register()
+	NewDeleteClusterFromStoreActivity(nil).Register()
+
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input clusterworkflow.SetClusterStatusActivityInput) error { return nil },
+		activity.RegisterOptions{Name: clusterworkflow.SetClusterStatusActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input pipCluster.DownloadK8sConfigActivityInput) (string, error) {
+			return "", nil
+		},
+		activity.RegisterOptions{Name: pipCluster.DownloadK8sConfigActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input intClusterWorkflow.DeleteUnusedClusterSecretsActivityInput) error {
+			return nil
+		},
+		activity.RegisterOptions{Name: intClusterWorkflow.DeleteUnusedClusterSecretsActivityName},
+	)
+
+	workflow.RegisterWithOptions(
+		func(ctx workflow.Context, input clustersetup.WorkflowInput) error { return nil },
+		workflow.RegisterOptions{Name: clustersetup.WorkflowName},
+	)
+	workflow.RegisterWithOptions(
+		func(ctx workflow.Context, input pipCluster.RunPostHooksWorkflowInput) error { return nil },
+		workflow.RegisterOptions{Name: pipCluster.RunPostHooksWorkflowName},
+	)
+	workflow.RegisterWithOptions(
+		func(ctx workflow.Context, input intClusterWorkflow.DeleteK8sResourcesWorkflowInput) error { return nil },
+		workflow.RegisterOptions{Name: intClusterWorkflow.DeleteK8sResourcesWorkflowName},
+	)
+}
+
+type ClusterWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestClusterWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(ClusterWorkflowTestSuite))
+}
+
+func (s *ClusterWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+}
+
+func (s *ClusterWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+func (s *ClusterWorkflowTestSuite) Test_CreateCluster() {
+	wf := NewCreateClusterWorkflow(time.Minute)
+	workflow.RegisterWithOptions(wf.Execute, workflow.RegisterOptions{Name: s.T().Name()})
+
+	nodesActivityInput := NodesActivityInput{
+		OrganizationID: 1,
+		SecretID:       "secret",
+		ClusterID:      2,
+		NodePoolName:   "pool0",
+	}
+
+	s.env.OnActivity(
+		CreateNodesActivityName,
+		mock.Anything,
+		CreateNodesActivityInput{
+			NodesActivityInput: nodesActivityInput,
+			InstanceType:       simulated.DefaultInstanceType,
+			Size:               3,
+		},
+	).Return(nil).Once()
+
+	s.env.OnActivity(
+		pipCluster.DownloadK8sConfigActivityName,
+		mock.Anything,
+		pipCluster.DownloadK8sConfigActivityInput{ClusterID: 2},
+	).Return("config-secret", nil).Once()
+
+	s.env.OnWorkflow(
+		clustersetup.WorkflowName,
+		mock.Anything,
+		clustersetup.WorkflowInput{
+			ConfigSecretID: "brn:1:secret:config-secret",
+			Cluster: clustersetup.Cluster{
+				ID:   2,
+				UID:  "uid",
+				Name: "cluster",
+			},
+			Organization: clustersetup.Organization{
+				ID:   1,
+				Name: "org",
+			},
+			NodePoolLabels: map[string]map[string]string{"pool0": {"key": "value"}},
+		},
+	).Return(nil).Once()
+
+	s.env.OnWorkflow(
+		pipCluster.RunPostHooksWorkflowName,
+		mock.Anything,
+		pipCluster.RunPostHooksWorkflowInput{
+			ClusterID: 2,
+			PostHooks: pipCluster.BuildWorkflowPostHookFunctions(nil, true),
+		},
+	).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(s.T().Name(), CreateClusterWorkflowInput{
+		OrganizationID:   1,
+		OrganizationName: "org",
+		SecretID:         "secret",
+		ClusterID:        2,
+		ClusterUID:       "uid",
+		ClusterName:      "cluster",
+		NodePools: []simulated.NodePool{
+			{
+				Name:         "pool0",
+				Size:         3,
+				InstanceType: simulated.DefaultInstanceType,
+			},
+		},
+		NodePoolLabels: map[string]map[string]string{"pool0": {"key": "value"}},
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+func (s *ClusterWorkflowTestSuite) Test_DeleteCluster() {
+	wf := NewDeleteClusterWorkflow()
+	workflow.RegisterWithOptions(wf.Execute, workflow.RegisterOptions{Name: s.T().Name()})
+
+	s.env.OnWorkflow(
+		intClusterWorkflow.DeleteK8sResourcesWorkflowName,
+		mock.Anything,
+		intClusterWorkflow.DeleteK8sResourcesWorkflowInput{
+			OrganizationID: 1,
+			ClusterName:    "cluster",
+			K8sSecretID:    "config-secret",
+		},
+	).Return(nil).Once()
+
+	s.env.OnActivity(
+		DeleteNodesActivityName,
+		mock.Anything,
+		DeleteNodesActivityInput{
+			NodesActivityInput: NodesActivityInput{
+				OrganizationID: 1,
+				SecretID:       "secret",
+				ClusterID:      2,
+			},
+		},
+	).Return(nil).Once()
+
+	s.env.OnActivity(
+		intClusterWorkflow.DeleteUnusedClusterSecretsActivityName,
+		mock.Anything,
+		intClusterWorkflow.DeleteUnusedClusterSecretsActivityInput{
+			OrganizationID: 1,
+			ClusterUID:     "uid",
+		},
+	).Return(nil).Once()
+
+	s.env.OnActivity(
+		DeleteClusterFromStoreActivityName,
+		mock.Anything,
+		DeleteClusterFromStoreActivityInput{ClusterID: 2},
+	).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(s.T().Name(), DeleteClusterWorkflowInput{
+		OrganizationID: 1,
+		SecretID:       "secret",
+		ClusterID:      2,
+		ClusterUID:     "uid",
+		ClusterName:    "cluster",
+		K8sSecretID:    "config-secret",
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+func (s *ClusterWorkflowTestSuite) Test_DeleteCluster_ForcedIgnoresErrors() {
+	wf := NewDeleteClusterWorkflow()
+	workflow.RegisterWithOptions(wf.Execute, workflow.RegisterOptions{Name: s.T().Name()})
+
+	s.env.OnActivity(DeleteNodesActivityName, mock.Anything, mock.Anything).
+		Return(errors.New("API server is unavailable")).Once()
+
+	s.env.OnActivity(intClusterWorkflow.DeleteUnusedClusterSecretsActivityName, mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	s.env.OnActivity(DeleteClusterFromStoreActivityName, mock.Anything, DeleteClusterFromStoreActivityInput{ClusterID: 2}).
+		Return(nil).Once()
+
+	s.env.ExecuteWorkflow(s.T().Name(), DeleteClusterWorkflowInput{
+		OrganizationID: 1,
+		SecretID:       "secret",
+		ClusterID:      2,
+		ClusterUID:     "uid",
+		ClusterName:    "cluster",
+		Forced:         true,
+	})
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
@@ -0,0 +1,158 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedworkflow
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustersetup"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	pipCluster "github.com/banzaicloud/pipeline/src/cluster"
+)
+
+const CreateClusterWorkflowName = "simulated-create-cluster"
+
+// CreateClusterWorkflow provisions a simulated cluster.
+//
+// The workflow follows the same steps as the cloud provider specific ones
+// (node provisioning, kubeconfig download, cluster setup and post hooks),
+// but the nodes are only registered in the backing API server.
+type CreateClusterWorkflow struct {
+	provisioningDelay time.Duration
+}
+
+// NewCreateClusterWorkflow returns a new CreateClusterWorkflow.
+func NewCreateClusterWorkflow(provisioningDelay time.Duration) CreateClusterWorkflow {
+	return CreateClusterWorkflow{
+		provisioningDelay: provisioningDelay,
+	}
+}
+
+type CreateClusterWorkflowInput struct {
+	OrganizationID   uint
+	OrganizationName string
+	SecretID         string
+
+	ClusterID   uint
+	ClusterUID  string
+	ClusterName string
+
+	NodePools      []simulated.NodePool
+	NodePoolLabels map[string]map[string]string
+	PostHooks      pkgCluster.PostHooks
+}
+
+func (w CreateClusterWorkflow) Register() {
+	workflow.RegisterWithOptions(w.Execute, workflow.RegisterOptions{Name: CreateClusterWorkflowName})
+}
+
+func (w CreateClusterWorkflow) Execute(ctx workflow.Context, input CreateClusterWorkflowInput) (err error) {
+	ctx = workflow.WithActivityOptions(ctx, activityOptions(ctx))
+
+	defer func() {
+		if err != nil {
+			_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+		}
+	}()
+
+	// simulate the time needed to provision the cloud infrastructure
+	if w.provisioningDelay > 0 {
+		err = workflow.Sleep(ctx, w.provisioningDelay)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		futures := make([]workflow.Future, 0, len(input.NodePools))
+
+		for _, nodePool := range input.NodePools {
+			activityInput := CreateNodesActivityInput{
+				NodesActivityInput: NodesActivityInput{
+					OrganizationID: input.OrganizationID,
+					SecretID:       input.SecretID,
+					ClusterID:      input.ClusterID,
+					NodePoolName:   nodePool.Name,
+				},
+				InstanceType: nodePool.InstanceType,
+				Size:         nodePool.Size,
+			}
+
+			futures = append(futures, workflow.ExecuteActivity(ctx, CreateNodesActivityName, activityInput))
+		}
+
+		errs := make([]error, 0, len(futures))
+		for i, future := range futures {
+			errs = append(errs, errors.WrapIff(future.Get(ctx, nil), "creating nodes of node pool %q", input.NodePools[i].Name))
+		}
+
+		err = errors.Combine(errs...)
+		if err != nil {
+			return err
+		}
+	}
+
+	var configSecretID string
+	{
+		activityInput := pipCluster.DownloadK8sConfigActivityInput{
+			ClusterID: input.ClusterID,
+		}
+
+		err = workflow.ExecuteActivity(ctx, pipCluster.DownloadK8sConfigActivityName, activityInput).Get(ctx, &configSecretID)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		workflowInput := clustersetup.WorkflowInput{
+			ConfigSecretID: brn.New(input.OrganizationID, brn.SecretResourceType, configSecretID).String(),
+			Cluster: clustersetup.Cluster{
+				ID:   input.ClusterID,
+				UID:  input.ClusterUID,
+				Name: input.ClusterName,
+			},
+			Organization: clustersetup.Organization{
+				ID:   input.OrganizationID,
+				Name: input.OrganizationName,
+			},
+			NodePoolLabels: input.NodePoolLabels,
+		}
+
+		err = workflow.ExecuteChildWorkflow(ctx, clustersetup.WorkflowName, workflowInput).Get(ctx, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		workflowInput := pipCluster.RunPostHooksWorkflowInput{
+			ClusterID: input.ClusterID,
+			PostHooks: pipCluster.BuildWorkflowPostHookFunctions(input.PostHooks, true),
+		}
+
+		err = workflow.ExecuteChildWorkflow(ctx, pipCluster.RunPostHooksWorkflowName, workflowInput).Get(ctx, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedworkflow
+
+import (
+	"fmt"
+
+	"go.uber.org/cadence/workflow"
+
+	intClusterWorkflow "github.com/banzaicloud/pipeline/internal/cluster/workflow"
+	pkgCadence "github.com/banzaicloud/pipeline/pkg/cadence"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+const DeleteClusterWorkflowName = "simulated-delete-cluster"
+
+// DeleteClusterWorkflow deletes a simulated cluster.
+type DeleteClusterWorkflow struct{}
+
+// NewDeleteClusterWorkflow returns a new DeleteClusterWorkflow.
+func NewDeleteClusterWorkflow() DeleteClusterWorkflow {
+	return DeleteClusterWorkflow{}
+}
+
+type DeleteClusterWorkflowInput struct {
+	OrganizationID uint
+	SecretID       string
+
+	ClusterID   uint
+	ClusterUID  string
+	ClusterName string
+	K8sSecretID string
+
+	Forced bool
+}
+
+func (w DeleteClusterWorkflow) Register() {
+	workflow.RegisterWithOptions(w.Execute, workflow.RegisterOptions{Name: DeleteClusterWorkflowName})
+}
+
+func (w DeleteClusterWorkflow) Execute(ctx workflow.Context, input DeleteClusterWorkflowInput) error {
+	ctx = workflow.WithActivityOptions(ctx, activityOptions(ctx))
+
+	logger := workflow.GetLogger(ctx).Sugar()
+
+	// delete k8s resources
+	if input.K8sSecretID != "" {
+		workflowInput := intClusterWorkflow.DeleteK8sResourcesWorkflowInput{
+			OrganizationID: input.OrganizationID,
+			ClusterName:    input.ClusterName,
+			K8sSecretID:    input.K8sSecretID,
+		}
+
+		err := workflow.ExecuteChildWorkflow(ctx, intClusterWorkflow.DeleteK8sResourcesWorkflowName, workflowInput).Get(ctx, nil)
+		if err != nil {
+			if input.Forced {
+				logger.Errorw("deleting k8s resources failed", "error", err)
+			} else {
+				_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+				return err
+			}
+		}
+	}
+
+	// deregister nodes
+	{
+		activityInput := DeleteNodesActivityInput{
+			NodesActivityInput: NodesActivityInput{
+				OrganizationID: input.OrganizationID,
+				SecretID:       input.SecretID,
+				ClusterID:      input.ClusterID,
+			},
+		}
+
+		err := workflow.ExecuteActivity(ctx, DeleteNodesActivityName, activityInput).Get(ctx, nil)
+		if err != nil {
+			if input.Forced {
+				logger.Errorw("deleting nodes failed", "error", err)
+			} else {
+				_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+				return err
+			}
+		}
+	}
+
+	// delete unused secrets
+	{
+		activityInput := intClusterWorkflow.DeleteUnusedClusterSecretsActivityInput{
+			OrganizationID: input.OrganizationID,
+			ClusterUID:     input.ClusterUID,
+		}
+
+		err := workflow.ExecuteActivity(ctx, intClusterWorkflow.DeleteUnusedClusterSecretsActivityName, activityInput).Get(ctx, nil)
+		if err != nil {
+			_ = setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, fmt.Sprintf("failed to delete unused cluster secrets: %v", pkgCadence.UnwrapError(err)))
+		}
+	}
+
+	// delete cluster from data store
+	{
+		activityInput := DeleteClusterFromStoreActivityInput{
+			ClusterID: input.ClusterID,
+		}
+
+		err := workflow.ExecuteActivity(ctx, DeleteClusterFromStoreActivityName, activityInput).Get(ctx, nil)
+		if err != nil {
+			if input.Forced {
+				logger.Errorw("deleting cluster from data store failed", "error", err)
+			} else {
+				_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
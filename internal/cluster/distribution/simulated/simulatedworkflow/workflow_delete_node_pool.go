@@ -0,0 +1,108 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulatedworkflow
+
+import (
+	"fmt"
+
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	"github.com/banzaicloud/pipeline/pkg/sdk/brn"
+	"github.com/banzaicloud/pipeline/pkg/sdk/cadence/lib/pipeline/processlog"
+)
+
+const DeleteNodePoolWorkflowName = "simulated-delete-node-pool"
+
+type DeleteNodePoolWorkflow struct {
+	processLogger processlog.ProcessLogger
+}
+
+// NewDeleteNodePoolWorkflow returns a new DeleteNodePoolWorkflow.
+func NewDeleteNodePoolWorkflow(processLogger processlog.ProcessLogger) DeleteNodePoolWorkflow {
+	return DeleteNodePoolWorkflow{
+		processLogger: processLogger,
+	}
+}
+
+type DeleteNodePoolWorkflowInput struct {
+	OrganizationID uint
+	SecretID       string
+
+	ClusterID   uint
+	ClusterName string
+
+	NodePoolName string
+}
+
+func (w DeleteNodePoolWorkflow) Register() {
+	workflow.RegisterWithOptions(w.Execute, workflow.RegisterOptions{Name: DeleteNodePoolWorkflowName})
+}
+
+func (w DeleteNodePoolWorkflow) Execute(ctx workflow.Context, input DeleteNodePoolWorkflowInput) (err error) {
+	ctx = workflow.WithActivityOptions(ctx, activityOptions(ctx))
+
+	clusterID := brn.New(input.OrganizationID, brn.ClusterResourceType, fmt.Sprint(input.ClusterID))
+
+	process := w.processLogger.StartProcess(ctx, clusterID.String())
+	defer func() {
+		process.Finish(ctx, err)
+	}()
+	defer func() {
+		finishClusterUpdate(ctx, input.ClusterID, "failed to delete node pool", err)
+	}()
+
+	{
+		activityInput := DeleteNodesActivityInput{
+			NodesActivityInput: NodesActivityInput{
+				OrganizationID: input.OrganizationID,
+				SecretID:       input.SecretID,
+				ClusterID:      input.ClusterID,
+				NodePoolName:   input.NodePoolName,
+			},
+		}
+
+		err = executeProcessActivity(ctx, process, DeleteNodesActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		activityInput := clusterworkflow.DeleteNodePoolLabelSetActivityInput{
+			ClusterID:    input.ClusterID,
+			NodePoolName: input.NodePoolName,
+		}
+
+		err = executeProcessActivity(ctx, process, clusterworkflow.DeleteNodePoolLabelSetActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		activityInput := DeleteStoredNodePoolActivityInput{
+			ClusterID:    input.ClusterID,
+			NodePoolName: input.NodePoolName,
+		}
+
+		err = executeProcessActivity(ctx, process, DeleteStoredNodePoolActivityName, activityInput)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+type distributionNodePoolManager struct {
+	clusters Store
+	managers map[string]NodePoolManager
+}
+
+// NewDistributionNodePoolManager returns a new NodePoolManager
+// that allows registering managers for Kubernetes distributions.
+func NewDistributionNodePoolManager(clusters Store, managers map[string]NodePoolManager) NodePoolManager {
+	return distributionNodePoolManager{
+		clusters: clusters,
+		managers: managers,
+	}
+}
+
+func (m distributionNodePoolManager) getManager(ctx context.Context, clusterID uint) (NodePoolManager, error) {
+	cluster, err := m.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	manager, ok := m.managers[cluster.Distribution]
+	if !ok {
+		return nil, errors.WithStack(NotSupportedDistributionError{
+			ID:           cluster.ID,
+			Cloud:        cluster.Cloud,
+			Distribution: cluster.Distribution,
+
+			Message: "cannot manage node pools of unsupported distribution",
+		})
+	}
+
+	return manager, nil
+}
+
+func (m distributionNodePoolManager) CreateNodePool(ctx context.Context, clusterID uint, rawNodePool NewRawNodePool) error {
+	manager, err := m.getManager(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return manager.CreateNodePool(ctx, clusterID, rawNodePool)
+}
+
+func (m distributionNodePoolManager) DeleteNodePool(ctx context.Context, clusterID uint, name string) error {
+	manager, err := m.getManager(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return manager.DeleteNodePool(ctx, clusterID, name)
+}
+
+type cloudNodePoolManager struct {
+	clusters Store
+	managers map[string]NodePoolManager
+}
+
+// NewCloudNodePoolManager returns a new NodePoolManager
+// that allows registering managers for cloud providers.
+// It is useful for distributions available on multiple clouds (eg. PKE).
+func NewCloudNodePoolManager(clusters Store, managers map[string]NodePoolManager) NodePoolManager {
+	return cloudNodePoolManager{
+		clusters: clusters,
+		managers: managers,
+	}
+}
+
+func (m cloudNodePoolManager) getManager(ctx context.Context, clusterID uint) (NodePoolManager, error) {
+	cluster, err := m.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	manager, ok := m.managers[cluster.Cloud]
+	if !ok {
+		return nil, errors.WithStack(NotSupportedDistributionError{
+			ID:           cluster.ID,
+			Cloud:        cluster.Cloud,
+			Distribution: cluster.Distribution,
+
+			Message: "cannot manage node pools on unsupported cloud",
+		})
+	}
+
+	return manager, nil
+}
+
+func (m cloudNodePoolManager) CreateNodePool(ctx context.Context, clusterID uint, rawNodePool NewRawNodePool) error {
+	manager, err := m.getManager(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return manager.CreateNodePool(ctx, clusterID, rawNodePool)
+}
+
+func (m cloudNodePoolManager) DeleteNodePool(ctx context.Context, clusterID uint, name string) error {
+	manager, err := m.getManager(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return manager.DeleteNodePool(ctx, clusterID, name)
+}
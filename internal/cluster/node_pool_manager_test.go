@@ -0,0 +1,107 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistributionNodePoolManager_CreateNodePool(t *testing.T) {
+	ctx := context.Background()
+	nodePool := NewRawNodePool{"name": "pool0"}
+
+	t.Run("OK", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("GetCluster", ctx, uint(1)).Return(Cluster{ID: 1, Cloud: "azure", Distribution: "aks"}, nil)
+
+		aksManager := new(MockNodePoolManager)
+		aksManager.On("CreateNodePool", ctx, uint(1), nodePool).Return(nil)
+
+		manager := NewDistributionNodePoolManager(store, map[string]NodePoolManager{
+			"aks": aksManager,
+			"eks": new(MockNodePoolManager),
+		})
+
+		err := manager.CreateNodePool(ctx, 1, nodePool)
+		require.NoError(t, err)
+
+		store.AssertExpectations(t)
+		aksManager.AssertExpectations(t)
+	})
+
+	t.Run("UnsupportedDistribution", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("GetCluster", ctx, uint(1)).Return(Cluster{ID: 1, Cloud: "azure", Distribution: "aks"}, nil)
+
+		manager := NewDistributionNodePoolManager(store, map[string]NodePoolManager{
+			"eks": new(MockNodePoolManager),
+		})
+
+		err := manager.CreateNodePool(ctx, 1, nodePool)
+		require.Error(t, err)
+
+		var nerr NotSupportedDistributionError
+
+		assert.True(t, errors.As(err, &nerr))
+
+		store.AssertExpectations(t)
+	})
+}
+
+func TestCloudNodePoolManager_DeleteNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("GetCluster", ctx, uint(1)).Return(Cluster{ID: 1, Cloud: "azure", Distribution: "pke"}, nil)
+
+		azureManager := new(MockNodePoolManager)
+		azureManager.On("DeleteNodePool", ctx, uint(1), "pool0").Return(nil)
+
+		manager := NewCloudNodePoolManager(store, map[string]NodePoolManager{
+			"amazon": new(MockNodePoolManager),
+			"azure":  azureManager,
+		})
+
+		err := manager.DeleteNodePool(ctx, 1, "pool0")
+		require.NoError(t, err)
+
+		store.AssertExpectations(t)
+		azureManager.AssertExpectations(t)
+	})
+
+	t.Run("UnsupportedCloud", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("GetCluster", ctx, uint(1)).Return(Cluster{ID: 1, Cloud: "google", Distribution: "pke"}, nil)
+
+		manager := NewCloudNodePoolManager(store, map[string]NodePoolManager{
+			"amazon": new(MockNodePoolManager),
+		})
+
+		err := manager.DeleteNodePool(ctx, 1, "pool0")
+		require.Error(t, err)
+
+		var nerr NotSupportedDistributionError
+
+		assert.True(t, errors.As(err, &nerr))
+
+		store.AssertExpectations(t)
+	})
+}
@@ -107,3 +107,35 @@ func (p distributionNodePoolProcessor) ProcessNew(
 
 	return processor.ProcessNew(ctx, cluster, rawNodePool)
 }
+
+type cloudNodePoolProcessor struct {
+	processors map[string]NodePoolProcessor
+}
+
+// NewCloudNodePoolProcessor returns a new NodePoolProcessor
+// that allows registering processors for cloud providers.
+// It is useful for distributions available on multiple clouds (eg. PKE).
+func NewCloudNodePoolProcessor(processors map[string]NodePoolProcessor) NodePoolProcessor {
+	return cloudNodePoolProcessor{
+		processors: processors,
+	}
+}
+
+func (p cloudNodePoolProcessor) ProcessNew(
+	ctx context.Context,
+	cluster Cluster,
+	rawNodePool NewRawNodePool,
+) (NewRawNodePool, error) {
+	processor, ok := p.processors[cluster.Cloud]
+	if !ok {
+		return rawNodePool, errors.WithStack(NotSupportedDistributionError{
+			ID:           cluster.ID,
+			Cloud:        cluster.Cloud,
+			Distribution: cluster.Distribution,
+
+			Message: "cannot process unsupported cloud",
+		})
+	}
+
+	return processor.ProcessNew(ctx, cluster, rawNodePool)
+}
@@ -193,3 +193,55 @@ func TestNewDistributionNodePoolProcessor_ProcessNew(t *testing.T) {
 		assert.Equal(t, expectedErr, errors.Cause(err))
 	})
 }
+
+func TestNewCloudNodePoolProcessor_ProcessNew(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		cloudProcessor := new(MockNodePoolProcessor)
+
+		ctx := context.Background()
+		cluster := Cluster{
+			Cloud:        "amazon",
+			Distribution: "pke",
+		}
+		nodePool := NewRawNodePool{}
+		nodePool2 := NewRawNodePool{"key": "value"}
+
+		cloudProcessor.On("ProcessNew", ctx, cluster, nodePool).Return(nodePool2, nil)
+
+		processor := NewCloudNodePoolProcessor(map[string]NodePoolProcessor{
+			"amazon": cloudProcessor,
+		})
+
+		processedNodePool, err := processor.ProcessNew(ctx, cluster, nodePool)
+		require.NoError(t, err)
+
+		assert.Equal(t, nodePool2, processedNodePool)
+
+		cloudProcessor.AssertExpectations(t)
+	})
+
+	t.Run("UnsupportedCloud", func(t *testing.T) {
+		ctx := context.Background()
+		cluster := Cluster{
+			ID:           1,
+			Cloud:        "azure",
+			Distribution: "pke",
+		}
+		nodePool := NewRawNodePool{}
+
+		processor := NewCloudNodePoolProcessor(map[string]NodePoolProcessor{})
+
+		_, err := processor.ProcessNew(ctx, cluster, nodePool)
+		require.Error(t, err)
+
+		expectedErr := NotSupportedDistributionError{
+			ID:           cluster.ID,
+			Cloud:        cluster.Cloud,
+			Distribution: cluster.Distribution,
+
+			Message: "cannot process unsupported cloud",
+		}
+
+		assert.Equal(t, expectedErr, errors.Cause(err))
+	})
+}
@@ -121,6 +121,34 @@ func (v distributionNodePoolValidator) ValidateNew(ctx context.Context, cluster
 	return validator.ValidateNew(ctx, cluster, rawNodePool)
 }
 
+type cloudNodePoolValidator struct {
+	validators map[string]NodePoolValidator
+}
+
+// NewCloudNodePoolValidator returns a new NodePoolValidator
+// that allows registering validators for cloud providers.
+// It is useful for distributions available on multiple clouds (eg. PKE).
+func NewCloudNodePoolValidator(validators map[string]NodePoolValidator) NodePoolValidator {
+	return cloudNodePoolValidator{
+		validators: validators,
+	}
+}
+
+func (v cloudNodePoolValidator) ValidateNew(ctx context.Context, cluster Cluster, rawNodePool NewRawNodePool) error {
+	validator, ok := v.validators[cluster.Cloud]
+	if !ok {
+		return errors.WithStack(NotSupportedDistributionError{
+			ID:           cluster.ID,
+			Cloud:        cluster.Cloud,
+			Distribution: cluster.Distribution,
+
+			Message: "cannot validate unsupported cloud",
+		})
+	}
+
+	return validator.ValidateNew(ctx, cluster, rawNodePool)
+}
+
 // unwrapViolations is a helper func to unwrap violations from a validation error
 func unwrapViolations(err error) []string {
 	var verr interface {
@@ -220,3 +220,52 @@ func TestNewDistributionNodePoolValidator_ValidateNew(t *testing.T) {
 		assert.Equal(t, expectedErr, errors.Cause(err))
 	})
 }
+
+func TestNewCloudNodePoolValidator_ValidateNew(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cloudValidator := new(MockNodePoolValidator)
+
+		ctx := context.Background()
+		cluster := Cluster{
+			Cloud:        "amazon",
+			Distribution: "pke",
+		}
+		nodePool := NewRawNodePool{}
+
+		cloudValidator.On("ValidateNew", ctx, cluster, nodePool).Return(nil)
+
+		validator := NewCloudNodePoolValidator(map[string]NodePoolValidator{
+			"amazon": cloudValidator,
+		})
+
+		err := validator.ValidateNew(ctx, cluster, nodePool)
+		require.NoError(t, err)
+
+		cloudValidator.AssertExpectations(t)
+	})
+
+	t.Run("UnsupportedCloud", func(t *testing.T) {
+		ctx := context.Background()
+		cluster := Cluster{
+			ID:           1,
+			Cloud:        "azure",
+			Distribution: "pke",
+		}
+		nodePool := NewRawNodePool{}
+
+		validator := NewCloudNodePoolValidator(map[string]NodePoolValidator{})
+
+		err := validator.ValidateNew(ctx, cluster, nodePool)
+		require.Error(t, err)
+
+		expectedErr := NotSupportedDistributionError{
+			ID:           cluster.ID,
+			Cloud:        cluster.Cloud,
+			Distribution: cluster.Distribution,
+
+			Message: "cannot validate unsupported cloud",
+		}
+
+		assert.Equal(t, expectedErr, errors.Cause(err))
+	})
+}
@@ -332,6 +332,16 @@ func (s service) nodePoolSupported(cluster Cluster) error {
 	switch {
 	case cluster.Cloud == cloud.Amazon && cluster.Distribution == "eks":
 		return nil
+	case cluster.Cloud == cloud.Amazon && cluster.Distribution == "pke":
+		return nil
+	case cluster.Cloud == cloud.Google && cluster.Distribution == "gke":
+		return nil
+	case cluster.Cloud == cloud.Azure && cluster.Distribution == "aks":
+		return nil
+	case cluster.Cloud == cloud.Azure && cluster.Distribution == "pke":
+		return nil
+	case cluster.Cloud == "simulated" && cluster.Distribution == "simulated":
+		return nil
 	}
 
 	return errors.WithStack(NotSupportedDistributionError{
@@ -20,6 +20,7 @@ import (
 	"emperror.dev/errors"
 	"github.com/ghodss/yaml"
 
+	internalhelm "github.com/banzaicloud/pipeline/internal/helm"
 	"github.com/banzaicloud/pipeline/pkg/jsonstructure"
 	"github.com/banzaicloud/pipeline/src/helm"
 )
@@ -37,6 +38,9 @@ type ClusterGroupDeployment struct {
 	ValueOverrides map[string]map[string]interface{} `json:"valueOverrides,omitempty" yaml:"valueOverrides,omitempty"`
 	RollingMode    bool                              `json:"rollingMode,omitempty" yaml:"rollingMode,omitempty"`
 	Atomic         bool                              `json:"atomic,omitempty" yaml:"atomic,omitempty"`
+	// Verify enables the health verification of the deployment on each target cluster;
+	// the deployment is rolled back on the clusters where it fails the verification
+	Verify *internalhelm.VerifyOptions `json:"verify,omitempty" yaml:"verify,omitempty"`
 }
 
 // DeploymentInfo describes the details of a helm deployment
@@ -54,6 +58,8 @@ type DeploymentInfo struct {
 	ValueOverrides       map[string]map[string]interface{} `json:"valueOverrides,omitempty" yaml:"valueOverrides,omitempty"`
 	TargetClusters       map[uint]bool                     `json:"-" yaml:"-"`
 	TargetClustersStatus []TargetClusterStatus             `json:"targetClusters"`
+	// Verify holds the verification options of the deployment, it's applied on every sync as well
+	Verify *internalhelm.VerifyOptions `json:"verify,omitempty" yaml:"verify,omitempty"`
 }
 
 func (c *DeploymentInfo) GetValuesForCluster(clusterName string) ([]byte, error) {
@@ -31,6 +31,8 @@ type ChartMeta struct {
 
 type HelmService interface {
 	GetChartMeta(orgId uint, name, version string) (ChartMeta, error)
+	// CheckChartPolicy checks whether the chart can be deployed according to the chart policy of the organization
+	CheckChartPolicy(ctx context.Context, orgID uint, name, version string) error
 	InstallOrUpgrade(
 		orgID uint,
 		c helm.ClusterDataProvider,
@@ -65,6 +67,13 @@ func (h *helm3Service) InstallOrUpgrade(orgID uint, c helm.ClusterDataProvider,
 	return h.releaser.InstallOrUpgrade(orgID, c, release, opts)
 }
 
+func (h *helm3Service) CheckChartPolicy(ctx context.Context, orgID uint, name, version string) error {
+	return h.facade.CheckChartPolicy(ctx, orgID, helm.Release{
+		ChartName: name,
+		Version:   version,
+	})
+}
+
 func (h *helm3Service) GetChartMeta(orgId uint, name, version string) (ChartMeta, error) {
 	repoAndChart := strings.Split(name, "/")
 	if len(repoAndChart) != 2 {
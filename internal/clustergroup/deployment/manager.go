@@ -139,6 +139,7 @@ func (m CGDeploymentManager) installDeploymentOnCluster(orgID uint, apiCluster a
 		Namespace: depInfo.Namespace,
 		DryRun:    dryRun,
 		Install:   true,
+		Verify:    depInfo.Verify,
 	})
 	if err != nil {
 		return fmt.Errorf("error deploying chart: %v", err)
@@ -171,6 +172,7 @@ func (m CGDeploymentManager) upgradeDeploymentOnCluster(orgID uint, apiCluster a
 		Namespace: depInfo.Namespace,
 		DryRun:    dryRun,
 		Install:   true,
+		Verify:    depInfo.Verify,
 	})
 	if err != nil {
 		return fmt.Errorf("error deploying chart: %v", err)
@@ -294,6 +296,13 @@ func (m CGDeploymentManager) createDeploymentModel(clusterGroup *api.ClusterGrou
 		return nil, err
 	}
 	deploymentModel.Values = values
+	if cgDeployment.Verify != nil {
+		verify, err := json.Marshal(cgDeployment.Verify)
+		if err != nil {
+			return nil, err
+		}
+		deploymentModel.Verify = verify
+	}
 	deploymentModel.TargetClusters = make([]*TargetCluster, 0)
 	for _, cluster := range clusterGroup.Clusters {
 		targetCluster := &TargetCluster{
@@ -341,6 +350,17 @@ func (m CGDeploymentManager) updateDeploymentModel(clusterGroup *api.ClusterGrou
 	}
 	deploymentModel.Values = values
 
+	// verification options are kept with ReUseValues = true unless the request sets new ones
+	if cgDeployment.Verify != nil {
+		verify, err := json.Marshal(cgDeployment.Verify)
+		if err != nil {
+			return err
+		}
+		deploymentModel.Verify = verify
+	} else if !cgDeployment.ReUseValues {
+		deploymentModel.Verify = nil
+	}
+
 	existingTargetsMap := make(map[uint]*TargetCluster, 0)
 	for _, target := range deploymentModel.TargetClusters {
 		existingTargetsMap[target.ClusterID] = target
@@ -401,6 +421,14 @@ func (m CGDeploymentManager) getDeploymentFromModel(deploymentModel *ClusterGrou
 	}
 	deployment.Values = values
 
+	if len(deploymentModel.Verify) > 0 {
+		deployment.Verify = &internalhelm.VerifyOptions{}
+		err = json.Unmarshal(deploymentModel.Verify, deployment.Verify)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	deployment.TargetClusters = make(map[uint]bool, 0)
 	deployment.ValueOverrides = make(map[string]map[string]interface{}, 0)
 	for _, targetCluster := range deploymentModel.TargetClusters {
@@ -679,7 +707,7 @@ func (m CGDeploymentManager) upgradeOrInstallDeploymentToTargetClusters(orgID ui
 	return targetClusterStatus
 }
 
-func (m CGDeploymentManager) CreateDeployment(clusterGroup *api.ClusterGroup, orgId uint, orgName string, cgDeployment *ClusterGroupDeployment) ([]TargetClusterStatus, error) {
+func (m CGDeploymentManager) CreateDeployment(ctx context.Context, clusterGroup *api.ClusterGroup, orgId uint, orgName string, cgDeployment *ClusterGroupDeployment) ([]TargetClusterStatus, error) {
 	if len(cgDeployment.ReleaseName) == 0 {
 		return nil, errors.Errorf("release name is mandatory")
 	}
@@ -703,6 +731,10 @@ func (m CGDeploymentManager) CreateDeployment(clusterGroup *api.ClusterGroup, or
 		return nil, errors.WrapIf(err, "error getting chart description")
 	}
 
+	if err := m.helmService.CheckChartPolicy(ctx, orgId, cgDeployment.Name, cgDeployment.Version); err != nil {
+		return nil, err
+	}
+
 	if cgDeployment.Namespace == "" {
 		log.Warn("Deployment namespace was not set failing back to default")
 		cgDeployment.Namespace = helm.DefaultNamespace
@@ -731,12 +763,16 @@ func (m CGDeploymentManager) CreateDeployment(clusterGroup *api.ClusterGroup, or
 
 // UpdateDeployment upgrades deployment using provided values or using already provided values if ReUseValues = true.
 // The deployment is installed on a member cluster in case it's was not installed previously.
-func (m CGDeploymentManager) UpdateDeployment(clusterGroup *api.ClusterGroup, orgId uint, cgDeployment *ClusterGroupDeployment) ([]TargetClusterStatus, error) {
+func (m CGDeploymentManager) UpdateDeployment(ctx context.Context, clusterGroup *api.ClusterGroup, orgId uint, cgDeployment *ClusterGroupDeployment) ([]TargetClusterStatus, error) {
 	requestedChart, err := m.helmService.GetChartMeta(orgId, cgDeployment.Name, cgDeployment.Version)
 	if err != nil {
 		return nil, errors.WrapIf(err, "error getting chart description")
 	}
 
+	if err := m.helmService.CheckChartPolicy(ctx, orgId, cgDeployment.Name, cgDeployment.Version); err != nil {
+		return nil, err
+	}
+
 	if cgDeployment.Namespace == "" {
 		log.Warn("Deployment namespace was not set failing back to default")
 		cgDeployment.Namespace = helm.DefaultNamespace
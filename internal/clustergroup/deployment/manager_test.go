@@ -0,0 +1,60 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/api"
+	internalhelm "github.com/banzaicloud/pipeline/internal/helm"
+)
+
+func TestCGDeploymentManager_DeploymentModelVerify(t *testing.T) {
+	m := CGDeploymentManager{}
+	clusterGroup := &api.ClusterGroup{Id: 1}
+	verify := &internalhelm.VerifyOptions{Timeout: 120, RunTests: true}
+
+	deploymentModel, err := m.createDeploymentModel(clusterGroup, "org", &ClusterGroupDeployment{
+		Name:        "stable/mysql",
+		ReleaseName: "mysql",
+		Verify:      verify,
+	}, ChartMeta{Name: "mysql"})
+	require.NoError(t, err)
+
+	depInfo, err := m.getDeploymentFromModel(deploymentModel)
+	require.NoError(t, err)
+	assert.Equal(t, verify, depInfo.Verify)
+
+	t.Run("ReUseValuesKeepsVerify", func(t *testing.T) {
+		err := m.updateDeploymentModel(clusterGroup, deploymentModel, &ClusterGroupDeployment{ReUseValues: true}, ChartMeta{Name: "mysql"})
+		require.NoError(t, err)
+
+		depInfo, err := m.getDeploymentFromModel(deploymentModel)
+		require.NoError(t, err)
+		assert.Equal(t, verify, depInfo.Verify)
+	})
+
+	t.Run("UpdateClearsVerify", func(t *testing.T) {
+		err := m.updateDeploymentModel(clusterGroup, deploymentModel, &ClusterGroupDeployment{}, ChartMeta{Name: "mysql"})
+		require.NoError(t, err)
+
+		depInfo, err := m.getDeploymentFromModel(deploymentModel)
+		require.NoError(t, err)
+		assert.Nil(t, depInfo.Verify)
+	})
+}
@@ -53,6 +53,7 @@ type ClusterGroupDeploymentModel struct {
 	Namespace             string
 	OrganizationName      string
 	Values                []byte           `sql:"type:text;"`
+	Verify                []byte           `sql:"type:text;"`
 	TargetClusters        []*TargetCluster `gorm:"foreignkey:ClusterGroupDeploymentID"`
 }
 
@@ -25,6 +25,7 @@ import (
 	"github.com/banzaicloud/pipeline/internal/cluster/clusterconfig"
 	"github.com/banzaicloud/pipeline/internal/federation"
 	"github.com/banzaicloud/pipeline/internal/helm"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/certmanager"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/dns"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/ingress"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/logging"
@@ -79,6 +80,11 @@ type Config struct {
 				DefaultNetworkProvider string
 			}
 		}
+
+		Simulated struct {
+			Enabled           bool
+			ProvisioningDelay time.Duration
+		}
 	}
 
 	Helm helm.Config
@@ -97,11 +103,7 @@ type Config struct {
 	// Log configuration
 	Log log.Config
 
-	Secret struct {
-		TLS struct {
-			DefaultValidity time.Duration
-		}
-	}
+	Secret SecretConfig
 
 	// Telemetry configuration
 	Telemetry TelemetryConfig
@@ -122,6 +124,8 @@ func (c Config) Validate() error {
 
 	err = errors.Append(err, c.Helm.Validate())
 
+	err = errors.Append(err, c.Secret.Validate())
+
 	return err
 }
 
@@ -142,6 +146,130 @@ func (c *Config) Process() error {
 	return err
 }
 
+// SecretConfig contains secret configuration.
+type SecretConfig struct {
+	// Backend storing the secrets (vault or database)
+	Backend string
+
+	Database SecretDatabaseConfig
+
+	Bindings SecretBindingsConfig
+
+	Rotation SecretRotationConfig
+
+	CertificateExpiry SecretCertificateExpiryConfig
+
+	TLS struct {
+		DefaultValidity time.Duration
+	}
+}
+
+func (c SecretConfig) Validate() error {
+	var errs error
+
+	switch c.Backend {
+	case SecretBackendVault:
+	case SecretBackendDatabase:
+		errs = errors.Append(errs, c.Database.Validate())
+	default:
+		errs = errors.Append(errs, errors.Errorf("invalid secret backend: %q", c.Backend))
+	}
+
+	return errors.Combine(errs, c.Bindings.Validate(), c.Rotation.Validate(), c.CertificateExpiry.Validate())
+}
+
+// Secret backends.
+const (
+	SecretBackendVault    = "vault"
+	SecretBackendDatabase = "database"
+)
+
+// SecretDatabaseConfig contains the configuration of the database secret backend.
+type SecretDatabaseConfig struct {
+	// File containing the base64 encoded, 32 byte key encrypting the data keys of the secrets
+	KeyFile string
+}
+
+func (c SecretDatabaseConfig) Validate() error {
+	var errs error
+
+	if c.KeyFile == "" {
+		errs = errors.Append(errs, errors.New("secret database key file is required"))
+	}
+
+	return errs
+}
+
+// SecretBindingsConfig contains secret binding configuration.
+type SecretBindingsConfig struct {
+	Reconcile SecretBindingsReconcileConfig
+}
+
+func (c SecretBindingsConfig) Validate() error {
+	return c.Reconcile.Validate()
+}
+
+// SecretBindingsReconcileConfig contains secret binding reconcile configuration.
+type SecretBindingsReconcileConfig struct {
+	Enabled bool
+
+	// Cron schedule of the reconciliation
+	Schedule string
+}
+
+func (c SecretBindingsReconcileConfig) Validate() error {
+	var errs error
+
+	if c.Enabled && c.Schedule == "" {
+		errs = errors.Append(errs, errors.New("secret binding reconcile schedule is required"))
+	}
+
+	return errs
+}
+
+// SecretRotationConfig contains secret rotation configuration.
+type SecretRotationConfig struct {
+	Enabled bool
+
+	// Cron schedule of checking the secret rotation policies
+	Schedule string
+}
+
+func (c SecretRotationConfig) Validate() error {
+	var errs error
+
+	if c.Enabled && c.Schedule == "" {
+		errs = errors.Append(errs, errors.New("secret rotation schedule is required"))
+	}
+
+	return errs
+}
+
+// SecretCertificateExpiryConfig contains secret certificate expiry tracking configuration.
+type SecretCertificateExpiryConfig struct {
+	Enabled bool
+
+	// Cron schedule of checking the certificates stored in secrets
+	Schedule string
+
+	// Certificates expiring within this period are reported as notifications
+	WarningPeriod time.Duration
+}
+
+func (c SecretCertificateExpiryConfig) Validate() error {
+	var errs error
+
+	if c.Enabled && c.Schedule == "" {
+		errs = errors.Append(errs, errors.New("secret certificate expiry schedule is required"))
+	}
+
+	if c.Enabled && c.WarningPeriod <= 0 {
+		errs = errors.Append(errs, errors.New("secret certificate expiry warning period must be positive"))
+	}
+
+	return errs
+}
+
 type CloudConfig struct {
 	Amazon AmazonCloudConfig
 
@@ -192,6 +320,8 @@ type ClusterConfig struct {
 
 	Backyards istiofeature.StaticConfig
 
+	CertManager ClusterCertManagerConfig
+
 	DisasterRecovery ClusterDisasterRecoveryConfig
 
 	DNS ClusterDNSConfig
@@ -200,8 +330,12 @@ type ClusterConfig struct {
 
 	Federation federation.StaticConfig
 
+	Imported ClusterImportedConfig
+
 	Ingress ClusterIngressConfig
 
+	IntegratedServices ClusterIntegratedServicesConfig
+
 	Labels clusterconfig.LabelConfig
 
 	// Initial manifest
@@ -226,10 +360,16 @@ type ClusterConfig struct {
 func (c ClusterConfig) Validate() error {
 	var errs error
 
+	errs = errors.Append(errs, c.CertManager.Validate())
+
 	errs = errors.Append(errs, c.DNS.Validate())
 
+	errs = errors.Append(errs, c.Imported.Validate())
+
 	errs = errors.Append(errs, c.Ingress.Validate())
 
+	errs = errors.Append(errs, c.IntegratedServices.Validate())
+
 	errs = errors.Append(errs, c.Labels.Validate())
 
 	errs = errors.Append(errs, c.Logging.Validate())
@@ -265,6 +405,10 @@ func (c *ClusterConfig) Process() error {
 		c.DisasterRecovery.Namespace = c.Namespace
 	}
 
+	if c.CertManager.Namespace == "" {
+		c.CertManager.Namespace = c.Namespace
+	}
+
 	if c.DNS.Namespace == "" {
 		c.DNS.Namespace = c.Namespace
 	}
@@ -347,6 +491,12 @@ type ClusterDisasterRecoveryConfig struct {
 					Tag        string
 					PullPolicy string
 				}
+				Plugins struct {
+					Alibaba struct {
+						Repository string
+						Tag        string
+					}
+				}
 			}
 		}
 	}
@@ -369,6 +519,23 @@ func (c ClusterDNSConfig) Validate() error {
 	return errs
 }
 
+// ClusterCertManagerConfig contains cluster cert-manager configuration.
+type ClusterCertManagerConfig struct {
+	Enabled bool
+
+	certmanager.Config `mapstructure:",squash"`
+}
+
+func (c ClusterCertManagerConfig) Validate() error {
+	var errs error
+
+	if c.Enabled {
+		errs = errors.Append(errs, c.Config.Validate())
+	}
+
+	return errs
+}
+
 type ClusterExpiryConfig struct {
 	Enabled bool
 }
@@ -389,6 +556,68 @@ func (c ClusterIngressConfig) Validate() error {
 	return errs
 }
 
+// ClusterImportedConfig contains imported cluster configuration.
+type ClusterImportedConfig struct {
+	HealthCheck ClusterImportedHealthCheckConfig
+}
+
+func (c ClusterImportedConfig) Validate() error {
+	return c.HealthCheck.Validate()
+}
+
+// ClusterImportedHealthCheckConfig contains imported cluster health check configuration.
+type ClusterImportedHealthCheckConfig struct {
+	Enabled bool
+
+	// Cron schedule of the health check
+	Schedule string
+
+	// Timeout of a single cluster probe
+	Timeout time.Duration
+}
+
+func (c ClusterImportedHealthCheckConfig) Validate() error {
+	var errs error
+
+	if c.Enabled && c.Schedule == "" {
+		errs = errors.Append(errs, errors.New("imported cluster health check schedule is required"))
+	}
+
+	if c.Enabled && c.Timeout <= 0 {
+		errs = errors.Append(errs, errors.New("imported cluster health check timeout must be positive"))
+	}
+
+	return errs
+}
+
+// ClusterIntegratedServicesConfig contains integrated service configuration.
+type ClusterIntegratedServicesConfig struct {
+	DriftDetection ClusterIntegratedServicesDriftDetectionConfig
+}
+
+func (c ClusterIntegratedServicesConfig) Validate() error {
+	return c.DriftDetection.Validate()
+}
+
+// ClusterIntegratedServicesDriftDetectionConfig contains integrated service drift detection configuration.
+type ClusterIntegratedServicesDriftDetectionConfig struct {
+	Enabled bool
+
+	// Cron schedule of the drift detection
+	Schedule string
+
+	// Reapply the specification of drifted integrated services
+	AutoReapply bool
+}
+
+func (c ClusterIntegratedServicesDriftDetectionConfig) Validate() error {
+	if c.Enabled && c.Schedule == "" {
+		return errors.New("integrated service drift detection schedule is required")
+	}
+
+	return nil
+}
+
 // ClusterLoggingConfig contains cluster logging configuration.
 type ClusterLoggingConfig struct {
 	Enabled bool
@@ -632,6 +861,13 @@ func Configure(v *viper.Viper, p *pflag.FlagSet) {
 		},
 	})
 
+	v.SetDefault("cluster::certManager::enabled", false)
+	v.SetDefault("cluster::certManager::namespace", "")
+	v.SetDefault("cluster::certManager::releaseName", "cert-manager")
+	v.SetDefault("cluster::certManager::charts::certManager::chart", "jetstack/cert-manager")
+	v.SetDefault("cluster::certManager::charts::certManager::version", "v0.16.1")
+	v.SetDefault("cluster::certManager::charts::certManager::values", map[string]interface{}{})
+
 	v.SetDefault("cluster::ingress::enabled", false)
 	v.SetDefault("cluster::ingress::controllers", []string{"traefik"})
 	v.SetDefault("cluster::ingress::namespace", "")
@@ -711,6 +947,14 @@ rbac:
 
 	v.SetDefault("cluster::expiry::enabled", true)
 
+	v.SetDefault("cluster::imported::healthCheck::enabled", true)
+	v.SetDefault("cluster::imported::healthCheck::schedule", "*/5 * * * *")
+	v.SetDefault("cluster::imported::healthCheck::timeout", 30*time.Second)
+
+	v.SetDefault("cluster::integratedServices::driftDetection::enabled", false)
+	v.SetDefault("cluster::integratedServices::driftDetection::schedule", "*/30 * * * *")
+	v.SetDefault("cluster::integratedServices::driftDetection::autoReapply", false)
+
 	// ingress controller config
 	v.SetDefault("cluster::posthook::ingress::enabled", true)
 	v.SetDefault("cluster::posthook::ingress::chart", "banzaicloud-stable/pipeline-cluster-ingress")
@@ -760,6 +1004,12 @@ traefik:
 			"tag":        "v0.9.11",
 			"pullPolicy": "IfNotPresent",
 		},
+		"plugins": map[string]interface{}{
+			"alibaba": map[string]interface{}{
+				"repository": "registry.cn-hangzhou.aliyuncs.com/acs/velero-plugin-alibabacloud",
+				"tag":        "v1.0.0",
+			},
+		},
 	})
 
 	// v.SetDefault("cluster::backyards::enabled", true)
@@ -825,6 +1075,7 @@ traefik:
 	v.SetDefault("helm::repositories::stable", "https://kubernetes-charts.storage.googleapis.com")
 	v.SetDefault("helm::repositories::banzaicloud-stable", "https://kubernetes-charts.banzaicloud.com")
 	v.SetDefault("helm::repositories::loki", "https://grafana.github.io/loki/charts")
+	v.SetDefault("helm::repositories::jetstack", "https://charts.jetstack.io")
 
 	// Cloud configuration
 	v.SetDefault("cloud::amazon::defaultRegion", "us-west-1")
@@ -838,10 +1089,22 @@ traefik:
 	v.SetDefault("distribution::pke::amazon::defaultImages", map[string]string{})
 	v.SetDefault("distribution::pke::amazon::defaultNetworkProvider", "cilium")
 
+	v.SetDefault("distribution::simulated::enabled", false)
+	v.SetDefault("distribution::simulated::provisioningDelay", 10*time.Second)
+
 	v.SetDefault("cloudinfo::endpoint", "")
 	v.SetDefault("hollowtrees::endpoint", "")
 	v.SetDefault("hollowtrees::tokenSigningKey", "")
 
+	v.SetDefault("secret::backend", SecretBackendVault)
+	v.SetDefault("secret::database::keyFile", "")
+	v.SetDefault("secret::bindings::reconcile::enabled", true)
+	v.SetDefault("secret::bindings::reconcile::schedule", "*/10 * * * *")
+	v.SetDefault("secret::rotation::enabled", true)
+	v.SetDefault("secret::rotation::schedule", "*/15 * * * *")
+	v.SetDefault("secret::certificateExpiry::enabled", true)
+	v.SetDefault("secret::certificateExpiry::schedule", "0 * * * *")
+	v.SetDefault("secret::certificateExpiry::warningPeriod", "720h") // 30 days
 	v.SetDefault("secret::tls::defaultValidity", "8760h") // 1 year
 
 	// Telemetry configuration
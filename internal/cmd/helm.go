@@ -60,6 +60,7 @@ func CreateUnifiedHelmReleaser(
 	service := helm.NewService(
 		helmConfig,
 		repoStore,
+		helmadapter.NewChartPolicyStore(db, logger),
 		secretStore,
 		validator,
 		ensuringEnvResolver,
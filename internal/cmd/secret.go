@@ -0,0 +1,43 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"emperror.dev/errors"
+	"github.com/banzaicloud/bank-vaults/pkg/sdk/vault"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
+)
+
+// CreateSecretStore creates a secret store for the specified backend.
+func CreateSecretStore(backend string, config SecretDatabaseConfig, db *gorm.DB, vaultClient *vault.Client) (secret.Store, error) {
+	switch backend {
+	case SecretBackendVault:
+		return secretadapter.NewVaultStore(vaultClient, "secret"), nil
+
+	case SecretBackendDatabase:
+		keys, err := secretadapter.NewLocalKeyProviderFromFile(config.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return secretadapter.NewDatabaseStore(db, keys), nil
+
+	default:
+		return nil, errors.Errorf("invalid secret backend: %q", backend)
+	}
+}
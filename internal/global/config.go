@@ -86,6 +86,12 @@ var Config struct {
 							Tag        string
 							PullPolicy string
 						}
+						Plugins struct {
+							Alibaba struct {
+								Repository string
+								Tag        string
+							}
+						}
 					}
 				}
 			}
@@ -0,0 +1,360 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/Masterminds/semver/v3"
+)
+
+// ChartPolicy restricts the charts the members of an organization can deploy.
+// An empty policy allows every chart.
+type ChartPolicy struct {
+	// AllowedRepositories lists the repositories charts can be deployed from: repository names
+	// or OCI registry locations (eg. oci://registry.example.com/charts); all repositories are allowed when empty
+	AllowedRepositories []string `json:"allowedRepositories,omitempty"`
+
+	// AllowedCharts lists the patterns (eg. stable/* or oci://registry.example.com/charts/*)
+	// charts must match to be deployed; all charts are allowed when empty
+	AllowedCharts []string `json:"allowedCharts,omitempty"`
+
+	// DeniedCharts lists the patterns of charts that must not be deployed; it takes precedence over AllowedCharts
+	DeniedCharts []string `json:"deniedCharts,omitempty"`
+
+	// VersionConstraints restricts the chart versions that can be deployed
+	VersionConstraints []ChartVersionConstraint `json:"versionConstraints,omitempty"`
+
+	// RequireProvenance requires charts to be signed and verified against the configured keyring
+	RequireProvenance bool `json:"requireProvenance,omitempty"`
+}
+
+// ChartVersionConstraint restricts the versions of the charts matching a pattern.
+type ChartVersionConstraint struct {
+	// Chart is the pattern of the charts the constraint applies to
+	Chart string `json:"chart"`
+
+	// Constraint is a semantic version constraint (eg. ">= 1.2.0, < 2.0.0")
+	Constraint string `json:"constraint"`
+}
+
+// Validate checks the patterns and version constraints of the policy.
+func (p ChartPolicy) Validate() error {
+	var violations []string
+
+	for _, repository := range p.AllowedRepositories {
+		if repository == "" {
+			violations = append(violations, "allowed repositories must not be empty")
+		}
+	}
+
+	for _, pattern := range append(append([]string{}, p.AllowedCharts...), p.DeniedCharts...) {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			violations = append(violations, fmt.Sprintf("invalid chart pattern %q", pattern))
+		}
+	}
+
+	for _, constraint := range p.VersionConstraints {
+		if _, err := path.Match(constraint.Chart, ""); err != nil || constraint.Chart == "" {
+			violations = append(violations, fmt.Sprintf("invalid chart pattern %q", constraint.Chart))
+		}
+
+		if _, err := semver.NewConstraint(constraint.Constraint); err != nil {
+			violations = append(violations, fmt.Sprintf("invalid version constraint %q for %s", constraint.Constraint, constraint.Chart))
+		}
+	}
+
+	if len(violations) > 0 {
+		return errors.WithStack(NewValidationError("invalid chart policy", violations))
+	}
+
+	return nil
+}
+
+// Violations returns the rules of the policy the given chart (repo/chart or an OCI reference) and version break.
+func (p ChartPolicy) Violations(chartName string, version string) []string {
+	var violations []string
+
+	chart, repository := chartName, ""
+	if IsOCIReference(chartName) {
+		// match OCI charts without their tags
+		if ref, err := ParseOCIReference(chartName); err == nil {
+			chart = ref.WithTag("").String()
+			if version == "" {
+				version = ref.Tag
+			}
+		}
+	} else if i := strings.Index(chartName, "/"); i > 0 {
+		repository = chartName[:i]
+	}
+
+	if len(p.AllowedRepositories) > 0 && !p.repositoryAllowed(chart, repository) {
+		violations = append(violations, fmt.Sprintf("chart %s is not from an allowed repository", chartName))
+	}
+
+	if len(p.AllowedCharts) > 0 && !matchAny(p.AllowedCharts, chart) {
+		violations = append(violations, fmt.Sprintf("chart %s is not allowed", chartName))
+	}
+
+	if matchAny(p.DeniedCharts, chart) {
+		violations = append(violations, fmt.Sprintf("chart %s is denied", chartName))
+	}
+
+	for _, constraint := range p.VersionConstraints {
+		if !matchAny([]string{constraint.Chart}, chart) {
+			continue
+		}
+
+		if version == "" {
+			violations = append(violations, fmt.Sprintf("chart %s must be deployed with an explicit version (%s)", chartName, constraint.Constraint))
+
+			continue
+		}
+
+		c, err := semver.NewConstraint(constraint.Constraint)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid version constraint %q for %s", constraint.Constraint, constraint.Chart))
+
+			continue
+		}
+
+		v, err := semver.NewVersion(version)
+		if err != nil || !c.Check(v) {
+			violations = append(violations, fmt.Sprintf("version %s of chart %s does not satisfy %s", version, chartName, constraint.Constraint))
+		}
+	}
+
+	if p.RequireProvenance && IsOCIReference(chartName) {
+		violations = append(violations, fmt.Sprintf("provenance of chart %s cannot be verified: OCI charts are not supported", chartName))
+	}
+
+	return violations
+}
+
+// RevisionViolations returns the rules of the policy a deployed chart revision breaks.
+// Deployed revisions only record the name of the chart, not the repository it was deployed from,
+// so chart patterns are matched against the chart name in any repository and repository rules are not checked.
+func (p ChartPolicy) RevisionViolations(chartName string, version string) []string {
+	var violations []string
+
+	matchName := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(path.Base(pattern), chartName); matched {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(p.AllowedCharts) > 0 && !matchName(p.AllowedCharts) {
+		violations = append(violations, fmt.Sprintf("chart %s is not allowed", chartName))
+	}
+
+	if matchName(p.DeniedCharts) {
+		violations = append(violations, fmt.Sprintf("chart %s is denied", chartName))
+	}
+
+	for _, constraint := range p.VersionConstraints {
+		if !matchName([]string{constraint.Chart}) {
+			continue
+		}
+
+		c, err := semver.NewConstraint(constraint.Constraint)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid version constraint %q for %s", constraint.Constraint, constraint.Chart))
+
+			continue
+		}
+
+		v, err := semver.NewVersion(version)
+		if err != nil || !c.Check(v) {
+			violations = append(violations, fmt.Sprintf("version %s of chart %s does not satisfy %s", version, chartName, constraint.Constraint))
+		}
+	}
+
+	return violations
+}
+
+func (p ChartPolicy) repositoryAllowed(chart string, repository string) bool {
+	for _, allowed := range p.AllowedRepositories {
+		if IsOCIReference(allowed) {
+			if strings.HasPrefix(chart, strings.TrimSuffix(allowed, "/")+"/") {
+				return true
+			}
+
+			continue
+		}
+
+		if allowed == repository {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAny(patterns []string, chart string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, chart); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// chartPolicy collects chart policy related operations
+// it's intended to be embedded in the "Helm Facade"
+type chartPolicy interface {
+	// GetChartPolicy retrieves the chart policy of the organization
+	GetChartPolicy(ctx context.Context, organizationID uint) (ChartPolicy, error)
+	// UpdateChartPolicy replaces the chart policy of the organization
+	UpdateChartPolicy(ctx context.Context, organizationID uint, policy ChartPolicy) error
+	// DeleteChartPolicy removes the chart policy of the organization (allowing every chart)
+	DeleteChartPolicy(ctx context.Context, organizationID uint) error
+	// CheckChartPolicy checks whether the chart of the release can be deployed in the organization
+	CheckChartPolicy(ctx context.Context, organizationID uint, releaseInput Release) error
+}
+
+// +testify:mock:testOnly=true
+
+// ChartPolicyStore persists organization chart policies
+type ChartPolicyStore interface {
+	// Get retrieves the chart policy of the organization; an empty policy is returned if there is none
+	Get(ctx context.Context, organizationID uint) (ChartPolicy, error)
+	// Save creates or replaces the chart policy of the organization
+	Save(ctx context.Context, organizationID uint, policy ChartPolicy) error
+	// Delete removes the chart policy of the organization
+	Delete(ctx context.Context, organizationID uint) error
+}
+
+func (s service) GetChartPolicy(ctx context.Context, organizationID uint) (ChartPolicy, error) {
+	policy, err := s.chartPolicyStore.Get(ctx, organizationID)
+	if err != nil {
+		return ChartPolicy{}, errors.WrapIf(err, "failed to retrieve chart policy")
+	}
+
+	return policy, nil
+}
+
+func (s service) UpdateChartPolicy(ctx context.Context, organizationID uint, policy ChartPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	if policy.RequireProvenance && s.config.Keyring == "" {
+		return errors.WithStack(NewValidationError("invalid chart policy", []string{"provenance verification requires a keyring to be configured"}))
+	}
+
+	if err := s.chartPolicyStore.Save(ctx, organizationID, policy); err != nil {
+		return errors.WrapIf(err, "failed to save chart policy")
+	}
+
+	s.logger.Info("chart policy updated", map[string]interface{}{"orgID": organizationID})
+
+	return nil
+}
+
+func (s service) DeleteChartPolicy(ctx context.Context, organizationID uint) error {
+	if err := s.chartPolicyStore.Delete(ctx, organizationID); err != nil {
+		return errors.WrapIf(err, "failed to delete chart policy")
+	}
+
+	s.logger.Info("chart policy deleted", map[string]interface{}{"orgID": organizationID})
+
+	return nil
+}
+
+func (s service) CheckChartPolicy(ctx context.Context, organizationID uint, releaseInput Release) error {
+	_, err := s.applyChartPolicy(ctx, organizationID, releaseInput, Options{})
+
+	return err
+}
+
+// applyChartPolicy checks the chart of the release against the policy of the organization
+// and returns the options extended with the provenance verification settings required by the policy
+func (s service) applyChartPolicy(ctx context.Context, organizationID uint, releaseInput Release, options Options) (Options, error) {
+	// platform deployments (organization 0) are not subject to organization policies
+	if organizationID == 0 {
+		return options, nil
+	}
+
+	policy, err := s.chartPolicyStore.Get(ctx, organizationID)
+	if err != nil {
+		return options, errors.WrapIf(err, "failed to retrieve chart policy")
+	}
+
+	if violations := policy.Violations(releaseInput.ChartName, releaseInput.Version); len(violations) > 0 {
+		return options, errors.WithStack(NewValidationError("chart policy violation", violations))
+	}
+
+	if policy.RequireProvenance {
+		if s.config.Keyring == "" {
+			return options, errors.WithStack(NewValidationError("chart policy violation", []string{"provenance verification requires a keyring to be configured"}))
+		}
+
+		options.ProvenanceKeyring = s.config.Keyring
+	}
+
+	return options, nil
+}
+
+// checkRollbackChartPolicy makes sure that the revision a release is rolled back to does not break the chart policy
+func (s service) checkRollbackChartPolicy(ctx context.Context, organizationID uint, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseName string, revision int32, options Options) error {
+	// platform deployments (organization 0) are not subject to organization policies
+	if organizationID == 0 {
+		return nil
+	}
+
+	policy, err := s.chartPolicyStore.Get(ctx, organizationID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to retrieve chart policy")
+	}
+
+	if len(policy.AllowedCharts) == 0 && len(policy.DeniedCharts) == 0 && len(policy.VersionConstraints) == 0 {
+		return nil
+	}
+
+	revisions, err := s.releaser.History(ctx, helmEnv, kubeConfig, releaseName, options)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to retrieve release history", "releaseName", releaseName)
+	}
+
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	// the previous revision is used when revision is 0
+	if revision == 0 {
+		revision = revisions[len(revisions)-1].Revision - 1
+	}
+
+	for _, r := range revisions {
+		if r.Revision != revision {
+			continue
+		}
+
+		if violations := policy.RevisionViolations(r.ChartName, r.ChartVersion); len(violations) > 0 {
+			return errors.WithStack(NewValidationError("chart policy violation", violations))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func TestChartPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy ChartPolicy
+		valid  bool
+	}{
+		{
+			name:   "empty policy",
+			policy: ChartPolicy{},
+			valid:  true,
+		},
+		{
+			name: "valid policy",
+			policy: ChartPolicy{
+				AllowedRepositories: []string{"stable", "oci://registry.example.com/charts"},
+				AllowedCharts:       []string{"stable/*"},
+				DeniedCharts:        []string{"stable/mysql"},
+				VersionConstraints:  []ChartVersionConstraint{{Chart: "stable/*", Constraint: ">= 1.0.0, < 2.0.0"}},
+			},
+			valid: true,
+		},
+		{
+			name:   "invalid chart pattern",
+			policy: ChartPolicy{AllowedCharts: []string{"stable/[*"}},
+		},
+		{
+			name:   "empty chart pattern",
+			policy: ChartPolicy{DeniedCharts: []string{""}},
+		},
+		{
+			name:   "invalid version constraint",
+			policy: ChartPolicy{VersionConstraints: []ChartVersionConstraint{{Chart: "stable/*", Constraint: "latest"}}},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			err := test.policy.Validate()
+			if test.valid {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var validationErr ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.NotEmpty(t, validationErr.Violations())
+		})
+	}
+}
+
+func TestChartPolicy_Violations(t *testing.T) {
+	policy := ChartPolicy{
+		AllowedRepositories: []string{"stable", "oci://registry.example.com/charts"},
+		DeniedCharts:        []string{"stable/mysql"},
+		VersionConstraints: []ChartVersionConstraint{
+			{Chart: "stable/nginx*", Constraint: "^1.2.0"},
+			{Chart: "oci://registry.example.com/charts/*", Constraint: ">= 2.0.0"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		chartName  string
+		version    string
+		violations int
+	}{
+		{name: "allowed chart", chartName: "stable/redis"},
+		{name: "allowed version", chartName: "stable/nginx-ingress", version: "1.4.0"},
+		{name: "repository not allowed", chartName: "incubator/redis", violations: 1},
+		{name: "denied chart", chartName: "stable/mysql", violations: 1},
+		{name: "version not satisfying the constraint", chartName: "stable/nginx-ingress", version: "2.0.0", violations: 1},
+		{name: "missing version", chartName: "stable/nginx-ingress", violations: 1},
+		{name: "allowed OCI chart", chartName: "oci://registry.example.com/charts/app:2.1.0"},
+		{name: "OCI chart version", chartName: "oci://registry.example.com/charts/app", version: "1.0.0", violations: 1},
+		{name: "OCI registry not allowed", chartName: "oci://registry.example.com/other/app:2.1.0", violations: 1},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			violations := policy.Violations(test.chartName, test.version)
+			assert.Len(t, violations, test.violations, violations)
+		})
+	}
+
+	t.Run("allowed charts", func(t *testing.T) {
+		policy := ChartPolicy{AllowedCharts: []string{"stable/nginx-*"}}
+
+		assert.Empty(t, policy.Violations("stable/nginx-ingress", ""))
+		assert.Len(t, policy.Violations("stable/redis", ""), 1)
+	})
+
+	t.Run("provenance of OCI charts", func(t *testing.T) {
+		policy := ChartPolicy{RequireProvenance: true}
+
+		assert.Empty(t, policy.Violations("stable/redis", ""))
+		assert.Len(t, policy.Violations("oci://registry.example.com/charts/app:2.1.0", ""), 1)
+	})
+}
+
+func TestService_InstallRelease_ChartPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	envResolver := &MockEnvResolver{}
+	envResolver.On("ResolveHelmEnv", mock.Anything, uint(1)).Return(HelmEnv{}, nil)
+
+	kubeConfig := ClusterKubeConfigFunc(func(ctx context.Context, clusterID uint) ([]byte, error) {
+		return []byte("kubeconfig"), nil
+	})
+
+	chartPolicyStore := &MockChartPolicyStore{}
+	chartPolicyStore.On("Get", ctx, uint(1)).Return(ChartPolicy{DeniedCharts: []string{"stable/mysql"}, RequireProvenance: true}, nil)
+
+	t.Run("denied chart", func(t *testing.T) {
+		releaser := &MockReleaser{}
+		service := NewService(Config{Keyring: "/keyring.gpg"}, nil, chartPolicyStore, nil, nil, envResolver, nil, releaser, kubeConfig, common.NoopLogger{})
+
+		_, err := service.InstallRelease(ctx, 1, 2, Release{ReleaseName: "db", ChartName: "stable/mysql"}, Options{})
+
+		var validationErr ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Len(t, validationErr.Violations(), 1)
+
+		releaser.AssertExpectations(t)
+	})
+
+	t.Run("provenance verification", func(t *testing.T) {
+		releaseInput := Release{ReleaseName: "cache", ChartName: "stable/redis"}
+
+		releaser := &MockReleaser{}
+		releaser.On("Install", ctx, HelmEnv{}, []byte("kubeconfig"), releaseInput, Options{ProvenanceKeyring: "/keyring.gpg"}).Return(Release{ReleaseName: "cache"}, nil)
+
+		service := NewService(Config{Keyring: "/keyring.gpg"}, nil, chartPolicyStore, nil, nil, envResolver, nil, releaser, kubeConfig, common.NoopLogger{})
+
+		_, err := service.InstallRelease(ctx, 1, 2, releaseInput, Options{})
+		require.NoError(t, err)
+
+		releaser.AssertExpectations(t)
+	})
+
+	t.Run("missing keyring", func(t *testing.T) {
+		releaser := &MockReleaser{}
+		service := NewService(Config{}, nil, chartPolicyStore, nil, nil, envResolver, nil, releaser, kubeConfig, common.NoopLogger{})
+
+		_, err := service.InstallRelease(ctx, 1, 2, Release{ReleaseName: "cache", ChartName: "stable/redis"}, Options{})
+
+		var validationErr ValidationError
+		require.True(t, errors.As(err, &validationErr))
+
+		releaser.AssertExpectations(t)
+	})
+
+	t.Run("platform deployments", func(t *testing.T) {
+		releaseInput := Release{ReleaseName: "db", ChartName: "stable/mysql"}
+
+		envResolver := &MockEnvResolver{}
+		envResolver.On("ResolveHelmEnv", mock.Anything, uint(0)).Return(HelmEnv{}, nil)
+
+		releaser := &MockReleaser{}
+		releaser.On("Install", ctx, HelmEnv{}, []byte("kubeconfig"), releaseInput, Options{}).Return(Release{ReleaseName: "db"}, nil)
+
+		service := NewService(Config{}, nil, &MockChartPolicyStore{}, nil, nil, envResolver, nil, releaser, kubeConfig, common.NoopLogger{})
+
+		_, err := service.InstallRelease(ctx, 0, 2, releaseInput, Options{})
+		require.NoError(t, err)
+
+		releaser.AssertExpectations(t)
+	})
+}
+
+func TestChartPolicy_RevisionViolations(t *testing.T) {
+	policy := ChartPolicy{
+		AllowedCharts: []string{"stable/*"},
+		DeniedCharts:  []string{"stable/mysql"},
+		VersionConstraints: []ChartVersionConstraint{
+			{Chart: "stable/nginx-ingress", Constraint: ">= 1.0.0"},
+		},
+	}
+
+	assert.Empty(t, policy.RevisionViolations("redis", "10.0.0"))
+	assert.Len(t, policy.RevisionViolations("mysql", "1.0.0"), 1)
+	assert.Len(t, policy.RevisionViolations("nginx-ingress", "0.9.0"), 1)
+	assert.Empty(t, policy.RevisionViolations("nginx-ingress", "1.2.0"))
+}
+
+func TestService_RollbackRelease_ChartPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	envResolver := &MockEnvResolver{}
+	envResolver.On("ResolveHelmEnv", mock.Anything, uint(1)).Return(HelmEnv{}, nil)
+
+	kubeConfig := ClusterKubeConfigFunc(func(ctx context.Context, clusterID uint) ([]byte, error) {
+		return []byte("kubeconfig"), nil
+	})
+
+	chartPolicyStore := &MockChartPolicyStore{}
+	chartPolicyStore.On("Get", ctx, uint(1)).Return(ChartPolicy{VersionConstraints: []ChartVersionConstraint{{Chart: "stable/mysql", Constraint: ">= 2.0.0"}}}, nil)
+
+	revisions := []ReleaseRevision{
+		{Revision: 1, ChartName: "mysql", ChartVersion: "1.0.0"},
+		{Revision: 2, ChartName: "mysql", ChartVersion: "2.0.0"},
+		{Revision: 3, ChartName: "mysql", ChartVersion: "2.1.0"},
+	}
+
+	t.Run("revision breaking the policy", func(t *testing.T) {
+		releaser := &MockReleaser{}
+		releaser.On("History", ctx, HelmEnv{}, []byte("kubeconfig"), "db", Options{}).Return(revisions, nil)
+
+		service := NewService(Config{}, nil, chartPolicyStore, nil, nil, envResolver, nil, releaser, kubeConfig, common.NoopLogger{})
+
+		_, err := service.RollbackRelease(ctx, 1, 2, "db", 1, Options{})
+
+		var validationErr ValidationError
+		require.True(t, errors.As(err, &validationErr))
+
+		releaser.AssertExpectations(t)
+	})
+
+	t.Run("previous revision", func(t *testing.T) {
+		releaser := &MockReleaser{}
+		releaser.On("History", ctx, HelmEnv{}, []byte("kubeconfig"), "db", Options{}).Return(revisions, nil)
+		releaser.On("Rollback", ctx, HelmEnv{}, []byte("kubeconfig"), "db", int32(0), Options{}).Return(Release{ReleaseName: "db", ReleaseVersion: 4}, nil)
+
+		service := NewService(Config{}, nil, chartPolicyStore, nil, nil, envResolver, nil, releaser, kubeConfig, common.NoopLogger{})
+
+		_, err := service.RollbackRelease(ctx, 1, 2, "db", 0, Options{})
+		require.NoError(t, err)
+
+		releaser.AssertExpectations(t)
+	})
+}
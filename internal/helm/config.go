@@ -20,6 +20,9 @@ type Config struct {
 	Home string
 
 	Repositories map[string]string
+
+	// Keyring is the path of the keyring chart provenance is verified against
+	Keyring string
 }
 
 // Validate validates the configuration.
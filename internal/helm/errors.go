@@ -106,3 +106,30 @@ func (NotFoundError) ServiceError() bool {
 func (NotFoundError) NotFound() bool {
 	return true
 }
+
+// ReleaseNotFoundError is returned when a release cannot be found in the cluster.
+type ReleaseNotFoundError struct {
+	ReleaseName string
+}
+
+// Error implements the error interface.
+func (e ReleaseNotFoundError) Error() string {
+	return "release not found"
+}
+
+// Details returns error details.
+func (e ReleaseNotFoundError) Details() []interface{} {
+	return []interface{}{"releaseName", e.ReleaseName}
+}
+
+// ServiceError tells the consumer that this is a business error and it should be returned to the client.
+// Non-service errors are usually translated into "internal" errors.
+func (ReleaseNotFoundError) ServiceError() bool {
+	return true
+}
+
+// NotFound tells the consumer that this error is related to a resource being not found.
+// Can be used to translate the error to the consumer's response format (eg. status codes).
+func (ReleaseNotFoundError) NotFound() bool {
+	return true
+}
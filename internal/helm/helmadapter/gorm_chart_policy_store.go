@@ -0,0 +1,107 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmadapter
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+// chartPolicyModel describes the helm chart policy model.
+type chartPolicyModel struct {
+	ID             uint `gorm:"primary_key"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	OrganizationID uint   `gorm:"unique_index:idx_helm_chart_policies_org"`
+	Policy         string `sql:"type:text;"`
+}
+
+// TableName changes the default table name.
+func (chartPolicyModel) TableName() string {
+	return "helm_chart_policies"
+}
+
+type chartPolicyStore struct {
+	db     *gorm.DB
+	logger Logger
+}
+
+// NewChartPolicyStore returns a new gorm based chart policy store.
+func NewChartPolicyStore(db *gorm.DB, logger Logger) helm.ChartPolicyStore {
+	return chartPolicyStore{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (c chartPolicyStore) Get(_ context.Context, organizationID uint) (helm.ChartPolicy, error) {
+	var model chartPolicyModel
+	if err := c.db.Where(&chartPolicyModel{OrganizationID: organizationID}).First(&model).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return helm.ChartPolicy{}, nil
+		}
+
+		return helm.ChartPolicy{}, errors.WrapIfWithDetails(err, "failed to get chart policy", "orgID", organizationID)
+	}
+
+	var policy helm.ChartPolicy
+	if err := json.Unmarshal([]byte(model.Policy), &policy); err != nil {
+		return helm.ChartPolicy{}, errors.WrapIfWithDetails(err, "failed to decode chart policy", "orgID", organizationID)
+	}
+
+	return policy, nil
+}
+
+func (c chartPolicyStore) Save(_ context.Context, organizationID uint, policy helm.ChartPolicy) error {
+	rawPolicy, err := json.Marshal(policy)
+	if err != nil {
+		return errors.WrapIf(err, "failed to encode chart policy")
+	}
+
+	var model chartPolicyModel
+	if err := c.db.Where(&chartPolicyModel{OrganizationID: organizationID}).First(&model).Error; err != nil {
+		if !gorm.IsRecordNotFoundError(err) {
+			return errors.WrapIfWithDetails(err, "failed to get chart policy", "orgID", organizationID)
+		}
+
+		model.OrganizationID = organizationID
+	}
+
+	model.Policy = string(rawPolicy)
+
+	if err := c.db.Save(&model).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to save chart policy", "orgID", organizationID)
+	}
+
+	c.logger.Debug("saved chart policy record", map[string]interface{}{"organizationID": organizationID})
+
+	return nil
+}
+
+func (c chartPolicyStore) Delete(_ context.Context, organizationID uint) error {
+	if err := c.db.Where(&chartPolicyModel{OrganizationID: organizationID}).Delete(&chartPolicyModel{}).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to delete chart policy", "orgID", organizationID)
+	}
+
+	c.logger.Debug("deleted chart policy record", map[string]interface{}{"organizationID": organizationID})
+
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmadapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+func Test_chartPolicyStore(t *testing.T) {
+	ctx := context.Background()
+	db := setUpDatabase(t)
+	store := NewChartPolicyStore(db, common.NoopLogger{})
+
+	policy, err := store.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, helm.ChartPolicy{}, policy, "no policy means an empty policy")
+
+	newPolicy := helm.ChartPolicy{
+		AllowedRepositories: []string{"stable"},
+		DeniedCharts:        []string{"stable/mysql"},
+		VersionConstraints:  []helm.ChartVersionConstraint{{Chart: "stable/*", Constraint: ">= 1.0.0"}},
+	}
+	require.NoError(t, store.Save(ctx, 1, newPolicy))
+
+	policy, err = store.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, newPolicy, policy)
+
+	// saving again replaces the policy
+	updatedPolicy := helm.ChartPolicy{RequireProvenance: true}
+	require.NoError(t, store.Save(ctx, 1, updatedPolicy))
+
+	policy, err = store.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, updatedPolicy, policy)
+
+	policy, err = store.Get(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, helm.ChartPolicy{}, policy, "policies are scoped to organizations")
+
+	require.NoError(t, store.Delete(ctx, 1))
+
+	policy, err = store.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, helm.ChartPolicy{}, policy)
+}
@@ -108,6 +108,20 @@ func (h helm3EnvService) AddRepository(ctx context.Context, helmEnv helm.HelmEnv
 		c.Password = passwordSecret.Password
 	}
 
+	if helm.IsOCIReference(repository.URL) {
+		if err := h.addOCIRegistry(ctx, helmEnv, repository, &c); err != nil {
+			return err
+		}
+
+		f.Update(&c)
+
+		if err := f.WriteFile(repoFile, 0644); err != nil {
+			return err
+		}
+		h.logger.Info("registry has been added", map[string]interface{}{"repository": repository.Name})
+		return nil
+	}
+
 	envSettings := h.processEnvSettings(helmEnv)
 	r, err := repo.NewChartRepository(&c, getter.All(envSettings))
 	if err != nil {
@@ -265,6 +279,111 @@ func (h helm3EnvService) GetChart(ctx context.Context, helmEnv helm.HelmEnv, fil
 	return h.adaptChartDetailsResponse(detailedCharts, filter.RepoFilter(), chartsInSlice[0])
 }
 
+// addOCIRegistry sets up the TLS configuration of the registry entry and checks that the registry can be logged in to
+func (h helm3EnvService) addOCIRegistry(ctx context.Context, helmEnv helm.HelmEnv, repository helm.Repository, entry *repo.Entry) error {
+	registry := strings.SplitN(strings.TrimPrefix(repository.URL, helm.OCIScheme+"://"), "/", 2)[0]
+	if registry == "" {
+		return errors.WithStack(helm.NewValidationError("invalid registry URL", []string{"registry URL must contain a host"}))
+	}
+
+	if repository.TlsSecretID != "" {
+		tlsSecret, err := h.secretStore.ResolveTlsSecrets(ctx, repository.TlsSecretID)
+		if err != nil {
+			return errors.WrapIf(err, "failed to resolve registry TLS secret")
+		}
+
+		tlsDir := filepath.Join(helmEnv.GetRepoCache(), tlsDirName(repository.Name))
+		if err := os.MkdirAll(tlsDir, 0700); err != nil {
+			return errors.WrapIf(err, "failed to create registry TLS directory")
+		}
+
+		writeTLSFile := func(name string, content string) (string, error) {
+			if content == "" {
+				return "", nil
+			}
+
+			path := filepath.Join(tlsDir, name)
+
+			return path, errors.WrapIf(ioutil.WriteFile(path, []byte(content), 0600), "failed to write registry TLS file")
+		}
+
+		var err2 error
+		if entry.CAFile, err2 = writeTLSFile("ca.crt", tlsSecret.CACert); err2 != nil {
+			return err2
+		}
+		if entry.CertFile, err2 = writeTLSFile("client.crt", tlsSecret.ClientCert); err2 != nil {
+			return err2
+		}
+		if entry.KeyFile, err2 = writeTLSFile("client.key", tlsSecret.ClientKey); err2 != nil {
+			return err2
+		}
+	}
+
+	client, err := newOCIRegistryClient(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Login(ctx, registry); err != nil {
+		return errors.Wrapf(err, "looks like %q is not a valid chart registry or cannot be reached", repository.URL)
+	}
+
+	return nil
+}
+
+// listOCICharts lists the versions of the chart matching the filter in the registry
+// registries cannot be searched, so charts are only listed when an exact chart name is provided
+func (h helm3EnvService) listOCICharts(ctx context.Context, repoEntry *repo.Entry, filter helm.ChartFilter) (repo.ChartVersions, error) {
+	chartName := strings.TrimSuffix(strings.TrimPrefix(filter.NameFilter(), "^"), "$")
+	if chartName == "" || filter.KeywordFilter() != "" {
+		h.logger.Debug("registry charts can only be listed by name, skipping the registry",
+			map[string]interface{}{"repoEntry": repoEntry.Name})
+		return nil, nil
+	}
+
+	ref, err := helm.ParseOCIReference(strings.TrimSuffix(repoEntry.URL, "/") + "/" + chartName)
+	if err != nil {
+		// not a valid chart name in the registry
+		return nil, nil
+	}
+
+	client, err := newOCIRegistryClient(repoEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := client.ListVersions(ctx, ref)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list chart versions in registry", "repository", repoEntry.Name)
+	}
+
+	chartVersions := make(repo.ChartVersions, 0, len(versions))
+	for _, version := range versions {
+		if filter.VersionFilter() != "all" && filter.VersionFilter() != "latest" && !matchesFilter(filter.StrictVersionFilter(), version) {
+			continue
+		}
+
+		chartVersions = append(chartVersions, &repo.ChartVersion{
+			Metadata: &chart.Metadata{
+				APIVersion: chart.APIVersionV2,
+				Name:       ref.ChartName(),
+				Version:    version,
+			},
+			URLs: []string{ref.WithTag(version).String()},
+		})
+
+		if filter.VersionFilter() == "latest" {
+			break
+		}
+	}
+
+	return chartVersions, nil
+}
+
+func tlsDirName(repoName string) string {
+	return repoName + "-tls"
+}
+
 // processEnvSettings emulates an cli.EnvSettings instance based on the passed in data
 func (h helm3EnvService) processEnvSettings(helmEnv helm.HelmEnv) *cli.EnvSettings {
 	envSettings := cli.New()
@@ -275,6 +394,10 @@ func (h helm3EnvService) processEnvSettings(helmEnv helm.HelmEnv) *cli.EnvSettin
 }
 
 func removeRepoCache(root, name string) error {
+	if err := os.RemoveAll(filepath.Join(root, tlsDirName(name))); err != nil {
+		return errors.Wrapf(err, "can't remove TLS files of %s", name)
+	}
+
 	idx := filepath.Join(root, helmpath.CacheChartsFile(name))
 	if _, err := os.Stat(idx); err == nil {
 		os.Remove(idx)
@@ -342,7 +465,7 @@ func (h helm3EnvService) getRawChartFileContent(chartFileName string, chartPtr *
 
 // listCharts retrieves  charts based on the input data
 // operates with h3 lib types
-func (h helm3EnvService) listCharts(_ context.Context, helmEnv helm.HelmEnv, filter helm.ChartFilter) (map[string][]repo.ChartVersions, error) {
+func (h helm3EnvService) listCharts(ctx context.Context, helmEnv helm.HelmEnv, filter helm.ChartFilter) (map[string][]repo.ChartVersions, error) {
 	chartVersionsSlice := make(map[string][]repo.ChartVersions)
 
 	repoFile, err := repo.LoadFile(helmEnv.GetHome())
@@ -362,6 +485,18 @@ func (h helm3EnvService) listCharts(_ context.Context, helmEnv helm.HelmEnv, fil
 			continue
 		}
 
+		if helm.IsOCIReference(repoEntry.URL) {
+			chartVersions, err := h.listOCICharts(ctx, repoEntry, filter)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(chartVersions) > 0 {
+				chartVersionsSlice[repoEntry.Name] = append(chartVersionsSlice[repoEntry.Name], chartVersions)
+			}
+			continue
+		}
+
 		repoIndexFilePath := path.Join(helmEnv.GetRepoCache(), helmpath.CacheIndexFile(repoEntry.Name))
 		repoIndexFile, err := repo.LoadIndexFile(repoIndexFilePath)
 		if err != nil {
@@ -419,7 +554,7 @@ func (h helm3EnvService) listCharts(_ context.Context, helmEnv helm.HelmEnv, fil
 }
 
 // getDetailedChart gets the chart details from the chart archive
-func (h helm3EnvService) getDetailedCharts(_ context.Context, helmEnv helm.HelmEnv, repoVersions repo.ChartVersions) (map[string]*chart.Chart, error) {
+func (h helm3EnvService) getDetailedCharts(ctx context.Context, helmEnv helm.HelmEnv, repoVersions repo.ChartVersions) (map[string]*chart.Chart, error) {
 	// set up a "fake" chart repo to use it's getter capabilities
 	chartRepo, err := repo.NewChartRepository(&repo.Entry{URL: "http://test"}, getter.All(h.processEnvSettings(helmEnv)))
 	if err != nil {
@@ -428,6 +563,16 @@ func (h helm3EnvService) getDetailedCharts(_ context.Context, helmEnv helm.HelmE
 
 	detailedCharts := make(map[string]*chart.Chart)
 	for _, repoChartVersionPtr := range repoVersions {
+		if helm.IsOCIReference(repoChartVersionPtr.URLs[0]) {
+			detailedChart, err := pullOCIChart(ctx, helmEnv, repoChartVersionPtr.URLs[0], "")
+			if err != nil {
+				return nil, err
+			}
+
+			detailedCharts[fmt.Sprintf("%s-%s", repoChartVersionPtr.Name, repoChartVersionPtr.Version)] = detailedChart
+			continue
+		}
+
 		// todo check the other urls, other checks?
 		buffer, err := chartRepo.Client.Get(repoChartVersionPtr.URLs[0])
 		if err != nil {
@@ -196,6 +196,8 @@ func (h *helm3UnifiedReleaser) GetDeployment(ctx context.Context, clusterID uint
 		Namespace:    release.Namespace,
 		Version:      0,
 		Status:       release.ReleaseInfo.Status,
+		Values:       release.ReleaseInfo.Values,
+		Manifest:     release.ReleaseInfo.Manifest,
 	}, nil
 }
 
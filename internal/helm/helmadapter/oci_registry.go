@@ -0,0 +1,563 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmadapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+const (
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+	// chart layer media types (the latter one is used by the experimental registry support of earlier Helm 3 releases)
+	helmChartContentMediaType       = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	legacyHelmChartContentMediaType = "application/tar+gzip"
+
+	// maxChartSize limits the size of chart archives pulled from registries
+	maxChartSize = 20 * 1024 * 1024
+)
+
+var authParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// ociRegistryClient talks to OCI registries using the distribution API to manage Helm charts stored in them.
+//
+// The registry client of Helm 3.2 lives in an internal package (helm.sh/helm/v3/internal/experimental/registry),
+// works through a local chart cache and cannot list tags, so it cannot be used for resolving chart versions.
+type ociRegistryClient struct {
+	httpClient *http.Client
+	scheme     string
+	username   string
+	password   string
+
+	// tokens caches bearer tokens by scope
+	tokens map[string]string
+}
+
+// newOCIRegistryClient sets up a registry client using the credentials and TLS settings of the repository entry.
+// Anonymous access is used when the entry is nil.
+func newOCIRegistryClient(entry *repo.Entry) (*ociRegistryClient, error) {
+	client := &ociRegistryClient{
+		scheme: "https",
+		tokens: make(map[string]string),
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if entry != nil {
+		client.username = entry.Username
+		client.password = entry.Password
+
+		tlsConfig.InsecureSkipVerify = entry.InsecureSkipTLSverify // nolint: gosec
+
+		if entry.CAFile != "" {
+			caCert, err := ioutil.ReadFile(entry.CAFile)
+			if err != nil {
+				return nil, errors.WrapIf(err, "failed to read registry CA certificate")
+			}
+
+			pool, err := x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+
+			if !pool.AppendCertsFromPEM(caCert) {
+				return nil, errors.New("failed to parse registry CA certificate")
+			}
+
+			tlsConfig.RootCAs = pool
+		}
+
+		if entry.CertFile != "" && entry.KeyFile != "" {
+			cert, err := tls.LoadX509KeyPair(entry.CertFile, entry.KeyFile)
+			if err != nil {
+				return nil, errors.WrapIf(err, "failed to load registry client certificate")
+			}
+
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	client.httpClient = &http.Client{
+		Transport: transport,
+		Timeout:   time.Minute,
+	}
+
+	return client, nil
+}
+
+// findOCIRepositoryEntry returns the repository entry with the longest URL prefix matching the reference.
+func findOCIRepositoryEntry(repoFile *repo.File, ref string) *repo.Entry {
+	if repoFile == nil {
+		return nil
+	}
+
+	var found *repo.Entry
+	for _, entry := range repoFile.Repositories {
+		if !helm.IsOCIReference(entry.URL) {
+			continue
+		}
+
+		prefix := strings.TrimSuffix(entry.URL, "/")
+		if ref != prefix && !strings.HasPrefix(ref, prefix+"/") {
+			continue
+		}
+
+		if found == nil || len(prefix) > len(strings.TrimSuffix(found.URL, "/")) {
+			found = entry
+		}
+	}
+
+	return found
+}
+
+// Login checks that the registry accepts the configured credentials.
+func (c *ociRegistryClient) Login(ctx context.Context, registry string) error {
+	resp, err := c.do(ctx, http.MethodGet, c.url(registry, "/v2/"), "", "")
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to log in to registry", "registry", registry)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.NewWithDetails("failed to log in to registry", "registry", registry, "status", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ListTags returns the tags of the chart repository, which are the available chart versions.
+func (c *ociRegistryClient) ListTags(ctx context.Context, ref helm.OCIReference) ([]string, error) {
+	var tags []string
+
+	next := c.url(ref.Registry, fmt.Sprintf("/v2/%s/tags/list", ref.Repository))
+	for next != "" {
+		resp, err := c.do(ctx, http.MethodGet, next, "", pullScope(ref))
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to list tags", "repository", ref.String())
+		}
+
+		var tagList struct {
+			Tags []string `json:"tags"`
+		}
+
+		err = decodeRegistryResponse(resp, &tagList)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to list tags", "repository", ref.String())
+		}
+
+		tags = append(tags, tagList.Tags...)
+
+		next, err = c.nextPage(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tags, nil
+}
+
+// LatestVersion returns the highest semantic version among the tags of the chart repository.
+func (c *ociRegistryClient) LatestVersion(ctx context.Context, ref helm.OCIReference) (string, error) {
+	versions, err := c.ListVersions(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	if len(versions) == 0 {
+		return "", errors.NewWithDetails("no chart versions found in registry", "repository", ref.String())
+	}
+
+	return versions[0], nil
+}
+
+// ListVersions returns the tags of the chart repository that are valid semantic versions, the highest first.
+func (c *ociRegistryClient) ListVersions(ctx context.Context, ref helm.OCIReference) ([]string, error) {
+	tags, err := c.ListTags(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*semver.Version, 0, len(tags))
+	for _, tag := range tags {
+		version, err := semver.NewVersion(tag)
+		if err != nil {
+			// charts are always tagged with their version, other tags are ignored
+			continue
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Sort(sort.Reverse(semver.Collection(versions)))
+
+	result := make([]string, 0, len(versions))
+	for _, version := range versions {
+		result = append(result, version.Original())
+	}
+
+	return result, nil
+}
+
+// PullChart downloads and loads the chart identified by the reference.
+func (c *ociRegistryClient) PullChart(ctx context.Context, ref helm.OCIReference) (*chart.Chart, error) {
+	if ref.Tag == "" {
+		return nil, errors.NewWithDetails("chart version is required to pull a chart", "reference", ref.String())
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, c.url(ref.Registry, fmt.Sprintf("/v2/%s/manifests/%s", ref.Repository, ref.Tag)), ociManifestMediaType, pullScope(ref))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to get chart manifest", "reference", ref.String())
+	}
+
+	var manifest struct {
+		Layers []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+			Size      int64  `json:"size"`
+		} `json:"layers"`
+	}
+
+	if err := decodeRegistryResponse(resp, &manifest); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to get chart manifest", "reference", ref.String())
+	}
+
+	var digest string
+	for _, layer := range manifest.Layers {
+		if layer.MediaType == helmChartContentMediaType || layer.MediaType == legacyHelmChartContentMediaType {
+			if layer.Size > maxChartSize {
+				return nil, errors.NewWithDetails("chart archive is too large", "reference", ref.String(), "size", layer.Size)
+			}
+
+			digest = layer.Digest
+
+			break
+		}
+	}
+
+	if digest == "" {
+		return nil, errors.NewWithDetails("manifest does not contain a chart layer", "reference", ref.String())
+	}
+
+	resp, err = c.do(ctx, http.MethodGet, c.url(ref.Registry, fmt.Sprintf("/v2/%s/blobs/%s", ref.Repository, digest)), "", pullScope(ref))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to download chart", "reference", ref.String())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.NewWithDetails("failed to download chart", "reference", ref.String(), "status", resp.StatusCode)
+	}
+
+	archive, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxChartSize+1))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to download chart", "reference", ref.String())
+	}
+
+	if len(archive) > maxChartSize {
+		return nil, errors.NewWithDetails("chart archive is too large", "reference", ref.String())
+	}
+
+	if err := verifyDigest(archive, digest); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to verify chart", "reference", ref.String())
+	}
+
+	ch, err := loader.LoadArchive(bytes.NewReader(archive))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to load chart", "reference", ref.String())
+	}
+
+	return ch, nil
+}
+
+func (c *ociRegistryClient) url(registry string, path string) string {
+	return fmt.Sprintf("%s://%s%s", c.scheme, registry, path)
+}
+
+// do sends a request to the registry, answering the authentication challenge of the registry if necessary.
+func (c *ociRegistryClient) do(ctx context.Context, method string, u string, accept string, scope string) (*http.Response, error) {
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, u, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+
+		if token, ok := c.tokens[scope]; ok {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else if c.username != "" {
+			req.SetBasicAuth(c.username, c.password)
+		}
+
+		return req, nil
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create registry request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapIf(err, "registry request failed")
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	scheme, params := parseAuthChallenge(challenge)
+	if !strings.EqualFold(scheme, "bearer") {
+		// basic credentials (if any) were already sent
+		return nil, errors.NewWithDetails("registry rejected the credentials", "challenge", scheme)
+	}
+
+	token, err := c.fetchToken(ctx, params["realm"], params["service"], scope)
+	if err != nil {
+		return nil, err
+	}
+
+	c.tokens[scope] = token
+
+	req, err = newRequest()
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create registry request")
+	}
+
+	resp, err = c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapIf(err, "registry request failed")
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+
+		return nil, errors.New("registry rejected the credentials")
+	}
+
+	return resp, nil
+}
+
+// fetchToken requests a bearer token from the token service of the registry.
+func (c *ociRegistryClient) fetchToken(ctx context.Context, realm string, service string, scope string) (string, error) {
+	if realm == "" {
+		return "", errors.New("registry authentication challenge does not specify a realm")
+	}
+
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", errors.WrapIf(err, "invalid token realm")
+	}
+
+	query := tokenURL.Query()
+	if service != "" {
+		query.Set("service", service)
+	}
+	if scope != "" {
+		query.Set("scope", scope)
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to create token request")
+	}
+
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", errors.WrapIf(err, "token request failed")
+	}
+
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := decodeRegistryResponse(resp, &tokenResponse); err != nil {
+		return "", errors.WrapIf(err, "failed to get registry token")
+	}
+
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+
+	if tokenResponse.AccessToken != "" {
+		return tokenResponse.AccessToken, nil
+	}
+
+	return "", errors.New("token service returned an empty token")
+}
+
+// nextPage returns the URL of the next page based on the Link header of a paginated response.
+func (c *ociRegistryClient) nextPage(resp *http.Response) (string, error) {
+	link := resp.Header.Get("Link")
+	if link == "" || !strings.Contains(link, `rel="next"`) {
+		return "", nil
+	}
+
+	start, end := strings.Index(link, "<"), strings.Index(link, ">")
+	if start < 0 || end < start {
+		return "", errors.NewWithDetails("invalid pagination link", "link", link)
+	}
+
+	next, err := resp.Request.URL.Parse(link[start+1 : end])
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "invalid pagination link", "link", link)
+	}
+
+	return next.String(), nil
+}
+
+func pullScope(ref helm.OCIReference) string {
+	return fmt.Sprintf("repository:%s:pull", ref.Repository)
+}
+
+// parseAuthChallenge parses a WWW-Authenticate header value.
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	params := make(map[string]string)
+
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) == 2 {
+		for _, match := range authParamRegexp.FindAllStringSubmatch(parts[1], -1) {
+			params[strings.ToLower(match[1])] = match[2]
+		}
+	}
+
+	return parts[0], params
+}
+
+func decodeRegistryResponse(resp *http.Response, v interface{}) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.NewWithDetails("not found in registry", "url", resp.Request.URL.String())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.NewWithDetails("unexpected registry response", "url", resp.Request.URL.String(), "status", resp.StatusCode)
+	}
+
+	return errors.WrapIf(json.NewDecoder(resp.Body).Decode(v), "failed to decode registry response")
+}
+
+func verifyDigest(content []byte, digest string) error {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 || parts[0] != "sha256" {
+		return errors.NewWithDetails("unsupported digest", "digest", digest)
+	}
+
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != parts[1] {
+		return errors.NewWithDetails("digest mismatch", "digest", digest)
+	}
+
+	return nil
+}
+
+// pullOCIChart pulls a chart from a registry using the credentials of the matching registry of the helm env.
+// The latest chart version is pulled when neither the reference nor the version argument specifies it.
+func pullOCIChart(ctx context.Context, helmEnv helm.HelmEnv, chartRef string, version string) (*chart.Chart, error) {
+	ref, err := helm.ParseOCIReference(chartRef)
+	if err != nil {
+		return nil, errors.WithStack(helm.NewValidationError(err.Error(), []string{"invalid OCI chart reference"}))
+	}
+
+	repoFile, err := repo.LoadFile(helmEnv.GetHome())
+	if err != nil && !isNotExist(err) {
+		return nil, errors.WrapIf(err, "failed to load repo file")
+	}
+
+	client, err := newOCIRegistryClient(findOCIRepositoryEntry(repoFile, ref.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	if ref.Tag == "" {
+		tag, err := resolveOCIChartVersion(ctx, client, ref, version)
+		if err != nil {
+			return nil, err
+		}
+
+		ref.Tag = tag
+	}
+
+	return client.PullChart(ctx, ref)
+}
+
+// resolveOCIChartVersion resolves the tag to pull: exact versions are used as they are,
+// version constraints (or an empty version) are resolved to the highest matching tag
+func resolveOCIChartVersion(ctx context.Context, client *ociRegistryClient, ref helm.OCIReference, version string) (string, error) {
+	if version == "" {
+		return client.LatestVersion(ctx, ref)
+	}
+
+	if _, err := semver.StrictNewVersion(version); err == nil {
+		return version, nil
+	}
+
+	constraint, err := semver.NewConstraint(version)
+	if err != nil {
+		// not a constraint, use it as a plain tag
+		return version, nil
+	}
+
+	versions, err := client.ListVersions(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	for _, v := range versions {
+		if constraint.Check(semver.MustParse(v)) {
+			return v, nil
+		}
+	}
+
+	return "", errors.NewWithDetails("no chart version found matching the constraint", "chart", ref.String(), "version", version)
+}
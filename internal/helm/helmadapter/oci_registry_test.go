@@ -0,0 +1,266 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmadapter
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/banzaicloud/pipeline/internal/helm"
+)
+
+const (
+	testRegistryUser     = "user"
+	testRegistryPassword = "secret"
+	testRegistryToken    = "token"
+)
+
+// fakeRegistry serves a single chart repository protected by bearer token authentication
+type fakeRegistry struct {
+	server  *httptest.Server
+	tags    []string
+	archive []byte
+	digest  string
+}
+
+func newFakeRegistry(t *testing.T, tags []string) *fakeRegistry {
+	archive := chartArchive(t, "mychart", "1.1.0")
+
+	r := &fakeRegistry{
+		tags:    tags,
+		archive: archive,
+		digest:  fmt.Sprintf("sha256:%x", sha256.Sum256(archive)),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		user, password, ok := req.BasicAuth()
+		if !ok || user != testRegistryUser || password != testRegistryPassword {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": testRegistryToken})
+	})
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer "+testRegistryToken {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, r.server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch path := req.URL.Path; {
+		case path == "/v2/":
+			w.WriteHeader(http.StatusOK)
+
+		case path == "/v2/charts/mychart/tags/list":
+			// serve one tag per page
+			page := 0
+			if last := req.URL.Query().Get("last"); last != "" {
+				for i, tag := range r.tags {
+					if tag == last {
+						page = i + 1
+					}
+				}
+			}
+
+			if page+1 < len(r.tags) {
+				w.Header().Set("Link", fmt.Sprintf(`</v2/charts/mychart/tags/list?n=1&last=%s>; rel="next"`, r.tags[page]))
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "charts/mychart", "tags": r.tags[page : page+1]})
+
+		case path == "/v2/charts/mychart/manifests/1.1.0":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"schemaVersion": 2,
+				"layers": []map[string]interface{}{
+					{"mediaType": helmChartContentMediaType, "digest": r.digest, "size": len(r.archive)},
+				},
+			})
+
+		case path == "/v2/charts/mychart/blobs/"+r.digest:
+			_, _ = w.Write(r.archive)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	r.server = httptest.NewTLSServer(mux)
+	t.Cleanup(r.server.Close)
+
+	return r
+}
+
+func (r *fakeRegistry) host() string {
+	return strings.TrimPrefix(r.server.URL, "https://")
+}
+
+// entry returns a repository entry trusting the TLS certificate of the registry
+func (r *fakeRegistry) entry(t *testing.T, username string, password string) *repo.Entry {
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: r.server.Certificate().Raw})
+	require.NoError(t, ioutil.WriteFile(caFile, caCert, 0600))
+
+	return &repo.Entry{
+		Name:     "registry",
+		URL:      "oci://" + r.host() + "/charts",
+		Username: username,
+		Password: password,
+		CAFile:   caFile,
+	}
+}
+
+func chartArchive(t *testing.T, name string, version string) []byte {
+	var buf bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	chartYaml := []byte(fmt.Sprintf("apiVersion: v2\nname: %s\nversion: %s\n", name, version))
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+		Name: name + "/Chart.yaml",
+		Mode: 0644,
+		Size: int64(len(chartYaml)),
+	}))
+	_, err := tarWriter.Write(chartYaml)
+	require.NoError(t, err)
+
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+
+	return buf.Bytes()
+}
+
+func TestOCIRegistryClient_Login(t *testing.T) {
+	registry := newFakeRegistry(t, []string{"1.1.0"})
+
+	client, err := newOCIRegistryClient(registry.entry(t, testRegistryUser, testRegistryPassword))
+	require.NoError(t, err)
+
+	assert.NoError(t, client.Login(context.Background(), registry.host()))
+
+	client, err = newOCIRegistryClient(registry.entry(t, testRegistryUser, "wrong"))
+	require.NoError(t, err)
+
+	assert.Error(t, client.Login(context.Background(), registry.host()))
+}
+
+func TestOCIRegistryClient_Login_UntrustedCertificate(t *testing.T) {
+	registry := newFakeRegistry(t, []string{"1.1.0"})
+
+	client, err := newOCIRegistryClient(&repo.Entry{Username: testRegistryUser, Password: testRegistryPassword})
+	require.NoError(t, err)
+
+	assert.Error(t, client.Login(context.Background(), registry.host()))
+}
+
+func TestOCIRegistryClient_ListVersions(t *testing.T) {
+	registry := newFakeRegistry(t, []string{"1.0.0", "latest", "1.1.0", "0.9.1"})
+
+	client, err := newOCIRegistryClient(registry.entry(t, testRegistryUser, testRegistryPassword))
+	require.NoError(t, err)
+
+	ref, err := helm.ParseOCIReference("oci://" + registry.host() + "/charts/mychart")
+	require.NoError(t, err)
+
+	tags, err := client.ListTags(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.0.0", "latest", "1.1.0", "0.9.1"}, tags)
+
+	versions, err := client.ListVersions(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.1.0", "1.0.0", "0.9.1"}, versions)
+
+	version, err := resolveOCIChartVersion(context.Background(), client, ref, "~1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+
+	version, err = resolveOCIChartVersion(context.Background(), client, ref, "")
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", version)
+}
+
+func TestOCIRegistryClient_PullChart(t *testing.T) {
+	registry := newFakeRegistry(t, []string{"1.1.0"})
+
+	client, err := newOCIRegistryClient(registry.entry(t, testRegistryUser, testRegistryPassword))
+	require.NoError(t, err)
+
+	ref, err := helm.ParseOCIReference("oci://" + registry.host() + "/charts/mychart:1.1.0")
+	require.NoError(t, err)
+
+	ch, err := client.PullChart(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, "mychart", ch.Name())
+	assert.Equal(t, "1.1.0", ch.Metadata.Version)
+
+	// the registry serves content not matching the digest of the manifest
+	registry.archive = chartArchive(t, "mychart", "6.6.6")
+
+	_, err = client.PullChart(context.Background(), ref)
+	assert.Error(t, err)
+}
+
+func TestFindOCIRepositoryEntry(t *testing.T) {
+	repoFile := repo.NewFile()
+	repoFile.Update(
+		&repo.Entry{Name: "stable", URL: "https://kubernetes-charts.storage.googleapis.com"},
+		&repo.Entry{Name: "registry", URL: "oci://registry.example.com"},
+		&repo.Entry{Name: "team", URL: "oci://registry.example.com/team/"},
+	)
+
+	tests := []struct {
+		ref   string
+		entry string
+	}{
+		{ref: "oci://registry.example.com/team/mychart:1.0.0", entry: "team"},
+		{ref: "oci://registry.example.com/other/mychart", entry: "registry"},
+		{ref: "oci://registry.example.com/teammate/mychart", entry: "registry"},
+		{ref: "oci://other.example.com/mychart", entry: ""},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.ref, func(t *testing.T) {
+			entry := findOCIRepositoryEntry(repoFile, test.ref)
+			if test.entry == "" {
+				assert.Nil(t, entry)
+
+				return
+			}
+
+			require.NotNil(t, entry)
+			assert.Equal(t, test.entry, entry.Name)
+		})
+	}
+}
@@ -15,12 +15,14 @@
 package helmadapter
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha1"
 	"encoding/base64"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -86,7 +88,17 @@ func (r releaser) Install(ctx context.Context, helmEnv helm.HelmEnv, kubeConfig
 		installAction.GenerateName = true
 	}
 
-	name, chartRef, err := installAction.NameAndChart(releaseInput.NameAndChartSlice())
+	nameAndChart := releaseInput.NameAndChartSlice()
+	if helm.IsOCIReference(releaseInput.ChartName) {
+		// the reference itself is not a valid name for name generation
+		ref, err := helm.ParseOCIReference(releaseInput.ChartName)
+		if err != nil {
+			return helm.Release{}, errors.WithStack(helm.NewValidationError(err.Error(), []string{"invalid OCI chart reference"}))
+		}
+		nameAndChart[len(nameAndChart)-1] = ref.ChartName()
+	}
+
+	name, chartRef, err := installAction.NameAndChart(nameAndChart)
 	if err != nil {
 		return helm.Release{}, errors.WrapIf(err, "failed to get  name  and chart")
 	}
@@ -95,18 +107,14 @@ func (r releaser) Install(ctx context.Context, helmEnv helm.HelmEnv, kubeConfig
 	installAction.Timeout = time.Minute * 5
 	installAction.Version = releaseInput.Version
 	installAction.SkipCRDs = options.SkipCRDs
-
-	cp, err := installAction.ChartPathOptions.LocateChart(chartRef, envSettings)
-	if err != nil {
-		return helm.Release{}, errors.WrapIf(err, "failed to locate chart")
-	}
+	installAction.Verify = options.ProvenanceKeyring != ""
+	installAction.Keyring = options.ProvenanceKeyring
 
 	p := getter.All(envSettings)
 
-	// Check chart dependencies to make sure all are present in /charts
-	chartRequested, err := loader.Load(cp)
+	chartRequested, cp, err := r.loadChart(ctx, helmEnv, releaseInput.ChartName, chartRef, &installAction.ChartPathOptions)
 	if err != nil {
-		return helm.Release{}, errors.WrapIf(err, "failed to load chart")
+		return helm.Release{}, err
 	}
 
 	validInstallableChart, err := isChartInstallable(chartRequested)
@@ -309,6 +317,7 @@ func (r releaser) Get(_ context.Context, helmEnv helm.HelmEnv, kubeConfig helm.K
 			Status:        rawRelease.Info.Status.String(),
 			Notes:         base64.StdEncoding.EncodeToString([]byte(rawRelease.Info.Notes)),
 			Values:        rawRelease.Config,
+			Manifest:      rawRelease.Manifest,
 		},
 	}, nil
 }
@@ -340,17 +349,14 @@ func (r releaser) Upgrade(ctx context.Context, helmEnv helm.HelmEnv, kubeConfig
 	upgradeAction.Timeout = time.Minute * 5
 	upgradeAction.Version = releaseInput.Version
 	upgradeAction.SkipCRDs = options.SkipCRDs
+	upgradeAction.Verify = options.ProvenanceKeyring != ""
+	upgradeAction.Keyring = options.ProvenanceKeyring
 
 	if upgradeAction.Version == "" && upgradeAction.Devel {
 		r.logger.Debug("setting version to >0.0.0-0")
 		upgradeAction.Version = ">0.0.0-0"
 	}
 
-	chartPath, err := upgradeAction.ChartPathOptions.LocateChart(releaseInput.ChartName, r.processEnvSettings(helmEnv))
-	if err != nil {
-		return helm.Release{}, errors.WrapIf(err, "failed to locate chart")
-	}
-
 	if upgradeAction.Install {
 		// If a release does not exist, install it.
 		histClient := action.NewHistory(actionConfig)
@@ -369,11 +375,12 @@ func (r releaser) Upgrade(ctx context.Context, helmEnv helm.HelmEnv, kubeConfig
 		}
 	}
 
-	// Check chart dependencies to make sure all are present in /charts
-	ch, err := loader.Load(chartPath)
+	ch, _, err := r.loadChart(ctx, helmEnv, releaseInput.ChartName, releaseInput.ChartName, &upgradeAction.ChartPathOptions)
 	if err != nil {
-		return helm.Release{}, errors.WrapIf(err, "failed to load chart")
+		return helm.Release{}, err
 	}
+
+	// Check chart dependencies to make sure all are present in /charts
 	if req := ch.Metadata.Dependencies; req != nil {
 		if err := action.CheckDependencies(ch, req); err != nil {
 			return helm.Release{}, errors.WrapIf(err, "failed to check dependencies")
@@ -423,6 +430,249 @@ func (r releaser) Resources(_ context.Context, helmEnv helm.HelmEnv, kubeConfig
 	return resources, nil
 }
 
+func (r releaser) History(_ context.Context, helmEnv helm.HelmEnv, kubeConfig helm.KubeConfigBytes, releaseName string, options helm.Options) ([]helm.ReleaseRevision, error) {
+	ns := "default"
+	if options.Namespace != "" {
+		ns = options.Namespace
+	}
+
+	// component processing the kubeconfig
+	restClientGetter := NewCustomGetter(ns, kubeConfig, helmEnv.GetCacheDir(), r.logger)
+
+	actionConfig, err := r.getActionConfiguration(restClientGetter, ns)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to get action configuration")
+	}
+
+	historyAction := action.NewHistory(actionConfig)
+
+	rawReleases, err := historyAction.Run(releaseName)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return nil, errors.WithStack(helm.ReleaseNotFoundError{ReleaseName: releaseName})
+	} else if err != nil {
+		return nil, errors.WrapIf(err, "failed to get release history")
+	}
+
+	revisions := make([]helm.ReleaseRevision, 0, len(rawReleases))
+	for _, rawRelease := range rawReleases {
+		revision, err := r.adaptRevision(rawRelease)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+
+	return revisions, nil
+}
+
+func (r releaser) adaptRevision(rawRelease *release.Release) (helm.ReleaseRevision, error) {
+	digest, err := helm.ValuesDigest(rawRelease.Config)
+	if err != nil {
+		return helm.ReleaseRevision{}, errors.WrapIfWithDetails(err, "failed to compute values digest", "revision", rawRelease.Version)
+	}
+
+	revision := helm.ReleaseRevision{
+		Revision:     int32(rawRelease.Version),
+		ValuesDigest: digest,
+	}
+
+	if rawRelease.Chart != nil && rawRelease.Chart.Metadata != nil {
+		revision.ChartName = rawRelease.Chart.Metadata.Name
+		revision.ChartVersion = rawRelease.Chart.Metadata.Version
+		revision.AppVersion = rawRelease.Chart.Metadata.AppVersion
+	}
+
+	if rawRelease.Info != nil {
+		revision.Status = rawRelease.Info.Status.String()
+		revision.Description = rawRelease.Info.Description
+		revision.FirstDeployed = rawRelease.Info.FirstDeployed.Time
+		revision.LastDeployed = rawRelease.Info.LastDeployed.Time
+		revision.Deleted = rawRelease.Info.Deleted.Time
+	}
+
+	return revision, nil
+}
+
+func (r releaser) Rollback(_ context.Context, helmEnv helm.HelmEnv, kubeConfig helm.KubeConfigBytes, releaseName string, revision int32, options helm.Options) (helm.Release, error) {
+	ns := "default"
+	if options.Namespace != "" {
+		ns = options.Namespace
+	}
+
+	// component processing the kubeconfig
+	restClientGetter := NewCustomGetter(ns, kubeConfig, helmEnv.GetCacheDir(), r.logger)
+
+	actionConfig, err := r.getActionConfiguration(restClientGetter, ns)
+	if err != nil {
+		return helm.Release{}, errors.WrapIf(err, "failed to get action configuration")
+	}
+
+	// revision 0 means the previous revision, which is resolved here so that a dry run can return it as well
+	if revision == 0 {
+		current, err := action.NewGet(actionConfig).Run(releaseName)
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return helm.Release{}, errors.WithStack(helm.ReleaseNotFoundError{ReleaseName: releaseName})
+		} else if err != nil {
+			return helm.Release{}, errors.WrapIf(err, "failed to get release")
+		}
+
+		if current.Version <= 1 {
+			return helm.Release{}, errors.WithStack(helm.NewValidationError(
+				"invalid release rollback request",
+				[]string{"release has no earlier revision to roll back to"},
+			))
+		}
+
+		revision = int32(current.Version - 1)
+	}
+
+	rollbackAction := action.NewRollback(actionConfig)
+	rollbackAction.Version = int(revision)
+	rollbackAction.Wait = options.Wait
+	rollbackAction.DryRun = options.DryRun
+	rollbackAction.Timeout = time.Minute * 5
+
+	if err := rollbackAction.Run(releaseName); errors.Is(err, driver.ErrReleaseNotFound) {
+		return helm.Release{}, errors.WithStack(helm.ReleaseNotFoundError{ReleaseName: releaseName})
+	} else if err != nil {
+		return helm.Release{}, errors.WrapIf(err, "failed to roll back release")
+	}
+
+	getAction := action.NewGet(actionConfig)
+
+	// a dry run leaves the release untouched, so the revision it would be rolled back to is returned instead
+	message := "release successfully rolled back"
+	if options.DryRun {
+		getAction.Version = int(revision)
+		message = "release rollback dry run succeeded"
+	}
+
+	rawRelease, err := getAction.Run(releaseName)
+	if err != nil {
+		return helm.Release{}, errors.WrapIf(err, "failed to get release")
+	}
+
+	r.logger.Info(message, map[string]interface{}{"releaseName": releaseName, "revision": rawRelease.Version})
+
+	return r.adaptReleasePtr(rawRelease), nil
+}
+
+func (r releaser) Diff(ctx context.Context, helmEnv helm.HelmEnv, kubeConfig helm.KubeConfigBytes, releaseInput helm.Release, options helm.Options) (helm.ReleaseDiff, error) {
+	ns := "default"
+	if releaseInput.Namespace != "" {
+		ns = releaseInput.Namespace
+	}
+
+	if options.Namespace != "" {
+		ns = options.Namespace
+	}
+
+	// component processing the kubeconfig
+	restClientGetter := NewCustomGetter(ns, kubeConfig, helmEnv.GetCacheDir(), r.logger)
+
+	actionConfig, err := r.getActionConfiguration(restClientGetter, ns)
+	if err != nil {
+		return helm.ReleaseDiff{}, errors.WrapIf(err, "failed to get action configuration")
+	}
+
+	currentRelease, err := action.NewGet(actionConfig).Run(releaseInput.ReleaseName)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return helm.ReleaseDiff{}, errors.WithStack(helm.ReleaseNotFoundError{ReleaseName: releaseInput.ReleaseName})
+	} else if err != nil {
+		return helm.ReleaseDiff{}, errors.WrapIf(err, "failed to get release")
+	}
+
+	upgradeAction := action.NewUpgrade(actionConfig)
+	upgradeAction.Namespace = ns
+	upgradeAction.DryRun = true
+	upgradeAction.ReuseValues = options.ReuseValues
+	upgradeAction.Version = releaseInput.Version
+	upgradeAction.SkipCRDs = options.SkipCRDs
+
+	ch, _, err := r.loadChart(ctx, helmEnv, releaseInput.ChartName, releaseInput.ChartName, &upgradeAction.ChartPathOptions)
+	if err != nil {
+		return helm.ReleaseDiff{}, err
+	}
+
+	if req := ch.Metadata.Dependencies; req != nil {
+		if err := action.CheckDependencies(ch, req); err != nil {
+			return helm.ReleaseDiff{}, errors.WrapIf(err, "failed to check dependencies")
+		}
+	}
+
+	targetRelease, err := upgradeAction.Run(releaseInput.ReleaseName, ch, releaseInput.Values)
+	if err != nil {
+		return helm.ReleaseDiff{}, errors.WrapIf(err, "failed to render release upgrade")
+	}
+
+	resources, err := helm.DiffManifests(currentRelease.Manifest, targetRelease.Manifest)
+	if err != nil {
+		return helm.ReleaseDiff{}, errors.WrapIf(err, "failed to compare release manifests")
+	}
+
+	return helm.ReleaseDiff{
+		ReleaseName:         currentRelease.Name,
+		Namespace:           currentRelease.Namespace,
+		CurrentRevision:     int32(currentRelease.Version),
+		CurrentChartVersion: currentRelease.Chart.Metadata.Version,
+		TargetChartVersion:  ch.Metadata.Version,
+		Resources:           resources,
+	}, nil
+}
+
+func (r releaser) Verify(_ context.Context, helmEnv helm.HelmEnv, kubeConfig helm.KubeConfigBytes, releaseName string, options helm.Options) error {
+	var verifyOptions helm.VerifyOptions
+	if options.Verify != nil {
+		verifyOptions = *options.Verify
+	}
+
+	ns := "default"
+	if options.Namespace != "" {
+		ns = options.Namespace
+	}
+
+	// component processing the kubeconfig
+	restClientGetter := NewCustomGetter(ns, kubeConfig, helmEnv.GetCacheDir(), r.logger)
+
+	actionConfig, err := r.getActionConfiguration(restClientGetter, ns)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get action configuration")
+	}
+
+	rawRelease, err := action.NewGet(actionConfig).Run(releaseName)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get release")
+	}
+
+	resources, err := actionConfig.KubeClient.Build(bytes.NewBufferString(rawRelease.Manifest), false)
+	if err != nil {
+		return errors.WrapIf(err, "failed to build release resources")
+	}
+
+	if err := actionConfig.KubeClient.Wait(resources, verifyOptions.TimeoutDuration()); err != nil {
+		return errors.WrapIf(err, "release resources did not become ready")
+	}
+
+	if verifyOptions.RunTests {
+		testAction := action.NewReleaseTesting(actionConfig)
+		testAction.Namespace = ns
+		testAction.Timeout = verifyOptions.TimeoutDuration()
+
+		if _, err := testAction.Run(releaseName); err != nil {
+			return errors.WrapIf(err, "release tests failed")
+		}
+	}
+
+	r.logger.Debug("release verified", map[string]interface{}{"releaseName": releaseName})
+
+	return nil
+}
+
 // resourcesFromManifest digs out the resources from a release manifest
 func (r releaser) resourcesFromManifest(manifest string) ([]helm.ReleaseResource, error) {
 	var (
@@ -456,6 +706,35 @@ func (r releaser) resourcesFromManifest(manifest string) ([]helm.ReleaseResource
 	return resources, nil
 }
 
+// loadChart loads the requested chart either from a registry (OCI references) or from the configured repositories
+// the returned chart path is empty for charts pulled from a registry
+func (r releaser) loadChart(ctx context.Context, helmEnv helm.HelmEnv, chartName string, chartRef string, chartPathOptions *action.ChartPathOptions) (*chart.Chart, string, error) {
+	if helm.IsOCIReference(chartName) {
+		if chartPathOptions.Verify {
+			return nil, "", errors.WithStack(helm.NewValidationError("chart provenance cannot be verified", []string{"provenance verification is not supported for OCI charts"}))
+		}
+
+		ch, err := pullOCIChart(ctx, helmEnv, chartName, chartPathOptions.Version)
+		if err != nil {
+			return nil, "", errors.WrapIf(err, "failed to pull chart from registry")
+		}
+
+		return ch, "", nil
+	}
+
+	chartPath, err := chartPathOptions.LocateChart(chartRef, r.processEnvSettings(helmEnv))
+	if err != nil {
+		return nil, "", errors.WrapIf(err, "failed to locate chart")
+	}
+
+	ch, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, "", errors.WrapIf(err, "failed to load chart")
+	}
+
+	return ch, chartPath, nil
+}
+
 // processEnvSettings emulates an cli.EnvSettings instance based on the passed in data
 func (r releaser) processEnvSettings(helmEnv helm.HelmEnv) *cli.EnvSettings {
 	envSettings := cli.New()
@@ -17,6 +17,7 @@ package helmdriver
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -85,6 +86,27 @@ func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...ki
 		kitxhttp.ErrorResponseEncoder(encodeChartDetailsHTTPResponse, errorEncoder),
 		options...,
 	))
+
+	router.Methods(http.MethodGet).Path("/policy").Handler(kithttp.NewServer(
+		endpoints.GetChartPolicy,
+		decodeGetChartPolicyHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetChartPolicyHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPut).Path("/policy").Handler(kithttp.NewServer(
+		endpoints.UpdateChartPolicy,
+		decodeUpdateChartPolicyHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusAccepted), errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodDelete).Path("/policy").Handler(kithttp.NewServer(
+		endpoints.DeleteChartPolicy,
+		decodeDeleteChartPolicyHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
 }
 
 func RegisterReleaserHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
@@ -125,6 +147,27 @@ func RegisterReleaserHTTPHandlers(endpoints Endpoints, router *mux.Router, optio
 		options...,
 	))
 
+	router.Methods(http.MethodGet).Path("/{name}/history").Handler(kithttp.NewServer(
+		endpoints.GetReleaseHistory,
+		decodeGetReleaseHistoryHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetReleaseHistoryHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/{name}/rollback").Handler(kithttp.NewServer(
+		endpoints.RollbackRelease,
+		decodeRollbackReleaseHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeRollbackReleaseHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/{name}/diff").Handler(kithttp.NewServer(
+		endpoints.DiffRelease,
+		decodeDiffReleaseHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeDiffReleaseHTTPResponse, errorEncoder),
+		options...,
+	))
+
 	router.Methods(http.MethodHead).Path("/{name}").Handler(kithttp.NewServer(
 		endpoints.CheckRelease,
 		decodeCheckReleaseHTTPRequest,
@@ -177,6 +220,7 @@ func decodeInstallReleaseHTTPRequest(_ context.Context, r *http.Request) (interf
 			GenerateName: request.ReleaseName == "",
 			Wait:         request.Wait,
 			Namespace:    request.Namespace,
+			Verify:       verifyOptions(request.Verify),
 		},
 	}, nil
 }
@@ -236,6 +280,7 @@ func decodeUpgradeReleaseHTTPRequest(_ context.Context, r *http.Request) (interf
 			DryRun:       request.DryRun,
 			GenerateName: request.ReleaseName == "",
 			Wait:         request.Wait,
+			Verify:       verifyOptions(request.Verify),
 		},
 	}, nil
 }
@@ -385,6 +430,75 @@ func encodeDeleteRepositoryHTTPResponse(ctx context.Context, w http.ResponseWrit
 	return kitxhttp.JSONResponseEncoder(ctx, w, resp)
 }
 
+func decodeGetChartPolicyHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode get chart policy request")
+	}
+
+	return GetChartPolicyRequest{OrganizationID: orgID}, nil
+}
+
+func encodeGetChartPolicyHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetChartPolicyResponse)
+
+	constraints := make([]pipeline.HelmChartVersionConstraint, 0, len(resp.R0.VersionConstraints))
+	for _, constraint := range resp.R0.VersionConstraints {
+		constraints = append(constraints, pipeline.HelmChartVersionConstraint{
+			Chart:      constraint.Chart,
+			Constraint: constraint.Constraint,
+		})
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, pipeline.HelmChartPolicy{
+		AllowedRepositories: resp.R0.AllowedRepositories,
+		AllowedCharts:       resp.R0.AllowedCharts,
+		DeniedCharts:        resp.R0.DeniedCharts,
+		VersionConstraints:  constraints,
+		RequireProvenance:   resp.R0.RequireProvenance,
+	})
+}
+
+func decodeUpdateChartPolicyHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode update chart policy request")
+	}
+
+	var request pipeline.HelmChartPolicy
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.WrapIf(err, "failed to decode request")
+	}
+
+	var constraints []helm.ChartVersionConstraint
+	for _, constraint := range request.VersionConstraints {
+		constraints = append(constraints, helm.ChartVersionConstraint{
+			Chart:      constraint.Chart,
+			Constraint: constraint.Constraint,
+		})
+	}
+
+	return UpdateChartPolicyRequest{
+		OrganizationID: orgID,
+		Policy: helm.ChartPolicy{
+			AllowedRepositories: request.AllowedRepositories,
+			AllowedCharts:       request.AllowedCharts,
+			DeniedCharts:        request.DeniedCharts,
+			VersionConstraints:  constraints,
+			RequireProvenance:   request.RequireProvenance,
+		},
+	}, nil
+}
+
+func decodeDeleteChartPolicyHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode delete chart policy request")
+	}
+
+	return DeleteChartPolicyRequest{OrganizationID: orgID}, nil
+}
+
 func decodeDeleteReleaseHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	orgID, err := extractUintParamFromRequest("orgId", r)
 	if err != nil {
@@ -552,6 +666,166 @@ func encodeGetReleaseHTTPResponse(ctx context.Context, w http.ResponseWriter, re
 	return kitxhttp.JSONResponseEncoder(ctx, w, resp)
 }
 
+func decodeGetReleaseHistoryHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode get release history request")
+	}
+
+	clusterID, err := extractUintParamFromRequest("clusterId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode get release history request")
+	}
+
+	releaseName, err := extractStringParamFromRequest("name", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode get release history request")
+	}
+
+	return GetReleaseHistoryRequest{
+		OrganizationID: orgID,
+		ClusterID:      clusterID,
+		ReleaseName:    releaseName,
+		Options: helm.Options{
+			Namespace: r.URL.Query().Get("namespace"),
+		},
+	}, nil
+}
+
+func encodeGetReleaseHistoryHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp, ok := response.(GetReleaseHistoryResponse)
+	if !ok {
+		return errors.New("invalid release history response")
+	}
+
+	if resp.Err != nil {
+		return errors.WrapIf(resp.Err, "failed to retrieve release history")
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.R0)
+}
+
+func decodeRollbackReleaseHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode rollback release request")
+	}
+
+	clusterID, err := extractUintParamFromRequest("clusterId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode rollback release request")
+	}
+
+	releaseName, err := extractStringParamFromRequest("name", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode rollback release request")
+	}
+
+	var request struct {
+		Revision int32 `json:"revision"`
+		Wait     bool  `json:"wait"`
+		DryRun   bool  `json:"dryRun"`
+	}
+
+	// an empty body rolls back to the previous revision
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && err != io.EOF {
+		return nil, errors.WrapIf(err, "failed to decode rollback release request")
+	}
+
+	return RollbackReleaseRequest{
+		OrganizationID: orgID,
+		ClusterID:      clusterID,
+		ReleaseName:    releaseName,
+		Revision:       request.Revision,
+		Options: helm.Options{
+			Namespace: r.URL.Query().Get("namespace"),
+			Wait:      request.Wait,
+			DryRun:    request.DryRun,
+		},
+	}, nil
+}
+
+func encodeRollbackReleaseHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp, ok := response.(RollbackReleaseResponse)
+	if !ok {
+		return errors.New("invalid release rollback response")
+	}
+
+	if resp.Err != nil {
+		return errors.WrapIf(resp.Err, "failed to roll back release")
+	}
+
+	release := struct {
+		ReleaseName  string `json:"releaseName"`
+		Namespace    string `json:"namespace"`
+		ChartName    string `json:"chartName"`
+		ChartVersion string `json:"chartVersion"`
+		Revision     int32  `json:"revision"`
+		Status       string `json:"status"`
+	}{
+		ReleaseName:  resp.R0.ReleaseName,
+		Namespace:    resp.R0.Namespace,
+		ChartName:    resp.R0.ChartName,
+		ChartVersion: resp.R0.Version,
+		Revision:     resp.R0.ReleaseVersion,
+		Status:       resp.R0.ReleaseInfo.Status,
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(release, http.StatusAccepted))
+}
+
+func decodeDiffReleaseHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParamFromRequest("orgId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release request")
+	}
+
+	clusterID, err := extractUintParamFromRequest("clusterId", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release request")
+	}
+
+	releaseName, err := extractStringParamFromRequest("name", r)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release request")
+	}
+
+	var request pipeline.CreateUpdateDeploymentRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.WrapIf(err, "failed to decode diff release request")
+	}
+
+	return DiffReleaseRequest{
+		OrganizationID: orgID,
+		ClusterID:      clusterID,
+		ReleaseInput: helm.Release{
+			ReleaseName: releaseName,
+			ChartName:   request.Name,
+			Namespace:   request.Namespace,
+			Values:      request.Values,
+			Version:     request.Version,
+		},
+		Options: helm.Options{
+			Namespace:   request.Namespace,
+			ReuseValues: request.ReuseValues,
+		},
+	}, nil
+}
+
+func encodeDiffReleaseHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp, ok := response.(DiffReleaseResponse)
+	if !ok {
+		return errors.New("invalid release diff response")
+	}
+
+	if resp.Err != nil {
+		return errors.WrapIf(resp.Err, "failed to diff release")
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.R0)
+}
+
 func decodeGetReleasesHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	orgID, err := extractUintParamFromRequest("orgId", r)
 	if err != nil {
@@ -708,6 +982,18 @@ func encodeChartDetailsHTTPResponse(ctx context.Context, w http.ResponseWriter,
 	return kitxhttp.JSONResponseEncoder(ctx, w, chart.ChartDetails)
 }
 
+// verifyOptions converts the verification settings of a deployment request; nil means no verification
+func verifyOptions(verification pipeline.DeploymentVerification) *helm.VerifyOptions {
+	if !verification.Enabled {
+		return nil
+	}
+
+	return &helm.VerifyOptions{
+		Timeout:  verification.Timeout,
+		RunTests: verification.RunTests,
+	}
+}
+
 func extractStringParamFromRequest(key string, r *http.Request) (string, error) {
 	vars := mux.Vars(r)
 
@@ -157,3 +157,267 @@ func TestRegisterHTTPHandlers_AddRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterReleaserHTTPHandlers_GetReleaseHistory(t *testing.T) {
+	handler := mux.NewRouter()
+	RegisterReleaserHTTPHandlers(
+		Endpoints{
+			GetReleaseHistory: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				req := request.(GetReleaseHistoryRequest)
+
+				assert.Equal(t, uint(1), req.OrganizationID)
+				assert.Equal(t, uint(2), req.ClusterID)
+				assert.Equal(t, "my-release", req.ReleaseName)
+				assert.Equal(t, "my-namespace", req.Options.Namespace)
+
+				return GetReleaseHistoryResponse{
+					R0: []helm.ReleaseRevision{
+						{Revision: 1, ChartName: "my-chart", ChartVersion: "1.0.0", Status: "superseded", ValuesDigest: "sha256:1"},
+						{Revision: 2, ChartName: "my-chart", ChartVersion: "1.1.0", Status: "deployed", ValuesDigest: "sha256:2"},
+					},
+				}, nil
+			},
+		},
+		handler.PathPrefix("/orgs/{orgId}/clusters/{clusterId}/deployments").Subrouter(),
+	)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(fmt.Sprintf("%s/orgs/%d/clusters/%d/deployments/my-release/history?namespace=my-namespace", ts.URL, 1, 2))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var revisions []helm.ReleaseRevision
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&revisions))
+
+	require.Len(t, revisions, 2)
+	assert.Equal(t, int32(2), revisions[1].Revision)
+	assert.Equal(t, "1.1.0", revisions[1].ChartVersion)
+	assert.Equal(t, "sha256:2", revisions[1].ValuesDigest)
+}
+
+func TestRegisterReleaserHTTPHandlers_RollbackRelease(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		expectedRevision int32
+	}{
+		{
+			name:             "explicit revision",
+			body:             `{"revision": 3, "wait": true}`,
+			expectedRevision: 3,
+		},
+		{
+			name:             "previous revision",
+			body:             "",
+			expectedRevision: 0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			handler := mux.NewRouter()
+			RegisterReleaserHTTPHandlers(
+				Endpoints{
+					RollbackRelease: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+						req := request.(RollbackReleaseRequest)
+
+						assert.Equal(t, "my-release", req.ReleaseName)
+						assert.Equal(t, tt.expectedRevision, req.Revision)
+
+						return RollbackReleaseResponse{
+							R0: helm.Release{
+								ReleaseName:    req.ReleaseName,
+								ReleaseVersion: 5,
+								ReleaseInfo:    helm.ReleaseInfo{Status: "deployed"},
+							},
+						}, nil
+					},
+				},
+				handler.PathPrefix("/orgs/{orgId}/clusters/{clusterId}/deployments").Subrouter(),
+			)
+
+			ts := httptest.NewServer(handler)
+			defer ts.Close()
+
+			resp, err := ts.Client().Post(
+				fmt.Sprintf("%s/orgs/%d/clusters/%d/deployments/my-release/rollback", ts.URL, 1, 2),
+				"application/json",
+				bytes.NewReader([]byte(tt.body)),
+			)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+			var release struct {
+				Revision int32  `json:"revision"`
+				Status   string `json:"status"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&release))
+
+			assert.Equal(t, int32(5), release.Revision)
+			assert.Equal(t, "deployed", release.Status)
+		})
+	}
+}
+
+func TestRegisterReleaserHTTPHandlers_DiffRelease(t *testing.T) {
+	handler := mux.NewRouter()
+	RegisterReleaserHTTPHandlers(
+		Endpoints{
+			DiffRelease: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				req := request.(DiffReleaseRequest)
+
+				assert.Equal(t, uint(1), req.OrganizationID)
+				assert.Equal(t, uint(2), req.ClusterID)
+				assert.Equal(t, "my-release", req.ReleaseInput.ReleaseName)
+				assert.Equal(t, "stable/my-chart", req.ReleaseInput.ChartName)
+				assert.Equal(t, "1.1.0", req.ReleaseInput.Version)
+				assert.Equal(t, map[string]interface{}{"replicas": float64(2)}, req.ReleaseInput.Values)
+				assert.Equal(t, "my-namespace", req.Options.Namespace)
+				assert.False(t, req.Options.DryRun)
+
+				return DiffReleaseResponse{
+					R0: helm.ReleaseDiff{
+						ReleaseName:         req.ReleaseInput.ReleaseName,
+						Namespace:           "my-namespace",
+						CurrentRevision:     3,
+						CurrentChartVersion: "1.0.0",
+						TargetChartVersion:  "1.1.0",
+						Resources: []helm.ResourceDiff{
+							{Kind: "Deployment", Name: "my-release", Change: helm.ResourceChanged, Diff: "-replicas: 1\n+replicas: 2\n"},
+						},
+					},
+				}, nil
+			},
+		},
+		handler.PathPrefix("/orgs/{orgId}/clusters/{clusterId}/deployments").Subrouter(),
+	)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp, err := ts.Client().Post(
+		fmt.Sprintf("%s/orgs/%d/clusters/%d/deployments/my-release/diff", ts.URL, 1, 2),
+		"application/json",
+		bytes.NewReader([]byte(`{"name": "stable/my-chart", "version": "1.1.0", "namespace": "my-namespace", "values": {"replicas": 2}}`)),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var diff helm.ReleaseDiff
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&diff))
+
+	assert.Equal(t, int32(3), diff.CurrentRevision)
+	assert.Equal(t, "1.1.0", diff.TargetChartVersion)
+	require.Len(t, diff.Resources, 1)
+	assert.Equal(t, helm.ResourceChanged, diff.Resources[0].Change)
+}
+
+func TestRegisterReleaserHTTPHandlers_UpgradeRelease_Verify(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedVerify *helm.VerifyOptions
+	}{
+		{
+			name:           "verification enabled",
+			body:           `{"name": "stable/my-chart", "releaseName": "my-release", "verify": {"enabled": true, "timeout": 120, "runTests": true}}`,
+			expectedVerify: &helm.VerifyOptions{Timeout: 120, RunTests: true},
+		},
+		{
+			name:           "verification disabled",
+			body:           `{"name": "stable/my-chart", "releaseName": "my-release", "verify": {"timeout": 120}}`,
+			expectedVerify: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			handler := mux.NewRouter()
+			RegisterReleaserHTTPHandlers(
+				Endpoints{
+					UpgradeRelease: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+						req := request.(UpgradeReleaseRequest)
+
+						assert.Equal(t, tt.expectedVerify, req.Options.Verify)
+
+						return UpgradeReleaseResponse{Release: helm.Release{ReleaseName: req.ReleaseInput.ReleaseName}}, nil
+					},
+				},
+				handler.PathPrefix("/orgs/{orgId}/clusters/{clusterId}/deployments").Subrouter(),
+			)
+
+			ts := httptest.NewServer(handler)
+			defer ts.Close()
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				fmt.Sprintf("%s/orgs/%d/clusters/%d/deployments/my-release", ts.URL, 1, 2),
+				bytes.NewReader([]byte(tt.body)),
+			)
+			require.NoError(t, err)
+
+			resp, err := ts.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		})
+	}
+}
+
+func TestRegisterHTTPHandlers_ChartPolicy(t *testing.T) {
+	policy := helm.ChartPolicy{
+		AllowedRepositories: []string{"stable"},
+		DeniedCharts:        []string{"stable/mysql"},
+		VersionConstraints:  []helm.ChartVersionConstraint{{Chart: "stable/*", Constraint: ">= 1.0.0"}},
+		RequireProvenance:   true,
+	}
+
+	var updateRequest UpdateChartPolicyRequest
+
+	handler := mux.NewRouter()
+	RegisterHTTPHandlers(
+		Endpoints{
+			GetChartPolicy: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				return GetChartPolicyResponse{R0: policy}, nil
+			},
+			UpdateChartPolicy: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				updateRequest = request.(UpdateChartPolicyRequest)
+
+				return UpdateChartPolicyResponse{}, nil
+			},
+		},
+		handler.PathPrefix("/orgs/{orgId}/helm").Subrouter(),
+	)
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(fmt.Sprintf("%s/orgs/%d/helm/policy", ts.URL, 1))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body bytes.Buffer
+	_, err = body.ReadFrom(resp.Body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/orgs/%d/helm/policy", ts.URL, 1), &body)
+	require.NoError(t, err)
+
+	resp, err = ts.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, UpdateChartPolicyRequest{OrganizationID: 1, Policy: policy}, updateRequest, "the policy survives a round trip")
+}
@@ -85,18 +85,25 @@ func MakeGetReleasesRestAPIEndpoint(service helm.RestAPI) endpoint.Endpoint {
 // single parameter.
 type Endpoints struct {
 	AddRepository       endpoint.Endpoint
+	CheckChartPolicy    endpoint.Endpoint
 	CheckRelease        endpoint.Endpoint
 	CheckReleases       endpoint.Endpoint
+	DeleteChartPolicy   endpoint.Endpoint
 	DeleteRelease       endpoint.Endpoint
 	DeleteRepository    endpoint.Endpoint
+	DiffRelease         endpoint.Endpoint
 	GetChart            endpoint.Endpoint
+	GetChartPolicy      endpoint.Endpoint
 	GetRelease          endpoint.Endpoint
+	GetReleaseHistory   endpoint.Endpoint
 	GetReleaseResources endpoint.Endpoint
 	InstallRelease      endpoint.Endpoint
 	ListCharts          endpoint.Endpoint
 	ListReleases        endpoint.Endpoint
 	ListRepositories    endpoint.Endpoint
 	ModifyRepository    endpoint.Endpoint
+	RollbackRelease     endpoint.Endpoint
+	UpdateChartPolicy   endpoint.Endpoint
 	UpdateRepository    endpoint.Endpoint
 	UpgradeRelease      endpoint.Endpoint
 }
@@ -108,18 +115,25 @@ func MakeEndpoints(service helm.Service, middleware ...endpoint.Middleware) Endp
 
 	return Endpoints{
 		AddRepository:       kitxendpoint.OperationNameMiddleware("helm.AddRepository")(mw(MakeAddRepositoryEndpoint(service))),
+		CheckChartPolicy:    kitxendpoint.OperationNameMiddleware("helm.CheckChartPolicy")(mw(MakeCheckChartPolicyEndpoint(service))),
 		CheckRelease:        kitxendpoint.OperationNameMiddleware("helm.CheckRelease")(mw(MakeCheckReleaseEndpoint(service))),
 		CheckReleases:       kitxendpoint.OperationNameMiddleware("helm.CheckReleases")(mw(MakeCheckReleasesEndpoint(service))),
+		DeleteChartPolicy:   kitxendpoint.OperationNameMiddleware("helm.DeleteChartPolicy")(mw(MakeDeleteChartPolicyEndpoint(service))),
 		DeleteRelease:       kitxendpoint.OperationNameMiddleware("helm.DeleteRelease")(mw(MakeDeleteReleaseEndpoint(service))),
 		DeleteRepository:    kitxendpoint.OperationNameMiddleware("helm.DeleteRepository")(mw(MakeDeleteRepositoryEndpoint(service))),
+		DiffRelease:         kitxendpoint.OperationNameMiddleware("helm.DiffRelease")(mw(MakeDiffReleaseEndpoint(service))),
 		GetChart:            kitxendpoint.OperationNameMiddleware("helm.GetChart")(mw(MakeGetChartEndpoint(service))),
+		GetChartPolicy:      kitxendpoint.OperationNameMiddleware("helm.GetChartPolicy")(mw(MakeGetChartPolicyEndpoint(service))),
 		GetRelease:          kitxendpoint.OperationNameMiddleware("helm.GetRelease")(mw(MakeGetReleaseEndpoint(service))),
+		GetReleaseHistory:   kitxendpoint.OperationNameMiddleware("helm.GetReleaseHistory")(mw(MakeGetReleaseHistoryEndpoint(service))),
 		GetReleaseResources: kitxendpoint.OperationNameMiddleware("helm.GetReleaseResources")(mw(MakeGetReleaseResourcesEndpoint(service))),
 		InstallRelease:      kitxendpoint.OperationNameMiddleware("helm.InstallRelease")(mw(MakeInstallReleaseEndpoint(service))),
 		ListCharts:          kitxendpoint.OperationNameMiddleware("helm.ListCharts")(mw(MakeListChartsEndpoint(service))),
 		ListReleases:        kitxendpoint.OperationNameMiddleware("helm.ListReleases")(mw(MakeListReleasesEndpoint(service))),
 		ListRepositories:    kitxendpoint.OperationNameMiddleware("helm.ListRepositories")(mw(MakeListRepositoriesEndpoint(service))),
 		ModifyRepository:    kitxendpoint.OperationNameMiddleware("helm.ModifyRepository")(mw(MakeModifyRepositoryEndpoint(service))),
+		RollbackRelease:     kitxendpoint.OperationNameMiddleware("helm.RollbackRelease")(mw(MakeRollbackReleaseEndpoint(service))),
+		UpdateChartPolicy:   kitxendpoint.OperationNameMiddleware("helm.UpdateChartPolicy")(mw(MakeUpdateChartPolicyEndpoint(service))),
 		UpdateRepository:    kitxendpoint.OperationNameMiddleware("helm.UpdateRepository")(mw(MakeUpdateRepositoryEndpoint(service))),
 		UpgradeRelease:      kitxendpoint.OperationNameMiddleware("helm.UpgradeRelease")(mw(MakeUpgradeReleaseEndpoint(service))),
 	}
@@ -159,6 +173,40 @@ func MakeAddRepositoryEndpoint(service helm.Service) endpoint.Endpoint {
 	}
 }
 
+// CheckChartPolicyRequest is a request struct for CheckChartPolicy endpoint.
+type CheckChartPolicyRequest struct {
+	OrganizationID uint
+	ReleaseInput   helm.Release
+}
+
+// CheckChartPolicyResponse is a response struct for CheckChartPolicy endpoint.
+type CheckChartPolicyResponse struct {
+	Err error
+}
+
+func (r CheckChartPolicyResponse) Failed() error {
+	return r.Err
+}
+
+// MakeCheckChartPolicyEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeCheckChartPolicyEndpoint(service helm.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CheckChartPolicyRequest)
+
+		err := service.CheckChartPolicy(ctx, req.OrganizationID, req.ReleaseInput)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return CheckChartPolicyResponse{Err: err}, nil
+			}
+
+			return CheckChartPolicyResponse{Err: err}, err
+		}
+
+		return CheckChartPolicyResponse{}, nil
+	}
+}
+
 // CheckReleaseRequest is a request struct for CheckRelease endpoint.
 type CheckReleaseRequest struct {
 	OrganizationID uint
@@ -243,6 +291,39 @@ func MakeCheckReleasesEndpoint(service helm.Service) endpoint.Endpoint {
 	}
 }
 
+// DeleteChartPolicyRequest is a request struct for DeleteChartPolicy endpoint.
+type DeleteChartPolicyRequest struct {
+	OrganizationID uint
+}
+
+// DeleteChartPolicyResponse is a response struct for DeleteChartPolicy endpoint.
+type DeleteChartPolicyResponse struct {
+	Err error
+}
+
+func (r DeleteChartPolicyResponse) Failed() error {
+	return r.Err
+}
+
+// MakeDeleteChartPolicyEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeDeleteChartPolicyEndpoint(service helm.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeleteChartPolicyRequest)
+
+		err := service.DeleteChartPolicy(ctx, req.OrganizationID)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return DeleteChartPolicyResponse{Err: err}, nil
+			}
+
+			return DeleteChartPolicyResponse{Err: err}, err
+		}
+
+		return DeleteChartPolicyResponse{}, nil
+	}
+}
+
 // DeleteReleaseRequest is a request struct for DeleteRelease endpoint.
 type DeleteReleaseRequest struct {
 	OrganizationID uint
@@ -313,6 +394,49 @@ func MakeDeleteRepositoryEndpoint(service helm.Service) endpoint.Endpoint {
 	}
 }
 
+// DiffReleaseRequest is a request struct for DiffRelease endpoint.
+type DiffReleaseRequest struct {
+	OrganizationID uint
+	ClusterID      uint
+	ReleaseInput   helm.Release
+	Options        helm.Options
+}
+
+// DiffReleaseResponse is a response struct for DiffRelease endpoint.
+type DiffReleaseResponse struct {
+	R0  helm.ReleaseDiff
+	Err error
+}
+
+func (r DiffReleaseResponse) Failed() error {
+	return r.Err
+}
+
+// MakeDiffReleaseEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeDiffReleaseEndpoint(service helm.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DiffReleaseRequest)
+
+		r0, err := service.DiffRelease(ctx, req.OrganizationID, req.ClusterID, req.ReleaseInput, req.Options)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return DiffReleaseResponse{
+					Err: err,
+					R0:  r0,
+				}, nil
+			}
+
+			return DiffReleaseResponse{
+				Err: err,
+				R0:  r0,
+			}, err
+		}
+
+		return DiffReleaseResponse{R0: r0}, nil
+	}
+}
+
 // GetChartRequest is a request struct for GetChart endpoint.
 type GetChartRequest struct {
 	OrganizationID uint
@@ -355,6 +479,46 @@ func MakeGetChartEndpoint(service helm.Service) endpoint.Endpoint {
 	}
 }
 
+// GetChartPolicyRequest is a request struct for GetChartPolicy endpoint.
+type GetChartPolicyRequest struct {
+	OrganizationID uint
+}
+
+// GetChartPolicyResponse is a response struct for GetChartPolicy endpoint.
+type GetChartPolicyResponse struct {
+	R0  helm.ChartPolicy
+	Err error
+}
+
+func (r GetChartPolicyResponse) Failed() error {
+	return r.Err
+}
+
+// MakeGetChartPolicyEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeGetChartPolicyEndpoint(service helm.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetChartPolicyRequest)
+
+		r0, err := service.GetChartPolicy(ctx, req.OrganizationID)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return GetChartPolicyResponse{
+					Err: err,
+					R0:  r0,
+				}, nil
+			}
+
+			return GetChartPolicyResponse{
+				Err: err,
+				R0:  r0,
+			}, err
+		}
+
+		return GetChartPolicyResponse{R0: r0}, nil
+	}
+}
+
 // GetReleaseRequest is a request struct for GetRelease endpoint.
 type GetReleaseRequest struct {
 	OrganizationID uint
@@ -398,6 +562,49 @@ func MakeGetReleaseEndpoint(service helm.Service) endpoint.Endpoint {
 	}
 }
 
+// GetReleaseHistoryRequest is a request struct for GetReleaseHistory endpoint.
+type GetReleaseHistoryRequest struct {
+	OrganizationID uint
+	ClusterID      uint
+	ReleaseName    string
+	Options        helm.Options
+}
+
+// GetReleaseHistoryResponse is a response struct for GetReleaseHistory endpoint.
+type GetReleaseHistoryResponse struct {
+	R0  []helm.ReleaseRevision
+	Err error
+}
+
+func (r GetReleaseHistoryResponse) Failed() error {
+	return r.Err
+}
+
+// MakeGetReleaseHistoryEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeGetReleaseHistoryEndpoint(service helm.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetReleaseHistoryRequest)
+
+		r0, err := service.GetReleaseHistory(ctx, req.OrganizationID, req.ClusterID, req.ReleaseName, req.Options)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return GetReleaseHistoryResponse{
+					Err: err,
+					R0:  r0,
+				}, nil
+			}
+
+			return GetReleaseHistoryResponse{
+				Err: err,
+				R0:  r0,
+			}, err
+		}
+
+		return GetReleaseHistoryResponse{R0: r0}, nil
+	}
+}
+
 // GetReleaseResourcesRequest is a request struct for GetReleaseResources endpoint.
 type GetReleaseResourcesRequest struct {
 	OrganizationID uint
@@ -643,6 +850,84 @@ func MakeModifyRepositoryEndpoint(service helm.Service) endpoint.Endpoint {
 	}
 }
 
+// RollbackReleaseRequest is a request struct for RollbackRelease endpoint.
+type RollbackReleaseRequest struct {
+	OrganizationID uint
+	ClusterID      uint
+	ReleaseName    string
+	Revision       int32
+	Options        helm.Options
+}
+
+// RollbackReleaseResponse is a response struct for RollbackRelease endpoint.
+type RollbackReleaseResponse struct {
+	R0  helm.Release
+	Err error
+}
+
+func (r RollbackReleaseResponse) Failed() error {
+	return r.Err
+}
+
+// MakeRollbackReleaseEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeRollbackReleaseEndpoint(service helm.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RollbackReleaseRequest)
+
+		r0, err := service.RollbackRelease(ctx, req.OrganizationID, req.ClusterID, req.ReleaseName, req.Revision, req.Options)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return RollbackReleaseResponse{
+					Err: err,
+					R0:  r0,
+				}, nil
+			}
+
+			return RollbackReleaseResponse{
+				Err: err,
+				R0:  r0,
+			}, err
+		}
+
+		return RollbackReleaseResponse{R0: r0}, nil
+	}
+}
+
+// UpdateChartPolicyRequest is a request struct for UpdateChartPolicy endpoint.
+type UpdateChartPolicyRequest struct {
+	OrganizationID uint
+	Policy         helm.ChartPolicy
+}
+
+// UpdateChartPolicyResponse is a response struct for UpdateChartPolicy endpoint.
+type UpdateChartPolicyResponse struct {
+	Err error
+}
+
+func (r UpdateChartPolicyResponse) Failed() error {
+	return r.Err
+}
+
+// MakeUpdateChartPolicyEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeUpdateChartPolicyEndpoint(service helm.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UpdateChartPolicyRequest)
+
+		err := service.UpdateChartPolicy(ctx, req.OrganizationID, req.Policy)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return UpdateChartPolicyResponse{Err: err}, nil
+			}
+
+			return UpdateChartPolicyResponse{Err: err}, err
+		}
+
+		return UpdateChartPolicyResponse{}, nil
+	}
+}
+
 // UpdateRepositoryRequest is a request struct for UpdateRepository endpoint.
 type UpdateRepositoryRequest struct {
 	OrganizationID uint
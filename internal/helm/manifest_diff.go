@@ -0,0 +1,248 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+)
+
+// Resource change types reported by release diffs
+const (
+	ResourceAdded   = "added"
+	ResourceChanged = "changed"
+	ResourceRemoved = "removed"
+)
+
+// ReleaseDiff describes the changes an upgrade would make to a deployed release
+type ReleaseDiff struct {
+	// ReleaseName is the name of the compared release
+	ReleaseName string `json:"releaseName"`
+	// Namespace is the namespace of the compared release
+	Namespace string `json:"namespace"`
+	// CurrentRevision is the deployed revision the target is compared to
+	CurrentRevision int32 `json:"currentRevision"`
+	// CurrentChartVersion is the chart version of the deployed revision
+	CurrentChartVersion string `json:"currentChartVersion"`
+	// TargetChartVersion is the chart version the release would be upgraded to
+	TargetChartVersion string `json:"targetChartVersion"`
+	// Resources lists the resources that would be added, changed or removed; unchanged resources are omitted
+	Resources []ResourceDiff `json:"resources"`
+}
+
+// ResourceDiff describes the change of a single resource in a release manifest
+type ResourceDiff struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Change is one of added, changed or removed
+	Change string `json:"change"`
+	// Diff is the unified diff of the resource manifest (with Secret values redacted)
+	Diff string `json:"diff"`
+	// ChangedSecretKeys lists the Secret data keys that would be added, changed or removed
+	ChangedSecretKeys []string `json:"changedSecretKeys,omitempty"`
+}
+
+var manifestSeparatorRegexp = regexp.MustCompile(`(?m)^---\s*$`)
+
+// redactedSecretValue replaces Secret values in diffs, so that they never leave the cluster
+const redactedSecretValue = "(redacted)"
+
+type manifestResource struct {
+	kind      string
+	name      string
+	namespace string
+	content   string
+
+	// secretValues holds the redacted values of Secret resources keyed by their data key
+	secretValues map[string]string
+}
+
+func (r manifestResource) key() string {
+	return fmt.Sprintf("%s/%s/%s", r.kind, r.namespace, r.name)
+}
+
+// DiffManifests compares two rendered release manifests resource by resource
+// Resources are identified by kind, namespace and name, and compared in a normalized form, so that formatting
+// and key order differences are not reported.
+func DiffManifests(current string, target string) ([]ResourceDiff, error) {
+	currentResources, err := parseManifest(current)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to parse current manifest")
+	}
+
+	targetResources, err := parseManifest(target)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to parse target manifest")
+	}
+
+	var diffs []ResourceDiff
+
+	for key, targetResource := range targetResources {
+		currentResource, ok := currentResources[key]
+		if !ok {
+			diffs = append(diffs, newResourceDiff(targetResource, ResourceAdded, manifestResource{}, targetResource))
+			continue
+		}
+
+		diff := newResourceDiff(targetResource, ResourceChanged, currentResource, targetResource)
+		if currentResource.content != targetResource.content || len(diff.ChangedSecretKeys) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	for key, currentResource := range currentResources {
+		if _, ok := targetResources[key]; !ok {
+			diffs = append(diffs, newResourceDiff(currentResource, ResourceRemoved, currentResource, manifestResource{}))
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Kind != diffs[j].Kind {
+			return diffs[i].Kind < diffs[j].Kind
+		}
+
+		if diffs[i].Namespace != diffs[j].Namespace {
+			return diffs[i].Namespace < diffs[j].Namespace
+		}
+
+		return diffs[i].Name < diffs[j].Name
+	})
+
+	return diffs, nil
+}
+
+func newResourceDiff(resource manifestResource, change string, current manifestResource, target manifestResource) ResourceDiff {
+	// the inputs are plain strings, so the diff cannot fail
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(current.content),
+		B:        difflib.SplitLines(target.content),
+		FromFile: "current",
+		ToFile:   "target",
+		Context:  3,
+	})
+
+	return ResourceDiff{
+		Kind:              resource.kind,
+		Name:              resource.name,
+		Namespace:         resource.namespace,
+		Change:            change,
+		Diff:              diff,
+		ChangedSecretKeys: changedSecretKeys(current.secretValues, target.secretValues),
+	}
+}
+
+// changedSecretKeys compares Secret values without exposing them
+func changedSecretKeys(current map[string]string, target map[string]string) []string {
+	var keys []string
+
+	for key, value := range target {
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			keys = append(keys, key)
+		}
+	}
+
+	for key := range current {
+		if _, ok := target[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// redactSecret replaces the values of a Secret resource with a placeholder and returns the original values
+func redactSecret(content interface{}) map[string]string {
+	object, ok := content.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	values := make(map[string]string)
+
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := object[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for key, value := range data {
+			values[field+"."+key] = fmt.Sprint(value)
+			data[key] = redactedSecretValue
+		}
+	}
+
+	return values
+}
+
+// parseManifest splits a multi-document manifest into resources keyed by their identity
+func parseManifest(manifest string) (map[string]manifestResource, error) {
+	resources := make(map[string]manifestResource)
+
+	for _, document := range manifestSeparatorRegexp.Split(manifest, -1) {
+		var object struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+
+		if err := yaml.Unmarshal([]byte(document), &object); err != nil {
+			return nil, errors.WrapIf(err, "failed to decode manifest document")
+		}
+
+		// empty documents (eg. templates rendering nothing)
+		if object.Kind == "" {
+			continue
+		}
+
+		var content interface{}
+		if err := yaml.Unmarshal([]byte(document), &content); err != nil {
+			return nil, errors.WrapIf(err, "failed to decode manifest document")
+		}
+
+		var secretValues map[string]string
+		if object.Kind == "Secret" {
+			secretValues = redactSecret(content)
+		}
+
+		normalized, err := yaml.Marshal(content)
+		if err != nil {
+			return nil, errors.WrapIf(err, "failed to normalize manifest document")
+		}
+
+		resource := manifestResource{
+			kind:      object.Kind,
+			name:      object.Metadata.Name,
+			namespace: object.Metadata.Namespace,
+			content:   strings.TrimSpace(string(normalized)) + "\n",
+
+			secretValues: secretValues,
+		}
+
+		resources[resource.key()] = resource
+	}
+
+	return resources, nil
+}
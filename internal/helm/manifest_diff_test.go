@@ -0,0 +1,172 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffManifests(t *testing.T) {
+	current := `---
+# Source: app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+spec:
+  ports:
+    - port: 80
+---
+# Source: app/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+data:
+  key: value
+---
+# Source: app/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+  namespace: default
+`
+
+	target := `---
+# Source: app/templates/service.yaml
+kind: Service
+apiVersion: v1
+metadata:
+  name: app
+spec:
+  ports:
+  - port: 80
+---
+# Source: app/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+data:
+  key: other
+---
+# Source: app/templates/empty.yaml
+---
+# Source: app/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`
+
+	diffs, err := DiffManifests(current, target)
+	require.NoError(t, err)
+	require.Len(t, diffs, 3)
+
+	assert.Equal(t, "ConfigMap", diffs[0].Kind)
+	assert.Equal(t, ResourceChanged, diffs[0].Change)
+	assert.Contains(t, diffs[0].Diff, "-  key: value\n+  key: other\n")
+
+	assert.Equal(t, "Deployment", diffs[1].Kind)
+	assert.Equal(t, ResourceAdded, diffs[1].Change)
+	assert.Contains(t, diffs[1].Diff, "+kind: Deployment\n")
+
+	assert.Equal(t, "Secret", diffs[2].Kind)
+	assert.Equal(t, "default", diffs[2].Namespace)
+	assert.Equal(t, ResourceRemoved, diffs[2].Change)
+	assert.Contains(t, diffs[2].Diff, "-kind: Secret\n")
+}
+
+func TestDiffManifests_RedactsSecrets(t *testing.T) {
+	current := `apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+data:
+  password: Y3VycmVudA==
+  username: YWRtaW4=
+  token: dG9rZW4=
+`
+
+	target := `apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+data:
+  password: dGFyZ2V0
+  username: YWRtaW4=
+stringData:
+  apiKey: plaintext-key
+`
+
+	diffs, err := DiffManifests(current, target)
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+
+	assert.Equal(t, ResourceChanged, diffs[0].Change)
+	assert.Equal(t, []string{"data.password", "data.token", "stringData.apiKey"}, diffs[0].ChangedSecretKeys)
+
+	for _, value := range []string{"Y3VycmVudA==", "dGFyZ2V0", "YWRtaW4=", "dG9rZW4=", "plaintext-key"} {
+		assert.NotContains(t, diffs[0].Diff, value)
+	}
+
+	assert.Contains(t, diffs[0].Diff, "-  token: (redacted)\n")
+}
+
+func TestDiffManifests_SecretValueChangeOnly(t *testing.T) {
+	current := `apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+data:
+  password: Y3VycmVudA==
+`
+
+	target := `apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+data:
+  password: dGFyZ2V0
+`
+
+	diffs, err := DiffManifests(current, target)
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+
+	assert.Equal(t, []string{"data.password"}, diffs[0].ChangedSecretKeys)
+	assert.Empty(t, diffs[0].Diff)
+}
+
+func TestDiffManifests_Unchanged(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+`
+
+	diffs, err := DiffManifests(manifest, manifest)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+}
+
+func TestDiffManifests_Invalid(t *testing.T) {
+	_, err := DiffManifests("kind: [", "")
+	assert.Error(t, err)
+}
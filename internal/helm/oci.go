@@ -0,0 +1,104 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+// OCIScheme is the URL scheme of chart references pointing to OCI registries.
+const OCIScheme = "oci"
+
+const ociPrefix = OCIScheme + "://"
+
+// IsOCIReference tells whether the given chart (or repository) reference points to an OCI registry.
+func IsOCIReference(ref string) bool {
+	return strings.HasPrefix(ref, ociPrefix)
+}
+
+// OCIReference identifies a chart stored in an OCI registry (eg. oci://registry.example.com/charts/mychart:1.0.0).
+type OCIReference struct {
+	// Registry is the host (and optionally the port) of the registry
+	Registry string
+
+	// Repository is the path of the chart within the registry
+	Repository string
+
+	// Tag is the chart version; it can be empty when the version is specified separately
+	Tag string
+}
+
+// ParseOCIReference parses an oci:// chart reference.
+func ParseOCIReference(ref string) (OCIReference, error) {
+	if !IsOCIReference(ref) {
+		return OCIReference{}, errors.NewWithDetails("not an OCI reference", "reference", ref)
+	}
+
+	rest := strings.TrimPrefix(ref, ociPrefix)
+
+	slash := strings.Index(rest, "/")
+	if slash <= 0 || slash == len(rest)-1 {
+		return OCIReference{}, errors.NewWithDetails("OCI reference must contain a registry and a repository", "reference", ref)
+	}
+
+	parsed := OCIReference{
+		Registry:   rest[:slash],
+		Repository: rest[slash+1:],
+	}
+
+	// the tag separator must come after the last path segment separator (registry hosts can contain ports)
+	if colon := strings.LastIndex(parsed.Repository, ":"); colon > strings.LastIndex(parsed.Repository, "/") {
+		parsed.Tag = parsed.Repository[colon+1:]
+		parsed.Repository = parsed.Repository[:colon]
+
+		if parsed.Tag == "" {
+			return OCIReference{}, errors.NewWithDetails("OCI reference contains an empty tag", "reference", ref)
+		}
+	}
+
+	if strings.Contains(parsed.Repository, "@") {
+		return OCIReference{}, errors.NewWithDetails("OCI references with digests are not supported", "reference", ref)
+	}
+
+	if parsed.Repository != strings.ToLower(parsed.Repository) {
+		return OCIReference{}, errors.NewWithDetails("OCI repository names must be lowercase", "reference", ref)
+	}
+
+	return parsed, nil
+}
+
+// ChartName returns the name of the chart (the last segment of the repository path).
+func (r OCIReference) ChartName() string {
+	return r.Repository[strings.LastIndex(r.Repository, "/")+1:]
+}
+
+// WithTag returns a copy of the reference with the given tag.
+func (r OCIReference) WithTag(tag string) OCIReference {
+	r.Tag = tag
+
+	return r
+}
+
+// String returns the oci:// form of the reference.
+func (r OCIReference) String() string {
+	if r.Tag == "" {
+		return fmt.Sprintf("%s%s/%s", ociPrefix, r.Registry, r.Repository)
+	}
+
+	return fmt.Sprintf("%s%s/%s:%s", ociPrefix, r.Registry, r.Repository, r.Tag)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		ref       string
+		expected  OCIReference
+		chartName string
+		wantErr   bool
+	}{
+		{
+			ref:       "oci://registry.example.com/charts/mychart:1.2.3",
+			expected:  OCIReference{Registry: "registry.example.com", Repository: "charts/mychart", Tag: "1.2.3"},
+			chartName: "mychart",
+		},
+		{
+			ref:       "oci://localhost:5000/mychart",
+			expected:  OCIReference{Registry: "localhost:5000", Repository: "mychart"},
+			chartName: "mychart",
+		},
+		{
+			ref:       "oci://localhost:5000/org/team/mychart:0.1.0-rc.1",
+			expected:  OCIReference{Registry: "localhost:5000", Repository: "org/team/mychart", Tag: "0.1.0-rc.1"},
+			chartName: "mychart",
+		},
+		{ref: "https://example.com/charts", wantErr: true},
+		{ref: "oci://registry.example.com", wantErr: true},
+		{ref: "oci://registry.example.com/", wantErr: true},
+		{ref: "oci://registry.example.com/mychart:", wantErr: true},
+		{ref: "oci://registry.example.com/mychart@sha256:abcd", wantErr: true},
+		{ref: "oci://registry.example.com/MyChart", wantErr: true},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.ref, func(t *testing.T) {
+			ref, err := ParseOCIReference(test.ref)
+			if test.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ref)
+			assert.Equal(t, test.chartName, ref.ChartName())
+			assert.Equal(t, test.ref, ref.String())
+		})
+	}
+}
+
+func TestIsOCIReference(t *testing.T) {
+	assert.True(t, IsOCIReference("oci://registry.example.com/mychart"))
+	assert.False(t, IsOCIReference("stable/mysql"))
+	assert.False(t, IsOCIReference("https://kubernetes-charts.banzaicloud.com"))
+}
@@ -38,6 +38,8 @@ type ReleaseInfo struct {
 	Notes string
 	// Contains override values provided to the release
 	Values map[string]interface{}
+	// Contains the rendered manifest of the release
+	Manifest string `json:"-"`
 }
 
 type ReleaseResource struct {
@@ -58,6 +60,30 @@ type Release struct {
 	ReleaseResources []ReleaseResource
 }
 
+// ReleaseRevision describes a single revision in the history of a release
+type ReleaseRevision struct {
+	// Revision is the sequence number of the revision
+	Revision int32 `json:"revision"`
+	// ChartName is the name of the chart deployed by the revision
+	ChartName string `json:"chartName"`
+	// ChartVersion is the version of the chart deployed by the revision
+	ChartVersion string `json:"chartVersion"`
+	// AppVersion is the application version of the chart deployed by the revision
+	AppVersion string `json:"appVersion,omitempty"`
+	// Status is the state of the revision
+	Status string `json:"status"`
+	// Description is human-friendly "log entry" about the revision
+	Description string `json:"description,omitempty"`
+	// ValuesDigest is the digest of the override values provided to the revision
+	ValuesDigest string `json:"valuesDigest"`
+	// FirstDeployed is when the release was first deployed.
+	FirstDeployed time.Time `json:"firstDeployed,omitempty"`
+	// LastDeployed is when the revision was deployed.
+	LastDeployed time.Time `json:"lastDeployed,omitempty"`
+	// Deleted tracks when the revision was deleted.
+	Deleted time.Time `json:"deleted,omitempty"`
+}
+
 type KubeConfigBytes = []byte
 
 // ReleaseFilter struct for release filter data
@@ -84,6 +110,12 @@ type releaser interface {
 	CheckRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (string, error)
 	// ReleaseResources retrieves resources belonging to the release
 	GetReleaseResources(ctx context.Context, organizationID uint, clusterID uint, release Release, options Options) ([]ReleaseResource, error)
+	// GetReleaseHistory retrieves the revisions of the given release, the latest one last
+	GetReleaseHistory(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) ([]ReleaseRevision, error)
+	// RollbackRelease rolls the given release back to the specified revision
+	RollbackRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, revision int32, options Options) (Release, error)
+	// DiffRelease compares the deployed release to the result of upgrading it with the given chart and values
+	DiffRelease(ctx context.Context, organizationID uint, clusterID uint, releaseInput Release, options Options) (ReleaseDiff, error)
 }
 
 // utility for providing input arguments ...
@@ -94,6 +126,8 @@ func (ri Release) NameAndChartSlice() []string {
 	return []string{ri.ReleaseName, ri.ChartName}
 }
 
+// +testify:mock:testOnly=true
+
 // Releaser interface collecting operations related to releases
 // It manages releases on the cluster
 type Releaser interface {
@@ -109,6 +143,14 @@ type Releaser interface {
 	Upgrade(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseInput Release, options Options) (release Release, err error)
 	// Resources retrieves the kubernetes resources belonging to the release
 	Resources(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseInput Release, options Options) ([]ReleaseResource, error)
+	// History retrieves the revisions of the release, the latest one last
+	History(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseName string, options Options) ([]ReleaseRevision, error)
+	// Rollback rolls the release back to the given revision; the previous revision is used when revision is 0
+	Rollback(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseName string, revision int32, options Options) (Release, error)
+	// Diff renders the upgrade of the release without applying it and compares the result to the deployed release
+	Diff(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseInput Release, options Options) (ReleaseDiff, error)
+	// Verify waits for the resources of the release to become ready and runs the release tests as configured by options.Verify
+	Verify(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseName string, options Options) error
 }
 
 func ErrReleaseNotFound(err error) bool {
@@ -105,6 +105,7 @@ func getHelmFacade(t *testing.T) helm.Service {
 	return helm.NewService(
 		helmConfig,
 		repoStore,
+		helmadapter.NewChartPolicyStore(db, logger),
 		secretStore,
 		helm.NewHelmRepoValidator(),
 		ensuringEnvResolver,
@@ -58,6 +58,10 @@ type Options struct {
 	Install      bool    `json:"install,omitempty"`
 	Filter       *string `json:"filter,omitempty"`
 	SkipCRDs     bool    `json:"skipCRDs,omitempty"`
+	// Verify enables the health verification (and automatic rollback) of the release after install or upgrade
+	Verify *VerifyOptions `json:"verify,omitempty"`
+	// ProvenanceKeyring is the keyring the provenance of the chart is verified against (set by the chart policy)
+	ProvenanceKeyring string `json:"-"`
 }
 
 // +kit:endpoint:errorStrategy=service
@@ -73,6 +77,9 @@ type Service interface {
 
 	// chart related operations
 	charter
+
+	// chart policy management operations
+	chartPolicy
 }
 
 type ClusterDataProvider interface {
@@ -199,9 +206,9 @@ type PasswordSecret struct {
 }
 
 type TlsSecret struct {
-	CAFile   string
-	CertFile string
-	KeyFile  string
+	CACert     string `mapstructure:"caCert"`
+	ClientCert string `mapstructure:"clientCert"`
+	ClientKey  string `mapstructure:"clientKey"`
 }
 
 // +testify:mock:testOnly=true
@@ -231,21 +238,23 @@ func (c ClusterKubeConfigFunc) GetKubeConfig(ctx context.Context, clusterID uint
 }
 
 type service struct {
-	config         Config
-	store          Store
-	secretStore    SecretStore
-	repoValidator  RepoValidator
-	envResolver    EnvResolver
-	envService     EnvService
-	releaser       Releaser
-	clusterService ClusterService
-	logger         Logger
+	config           Config
+	store            Store
+	chartPolicyStore ChartPolicyStore
+	secretStore      SecretStore
+	repoValidator    RepoValidator
+	envResolver      EnvResolver
+	envService       EnvService
+	releaser         Releaser
+	clusterService   ClusterService
+	logger           Logger
 }
 
 // NewService returns a new Service.
 func NewService(
 	config Config,
 	store Store,
+	chartPolicyStore ChartPolicyStore,
 	secretStore SecretStore,
 	validator RepoValidator,
 	envResolver EnvResolver,
@@ -254,15 +263,16 @@ func NewService(
 	clusterService ClusterService,
 	logger Logger) Service {
 	return service{
-		config:         config,
-		store:          store,
-		secretStore:    secretStore,
-		repoValidator:  validator,
-		envResolver:    envResolver,
-		envService:     envService,
-		releaser:       releaser,
-		clusterService: clusterService,
-		logger:         logger,
+		config:           config,
+		store:            store,
+		chartPolicyStore: chartPolicyStore,
+		secretStore:      secretStore,
+		repoValidator:    validator,
+		envResolver:      envResolver,
+		envService:       envService,
+		releaser:         releaser,
+		clusterService:   clusterService,
+		logger:           logger,
 	}
 }
 
@@ -445,6 +455,11 @@ func (s service) UpdateRepository(ctx context.Context, organizationID uint, repo
 }
 
 func (s service) InstallRelease(ctx context.Context, organizationID uint, clusterID uint, releaseInput Release, options Options) (release Release, err error) {
+	options, err = s.applyChartPolicy(ctx, organizationID, releaseInput, options)
+	if err != nil {
+		return release, err
+	}
+
 	helmEnv, err := s.envResolver.ResolveHelmEnv(ctx, organizationID)
 	if err != nil {
 		return release, errors.WrapIf(err, "failed to set up helm repository environment")
@@ -460,6 +475,10 @@ func (s service) InstallRelease(ctx context.Context, organizationID uint, cluste
 		return Release{}, errors.WrapIf(err, "failed to install release")
 	}
 
+	if err := s.verifyRelease(ctx, helmEnv, kubeKonfig, release, options); err != nil {
+		return release, err
+	}
+
 	return release, nil
 }
 
@@ -524,6 +543,11 @@ func (s service) GetRelease(ctx context.Context, organizationID uint, clusterID
 }
 
 func (s service) UpgradeRelease(ctx context.Context, organizationID uint, clusterID uint, releaseInput Release, options Options) (Release, error) {
+	options, err := s.applyChartPolicy(ctx, organizationID, releaseInput, options)
+	if err != nil {
+		return Release{}, err
+	}
+
 	helmEnv, err := s.envResolver.ResolveHelmEnv(ctx, organizationID)
 	if err != nil {
 		return Release{}, errors.WrapIf(err, "failed to set up helm repository environment")
@@ -539,6 +563,10 @@ func (s service) UpgradeRelease(ctx context.Context, organizationID uint, cluste
 		return Release{}, errors.WrapIfWithDetails(err, "failed to upgrade release", "releaseName", releaseInput.ReleaseName)
 	}
 
+	if err := s.verifyRelease(ctx, helmEnv, kubeKonfig, release, options); err != nil {
+		return release, err
+	}
+
 	return release, nil
 }
 
@@ -596,6 +624,77 @@ func (s service) GetReleaseResources(ctx context.Context, organizationID uint, c
 	return resources, nil
 }
 
+func (s service) GetReleaseHistory(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) ([]ReleaseRevision, error) {
+	helmEnv, err := s.envResolver.ResolveHelmEnv(ctx, organizationID)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to set up helm repository environment")
+	}
+
+	kubeKonfig, err := s.clusterService.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to get cluster configuration")
+	}
+
+	revisions, err := s.releaser.History(ctx, helmEnv, kubeKonfig, releaseName, options)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to retrieve release history", "releaseName", releaseName)
+	}
+
+	return revisions, nil
+}
+
+func (s service) RollbackRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, revision int32, options Options) (Release, error) {
+	if revision < 0 {
+		return Release{}, errors.WithStack(NewValidationError("invalid rollback request", []string{"revision must not be negative"}))
+	}
+
+	helmEnv, err := s.envResolver.ResolveHelmEnv(ctx, organizationID)
+	if err != nil {
+		return Release{}, errors.WrapIf(err, "failed to set up helm repository environment")
+	}
+
+	kubeKonfig, err := s.clusterService.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return Release{}, errors.WrapIf(err, "failed to get cluster configuration")
+	}
+
+	if err := s.checkRollbackChartPolicy(ctx, organizationID, helmEnv, kubeKonfig, releaseName, revision, options); err != nil {
+		return Release{}, err
+	}
+
+	release, err := s.releaser.Rollback(ctx, helmEnv, kubeKonfig, releaseName, revision, options)
+	if err != nil {
+		return Release{}, errors.WrapIfWithDetails(err, "failed to roll back release", "releaseName", releaseName, "revision", revision)
+	}
+
+	s.logger.Info("release rolled back", map[string]interface{}{"orgID": organizationID, "clusterID": clusterID, "releaseName": releaseName, "revision": release.ReleaseVersion})
+
+	return release, nil
+}
+
+func (s service) DiffRelease(ctx context.Context, organizationID uint, clusterID uint, releaseInput Release, options Options) (ReleaseDiff, error) {
+	if releaseInput.ChartName == "" {
+		return ReleaseDiff{}, errors.WithStack(NewValidationError("invalid diff request", []string{"chart name must be specified"}))
+	}
+
+	helmEnv, err := s.envResolver.ResolveHelmEnv(ctx, organizationID)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIf(err, "failed to set up helm repository environment")
+	}
+
+	kubeKonfig, err := s.clusterService.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIf(err, "failed to get cluster configuration")
+	}
+
+	diff, err := s.releaser.Diff(ctx, helmEnv, kubeKonfig, releaseInput, options)
+	if err != nil {
+		return ReleaseDiff{}, errors.WrapIfWithDetails(err, "failed to diff release", "releaseName", releaseInput.ReleaseName)
+	}
+
+	return diff, nil
+}
+
 func (s service) CheckRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (string, error) {
 	release, err := s.GetRelease(ctx, organizationID, clusterID, releaseName, options)
 	if err != nil {
@@ -653,6 +752,7 @@ func (s service) decorateRepos(ctx context.Context, orgID uint, repos []Reposito
 		for _, persistedRepo := range persistedRepos {
 			if repo.Name == persistedRepo.Name {
 				repo.PasswordSecretID = persistedRepo.PasswordSecretID
+				repo.TlsSecretID = persistedRepo.TlsSecretID
 			}
 		}
 		decorated = append(decorated, repo)
@@ -15,6 +15,10 @@
 package helm
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	"emperror.dev/errors"
 	ghodss "github.com/ghodss/yaml"
 	"sigs.k8s.io/yaml"
@@ -44,3 +48,20 @@ func ConvertBytes(b []byte) (map[string]interface{}, error) {
 	}
 	return mapStringValues, nil
 }
+
+// ValuesDigest returns a digest of the given values that is independent of the order of the keys.
+func ValuesDigest(values map[string]interface{}) (string, error) {
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+
+	// JSON encoding sorts map keys, so equal values always result in the same output
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to marshal values")
+	}
+
+	sum := sha256.Sum256(b)
+
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
@@ -77,3 +77,48 @@ func TestFromValues(t *testing.T) {
 		}
 	}
 }
+
+func TestValuesDigest(t *testing.T) {
+	digest, err := ValuesDigest(map[string]interface{}{
+		"a": 1,
+		"b": map[string]interface{}{"c": "d", "e": true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sameDigest, err := ValuesDigest(map[string]interface{}{
+		"b": map[string]interface{}{"e": true, "c": "d"},
+		"a": 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if digest != sameDigest {
+		t.Errorf("digest should not depend on key order: %q != %q", digest, sameDigest)
+	}
+
+	otherDigest, err := ValuesDigest(map[string]interface{}{"a": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if digest == otherDigest {
+		t.Error("different values should result in a different digest")
+	}
+
+	nilDigest, err := ValuesDigest(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emptyDigest, err := ValuesDigest(map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nilDigest != emptyDigest {
+		t.Error("nil and empty values should result in the same digest")
+	}
+}
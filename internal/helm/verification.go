@@ -0,0 +1,134 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// defaultVerifyTimeout is the verification window used when none is specified
+const defaultVerifyTimeout = 5 * time.Minute
+
+// VerifyOptions configures the health verification of a release after it's installed or upgraded
+// A release failing the verification is rolled back to its previous revision (or uninstalled if it has none).
+type VerifyOptions struct {
+	// Timeout is the verification window in seconds the resources of the release have to become ready in
+	Timeout int64 `json:"timeout,omitempty"`
+	// RunTests runs the tests of the release (test hooks of the chart) once its resources are ready
+	RunTests bool `json:"runTests,omitempty"`
+}
+
+// TimeoutDuration returns the verification window
+func (o VerifyOptions) TimeoutDuration() time.Duration {
+	if o.Timeout <= 0 {
+		return defaultVerifyTimeout
+	}
+
+	return time.Duration(o.Timeout) * time.Second
+}
+
+// ReleaseVerificationError is returned when a release fails the health verification after install or upgrade.
+type ReleaseVerificationError struct {
+	ReleaseName string
+	// Reason describes why the verification failed
+	Reason string
+	// RolledBack tells whether the release was restored to its previous revision (or uninstalled if it had none)
+	RolledBack bool
+	// Revision is the revision the release was rolled back to; 0 means the release was uninstalled
+	Revision int32
+}
+
+// Error implements the error interface.
+func (e ReleaseVerificationError) Error() string {
+	msg := fmt.Sprintf("release %s failed verification: %s", e.ReleaseName, e.Reason)
+
+	switch {
+	case !e.RolledBack:
+		return msg + " (rollback failed)"
+	case e.Revision == 0:
+		return msg + " (release uninstalled)"
+	default:
+		return fmt.Sprintf("%s (rolled back to revision %d)", msg, e.Revision)
+	}
+}
+
+// Details returns error details.
+func (e ReleaseVerificationError) Details() []interface{} {
+	return []interface{}{"releaseName", e.ReleaseName, "rolledBack", e.RolledBack, "revision", e.Revision}
+}
+
+// ServiceError tells the consumer that this is a business error and it should be returned to the client.
+func (ReleaseVerificationError) ServiceError() bool {
+	return true
+}
+
+// IsReleaseVerificationError checks whether the error (or its cause) is a release verification error.
+func IsReleaseVerificationError(err error) (ReleaseVerificationError, bool) {
+	var verificationErr ReleaseVerificationError
+
+	return verificationErr, errors.As(err, &verificationErr)
+}
+
+// verifyRelease checks the health of the release if requested in the options and rolls it back if it's unhealthy
+func (s service) verifyRelease(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, release Release, options Options) error {
+	if options.Verify == nil || options.DryRun {
+		return nil
+	}
+
+	verifyErr := s.releaser.Verify(ctx, helmEnv, kubeConfig, release.ReleaseName, options)
+	if verifyErr == nil {
+		s.logger.Info("release verified", map[string]interface{}{"releaseName": release.ReleaseName, "revision": release.ReleaseVersion})
+
+		return nil
+	}
+
+	s.logger.Warn("release failed verification, rolling back", map[string]interface{}{"releaseName": release.ReleaseName, "revision": release.ReleaseVersion, "reason": verifyErr.Error()})
+
+	verificationErr := ReleaseVerificationError{
+		ReleaseName: release.ReleaseName,
+		Reason:      verifyErr.Error(),
+	}
+
+	// the first revision has nothing to be rolled back to
+	if release.ReleaseVersion <= 1 {
+		if err := s.releaser.Uninstall(ctx, helmEnv, kubeConfig, release.ReleaseName, options); err != nil {
+			s.logger.Error("failed to uninstall release after failed verification", map[string]interface{}{"releaseName": release.ReleaseName, "error": err.Error()})
+
+			return errors.WithStack(verificationErr)
+		}
+
+		verificationErr.RolledBack = true
+
+		return errors.WithStack(verificationErr)
+	}
+
+	rolledBack, err := s.releaser.Rollback(ctx, helmEnv, kubeConfig, release.ReleaseName, release.ReleaseVersion-1, options)
+	if err != nil {
+		s.logger.Error("failed to roll back release after failed verification", map[string]interface{}{"releaseName": release.ReleaseName, "error": err.Error()})
+
+		return errors.WithStack(verificationErr)
+	}
+
+	verificationErr.RolledBack = true
+	verificationErr.Revision = release.ReleaseVersion - 1
+
+	s.logger.Info("release rolled back after failed verification", map[string]interface{}{"releaseName": release.ReleaseName, "revision": rolledBack.ReleaseVersion})
+
+	return errors.WithStack(verificationErr)
+}
@@ -0,0 +1,146 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func setUpVerificationService(releaser Releaser) Service {
+	envResolver := &MockEnvResolver{}
+	envResolver.On("ResolveHelmEnv", mock.Anything, uint(1)).Return(HelmEnv{}, nil)
+
+	kubeConfig := ClusterKubeConfigFunc(func(ctx context.Context, clusterID uint) ([]byte, error) {
+		return []byte("kubeconfig"), nil
+	})
+
+	chartPolicyStore := &MockChartPolicyStore{}
+	chartPolicyStore.On("Get", mock.Anything, uint(1)).Return(ChartPolicy{}, nil)
+
+	return NewService(Config{}, nil, chartPolicyStore, nil, nil, envResolver, nil, releaser, kubeConfig, common.NoopLogger{})
+}
+
+func TestService_UpgradeRelease_Verify(t *testing.T) {
+	ctx := context.Background()
+	releaseInput := Release{ReleaseName: "my-release", ChartName: "stable/my-chart"}
+	upgraded := Release{ReleaseName: "my-release", ReleaseVersion: 4}
+
+	t.Run("verification disabled", func(t *testing.T) {
+		releaser := &MockReleaser{}
+		releaser.On("Upgrade", ctx, HelmEnv{}, []byte("kubeconfig"), releaseInput, Options{}).Return(upgraded, nil)
+
+		release, err := setUpVerificationService(releaser).UpgradeRelease(ctx, 1, 2, releaseInput, Options{})
+		require.NoError(t, err)
+		assert.Equal(t, upgraded, release)
+
+		releaser.AssertExpectations(t)
+	})
+
+	t.Run("healthy release", func(t *testing.T) {
+		options := Options{Verify: &VerifyOptions{Timeout: 60, RunTests: true}}
+
+		releaser := &MockReleaser{}
+		releaser.On("Upgrade", ctx, HelmEnv{}, []byte("kubeconfig"), releaseInput, options).Return(upgraded, nil)
+		releaser.On("Verify", ctx, HelmEnv{}, []byte("kubeconfig"), "my-release", options).Return(nil)
+
+		_, err := setUpVerificationService(releaser).UpgradeRelease(ctx, 1, 2, releaseInput, options)
+		require.NoError(t, err)
+
+		releaser.AssertExpectations(t)
+	})
+
+	t.Run("unhealthy release is rolled back", func(t *testing.T) {
+		options := Options{Verify: &VerifyOptions{}}
+
+		releaser := &MockReleaser{}
+		releaser.On("Upgrade", ctx, HelmEnv{}, []byte("kubeconfig"), releaseInput, options).Return(upgraded, nil)
+		releaser.On("Verify", ctx, HelmEnv{}, []byte("kubeconfig"), "my-release", options).Return(errors.New("deployment not ready"))
+		releaser.On("Rollback", ctx, HelmEnv{}, []byte("kubeconfig"), "my-release", int32(3), options).Return(Release{ReleaseVersion: 5}, nil)
+
+		_, err := setUpVerificationService(releaser).UpgradeRelease(ctx, 1, 2, releaseInput, options)
+		require.Error(t, err)
+
+		verificationErr, ok := IsReleaseVerificationError(err)
+		require.True(t, ok)
+		assert.True(t, verificationErr.RolledBack)
+		assert.Equal(t, int32(3), verificationErr.Revision)
+		assert.Equal(t, "deployment not ready", verificationErr.Reason)
+
+		releaser.AssertExpectations(t)
+	})
+
+	t.Run("unhealthy first revision is uninstalled", func(t *testing.T) {
+		options := Options{Verify: &VerifyOptions{}, Install: true}
+		installed := Release{ReleaseName: "my-release", ReleaseVersion: 1}
+
+		releaser := &MockReleaser{}
+		releaser.On("Upgrade", ctx, HelmEnv{}, []byte("kubeconfig"), releaseInput, options).Return(installed, nil)
+		releaser.On("Verify", ctx, HelmEnv{}, []byte("kubeconfig"), "my-release", options).Return(errors.New("tests failed"))
+		releaser.On("Uninstall", ctx, HelmEnv{}, []byte("kubeconfig"), "my-release", options).Return(nil)
+
+		_, err := setUpVerificationService(releaser).UpgradeRelease(ctx, 1, 2, releaseInput, options)
+
+		verificationErr, ok := IsReleaseVerificationError(err)
+		require.True(t, ok)
+		assert.True(t, verificationErr.RolledBack)
+		assert.Equal(t, int32(0), verificationErr.Revision)
+
+		releaser.AssertExpectations(t)
+	})
+
+	t.Run("failed rollback is reported", func(t *testing.T) {
+		options := Options{Verify: &VerifyOptions{}}
+
+		releaser := &MockReleaser{}
+		releaser.On("Upgrade", ctx, HelmEnv{}, []byte("kubeconfig"), releaseInput, options).Return(upgraded, nil)
+		releaser.On("Verify", ctx, HelmEnv{}, []byte("kubeconfig"), "my-release", options).Return(errors.New("deployment not ready"))
+		releaser.On("Rollback", ctx, HelmEnv{}, []byte("kubeconfig"), "my-release", int32(3), options).Return(Release{}, errors.New("rollback failed"))
+
+		_, err := setUpVerificationService(releaser).UpgradeRelease(ctx, 1, 2, releaseInput, options)
+
+		verificationErr, ok := IsReleaseVerificationError(err)
+		require.True(t, ok)
+		assert.False(t, verificationErr.RolledBack)
+
+		releaser.AssertExpectations(t)
+	})
+}
+
+func TestService_InstallRelease_DryRunIsNotVerified(t *testing.T) {
+	ctx := context.Background()
+	releaseInput := Release{ReleaseName: "my-release", ChartName: "stable/my-chart"}
+	options := Options{DryRun: true, Verify: &VerifyOptions{}}
+
+	releaser := &MockReleaser{}
+	releaser.On("Install", ctx, HelmEnv{}, []byte("kubeconfig"), releaseInput, options).Return(Release{ReleaseName: "my-release", ReleaseVersion: 1}, nil)
+
+	_, err := setUpVerificationService(releaser).InstallRelease(ctx, 1, 2, releaseInput, options)
+	require.NoError(t, err)
+
+	releaser.AssertExpectations(t)
+}
+
+func TestVerifyOptions_TimeoutDuration(t *testing.T) {
+	assert.Equal(t, defaultVerifyTimeout, VerifyOptions{}.TimeoutDuration())
+	assert.Equal(t, "1m30s", VerifyOptions{Timeout: 90}.TimeoutDuration().String())
+}
@@ -9,6 +9,60 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// MockChartPolicyStore is an autogenerated mock for the ChartPolicyStore type.
+type MockChartPolicyStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function.
+func (_m *MockChartPolicyStore) Delete(ctx context.Context, organizationID uint) (_result_0 error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function.
+func (_m *MockChartPolicyStore) Get(ctx context.Context, organizationID uint) (_result_0 ChartPolicy, _result_1 error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 ChartPolicy
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ChartPolicy); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		r0 = ret.Get(0).(ChartPolicy)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function.
+func (_m *MockChartPolicyStore) Save(ctx context.Context, organizationID uint, policy ChartPolicy) (_result_0 error) {
+	ret := _m.Called(ctx, organizationID, policy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, ChartPolicy) error); ok {
+		r0 = rf(ctx, organizationID, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MockOrgService is an autogenerated mock for the OrgService type.
 type MockOrgService struct {
 	mock.Mock
@@ -82,6 +136,213 @@ func (_m *MockEnvResolver) ResolvePlatformEnv(ctx context.Context) (_result_0 He
 	return r0, r1
 }
 
+// MockReleaser is an autogenerated mock for the Releaser type.
+type MockReleaser struct {
+	mock.Mock
+}
+
+// Diff provides a mock function.
+func (_m *MockReleaser) Diff(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseInput Release, options Options) (_result_0 ReleaseDiff, _result_1 error) {
+	ret := _m.Called(ctx, helmEnv, kubeConfig, releaseInput, options)
+
+	var r0 ReleaseDiff
+	if rf, ok := ret.Get(0).(func(context.Context, HelmEnv, KubeConfigBytes, Release, Options) ReleaseDiff); ok {
+		r0 = rf(ctx, helmEnv, kubeConfig, releaseInput, options)
+	} else {
+		r0 = ret.Get(0).(ReleaseDiff)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, HelmEnv, KubeConfigBytes, Release, Options) error); ok {
+		r1 = rf(ctx, helmEnv, kubeConfig, releaseInput, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function.
+func (_m *MockReleaser) Get(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseInput Release, options Options) (_result_0 Release, _result_1 error) {
+	ret := _m.Called(ctx, helmEnv, kubeConfig, releaseInput, options)
+
+	var r0 Release
+	if rf, ok := ret.Get(0).(func(context.Context, HelmEnv, KubeConfigBytes, Release, Options) Release); ok {
+		r0 = rf(ctx, helmEnv, kubeConfig, releaseInput, options)
+	} else {
+		r0 = ret.Get(0).(Release)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, HelmEnv, KubeConfigBytes, Release, Options) error); ok {
+		r1 = rf(ctx, helmEnv, kubeConfig, releaseInput, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// History provides a mock function.
+func (_m *MockReleaser) History(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseName string, options Options) (_result_0 []ReleaseRevision, _result_1 error) {
+	ret := _m.Called(ctx, helmEnv, kubeConfig, releaseName, options)
+
+	var r0 []ReleaseRevision
+	if rf, ok := ret.Get(0).(func(context.Context, HelmEnv, KubeConfigBytes, string, Options) []ReleaseRevision); ok {
+		r0 = rf(ctx, helmEnv, kubeConfig, releaseName, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ReleaseRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, HelmEnv, KubeConfigBytes, string, Options) error); ok {
+		r1 = rf(ctx, helmEnv, kubeConfig, releaseName, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Install provides a mock function.
+func (_m *MockReleaser) Install(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseInput Release, options Options) (_result_0 Release, _result_1 error) {
+	ret := _m.Called(ctx, helmEnv, kubeConfig, releaseInput, options)
+
+	var r0 Release
+	if rf, ok := ret.Get(0).(func(context.Context, HelmEnv, KubeConfigBytes, Release, Options) Release); ok {
+		r0 = rf(ctx, helmEnv, kubeConfig, releaseInput, options)
+	} else {
+		r0 = ret.Get(0).(Release)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, HelmEnv, KubeConfigBytes, Release, Options) error); ok {
+		r1 = rf(ctx, helmEnv, kubeConfig, releaseInput, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function.
+func (_m *MockReleaser) List(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, options Options) (_result_0 []Release, _result_1 error) {
+	ret := _m.Called(ctx, helmEnv, kubeConfig, options)
+
+	var r0 []Release
+	if rf, ok := ret.Get(0).(func(context.Context, HelmEnv, KubeConfigBytes, Options) []Release); ok {
+		r0 = rf(ctx, helmEnv, kubeConfig, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Release)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, HelmEnv, KubeConfigBytes, Options) error); ok {
+		r1 = rf(ctx, helmEnv, kubeConfig, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resources provides a mock function.
+func (_m *MockReleaser) Resources(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseInput Release, options Options) (_result_0 []ReleaseResource, _result_1 error) {
+	ret := _m.Called(ctx, helmEnv, kubeConfig, releaseInput, options)
+
+	var r0 []ReleaseResource
+	if rf, ok := ret.Get(0).(func(context.Context, HelmEnv, KubeConfigBytes, Release, Options) []ReleaseResource); ok {
+		r0 = rf(ctx, helmEnv, kubeConfig, releaseInput, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ReleaseResource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, HelmEnv, KubeConfigBytes, Release, Options) error); ok {
+		r1 = rf(ctx, helmEnv, kubeConfig, releaseInput, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rollback provides a mock function.
+func (_m *MockReleaser) Rollback(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseName string, revision int32, options Options) (_result_0 Release, _result_1 error) {
+	ret := _m.Called(ctx, helmEnv, kubeConfig, releaseName, revision, options)
+
+	var r0 Release
+	if rf, ok := ret.Get(0).(func(context.Context, HelmEnv, KubeConfigBytes, string, int32, Options) Release); ok {
+		r0 = rf(ctx, helmEnv, kubeConfig, releaseName, revision, options)
+	} else {
+		r0 = ret.Get(0).(Release)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, HelmEnv, KubeConfigBytes, string, int32, Options) error); ok {
+		r1 = rf(ctx, helmEnv, kubeConfig, releaseName, revision, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Uninstall provides a mock function.
+func (_m *MockReleaser) Uninstall(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseName string, options Options) (_result_0 error) {
+	ret := _m.Called(ctx, helmEnv, kubeConfig, releaseName, options)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, HelmEnv, KubeConfigBytes, string, Options) error); ok {
+		r0 = rf(ctx, helmEnv, kubeConfig, releaseName, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Upgrade provides a mock function.
+func (_m *MockReleaser) Upgrade(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseInput Release, options Options) (_result_0 Release, _result_1 error) {
+	ret := _m.Called(ctx, helmEnv, kubeConfig, releaseInput, options)
+
+	var r0 Release
+	if rf, ok := ret.Get(0).(func(context.Context, HelmEnv, KubeConfigBytes, Release, Options) Release); ok {
+		r0 = rf(ctx, helmEnv, kubeConfig, releaseInput, options)
+	} else {
+		r0 = ret.Get(0).(Release)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, HelmEnv, KubeConfigBytes, Release, Options) error); ok {
+		r1 = rf(ctx, helmEnv, kubeConfig, releaseInput, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Verify provides a mock function.
+func (_m *MockReleaser) Verify(ctx context.Context, helmEnv HelmEnv, kubeConfig KubeConfigBytes, releaseName string, options Options) (_result_0 error) {
+	ret := _m.Called(ctx, helmEnv, kubeConfig, releaseName, options)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, HelmEnv, KubeConfigBytes, string, Options) error); ok {
+		r0 = rf(ctx, helmEnv, kubeConfig, releaseName, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MockService is an autogenerated mock for the Service type.
 type MockService struct {
 	mock.Mock
@@ -101,6 +362,20 @@ func (_m *MockService) AddRepository(ctx context.Context, organizationID uint, r
 	return r0
 }
 
+// CheckChartPolicy provides a mock function.
+func (_m *MockService) CheckChartPolicy(ctx context.Context, organizationID uint, releaseInput Release) (_result_0 error) {
+	ret := _m.Called(ctx, organizationID, releaseInput)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, Release) error); ok {
+		r0 = rf(ctx, organizationID, releaseInput)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CheckRelease provides a mock function.
 func (_m *MockService) CheckRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (_result_0 string, _result_1 error) {
 	ret := _m.Called(ctx, organizationID, clusterID, releaseName, options)
@@ -145,6 +420,20 @@ func (_m *MockService) CheckReleases(ctx context.Context, organizationID uint, r
 	return r0, r1
 }
 
+// DeleteChartPolicy provides a mock function.
+func (_m *MockService) DeleteChartPolicy(ctx context.Context, organizationID uint) (_result_0 error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteRelease provides a mock function.
 func (_m *MockService) DeleteRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (_result_0 error) {
 	ret := _m.Called(ctx, organizationID, clusterID, releaseName, options)
@@ -173,6 +462,27 @@ func (_m *MockService) DeleteRepository(ctx context.Context, organizationID uint
 	return r0
 }
 
+// DiffRelease provides a mock function.
+func (_m *MockService) DiffRelease(ctx context.Context, organizationID uint, clusterID uint, releaseInput Release, options Options) (_result_0 ReleaseDiff, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, clusterID, releaseInput, options)
+
+	var r0 ReleaseDiff
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, Release, Options) ReleaseDiff); ok {
+		r0 = rf(ctx, organizationID, clusterID, releaseInput, options)
+	} else {
+		r0 = ret.Get(0).(ReleaseDiff)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, Release, Options) error); ok {
+		r1 = rf(ctx, organizationID, clusterID, releaseInput, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChart provides a mock function.
 func (_m *MockService) GetChart(ctx context.Context, organizationID uint, chartFilter ChartFilter, options Options) (chartDetails ChartDetails, err error) {
 	ret := _m.Called(ctx, organizationID, chartFilter, options)
@@ -196,6 +506,27 @@ func (_m *MockService) GetChart(ctx context.Context, organizationID uint, chartF
 	return r0, r1
 }
 
+// GetChartPolicy provides a mock function.
+func (_m *MockService) GetChartPolicy(ctx context.Context, organizationID uint) (_result_0 ChartPolicy, _result_1 error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 ChartPolicy
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ChartPolicy); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		r0 = ret.Get(0).(ChartPolicy)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRelease provides a mock function.
 func (_m *MockService) GetRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (_result_0 Release, _result_1 error) {
 	ret := _m.Called(ctx, organizationID, clusterID, releaseName, options)
@@ -217,6 +548,29 @@ func (_m *MockService) GetRelease(ctx context.Context, organizationID uint, clus
 	return r0, r1
 }
 
+// GetReleaseHistory provides a mock function.
+func (_m *MockService) GetReleaseHistory(ctx context.Context, organizationID uint, clusterID uint, releaseName string, options Options) (_result_0 []ReleaseRevision, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, clusterID, releaseName, options)
+
+	var r0 []ReleaseRevision
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string, Options) []ReleaseRevision); ok {
+		r0 = rf(ctx, organizationID, clusterID, releaseName, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ReleaseRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string, Options) error); ok {
+		r1 = rf(ctx, organizationID, clusterID, releaseName, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetReleaseResources provides a mock function.
 func (_m *MockService) GetReleaseResources(ctx context.Context, organizationID uint, clusterID uint, release Release, options Options) (_result_0 []ReleaseResource, _result_1 error) {
 	ret := _m.Called(ctx, organizationID, clusterID, release, options)
@@ -344,6 +698,41 @@ func (_m *MockService) ModifyRepository(ctx context.Context, organizationID uint
 	return r0
 }
 
+// RollbackRelease provides a mock function.
+func (_m *MockService) RollbackRelease(ctx context.Context, organizationID uint, clusterID uint, releaseName string, revision int32, options Options) (_result_0 Release, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, clusterID, releaseName, revision, options)
+
+	var r0 Release
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string, int32, Options) Release); ok {
+		r0 = rf(ctx, organizationID, clusterID, releaseName, revision, options)
+	} else {
+		r0 = ret.Get(0).(Release)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string, int32, Options) error); ok {
+		r1 = rf(ctx, organizationID, clusterID, releaseName, revision, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateChartPolicy provides a mock function.
+func (_m *MockService) UpdateChartPolicy(ctx context.Context, organizationID uint, policy ChartPolicy) (_result_0 error) {
+	ret := _m.Called(ctx, organizationID, policy)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, ChartPolicy) error); ok {
+		r0 = rf(ctx, organizationID, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRepository provides a mock function.
 func (_m *MockService) UpdateRepository(ctx context.Context, organizationID uint, repository Repository) (_result_0 error) {
 	ret := _m.Called(ctx, organizationID, repository)
@@ -0,0 +1,147 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integratedservices
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// RedactedValue replaces sensitive values (eg. passwords) in dry run outputs.
+const RedactedValue = "<redacted>"
+
+// IntegratedServiceDryRunOutput contains the resources an integrated service operator would apply for a specification.
+type IntegratedServiceDryRunOutput struct {
+	// Releases are the Helm releases that would be installed or upgraded.
+	Releases []HelmReleaseDryRun `json:"releases,omitempty"`
+
+	// Objects are the Kubernetes objects that would be created or updated.
+	Objects []map[string]interface{} `json:"objects,omitempty"`
+}
+
+// HelmReleaseDryRun describes a Helm release rendered by a dry run.
+type HelmReleaseDryRun struct {
+	ReleaseName  string                 `json:"releaseName"`
+	Namespace    string                 `json:"namespace"`
+	ChartName    string                 `json:"chartName"`
+	ChartVersion string                 `json:"chartVersion,omitempty"`
+	Values       map[string]interface{} `json:"values,omitempty"`
+}
+
+// IntegratedServiceDryRun is the result of an integrated service dry run.
+type IntegratedServiceDryRun struct {
+	// Output contains the rendered resources.
+	Output IntegratedServiceDryRunOutput `json:"output"`
+
+	// Changes lists the differences between the currently applied and the requested specification.
+	Changes []IntegratedServiceSpecChange `json:"changes"`
+}
+
+// Integrated service spec change operations
+const (
+	SpecChangeAdd     = "add"
+	SpecChangeRemove  = "remove"
+	SpecChangeReplace = "replace"
+)
+
+// IntegratedServiceSpecChange describes the change of a single value in an integrated service specification.
+type IntegratedServiceSpecChange struct {
+	// Path is the dot separated path of the changed value.
+	Path string `json:"path"`
+
+	// Operation is one of add, remove or replace.
+	Operation string `json:"op"`
+
+	OldValue interface{} `json:"oldValue,omitempty"`
+	NewValue interface{} `json:"newValue,omitempty"`
+}
+
+// DiffIntegratedServiceSpecs returns the changes between two integrated service specifications.
+// Nested objects are compared recursively, any other values (including lists) are compared as a whole.
+func DiffIntegratedServiceSpecs(oldSpec IntegratedServiceSpec, newSpec IntegratedServiceSpec) []IntegratedServiceSpecChange {
+	changes := make([]IntegratedServiceSpecChange, 0)
+
+	return diffSpecValues(changes, "", oldSpec, newSpec)
+}
+
+func diffSpecValues(changes []IntegratedServiceSpecChange, prefix string, oldSpec map[string]interface{}, newSpec map[string]interface{}) []IntegratedServiceSpecChange {
+	keys := make([]string, 0, len(oldSpec)+len(newSpec))
+	for key := range oldSpec {
+		keys = append(keys, key)
+	}
+	for key := range newSpec {
+		if _, ok := oldSpec[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		path := key
+		if prefix != "" {
+			path = fmt.Sprintf("%s.%s", prefix, key)
+		}
+
+		oldValue, inOld := oldSpec[key]
+		newValue, inNew := newSpec[key]
+
+		switch {
+		case !inOld:
+			changes = append(changes, IntegratedServiceSpecChange{Path: path, Operation: SpecChangeAdd, NewValue: newValue})
+
+		case !inNew:
+			changes = append(changes, IntegratedServiceSpecChange{Path: path, Operation: SpecChangeRemove, OldValue: oldValue})
+
+		default:
+			oldMap, oldIsMap := oldValue.(map[string]interface{})
+			newMap, newIsMap := newValue.(map[string]interface{})
+
+			if oldIsMap && newIsMap {
+				changes = diffSpecValues(changes, path, oldMap, newMap)
+			} else if !reflect.DeepEqual(oldValue, newValue) {
+				changes = append(changes, IntegratedServiceSpecChange{Path: path, Operation: SpecChangeReplace, OldValue: oldValue, NewValue: newValue})
+			}
+		}
+	}
+
+	return changes
+}
+
+// DryRunNotSupportedError is returned when an integrated service does not support dry runs.
+type DryRunNotSupportedError struct {
+	IntegratedServiceName string
+}
+
+func (DryRunNotSupportedError) Error() string {
+	return "integrated service does not support dry run"
+}
+
+// Details returns the error's details
+func (e DryRunNotSupportedError) Details() []interface{} {
+	return []interface{}{"integratedService", e.IntegratedServiceName}
+}
+
+// BadRequest tells a client that this error is related to an invalid request.
+// Can be used to translate the error to eg. status code.
+func (DryRunNotSupportedError) BadRequest() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (DryRunNotSupportedError) ServiceError() bool {
+	return true
+}
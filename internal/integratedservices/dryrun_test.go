@@ -0,0 +1,67 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integratedservices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffIntegratedServiceSpecs(t *testing.T) {
+	oldSpec := IntegratedServiceSpec{
+		"unchanged": "value",
+		"removed":   "value",
+		"replaced":  1.0,
+		"list":      []interface{}{"a", "b"},
+		"nested": map[string]interface{}{
+			"enabled": false,
+			"deep": map[string]interface{}{
+				"removed": "value",
+			},
+		},
+	}
+
+	newSpec := IntegratedServiceSpec{
+		"unchanged": "value",
+		"added":     "value",
+		"replaced":  2.0,
+		"list":      []interface{}{"a", "c"},
+		"nested": map[string]interface{}{
+			"enabled": true,
+			"deep":    map[string]interface{}{},
+		},
+	}
+
+	expected := []IntegratedServiceSpecChange{
+		{Path: "added", Operation: SpecChangeAdd, NewValue: "value"},
+		{Path: "list", Operation: SpecChangeReplace, OldValue: []interface{}{"a", "b"}, NewValue: []interface{}{"a", "c"}},
+		{Path: "nested.deep.removed", Operation: SpecChangeRemove, OldValue: "value"},
+		{Path: "nested.enabled", Operation: SpecChangeReplace, OldValue: false, NewValue: true},
+		{Path: "removed", Operation: SpecChangeRemove, OldValue: "value"},
+		{Path: "replaced", Operation: SpecChangeReplace, OldValue: 1.0, NewValue: 2.0},
+	}
+
+	assert.Equal(t, expected, DiffIntegratedServiceSpecs(oldSpec, newSpec))
+}
+
+func TestDiffIntegratedServiceSpecs_NoChanges(t *testing.T) {
+	spec := IntegratedServiceSpec{
+		"key": map[string]interface{}{"nested": "value"},
+	}
+
+	assert.Empty(t, DiffIntegratedServiceSpecs(spec, spec))
+	assert.Empty(t, DiffIntegratedServiceSpecs(nil, nil))
+}
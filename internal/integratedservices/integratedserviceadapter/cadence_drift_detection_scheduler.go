@@ -0,0 +1,49 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integratedserviceadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter/workflow"
+	"github.com/banzaicloud/pipeline/internal/platform/cadence"
+)
+
+// ScheduleCadenceDriftDetection makes sure the integrated service drift detection cron workflow is running with the specified schedule.
+func ScheduleCadenceDriftDetection(ctx context.Context, cadenceClient client.Client, schedule string, autoReapply bool, logger common.Logger) error {
+	options := client.StartWorkflowOptions{
+		ID:                           workflow.IntegratedServiceDriftDetectionWorkflowID,
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 1 * time.Hour,
+		CronSchedule:                 schedule,
+	}
+
+	workflowInput := workflow.IntegratedServiceDriftDetectionWorkflowInput{
+		AutoReapply: autoReapply,
+	}
+
+	if err := cadence.ScheduleCronWorkflow(ctx, cadenceClient, options, workflow.IntegratedServiceDriftDetectionWorkflowName, workflowInput); err != nil {
+		return errors.WrapIf(err, "failed to schedule drift detection workflow")
+	}
+
+	logger.Info("integrated service drift detection scheduled", map[string]interface{}{"schedule": schedule})
+
+	return nil
+}
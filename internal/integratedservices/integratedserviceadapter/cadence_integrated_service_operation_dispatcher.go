@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"emperror.dev/errors"
+	"go.uber.org/cadence"
 	"go.uber.org/cadence/client"
 
 	"github.com/banzaicloud/pipeline/internal/common"
@@ -54,6 +55,37 @@ func (d CadenceIntegratedServiceOperationDispatcher) DispatchDeactivate(ctx cont
 	return d.dispatchOperation(ctx, workflow.OperationDeactivate, clusterID, integratedServiceName, spec)
 }
 
+// DispatchDryRun renders the resources of an integrated service specification and waits for the result
+func (d CadenceIntegratedServiceOperationDispatcher) DispatchDryRun(ctx context.Context, clusterID uint, integratedServiceName string, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	const workflowName = workflow.IntegratedServiceDryRunWorkflowName
+	options := client.StartWorkflowOptions{
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 5 * time.Minute,
+	}
+	workflowInput := workflow.IntegratedServiceDryRunWorkflowInput{
+		ClusterID:             clusterID,
+		IntegratedServiceName: integratedServiceName,
+		IntegratedServiceSpec: spec,
+	}
+
+	run, err := d.cadenceClient.ExecuteWorkflow(ctx, options, workflowName, workflowInput)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to start dry run workflow")
+	}
+
+	var output integratedservices.IntegratedServiceDryRunOutput
+	if err := run.Get(ctx, &output); err != nil {
+		var customErr *cadence.CustomError
+		if errors.As(err, &customErr) && customErr.Reason() == workflow.ErrReasonDryRunNotSupported {
+			return integratedservices.IntegratedServiceDryRunOutput{}, errors.WithStack(integratedservices.DryRunNotSupportedError{IntegratedServiceName: integratedServiceName})
+		}
+
+		return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIfWithDetails(err, "dry run workflow failed", "workflowId", run.GetID())
+	}
+
+	return output, nil
+}
+
 func (d CadenceIntegratedServiceOperationDispatcher) dispatchOperation(ctx context.Context, op string, clusterID uint, integratedServiceName string, spec integratedservices.IntegratedServiceSpec) error {
 	const workflowName = workflow.IntegratedServiceJobWorkflowName
 	workflowID := getWorkflowID(workflowName, clusterID, integratedServiceName)
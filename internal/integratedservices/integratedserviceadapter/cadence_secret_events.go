@@ -0,0 +1,55 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integratedserviceadapter
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter/workflow"
+)
+
+type eventBus interface {
+	SubscribeAsync(topic string, fn interface{}, transactional bool) error
+}
+
+const secretRotatedTopic = "secret_rotated"
+
+// SubscribeSecretEvents reapplies the integrated services referencing a secret when the secret is rotated.
+// The integrated services are reapplied by a Cadence workflow, so rotations made by any Pipeline process reach them.
+func SubscribeSecretEvents(eb eventBus, cadenceClient client.Client, logger common.Logger) {
+	eb.SubscribeAsync(secretRotatedTopic, func(organizationID uint, secretID string) { // nolint: errcheck
+		options := client.StartWorkflowOptions{
+			TaskList:                     "pipeline",
+			ExecutionStartToCloseTimeout: 1 * time.Hour,
+		}
+
+		input := workflow.IntegratedServiceSecretRotatedWorkflowInput{
+			OrganizationID: organizationID,
+			SecretID:       secretID,
+		}
+
+		if _, err := cadenceClient.StartWorkflow(context.Background(), options, workflow.IntegratedServiceSecretRotatedWorkflowName, input); err != nil {
+			logger.Error("failed to start integrated service secret rotated workflow", map[string]interface{}{
+				"organizationId": organizationID,
+				"secretId":       secretID,
+				"error":          err.Error(),
+			})
+		}
+	}, false)
+}
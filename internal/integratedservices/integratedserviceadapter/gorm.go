@@ -25,6 +25,7 @@ import (
 func Migrate(db *gorm.DB, logger logrus.FieldLogger) error {
 	tables := []interface{}{
 		&integratedServiceModel{},
+		&integratedServiceRevisionModel{},
 	}
 
 	var tableNames string
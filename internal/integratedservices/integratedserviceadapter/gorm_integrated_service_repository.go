@@ -31,7 +31,8 @@ import (
 
 // TableName constants
 const (
-	integratedServiceTableName = "cluster_features"
+	integratedServiceTableName         = "cluster_features"
+	integratedServiceRevisionTableName = "cluster_feature_revisions"
 )
 
 type integratedServiceSpec map[string]interface{}
@@ -68,18 +69,45 @@ func (cfm integratedServiceModel) String() string {
 	return fmt.Sprintf("Id: %d, Creation date: %s, Name: %s", cfm.ID, cfm.CreatedAt, cfm.Name)
 }
 
+// integratedServiceRevisionModel describes a specification applied to an integrated service.
+type integratedServiceRevisionModel struct {
+	ID        uint `gorm:"primary_key"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	ClusterId uint                  `gorm:"unique_index:idx_cluster_feature_revision_cluster_id_name_revision"`
+	Name      string                `gorm:"unique_index:idx_cluster_feature_revision_cluster_id_name_revision"`
+	Revision  uint                  `gorm:"unique_index:idx_cluster_feature_revision_cluster_id_name_revision"`
+	Spec      integratedServiceSpec `gorm:"type:text"`
+	Status    string
+	CreatedBy uint
+}
+
+// TableName changes the default table name.
+func (m integratedServiceRevisionModel) TableName() string {
+	return integratedServiceRevisionTableName
+}
+
+// UserIDContextExtractor extracts a user ID from a context (if there is any).
+type UserIDContextExtractor interface {
+	// GetUserID returns the ID of the user the context belongs to.
+	GetUserID(ctx context.Context) (uint, bool)
+}
+
 // GORMIntegratedServiceRepository implements integrated service persistence in RDBMS using GORM.
 // TODO: write integration tests
 type GORMIntegratedServiceRepository struct {
-	db     *gorm.DB
-	logger common.Logger
+	db              *gorm.DB
+	userIDExtractor UserIDContextExtractor
+	logger          common.Logger
 }
 
 // NewGormIntegratedServiceRepository returns an integrated service repository persisting integrated service state into database using GORM.
-func NewGormIntegratedServiceRepository(db *gorm.DB, logger common.Logger) GORMIntegratedServiceRepository {
+func NewGormIntegratedServiceRepository(db *gorm.DB, userIDExtractor UserIDContextExtractor, logger common.Logger) GORMIntegratedServiceRepository {
 	return GORMIntegratedServiceRepository{
-		db:     db,
-		logger: logger,
+		db:              db,
+		userIDExtractor: userIDExtractor,
+		logger:          logger,
 	}
 }
 
@@ -115,19 +143,100 @@ func (r GORMIntegratedServiceRepository) GetIntegratedServices(ctx context.Conte
 	return integratedServiceList, nil
 }
 
+// GetIntegratedServicesByStatus returns integrated services in any of the specified statuses grouped by cluster ID.
+func (r GORMIntegratedServiceRepository) GetIntegratedServicesByStatus(ctx context.Context, statuses ...string) (map[uint][]integratedservices.IntegratedService, error) {
+	var models []integratedServiceModel
+
+	if err := r.db.Where("status IN (?)", statuses).Order("cluster_id, name").Find(&models).Error; err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not retrieve integrated services", "statuses", statuses)
+	}
+
+	return r.groupByCluster(models), nil
+}
+
+// GetOrganizationIntegratedServicesByStatus returns the integrated services of an organization's clusters in any of the specified statuses grouped by cluster ID.
+func (r GORMIntegratedServiceRepository) GetOrganizationIntegratedServicesByStatus(ctx context.Context, organizationID uint, statuses ...string) (map[uint][]integratedservices.IntegratedService, error) {
+	var models []integratedServiceModel
+
+	err := r.db.
+		Joins("JOIN clusters ON clusters.id = "+integratedServiceTableName+".cluster_id AND clusters.deleted_at IS NULL").
+		Where("clusters.organization_id = ? AND "+integratedServiceTableName+".status IN (?)", organizationID, statuses).
+		Order(integratedServiceTableName + ".cluster_id, " + integratedServiceTableName + ".name").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not retrieve integrated services", "organizationId", organizationID, "statuses", statuses)
+	}
+
+	return r.groupByCluster(models), nil
+}
+
+func (r GORMIntegratedServiceRepository) groupByCluster(models []integratedServiceModel) map[uint][]integratedservices.IntegratedService {
+	integratedServices := make(map[uint][]integratedservices.IntegratedService)
+	for _, model := range models {
+		f, err := r.modelToIntegratedService(model)
+		if err != nil {
+			continue
+		}
+
+		integratedServices[model.ClusterId] = append(integratedServices[model.ClusterId], f)
+	}
+
+	return integratedServices
+}
+
 // SaveIntegratedService persists an integrated service with the specified properties in the database.
+// The specification is also recorded as a new revision of the integrated service.
 func (r GORMIntegratedServiceRepository) SaveIntegratedService(ctx context.Context, clusterID uint, integratedServiceName string, spec integratedservices.IntegratedServiceSpec, status string) error {
 	model := integratedServiceModel{
 		ClusterId: clusterID,
 		Name:      integratedServiceName,
 	}
 
-	if err := r.db.Where(&model).First(&model).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+	var userID uint
+	if r.userIDExtractor != nil {
+		userID, _ = r.userIDExtractor.GetUserID(ctx)
+	}
+
+	tx := r.db.Begin()
+	if err := tx.Error; err != nil {
+		return errors.WrapIf(err, "failed to begin transaction")
+	}
+
+	if err := tx.Where(&model).First(&model).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		tx.Rollback()
 		return errors.WrapIfWithDetails(err, "failed to query integrated service", "clusterId", clusterID, "integrated service", integratedServiceName)
 	}
+	if model.ID == 0 {
+		model.CreatedBy = userID
+	}
 	model.Spec = spec
 	model.Status = status
-	return errors.WrapIfWithDetails(r.db.Save(&model).Error, "failed to save integrated service", "clusterId", clusterID, "integrated service", integratedServiceName)
+	if err := tx.Save(&model).Error; err != nil {
+		tx.Rollback()
+		return errors.WrapIfWithDetails(err, "failed to save integrated service", "clusterId", clusterID, "integrated service", integratedServiceName)
+	}
+
+	var latest integratedServiceRevisionModel
+	err := tx.Where(&integratedServiceRevisionModel{ClusterId: clusterID, Name: integratedServiceName}).Order("revision desc").First(&latest).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		tx.Rollback()
+		return errors.WrapIfWithDetails(err, "failed to query integrated service revision", "clusterId", clusterID, "integrated service", integratedServiceName)
+	}
+
+	revision := integratedServiceRevisionModel{
+		ClusterId: clusterID,
+		Name:      integratedServiceName,
+		Revision:  latest.Revision + 1,
+		Spec:      spec,
+		Status:    status,
+		CreatedBy: userID,
+	}
+	if err := tx.Create(&revision).Error; err != nil {
+		tx.Rollback()
+		return errors.WrapIfWithDetails(err, "failed to save integrated service revision", "clusterId", clusterID, "integrated service", integratedServiceName)
+	}
+
+	return errors.WrapIf(tx.Commit().Error, "failed to commit transaction")
 }
 
 // GetIntegratedService retrieves an integrated service by the cluster ID and integrated service name.
@@ -156,7 +265,24 @@ func (r GORMIntegratedServiceRepository) UpdateIntegratedServiceStatus(ctx conte
 		Name:      integratedServiceName,
 	}
 
-	return errors.WrapIf(r.db.Find(&fm, fm).Updates(integratedServiceModel{Status: status}).Error, "could not update integrated service status")
+	if err := r.db.Find(&fm, fm).Updates(integratedServiceModel{Status: status}).Error; err != nil {
+		return errors.WrapIf(err, "could not update integrated service status")
+	}
+
+	// the latest pending revision gets the resulting status of the operation
+	var latest integratedServiceRevisionModel
+	err := r.db.Where(&integratedServiceRevisionModel{ClusterId: clusterID, Name: integratedServiceName}).Order("revision desc").First(&latest).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil
+	} else if err != nil {
+		return errors.WrapIf(err, "could not retrieve integrated service revision")
+	}
+
+	if latest.Status != integratedservices.IntegratedServiceStatusPending || status == latest.Status {
+		return nil
+	}
+
+	return errors.WrapIf(r.db.Model(&latest).Updates(integratedServiceRevisionModel{Status: status}).Error, "could not update integrated service revision status")
 }
 
 // UpdateIntegratedServiceSpec sets the specification of the specified integrated service
@@ -187,6 +313,52 @@ func (r GORMIntegratedServiceRepository) DeleteIntegratedService(ctx context.Con
 	return nil
 }
 
+// GetIntegratedServiceRevisions returns the revisions of an integrated service (latest first).
+func (r GORMIntegratedServiceRepository) GetIntegratedServiceRevisions(ctx context.Context, clusterID uint, integratedServiceName string) ([]integratedservices.IntegratedServiceRevision, error) {
+	var models []integratedServiceRevisionModel
+
+	err := r.db.Where(&integratedServiceRevisionModel{ClusterId: clusterID, Name: integratedServiceName}).Order("revision desc").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not retrieve integrated service revisions", "clusterId", clusterID, "integrated service", integratedServiceName)
+	}
+
+	revisions := make([]integratedservices.IntegratedServiceRevision, 0, len(models))
+	for _, model := range models {
+		revisions = append(revisions, modelToIntegratedServiceRevision(model))
+	}
+
+	return revisions, nil
+}
+
+// GetIntegratedServiceRevision retrieves a revision of an integrated service.
+// It returns a "not found" error if the revision is not in the database.
+func (r GORMIntegratedServiceRepository) GetIntegratedServiceRevision(ctx context.Context, clusterID uint, integratedServiceName string, revision uint) (integratedservices.IntegratedServiceRevision, error) {
+	var model integratedServiceRevisionModel
+
+	err := r.db.Where(&integratedServiceRevisionModel{ClusterId: clusterID, Name: integratedServiceName, Revision: revision}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return integratedservices.IntegratedServiceRevision{}, integratedServiceRevisionNotFoundError{
+			ClusterID:             clusterID,
+			IntegratedServiceName: integratedServiceName,
+			Revision:              revision,
+		}
+	} else if err != nil {
+		return integratedservices.IntegratedServiceRevision{}, errors.WrapIf(err, "could not retrieve integrated service revision")
+	}
+
+	return modelToIntegratedServiceRevision(model), nil
+}
+
+func modelToIntegratedServiceRevision(m integratedServiceRevisionModel) integratedservices.IntegratedServiceRevision {
+	return integratedservices.IntegratedServiceRevision{
+		Revision:  m.Revision,
+		Spec:      m.Spec,
+		Status:    m.Status,
+		CreatedAt: m.CreatedAt,
+		CreatedBy: m.CreatedBy,
+	}
+}
+
 type integratedServiceNotFoundError struct {
 	ClusterID             uint
 	IntegratedServiceName string
@@ -218,3 +390,33 @@ func (integratedServiceNotFoundError) NotFound() bool {
 func (integratedServiceNotFoundError) ServiceError() bool {
 	return true
 }
+
+type integratedServiceRevisionNotFoundError struct {
+	ClusterID             uint
+	IntegratedServiceName string
+	Revision              uint
+}
+
+func (e integratedServiceRevisionNotFoundError) Error() string {
+	return fmt.Sprintf("Revision %d of IntegratedService %q not found for cluster %d", e.Revision, e.IntegratedServiceName, e.ClusterID)
+}
+
+func (e integratedServiceRevisionNotFoundError) Details() []interface{} {
+	return []interface{}{
+		"clusterId", e.ClusterID,
+		"integrated service", e.IntegratedServiceName,
+		"revision", e.Revision,
+	}
+}
+
+// NotFound tells a client that this error is related to a resource being not found.
+// Can be used to translate the error to eg. status code.
+func (integratedServiceRevisionNotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (integratedServiceRevisionNotFoundError) ServiceError() bool {
+	return true
+}
@@ -0,0 +1,157 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integratedserviceadapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+type userIDExtractorFunc func(ctx context.Context) (uint, bool)
+
+func (fn userIDExtractorFunc) GetUserID(ctx context.Context) (uint, bool) {
+	return fn(ctx)
+}
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	err = Migrate(db, logger)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGORMIntegratedServiceRepository_Revisions(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.Close()
+
+	userIDExtractor := userIDExtractorFunc(func(ctx context.Context) (uint, bool) {
+		return 7, true
+	})
+	repository := NewGormIntegratedServiceRepository(db, userIDExtractor, common.NoopLogger{})
+
+	ctx := context.Background()
+	clusterID := uint(1)
+	serviceName := "myService"
+
+	err := repository.SaveIntegratedService(ctx, clusterID, serviceName, integratedservices.IntegratedServiceSpec{"key": "v1"}, integratedservices.IntegratedServiceStatusPending)
+	require.NoError(t, err)
+
+	err = repository.UpdateIntegratedServiceStatus(ctx, clusterID, serviceName, integratedservices.IntegratedServiceStatusActive)
+	require.NoError(t, err)
+
+	err = repository.SaveIntegratedService(ctx, clusterID, serviceName, integratedservices.IntegratedServiceSpec{"key": "v2"}, integratedservices.IntegratedServiceStatusPending)
+	require.NoError(t, err)
+
+	revisions, err := repository.GetIntegratedServiceRevisions(ctx, clusterID, serviceName)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	assert.Equal(t, uint(2), revisions[0].Revision)
+	assert.Equal(t, integratedservices.IntegratedServiceSpec{"key": "v2"}, revisions[0].Spec)
+	assert.Equal(t, integratedservices.IntegratedServiceStatusPending, revisions[0].Status)
+	assert.Equal(t, uint(7), revisions[0].CreatedBy)
+	assert.Equal(t, uint(1), revisions[1].Revision)
+	assert.Equal(t, integratedservices.IntegratedServiceStatusActive, revisions[1].Status)
+
+	revision, err := repository.GetIntegratedServiceRevision(ctx, clusterID, serviceName, 1)
+	require.NoError(t, err)
+	assert.Equal(t, integratedservices.IntegratedServiceSpec{"key": "v1"}, revision.Spec)
+
+	_, err = repository.GetIntegratedServiceRevision(ctx, clusterID, serviceName, 3)
+	assert.Error(t, err)
+
+	service, err := repository.GetIntegratedService(ctx, clusterID, serviceName)
+	require.NoError(t, err)
+	assert.Equal(t, integratedservices.IntegratedServiceSpec{"key": "v2"}, service.Spec)
+}
+
+func TestGORMIntegratedServiceRepository_GetIntegratedServicesByStatus(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.Close()
+
+	repository := NewGormIntegratedServiceRepository(db, nil, common.NoopLogger{})
+
+	ctx := context.Background()
+
+	require.NoError(t, repository.SaveIntegratedService(ctx, 1, "logging", integratedservices.IntegratedServiceSpec{}, integratedservices.IntegratedServiceStatusActive))
+	require.NoError(t, repository.SaveIntegratedService(ctx, 1, "monitoring", integratedservices.IntegratedServiceSpec{}, integratedservices.IntegratedServiceStatusPending))
+	require.NoError(t, repository.SaveIntegratedService(ctx, 2, "monitoring", integratedservices.IntegratedServiceSpec{}, integratedservices.IntegratedServiceStatusDrifted))
+	require.NoError(t, repository.SaveIntegratedService(ctx, 3, "vault", integratedservices.IntegratedServiceSpec{}, integratedservices.IntegratedServiceStatusError))
+
+	result, err := repository.GetIntegratedServicesByStatus(ctx, integratedservices.IntegratedServiceStatusActive, integratedservices.IntegratedServiceStatusDrifted)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[uint][]integratedservices.IntegratedService{
+		1: {
+			{
+				Name:   "logging",
+				Spec:   integratedservices.IntegratedServiceSpec{},
+				Status: integratedservices.IntegratedServiceStatusActive,
+			},
+		},
+		2: {
+			{
+				Name:   "monitoring",
+				Spec:   integratedservices.IntegratedServiceSpec{},
+				Status: integratedservices.IntegratedServiceStatusDrifted,
+			},
+		},
+	}, result)
+}
+
+func TestGORMIntegratedServiceRepository_GetOrganizationIntegratedServicesByStatus(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.Close()
+
+	require.NoError(t, db.Exec("CREATE TABLE clusters (id INTEGER PRIMARY KEY, organization_id INTEGER, deleted_at DATETIME)").Error)
+	require.NoError(t, db.Exec("INSERT INTO clusters (id, organization_id, deleted_at) VALUES (1, 1, NULL), (2, 2, NULL), (3, 1, CURRENT_TIMESTAMP)").Error)
+
+	repository := NewGormIntegratedServiceRepository(db, nil, common.NoopLogger{})
+
+	ctx := context.Background()
+
+	require.NoError(t, repository.SaveIntegratedService(ctx, 1, "dns", integratedservices.IntegratedServiceSpec{}, integratedservices.IntegratedServiceStatusActive))
+	require.NoError(t, repository.SaveIntegratedService(ctx, 1, "logging", integratedservices.IntegratedServiceSpec{}, integratedservices.IntegratedServiceStatusPending))
+	require.NoError(t, repository.SaveIntegratedService(ctx, 2, "dns", integratedservices.IntegratedServiceSpec{}, integratedservices.IntegratedServiceStatusActive))
+	require.NoError(t, repository.SaveIntegratedService(ctx, 3, "dns", integratedservices.IntegratedServiceSpec{}, integratedservices.IntegratedServiceStatusActive))
+
+	result, err := repository.GetOrganizationIntegratedServicesByStatus(ctx, 1, integratedservices.IntegratedServiceStatusActive, integratedservices.IntegratedServiceStatusDrifted)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[uint][]integratedservices.IntegratedService{
+		1: {
+			{
+				Name:   "dns",
+				Spec:   integratedservices.IntegratedServiceSpec{},
+				Status: integratedservices.IntegratedServiceStatusActive,
+			},
+		},
+	}, result)
+}
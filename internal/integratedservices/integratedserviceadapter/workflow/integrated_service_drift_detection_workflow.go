@@ -0,0 +1,243 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+// IntegratedServiceDriftDetectionWorkflowName is the name the IntegratedServiceDriftDetectionWorkflow is registered under
+const IntegratedServiceDriftDetectionWorkflowName = "integrated-service-drift-detection"
+
+// IntegratedServiceDriftDetectionWorkflowID is the fixed ID of the scheduled IntegratedServiceDriftDetectionWorkflow
+const IntegratedServiceDriftDetectionWorkflowID = "integrated-service-drift-detection"
+
+// IntegratedServiceListDriftTargetsActivityName is the name the IntegratedServiceListDriftTargetsActivity is registered under
+const IntegratedServiceListDriftTargetsActivityName = "integrated-service-list-drift-targets"
+
+// IntegratedServiceDetectDriftActivityName is the name the IntegratedServiceDetectDriftActivity is registered under
+const IntegratedServiceDetectDriftActivityName = "integrated-service-detect-drift"
+
+// IntegratedServiceReapplyActivityName is the name the IntegratedServiceReapplyActivity is registered under
+const IntegratedServiceReapplyActivityName = "integrated-service-reapply"
+
+// IntegratedServiceDriftDetectionWorkflowInput defines the inputs of the IntegratedServiceDriftDetectionWorkflow
+type IntegratedServiceDriftDetectionWorkflowInput struct {
+	// AutoReapply makes the workflow reapply the specification of drifted integrated services
+	AutoReapply bool
+}
+
+// IntegratedServiceDriftTarget identifies an integrated service checked for drift
+type IntegratedServiceDriftTarget struct {
+	ClusterID             uint
+	IntegratedServiceName string
+}
+
+// IntegratedServiceDriftDetectionWorkflow compares the specification of active integrated services with their state in the cluster
+// and marks the integrated services that differ as drifted.
+func IntegratedServiceDriftDetectionWorkflow(ctx workflow.Context, input IntegratedServiceDriftDetectionWorkflowInput) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+	})
+
+	logger := workflow.GetLogger(ctx)
+
+	var targets []IntegratedServiceDriftTarget
+	if err := workflow.ExecuteActivity(ctx, IntegratedServiceListDriftTargetsActivityName, IntegratedServiceListDriftTargetsActivityInput{}).Get(ctx, &targets); err != nil {
+		return err
+	}
+
+	for _, target := range targets {
+		logger := logger.With(zap.Uint("clusterId", target.ClusterID), zap.String("integratedService", target.IntegratedServiceName))
+
+		var output IntegratedServiceDetectDriftActivityOutput
+		if err := workflow.ExecuteActivity(ctx, IntegratedServiceDetectDriftActivityName, IntegratedServiceDetectDriftActivityInput(target)).Get(ctx, &output); err != nil {
+			logger.Error("failed to detect integrated service drift", zap.Error(err))
+			continue
+		}
+
+		if !output.Drifted || !input.AutoReapply {
+			continue
+		}
+
+		activityInput := IntegratedServiceReapplyActivityInput{
+			ClusterID:             target.ClusterID,
+			IntegratedServiceName: target.IntegratedServiceName,
+		}
+
+		if err := workflow.ExecuteActivity(ctx, IntegratedServiceReapplyActivityName, activityInput).Get(ctx, nil); err != nil {
+			logger.Error("failed to reapply drifted integrated service", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// IntegratedServiceStatusLister lists integrated services by status.
+type IntegratedServiceStatusLister interface {
+	// GetIntegratedServicesByStatus returns integrated services in any of the specified statuses grouped by cluster ID.
+	GetIntegratedServicesByStatus(ctx context.Context, statuses ...string) (map[uint][]integratedservices.IntegratedService, error)
+}
+
+// IntegratedServiceListDriftTargetsActivityInput defines the inputs of the IntegratedServiceListDriftTargetsActivity
+type IntegratedServiceListDriftTargetsActivityInput struct{}
+
+// IntegratedServiceListDriftTargetsActivity lists the integrated services that should be checked for drift
+type IntegratedServiceListDriftTargetsActivity struct {
+	integratedServices IntegratedServiceStatusLister
+}
+
+// MakeIntegratedServiceListDriftTargetsActivity returns a new IntegratedServiceListDriftTargetsActivity
+func MakeIntegratedServiceListDriftTargetsActivity(integratedServices IntegratedServiceStatusLister) IntegratedServiceListDriftTargetsActivity {
+	return IntegratedServiceListDriftTargetsActivity{
+		integratedServices: integratedServices,
+	}
+}
+
+// Execute executes the activity
+func (a IntegratedServiceListDriftTargetsActivity) Execute(ctx context.Context, _ IntegratedServiceListDriftTargetsActivityInput) ([]IntegratedServiceDriftTarget, error) {
+	integratedServices, err := a.integratedServices.GetIntegratedServicesByStatus(ctx, integratedservices.IntegratedServiceStatusActive, integratedservices.IntegratedServiceStatusDrifted)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]IntegratedServiceDriftTarget, 0, len(integratedServices))
+	for clusterID, services := range integratedServices {
+		for _, service := range services {
+			targets = append(targets, IntegratedServiceDriftTarget{
+				ClusterID:             clusterID,
+				IntegratedServiceName: service.Name,
+			})
+		}
+	}
+
+	return targets, nil
+}
+
+// IntegratedServiceDetectDriftActivityInput defines the inputs of the IntegratedServiceDetectDriftActivity
+type IntegratedServiceDetectDriftActivityInput struct {
+	ClusterID             uint
+	IntegratedServiceName string
+}
+
+// IntegratedServiceDetectDriftActivityOutput defines the outputs of the IntegratedServiceDetectDriftActivity
+type IntegratedServiceDetectDriftActivityOutput struct {
+	Drifted bool
+	Changes []integratedservices.IntegratedServiceSpecChange
+}
+
+// IntegratedServiceDetectDriftActivity checks an integrated service for drift and updates its status accordingly
+type IntegratedServiceDetectDriftActivity struct {
+	integratedServices integratedservices.IntegratedServiceRepository
+	driftDetector      integratedservices.IntegratedServiceDriftDetector
+}
+
+// MakeIntegratedServiceDetectDriftActivity returns a new IntegratedServiceDetectDriftActivity
+func MakeIntegratedServiceDetectDriftActivity(
+	integratedServices integratedservices.IntegratedServiceRepository,
+	driftDetector integratedservices.IntegratedServiceDriftDetector,
+) IntegratedServiceDetectDriftActivity {
+	return IntegratedServiceDetectDriftActivity{
+		integratedServices: integratedServices,
+		driftDetector:      driftDetector,
+	}
+}
+
+// Execute executes the activity
+func (a IntegratedServiceDetectDriftActivity) Execute(ctx context.Context, input IntegratedServiceDetectDriftActivityInput) (IntegratedServiceDetectDriftActivityOutput, error) {
+	integratedService, err := a.integratedServices.GetIntegratedService(ctx, input.ClusterID, input.IntegratedServiceName)
+	if err != nil {
+		return IntegratedServiceDetectDriftActivityOutput{}, err
+	}
+
+	// the integrated service changed since it was listed
+	if !isDriftCheckable(integratedService.Status) {
+		return IntegratedServiceDetectDriftActivityOutput{}, nil
+	}
+
+	changes, err := a.driftDetector.DetectDrift(ctx, input.ClusterID, input.IntegratedServiceName, integratedService.Spec)
+	if err != nil {
+		return IntegratedServiceDetectDriftActivityOutput{}, errors.WrapIfWithDetails(err, "failed to detect drift", "clusterId", input.ClusterID, "integratedService", input.IntegratedServiceName)
+	}
+
+	status := integratedservices.IntegratedServiceStatusActive
+	if len(changes) > 0 {
+		status = integratedservices.IntegratedServiceStatusDrifted
+	}
+
+	if status != integratedService.Status {
+		if err := a.integratedServices.UpdateIntegratedServiceStatus(ctx, input.ClusterID, input.IntegratedServiceName, status); err != nil {
+			return IntegratedServiceDetectDriftActivityOutput{}, err
+		}
+	}
+
+	return IntegratedServiceDetectDriftActivityOutput{
+		Drifted: len(changes) > 0,
+		Changes: changes,
+	}, nil
+}
+
+func isDriftCheckable(status string) bool {
+	return status == integratedservices.IntegratedServiceStatusActive || status == integratedservices.IntegratedServiceStatusDrifted
+}
+
+// IntegratedServiceReapplyActivityInput defines the inputs of the IntegratedServiceReapplyActivity
+type IntegratedServiceReapplyActivityInput struct {
+	ClusterID             uint
+	IntegratedServiceName string
+
+	// Force reapplies active integrated services as well (eg. when a secret they use changes)
+	Force bool
+}
+
+// IntegratedServiceReapplyActivity dispatches the application of the stored specification of a drifted integrated service
+type IntegratedServiceReapplyActivity struct {
+	integratedServices  integratedservices.IntegratedServiceRepository
+	operationDispatcher integratedservices.IntegratedServiceOperationDispatcher
+}
+
+// MakeIntegratedServiceReapplyActivity returns a new IntegratedServiceReapplyActivity
+func MakeIntegratedServiceReapplyActivity(
+	integratedServices integratedservices.IntegratedServiceRepository,
+	operationDispatcher integratedservices.IntegratedServiceOperationDispatcher,
+) IntegratedServiceReapplyActivity {
+	return IntegratedServiceReapplyActivity{
+		integratedServices:  integratedServices,
+		operationDispatcher: operationDispatcher,
+	}
+}
+
+// Execute executes the activity
+func (a IntegratedServiceReapplyActivity) Execute(ctx context.Context, input IntegratedServiceReapplyActivityInput) error {
+	integratedService, err := a.integratedServices.GetIntegratedService(ctx, input.ClusterID, input.IntegratedServiceName)
+	if err != nil {
+		return err
+	}
+
+	// the integrated service changed since drift was detected
+	if integratedService.Status != integratedservices.IntegratedServiceStatusDrifted && !(input.Force && isDriftCheckable(integratedService.Status)) {
+		return nil
+	}
+
+	return a.operationDispatcher.DispatchApply(ctx, input.ClusterID, input.IntegratedServiceName, integratedService.Spec)
+}
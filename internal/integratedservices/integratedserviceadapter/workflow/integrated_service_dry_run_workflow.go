@@ -0,0 +1,100 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+// IntegratedServiceDryRunWorkflowName is the name the IntegratedServiceDryRunWorkflow is registered under
+const IntegratedServiceDryRunWorkflowName = "integrated-service-dry-run"
+
+// IntegratedServiceDryRunActivityName is the name the IntegratedServiceDryRunActivity is registered under
+const IntegratedServiceDryRunActivityName = "integrated-service-dry-run"
+
+// ErrReasonDryRunNotSupported is the custom error reason returned when an integrated service does not support dry runs
+const ErrReasonDryRunNotSupported = "DRY_RUN_NOT_SUPPORTED"
+
+// IntegratedServiceDryRunWorkflowInput defines the inputs of the IntegratedServiceDryRunWorkflow
+type IntegratedServiceDryRunWorkflowInput struct {
+	ClusterID             uint
+	IntegratedServiceName string
+	IntegratedServiceSpec integratedservices.IntegratedServiceSpec
+}
+
+// IntegratedServiceDryRunWorkflow renders the resources of an integrated service specification
+func IntegratedServiceDryRunWorkflow(ctx workflow.Context, input IntegratedServiceDryRunWorkflowInput) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 1 * time.Minute,
+		StartToCloseTimeout:    2 * time.Minute,
+	})
+
+	activityInput := IntegratedServiceDryRunActivityInput{
+		ClusterID:             input.ClusterID,
+		IntegratedServiceName: input.IntegratedServiceName,
+		IntegratedServiceSpec: input.IntegratedServiceSpec,
+	}
+
+	var output integratedservices.IntegratedServiceDryRunOutput
+	err := workflow.ExecuteActivity(ctx, IntegratedServiceDryRunActivityName, activityInput).Get(ctx, &output)
+
+	return output, err
+}
+
+// IntegratedServiceDryRunActivityInput defines the inputs of the IntegratedServiceDryRunActivity
+type IntegratedServiceDryRunActivityInput struct {
+	ClusterID             uint
+	IntegratedServiceName string
+	IntegratedServiceSpec integratedservices.IntegratedServiceSpec
+}
+
+// IntegratedServiceDryRunActivity renders the resources of an integrated service specification using its operator
+type IntegratedServiceDryRunActivity struct {
+	integratedServices integratedservices.IntegratedServiceOperatorRegistry
+}
+
+// MakeIntegratedServiceDryRunActivity returns a new IntegratedServiceDryRunActivity
+func MakeIntegratedServiceDryRunActivity(integratedServices integratedservices.IntegratedServiceOperatorRegistry) IntegratedServiceDryRunActivity {
+	return IntegratedServiceDryRunActivity{
+		integratedServices: integratedServices,
+	}
+}
+
+// Execute executes the activity
+func (a IntegratedServiceDryRunActivity) Execute(ctx context.Context, input IntegratedServiceDryRunActivityInput) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	f, err := a.integratedServices.GetIntegratedServiceOperator(input.IntegratedServiceName)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	output, err := f.DryRun(ctx, input.ClusterID, input.IntegratedServiceSpec)
+	if err != nil {
+		var notSupportedErr integratedservices.DryRunNotSupportedError
+		if errors.As(err, &notSupportedErr) {
+			return integratedservices.IntegratedServiceDryRunOutput{}, cadence.NewCustomError(ErrReasonDryRunNotSupported, input.IntegratedServiceName)
+		}
+
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,153 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+// IntegratedServiceSecretRotatedWorkflowName is the name the IntegratedServiceSecretRotatedWorkflow is registered under
+const IntegratedServiceSecretRotatedWorkflowName = "integrated-service-secret-rotated"
+
+// IntegratedServiceListSecretReferencesActivityName is the name the IntegratedServiceListSecretReferencesActivity is registered under
+const IntegratedServiceListSecretReferencesActivityName = "integrated-service-list-secret-references"
+
+// IntegratedServiceSecretRotatedWorkflowInput defines the inputs of the IntegratedServiceSecretRotatedWorkflow
+type IntegratedServiceSecretRotatedWorkflowInput struct {
+	OrganizationID uint
+	SecretID       string
+}
+
+// IntegratedServiceSecretRotatedWorkflow reapplies the integrated services referencing a rotated secret,
+// so that the new values of the secret are propagated to the clusters.
+func IntegratedServiceSecretRotatedWorkflow(ctx workflow.Context, input IntegratedServiceSecretRotatedWorkflowInput) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+	})
+
+	logger := workflow.GetLogger(ctx).With(zap.Uint("organizationId", input.OrganizationID), zap.String("secretId", input.SecretID))
+
+	var targets []IntegratedServiceDriftTarget
+	if err := workflow.ExecuteActivity(ctx, IntegratedServiceListSecretReferencesActivityName, IntegratedServiceListSecretReferencesActivityInput(input)).Get(ctx, &targets); err != nil {
+		return err
+	}
+
+	for _, target := range targets {
+		activityInput := IntegratedServiceReapplyActivityInput{
+			ClusterID:             target.ClusterID,
+			IntegratedServiceName: target.IntegratedServiceName,
+			Force:                 true,
+		}
+
+		if err := workflow.ExecuteActivity(ctx, IntegratedServiceReapplyActivityName, activityInput).Get(ctx, nil); err != nil {
+			logger.Error(
+				"failed to reapply integrated service referencing rotated secret",
+				zap.Uint("clusterId", target.ClusterID),
+				zap.String("integratedService", target.IntegratedServiceName),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// OrganizationIntegratedServiceStatusLister lists the integrated services of an organization by status.
+type OrganizationIntegratedServiceStatusLister interface {
+	// GetOrganizationIntegratedServicesByStatus returns the integrated services of an organization's clusters in any of the specified statuses grouped by cluster ID.
+	GetOrganizationIntegratedServicesByStatus(ctx context.Context, organizationID uint, statuses ...string) (map[uint][]integratedservices.IntegratedService, error)
+}
+
+// IntegratedServiceListSecretReferencesActivityInput defines the inputs of the IntegratedServiceListSecretReferencesActivity
+type IntegratedServiceListSecretReferencesActivityInput struct {
+	OrganizationID uint
+	SecretID       string
+}
+
+// IntegratedServiceListSecretReferencesActivity lists the active integrated services referencing a secret
+type IntegratedServiceListSecretReferencesActivity struct {
+	integratedServices OrganizationIntegratedServiceStatusLister
+}
+
+// MakeIntegratedServiceListSecretReferencesActivity returns a new IntegratedServiceListSecretReferencesActivity
+func MakeIntegratedServiceListSecretReferencesActivity(integratedServices OrganizationIntegratedServiceStatusLister) IntegratedServiceListSecretReferencesActivity {
+	return IntegratedServiceListSecretReferencesActivity{
+		integratedServices: integratedServices,
+	}
+}
+
+// Execute executes the activity
+func (a IntegratedServiceListSecretReferencesActivity) Execute(ctx context.Context, input IntegratedServiceListSecretReferencesActivityInput) ([]IntegratedServiceDriftTarget, error) {
+	integratedServices, err := a.integratedServices.GetOrganizationIntegratedServicesByStatus(
+		ctx,
+		input.OrganizationID,
+		integratedservices.IntegratedServiceStatusActive,
+		integratedservices.IntegratedServiceStatusDrifted,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []IntegratedServiceDriftTarget
+	for clusterID, services := range integratedServices {
+		for _, service := range services {
+			if !referencesSecret(service.Spec, input.SecretID) {
+				continue
+			}
+
+			targets = append(targets, IntegratedServiceDriftTarget{
+				ClusterID:             clusterID,
+				IntegratedServiceName: service.Name,
+			})
+		}
+	}
+
+	return targets, nil
+}
+
+// secretIDSpecKey is the key integrated service specifications reference secrets with
+const secretIDSpecKey = "secretId"
+
+// referencesSecret checks whether an integrated service specification (or any part of it) references a secret.
+func referencesSecret(spec interface{}, secretID string) bool {
+	switch s := spec.(type) {
+	case map[string]interface{}:
+		for key, value := range s {
+			if key == secretIDSpecKey && value == secretID {
+				return true
+			}
+
+			if referencesSecret(value, secretID) {
+				return true
+			}
+		}
+
+	case []interface{}:
+		for _, value := range s {
+			if referencesSecret(value, secretID) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
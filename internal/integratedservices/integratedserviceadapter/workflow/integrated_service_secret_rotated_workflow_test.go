@@ -0,0 +1,86 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+// nolint: gochecknoinits
+func init() {
+	workflow.RegisterWithOptions(IntegratedServiceSecretRotatedWorkflow, workflow.RegisterOptions{Name: IntegratedServiceSecretRotatedWorkflowName})
+	activity.RegisterWithOptions(IntegratedServiceListSecretReferencesActivity{}.Execute, activity.RegisterOptions{Name: IntegratedServiceListSecretReferencesActivityName})
+	activity.RegisterWithOptions(IntegratedServiceReapplyActivity{}.Execute, activity.RegisterOptions{Name: IntegratedServiceReapplyActivityName})
+}
+
+func TestIntegratedServiceSecretRotatedWorkflow(t *testing.T) {
+	env := (&testsuite.WorkflowTestSuite{}).NewTestWorkflowEnvironment()
+
+	input := IntegratedServiceSecretRotatedWorkflowInput{OrganizationID: 1, SecretID: "secret"}
+
+	env.OnActivity(IntegratedServiceListSecretReferencesActivityName, mock.Anything, IntegratedServiceListSecretReferencesActivityInput(input)).
+		Return([]IntegratedServiceDriftTarget{{ClusterID: 1, IntegratedServiceName: "dns"}, {ClusterID: 2, IntegratedServiceName: "logging"}}, nil)
+	env.OnActivity(IntegratedServiceReapplyActivityName, mock.Anything, IntegratedServiceReapplyActivityInput{ClusterID: 1, IntegratedServiceName: "dns", Force: true}).
+		Return(errors.New("cluster unreachable"))
+	env.OnActivity(IntegratedServiceReapplyActivityName, mock.Anything, IntegratedServiceReapplyActivityInput{ClusterID: 2, IntegratedServiceName: "logging", Force: true}).
+		Return(nil)
+
+	env.ExecuteWorkflow(IntegratedServiceSecretRotatedWorkflowName, input)
+
+	require.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+}
+
+type organizationIntegratedServiceStatusListerFunc func(ctx context.Context, organizationID uint, statuses ...string) (map[uint][]integratedservices.IntegratedService, error)
+
+func (fn organizationIntegratedServiceStatusListerFunc) GetOrganizationIntegratedServicesByStatus(ctx context.Context, organizationID uint, statuses ...string) (map[uint][]integratedservices.IntegratedService, error) {
+	return fn(ctx, organizationID, statuses...)
+}
+
+func TestIntegratedServiceListSecretReferencesActivity(t *testing.T) {
+	lister := organizationIntegratedServiceStatusListerFunc(func(ctx context.Context, organizationID uint, statuses ...string) (map[uint][]integratedservices.IntegratedService, error) {
+		assert.Equal(t, uint(1), organizationID)
+
+		return map[uint][]integratedservices.IntegratedService{
+			1: {
+				{Name: "dns", Spec: integratedservices.IntegratedServiceSpec{"clusterDomain": "example.org", "externalDns": map[string]interface{}{"provider": map[string]interface{}{"secretId": "secret"}}}},
+				{Name: "monitoring", Spec: integratedservices.IntegratedServiceSpec{"grafana": map[string]interface{}{"secretId": "other"}}},
+			},
+			2: {
+				{Name: "logging", Spec: integratedservices.IntegratedServiceSpec{"clusterOutputs": []interface{}{map[string]interface{}{"secretId": "secret"}}}},
+			},
+		}, nil
+	})
+
+	targets, err := MakeIntegratedServiceListSecretReferencesActivity(lister).Execute(context.Background(), IntegratedServiceListSecretReferencesActivityInput{OrganizationID: 1, SecretID: "secret"})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []IntegratedServiceDriftTarget{
+		{ClusterID: 1, IntegratedServiceName: "dns"},
+		{ClusterID: 2, IntegratedServiceName: "logging"},
+	}, targets)
+}
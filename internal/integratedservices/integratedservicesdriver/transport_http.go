@@ -53,6 +53,14 @@ func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...ki
 			options...,
 		))
 
+		// dry runs share the paths of activation and update requests
+		router.Methods(http.MethodPost, http.MethodPut).Queries("dryRun", "true").Handler(kithttp.NewServer(
+			endpoints.DryRun,
+			decodeDryRunIntegratedServiceRequest,
+			kitxhttp.ErrorResponseEncoder(encodeDryRunIntegratedServiceResponse, errorEncoder),
+			options...,
+		))
+
 		router.Methods(http.MethodPost).Handler(kithttp.NewServer(
 			endpoints.Activate,
 			decodeActivateIntegratedServiceRequest,
@@ -74,6 +82,20 @@ func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...ki
 			options...,
 		))
 	}
+
+	router.Methods(http.MethodGet).Path(fmt.Sprintf("/{%s}/revisions", integratedServiceNameParamKey)).Handler(kithttp.NewServer(
+		endpoints.ListRevisions,
+		decodeListIntegratedServiceRevisionsRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListIntegratedServiceRevisionsResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path(fmt.Sprintf("/{%s}/rollback", integratedServiceNameParamKey)).Handler(kithttp.NewServer(
+		endpoints.Rollback,
+		decodeRollbackIntegratedServiceRequest,
+		kitxhttp.ErrorResponseEncoder(encodeRollbackIntegratedServiceResponse, errorEncoder),
+		options...,
+	))
 }
 
 func decodeListIntegratedServicesRequest(_ context.Context, req *http.Request) (interface{}, error) {
@@ -220,6 +242,106 @@ func encodeUpdateIntegratedServiceResponse(_ context.Context, w http.ResponseWri
 	return nil
 }
 
+func decodeDryRunIntegratedServiceRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	clusterID, err := getClusterID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName, err := getServiceName(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec map[string]interface{}
+	if req.Method == http.MethodPost {
+		var requestBody pipeline.ActivateIntegratedServiceRequest
+		if err := decodeRequestBody(req, &requestBody); err != nil {
+			return nil, err
+		}
+
+		spec = requestBody.Spec
+	} else {
+		var requestBody pipeline.UpdateIntegratedServiceRequest
+		if err := decodeRequestBody(req, &requestBody); err != nil {
+			return nil, err
+		}
+
+		spec = requestBody.Spec
+	}
+
+	return DryRunRequest{
+		ClusterID:   clusterID,
+		ServiceName: serviceName,
+		Spec:        spec,
+	}, nil
+}
+
+func encodeDryRunIntegratedServiceResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(DryRunResponse)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	return json.NewEncoder(w).Encode(resp.DryRun)
+}
+
+func decodeListIntegratedServiceRevisionsRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	clusterID, err := getClusterID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName, err := getServiceName(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListRevisionsRequest{
+		ClusterID:   clusterID,
+		ServiceName: serviceName,
+	}, nil
+}
+
+func encodeListIntegratedServiceRevisionsResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListRevisionsResponse)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	return json.NewEncoder(w).Encode(resp.Revisions)
+}
+
+func decodeRollbackIntegratedServiceRequest(_ context.Context, req *http.Request) (interface{}, error) {
+	clusterID, err := getClusterID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName, err := getServiceName(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var requestBody struct {
+		Revision uint `json:"revision"`
+	}
+	if err := decodeRequestBody(req, &requestBody); err != nil {
+		return nil, err
+	}
+
+	return RollbackRequest{
+		ClusterID:   clusterID,
+		ServiceName: serviceName,
+		Revision:    requestBody.Revision,
+	}, nil
+}
+
+func encodeRollbackIntegratedServiceResponse(_ context.Context, w http.ResponseWriter, _ interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+
+	return nil
+}
+
 func decodeRequestBody(req *http.Request, result interface{}) error {
 	if err := json.NewDecoder(req.Body).Decode(result); err != nil {
 		return invalidRequestBodyError{errors.WrapIf(err, "failed to decode request body")}
@@ -224,3 +224,104 @@ func TestRegisterHTTPHandlers_Update(t *testing.T) {
 
 	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
 }
+
+func TestRegisterHTTPHandlers_DryRun(t *testing.T) {
+	handler := mux.NewRouter()
+	RegisterHTTPHandlers(
+		Endpoints{
+			DryRun: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				req := request.(DryRunRequest)
+
+				return DryRunResponse{
+					DryRun: integratedservices.IntegratedServiceDryRun{
+						Output: integratedservices.IntegratedServiceDryRunOutput{
+							Releases: []integratedservices.HelmReleaseDryRun{
+								{
+									ReleaseName: req.ServiceName,
+									Values:      req.Spec,
+								},
+							},
+						},
+						Changes: []integratedservices.IntegratedServiceSpecChange{
+							{
+								Path:      "hello",
+								Operation: integratedservices.SpecChangeAdd,
+								NewValue:  "world",
+							},
+						},
+					},
+				}, nil
+			},
+			Activate: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				t.Fatal("dry run request should not activate the integrated service")
+
+				return nil, nil
+			},
+			Update: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				t.Fatal("dry run request should not update the integrated service")
+
+				return nil, nil
+			},
+		},
+		handler.PathPrefix("/clusters/{clusterId}/services").Subrouter(),
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	body, err := json.Marshal(pipeline.UpdateIntegratedServiceRequest{
+		Spec: map[string]interface{}{
+			"hello": "world",
+		},
+	})
+	require.NoError(t, err)
+
+	for _, method := range []string{http.MethodPost, http.MethodPut} {
+		method := method
+		t.Run(method, func(t *testing.T) {
+			req, err := http.NewRequest(method, ts.URL+"/clusters/1/services/hello-world?dryRun=true", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			resp, err := ts.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var dryRun integratedservices.IntegratedServiceDryRun
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&dryRun))
+
+			require.Len(t, dryRun.Output.Releases, 1)
+			assert.Equal(t, "hello-world", dryRun.Output.Releases[0].ReleaseName)
+			assert.Equal(t, map[string]interface{}{"hello": "world"}, dryRun.Output.Releases[0].Values)
+			assert.Equal(t, []integratedservices.IntegratedServiceSpecChange{{Path: "hello", Operation: integratedservices.SpecChangeAdd, NewValue: "world"}}, dryRun.Changes)
+		})
+	}
+}
+
+func TestRegisterHTTPHandlers_Rollback(t *testing.T) {
+	handler := mux.NewRouter()
+	RegisterHTTPHandlers(
+		Endpoints{
+			Rollback: func(ctx context.Context, request interface{}) (response interface{}, err error) {
+				assert.Equal(t, RollbackRequest{ClusterID: 1, ServiceName: "example", Revision: 2}, request)
+
+				return RollbackResponse{}, nil
+			},
+		},
+		handler.PathPrefix("/clusters/{clusterId}/services").Subrouter(),
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	resp, err := ts.Client().Post(ts.URL+"/clusters/1/services/example/rollback", "application/json", bytes.NewReader([]byte(`{"revision": 2}`)))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+}
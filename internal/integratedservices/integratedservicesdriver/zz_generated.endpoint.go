@@ -26,11 +26,14 @@ type serviceError interface {
 // meant to be used as a helper struct, to collect all of the endpoints into a
 // single parameter.
 type Endpoints struct {
-	Activate   endpoint.Endpoint
-	Deactivate endpoint.Endpoint
-	Details    endpoint.Endpoint
-	List       endpoint.Endpoint
-	Update     endpoint.Endpoint
+	Activate      endpoint.Endpoint
+	Deactivate    endpoint.Endpoint
+	Details       endpoint.Endpoint
+	DryRun        endpoint.Endpoint
+	List          endpoint.Endpoint
+	ListRevisions endpoint.Endpoint
+	Rollback      endpoint.Endpoint
+	Update        endpoint.Endpoint
 }
 
 // MakeEndpoints returns a(n) Endpoints struct where each endpoint invokes
@@ -39,11 +42,14 @@ func MakeEndpoints(service integratedservices.Service, middleware ...endpoint.Mi
 	mw := kitxendpoint.Combine(middleware...)
 
 	return Endpoints{
-		Activate:   kitxendpoint.OperationNameMiddleware("integratedservices.Activate")(mw(MakeActivateEndpoint(service))),
-		Deactivate: kitxendpoint.OperationNameMiddleware("integratedservices.Deactivate")(mw(MakeDeactivateEndpoint(service))),
-		Details:    kitxendpoint.OperationNameMiddleware("integratedservices.Details")(mw(MakeDetailsEndpoint(service))),
-		List:       kitxendpoint.OperationNameMiddleware("integratedservices.List")(mw(MakeListEndpoint(service))),
-		Update:     kitxendpoint.OperationNameMiddleware("integratedservices.Update")(mw(MakeUpdateEndpoint(service))),
+		Activate:      kitxendpoint.OperationNameMiddleware("integratedservices.Activate")(mw(MakeActivateEndpoint(service))),
+		Deactivate:    kitxendpoint.OperationNameMiddleware("integratedservices.Deactivate")(mw(MakeDeactivateEndpoint(service))),
+		Details:       kitxendpoint.OperationNameMiddleware("integratedservices.Details")(mw(MakeDetailsEndpoint(service))),
+		DryRun:        kitxendpoint.OperationNameMiddleware("integratedservices.DryRun")(mw(MakeDryRunEndpoint(service))),
+		List:          kitxendpoint.OperationNameMiddleware("integratedservices.List")(mw(MakeListEndpoint(service))),
+		ListRevisions: kitxendpoint.OperationNameMiddleware("integratedservices.ListRevisions")(mw(MakeListRevisionsEndpoint(service))),
+		Rollback:      kitxendpoint.OperationNameMiddleware("integratedservices.Rollback")(mw(MakeRollbackEndpoint(service))),
+		Update:        kitxendpoint.OperationNameMiddleware("integratedservices.Update")(mw(MakeUpdateEndpoint(service))),
 	}
 }
 
@@ -157,6 +163,48 @@ func MakeDetailsEndpoint(service integratedservices.Service) endpoint.Endpoint {
 	}
 }
 
+// DryRunRequest is a request struct for DryRun endpoint.
+type DryRunRequest struct {
+	ClusterID   uint
+	ServiceName string
+	Spec        map[string]interface{}
+}
+
+// DryRunResponse is a response struct for DryRun endpoint.
+type DryRunResponse struct {
+	DryRun integratedservices.IntegratedServiceDryRun
+	Err    error
+}
+
+func (r DryRunResponse) Failed() error {
+	return r.Err
+}
+
+// MakeDryRunEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeDryRunEndpoint(service integratedservices.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DryRunRequest)
+
+		dryRun, err := service.DryRun(ctx, req.ClusterID, req.ServiceName, req.Spec)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return DryRunResponse{
+					DryRun: dryRun,
+					Err:    err,
+				}, nil
+			}
+
+			return DryRunResponse{
+				DryRun: dryRun,
+				Err:    err,
+			}, err
+		}
+
+		return DryRunResponse{DryRun: dryRun}, nil
+	}
+}
+
 // ListRequest is a request struct for List endpoint.
 type ListRequest struct {
 	ClusterID uint
@@ -197,6 +245,82 @@ func MakeListEndpoint(service integratedservices.Service) endpoint.Endpoint {
 	}
 }
 
+// ListRevisionsRequest is a request struct for ListRevisions endpoint.
+type ListRevisionsRequest struct {
+	ClusterID   uint
+	ServiceName string
+}
+
+// ListRevisionsResponse is a response struct for ListRevisions endpoint.
+type ListRevisionsResponse struct {
+	Revisions []integratedservices.IntegratedServiceRevision
+	Err       error
+}
+
+func (r ListRevisionsResponse) Failed() error {
+	return r.Err
+}
+
+// MakeListRevisionsEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeListRevisionsEndpoint(service integratedservices.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListRevisionsRequest)
+
+		revisions, err := service.ListRevisions(ctx, req.ClusterID, req.ServiceName)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return ListRevisionsResponse{
+					Err:       err,
+					Revisions: revisions,
+				}, nil
+			}
+
+			return ListRevisionsResponse{
+				Err:       err,
+				Revisions: revisions,
+			}, err
+		}
+
+		return ListRevisionsResponse{Revisions: revisions}, nil
+	}
+}
+
+// RollbackRequest is a request struct for Rollback endpoint.
+type RollbackRequest struct {
+	ClusterID   uint
+	ServiceName string
+	Revision    uint
+}
+
+// RollbackResponse is a response struct for Rollback endpoint.
+type RollbackResponse struct {
+	Err error
+}
+
+func (r RollbackResponse) Failed() error {
+	return r.Err
+}
+
+// MakeRollbackEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeRollbackEndpoint(service integratedservices.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(RollbackRequest)
+
+		err := service.Rollback(ctx, req.ClusterID, req.ServiceName, req.Revision)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return RollbackResponse{Err: err}, nil
+			}
+
+			return RollbackResponse{Err: err}, err
+		}
+
+		return RollbackResponse{}, nil
+	}
+}
+
 // UpdateRequest is a request struct for Update endpoint.
 type UpdateRequest struct {
 	ClusterID   uint
@@ -16,6 +16,7 @@ package integratedservices
 
 import (
 	"context"
+	"time"
 
 	"emperror.dev/errors"
 )
@@ -28,6 +29,15 @@ type IntegratedService struct {
 	Status string                  `json:"status"`
 }
 
+// IntegratedServiceRevision represents a specification applied to an integrated service.
+type IntegratedServiceRevision struct {
+	Revision  uint                  `json:"revision"`
+	Spec      IntegratedServiceSpec `json:"spec"`
+	Status    string                `json:"status"`
+	CreatedAt time.Time             `json:"createdAt"`
+	CreatedBy uint                  `json:"createdBy,omitempty"`
+}
+
 // IntegratedServiceSpec represents an integrated service's specification (i.e. its input parameters).
 type IntegratedServiceSpec = map[string]interface{}
 
@@ -43,6 +53,7 @@ const (
 	IntegratedServiceStatusPending  IntegratedServiceStatus = "PENDING"
 	IntegratedServiceStatusActive   IntegratedServiceStatus = "ACTIVE"
 	IntegratedServiceStatusError    IntegratedServiceStatus = "ERROR"
+	IntegratedServiceStatusDrifted  IntegratedServiceStatus = "DRIFTED"
 )
 
 // IntegratedServiceManagerRegistry contains integrated service managers.
@@ -76,6 +87,12 @@ type IntegratedServiceRepository interface {
 
 	// DeleteIntegratedService deletes an integrated service.
 	DeleteIntegratedService(ctx context.Context, clusterID uint, integratedServiceName string) error
+
+	// GetIntegratedServiceRevisions retrieves the applied specifications of an integrated service (latest first).
+	GetIntegratedServiceRevisions(ctx context.Context, clusterID uint, integratedServiceName string) ([]IntegratedServiceRevision, error)
+
+	// GetIntegratedServiceRevision retrieves a single applied specification of an integrated service.
+	GetIntegratedServiceRevision(ctx context.Context, clusterID uint, integratedServiceName string, revision uint) (IntegratedServiceRevision, error)
 }
 
 // IsIntegratedServiceNotFoundError returns true when the specified error is a "integrated service not found" error
@@ -86,6 +103,13 @@ func IsIntegratedServiceNotFoundError(err error) bool {
 	return errors.As(err, &notFoundErr) && notFoundErr.IntegratedServiceNotFound()
 }
 
+// IntegratedServiceDriftDetector detects differences between the applied specification of an integrated service and its actual state in the cluster.
+type IntegratedServiceDriftDetector interface {
+	// DetectDrift returns the changes required to bring the cluster state back to the specification.
+	// It returns no changes if the integrated service does not support drift detection.
+	DetectDrift(ctx context.Context, clusterID uint, integratedServiceName string, spec IntegratedServiceSpec) ([]IntegratedServiceSpecChange, error)
+}
+
 // IntegratedServiceManager is a collection of integrated service specific methods that are used synchronously when responding to integrated service related requests.
 type IntegratedServiceManager interface {
 	IntegratedServiceOutputProducer
@@ -170,6 +194,10 @@ type IntegratedServiceOperationDispatcher interface {
 
 	// DispatchDeactivate starts deactivating an integrated service asynchronously.
 	DispatchDeactivate(ctx context.Context, clusterID uint, integratedServiceName string, spec IntegratedServiceSpec) error
+
+	// DispatchDryRun renders the resources of a desired state for an integrated service without applying them.
+	// Unlike the other operations, it waits for the result.
+	DispatchDryRun(ctx context.Context, clusterID uint, integratedServiceName string, spec IntegratedServiceSpec) (IntegratedServiceDryRunOutput, error)
 }
 
 // IntegratedServiceOperator defines the operations that can be applied to an integrated service.
@@ -180,6 +208,10 @@ type IntegratedServiceOperator interface {
 	// Deactivate deactivates an integrated service on the given cluster.
 	Deactivate(ctx context.Context, clusterID uint, spec IntegratedServiceSpec) error
 
+	// DryRun renders the resources Apply would create for a desired state without changing anything on the given cluster.
+	// Operators not supporting dry runs should return a DryRunNotSupportedError.
+	DryRun(ctx context.Context, clusterID uint, spec IntegratedServiceSpec) (IntegratedServiceDryRunOutput, error)
+
 	// Name returns the integrated service's name.
 	Name() string
 }
@@ -112,3 +112,7 @@ func (d dummyIntegratedServiceOperator) Apply(ctx context.Context, clusterID uin
 func (d dummyIntegratedServiceOperator) Deactivate(ctx context.Context, clusterID uint, spec IntegratedServiceSpec) error {
 	return nil
 }
+
+func (d dummyIntegratedServiceOperator) DryRun(ctx context.Context, clusterID uint, spec IntegratedServiceSpec) (IntegratedServiceDryRunOutput, error) {
+	return IntegratedServiceDryRunOutput{}, nil
+}
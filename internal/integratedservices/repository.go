@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 )
 
 // NewInMemoryIntegratedServiceRepository returns a new in-memory integrated service repository.
@@ -32,6 +33,7 @@ func NewInMemoryIntegratedServiceRepository(integratedServices map[uint][]Integr
 	}
 	return &InMemoryIntegratedServiceRepository{
 		integratedServices: lookup,
+		revisions:          make(map[uint]map[string][]IntegratedServiceRevision),
 	}
 }
 
@@ -39,6 +41,7 @@ func NewInMemoryIntegratedServiceRepository(integratedServices map[uint][]Integr
 // Use it in tests or for development/demo purposes.
 type InMemoryIntegratedServiceRepository struct {
 	integratedServices map[uint]map[string]IntegratedService
+	revisions          map[uint]map[string][]IntegratedServiceRevision
 
 	mu sync.RWMutex
 }
@@ -96,6 +99,19 @@ func (r *InMemoryIntegratedServiceRepository) SaveIntegratedService(ctx context.
 		Status: status,
 	}
 
+	revisions, ok := r.revisions[clusterID]
+	if !ok {
+		revisions = make(map[string][]IntegratedServiceRevision)
+		r.revisions[clusterID] = revisions
+	}
+
+	revisions[integratedServiceName] = append(revisions[integratedServiceName], IntegratedServiceRevision{
+		Revision:  uint(len(revisions[integratedServiceName]) + 1),
+		Spec:      spec,
+		Status:    status,
+		CreatedAt: time.Now(),
+	})
+
 	return nil
 }
 
@@ -108,6 +124,15 @@ func (r *InMemoryIntegratedServiceRepository) UpdateIntegratedServiceStatus(ctx
 		if integratedService, ok := integratedServices[integratedServiceName]; ok {
 			integratedService.Status = status
 			integratedServices[integratedServiceName] = integratedService
+
+			// the latest pending revision gets the resulting status of the operation
+			if revisions := r.revisions[clusterID][integratedServiceName]; len(revisions) > 0 {
+				latest := &revisions[len(revisions)-1]
+				if latest.Status == IntegratedServiceStatusPending {
+					latest.Status = status
+				}
+			}
+
 			return nil
 		}
 	}
@@ -150,12 +175,45 @@ func (r *InMemoryIntegratedServiceRepository) DeleteIntegratedService(ctx contex
 	return nil
 }
 
+// GetIntegratedServiceRevisions returns the revisions of the integrated service (latest first)
+func (r *InMemoryIntegratedServiceRepository) GetIntegratedServiceRevisions(ctx context.Context, clusterID uint, integratedServiceName string) ([]IntegratedServiceRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := r.revisions[clusterID][integratedServiceName]
+
+	result := make([]IntegratedServiceRevision, 0, len(revisions))
+	for i := len(revisions) - 1; i >= 0; i-- {
+		result = append(result, revisions[i])
+	}
+
+	return result, nil
+}
+
+// GetIntegratedServiceRevision returns the specified revision of the integrated service if it is in the repository, otherwise an error is returned
+func (r *InMemoryIntegratedServiceRepository) GetIntegratedServiceRevision(ctx context.Context, clusterID uint, integratedServiceName string, revision uint) (IntegratedServiceRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := r.revisions[clusterID][integratedServiceName]
+	if revision > 0 && revision <= uint(len(revisions)) {
+		return revisions[revision-1], nil
+	}
+
+	return IntegratedServiceRevision{}, integratedServiceRevisionNotFoundError{
+		clusterID:             clusterID,
+		integratedServiceName: integratedServiceName,
+		revision:              revision,
+	}
+}
+
 // Clear removes every entry from the repository
 func (r *InMemoryIntegratedServiceRepository) Clear() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	r.integratedServices = make(map[uint]map[string]IntegratedService)
+	r.revisions = make(map[uint]map[string][]IntegratedServiceRevision)
 }
 
 // Snapshot returns a snapshot of the repository's state that can be restored later
@@ -215,3 +273,33 @@ func (e integratedServiceNotFoundError) Details() []interface{} {
 func (integratedServiceNotFoundError) IntegratedServiceNotFound() bool {
 	return true
 }
+
+type integratedServiceRevisionNotFoundError struct {
+	clusterID             uint
+	integratedServiceName string
+	revision              uint
+}
+
+func (e integratedServiceRevisionNotFoundError) Error() string {
+	return fmt.Sprintf("Revision %d of IntegratedService %q not found for cluster %d.", e.revision, e.integratedServiceName, e.clusterID)
+}
+
+func (e integratedServiceRevisionNotFoundError) Details() []interface{} {
+	return []interface{}{
+		"clusterId", e.clusterID,
+		"integrated service", e.integratedServiceName,
+		"revision", e.revision,
+	}
+}
+
+// NotFound tells a client that this error is related to a resource being not found.
+// Can be used to translate the error to eg. status code.
+func (integratedServiceRevisionNotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the transport layer whether this error should be translated into the transport format
+// or an internal error should be returned instead.
+func (integratedServiceRevisionNotFoundError) ServiceError() bool {
+	return true
+}
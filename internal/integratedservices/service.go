@@ -42,6 +42,15 @@ type Service interface {
 
 	// Update updates a integrated service.
 	Update(ctx context.Context, clusterID uint, serviceName string, spec map[string]interface{}) error
+
+	// DryRun renders the resources of an integrated service specification and compares it to the currently applied one.
+	DryRun(ctx context.Context, clusterID uint, serviceName string, spec map[string]interface{}) (dryRun IntegratedServiceDryRun, err error)
+
+	// ListRevisions lists the specifications applied to an integrated service (latest first).
+	ListRevisions(ctx context.Context, clusterID uint, serviceName string) (revisions []IntegratedServiceRevision, err error)
+
+	// Rollback applies a previous specification of an integrated service again.
+	Rollback(ctx context.Context, clusterID uint, serviceName string, revision uint) error
 }
 
 // MakeIntegratedServiceService returns a new IntegratedServiceService instance.
@@ -284,6 +293,143 @@ func (s IntegratedServiceService) Update(ctx context.Context, clusterID uint, in
 	return nil
 }
 
+// DryRun renders the resources of an integrated service specification without applying it.
+func (s IntegratedServiceService) DryRun(ctx context.Context, clusterID uint, integratedServiceName string, spec map[string]interface{}) (IntegratedServiceDryRun, error) {
+	logger := s.logger.WithContext(ctx).WithFields(map[string]interface{}{"clusterId": clusterID, "integrated service": integratedServiceName})
+	logger.Info("processing integrated service dry run request")
+
+	logger.Debug("retrieving integrated service manager")
+	integratedServiceManager, err := s.integratedServiceManagerRegistry.GetIntegratedServiceManager(integratedServiceName)
+	if err != nil {
+		const msg = "failed to retrieve integrated service manager"
+		logger.Debug(msg)
+		return IntegratedServiceDryRun{}, errors.WrapIf(err, msg)
+	}
+
+	logger.Debug("validating integrated service specification")
+	if err := integratedServiceManager.ValidateSpec(ctx, spec); err != nil {
+		logger.Debug("integrated service specification validation failed")
+		return IntegratedServiceDryRun{}, InvalidIntegratedServiceSpecError{IntegratedServiceName: integratedServiceName, Problem: err.Error()}
+	}
+
+	logger.Debug("retrieving integrated service from repository")
+	var currentSpec IntegratedServiceSpec
+	if integratedService, err := s.integratedServiceRepository.GetIntegratedService(ctx, clusterID, integratedServiceName); err == nil {
+		currentSpec = integratedService.Spec
+	} else if !IsIntegratedServiceNotFoundError(err) {
+		const msg = "failed to retrieve integrated service from repository"
+		logger.Debug(msg)
+		return IntegratedServiceDryRun{}, errors.WrapIf(err, msg)
+	}
+
+	logger.Debug("preparing integrated service specification")
+	preparedSpec, err := integratedServiceManager.PrepareSpec(ctx, clusterID, spec)
+	if err != nil {
+		const msg = "failed to prepare integrated service specification"
+		logger.Debug(msg)
+		return IntegratedServiceDryRun{}, errors.WrapIf(err, msg)
+	}
+
+	logger.Debug("rendering integrated service resources")
+	output, err := s.integratedServiceOperationDispatcher.DispatchDryRun(ctx, clusterID, integratedServiceName, preparedSpec)
+	if err != nil {
+		const msg = "failed to render integrated service resources"
+		logger.Debug(msg)
+		return IntegratedServiceDryRun{}, errors.WrapIfWithDetails(err, msg, "clusterID", clusterID, "integrated service", integratedServiceName)
+	}
+
+	logger.Info("integrated service dry run request processed successfully")
+
+	return IntegratedServiceDryRun{
+		Output:  output,
+		Changes: DiffIntegratedServiceSpecs(currentSpec, spec),
+	}, nil
+}
+
+// ListRevisions returns the specifications applied to an integrated service.
+func (s IntegratedServiceService) ListRevisions(ctx context.Context, clusterID uint, integratedServiceName string) ([]IntegratedServiceRevision, error) {
+	logger := s.logger.WithContext(ctx).WithFields(map[string]interface{}{"clusterId": clusterID, "integrated service": integratedServiceName})
+	logger.Info("listing integrated service revisions")
+
+	logger.Debug("checking integrated service name")
+	if _, err := s.integratedServiceManagerRegistry.GetIntegratedServiceManager(integratedServiceName); err != nil {
+		const msg = "failed to retrieve integrated service manager"
+		logger.Debug(msg)
+		return nil, errors.WrapIf(err, msg)
+	}
+
+	revisions, err := s.integratedServiceRepository.GetIntegratedServiceRevisions(ctx, clusterID, integratedServiceName)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to retrieve integrated service revisions", "clusterId", clusterID, "integrated service", integratedServiceName)
+	}
+
+	logger.Info("integrated service revisions successfully listed")
+
+	return revisions, nil
+}
+
+// Rollback applies a previous specification of an integrated service again.
+// The rollback itself is recorded as a new revision.
+func (s IntegratedServiceService) Rollback(ctx context.Context, clusterID uint, integratedServiceName string, revision uint) error {
+	logger := s.logger.WithContext(ctx).WithFields(map[string]interface{}{"clusterId": clusterID, "integrated service": integratedServiceName, "revision": revision})
+	logger.Info("processing integrated service rollback request")
+
+	logger.Debug("retrieving integrated service manager")
+	integratedServiceManager, err := s.integratedServiceManagerRegistry.GetIntegratedServiceManager(integratedServiceName)
+	if err != nil {
+		const msg = "failed to retrieve integrated service manager"
+		logger.Debug(msg)
+		return errors.WrapIf(err, msg)
+	}
+
+	logger.Debug("retrieving integrated service from repository")
+	if _, err := s.integratedServiceRepository.GetIntegratedService(ctx, clusterID, integratedServiceName); err != nil {
+		const msg = "failed to retrieve integrated service from repository"
+		logger.Debug(msg)
+		return errors.WrapIf(err, msg)
+	}
+
+	logger.Debug("retrieving integrated service revision")
+	rev, err := s.integratedServiceRepository.GetIntegratedServiceRevision(ctx, clusterID, integratedServiceName, revision)
+	if err != nil {
+		const msg = "failed to retrieve integrated service revision"
+		logger.Debug(msg)
+		return errors.WrapIf(err, msg)
+	}
+
+	logger.Debug("validating integrated service specification")
+	if err := integratedServiceManager.ValidateSpec(ctx, rev.Spec); err != nil {
+		logger.Debug("integrated service specification validation failed")
+		return InvalidIntegratedServiceSpecError{IntegratedServiceName: integratedServiceName, Problem: err.Error()}
+	}
+
+	logger.Debug("preparing integrated service specification")
+	preparedSpec, err := integratedServiceManager.PrepareSpec(ctx, clusterID, rev.Spec)
+	if err != nil {
+		const msg = "failed to prepare integrated service specification"
+		logger.Debug(msg)
+		return errors.WrapIf(err, msg)
+	}
+
+	logger.Debug("starting integrated service rollback")
+	if err := s.integratedServiceOperationDispatcher.DispatchApply(ctx, clusterID, integratedServiceName, preparedSpec); err != nil {
+		const msg = "failed to start integrated service rollback"
+		logger.Debug(msg)
+		return errors.WrapIfWithDetails(err, msg, "clusterID", clusterID, "integrated service", integratedServiceName)
+	}
+
+	logger.Debug("persisting integrated service")
+	if err := s.integratedServiceRepository.SaveIntegratedService(ctx, clusterID, integratedServiceName, rev.Spec, IntegratedServiceStatusPending); err != nil {
+		const msg = "failed to persist integrated service"
+		logger.Debug(msg)
+		return errors.WrapIf(err, msg)
+	}
+
+	logger.Info("integrated service rollback request processed successfully")
+
+	return nil
+}
+
 func merge(this map[string]interface{}, that map[string]interface{}) map[string]interface{} {
 	result := make(map[string]interface{}, len(this)+len(that))
 	for k, v := range this {
@@ -418,9 +418,151 @@ func TestIntegratedServiceService_Update(t *testing.T) {
 	}
 }
 
+func TestIntegratedServiceService_DryRun(t *testing.T) {
+	clusterID := uint(1)
+	integratedServiceName := "myIntegratedService"
+	dispatcher := &dummyIntegratedServiceOperationDispatcher{}
+	integratedServiceManager := &dummyIntegratedServiceManager{
+		TheName: integratedServiceName,
+	}
+	registry := MakeIntegratedServiceManagerRegistry([]IntegratedServiceManager{integratedServiceManager})
+	logger := NoopLogger{}
+
+	output := IntegratedServiceDryRunOutput{
+		Releases: []HelmReleaseDryRun{
+			{
+				ReleaseName: "my-release",
+				Values:      map[string]interface{}{"key": "value"},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		IntegratedServiceName string
+		ValidationError       error
+		DryRunError           error
+		InitialServices       map[uint][]IntegratedService
+		Changes               []IntegratedServiceSpecChange
+		Error                 interface{}
+	}{
+		"inactive service": {
+			IntegratedServiceName: integratedServiceName,
+			Changes: []IntegratedServiceSpecChange{
+				{Path: "mySpecKey", Operation: SpecChangeAdd, NewValue: "mySpecValue"},
+			},
+		},
+		"active service": {
+			IntegratedServiceName: integratedServiceName,
+			InitialServices: map[uint][]IntegratedService{
+				clusterID: {
+					{
+						Name:   integratedServiceName,
+						Spec:   IntegratedServiceSpec{"mySpecKey": "oldValue", "removedKey": true},
+						Status: IntegratedServiceStatusActive,
+					},
+				},
+			},
+			Changes: []IntegratedServiceSpecChange{
+				{Path: "mySpecKey", Operation: SpecChangeReplace, OldValue: "oldValue", NewValue: "mySpecValue"},
+				{Path: "removedKey", Operation: SpecChangeRemove, OldValue: true},
+			},
+		},
+		"unknown integrated service": {
+			IntegratedServiceName: "notMyIntegratedService",
+			Error: UnknownIntegratedServiceError{
+				IntegratedServiceName: "notMyIntegratedService",
+			},
+		},
+		"invalid spec": {
+			IntegratedServiceName: integratedServiceName,
+			ValidationError:       errors.New("validation error"),
+			Error:                 true,
+		},
+		"dry run not supported": {
+			IntegratedServiceName: integratedServiceName,
+			DryRunError:           DryRunNotSupportedError{IntegratedServiceName: integratedServiceName},
+			Error:                 DryRunNotSupportedError{IntegratedServiceName: integratedServiceName},
+		},
+	}
+	spec := IntegratedServiceSpec{
+		"mySpecKey": "mySpecValue",
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			repository := NewInMemoryIntegratedServiceRepository(tc.InitialServices)
+			snapshot := repository.Snapshot()
+			service := MakeIntegratedServiceService(dispatcher, registry, repository, logger)
+			dispatcher.DryRunOutput = output
+			dispatcher.DryRunError = tc.DryRunError
+			integratedServiceManager.ValidationError = tc.ValidationError
+
+			dryRun, err := service.DryRun(context.Background(), clusterID, tc.IntegratedServiceName, spec)
+			switch tc.Error {
+			case true:
+				assert.Error(t, err)
+			case nil, false:
+				require.NoError(t, err)
+
+				assert.Equal(t, output, dryRun.Output)
+				assert.Equal(t, tc.Changes, dryRun.Changes)
+			default:
+				assert.Equal(t, tc.Error, errors.Cause(err))
+			}
+
+			assert.Equal(t, snapshot, repository.Snapshot())
+		})
+	}
+}
+
+func TestIntegratedServiceService_Rollback(t *testing.T) {
+	clusterID := uint(1)
+	integratedServiceName := "myIntegratedService"
+	dispatcher := &dummyIntegratedServiceOperationDispatcher{}
+	integratedServiceManager := &dummyIntegratedServiceManager{
+		TheName: integratedServiceName,
+	}
+	registry := MakeIntegratedServiceManagerRegistry([]IntegratedServiceManager{integratedServiceManager})
+	repository := NewInMemoryIntegratedServiceRepository(map[uint][]IntegratedService{})
+	logger := NoopLogger{}
+	service := MakeIntegratedServiceService(dispatcher, registry, repository, logger)
+
+	ctx := context.Background()
+	specV1 := IntegratedServiceSpec{"key": "v1"}
+	specV2 := IntegratedServiceSpec{"key": "v2"}
+
+	require.NoError(t, service.Activate(ctx, clusterID, integratedServiceName, specV1))
+	require.NoError(t, repository.UpdateIntegratedServiceStatus(ctx, clusterID, integratedServiceName, IntegratedServiceStatusActive))
+	require.NoError(t, service.Update(ctx, clusterID, integratedServiceName, specV2))
+	require.NoError(t, repository.UpdateIntegratedServiceStatus(ctx, clusterID, integratedServiceName, IntegratedServiceStatusError))
+
+	err := service.Rollback(ctx, clusterID, integratedServiceName, 3)
+	require.Error(t, err)
+
+	err = service.Rollback(ctx, clusterID, integratedServiceName, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, specV1, repository.integratedServices[clusterID][integratedServiceName].Spec)
+	assert.Equal(t, IntegratedServiceStatusPending, repository.integratedServices[clusterID][integratedServiceName].Status)
+
+	revisions, err := service.ListRevisions(ctx, clusterID, integratedServiceName)
+	require.NoError(t, err)
+
+	require.Len(t, revisions, 3)
+	assert.Equal(t, uint(3), revisions[0].Revision)
+	assert.Equal(t, specV1, revisions[0].Spec)
+	assert.Equal(t, IntegratedServiceStatusPending, revisions[0].Status)
+	assert.Equal(t, uint(2), revisions[1].Revision)
+	assert.Equal(t, IntegratedServiceStatusError, revisions[1].Status)
+	assert.Equal(t, uint(1), revisions[2].Revision)
+	assert.Equal(t, IntegratedServiceStatusActive, revisions[2].Status)
+}
+
 type dummyIntegratedServiceOperationDispatcher struct {
 	ApplyError      error
 	DeactivateError error
+	DryRunOutput    IntegratedServiceDryRunOutput
+	DryRunError     error
 }
 
 func (d dummyIntegratedServiceOperationDispatcher) DispatchApply(ctx context.Context, clusterID uint, integratedServiceName string, spec IntegratedServiceSpec) error {
@@ -430,3 +572,7 @@ func (d dummyIntegratedServiceOperationDispatcher) DispatchApply(ctx context.Con
 func (d dummyIntegratedServiceOperationDispatcher) DispatchDeactivate(ctx context.Context, clusterID uint, integratedServiceName string, spec IntegratedServiceSpec) error {
 	return d.DeactivateError
 }
+
+func (d dummyIntegratedServiceOperationDispatcher) DispatchDryRun(ctx context.Context, clusterID uint, integratedServiceName string, spec IntegratedServiceSpec) (IntegratedServiceDryRunOutput, error) {
+	return d.DryRunOutput, d.DryRunError
+}
@@ -0,0 +1,60 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/values"
+)
+
+// Config contains configuration for the cert-manager integrated service.
+type Config struct {
+	Namespace   string
+	ReleaseName string
+	Charts      ChartsConfig
+}
+
+func (c Config) Validate() error {
+	var errs error
+
+	if c.Namespace == "" {
+		errs = errors.Append(errs, errors.New("cert-manager namespace is required"))
+	}
+
+	if c.ReleaseName == "" {
+		errs = errors.Append(errs, errors.New("cert-manager release name is required"))
+	}
+
+	if c.Charts.CertManager.Chart == "" {
+		errs = errors.Append(errs, errors.New("cert-manager chart is required"))
+	}
+
+	if c.Charts.CertManager.Version == "" {
+		errs = errors.Append(errs, errors.New("cert-manager chart version is required"))
+	}
+
+	return errs
+}
+
+type ChartsConfig struct {
+	CertManager ChartConfig
+}
+
+type ChartConfig struct {
+	Chart   string
+	Version string
+	Values  values.Config
+}
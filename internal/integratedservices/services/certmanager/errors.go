@@ -0,0 +1,65 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"fmt"
+
+	pkgerrors "github.com/banzaicloud/pipeline/pkg/errors"
+)
+
+type invalidIssuerError struct {
+	Issuer  string
+	Problem string
+
+	pkgerrors.BadRequestBehavior
+	pkgerrors.ClientErrorBehavior
+	pkgerrors.ValidationBehavior
+}
+
+func (e invalidIssuerError) Error() string {
+	return fmt.Sprintf("invalid issuer %q: %s", e.Issuer, e.Problem)
+}
+
+type unsupportedIssuerTypeError struct {
+	Issuer string
+	Type   string
+
+	pkgerrors.BadRequestBehavior
+	pkgerrors.ClientErrorBehavior
+	pkgerrors.ValidationBehavior
+}
+
+func (e unsupportedIssuerTypeError) Error() string {
+	return fmt.Sprintf("issuer type %q of issuer %q is not supported", e.Type, e.Issuer)
+}
+
+// issuerNotReadyError is returned when cert-manager cannot accept issuers yet (eg. its webhook is still starting).
+type issuerNotReadyError struct {
+	err error
+}
+
+func (e issuerNotReadyError) Error() string {
+	return fmt.Sprintf("cert-manager is not ready to accept issuers: %s", e.err)
+}
+
+func (e issuerNotReadyError) Unwrap() error {
+	return e.err
+}
+
+// ShouldRetry returns true if the operation resulting in this error should be retried later.
+func (issuerNotReadyError) ShouldRetry() bool {
+	return true
+}
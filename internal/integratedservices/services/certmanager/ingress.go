@@ -0,0 +1,52 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"fmt"
+)
+
+// ClusterIssuerAnnotation is the ingress annotation requesting a certificate from a cert-manager cluster issuer.
+const ClusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+
+// IngressTLS describes the TLS configuration of an ingress (as used by most Helm charts).
+type IngressTLS struct {
+	SecretName string   `json:"secretName" mapstructure:"secretName"`
+	Hosts      []string `json:"hosts" mapstructure:"hosts"`
+}
+
+// IngressTLSSecretName returns the name of the secret the certificate of an ingress is stored in.
+func IngressTLSSecretName(ingressName string) string {
+	return fmt.Sprintf("%s-tls", ingressName)
+}
+
+// RequestIngressCertificate returns the annotations and TLS configuration that make cert-manager
+// issue a certificate for the host of an ingress using a cluster issuer.
+// The returned annotations contain the specified ones as well.
+func RequestIngressCertificate(annotations map[string]interface{}, clusterIssuer string, ingressName string, host string) (map[string]interface{}, []IngressTLS) {
+	result := make(map[string]interface{}, len(annotations)+1)
+	for k, v := range annotations {
+		result[k] = v
+	}
+
+	result[ClusterIssuerAnnotation] = clusterIssuer
+
+	return result, []IngressTLS{
+		{
+			SecretName: IngressTLSSecretName(ingressName),
+			Hosts:      []string{host},
+		},
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+)
+
+func resourceLabels() map[string]string {
+	return map[string]string{resourceLabelKey: ServiceName}
+}
+
+func clusterIssuerGroupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(clusterIssuerAPIVersion, clusterIssuerKind)
+}
+
+// caSecretName returns the name of the Kubernetes secret holding the CA of an issuer.
+func caSecretName(issuerName string) string {
+	return fmt.Sprintf("%s-ca", issuerName)
+}
+
+// acmeAccountSecretName returns the name of the Kubernetes secret holding the ACME account key of an issuer.
+func acmeAccountSecretName(issuerName string) string {
+	return fmt.Sprintf("%s-account-key", issuerName)
+}
+
+func newClusterIssuer(spec IssuerSpec) *unstructured.Unstructured {
+	var issuerSpec map[string]interface{}
+
+	switch spec.Type {
+	case IssuerTypeACME:
+		server := spec.ACME.Server
+		if server == "" {
+			server = defaultACMEServer
+		}
+
+		ingressClass := spec.ACME.IngressClass
+		if ingressClass == "" {
+			ingressClass = defaultACMEIngressClass
+		}
+
+		issuerSpec = map[string]interface{}{
+			"acme": map[string]interface{}{
+				"server": server,
+				"email":  spec.ACME.Email,
+				"privateKeySecretRef": map[string]interface{}{
+					"name": acmeAccountSecretName(spec.Name),
+				},
+				"solvers": []interface{}{
+					map[string]interface{}{
+						"http01": map[string]interface{}{
+							"ingress": map[string]interface{}{
+								"class": ingressClass,
+							},
+						},
+					},
+				},
+			},
+		}
+	case IssuerTypeSelfSigned:
+		issuerSpec = map[string]interface{}{
+			"selfSigned": map[string]interface{}{},
+		}
+	case IssuerTypeCA:
+		issuerSpec = map[string]interface{}{
+			"ca": map[string]interface{}{
+				"secretName": caSecretName(spec.Name),
+			},
+		}
+	}
+
+	issuer := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": issuerSpec,
+		},
+	}
+	issuer.SetGroupVersionKind(clusterIssuerGroupVersionKind())
+	issuer.SetName(spec.Name)
+	issuer.SetLabels(resourceLabels())
+
+	return issuer
+}
+
+// newCASecret returns a Kubernetes secret holding the CA material of a Pipeline TLS secret.
+func newCASecret(namespace string, issuerName string, values map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      caSecretName(issuerName),
+			Namespace: namespace,
+			Labels:    resourceLabels(),
+		},
+		Type: corev1.SecretTypeTLS,
+		StringData: map[string]string{
+			corev1.TLSCertKey:       values[secrettype.CACert],
+			corev1.TLSPrivateKeyKey: values[secrettype.CAKey],
+		},
+	}
+}
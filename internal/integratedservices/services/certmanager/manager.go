@@ -0,0 +1,89 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services"
+)
+
+// Manager implements the cert-manager integrated service manager
+type Manager struct {
+	integratedservices.PassthroughIntegratedServiceSpecPreparer
+
+	config      Config
+	helmService services.HelmService
+	logger      services.Logger
+}
+
+// NewManager returns a new cert-manager integrated service manager
+func NewManager(config Config, helmService services.HelmService, logger services.Logger) Manager {
+	return Manager{
+		config:      config,
+		helmService: helmService,
+		logger:      logger,
+	}
+}
+
+// Name returns the integrated service's name.
+func (Manager) Name() string {
+	return ServiceName
+}
+
+func (m Manager) GetOutput(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceOutput, error) {
+	var boundSpec Spec
+	if err := services.BindIntegratedServiceSpec(spec, &boundSpec); err != nil {
+		return nil, errors.WrapIf(err, "failed to bind spec")
+	}
+
+	version := m.config.Charts.CertManager.Version
+
+	rel, err := m.helmService.GetDeployment(ctx, clusterID, m.config.ReleaseName, m.config.Namespace)
+	if err != nil {
+		m.logger.Warn(err.Error(), map[string]interface{}{
+			"clusterId":   clusterID,
+			"releaseName": m.config.ReleaseName,
+		})
+	}
+
+	if rel != nil {
+		version = rel.ChartVersion
+	}
+
+	issuers := make([]string, 0, len(boundSpec.Issuers))
+	for _, issuer := range boundSpec.Issuers {
+		issuers = append(issuers, issuer.Name)
+	}
+
+	return integratedservices.IntegratedServiceOutput{
+		"certManager": map[string]interface{}{
+			"version": version,
+		},
+		"clusterIssuers": issuers,
+	}, nil
+}
+
+func (m Manager) ValidateSpec(ctx context.Context, spec integratedservices.IntegratedServiceSpec) error {
+	var boundSpec Spec
+	if err := services.BindIntegratedServiceSpec(spec, &boundSpec); err != nil {
+		return errors.WrapIf(err, "failed to bind spec")
+	}
+
+	return boundSpec.Validate()
+}
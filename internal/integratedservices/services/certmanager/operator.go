@@ -0,0 +1,307 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// Operator implements the cert-manager integrated service operator
+type Operator struct {
+	clusterGetter     integratedserviceadapter.ClusterGetter
+	clusterService    integratedservices.ClusterService
+	helmService       services.HelmService
+	kubernetesService KubernetesService
+	secretStore       services.SecretStore
+	config            Config
+	logger            services.Logger
+}
+
+// NewOperator returns a new cert-manager integrated service operator
+func NewOperator(
+	clusterGetter integratedserviceadapter.ClusterGetter,
+	clusterService integratedservices.ClusterService,
+	helmService services.HelmService,
+	kubernetesService KubernetesService,
+	secretStore services.SecretStore,
+	config Config,
+	logger services.Logger,
+) Operator {
+	return Operator{
+		clusterGetter:     clusterGetter,
+		clusterService:    clusterService,
+		helmService:       helmService,
+		kubernetesService: kubernetesService,
+		secretStore:       secretStore,
+		config:            config,
+		logger:            logger,
+	}
+}
+
+// Name returns the integrated service's name.
+func (Operator) Name() string {
+	return ServiceName
+}
+
+// Apply installs cert-manager and creates the cluster issuers of the specification on the given cluster.
+func (op Operator) Apply(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) error {
+	if err := op.clusterService.CheckClusterReady(ctx, clusterID); err != nil {
+		return err
+	}
+
+	ctx, err := op.ensureOrgIDInContext(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	var boundSpec Spec
+	if err := services.BindIntegratedServiceSpec(spec, &boundSpec); err != nil {
+		return errors.WrapIf(err, "failed to bind spec")
+	}
+
+	chartValues, err := op.compileChartValues()
+	if err != nil {
+		return errors.WrapIf(err, "failed to compile cert-manager chart values")
+	}
+
+	chartValuesBytes, err := json.Marshal(chartValues)
+	if err != nil {
+		return errors.WrapIf(err, "failed to marshal chart values to JSON")
+	}
+
+	if err := op.helmService.ApplyDeployment(
+		ctx,
+		clusterID,
+		op.config.Namespace,
+		op.config.Charts.CertManager.Chart,
+		op.config.ReleaseName,
+		chartValuesBytes,
+		op.config.Charts.CertManager.Version,
+	); err != nil {
+		return errors.WrapIf(err, "failed to apply deployment")
+	}
+
+	objects, err := op.renderObjects(ctx, boundSpec, op.secretStore)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objects {
+		if err := op.applyObject(ctx, clusterID, obj); err != nil {
+			if k8sapierrors.IsInternalError(err) || k8sapierrors.IsServiceUnavailable(err) || meta.IsNoMatchError(err) {
+				return issuerNotReadyError{err: err}
+			}
+
+			return err
+		}
+	}
+
+	return op.removeStaleObjects(ctx, clusterID, boundSpec)
+}
+
+// Deactivate removes the cluster issuers and cert-manager from the given cluster.
+func (op Operator) Deactivate(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) error {
+	if err := op.clusterService.CheckClusterReady(ctx, clusterID); err != nil {
+		return err
+	}
+
+	if err := op.removeStaleObjects(ctx, clusterID, Spec{}); err != nil {
+		return err
+	}
+
+	return errors.WrapIf(op.helmService.DeleteDeployment(ctx, clusterID, op.config.ReleaseName, op.config.Namespace), "failed to delete deployment")
+}
+
+// DryRun renders the cert-manager Helm release and the cluster issuers of the specification without applying them.
+func (op Operator) DryRun(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	ctx, err := op.ensureOrgIDInContext(ctx, clusterID)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	var boundSpec Spec
+	if err := services.BindIntegratedServiceSpec(spec, &boundSpec); err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to bind spec")
+	}
+
+	chartValues, err := op.compileChartValues()
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to compile cert-manager chart values")
+	}
+
+	chartValuesBytes, err := json.Marshal(chartValues)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to marshal chart values to JSON")
+	}
+
+	release, err := services.NewHelmReleaseDryRun(
+		op.config.Namespace,
+		op.config.Charts.CertManager.Chart,
+		op.config.ReleaseName,
+		chartValuesBytes,
+		op.config.Charts.CertManager.Version,
+	)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	output := integratedservices.IntegratedServiceDryRunOutput{
+		Releases: []integratedservices.HelmReleaseDryRun{release},
+	}
+
+	objects, err := op.renderObjects(ctx, boundSpec, services.NewRedactingSecretStore(op.secretStore))
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	for _, obj := range objects {
+		untypedObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to convert object")
+		}
+
+		output.Objects = append(output.Objects, untypedObj)
+	}
+
+	return output, nil
+}
+
+func (op Operator) ensureOrgIDInContext(ctx context.Context, clusterID uint) (context.Context, error) {
+	if _, ok := auth.GetCurrentOrganizationID(ctx); !ok {
+		cluster, err := op.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
+		if err != nil {
+			return ctx, errors.WrapIf(err, "failed to get cluster by ID")
+		}
+		ctx = auth.SetCurrentOrganizationID(ctx, cluster.GetOrganizationId())
+	}
+	return ctx, nil
+}
+
+// renderObjects returns the Kubernetes objects (CA secrets and cluster issuers) required by the issuers of the specification.
+func (op Operator) renderObjects(ctx context.Context, spec Spec, secretStore services.SecretStore) ([]runtime.Object, error) {
+	var objects []runtime.Object
+
+	for _, issuer := range spec.Issuers {
+		if issuer.Type == IssuerTypeCA {
+			values, err := secretStore.GetSecretValues(ctx, issuer.CA.SecretID)
+			if err != nil {
+				return nil, errors.WrapIfWithDetails(err, "failed to get CA secret", "issuer", issuer.Name, "secretId", issuer.CA.SecretID)
+			}
+
+			if values[secrettype.CACert] == "" || values[secrettype.CAKey] == "" {
+				return nil, errors.WithStack(invalidIssuerError{Issuer: issuer.Name, Problem: "CA secret must be a TLS secret containing a CA certificate and key"})
+			}
+
+			objects = append(objects, newCASecret(op.config.Namespace, issuer.Name, values))
+		}
+
+		objects = append(objects, newClusterIssuer(issuer))
+	}
+
+	return objects, nil
+}
+
+// applyObject creates or updates an object on the cluster.
+func (op Operator) applyObject(ctx context.Context, clusterID uint, obj runtime.Object) error {
+	metaObj, err := meta.Accessor(obj)
+	if err != nil {
+		return errors.WrapIf(err, "failed to access object metadata")
+	}
+
+	current := obj.DeepCopyObject()
+	if err := op.kubernetesService.GetObject(ctx, clusterID, corev1.ObjectReference{
+		Namespace: metaObj.GetNamespace(),
+		Name:      metaObj.GetName(),
+	}, current); err != nil {
+		if k8sapierrors.IsNotFound(err) {
+			return op.kubernetesService.EnsureObject(ctx, clusterID, obj)
+		}
+
+		return errors.WrapIfWithDetails(err, "failed to get object", "name", metaObj.GetName())
+	}
+
+	currentMetaObj, err := meta.Accessor(current)
+	if err != nil {
+		return errors.WrapIf(err, "failed to access object metadata")
+	}
+
+	metaObj.SetResourceVersion(currentMetaObj.GetResourceVersion())
+
+	return op.kubernetesService.Update(ctx, clusterID, obj)
+}
+
+// removeStaleObjects removes the cluster issuers and CA secrets not required by the specification from the cluster.
+func (op Operator) removeStaleObjects(ctx context.Context, clusterID uint, spec Spec) error {
+	issuers := make(map[string]bool, len(spec.Issuers))
+	caSecrets := make(map[string]bool)
+	for _, issuer := range spec.Issuers {
+		issuers[issuer.Name] = true
+
+		if issuer.Type == IssuerTypeCA {
+			caSecrets[caSecretName(issuer.Name)] = true
+		}
+	}
+
+	var issuerList unstructured.UnstructuredList
+	issuerList.SetGroupVersionKind(clusterIssuerGroupVersionKind().GroupVersion().WithKind(clusterIssuerKind + "List"))
+	if err := op.kubernetesService.List(ctx, clusterID, resourceLabels(), &issuerList); err != nil {
+		if !meta.IsNoMatchError(err) {
+			return errors.WrapIf(err, "failed to list cluster issuers")
+		}
+	}
+
+	for i := range issuerList.Items {
+		issuer := &issuerList.Items[i]
+		if issuers[issuer.GetName()] {
+			continue
+		}
+
+		if err := op.kubernetesService.DeleteObject(ctx, clusterID, issuer); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to delete cluster issuer", "issuer", issuer.GetName())
+		}
+	}
+
+	var secretList corev1.SecretList
+	if err := op.kubernetesService.List(ctx, clusterID, resourceLabels(), &secretList); err != nil {
+		return errors.WrapIf(err, "failed to list CA secrets")
+	}
+
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		if secret.Namespace != op.config.Namespace || caSecrets[secret.Name] {
+			continue
+		}
+
+		if err := op.kubernetesService.DeleteObject(ctx, clusterID, secret); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to delete CA secret", "secret", secret.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+type dummySecretStore struct {
+	secrets map[string]map[string]string
+}
+
+func (s dummySecretStore) GetSecretValues(ctx context.Context, secretID string) (map[string]string, error) {
+	values, ok := s.secrets[secretID]
+	if !ok {
+		return nil, common.SecretNotFoundError{SecretID: secretID}
+	}
+
+	return values, nil
+}
+
+func (s dummySecretStore) GetNameByID(ctx context.Context, secretID string) (string, error) {
+	return secretID, nil
+}
+
+func (s dummySecretStore) GetIDByName(ctx context.Context, secretName string) (string, error) {
+	return secretName, nil
+}
+
+func (s dummySecretStore) Delete(ctx context.Context, secretID string) error {
+	return nil
+}
+
+// dummyKubernetesService stores cluster issuers and secrets in memory
+type dummyKubernetesService struct {
+	objects map[string]runtime.Object
+}
+
+func objectKey(kind string, namespace string, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+func (s *dummyKubernetesService) key(o runtime.Object) string {
+	m, _ := meta.Accessor(o)
+	switch o.(type) {
+	case *corev1.Secret:
+		return objectKey("Secret", m.GetNamespace(), m.GetName())
+	default:
+		return objectKey(clusterIssuerKind, m.GetNamespace(), m.GetName())
+	}
+}
+
+func (s *dummyKubernetesService) EnsureObject(ctx context.Context, clusterID uint, o runtime.Object) error {
+	s.objects[s.key(o)] = o.DeepCopyObject()
+	return nil
+}
+
+func (s *dummyKubernetesService) Update(ctx context.Context, clusterID uint, o runtime.Object) error {
+	s.objects[s.key(o)] = o.DeepCopyObject()
+	return nil
+}
+
+func (s *dummyKubernetesService) DeleteObject(ctx context.Context, clusterID uint, o runtime.Object) error {
+	delete(s.objects, s.key(o))
+	return nil
+}
+
+func (s *dummyKubernetesService) GetObject(ctx context.Context, clusterID uint, objRef corev1.ObjectReference, obj runtime.Object) error {
+	kind := clusterIssuerKind
+	if _, ok := obj.(*corev1.Secret); ok {
+		kind = "Secret"
+	}
+
+	stored, ok := s.objects[objectKey(kind, objRef.Namespace, objRef.Name)]
+	if !ok {
+		return k8sapierrors.NewNotFound(schema.GroupResource{}, objRef.Name)
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, obj)
+}
+
+func (s *dummyKubernetesService) List(ctx context.Context, clusterID uint, labels map[string]string, o runtime.Object) error {
+	for _, stored := range s.objects {
+		switch list := o.(type) {
+		case *unstructured.UnstructuredList:
+			if issuer, ok := stored.(*unstructured.Unstructured); ok {
+				list.Items = append(list.Items, *issuer.DeepCopy())
+			}
+		case *corev1.SecretList:
+			if secret, ok := stored.(*corev1.Secret); ok {
+				list.Items = append(list.Items, *secret.DeepCopy())
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestOperator_Apply(t *testing.T) {
+	const clusterID = 1
+
+	config := Config{
+		Namespace:   "cert-manager",
+		ReleaseName: "cert-manager",
+		Charts: ChartsConfig{
+			CertManager: ChartConfig{
+				Chart:   "jetstack/cert-manager",
+				Version: "v0.16.1",
+			},
+		},
+	}
+
+	clusterService := new(integratedservices.MockClusterService)
+	clusterService.On("CheckClusterReady", mock.Anything, uint(clusterID)).Return(nil)
+
+	helmService := new(services.MockHelmService)
+	helmService.On("ApplyDeployment", mock.Anything, uint(clusterID), "cert-manager", "jetstack/cert-manager", "cert-manager", []byte(`{"installCRDs":true}`), "v0.16.1").Return(nil)
+
+	secretStore := dummySecretStore{
+		secrets: map[string]map[string]string{
+			"caSecretID": {
+				secrettype.CACert: "caCert",
+				secrettype.CAKey:  "caKey",
+			},
+		},
+	}
+
+	staleIssuer := newClusterIssuer(IssuerSpec{Name: "stale", Type: IssuerTypeSelfSigned})
+	kubernetesService := &dummyKubernetesService{
+		objects: map[string]runtime.Object{
+			objectKey(clusterIssuerKind, "", "stale"): staleIssuer,
+		},
+	}
+
+	operator := NewOperator(nil, clusterService, helmService, kubernetesService, secretStore, config, services.NoopLogger{})
+
+	ctx := auth.SetCurrentOrganizationID(context.Background(), 1)
+
+	spec := integratedservices.IntegratedServiceSpec{
+		"issuers": []interface{}{
+			map[string]interface{}{
+				"name": "letsencrypt",
+				"type": "acme",
+				"acme": map[string]interface{}{
+					"email": "admin@example.com",
+				},
+			},
+			map[string]interface{}{
+				"name": "internal-ca",
+				"type": "ca",
+				"ca": map[string]interface{}{
+					"secretId": "caSecretID",
+				},
+			},
+		},
+	}
+
+	err := operator.Apply(ctx, clusterID, spec)
+	require.NoError(t, err)
+
+	assert.Len(t, kubernetesService.objects, 3)
+	assert.NotContains(t, kubernetesService.objects, objectKey(clusterIssuerKind, "", "stale"))
+
+	require.Contains(t, kubernetesService.objects, objectKey("Secret", "cert-manager", "internal-ca-ca"))
+	caSecret := kubernetesService.objects[objectKey("Secret", "cert-manager", "internal-ca-ca")].(*corev1.Secret)
+	assert.Equal(t, map[string]string{
+		corev1.TLSCertKey:       "caCert",
+		corev1.TLSPrivateKeyKey: "caKey",
+	}, caSecret.StringData)
+
+	require.Contains(t, kubernetesService.objects, objectKey(clusterIssuerKind, "", "letsencrypt"))
+	acmeIssuer := kubernetesService.objects[objectKey(clusterIssuerKind, "", "letsencrypt")].(*unstructured.Unstructured)
+	server, _, _ := unstructured.NestedString(acmeIssuer.Object, "spec", "acme", "server")
+	assert.Equal(t, defaultACMEServer, server)
+
+	require.Contains(t, kubernetesService.objects, objectKey(clusterIssuerKind, "", "internal-ca"))
+	caIssuer := kubernetesService.objects[objectKey(clusterIssuerKind, "", "internal-ca")].(*unstructured.Unstructured)
+	secretName, _, _ := unstructured.NestedString(caIssuer.Object, "spec", "ca", "secretName")
+	assert.Equal(t, "internal-ca-ca", secretName)
+
+	clusterService.AssertExpectations(t)
+	helmService.AssertExpectations(t)
+}
+
+func TestOperator_DryRun(t *testing.T) {
+	const clusterID = 1
+
+	config := Config{
+		Namespace:   "cert-manager",
+		ReleaseName: "cert-manager",
+		Charts: ChartsConfig{
+			CertManager: ChartConfig{
+				Chart:   "jetstack/cert-manager",
+				Version: "v0.16.1",
+			},
+		},
+	}
+
+	secretStore := dummySecretStore{
+		secrets: map[string]map[string]string{
+			"caSecretID": {
+				secrettype.CACert: "caCert",
+				secrettype.CAKey:  "caKey",
+			},
+		},
+	}
+
+	operator := NewOperator(nil, nil, nil, nil, secretStore, config, services.NoopLogger{})
+
+	ctx := auth.SetCurrentOrganizationID(context.Background(), 1)
+
+	spec := integratedservices.IntegratedServiceSpec{
+		"issuers": []interface{}{
+			map[string]interface{}{
+				"name": "internal-ca",
+				"type": "ca",
+				"ca": map[string]interface{}{
+					"secretId": "caSecretID",
+				},
+			},
+		},
+	}
+
+	output, err := operator.DryRun(ctx, clusterID, spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, []integratedservices.HelmReleaseDryRun{
+		{
+			ReleaseName:  "cert-manager",
+			Namespace:    "cert-manager",
+			ChartName:    "jetstack/cert-manager",
+			ChartVersion: "v0.16.1",
+			Values: map[string]interface{}{
+				"installCRDs": true,
+			},
+		},
+	}, output.Releases)
+
+	require.Len(t, output.Objects, 2)
+	assert.Equal(t, "Secret", output.Objects[0]["kind"])
+	assert.Equal(t, map[string]interface{}{
+		corev1.TLSCertKey:       integratedservices.RedactedValue,
+		corev1.TLSPrivateKeyKey: integratedservices.RedactedValue,
+	}, output.Objects[0]["stringData"])
+	assert.Equal(t, clusterIssuerKind, output.Objects[1]["kind"])
+}
@@ -0,0 +1,84 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"strings"
+
+	"emperror.dev/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type Spec struct {
+	Issuers []IssuerSpec `json:"issuers" mapstructure:"issuers"`
+}
+
+func (s Spec) Validate() error {
+	var errs error
+
+	names := make(map[string]bool, len(s.Issuers))
+	for _, issuer := range s.Issuers {
+		if names[issuer.Name] {
+			errs = errors.Append(errs, invalidIssuerError{Issuer: issuer.Name, Problem: "issuer names must be unique"})
+		}
+		names[issuer.Name] = true
+
+		errs = errors.Append(errs, issuer.Validate())
+	}
+
+	return errs
+}
+
+// IssuerSpec describes a cluster-wide certificate issuer.
+type IssuerSpec struct {
+	Name string   `json:"name" mapstructure:"name"`
+	Type string   `json:"type" mapstructure:"type"`
+	ACME ACMESpec `json:"acme" mapstructure:"acme"`
+	CA   CASpec   `json:"ca" mapstructure:"ca"`
+}
+
+func (s IssuerSpec) Validate() error {
+	if msgs := validation.IsDNS1123Subdomain(s.Name); len(msgs) > 0 {
+		return invalidIssuerError{Issuer: s.Name, Problem: strings.Join(msgs, ", ")}
+	}
+
+	switch s.Type {
+	case IssuerTypeACME:
+		if s.ACME.Email == "" {
+			return invalidIssuerError{Issuer: s.Name, Problem: "ACME account email is required"}
+		}
+	case IssuerTypeSelfSigned:
+	case IssuerTypeCA:
+		if s.CA.SecretID == "" {
+			return invalidIssuerError{Issuer: s.Name, Problem: "CA secret ID is required"}
+		}
+	default:
+		return unsupportedIssuerTypeError{Issuer: s.Name, Type: s.Type}
+	}
+
+	return nil
+}
+
+// ACMESpec describes an issuer obtaining certificates from an ACME server (eg. Let's Encrypt) using HTTP01 challenges.
+type ACMESpec struct {
+	Server       string `json:"server" mapstructure:"server"`
+	Email        string `json:"email" mapstructure:"email"`
+	IngressClass string `json:"ingressClass" mapstructure:"ingressClass"`
+}
+
+// CASpec describes an issuer signing certificates with a CA stored in a Pipeline TLS secret.
+type CASpec struct {
+	SecretID string `json:"secretId" mapstructure:"secretId"`
+}
@@ -0,0 +1,222 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services"
+)
+
+func TestSpec(t *testing.T) {
+	type arr = []interface{}
+	type obj = map[string]interface{}
+
+	testCases := map[string]struct {
+		Input      integratedservices.IntegratedServiceSpec
+		Expected   Spec
+		Validation interface{}
+	}{
+		"no issuers": {
+			Input:    obj{},
+			Expected: Spec{},
+		},
+		"all issuer types": {
+			Input: obj{
+				"issuers": arr{
+					obj{
+						"name": "letsencrypt",
+						"type": "acme",
+						"acme": obj{
+							"email": "admin@example.com",
+						},
+					},
+					obj{
+						"name": "selfsigned",
+						"type": "selfSigned",
+					},
+					obj{
+						"name": "internal-ca",
+						"type": "ca",
+						"ca": obj{
+							"secretId": "caSecretID",
+						},
+					},
+				},
+			},
+			Expected: Spec{
+				Issuers: []IssuerSpec{
+					{
+						Name: "letsencrypt",
+						Type: IssuerTypeACME,
+						ACME: ACMESpec{
+							Email: "admin@example.com",
+						},
+					},
+					{
+						Name: "selfsigned",
+						Type: IssuerTypeSelfSigned,
+					},
+					{
+						Name: "internal-ca",
+						Type: IssuerTypeCA,
+						CA: CASpec{
+							SecretID: "caSecretID",
+						},
+					},
+				},
+			},
+		},
+		"ACME issuer without email": {
+			Input: obj{
+				"issuers": arr{
+					obj{
+						"name": "letsencrypt",
+						"type": "acme",
+					},
+				},
+			},
+			Expected: Spec{
+				Issuers: []IssuerSpec{
+					{
+						Name: "letsencrypt",
+						Type: IssuerTypeACME,
+					},
+				},
+			},
+			Validation: invalidIssuerError{
+				Issuer:  "letsencrypt",
+				Problem: "ACME account email is required",
+			},
+		},
+		"CA issuer without secret": {
+			Input: obj{
+				"issuers": arr{
+					obj{
+						"name": "internal-ca",
+						"type": "ca",
+					},
+				},
+			},
+			Expected: Spec{
+				Issuers: []IssuerSpec{
+					{
+						Name: "internal-ca",
+						Type: IssuerTypeCA,
+					},
+				},
+			},
+			Validation: invalidIssuerError{
+				Issuer:  "internal-ca",
+				Problem: "CA secret ID is required",
+			},
+		},
+		"invalid issuer name": {
+			Input: obj{
+				"issuers": arr{
+					obj{
+						"name": "Not_A_Name",
+						"type": "selfSigned",
+					},
+				},
+			},
+			Expected: Spec{
+				Issuers: []IssuerSpec{
+					{
+						Name: "Not_A_Name",
+						Type: IssuerTypeSelfSigned,
+					},
+				},
+			},
+			Validation: true,
+		},
+		"duplicate issuer names": {
+			Input: obj{
+				"issuers": arr{
+					obj{
+						"name": "selfsigned",
+						"type": "selfSigned",
+					},
+					obj{
+						"name": "selfsigned",
+						"type": "selfSigned",
+					},
+				},
+			},
+			Expected: Spec{
+				Issuers: []IssuerSpec{
+					{
+						Name: "selfsigned",
+						Type: IssuerTypeSelfSigned,
+					},
+					{
+						Name: "selfsigned",
+						Type: IssuerTypeSelfSigned,
+					},
+				},
+			},
+			Validation: invalidIssuerError{
+				Issuer:  "selfsigned",
+				Problem: "issuer names must be unique",
+			},
+		},
+		"unsupported issuer type": {
+			Input: obj{
+				"issuers": arr{
+					obj{
+						"name": "vault",
+						"type": "vault",
+					},
+				},
+			},
+			Expected: Spec{
+				Issuers: []IssuerSpec{
+					{
+						Name: "vault",
+						Type: "vault",
+					},
+				},
+			},
+			Validation: unsupportedIssuerTypeError{
+				Issuer: "vault",
+				Type:   "vault",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			var spec Spec
+			err := services.BindIntegratedServiceSpec(testCase.Input, &spec)
+			require.NoError(t, err)
+			require.Equal(t, testCase.Expected, spec)
+
+			err = spec.Validate()
+
+			switch testCase.Validation {
+			case nil, false:
+				require.NoError(t, err)
+			case true:
+				require.Error(t, err)
+			default:
+				require.Equal(t, testCase.Validation, err)
+			}
+		})
+	}
+}
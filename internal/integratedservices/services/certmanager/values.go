@@ -0,0 +1,50 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/any"
+	"github.com/banzaicloud/pipeline/pkg/jsonstructure"
+)
+
+type chartValues struct {
+	InstallCRDs bool `json:"installCRDs"`
+}
+
+func (op Operator) compileChartValues() (interface{}, error) {
+	defaultValues, err := jsonstructure.CopyObject(op.config.Charts.CertManager.Values)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to copy default chart values from config")
+	}
+
+	typedValues := chartValues{
+		// ClusterIssuers can only be created once the CRDs are installed
+		InstallCRDs: true,
+	}
+
+	untypedValues, err := jsonstructure.Encode(typedValues, jsonstructure.WithZeroStructsAsEmpty)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to encode chart values as JSON structure")
+	}
+
+	finalValues, err := any.Merge(defaultValues, untypedValues, jsonstructure.DefaultMergeOptions())
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to merge chart values")
+	}
+
+	return finalValues, nil
+}
@@ -150,6 +150,11 @@ func (op IntegratedServiceOperator) Deactivate(ctx context.Context, clusterID ui
 	return nil
 }
 
+// DryRun returns an error since the DNS integrated service does not support dry runs
+func (op IntegratedServiceOperator) DryRun(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	return integratedservices.IntegratedServiceDryRunOutput{}, errors.WithStack(integratedservices.DryRunNotSupportedError{IntegratedServiceName: IntegratedServiceName})
+}
+
 func (op IntegratedServiceOperator) getChartValues(ctx context.Context, clusterID uint, spec dnsIntegratedServiceSpec) ([]byte, error) {
 	cl, err := op.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
 	if err != nil {
@@ -0,0 +1,366 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/banzaicloud/pipeline/internal/helm"
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+// KubernetesService provides access to the Kubernetes objects of a cluster.
+type KubernetesService interface {
+	// GetObject gets an Object from a specific cluster.
+	GetObject(ctx context.Context, clusterID uint, objRef corev1.ObjectReference, obj runtime.Object) error
+}
+
+// HelmReleaseDriftDetector detects drift by comparing the resources rendered by an integrated service operator's dry run
+// with the resources installed in the cluster.
+// Helm releases are compared by their chart version and values, and the objects of their rendered manifests are compared
+// with the live objects, just like the objects applied by the operator directly (eg. custom resources).
+// Only the fields set by the rendered objects are compared, so that defaults and status set by the cluster are not reported.
+// Integrated services that do not support dry runs are not checked.
+type HelmReleaseDriftDetector struct {
+	operatorRegistry  integratedservices.IntegratedServiceOperatorRegistry
+	helmService       HelmService
+	kubernetesService KubernetesService
+}
+
+// NewHelmReleaseDriftDetector returns a new HelmReleaseDriftDetector.
+func NewHelmReleaseDriftDetector(
+	operatorRegistry integratedservices.IntegratedServiceOperatorRegistry,
+	helmService HelmService,
+	kubernetesService KubernetesService,
+) HelmReleaseDriftDetector {
+	return HelmReleaseDriftDetector{
+		operatorRegistry:  operatorRegistry,
+		helmService:       helmService,
+		kubernetesService: kubernetesService,
+	}
+}
+
+// DetectDrift returns the changes required to bring the resources of an integrated service back to the specification.
+func (d HelmReleaseDriftDetector) DetectDrift(
+	ctx context.Context,
+	clusterID uint,
+	integratedServiceName string,
+	spec integratedservices.IntegratedServiceSpec,
+) ([]integratedservices.IntegratedServiceSpecChange, error) {
+	operator, err := d.operatorRegistry.GetIntegratedServiceOperator(integratedServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := operator.DryRun(ctx, clusterID, spec)
+	if err != nil {
+		var notSupportedErr integratedservices.DryRunNotSupportedError
+		if errors.As(err, &notSupportedErr) {
+			return nil, nil
+		}
+
+		return nil, errors.WrapIf(err, "failed to render integrated service")
+	}
+
+	changes := make([]integratedservices.IntegratedServiceSpecChange, 0)
+
+	for _, release := range output.Releases {
+		path := fmt.Sprintf("releases.%s", release.ReleaseName)
+
+		deployment, err := d.helmService.GetDeployment(ctx, clusterID, release.ReleaseName, release.Namespace)
+		if err != nil {
+			if helm.ErrReleaseNotFound(err) {
+				changes = append(changes, integratedservices.IntegratedServiceSpecChange{
+					Path:      path,
+					Operation: integratedservices.SpecChangeAdd,
+					NewValue:  release.ChartName,
+				})
+
+				continue
+			}
+
+			return nil, errors.WrapIfWithDetails(err, "failed to get deployment", "release", release.ReleaseName)
+		}
+
+		if release.ChartVersion != "" && release.ChartVersion != deployment.ChartVersion {
+			changes = append(changes, integratedservices.IntegratedServiceSpecChange{
+				Path:      path + ".chartVersion",
+				Operation: integratedservices.SpecChangeReplace,
+				OldValue:  deployment.ChartVersion,
+				NewValue:  release.ChartVersion,
+			})
+		}
+
+		// normalize the installed values to the JSON representation used by the rendered ones
+		actualValues, err := normalizeValues(deployment.Values)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to normalize release values", "release", release.ReleaseName)
+		}
+
+		for _, change := range integratedservices.DiffIntegratedServiceSpecs(actualValues, release.Values) {
+			// secret values are redacted by dry runs, so they cannot be compared
+			if containsRedactedValue(change.NewValue) {
+				continue
+			}
+
+			change.Path = fmt.Sprintf("%s.values.%s", path, change.Path)
+			changes = append(changes, change)
+		}
+
+		// hand edits of the release's objects do not show up in the values stored by Helm
+		objects, err := parseManifestObjects(deployment.Manifest)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to parse release manifest", "release", release.ReleaseName)
+		}
+
+		for _, object := range objects {
+			if object.GetNamespace() == "" {
+				object.SetNamespace(release.Namespace)
+			}
+
+			objectChanges, err := d.detectObjectDrift(ctx, clusterID, path+".objects", object)
+			if err != nil {
+				return nil, errors.WrapIfWithDetails(err, "failed to detect release object drift", "release", release.ReleaseName)
+			}
+
+			changes = append(changes, objectChanges...)
+		}
+	}
+
+	for _, obj := range output.Objects {
+		objectChanges, err := d.detectObjectDrift(ctx, clusterID, "objects", &unstructured.Unstructured{Object: obj})
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, objectChanges...)
+	}
+
+	return changes, nil
+}
+
+// detectObjectDrift compares a rendered object with the live object in the cluster
+func (d HelmReleaseDriftDetector) detectObjectDrift(
+	ctx context.Context,
+	clusterID uint,
+	pathPrefix string,
+	object *unstructured.Unstructured,
+) ([]integratedservices.IntegratedServiceSpecChange, error) {
+	path := fmt.Sprintf("%s.%s", pathPrefix, objectKey(object))
+
+	liveObject := &unstructured.Unstructured{}
+	liveObject.SetAPIVersion(object.GetAPIVersion())
+	liveObject.SetKind(object.GetKind())
+
+	objRef := corev1.ObjectReference{
+		Namespace: object.GetNamespace(),
+		Name:      object.GetName(),
+	}
+
+	err := d.kubernetesService.GetObject(ctx, clusterID, objRef, liveObject)
+	if k8sapierrors.IsNotFound(errors.Cause(err)) {
+		return []integratedservices.IntegratedServiceSpecChange{
+			{
+				Path:      path,
+				Operation: integratedservices.SpecChangeAdd,
+				NewValue:  objectKey(object),
+			},
+		}, nil
+	} else if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to get object", "object", objectKey(object))
+	}
+
+	// normalize both objects to the same JSON representation
+	renderedValues, err := normalizeValues(object.Object)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to normalize rendered object", "object", objectKey(object))
+	}
+
+	liveValues, err := normalizeValues(liveObject.Object)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to normalize live object", "object", objectKey(object))
+	}
+
+	renderedValues, _ = pruneNilValues(renderedValues).(map[string]interface{})
+	liveValues, _ = pruneToShape(liveValues, renderedValues).(map[string]interface{})
+
+	changes := make([]integratedservices.IntegratedServiceSpecChange, 0)
+
+	for _, change := range integratedservices.DiffIntegratedServiceSpecs(liveValues, renderedValues) {
+		if containsRedactedValue(change.NewValue) {
+			continue
+		}
+
+		// secret values must not leave the cluster
+		if object.GetKind() == "Secret" && (strings.HasPrefix(change.Path, "data.") || strings.HasPrefix(change.Path, "stringData.")) {
+			if change.OldValue != nil {
+				change.OldValue = integratedservices.RedactedValue
+			}
+
+			if change.NewValue != nil {
+				change.NewValue = integratedservices.RedactedValue
+			}
+		}
+
+		change.Path = fmt.Sprintf("%s.%s", path, change.Path)
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+func objectKey(object *unstructured.Unstructured) string {
+	if object.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", object.GetKind(), object.GetName())
+	}
+
+	return fmt.Sprintf("%s/%s/%s", object.GetKind(), object.GetNamespace(), object.GetName())
+}
+
+var manifestSeparatorRegexp = regexp.MustCompile(`(?m)^---\s*$`)
+
+// parseManifestObjects splits a multi-document release manifest into objects
+func parseManifestObjects(manifest string) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+
+	for _, document := range manifestSeparatorRegexp.Split(manifest, -1) {
+		var object map[string]interface{}
+		if err := yaml.Unmarshal([]byte(document), &object); err != nil {
+			return nil, errors.WrapIf(err, "failed to decode manifest document")
+		}
+
+		// empty documents (eg. templates rendering nothing)
+		if object["kind"] == nil {
+			continue
+		}
+
+		objects = append(objects, &unstructured.Unstructured{Object: object})
+	}
+
+	return objects, nil
+}
+
+// pruneToShape drops every value of the live object that is not set in the rendered one
+// Lists of the same length are pruned item by item, lists of different lengths are kept as they are.
+func pruneToShape(live interface{}, rendered interface{}) interface{} {
+	switch renderedValue := rendered.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+
+		result := make(map[string]interface{}, len(renderedValue))
+		for key, value := range renderedValue {
+			if liveValue, ok := liveMap[key]; ok {
+				result[key] = pruneToShape(liveValue, value)
+			}
+		}
+
+		return result
+
+	case []interface{}:
+		liveList, ok := live.([]interface{})
+		if !ok || len(liveList) != len(renderedValue) {
+			return live
+		}
+
+		result := make([]interface{}, len(liveList))
+		for i := range liveList {
+			result[i] = pruneToShape(liveList[i], renderedValue[i])
+		}
+
+		return result
+
+	default:
+		return live
+	}
+}
+
+// pruneNilValues drops unset values (eg. an empty creation timestamp) from a rendered object
+func pruneNilValues(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if item != nil {
+				result[key] = pruneNilValues(item)
+			}
+		}
+
+		return result
+
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = pruneNilValues(item)
+		}
+
+		return result
+
+	default:
+		return value
+	}
+}
+
+// containsRedactedValue tells whether a value or any of its nested values were redacted by a dry run
+func containsRedactedValue(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.Contains(v, integratedservices.RedactedValue)
+
+	case map[string]interface{}:
+		for _, item := range v {
+			if containsRedactedValue(item) {
+				return true
+			}
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			if containsRedactedValue(item) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func normalizeValues(values map[string]interface{}) (map[string]interface{}, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]interface{}
+
+	return result, json.Unmarshal(data, &result)
+}
@@ -0,0 +1,304 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/pkg/helm"
+	legacyHelm "github.com/banzaicloud/pipeline/src/helm"
+)
+
+type dryRunOperator struct {
+	name   string
+	output integratedservices.IntegratedServiceDryRunOutput
+	err    error
+}
+
+func (o dryRunOperator) Apply(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) error {
+	return nil
+}
+
+func (o dryRunOperator) Deactivate(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) error {
+	return nil
+}
+
+func (o dryRunOperator) DryRun(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	return o.output, o.err
+}
+
+func (o dryRunOperator) Name() string {
+	return o.name
+}
+
+// liveObjects is a fake KubernetesService serving objects keyed by kind, namespace and name
+type liveObjects map[string]map[string]interface{}
+
+func (o liveObjects) GetObject(ctx context.Context, clusterID uint, objRef corev1.ObjectReference, obj runtime.Object) error {
+	u := obj.(*unstructured.Unstructured)
+
+	object, ok := o[u.GetKind()+"/"+objRef.Namespace+"/"+objRef.Name]
+	if !ok {
+		return k8sapierrors.NewNotFound(schema.GroupResource{Resource: u.GetKind()}, objRef.Name)
+	}
+
+	u.Object = runtime.DeepCopyJSON(object)
+
+	return nil
+}
+
+func TestHelmReleaseDriftDetector_DetectDrift(t *testing.T) {
+	const clusterID = 1
+
+	output := integratedservices.IntegratedServiceDryRunOutput{
+		Releases: []integratedservices.HelmReleaseDryRun{
+			{
+				ReleaseName:  "operator",
+				Namespace:    "pipeline-system",
+				ChartName:    "banzaicloud-stable/operator",
+				ChartVersion: "1.0.0",
+				Values: map[string]interface{}{
+					"replicas": float64(2),
+					"password": integratedservices.RedactedValue,
+				},
+			},
+			{
+				ReleaseName: "missing",
+				Namespace:   "pipeline-system",
+				ChartName:   "banzaicloud-stable/missing",
+			},
+		},
+	}
+
+	t.Run("drifted", func(t *testing.T) {
+		helmService := new(MockHelmService)
+		helmService.On("GetDeployment", mock.Anything, uint(clusterID), "operator", "pipeline-system").Return(&helm.GetDeploymentResponse{
+			ReleaseName:  "operator",
+			ChartVersion: "0.9.0",
+			Values: map[string]interface{}{
+				"replicas": 1,
+				"password": "secret",
+			},
+		}, nil)
+		helmService.On("GetDeployment", mock.Anything, uint(clusterID), "missing", "pipeline-system").Return(nil, &legacyHelm.DeploymentNotFoundError{HelmError: errors.New("release: not found")})
+
+		registry := integratedservices.MakeIntegratedServiceOperatorRegistry([]integratedservices.IntegratedServiceOperator{
+			dryRunOperator{name: "myService", output: output},
+		})
+
+		changes, err := NewHelmReleaseDriftDetector(registry, helmService, liveObjects{}).DetectDrift(context.Background(), clusterID, "myService", integratedservices.IntegratedServiceSpec{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []integratedservices.IntegratedServiceSpecChange{
+			{
+				Path:      "releases.operator.chartVersion",
+				Operation: integratedservices.SpecChangeReplace,
+				OldValue:  "0.9.0",
+				NewValue:  "1.0.0",
+			},
+			{
+				Path:      "releases.operator.values.replicas",
+				Operation: integratedservices.SpecChangeReplace,
+				OldValue:  float64(1),
+				NewValue:  float64(2),
+			},
+			{
+				Path:      "releases.missing",
+				Operation: integratedservices.SpecChangeAdd,
+				NewValue:  "banzaicloud-stable/missing",
+			},
+		}, changes)
+
+		helmService.AssertExpectations(t)
+	})
+
+	t.Run("hand edited objects", func(t *testing.T) {
+		const manifest = `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: operator
+        image: operator:1.0.0
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: operator
+data:
+  token: c2VjcmV0
+`
+
+		helmService := new(MockHelmService)
+		helmService.On("GetDeployment", mock.Anything, uint(clusterID), "operator", "pipeline-system").Return(&helm.GetDeploymentResponse{
+			ReleaseName:  "operator",
+			ChartVersion: "1.0.0",
+			Values: map[string]interface{}{
+				"replicas": 2,
+			},
+			Manifest: manifest,
+		}, nil)
+
+		registry := integratedservices.MakeIntegratedServiceOperatorRegistry([]integratedservices.IntegratedServiceOperator{
+			dryRunOperator{
+				name: "myService",
+				output: integratedservices.IntegratedServiceDryRunOutput{
+					Releases: []integratedservices.HelmReleaseDryRun{output.Releases[0]},
+					Objects: []map[string]interface{}{
+						{
+							"apiVersion": "logging.banzaicloud.io/v1beta1",
+							"kind":       "Logging",
+							"metadata": map[string]interface{}{
+								"name":              "logging",
+								"creationTimestamp": nil,
+							},
+							"spec": map[string]interface{}{
+								"controlNamespace": "pipeline-system",
+								"fluentd": map[string]interface{}{
+									"replicas": int64(1),
+								},
+							},
+						},
+						{
+							"apiVersion": "cert-manager.io/v1alpha2",
+							"kind":       "ClusterIssuer",
+							"metadata": map[string]interface{}{
+								"name": "letsencrypt",
+							},
+						},
+					},
+				},
+			},
+		})
+
+		objects := liveObjects{
+			"Deployment/pipeline-system/operator": {
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":              "operator",
+					"namespace":         "pipeline-system",
+					"creationTimestamp": "2020-01-01T00:00:00Z",
+				},
+				"spec": map[string]interface{}{
+					"replicas": int64(5),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{
+									"name":            "operator",
+									"image":           "operator:1.0.0",
+									"imagePullPolicy": "IfNotPresent",
+								},
+							},
+						},
+					},
+				},
+				"status": map[string]interface{}{
+					"replicas": int64(5),
+				},
+			},
+			"Secret/pipeline-system/operator": {
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata": map[string]interface{}{
+					"name":      "operator",
+					"namespace": "pipeline-system",
+				},
+				"data": map[string]interface{}{
+					"token": "aGFja2Vk",
+				},
+			},
+			"Logging//logging": {
+				"apiVersion": "logging.banzaicloud.io/v1beta1",
+				"kind":       "Logging",
+				"metadata": map[string]interface{}{
+					"name":              "logging",
+					"creationTimestamp": "2020-01-01T00:00:00Z",
+					"generation":        int64(2),
+				},
+				"spec": map[string]interface{}{
+					"controlNamespace": "pipeline-system",
+					"fluentd": map[string]interface{}{
+						"replicas": int64(3),
+					},
+				},
+			},
+		}
+
+		changes, err := NewHelmReleaseDriftDetector(registry, helmService, objects).DetectDrift(context.Background(), clusterID, "myService", integratedservices.IntegratedServiceSpec{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []integratedservices.IntegratedServiceSpecChange{
+			{
+				Path:      "releases.operator.objects.Deployment/pipeline-system/operator.spec.replicas",
+				Operation: integratedservices.SpecChangeReplace,
+				OldValue:  float64(5),
+				NewValue:  float64(2),
+			},
+			{
+				Path:      "releases.operator.objects.Secret/pipeline-system/operator.data.token",
+				Operation: integratedservices.SpecChangeReplace,
+				OldValue:  integratedservices.RedactedValue,
+				NewValue:  integratedservices.RedactedValue,
+			},
+			{
+				Path:      "objects.Logging/logging.spec.fluentd.replicas",
+				Operation: integratedservices.SpecChangeReplace,
+				OldValue:  float64(3),
+				NewValue:  float64(1),
+			},
+			{
+				Path:      "objects.ClusterIssuer/letsencrypt",
+				Operation: integratedservices.SpecChangeAdd,
+				NewValue:  "ClusterIssuer/letsencrypt",
+			},
+		}, changes)
+
+		helmService.AssertExpectations(t)
+	})
+
+	t.Run("dry run not supported", func(t *testing.T) {
+		helmService := new(MockHelmService)
+
+		registry := integratedservices.MakeIntegratedServiceOperatorRegistry([]integratedservices.IntegratedServiceOperator{
+			dryRunOperator{name: "myService", err: errors.WithStack(integratedservices.DryRunNotSupportedError{IntegratedServiceName: "myService"})},
+		})
+
+		changes, err := NewHelmReleaseDriftDetector(registry, helmService, liveObjects{}).DetectDrift(context.Background(), clusterID, "myService", integratedservices.IntegratedServiceSpec{})
+		require.NoError(t, err)
+
+		assert.Empty(t, changes)
+
+		helmService.AssertExpectations(t)
+	})
+}
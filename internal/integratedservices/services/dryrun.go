@@ -0,0 +1,78 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+// NewRedactingSecretStore returns a secret store that hides the values of the secrets it reads.
+// It's used by dry runs to render resources without exposing secret values.
+func NewRedactingSecretStore(secretStore SecretStore) SecretStore {
+	return redactingSecretStore{secretStore: secretStore}
+}
+
+type redactingSecretStore struct {
+	secretStore SecretStore
+}
+
+func (s redactingSecretStore) GetSecretValues(ctx context.Context, secretID string) (map[string]string, error) {
+	values, err := s.secretStore.GetSecretValues(ctx, secretID)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := make(map[string]string, len(values))
+	for key := range values {
+		redacted[key] = integratedservices.RedactedValue
+	}
+
+	return redacted, nil
+}
+
+func (s redactingSecretStore) GetNameByID(ctx context.Context, secretID string) (string, error) {
+	return s.secretStore.GetNameByID(ctx, secretID)
+}
+
+func (s redactingSecretStore) GetIDByName(ctx context.Context, secretName string) (string, error) {
+	return s.secretStore.GetIDByName(ctx, secretName)
+}
+
+func (s redactingSecretStore) Delete(ctx context.Context, secretID string) error {
+	return errors.NewWithDetails("cannot delete secrets during dry run", "secretId", secretID)
+}
+
+// NewHelmReleaseDryRun returns the dry run representation of a Helm release.
+func NewHelmReleaseDryRun(namespace string, chartName string, releaseName string, values []byte, chartVersion string) (integratedservices.HelmReleaseDryRun, error) {
+	release := integratedservices.HelmReleaseDryRun{
+		ReleaseName:  releaseName,
+		Namespace:    namespace,
+		ChartName:    chartName,
+		ChartVersion: chartVersion,
+	}
+
+	if len(values) > 0 {
+		if err := json.Unmarshal(values, &release.Values); err != nil {
+			return release, errors.WrapIfWithDetails(err, "failed to decode chart values", "release", releaseName)
+		}
+	}
+
+	return release, nil
+}
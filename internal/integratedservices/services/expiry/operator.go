@@ -61,3 +61,8 @@ func (e expiryServiceOperator) Deactivate(ctx context.Context, clusterID uint, s
 
 	return nil
 }
+
+// DryRun returns an error since the Expiry integrated service does not support dry runs
+func (e expiryServiceOperator) DryRun(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	return integratedservices.IntegratedServiceDryRunOutput{}, errors.WithStack(integratedservices.DryRunNotSupportedError{IntegratedServiceName: ServiceName})
+}
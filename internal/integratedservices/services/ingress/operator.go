@@ -92,3 +92,8 @@ func (op Operator) Deactivate(ctx context.Context, clusterID uint, spec integrat
 
 	return nil
 }
+
+// DryRun returns an error since the Ingress integrated service does not support dry runs
+func (op Operator) DryRun(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	return integratedservices.IntegratedServiceDryRunOutput{}, errors.WithStack(integratedservices.DryRunNotSupportedError{IntegratedServiceName: ServiceName})
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/banzaicloud/pipeline/internal/integratedservices"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/certmanager"
 	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
 	"github.com/banzaicloud/pipeline/pkg/any"
 	"github.com/banzaicloud/pipeline/pkg/jsonstructure"
@@ -155,6 +156,96 @@ func (op IntegratedServiceOperator) Deactivate(ctx context.Context, clusterID ui
 	return nil
 }
 
+// DryRun renders the Helm releases and the Logging resource of the integrated service without applying them
+func (op IntegratedServiceOperator) DryRun(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	ctx, err := op.ensureOrgIDInContext(ctx, clusterID)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	boundSpec, err := bindIntegratedServiceSpec(spec)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: integratedServiceName,
+			Problem:               err.Error(),
+		}
+	}
+
+	operatorValues, err := op.generateLoggingOperatorChartValues()
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	operatorRelease, err := services.NewHelmReleaseDryRun(
+		op.config.Namespace,
+		op.config.Charts.Operator.Chart,
+		loggingOperatorReleaseName,
+		operatorValues,
+		op.config.Charts.Operator.Version,
+	)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	output := integratedservices.IntegratedServiceDryRunOutput{
+		Releases: []integratedservices.HelmReleaseDryRun{operatorRelease},
+	}
+
+	if boundSpec.Loki.Enabled {
+		var annotations map[string]interface{}
+		if boundSpec.Loki.Ingress.Enabled {
+			// the Loki secret is only generated when the integrated service is applied
+			secretName := getLokiSecretName(clusterID)
+			if boundSpec.Loki.Ingress.SecretID != "" {
+				secretName, err = op.secretStore.GetNameByID(ctx, boundSpec.Loki.Ingress.SecretID)
+				if err != nil {
+					return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIfWithDetails(err,
+						"failed to get Loki secret",
+						"secretID", boundSpec.Loki.Ingress.SecretID)
+				}
+			}
+
+			annotations = generateAnnotations(secretName)
+		}
+
+		lokiValues, err := op.generateLokiChartValues(boundSpec.Loki, annotations)
+		if err != nil {
+			return integratedservices.IntegratedServiceDryRunOutput{}, err
+		}
+
+		lokiRelease, err := services.NewHelmReleaseDryRun(
+			op.config.Namespace,
+			op.config.Charts.Loki.Chart,
+			lokiReleaseName,
+			lokiValues,
+			op.config.Charts.Loki.Version,
+		)
+		if err != nil {
+			return integratedservices.IntegratedServiceDryRunOutput{}, err
+		}
+
+		output.Releases = append(output.Releases, lokiRelease)
+	}
+
+	loggingResource := op.newLoggingResource(boundSpec)
+	loggingResource.APIVersion = v1beta1.GroupVersion.String()
+	loggingResource.Kind = "Logging"
+
+	loggingResourceBytes, err := json.Marshal(loggingResource)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to encode Logging resource")
+	}
+
+	var loggingObject map[string]interface{}
+	if err := json.Unmarshal(loggingResourceBytes, &loggingObject); err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to decode Logging resource")
+	}
+
+	output.Objects = append(output.Objects, loggingObject)
+
+	return output, nil
+}
+
 func (op IntegratedServiceOperator) ensureOrgIDInContext(ctx context.Context, clusterID uint) (context.Context, error) {
 	if _, ok := auth.GetCurrentOrganizationID(ctx); !ok {
 		cluster, err := op.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
@@ -290,30 +381,9 @@ func (op IntegratedServiceOperator) processLoki(ctx context.Context, spec lokiSp
 			annotations = generateAnnotations(secretName)
 		}
 
-		domain := spec.Ingress.Domain
-		if domain == "" {
-			domain = "/"
-		}
-
-		chartValues := &lokiValues{
-			Ingress: ingressValues{
-				Enabled:     spec.Ingress.Enabled,
-				Hosts:       []string{path.Join(domain, spec.Ingress.Path)},
-				Annotations: annotations,
-			},
-			Image: imageValues{
-				Repository: op.config.Images.Loki.Repository,
-				Tag:        op.config.Images.Loki.Tag,
-			},
-		}
-
-		lokiConfigValues, err := copystructure.Copy(op.config.Charts.Loki.Values)
-		if err != nil {
-			return errors.WrapIf(err, "failed to copy loki values")
-		}
-		valuesBytes, err := mergeValuesWithConfig(chartValues, lokiConfigValues)
+		valuesBytes, err := op.generateLokiChartValues(spec, annotations)
 		if err != nil {
-			return errors.WrapIf(err, "failed to merge loki values with config")
+			return err
 		}
 
 		if err := op.helmService.ApplyDeployment(
@@ -332,6 +402,45 @@ func (op IntegratedServiceOperator) processLoki(ctx context.Context, spec lokiSp
 	return nil
 }
 
+func (op IntegratedServiceOperator) generateLokiChartValues(spec lokiSpec, annotations map[string]interface{}) ([]byte, error) {
+	domain := spec.Ingress.Domain
+	if domain == "" {
+		domain = "/"
+	}
+
+	chartValues := &lokiValues{
+		Ingress: ingressValues{
+			Enabled:     spec.Ingress.Enabled,
+			Hosts:       []string{path.Join(domain, spec.Ingress.Path)},
+			Annotations: annotations,
+		},
+		Image: imageValues{
+			Repository: op.config.Images.Loki.Repository,
+			Tag:        op.config.Images.Loki.Tag,
+		},
+	}
+
+	if spec.Ingress.Enabled && spec.Ingress.ClusterIssuer != "" {
+		chartValues.Ingress.Annotations, chartValues.Ingress.TLS = certmanager.RequestIngressCertificate(
+			annotations,
+			spec.Ingress.ClusterIssuer,
+			lokiReleaseName,
+			spec.Ingress.Domain,
+		)
+	}
+
+	lokiConfigValues, err := copystructure.Copy(op.config.Charts.Loki.Values)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to copy loki values")
+	}
+	valuesBytes, err := mergeValuesWithConfig(chartValues, lokiConfigValues)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to merge loki values with config")
+	}
+
+	return valuesBytes, nil
+}
+
 func (op IntegratedServiceOperator) installLokiSecret(ctx context.Context, secretName string, cl integratedserviceadapter.Cluster) error {
 	installSecretRequest := pkgCluster.InstallSecretRequest{
 		SourceSecretName: secretName,
@@ -400,6 +509,16 @@ func (op IntegratedServiceOperator) installSecret(ctx context.Context, cl integr
 }
 
 func (op IntegratedServiceOperator) installLoggingOperator(ctx context.Context, clusterID uint) error {
+	valuesBytes, err := op.generateLoggingOperatorChartValues()
+	if err != nil {
+		return err
+	}
+
+	return op.helmService.ApplyDeploymentSkipCRDs(
+		ctx, clusterID, op.config.Namespace, op.config.Charts.Operator.Chart, loggingOperatorReleaseName, valuesBytes, op.config.Charts.Operator.Version)
+}
+
+func (op IntegratedServiceOperator) generateLoggingOperatorChartValues() ([]byte, error) {
 	chartValues := loggingOperatorValues{
 		Image: imageValues{
 			Repository: op.config.Images.Operator.Repository,
@@ -409,15 +528,14 @@ func (op IntegratedServiceOperator) installLoggingOperator(ctx context.Context,
 
 	operatorConfigValues, err := copystructure.Copy(op.config.Charts.Operator.Values)
 	if err != nil {
-		return errors.WrapIf(err, "failed to copy operator values")
+		return nil, errors.WrapIf(err, "failed to copy operator values")
 	}
 	valuesBytes, err := mergeValuesWithConfig(chartValues, operatorConfigValues)
 	if err != nil {
-		return errors.WrapIf(err, "failed to merge operator values with config")
+		return nil, errors.WrapIf(err, "failed to merge operator values with config")
 	}
 
-	return op.helmService.ApplyDeploymentSkipCRDs(
-		ctx, clusterID, op.config.Namespace, op.config.Charts.Operator.Chart, loggingOperatorReleaseName, valuesBytes, op.config.Charts.Operator.Version)
+	return valuesBytes, nil
 }
 
 func mergeValuesWithConfig(chartValues interface{}, configValues interface{}) ([]byte, error) {
@@ -435,6 +553,26 @@ func mergeValuesWithConfig(chartValues interface{}, configValues interface{}) ([
 }
 
 func (op IntegratedServiceOperator) createLoggingResource(ctx context.Context, clusterID uint, spec integratedServiceSpec) error {
+	loggingResource := op.newLoggingResource(spec)
+
+	var oldLoggingResource v1beta1.Logging
+	if err := op.kubernetesService.GetObject(ctx, clusterID, corev1.ObjectReference{
+		Namespace: op.config.Namespace,
+		Name:      loggingResourceName,
+	}, &oldLoggingResource); err != nil {
+		if k8sapierrors.IsNotFound(err) {
+			// Logging resource is not found, create it
+			return op.kubernetesService.EnsureObject(ctx, clusterID, loggingResource)
+		}
+
+		return errors.WrapIf(err, "failed to get Logging resource")
+	}
+
+	loggingResource.ResourceVersion = oldLoggingResource.ResourceVersion
+	return op.kubernetesService.Update(ctx, clusterID, loggingResource)
+}
+
+func (op IntegratedServiceOperator) newLoggingResource(spec integratedServiceSpec) *v1beta1.Logging {
 	tlsEnabled := spec.Logging.TLS
 	loggingResource := &v1beta1.Logging{
 		ObjectMeta: metav1.ObjectMeta{
@@ -481,19 +619,5 @@ func (op IntegratedServiceOperator) createLoggingResource(ctx context.Context, c
 		loggingResource.Spec.FluentbitSpec.TLS.SharedKey = sharedKey
 	}
 
-	var oldLoggingResource v1beta1.Logging
-	if err := op.kubernetesService.GetObject(ctx, clusterID, corev1.ObjectReference{
-		Namespace: op.config.Namespace,
-		Name:      loggingResourceName,
-	}, &oldLoggingResource); err != nil {
-		if k8sapierrors.IsNotFound(err) {
-			// Logging resource is not found, create it
-			return op.kubernetesService.EnsureObject(ctx, clusterID, loggingResource)
-		}
-
-		return errors.WrapIf(err, "failed to get Logging resource")
-	}
-
-	loggingResource.ResourceVersion = oldLoggingResource.ResourceVersion
-	return op.kubernetesService.Update(ctx, clusterID, loggingResource)
+	return loggingResource
 }
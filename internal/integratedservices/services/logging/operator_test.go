@@ -163,3 +163,52 @@ func TestIntegratedServiceOperator_Deactivate(t *testing.T) {
 
 	_ = op.Deactivate(ctx, clusterID, nil)
 }
+
+func TestIntegratedServiceOperator_DryRun(t *testing.T) {
+	clusterID := uint(42)
+	orgID := uint(13)
+
+	clusterGetter := dummyClusterGetter{
+		Clusters: map[uint]dummyCluster{
+			clusterID: {
+				Status: pkgCluster.Running,
+				ID:     clusterID,
+				OrgID:  orgID,
+			},
+		},
+	}
+	clusterService := integratedserviceadapter.NewClusterService(clusterGetter)
+	orgSecretStore := dummyOrganizationalSecretStore{
+		Secrets: map[uint]map[string]*secret.SecretItemResponse{
+			orgID: nil,
+		},
+	}
+	secretStore := commonadapter.NewSecretStore(orgSecretStore, commonadapter.OrgIDContextExtractorFunc(auth.GetCurrentOrganizationID))
+	kubernetesService := dummyKubernetesService{}
+	op := MakeIntegratedServicesOperator(clusterGetter, clusterService, dummyHelmService{}, &kubernetesService, dummyEndpointService{}, Config{}, services.NoopLogger{}, secretStore)
+
+	spec := integratedservices.IntegratedServiceSpec{
+		"logging": map[string]interface{}{
+			"tls": true,
+		},
+		"loki": map[string]interface{}{
+			"enabled": true,
+			"ingress": map[string]interface{}{
+				"enabled": true,
+				"domain":  "example.com",
+			},
+		},
+	}
+
+	output, err := op.DryRun(context.Background(), clusterID, spec)
+	assert.NoError(t, err)
+
+	if assert.Len(t, output.Releases, 2) {
+		assert.Equal(t, loggingOperatorReleaseName, output.Releases[0].ReleaseName)
+		assert.Equal(t, lokiReleaseName, output.Releases[1].ReleaseName)
+	}
+
+	if assert.Len(t, output.Objects, 1) {
+		assert.Equal(t, "Logging", output.Objects[0]["kind"])
+	}
+}
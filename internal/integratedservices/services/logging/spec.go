@@ -38,6 +38,9 @@ type ingressSpec struct {
 	Domain   string `json:"domain" mapstructure:"domain"`
 	Path     string `json:"path" mapstructure:"path"`
 	SecretID string `json:"secretId" mapstructure:"secretId"`
+
+	// ClusterIssuer is the name of the cert-manager cluster issuer requested to issue a certificate for the domain
+	ClusterIssuer string `json:"clusterIssuer,omitempty" mapstructure:"clusterIssuer"`
 }
 
 type loggingSpec struct {
@@ -108,6 +111,10 @@ func (s ingressSpec) Validate() error {
 				return errors.New("invalid ingress domain")
 			}
 		}
+
+		if s.ClusterIssuer != "" && s.Domain == "" {
+			return requiredFieldError{name: "domain"}
+		}
 	}
 
 	return nil
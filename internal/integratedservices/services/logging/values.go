@@ -14,6 +14,10 @@
 
 package logging
 
+import (
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/certmanager"
+)
+
 type loggingOperatorValues struct {
 	Image imageValues `json:"image" mapstructure:"image"`
 }
@@ -30,8 +34,9 @@ type lokiValues struct {
 }
 
 type ingressValues struct {
-	Enabled     bool                   `json:"enabled" mapstructure:"enabled"`
-	Hosts       []string               `json:"hosts" mapstructure:"hosts"`
-	Path        string                 `json:"path,omitempty" mapstructure:"path"`
-	Annotations map[string]interface{} `json:"annotations,omitempty" mapstructure:"annotations"`
+	Enabled     bool                     `json:"enabled" mapstructure:"enabled"`
+	Hosts       []string                 `json:"hosts" mapstructure:"hosts"`
+	Path        string                   `json:"path,omitempty" mapstructure:"path"`
+	Annotations map[string]interface{}   `json:"annotations,omitempty" mapstructure:"annotations"`
+	TLS         []certmanager.IngressTLS `json:"tls,omitempty" mapstructure:"tls"`
 }
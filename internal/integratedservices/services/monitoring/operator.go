@@ -217,12 +217,132 @@ func (op IntegratedServiceOperator) Deactivate(ctx context.Context, clusterID ui
 	return nil
 }
 
+// DryRun renders the Helm releases of the integrated service without applying them
+func (op IntegratedServiceOperator) DryRun(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	ctx, err := op.ensureOrgIDInContext(ctx, clusterID)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	boundSpec, err := bindIntegratedServiceSpec(spec)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: integratedServiceName,
+			Problem:               err.Error(),
+		}
+	}
+
+	cluster, err := op.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to get cluster")
+	}
+
+	dryRunOp := op
+	dryRunOp.secretStore = services.NewRedactingSecretStore(op.secretStore)
+
+	var grafanaUser string
+	var grafanaPass string
+	if boundSpec.Grafana.Enabled {
+		grafanaUser = integratedservices.RedactedValue
+		grafanaPass = integratedservices.RedactedValue
+	}
+
+	baseSecretInfoer := baseSecretInfoer{
+		clusterID: clusterID,
+	}
+
+	var prometheusSecretName string
+	if boundSpec.Prometheus.Enabled && boundSpec.Prometheus.Ingress.Enabled {
+		manager := secretManager{
+			operator: dryRunOp,
+			cluster:  cluster,
+			infoer:   prometheusSecretInfoer{baseSecretInfoer: baseSecretInfoer},
+		}
+		prometheusSecretName, err = manager.getComponentSecretName(ctx, boundSpec.Prometheus.Ingress)
+		if err != nil {
+			return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to get Prometheus secret name")
+		}
+	}
+
+	var alertmanagerSecretName string
+	if boundSpec.Alertmanager.Enabled && boundSpec.Alertmanager.Ingress.Enabled {
+		manager := secretManager{
+			operator: dryRunOp,
+			cluster:  cluster,
+			infoer:   alertmanagerSecretInfoer{baseSecretInfoer: baseSecretInfoer},
+		}
+		alertmanagerSecretName, err = manager.getComponentSecretName(ctx, boundSpec.Alertmanager.Ingress)
+		if err != nil {
+			return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to get Alertmanager secret name")
+		}
+	}
+
+	operatorValues, err := dryRunOp.generatePrometheusOperatorChartValues(ctx, clusterID, boundSpec, grafanaUser, grafanaPass, prometheusSecretName, alertmanagerSecretName)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	operatorRelease, err := services.NewHelmReleaseDryRun(
+		op.config.Namespace,
+		op.config.Charts.Operator.Chart,
+		prometheusOperatorReleaseName,
+		operatorValues,
+		op.config.Charts.Operator.Version,
+	)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	output := integratedservices.IntegratedServiceDryRunOutput{
+		Releases: []integratedservices.HelmReleaseDryRun{operatorRelease},
+	}
+
+	if boundSpec.Pushgateway.Enabled {
+		pushgatewayValues, err := dryRunOp.generatePrometheusPushgatewayChartValues()
+		if err != nil {
+			return integratedservices.IntegratedServiceDryRunOutput{}, err
+		}
+
+		pushgatewayRelease, err := services.NewHelmReleaseDryRun(
+			op.config.Namespace,
+			op.config.Charts.Pushgateway.Chart,
+			prometheusPushgatewayReleaseName,
+			pushgatewayValues,
+			op.config.Charts.Pushgateway.Version,
+		)
+		if err != nil {
+			return integratedservices.IntegratedServiceDryRunOutput{}, err
+		}
+
+		output.Releases = append(output.Releases, pushgatewayRelease)
+	}
+
+	return output, nil
+}
+
 func (op IntegratedServiceOperator) installPrometheusPushGateway(
 	ctx context.Context,
 	cluster integratedserviceadapter.Cluster,
 	spec pushgatewaySpec,
 	logger common.Logger,
 ) error {
+	valuesBytes, err := op.generatePrometheusPushgatewayChartValues()
+	if err != nil {
+		return err
+	}
+
+	return op.helmService.ApplyDeployment(
+		ctx,
+		cluster.GetID(),
+		op.config.Namespace,
+		op.config.Charts.Pushgateway.Chart,
+		prometheusPushgatewayReleaseName,
+		valuesBytes,
+		op.config.Charts.Pushgateway.Version,
+	)
+}
+
+func (op IntegratedServiceOperator) generatePrometheusPushgatewayChartValues() ([]byte, error) {
 	chartValues := &prometheusPushgatewayValues{
 		Image: imageValues{
 			Repository: op.config.Images.Pushgateway.Repository,
@@ -236,22 +356,14 @@ func (op IntegratedServiceOperator) installPrometheusPushGateway(
 
 	pushgatewayConfigValues, err := copystructure.Copy(op.config.Charts.Pushgateway.Values)
 	if err != nil {
-		return errors.WrapIf(err, "failed to copy pushgateway values")
+		return nil, errors.WrapIf(err, "failed to copy pushgateway values")
 	}
 	valuesBytes, err := mergeOperatorValuesWithConfig(*chartValues, pushgatewayConfigValues)
 	if err != nil {
-		return errors.WrapIf(err, "failed to merge pushgateway values with config")
+		return nil, errors.WrapIf(err, "failed to merge pushgateway values with config")
 	}
 
-	return op.helmService.ApplyDeployment(
-		ctx,
-		cluster.GetID(),
-		op.config.Namespace,
-		op.config.Charts.Pushgateway.Chart,
-		prometheusPushgatewayReleaseName,
-		valuesBytes,
-		op.config.Charts.Pushgateway.Version,
-	)
+	return valuesBytes, nil
 }
 
 func (op IntegratedServiceOperator) installPrometheusOperator(
@@ -274,14 +386,65 @@ func (op IntegratedServiceOperator) installPrometheusOperator(
 		grafanaPass = grafanaSecret[secrettype.Password]
 	}
 
+	valuesBytes, err := op.generatePrometheusOperatorChartValues(ctx, cluster.GetID(), spec, grafanaUser, grafanaPass, prometheusSecretName, alertmanagerSecretName)
+	if err != nil {
+		return err
+	}
+
+	if op.migrator != nil {
+		release, err := op.helmService.GetDeployment(ctx, cluster.GetID(), prometheusOperatorReleaseName, op.config.Namespace)
+		if err != nil {
+			if !helm.ErrReleaseNotFound(err) {
+				return err
+			}
+		} else {
+			k8sClientFactory := func() (kubernetes.Interface, error) {
+				kubeConfig, err := cluster.GetK8sConfig()
+				if err != nil {
+					return nil, err
+				}
+				client, err := k8sclient.NewClientFromKubeConfig(kubeConfig)
+				if err != nil {
+					return nil, err
+				}
+				return client, nil
+			}
+
+			err = op.migrator(ctx, k8sClientFactory, op.config.Namespace, release.ChartVersion, op.config.Charts.Operator.Version)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return op.helmService.ApplyDeployment(
+		ctx,
+		cluster.GetID(),
+		op.config.Namespace,
+		op.config.Charts.Operator.Chart,
+		prometheusOperatorReleaseName,
+		valuesBytes,
+		op.config.Charts.Operator.Version,
+	)
+}
+
+func (op IntegratedServiceOperator) generatePrometheusOperatorChartValues(
+	ctx context.Context,
+	clusterID uint,
+	spec integratedServiceSpec,
+	grafanaUser string,
+	grafanaPass string,
+	prometheusSecretName string,
+	alertmanagerSecretName string,
+) ([]byte, error) {
 	valuesManager := chartValuesManager{
 		operator:  op,
-		clusterID: cluster.GetID(),
+		clusterID: clusterID,
 	}
 
 	alertmanagerValues, err := valuesManager.generateAlertmanagerChartValues(ctx, spec.Alertmanager, alertmanagerSecretName, op.config.Images.Alertmanager)
 	if err != nil {
-		return errors.WrapIf(err, "failed to generate Alertmanager chart values")
+		return nil, errors.WrapIf(err, "failed to generate Alertmanager chart values")
 	}
 
 	// create chart values
@@ -322,48 +485,14 @@ func (op IntegratedServiceOperator) installPrometheusOperator(
 
 	operatorConfigValues, err := copystructure.Copy(op.config.Charts.Operator.Values)
 	if err != nil {
-		return errors.WrapIf(err, "failed to copy operator values")
+		return nil, errors.WrapIf(err, "failed to copy operator values")
 	}
 	valuesBytes, err := mergeOperatorValuesWithConfig(*chartValues, operatorConfigValues)
 	if err != nil {
-		return errors.WrapIf(err, "failed to merge operator values with config")
-	}
-
-	if op.migrator != nil {
-		release, err := op.helmService.GetDeployment(ctx, cluster.GetID(), prometheusOperatorReleaseName, op.config.Namespace)
-		if err != nil {
-			if !helm.ErrReleaseNotFound(err) {
-				return err
-			}
-		} else {
-			k8sClientFactory := func() (kubernetes.Interface, error) {
-				kubeConfig, err := cluster.GetK8sConfig()
-				if err != nil {
-					return nil, err
-				}
-				client, err := k8sclient.NewClientFromKubeConfig(kubeConfig)
-				if err != nil {
-					return nil, err
-				}
-				return client, nil
-			}
-
-			err = op.migrator(ctx, k8sClientFactory, op.config.Namespace, release.ChartVersion, op.config.Charts.Operator.Version)
-			if err != nil {
-				return err
-			}
-		}
+		return nil, errors.WrapIf(err, "failed to merge operator values with config")
 	}
 
-	return op.helmService.ApplyDeployment(
-		ctx,
-		cluster.GetID(),
-		op.config.Namespace,
-		op.config.Charts.Operator.Chart,
-		prometheusOperatorReleaseName,
-		valuesBytes,
-		op.config.Charts.Operator.Version,
-	)
+	return valuesBytes, nil
 }
 
 func mergeOperatorValuesWithConfig(chartValues interface{}, configValues interface{}) ([]byte, error) {
@@ -616,7 +745,7 @@ func (m chartValuesManager) generateGrafanaChartValues(
 					Enabled: spec.Ingress.Enabled,
 					Hosts:   []string{spec.Ingress.Domain},
 					Path:    spec.Ingress.Path,
-				},
+				}.withCertificate(spec.Ingress, prometheusOperatorReleaseName+"-grafana"),
 			},
 			AdminUser:     username,
 			AdminPassword: password,
@@ -674,7 +803,7 @@ func (m chartValuesManager) generateAlertmanagerChartValues(
 					Hosts:       []string{spec.Ingress.Domain},
 					Paths:       []string{spec.Ingress.Path},
 					Annotations: annotations,
-				},
+				}.withCertificate(spec.Ingress.baseIngressSpec, prometheusOperatorReleaseName+"-alertmanager"),
 			},
 			Spec: baseSpecValues{
 				RoutePrefix: spec.Ingress.Path,
@@ -723,7 +852,7 @@ func (m chartValuesManager) generatePrometheusChartValues(
 					Hosts:       []string{spec.Ingress.Domain},
 					Paths:       []string{spec.Ingress.Path},
 					Annotations: annotations,
-				},
+				}.withCertificate(spec.Ingress.baseIngressSpec, prometheusOperatorReleaseName+"-prometheus"),
 			},
 			Spec: PrometheusSpecValues{
 				baseSpecValues: baseSpecValues{
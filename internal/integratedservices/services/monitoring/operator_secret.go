@@ -108,6 +108,21 @@ func (m secretManager) getComponentSecret(
 	return secretName, nil
 }
 
+// getComponentSecretName returns the name of the component secret without generating it
+func (m secretManager) getComponentSecretName(ctx context.Context, ingress ingressSpecWithSecret) (string, error) {
+	if ingress.SecretID == "" {
+		return m.infoer.generatedSecretName(), nil
+	}
+
+	secretName, err := m.operator.secretStore.GetNameByID(ctx, ingress.SecretID)
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to get secret",
+			"secretID", ingress.SecretID, "component", m.infoer.name())
+	}
+
+	return secretName, nil
+}
+
 func (m secretManager) installSecret(ctx context.Context, clusterID uint, secretName string) error {
 	installSecretRequest := pkgCluster.InstallSecretRequest{
 		SourceSecretName: secretName,
@@ -61,6 +61,9 @@ type baseIngressSpec struct {
 	Enabled bool   `json:"enabled" mapstructure:"enabled"`
 	Domain  string `json:"domain" mapstructure:"domain"`
 	Path    string `json:"path" mapstructure:"path"`
+
+	// ClusterIssuer is the name of the cert-manager cluster issuer requested to issue a certificate for the domain
+	ClusterIssuer string `json:"clusterIssuer,omitempty" mapstructure:"clusterIssuer"`
 }
 
 type exportersSpec struct {
@@ -164,6 +167,10 @@ func (s baseIngressSpec) Validate(ingressType string) error {
 				return errors.Append(err, invalidIngressHostError{hostType: ingressType})
 			}
 		}
+
+		if s.ClusterIssuer != "" && s.Domain == "" {
+			return requiredFieldError{fieldName: fmt.Sprintf("%s domain", ingressType)}
+		}
 	}
 
 	return nil
@@ -14,6 +14,10 @@
 
 package monitoring
 
+import (
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/certmanager"
+)
+
 type prometheusOperatorValues struct {
 	PrometheusOperator operatorSpecValues     `json:"prometheusOperator"`
 	Grafana            *grafanaValues         `json:"grafana"`
@@ -157,9 +161,19 @@ type nodeExporterValues struct {
 }
 
 type ingressValues struct {
-	Enabled     bool                   `json:"enabled"`
-	Hosts       []string               `json:"hosts"`
-	Path        string                 `json:"path,omitempty"`
-	Paths       []string               `json:"paths,omitempty"`
-	Annotations map[string]interface{} `json:"annotations,omitempty"`
+	Enabled     bool                     `json:"enabled"`
+	Hosts       []string                 `json:"hosts"`
+	Path        string                   `json:"path,omitempty"`
+	Paths       []string                 `json:"paths,omitempty"`
+	Annotations map[string]interface{}   `json:"annotations,omitempty"`
+	TLS         []certmanager.IngressTLS `json:"tls,omitempty"`
+}
+
+// withCertificate requests a certificate for the ingress from the cluster issuer of the ingress spec (if any)
+func (v ingressValues) withCertificate(spec baseIngressSpec, ingressName string) ingressValues {
+	if spec.Enabled && spec.ClusterIssuer != "" {
+		v.Annotations, v.TLS = certmanager.RequestIngressCertificate(v.Annotations, spec.ClusterIssuer, ingressName, spec.Domain)
+	}
+
+	return v
 }
@@ -233,6 +233,56 @@ func (op IntegratedServiceOperator) Deactivate(ctx context.Context, clusterID ui
 	return nil
 }
 
+// DryRun renders the chart values of the security scan webhook without applying them
+func (op IntegratedServiceOperator) DryRun(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	ctx, err := op.ensureOrgIDInContext(ctx, clusterID)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to render integrated service")
+	}
+
+	boundSpec, err := bindIntegratedServiceSpec(spec)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to render integrated service")
+	}
+
+	var anchoreValues AnchoreValues
+	if boundSpec.CustomAnchore.Enabled {
+		dryRunOp := op
+		dryRunOp.secretStore = services.NewRedactingSecretStore(op.secretStore)
+
+		anchoreValues, err = dryRunOp.getCustomAnchoreValues(ctx, boundSpec.CustomAnchore)
+		if err != nil {
+			return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to get custom anchore values")
+		}
+	} else {
+		if !op.config.Anchore.Enabled {
+			return integratedservices.IntegratedServiceDryRunOutput{}, errors.NewWithDetails("default anchore is not enabled")
+		}
+
+		// the anchore user of the cluster is only generated when the integrated service is applied
+		anchoreValues = AnchoreValues{
+			Host:     op.config.Anchore.Endpoint,
+			User:     integratedservices.RedactedValue,
+			Password: integratedservices.RedactedValue,
+			Insecure: op.config.Anchore.Insecure,
+		}
+	}
+
+	values, err := assembleChartValues(anchoreValues, boundSpec.WebhookConfig)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, errors.WrapIf(err, "failed to assemble chart values")
+	}
+
+	release, err := services.NewHelmReleaseDryRun(op.config.Webhook.Namespace, op.config.Webhook.Chart, op.config.Webhook.Release, values, op.config.Webhook.Version)
+	if err != nil {
+		return integratedservices.IntegratedServiceDryRunOutput{}, err
+	}
+
+	return integratedservices.IntegratedServiceDryRunOutput{
+		Releases: []integratedservices.HelmReleaseDryRun{release},
+	}, nil
+}
+
 func (op IntegratedServiceOperator) ensureOrgIDInContext(ctx context.Context, clusterID uint) (context.Context, error) {
 	if _, ok := auth.GetCurrentOrganizationID(ctx); !ok {
 		cl, err := op.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
@@ -420,6 +420,11 @@ func (op IntegratedServicesOperator) Deactivate(ctx context.Context, clusterID u
 	return nil
 }
 
+// DryRun returns an error since the Vault integrated service does not support dry runs
+func (op IntegratedServicesOperator) DryRun(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceDryRunOutput, error) {
+	return integratedservices.IntegratedServiceDryRunOutput{}, errors.WithStack(integratedservices.DryRunNotSupportedError{IntegratedServiceName: integratedServiceName})
+}
+
 func (op IntegratedServicesOperator) ensureOrgIDInContext(ctx context.Context, clusterID uint) (context.Context, error) {
 	if _, ok := auth.GetCurrentOrganizationID(ctx); !ok {
 		cluster, err := op.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
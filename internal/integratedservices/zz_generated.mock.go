@@ -82,6 +82,27 @@ func (_m *MockService) Details(ctx context.Context, clusterID uint, serviceName
 	return r0, r1
 }
 
+// DryRun provides a mock function.
+func (_m *MockService) DryRun(ctx context.Context, clusterID uint, serviceName string, spec map[string]interface{}) (dryRun IntegratedServiceDryRun, err error) {
+	ret := _m.Called(ctx, clusterID, serviceName, spec)
+
+	var r0 IntegratedServiceDryRun
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, map[string]interface{}) IntegratedServiceDryRun); ok {
+		r0 = rf(ctx, clusterID, serviceName, spec)
+	} else {
+		r0 = ret.Get(0).(IntegratedServiceDryRun)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, map[string]interface{}) error); ok {
+		r1 = rf(ctx, clusterID, serviceName, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function.
 func (_m *MockService) List(ctx context.Context, clusterID uint) (services []IntegratedService, err error) {
 	ret := _m.Called(ctx, clusterID)
@@ -105,6 +126,43 @@ func (_m *MockService) List(ctx context.Context, clusterID uint) (services []Int
 	return r0, r1
 }
 
+// ListRevisions provides a mock function.
+func (_m *MockService) ListRevisions(ctx context.Context, clusterID uint, serviceName string) (revisions []IntegratedServiceRevision, err error) {
+	ret := _m.Called(ctx, clusterID, serviceName)
+
+	var r0 []IntegratedServiceRevision
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) []IntegratedServiceRevision); ok {
+		r0 = rf(ctx, clusterID, serviceName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]IntegratedServiceRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, clusterID, serviceName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rollback provides a mock function.
+func (_m *MockService) Rollback(ctx context.Context, clusterID uint, serviceName string, revision uint) (_result_0 error) {
+	ret := _m.Called(ctx, clusterID, serviceName, revision)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, uint) error); ok {
+		r0 = rf(ctx, clusterID, serviceName, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function.
 func (_m *MockService) Update(ctx context.Context, clusterID uint, serviceName string, spec map[string]interface{}) (_result_0 error) {
 	ret := _m.Called(ctx, clusterID, serviceName, spec)
@@ -16,6 +16,7 @@ package cadence
 
 import (
 	"emperror.dev/errors"
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
 	"go.uber.org/cadence/client"
 	"go.uber.org/zap"
 )
@@ -35,3 +36,13 @@ func NewClient(config Config, logger *zap.Logger) (client.Client, error) {
 		},
 	), nil
 }
+
+// NewServiceClient returns a new Cadence workflow service client.
+func NewServiceClient(config Config, logger *zap.Logger) (workflowserviceclient.Interface, error) {
+	serviceClient, err := newServiceClient("cadence-service-client", config, logger)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create cadence service client")
+	}
+
+	return serviceClient, nil
+}
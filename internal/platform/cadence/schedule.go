@@ -0,0 +1,121 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cadence
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/encoded"
+)
+
+const cronScheduleMemoKey = "cronScheduleFingerprint"
+
+// ScheduleCronWorkflow makes sure the cron workflow described by the options is running with the specified schedule and input.
+//
+// A running workflow is left intact unless its schedule or input changed, in which case it's terminated and started again.
+func ScheduleCronWorkflow(ctx context.Context, cadenceClient client.Client, options client.StartWorkflowOptions, workflowName string, input interface{}) error {
+	fingerprint, err := cronScheduleFingerprint(options.CronSchedule, input)
+	if err != nil {
+		return err
+	}
+
+	options.WorkflowIDReusePolicy = client.WorkflowIDReusePolicyAllowDuplicate
+	options.Memo = map[string]interface{}{cronScheduleMemoKey: fingerprint}
+
+	started, err := startCronWorkflow(ctx, cadenceClient, options, workflowName, input)
+	if err != nil || started {
+		return err
+	}
+
+	execution, err := cadenceClient.DescribeWorkflowExecution(ctx, options.ID, "")
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to describe cron workflow", "workflowId", options.ID)
+	}
+
+	if runningCronScheduleFingerprint(execution) == fingerprint {
+		return nil
+	}
+
+	if err := cadenceClient.TerminateWorkflow(ctx, options.ID, "", "cron workflow rescheduled", nil); err != nil {
+		var entityNotExistsErr *shared.EntityNotExistsError
+		if !errors.As(err, &entityNotExistsErr) {
+			return errors.WrapIfWithDetails(err, "failed to terminate cron workflow", "workflowId", options.ID)
+		}
+	}
+
+	// another instance might have rescheduled the workflow in the meantime
+	_, err = startCronWorkflow(ctx, cadenceClient, options, workflowName, input)
+
+	return err
+}
+
+// UnscheduleCronWorkflow makes sure the cron workflow with the specified ID is not running.
+func UnscheduleCronWorkflow(ctx context.Context, cadenceClient client.Client, workflowID string) error {
+	if err := cadenceClient.TerminateWorkflow(ctx, workflowID, "", "cron workflow unscheduled", nil); err != nil {
+		var entityNotExistsErr *shared.EntityNotExistsError
+		if !errors.As(err, &entityNotExistsErr) {
+			return errors.WrapIfWithDetails(err, "failed to terminate cron workflow", "workflowId", workflowID)
+		}
+	}
+
+	return nil
+}
+
+// startCronWorkflow starts a workflow and reports whether it was started or it's already running.
+func startCronWorkflow(ctx context.Context, cadenceClient client.Client, options client.StartWorkflowOptions, workflowName string, input interface{}) (bool, error) {
+	if _, err := cadenceClient.StartWorkflow(ctx, options, workflowName, input); err != nil {
+		var alreadyStartedErr *shared.WorkflowExecutionAlreadyStartedError
+		if errors.As(err, &alreadyStartedErr) {
+			return false, nil
+		}
+
+		return false, errors.WrapIfWithDetails(err, "failed to start cron workflow", "workflowId", options.ID)
+	}
+
+	return true, nil
+}
+
+func cronScheduleFingerprint(schedule string, input interface{}) (string, error) {
+	rawInput, err := json.Marshal(input)
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to marshal cron workflow input")
+	}
+
+	return fmt.Sprintf("%s:%x", schedule, sha256.Sum256(rawInput)), nil
+}
+
+func runningCronScheduleFingerprint(execution *shared.DescribeWorkflowExecutionResponse) string {
+	if execution == nil || execution.WorkflowExecutionInfo == nil || execution.WorkflowExecutionInfo.Memo == nil {
+		return ""
+	}
+
+	data, ok := execution.WorkflowExecutionInfo.Memo.Fields[cronScheduleMemoKey]
+	if !ok {
+		return ""
+	}
+
+	var fingerprint string
+	if err := encoded.GetDefaultDataConverter().FromData(data, &fingerprint); err != nil {
+		return ""
+	}
+
+	return fingerprint
+}
@@ -0,0 +1,152 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cadence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/encoded"
+	"go.uber.org/cadence/mocks"
+)
+
+type cronWorkflowInput struct {
+	AutoReapply bool
+}
+
+func runningCronWorkflow(t *testing.T, schedule string, input interface{}) *shared.DescribeWorkflowExecutionResponse {
+	fingerprint, err := cronScheduleFingerprint(schedule, input)
+	require.NoError(t, err)
+
+	data, err := encoded.GetDefaultDataConverter().ToData(fingerprint)
+	require.NoError(t, err)
+
+	return &shared.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &shared.WorkflowExecutionInfo{
+			Memo: &shared.Memo{Fields: map[string][]byte{cronScheduleMemoKey: data}},
+		},
+	}
+}
+
+func TestScheduleCronWorkflow(t *testing.T) {
+	ctx := context.Background()
+	options := client.StartWorkflowOptions{
+		ID:                           "cron-workflow",
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 30 * time.Minute,
+		CronSchedule:                 "@every 1h",
+	}
+	input := cronWorkflowInput{AutoReapply: true}
+	startOptions := mock.MatchedBy(func(o client.StartWorkflowOptions) bool {
+		return o.ID == options.ID &&
+			o.CronSchedule == options.CronSchedule &&
+			o.WorkflowIDReusePolicy == client.WorkflowIDReusePolicyAllowDuplicate &&
+			o.Memo[cronScheduleMemoKey] != nil
+	})
+
+	t.Run("NotRunning", func(t *testing.T) {
+		cadenceClient := new(mocks.Client)
+		cadenceClient.On("StartWorkflow", ctx, startOptions, "cron", input).Return(nil, nil).Once()
+
+		err := ScheduleCronWorkflow(ctx, cadenceClient, options, "cron", input)
+		require.NoError(t, err)
+
+		cadenceClient.AssertExpectations(t)
+	})
+
+	t.Run("RunningWithSameSchedule", func(t *testing.T) {
+		cadenceClient := new(mocks.Client)
+		cadenceClient.On("StartWorkflow", ctx, startOptions, "cron", input).Return(nil, &shared.WorkflowExecutionAlreadyStartedError{}).Once()
+		cadenceClient.On("DescribeWorkflowExecution", ctx, options.ID, "").Return(runningCronWorkflow(t, options.CronSchedule, input), nil)
+
+		err := ScheduleCronWorkflow(ctx, cadenceClient, options, "cron", input)
+		require.NoError(t, err)
+
+		cadenceClient.AssertExpectations(t)
+		cadenceClient.AssertNotCalled(t, "TerminateWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RunningWithChangedSchedule", func(t *testing.T) {
+		cadenceClient := new(mocks.Client)
+		cadenceClient.On("StartWorkflow", ctx, startOptions, "cron", input).Return(nil, &shared.WorkflowExecutionAlreadyStartedError{}).Once()
+		cadenceClient.On("DescribeWorkflowExecution", ctx, options.ID, "").Return(runningCronWorkflow(t, "@every 2h", input), nil)
+		cadenceClient.On("TerminateWorkflow", ctx, options.ID, "", mock.Anything, []byte(nil)).Return(nil)
+		cadenceClient.On("StartWorkflow", ctx, startOptions, "cron", input).Return(nil, nil).Once()
+
+		err := ScheduleCronWorkflow(ctx, cadenceClient, options, "cron", input)
+		require.NoError(t, err)
+
+		cadenceClient.AssertExpectations(t)
+	})
+
+	t.Run("RunningWithChangedInput", func(t *testing.T) {
+		cadenceClient := new(mocks.Client)
+		cadenceClient.On("StartWorkflow", ctx, startOptions, "cron", input).Return(nil, &shared.WorkflowExecutionAlreadyStartedError{}).Once()
+		cadenceClient.On("DescribeWorkflowExecution", ctx, options.ID, "").Return(runningCronWorkflow(t, options.CronSchedule, cronWorkflowInput{}), nil)
+		cadenceClient.On("TerminateWorkflow", ctx, options.ID, "", mock.Anything, []byte(nil)).Return(nil)
+		cadenceClient.On("StartWorkflow", ctx, startOptions, "cron", input).Return(nil, nil).Once()
+
+		err := ScheduleCronWorkflow(ctx, cadenceClient, options, "cron", input)
+		require.NoError(t, err)
+
+		cadenceClient.AssertExpectations(t)
+	})
+
+	t.Run("StartError", func(t *testing.T) {
+		cadenceClient := new(mocks.Client)
+		cadenceClient.On("StartWorkflow", ctx, startOptions, "cron", input).Return(nil, &shared.BadRequestError{Message: "invalid"}).Once()
+
+		err := ScheduleCronWorkflow(ctx, cadenceClient, options, "cron", input)
+		assert.Error(t, err)
+	})
+}
+
+func TestUnscheduleCronWorkflow(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Running", func(t *testing.T) {
+		cadenceClient := new(mocks.Client)
+		cadenceClient.On("TerminateWorkflow", ctx, "cron-workflow", "", mock.Anything, []byte(nil)).Return(nil)
+
+		err := UnscheduleCronWorkflow(ctx, cadenceClient, "cron-workflow")
+		require.NoError(t, err)
+
+		cadenceClient.AssertExpectations(t)
+	})
+
+	t.Run("NotRunning", func(t *testing.T) {
+		cadenceClient := new(mocks.Client)
+		cadenceClient.On("TerminateWorkflow", ctx, "cron-workflow", "", mock.Anything, []byte(nil)).Return(&shared.EntityNotExistsError{})
+
+		err := UnscheduleCronWorkflow(ctx, cadenceClient, "cron-workflow")
+		require.NoError(t, err)
+
+		cadenceClient.AssertExpectations(t)
+	})
+
+	t.Run("TerminateError", func(t *testing.T) {
+		cadenceClient := new(mocks.Client)
+		cadenceClient.On("TerminateWorkflow", ctx, "cron-workflow", "", mock.Anything, []byte(nil)).Return(&shared.BadRequestError{Message: "invalid"})
+
+		err := UnscheduleCronWorkflow(ctx, cadenceClient, "cron-workflow")
+		assert.Error(t, err)
+	})
+}
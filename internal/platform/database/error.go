@@ -14,9 +14,38 @@
 
 package database
 
-import "github.com/jinzhu/gorm"
+import (
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
 
 // IsRecordNotFoundError returns true if the error was triggered by a record not being found.
 func IsRecordNotFoundError(err error) bool {
 	return gorm.IsRecordNotFoundError(err)
 }
+
+// uniqueConstraintErrorMessages are the error message fragments reported by the supported drivers
+// (MySQL, PostgreSQL and SQLite respectively) on unique constraint violations.
+var uniqueConstraintErrorMessages = []string{
+	"Error 1062:",
+	"duplicate key value violates unique constraint",
+	"UNIQUE constraint failed",
+}
+
+// IsUniqueConstraintError returns true if the error was triggered by a unique constraint violation.
+func IsUniqueConstraintError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	message := err.Error()
+
+	for _, fragment := range uniqueConstraintErrorMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
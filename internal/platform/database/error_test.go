@@ -0,0 +1,58 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsUniqueConstraintError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"mysql": {
+			err:      errors.New("Error 1062: Duplicate entry '1-secret-1' for key 'idx_encrypted_secrets_org_secret_version'"),
+			expected: true,
+		},
+		"postgres": {
+			err:      errors.New(`pq: duplicate key value violates unique constraint "idx_encrypted_secrets_org_secret_version"`),
+			expected: true,
+		},
+		"sqlite": {
+			err:      errors.New("UNIQUE constraint failed: encrypted_secrets.organization_id, encrypted_secrets.secret_id, encrypted_secrets.version"),
+			expected: true,
+		},
+		"other error": {
+			err:      errors.New("connection refused"),
+			expected: false,
+		},
+		"no error": {
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			if actual := IsUniqueConstraintError(test.err); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
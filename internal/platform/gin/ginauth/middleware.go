@@ -70,6 +70,11 @@ func (m *middleware) CheckPermission(r *http.Request) (bool, error) {
 		return false, nil
 	}
 
+	// Tokens restricted to an organization are rejected early for every other organization
+	if org != nil && user.Scopes != nil && !user.Scopes.AllowsOrganization(org.ID) {
+		return false, nil
+	}
+
 	if m.basePath != "/" {
 		path = strings.TrimPrefix(path, m.basePath)
 	}
@@ -25,6 +25,7 @@ import (
 	qorauth "github.com/qor/auth"
 	"github.com/stretchr/testify/assert"
 
+	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 	"github.com/banzaicloud/pipeline/src/auth"
 )
 
@@ -78,6 +79,7 @@ func TestAuthorizationMiddleware(t *testing.T) {
 		basePath     string
 		expectedCode int
 		user         *auth.User
+		org          *auth.Organization
 		method       string
 		path         string
 	}{
@@ -116,6 +118,22 @@ func TestAuthorizationMiddleware(t *testing.T) {
 			method:       http.MethodGet,
 			path:         "/path",
 		},
+		"scoped user": {
+			basePath:     "",
+			expectedCode: http.StatusOK,
+			user:         &auth.User{ID: 1, Scopes: &pkgAuth.TokenScopes{OrganizationID: 1}},
+			org:          &auth.Organization{ID: 1},
+			method:       http.MethodGet,
+			path:         "/path",
+		},
+		"scoped user in another organization": {
+			basePath:     "",
+			expectedCode: http.StatusForbidden,
+			user:         &auth.User{ID: 1, Scopes: &pkgAuth.TokenScopes{OrganizationID: 2}},
+			org:          &auth.Organization{ID: 1},
+			method:       http.MethodGet,
+			path:         "/path",
+		},
 		"empty user": {
 			basePath:     "",
 			expectedCode: http.StatusForbidden,
@@ -141,7 +159,12 @@ func TestAuthorizationMiddleware(t *testing.T) {
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest(test.method, test.path, nil)
 
-			req = req.WithContext(context.WithValue(context.Background(), qorauth.CurrentUser, test.user))
+			ctx := context.WithValue(context.Background(), qorauth.CurrentUser, test.user)
+			if test.org != nil {
+				ctx = context.WithValue(ctx, auth.CurrentOrganization, test.org)
+			}
+
+			req = req.WithContext(ctx)
 			router.ServeHTTP(w, req)
 
 			assert.Equal(t, test.expectedCode, w.Code)
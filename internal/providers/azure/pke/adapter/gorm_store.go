@@ -586,6 +586,19 @@ func (s ClusterStore) SetNodePoolSizes(clusterID uint, nodePoolName string, min,
 	return getError(s.db.Model(&model).Where("cluster_id = ? AND name = ?", clusterID, nodePoolName).Updates(fields), "failed to update nodepool model")
 }
 
+func (s ClusterStore) SetNodePoolInstanceType(clusterID uint, nodePoolName string, instanceType string) error {
+	if err := validateClusterID(clusterID); err != nil {
+		return errors.WrapIf(err, "invalid cluster ID")
+	}
+
+	model := nodePoolModel{
+		ClusterID: clusterID,
+		Name:      nodePoolName,
+	}
+
+	return getError(s.db.Model(&model).Where("cluster_id = ? AND name = ?", clusterID, nodePoolName).Update("InstanceType", instanceType), "failed to update nodepool model")
+}
+
 // Migrate executes the table migrations for the provider.
 func Migrate(db *gorm.DB, logger common.Logger) error {
 	tables := []interface{}{
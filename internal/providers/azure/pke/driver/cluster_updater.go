@@ -120,18 +120,11 @@ func (cu ClusterUpdater) Update(ctx context.Context, params ClusterUpdateParams)
 		return err
 	}
 
-	sn, err := conn.GetSubnetsClient().Get(ctx, cluster.ResourceGroup.Name, cluster.VirtualNetwork.Name, cluster.NodePools[0].Subnet.Name, "routeTable")
-	if err = errors.WrapIf(err, "failed to get subnet"); err != nil && sn.StatusCode != http.StatusNotFound {
+	routeTableName, err := getRouteTableName(ctx, conn, cluster)
+	if err != nil {
 		return err
 	}
 
-	var routeTableName string
-	if sn.StatusCode == http.StatusOK && sn.SubnetPropertiesFormat != nil && sn.RouteTable != nil && sn.RouteTable.Name != nil {
-		routeTableName = to.String(sn.RouteTable.Name)
-	} else {
-		routeTableName = pke.GetRouteTableName(cluster.Name)
-	}
-
 	toCreateVMSSTemplates := make([]workflow.VirtualMachineScaleSetTemplate, len(nodePoolsToCreate))
 	var toCreateSubnetTemplates []workflow.SubnetTemplate
 	var roleAssignmentTemplates []workflow.RoleAssignmentTemplate
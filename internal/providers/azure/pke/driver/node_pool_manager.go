@@ -0,0 +1,189 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"net/http"
+
+	"emperror.dev/errors"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/providers/azure/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/azure/pke/workflow"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	pkgAzure "github.com/banzaicloud/pipeline/pkg/providers/azure"
+)
+
+// NodePoolManager prepares individual node pool changes of PKE clusters on Azure.
+type NodePoolManager struct {
+	logger                      logrus.FieldLogger
+	paramsPreparer              ClusterUpdateParamsPreparer
+	pipelineExternalURL         string
+	pipelineExternalURLInsecure bool
+	secrets                     clusterUpdaterSecretStore
+	store                       pke.ClusterStore
+}
+
+func MakeNodePoolManager(
+	logger logrus.FieldLogger,
+	pipelineExternalURL string,
+	pipelineExternalURLInsecure bool,
+	secrets clusterUpdaterSecretStore,
+	store pke.ClusterStore,
+) NodePoolManager {
+	return NodePoolManager{
+		logger: logger,
+		paramsPreparer: ClusterUpdateParamsPreparer{
+			logger:  logger,
+			secrets: secrets,
+			store:   store,
+		},
+		pipelineExternalURL:         pipelineExternalURL,
+		pipelineExternalURLInsecure: pipelineExternalURLInsecure,
+		secrets:                     secrets,
+		store:                       store,
+	}
+}
+
+// NodePoolInfrastructure contains the templates of the Azure resources backing a new node pool.
+type NodePoolInfrastructure struct {
+	ResourceGroupName   string
+	PublicIPAddressName string
+	RouteTableName      string
+	VirtualNetworkName  string
+
+	// Subnet is nil when the node pool is placed in an existing subnet.
+	Subnet          *workflow.SubnetTemplate
+	ScaleSet        workflow.VirtualMachineScaleSetTemplate
+	RoleAssignments []workflow.RoleAssignmentTemplate
+
+	AccessPoints          pke.AccessPoints
+	APIServerAccessPoints pke.APIServerAccessPoints
+}
+
+// CreateNodePool prepares and stores a new node pool, and returns the templates of its infrastructure.
+func (m NodePoolManager) CreateNodePool(ctx context.Context, clusterID uint, nodePool NodePool) (NodePoolInfrastructure, error) {
+	logger := m.logger.WithField("clusterID", clusterID).WithField("nodePool", nodePool.Name)
+
+	logger.Info("creating node pool")
+
+	params := ClusterUpdateParams{
+		ClusterID: clusterID,
+		NodePools: []NodePool{nodePool},
+	}
+
+	if err := m.paramsPreparer.Prepare(ctx, &params); err != nil {
+		return NodePoolInfrastructure{}, errors.WrapIf(err, "params preparation failed")
+	}
+
+	nodePool = params.NodePools[0]
+
+	cluster, err := m.store.GetByID(clusterID)
+	if err != nil {
+		return NodePoolInfrastructure{}, errors.WrapIf(err, "failed to get cluster by ID")
+	}
+
+	sshKeyPair, err := GetOrCreateSSHKeyPair(cluster, m.secrets, m.store)
+	if err != nil {
+		return NodePoolInfrastructure{}, errors.WrapIf(err, "failed to get or create SSH key pair")
+	}
+
+	sir, err := m.secrets.Get(cluster.OrganizationID, cluster.SecretID)
+	if err != nil {
+		return NodePoolInfrastructure{}, errors.WrapIf(err, "failed to get cluster secret")
+	}
+	tenantID := sir.Values[secrettype.AzureTenantID]
+
+	conn, err := pkgAzure.NewCloudConnection(&azure.PublicCloud, pkgAzure.NewCredentials(sir.Values))
+	if err = errors.WrapIf(err, "failed to create new Azure cloud connection"); err != nil {
+		return NodePoolInfrastructure{}, err
+	}
+
+	routeTableName, err := getRouteTableName(ctx, conn, cluster)
+	if err != nil {
+		return NodePoolInfrastructure{}, err
+	}
+
+	tf := nodePoolTemplateFactory{
+		ClusterID:                   cluster.ID,
+		ClusterName:                 cluster.Name,
+		KubernetesVersion:           cluster.Kubernetes.Version,
+		Location:                    cluster.Location,
+		OrganizationID:              cluster.OrganizationID,
+		PipelineExternalURL:         m.pipelineExternalURL,
+		PipelineExternalURLInsecure: m.pipelineExternalURLInsecure,
+		ResourceGroupName:           cluster.ResourceGroup.Name,
+		RouteTableName:              routeTableName,
+		SingleNodePool:              false,
+		SSHPublicKey:                sshKeyPair.PublicKeyData,
+		TenantID:                    tenantID,
+		VirtualNetworkName:          cluster.VirtualNetwork.Name,
+	}
+
+	vmsst, snt, rats := tf.getTemplates(nodePool)
+
+	infrastructure := NodePoolInfrastructure{
+		ResourceGroupName:   cluster.ResourceGroup.Name,
+		PublicIPAddressName: pke.GetPublicIPAddressName(cluster.Name),
+		RouteTableName:      routeTableName,
+		VirtualNetworkName:  cluster.VirtualNetwork.Name,
+
+		ScaleSet:        vmsst,
+		RoleAssignments: rats,
+
+		AccessPoints:          cluster.AccessPoints,
+		APIServerAccessPoints: cluster.APIServerAccessPoints,
+	}
+
+	subnetExists := false
+	for _, np := range cluster.NodePools {
+		if np.Subnet.Name == nodePool.Subnet.Name {
+			subnetExists = true
+
+			break
+		}
+	}
+
+	if !subnetExists {
+		sn, err := conn.GetSubnetsClient().Get(ctx, cluster.ResourceGroup.Name, cluster.VirtualNetwork.Name, snt.Name, "")
+		if sn.Response.StatusCode == http.StatusNotFound {
+			infrastructure.Subnet = &snt
+		} else if err != nil {
+			return NodePoolInfrastructure{}, errors.WrapIf(err, "failed to get subnet")
+		} // else skip already existing subnet
+	}
+
+	if err := m.store.CreateNodePool(clusterID, nodePool.toPke()); err != nil {
+		return NodePoolInfrastructure{}, errors.WrapIfWithDetails(err, "failed to store new node pool", "clusterID", cluster.ID, "nodepool", nodePool.Name)
+	}
+
+	return infrastructure, nil
+}
+
+func getRouteTableName(ctx context.Context, conn *pkgAzure.CloudConnection, cluster pke.Cluster) (string, error) {
+	sn, err := conn.GetSubnetsClient().Get(ctx, cluster.ResourceGroup.Name, cluster.VirtualNetwork.Name, cluster.NodePools[0].Subnet.Name, "routeTable")
+	if err = errors.WrapIf(err, "failed to get subnet"); err != nil && sn.StatusCode != http.StatusNotFound {
+		return "", err
+	}
+
+	if sn.StatusCode == http.StatusOK && sn.SubnetPropertiesFormat != nil && sn.RouteTable != nil && sn.RouteTable.Name != nil {
+		return to.String(sn.RouteTable.Name), nil
+	}
+
+	return pke.GetRouteTableName(cluster.Name), nil
+}
@@ -52,6 +52,7 @@ type ClusterStore interface {
 	SetConfigSecretID(clusterID uint, secretID string) error
 	SetSSHSecretID(clusterID uint, sshSecretID string) error
 	SetNodePoolSizes(clusterID uint, nodePoolName string, min, max, desiredCount uint, autoscaling bool) error
+	SetNodePoolInstanceType(clusterID uint, nodePoolName string, instanceType string) error
 	UpdateClusterAccessPoints(clusterID uint, accessPoints AccessPoints) error
 }
 
@@ -0,0 +1,125 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package google
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"google.golang.org/api/container/v1"
+
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+)
+
+const (
+	gkeOperationStatusDone = "DONE"
+
+	gkeOperationPollInterval = 5 * time.Second
+)
+
+// CreateContainerService creates a new GKE API client.
+func (c ClientFactory) CreateContainerService(ctx context.Context, secretID string) (*container.Service, error) {
+	client, err := c.CreateClient(ctx, secretID)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create Google API client")
+	}
+
+	return container.New(client)
+}
+
+// GKEProjectID returns the ID of the project a GKE cluster belongs to.
+//
+// Older clusters do not store their project ID,
+// in which case the project of the service account is returned.
+func GKEProjectID(ctx context.Context, secretStore SecretStore, secretID string, projectID string) (string, error) {
+	if projectID != "" {
+		return projectID, nil
+	}
+
+	secret, err := secretStore.GetSecret(ctx, secretID)
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to get Google secret")
+	}
+
+	return secret.ProjectId, nil
+}
+
+// NewGKENodePool returns the GKE API representation of a node pool.
+func NewGKENodePool(nodePool GKENodePoolModel, nodeVersion string) *container.NodePool {
+	return &container.NodePool{
+		Name: nodePool.Name,
+		Config: &container.NodeConfig{
+			// only add node pool name label, other are added by NodeLabelController
+			Labels: map[string]string{
+				pkgCommon.LabelKey: nodePool.Name,
+			},
+			MachineType: nodePool.NodeInstanceType,
+			OauthScopes: []string{
+				"https://www.googleapis.com/auth/logging.write",
+				"https://www.googleapis.com/auth/monitoring",
+				"https://www.googleapis.com/auth/devstorage.read_write",
+				"https://www.googleapis.com/auth/cloud-platform",
+				"https://www.googleapis.com/auth/compute",
+			},
+			Preemptible: nodePool.Preemptible,
+		},
+		InitialNodeCount: int64(nodePool.NodeCount),
+		Version:          nodeVersion,
+		Autoscaling:      NewGKENodePoolAutoscaling(nodePool.Autoscaling, nodePool.NodeMinCount, nodePool.NodeMaxCount),
+	}
+}
+
+// NewGKENodePoolAutoscaling returns the GKE API representation of node pool autoscaling settings.
+func NewGKENodePoolAutoscaling(enabled bool, minSize int, maxSize int) *container.NodePoolAutoscaling {
+	if !enabled {
+		return &container.NodePoolAutoscaling{
+			Enabled: false,
+		}
+	}
+
+	return &container.NodePoolAutoscaling{
+		Enabled:      true,
+		MinNodeCount: int64(minSize),
+		MaxNodeCount: int64(maxSize),
+	}
+}
+
+// WaitForGKEOperation waits for a zonal GKE operation to finish.
+func WaitForGKEOperation(ctx context.Context, svc *container.Service, projectID string, zone string, operation *container.Operation) error {
+	operationName := operation.Name
+
+	for operation.Status != gkeOperationStatusDone {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-time.After(gkeOperationPollInterval):
+		}
+
+		var err error
+
+		operation, err = svc.Projects.Zones.Operations.Get(projectID, zone, operationName).Context(ctx).Do()
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "retrieving operation status failed", "operation", operationName)
+		}
+	}
+
+	if operation.StatusMessage != "" {
+		return errors.NewWithDetails(operation.StatusMessage, "operation", operationName, "operationType", operation.OperationType)
+	}
+
+	return nil
+}
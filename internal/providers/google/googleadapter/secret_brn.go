@@ -0,0 +1,88 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googleadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/providers/google"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	"github.com/banzaicloud/pipeline/pkg/brn"
+	pkgGoogle "github.com/banzaicloud/pipeline/pkg/providers/google"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// OrganizationSecretStore is the legacy, organization scoped secret store.
+type OrganizationSecretStore interface {
+	// Get returns a secret of an organization.
+	Get(organizationID uint, secretID string) (*secret.SecretItemResponse, error)
+}
+
+// BRNSecretStore accesses secrets identified by their fully qualified BRN.
+//
+// It does not depend on the organization being present in the context,
+// so it can be used in background processes (eg. workflow activities).
+type BRNSecretStore struct {
+	store OrganizationSecretStore
+}
+
+// NewBRNSecretStore returns a new BRNSecretStore.
+func NewBRNSecretStore(store OrganizationSecretStore) BRNSecretStore {
+	return BRNSecretStore{
+		store: store,
+	}
+}
+
+// GetSecret returns a secret.
+func (s BRNSecretStore) GetSecret(ctx context.Context, secretID string) (google.Secret, error) {
+	values, err := s.GetRawSecret(ctx, secretID)
+	if err != nil {
+		return google.Secret{}, err
+	}
+
+	return google.Secret{
+		Type:                   values[secrettype.Type],
+		ProjectId:              values[secrettype.ProjectId],
+		PrivateKeyId:           values[secrettype.PrivateKeyId],
+		PrivateKey:             values[secrettype.PrivateKey],
+		ClientEmail:            values[secrettype.ClientEmail],
+		ClientId:               values[secrettype.ClientId],
+		AuthUri:                values[secrettype.AuthUri],
+		TokenUri:               values[secrettype.TokenUri],
+		AuthProviderX50CertUrl: values[secrettype.AuthX509Url],
+		ClientX509CertUrl:      values[secrettype.ClientX509Url],
+	}, nil
+}
+
+// GetRawSecret returns the raw values of a secret.
+func (s BRNSecretStore) GetRawSecret(_ context.Context, secretID string) (map[string]string, error) {
+	secretResource, err := brn.Parse(secretID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "invalid secret ID", "secretId", secretID)
+	}
+
+	sir, err := s.store.Get(secretResource.OrganizationID, secretResource.ResourceID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to get Google secret", "secretId", secretID)
+	}
+
+	if err := secret.ValidateSecretType(sir, pkgGoogle.Provider); err != nil {
+		return nil, errors.WithDetails(err, "secretId", secretID)
+	}
+
+	return sir.Values, nil
+}
@@ -0,0 +1,166 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/common"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+// healthCheckMessagePrefix marks the status messages set by the health check.
+const healthCheckMessagePrefix = "Health check: "
+
+// ClusterHealth is the result of an imported cluster health probe.
+type ClusterHealth struct {
+	// Reachable tells whether the API server of the cluster could be reached.
+	Reachable bool
+
+	// Version is the Kubernetes version reported by the API server.
+	Version string
+
+	// Nodes is the number of nodes registered in the cluster.
+	Nodes int
+
+	// ReadyNodes is the number of nodes with a Ready condition.
+	ReadyNodes int
+
+	// Error describes why the API server could not be reached.
+	Error string
+
+	CheckedAt time.Time
+}
+
+// Status returns the cluster status and status message reflecting the health of the cluster.
+func (h ClusterHealth) Status() (string, string) {
+	switch {
+	case !h.Reachable:
+		return cluster.Error, healthCheckMessagePrefix + "API server is unreachable: " + h.Error
+
+	case h.ReadyNodes == 0:
+		return cluster.Error, healthCheckMessagePrefix + "no ready nodes"
+
+	case h.ReadyNodes < h.Nodes:
+		return cluster.Warning, fmt.Sprintf("%s%d of %d nodes are not ready", healthCheckMessagePrefix, h.Nodes-h.ReadyNodes, h.Nodes)
+
+	default:
+		return cluster.Running, cluster.RunningMessage
+	}
+}
+
+// +testify:mock:testOnly=true
+
+// HealthProber probes the health of a cluster.
+type HealthProber interface {
+	// ProbeHealth probes the API server and the nodes of the cluster
+	// accessible with the kubeconfig stored in the specified secret.
+	ProbeHealth(ctx context.Context, secretID string) ClusterHealth
+}
+
+// +testify:mock:testOnly=true
+
+// ClusterStore provides an interface to the generic Cluster model persistence.
+type ClusterStore interface {
+	// GetCluster returns a generic Cluster.
+	// Returns an error with the NotFound behavior when the cluster cannot be found.
+	GetCluster(ctx context.Context, id uint) (cluster.Cluster, error)
+
+	// SetStatus sets the cluster status.
+	SetStatus(ctx context.Context, id uint, status string, statusMessage string) error
+}
+
+// HealthChecker checks the health of imported clusters and keeps their status up to date.
+type HealthChecker struct {
+	clusters ClusterStore
+	prober   HealthProber
+	logger   common.Logger
+}
+
+// NewHealthChecker returns a new HealthChecker.
+func NewHealthChecker(clusters ClusterStore, prober HealthProber, logger common.Logger) HealthChecker {
+	return HealthChecker{
+		clusters: clusters,
+		prober:   prober,
+		logger:   logger,
+	}
+}
+
+// CheckClusterHealth probes an imported cluster and updates its status based on the result.
+// The status of clusters with an operation in progress (or failed for other reasons) is left untouched.
+func (c HealthChecker) CheckClusterHealth(ctx context.Context, clusterID uint) (ClusterHealth, error) {
+	cl, err := c.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return ClusterHealth{}, err
+	}
+
+	if cl.Cloud != pkgCluster.Kubernetes {
+		return ClusterHealth{}, errors.WithStack(cluster.NotSupportedDistributionError{
+			ID:           cl.ID,
+			Cloud:        cl.Cloud,
+			Distribution: cl.Distribution,
+
+			Message: "health checks are only supported for imported clusters",
+		})
+	}
+
+	secretID := cl.ConfigSecretID
+	if secretID.ResourceID == "" {
+		secretID = cl.SecretID
+	}
+
+	health := c.prober.ProbeHealth(ctx, secretID.String())
+
+	if !isHealthManagedStatus(cl.Status, cl.StatusMessage) {
+		return health, nil
+	}
+
+	status, statusMessage := health.Status()
+	if status == cl.Status && statusMessage == cl.StatusMessage {
+		return health, nil
+	}
+
+	c.logger.Info("imported cluster health changed", map[string]interface{}{
+		"clusterId": cl.ID,
+		"status":    status,
+		"message":   statusMessage,
+	})
+
+	if err := c.clusters.SetStatus(ctx, cl.ID, status, statusMessage); err != nil {
+		return health, errors.WrapIfWithDetails(err, "failed to set cluster status", "clusterId", cl.ID)
+	}
+
+	return health, nil
+}
+
+// isHealthManagedStatus tells whether a cluster status can be overwritten by the health check.
+func isHealthManagedStatus(status string, statusMessage string) bool {
+	switch status {
+	case cluster.Running:
+		return true
+
+	case cluster.Warning, cluster.Error:
+		return strings.HasPrefix(statusMessage, healthCheckMessagePrefix)
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/pkg/brn"
+)
+
+func TestClusterHealth_Status(t *testing.T) {
+	tests := []struct {
+		name          string
+		health        ClusterHealth
+		status        string
+		statusMessage string
+	}{
+		{
+			name:          "Unreachable",
+			health:        ClusterHealth{Error: "connection refused"},
+			status:        cluster.Error,
+			statusMessage: "Health check: API server is unreachable: connection refused",
+		},
+		{
+			name:          "NoReadyNodes",
+			health:        ClusterHealth{Reachable: true, Nodes: 2},
+			status:        cluster.Error,
+			statusMessage: "Health check: no ready nodes",
+		},
+		{
+			name:          "NotReadyNodes",
+			health:        ClusterHealth{Reachable: true, Nodes: 3, ReadyNodes: 2},
+			status:        cluster.Warning,
+			statusMessage: "Health check: 1 of 3 nodes are not ready",
+		},
+		{
+			name:          "Healthy",
+			health:        ClusterHealth{Reachable: true, Nodes: 3, ReadyNodes: 3},
+			status:        cluster.Running,
+			statusMessage: cluster.RunningMessage,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			status, statusMessage := test.health.Status()
+
+			assert.Equal(t, test.status, status)
+			assert.Equal(t, test.statusMessage, statusMessage)
+		})
+	}
+}
+
+func TestHealthChecker_CheckClusterHealth(t *testing.T) {
+	newCluster := func(status string, statusMessage string) cluster.Cluster {
+		return cluster.Cluster{
+			ID:             1,
+			OrganizationID: 1,
+			Status:         status,
+			StatusMessage:  statusMessage,
+			Cloud:          "kubernetes",
+			Distribution:   "unknown",
+			SecretID:       brn.New(1, brn.SecretResourceType, "imported"),
+			ConfigSecretID: brn.New(1, brn.SecretResourceType, "config"),
+		}
+	}
+
+	unhealthy := ClusterHealth{Error: "connection refused"}
+
+	t.Run("StatusChanged", func(t *testing.T) {
+		ctx := context.Background()
+		c := newCluster(cluster.Running, cluster.RunningMessage)
+
+		clusters := new(MockClusterStore)
+		clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+		clusters.On("SetStatus", ctx, c.ID, cluster.Error, "Health check: API server is unreachable: connection refused").Return(nil)
+
+		prober := new(MockHealthProber)
+		prober.On("ProbeHealth", ctx, c.ConfigSecretID.String()).Return(unhealthy)
+
+		checker := NewHealthChecker(clusters, prober, common.NoopLogger{})
+
+		health, err := checker.CheckClusterHealth(ctx, c.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, unhealthy, health)
+
+		clusters.AssertExpectations(t)
+		prober.AssertExpectations(t)
+	})
+
+	t.Run("Recovered", func(t *testing.T) {
+		ctx := context.Background()
+		c := newCluster(cluster.Warning, "Health check: 1 of 3 nodes are not ready")
+		healthy := ClusterHealth{Reachable: true, Nodes: 3, ReadyNodes: 3}
+
+		clusters := new(MockClusterStore)
+		clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+		clusters.On("SetStatus", ctx, c.ID, cluster.Running, cluster.RunningMessage).Return(nil)
+
+		prober := new(MockHealthProber)
+		prober.On("ProbeHealth", ctx, c.ConfigSecretID.String()).Return(healthy)
+
+		checker := NewHealthChecker(clusters, prober, common.NoopLogger{})
+
+		_, err := checker.CheckClusterHealth(ctx, c.ID)
+		require.NoError(t, err)
+
+		clusters.AssertExpectations(t)
+	})
+
+	t.Run("StatusUnchanged", func(t *testing.T) {
+		ctx := context.Background()
+		c := newCluster(cluster.Error, "Health check: API server is unreachable: connection refused")
+
+		clusters := new(MockClusterStore)
+		clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+
+		prober := new(MockHealthProber)
+		prober.On("ProbeHealth", ctx, c.ConfigSecretID.String()).Return(unhealthy)
+
+		checker := NewHealthChecker(clusters, prober, common.NoopLogger{})
+
+		_, err := checker.CheckClusterHealth(ctx, c.ID)
+		require.NoError(t, err)
+
+		clusters.AssertNotCalled(t, "SetStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("OperationInProgress", func(t *testing.T) {
+		ctx := context.Background()
+		c := newCluster(cluster.Deleting, cluster.DeletingMessage)
+
+		clusters := new(MockClusterStore)
+		clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+
+		prober := new(MockHealthProber)
+		prober.On("ProbeHealth", ctx, c.ConfigSecretID.String()).Return(unhealthy)
+
+		checker := NewHealthChecker(clusters, prober, common.NoopLogger{})
+
+		health, err := checker.CheckClusterHealth(ctx, c.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, unhealthy, health)
+
+		clusters.AssertNotCalled(t, "SetStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("OtherFailure", func(t *testing.T) {
+		ctx := context.Background()
+		c := newCluster(cluster.Error, "failed to install posthooks")
+
+		clusters := new(MockClusterStore)
+		clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+
+		prober := new(MockHealthProber)
+		prober.On("ProbeHealth", ctx, c.ConfigSecretID.String()).Return(ClusterHealth{Reachable: true, Nodes: 1, ReadyNodes: 1})
+
+		checker := NewHealthChecker(clusters, prober, common.NoopLogger{})
+
+		_, err := checker.CheckClusterHealth(ctx, c.ID)
+		require.NoError(t, err)
+
+		clusters.AssertNotCalled(t, "SetStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("NotImported", func(t *testing.T) {
+		ctx := context.Background()
+		c := newCluster(cluster.Running, cluster.RunningMessage)
+		c.Cloud = "amazon"
+		c.Distribution = "eks"
+
+		clusters := new(MockClusterStore)
+		clusters.On("GetCluster", ctx, c.ID).Return(c, nil)
+
+		prober := new(MockHealthProber)
+
+		checker := NewHealthChecker(clusters, prober, common.NoopLogger{})
+
+		_, err := checker.CheckClusterHealth(ctx, c.ID)
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &cluster.NotSupportedDistributionError{}))
+
+		prober.AssertNotCalled(t, "ProbeHealth", mock.Anything, mock.Anything)
+	})
+}
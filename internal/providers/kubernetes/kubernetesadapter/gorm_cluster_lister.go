@@ -0,0 +1,51 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetesadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+// GORMImportedClusterLister lists imported clusters from the database.
+type GORMImportedClusterLister struct {
+	db *gorm.DB
+}
+
+// NewGORMImportedClusterLister returns a new GORMImportedClusterLister.
+func NewGORMImportedClusterLister(db *gorm.DB) GORMImportedClusterLister {
+	return GORMImportedClusterLister{
+		db: db,
+	}
+}
+
+// ListImportedClusterIDs returns the IDs of every imported cluster.
+func (l GORMImportedClusterLister) ListImportedClusterIDs(ctx context.Context) ([]uint, error) {
+	var ids []uint
+
+	err := l.db.Table("clusters").
+		Where("cloud = ? AND deleted_at IS NULL", pkgCluster.Kubernetes).
+		Order("id").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list imported clusters")
+	}
+
+	return ids, nil
+}
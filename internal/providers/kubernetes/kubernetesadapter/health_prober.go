@@ -0,0 +1,99 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetesadapter
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kubernetesprovider "github.com/banzaicloud/pipeline/internal/providers/kubernetes"
+)
+
+// KubeClientFactory returns a Kubernetes client.
+type KubeClientFactory interface {
+	// FromSecret creates a Kubernetes client for a cluster from a secret.
+	FromSecret(ctx context.Context, secretID string) (kubernetes.Interface, error)
+}
+
+// ClientHealthProber probes the health of a cluster through the Kubernetes API.
+type ClientHealthProber struct {
+	clientFactory KubeClientFactory
+	timeout       time.Duration
+}
+
+// NewClientHealthProber returns a new ClientHealthProber.
+func NewClientHealthProber(clientFactory KubeClientFactory, timeout time.Duration) ClientHealthProber {
+	return ClientHealthProber{
+		clientFactory: clientFactory,
+		timeout:       timeout,
+	}
+}
+
+// ProbeHealth probes the API server and the nodes of the cluster
+// accessible with the kubeconfig stored in the specified secret.
+func (p ClientHealthProber) ProbeHealth(ctx context.Context, secretID string) kubernetesprovider.ClusterHealth {
+	health := kubernetesprovider.ClusterHealth{
+		CheckedAt: time.Now(),
+	}
+
+	client, err := p.clientFactory.FromSecret(ctx, secretID)
+	if err != nil {
+		health.Error = err.Error()
+
+		return health
+	}
+
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		health.Error = err.Error()
+
+		return health
+	}
+
+	health.Reachable = true
+
+	if version, err := client.Discovery().ServerVersion(); err == nil {
+		health.Version = version.GitVersion
+	}
+
+	health.Nodes = len(nodes.Items)
+	for _, node := range nodes.Items {
+		if isNodeReady(node) {
+			health.ReadyNodes++
+		}
+	}
+
+	return health
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
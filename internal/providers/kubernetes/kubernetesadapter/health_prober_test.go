@@ -0,0 +1,82 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetesadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type kubeClientFactoryStub struct {
+	client kubernetes.Interface
+	err    error
+}
+
+func (f kubeClientFactoryStub) FromSecret(_ context.Context, _ string) (kubernetes.Interface, error) {
+	return f.client, f.err
+}
+
+func newNode(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: ready,
+				},
+			},
+		},
+	}
+}
+
+func TestClientHealthProber_ProbeHealth(t *testing.T) {
+	t.Run("Reachable", func(t *testing.T) {
+		client := fake.NewSimpleClientset(
+			newNode("node0", corev1.ConditionTrue),
+			newNode("node1", corev1.ConditionTrue),
+			newNode("node2", corev1.ConditionUnknown),
+		)
+
+		prober := NewClientHealthProber(kubeClientFactoryStub{client: client}, time.Second)
+
+		health := prober.ProbeHealth(context.Background(), "secret")
+
+		assert.True(t, health.Reachable)
+		assert.Equal(t, 3, health.Nodes)
+		assert.Equal(t, 2, health.ReadyNodes)
+		assert.Empty(t, health.Error)
+		assert.False(t, health.CheckedAt.IsZero())
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		prober := NewClientHealthProber(kubeClientFactoryStub{err: errors.New("invalid kubeconfig")}, time.Second)
+
+		health := prober.ProbeHealth(context.Background(), "secret")
+
+		assert.False(t, health.Reachable)
+		assert.Equal(t, "invalid kubeconfig", health.Error)
+	})
+}
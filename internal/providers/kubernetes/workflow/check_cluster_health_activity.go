@@ -0,0 +1,59 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/providers/kubernetes"
+)
+
+// CheckClusterHealthActivityName is the name the CheckClusterHealthActivity is registered under
+const CheckClusterHealthActivityName = "kubernetes-check-cluster-health"
+
+// ClusterHealthChecker checks the health of an imported cluster.
+type ClusterHealthChecker interface {
+	// CheckClusterHealth probes an imported cluster and updates its status based on the result.
+	CheckClusterHealth(ctx context.Context, clusterID uint) (kubernetes.ClusterHealth, error)
+}
+
+// CheckClusterHealthActivityInput defines the inputs of the CheckClusterHealthActivity
+type CheckClusterHealthActivityInput struct {
+	ClusterID uint
+}
+
+// CheckClusterHealthActivity probes an imported cluster and updates its status
+type CheckClusterHealthActivity struct {
+	healthChecker ClusterHealthChecker
+}
+
+// MakeCheckClusterHealthActivity returns a new CheckClusterHealthActivity
+func MakeCheckClusterHealthActivity(healthChecker ClusterHealthChecker) CheckClusterHealthActivity {
+	return CheckClusterHealthActivity{
+		healthChecker: healthChecker,
+	}
+}
+
+// Execute executes the activity
+func (a CheckClusterHealthActivity) Execute(ctx context.Context, input CheckClusterHealthActivityInput) error {
+	_, err := a.healthChecker.CheckClusterHealth(ctx, input.ClusterID)
+	if cluster.IsNotFoundError(err) {
+		// the cluster has been deleted since it was listed
+		return nil
+	}
+
+	return err
+}
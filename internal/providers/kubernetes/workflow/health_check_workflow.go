@@ -0,0 +1,59 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+)
+
+// HealthCheckWorkflowName is the name the HealthCheckWorkflow is registered under
+const HealthCheckWorkflowName = "kubernetes-health-check"
+
+// HealthCheckWorkflowID is the fixed ID of the scheduled HealthCheckWorkflow
+const HealthCheckWorkflowID = "kubernetes-health-check"
+
+// HealthCheckWorkflowInput defines the inputs of the HealthCheckWorkflow
+type HealthCheckWorkflowInput struct{}
+
+// HealthCheckWorkflow probes every imported cluster and updates their status according to their health.
+func HealthCheckWorkflow(ctx workflow.Context, _ HealthCheckWorkflowInput) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    2 * time.Minute,
+	})
+
+	logger := workflow.GetLogger(ctx)
+
+	var clusterIDs []uint
+	if err := workflow.ExecuteActivity(ctx, ListImportedClustersActivityName, ListImportedClustersActivityInput{}).Get(ctx, &clusterIDs); err != nil {
+		return err
+	}
+
+	futures := make([]workflow.Future, len(clusterIDs))
+	for i, clusterID := range clusterIDs {
+		futures[i] = workflow.ExecuteActivity(ctx, CheckClusterHealthActivityName, CheckClusterHealthActivityInput{ClusterID: clusterID})
+	}
+
+	for i, future := range futures {
+		if err := future.Get(ctx, nil); err != nil {
+			logger.Error("failed to check cluster health", zap.Uint("clusterId", clusterIDs[i]), zap.Error(err))
+		}
+	}
+
+	return nil
+}
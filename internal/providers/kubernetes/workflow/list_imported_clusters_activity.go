@@ -0,0 +1,48 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+)
+
+// ListImportedClustersActivityName is the name the ListImportedClustersActivity is registered under
+const ListImportedClustersActivityName = "kubernetes-list-imported-clusters"
+
+// ImportedClusterLister lists imported clusters.
+type ImportedClusterLister interface {
+	// ListImportedClusterIDs returns the IDs of every imported cluster.
+	ListImportedClusterIDs(ctx context.Context) ([]uint, error)
+}
+
+// ListImportedClustersActivityInput defines the inputs of the ListImportedClustersActivity
+type ListImportedClustersActivityInput struct{}
+
+// ListImportedClustersActivity lists the imported clusters to be health checked
+type ListImportedClustersActivity struct {
+	clusters ImportedClusterLister
+}
+
+// MakeListImportedClustersActivity returns a new ListImportedClustersActivity
+func MakeListImportedClustersActivity(clusters ImportedClusterLister) ListImportedClustersActivity {
+	return ListImportedClustersActivity{
+		clusters: clusters,
+	}
+}
+
+// Execute executes the activity
+func (a ListImportedClustersActivity) Execute(ctx context.Context, _ ListImportedClustersActivityInput) ([]uint, error) {
+	return a.clusters.ListImportedClusterIDs(ctx)
+}
@@ -43,6 +43,10 @@ type ObjectStoreContext struct {
 	ResourceGroup  string
 	StorageAccount string
 
+	// S3 compatible object store parameters
+	Endpoint string
+	CACert   string
+
 	// ForceOperation indicates whether the operation needs to be executed forcibly (some errors are ignored)
 	ForceOperation bool
 }
@@ -73,7 +73,7 @@ func (a *CreateWorkerPoolActivity) Execute(ctx context.Context, input CreateWork
 		return "", err
 	}
 
-	stackName := fmt.Sprintf("pke-pool-%s-worker-%s", cluster.GetName(), input.Pool.Name)
+	stackName := pkeaws.NodePoolStackName(cluster.GetName(), input.Pool.Name)
 
 	awsCluster, ok := cluster.(AWSCluster)
 	if !ok {
@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"go.uber.org/cadence/activity"
 
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
 	pkgCloudformation "github.com/banzaicloud/pipeline/pkg/providers/amazon/cloudformation"
 	sdkAmazon "github.com/banzaicloud/pipeline/pkg/sdk/providers/amazon"
 )
@@ -67,7 +68,7 @@ func (a *DeletePoolActivity) Execute(ctx context.Context, input DeletePoolActivi
 
 	cfClient := cloudformation.New(client)
 
-	stackName := fmt.Sprintf("pke-pool-%s-worker-%s", cluster.GetName(), input.Pool.Name)
+	stackName := pkeaws.NodePoolStackName(cluster.GetName(), input.Pool.Name)
 	if input.Pool.Master {
 		stackName = fmt.Sprintf("pke-master-%s", cluster.GetName())
 	}
@@ -118,7 +119,7 @@ func (a *WaitForDeletePoolActivity) Execute(ctx context.Context, input DeletePoo
 
 	cfClient := cloudformation.New(client)
 
-	stackName := fmt.Sprintf("pke-pool-%s-worker-%s", cluster.GetName(), input.Pool.Name)
+	stackName := pkeaws.NodePoolStackName(cluster.GetName(), input.Pool.Name)
 	if input.Pool.Master {
 		stackName = fmt.Sprintf("pke-master-%s", cluster.GetName())
 	}
@@ -15,11 +15,12 @@
 package pkeworkflow
 
 import (
-	"fmt"
 	"time"
 
 	"emperror.dev/errors"
 	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/pke/pkeaws"
 )
 
 const UpdateClusterWorkflowName = "pke-update-cluster"
@@ -127,7 +128,7 @@ func UpdateClusterWorkflow(ctx workflow.Context, input UpdateClusterWorkflowInpu
 		futures := make([]workflow.Future, len(input.NodePoolsToUpdate))
 
 		for i, np := range input.NodePoolsToUpdate {
-			stackName := fmt.Sprintf("pke-pool-%s-worker-%s", input.ClusterName, np.Name)
+			stackName := pkeaws.NodePoolStackName(input.ClusterName, np.Name)
 
 			activityInput := WaitCFCompletionActivityInput{
 				AWSActivityInput: awsActivityInput,
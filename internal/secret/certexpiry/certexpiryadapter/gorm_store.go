@@ -0,0 +1,117 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certexpiryadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret/certexpiry"
+)
+
+// expiryModel describes the secret certificate expiry model.
+type expiryModel struct {
+	ID             uint   `gorm:"primary_key"`
+	OrganizationID uint   `gorm:"unique_index:idx_secret_certificate_expiries_org_secret"`
+	SecretID       string `gorm:"unique_index:idx_secret_certificate_expiries_org_secret;size:255"`
+	SecretName     string `gorm:"size:255"`
+	SecretType     string `gorm:"size:255"`
+	NotAfter       time.Time
+	CheckedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (expiryModel) TableName() string {
+	return "secret_certificate_expiries"
+}
+
+// GormStore is a gorm based secret certificate expiry store.
+type GormStore struct {
+	db     *gorm.DB
+	logger common.Logger
+}
+
+// NewGormStore returns a new GormStore.
+func NewGormStore(db *gorm.DB, logger common.Logger) GormStore {
+	return GormStore{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// List lists the certificate expiries of every organization.
+func (s GormStore) List(_ context.Context) ([]certexpiry.Expiry, error) {
+	var models []expiryModel
+	if err := s.db.Order("organization_id, secret_id").Find(&models).Error; err != nil {
+		return nil, errors.WrapIf(err, "failed to list secret certificate expiries")
+	}
+
+	expiries := make([]certexpiry.Expiry, 0, len(models))
+	for _, model := range models {
+		expiries = append(expiries, certexpiry.Expiry{
+			OrganizationID: model.OrganizationID,
+			SecretID:       model.SecretID,
+			SecretName:     model.SecretName,
+			SecretType:     model.SecretType,
+			NotAfter:       model.NotAfter,
+			CheckedAt:      model.CheckedAt,
+		})
+	}
+
+	return expiries, nil
+}
+
+// Replace replaces the certificate expiries recorded for an organization.
+func (s GormStore) Replace(_ context.Context, organizationID uint, expiries []certexpiry.Expiry) error {
+	tx := s.db.Begin()
+	if err := tx.Error; err != nil {
+		return errors.WrapIf(err, "failed to begin transaction")
+	}
+
+	if err := tx.Where("organization_id = ?", organizationID).Delete(&expiryModel{}).Error; err != nil {
+		tx.Rollback()
+
+		return errors.WrapIfWithDetails(err, "failed to delete secret certificate expiries", "orgID", organizationID)
+	}
+
+	for _, expiry := range expiries {
+		model := expiryModel{
+			OrganizationID: organizationID,
+			SecretID:       expiry.SecretID,
+			SecretName:     expiry.SecretName,
+			SecretType:     expiry.SecretType,
+			NotAfter:       expiry.NotAfter,
+			CheckedAt:      expiry.CheckedAt,
+		}
+
+		if err := tx.Create(&model).Error; err != nil {
+			tx.Rollback()
+
+			return errors.WrapIfWithDetails(err, "failed to save secret certificate expiry", "orgID", organizationID, "secretID", expiry.SecretID)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return errors.WrapIf(err, "failed to commit transaction")
+	}
+
+	s.logger.Debug("replaced secret certificate expiry records", map[string]interface{}{"organizationID": organizationID, "count": len(expiries)})
+
+	return nil
+}
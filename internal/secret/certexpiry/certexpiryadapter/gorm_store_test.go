@@ -0,0 +1,71 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certexpiryadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret/certexpiry"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewGormStore(setUpDatabase(t), common.NoopLogger{})
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	err := store.Replace(ctx, 1, []certexpiry.Expiry{
+		{SecretID: "old", SecretName: "old", SecretType: "tls", NotAfter: now, CheckedAt: now},
+	})
+	require.NoError(t, err)
+
+	err = store.Replace(ctx, 2, []certexpiry.Expiry{
+		{SecretID: "other", SecretName: "other", SecretType: "pkecert", NotAfter: now, CheckedAt: now},
+	})
+	require.NoError(t, err)
+
+	err = store.Replace(ctx, 1, []certexpiry.Expiry{
+		{SecretID: "new", SecretName: "new", SecretType: "tls", NotAfter: now.Add(time.Hour), CheckedAt: now},
+	})
+	require.NoError(t, err)
+
+	expiries, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, expiries, 2)
+
+	assert.Equal(t, uint(1), expiries[0].OrganizationID)
+	assert.Equal(t, "new", expiries[0].SecretID)
+	assert.True(t, now.Add(time.Hour).Equal(expiries[0].NotAfter))
+	assert.Equal(t, uint(2), expiries[1].OrganizationID)
+	assert.Equal(t, "other", expiries[1].SecretID)
+}
@@ -0,0 +1,68 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certexpiryadapter
+
+import (
+	"context"
+	"time"
+
+	"github.com/banzaicloud/pipeline/internal/app/frontend/notification"
+	"github.com/banzaicloud/pipeline/internal/secret/certexpiry"
+)
+
+// NotificationSource identifies the notifications published by the certificate expiry checks.
+const NotificationSource = "secret-certificate-expiry"
+
+// OrganizationNotificationStore stores notifications of organizations.
+type OrganizationNotificationStore interface {
+	// ReplaceOrganizationNotifications replaces the notifications published by a source for an organization.
+	ReplaceOrganizationNotifications(
+		ctx context.Context,
+		organizationID uint,
+		source string,
+		notifications []notification.Notification,
+		endTime time.Time,
+	) error
+}
+
+// NotificationPublisher publishes certificate expiry warnings as frontend notifications.
+type NotificationPublisher struct {
+	store OrganizationNotificationStore
+}
+
+// NewNotificationPublisher returns a new NotificationPublisher.
+func NewNotificationPublisher(store OrganizationNotificationStore) NotificationPublisher {
+	return NotificationPublisher{
+		store: store,
+	}
+}
+
+// PublishNotifications replaces the expiry notifications of an organization.
+func (p NotificationPublisher) PublishNotifications(
+	ctx context.Context,
+	organizationID uint,
+	notifications []certexpiry.Notification,
+	validUntil time.Time,
+) error {
+	messages := make([]notification.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		messages = append(messages, notification.Notification{
+			Message:  n.Message,
+			Priority: n.Priority,
+		})
+	}
+
+	return p.store.ReplaceOrganizationNotifications(ctx, organizationID, NotificationSource, messages, validUntil)
+}
@@ -0,0 +1,112 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certexpiryadapter
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret/certexpiry"
+)
+
+// ExpiryLister lists certificate expiries.
+type ExpiryLister interface {
+	// List lists the certificate expiries of every organization.
+	List(ctx context.Context) ([]certexpiry.Expiry, error)
+}
+
+// PrometheusCollector exports the recorded certificate expiries as Prometheus metrics.
+type PrometheusCollector struct {
+	expiries ExpiryLister
+	logger   common.Logger
+
+	expiryTimestamp *prometheus.GaugeVec
+	daysToExpiry    *prometheus.GaugeVec
+
+	mu sync.Mutex
+}
+
+// NewPrometheusCollector returns a new PrometheusCollector.
+func NewPrometheusCollector(expiries ExpiryLister, logger common.Logger) *PrometheusCollector {
+	labels := []string{"organization_id", "secret_id", "secret_name", "secret_type"}
+
+	return &PrometheusCollector{
+		expiries: expiries,
+		logger:   logger,
+
+		expiryTimestamp: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "pipeline",
+				Subsystem: "secret",
+				Name:      "certificate_expiry_timestamp_seconds",
+				Help:      "the expiry of the earliest expiring certificate stored in a secret",
+			},
+			labels,
+		),
+		daysToExpiry: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "pipeline",
+				Subsystem: "secret",
+				Name:      "certificate_days_to_expiry",
+				Help:      "the number of whole days until the earliest expiring certificate stored in a secret expires",
+			},
+			labels,
+		),
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *PrometheusCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.expiryTimestamp.Describe(ch)
+	c.daysToExpiry.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *PrometheusCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expiryTimestamp.Reset()
+	c.daysToExpiry.Reset()
+
+	expiries, err := c.expiries.List(context.Background())
+	if err != nil {
+		c.logger.Error("failed to list secret certificate expiries", map[string]interface{}{"error": err.Error()})
+
+		return
+	}
+
+	now := time.Now()
+
+	for _, expiry := range expiries {
+		labels := prometheus.Labels{
+			"organization_id": strconv.FormatUint(uint64(expiry.OrganizationID), 10),
+			"secret_id":       expiry.SecretID,
+			"secret_name":     expiry.SecretName,
+			"secret_type":     expiry.SecretType,
+		}
+
+		c.expiryTimestamp.With(labels).Set(float64(expiry.NotAfter.Unix()))
+		c.daysToExpiry.With(labels).Set(float64(expiry.DaysToExpiry(now)))
+	}
+
+	c.expiryTimestamp.Collect(ch)
+	c.daysToExpiry.Collect(ch)
+}
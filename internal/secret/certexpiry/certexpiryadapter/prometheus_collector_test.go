@@ -0,0 +1,54 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certexpiryadapter
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret/certexpiry"
+)
+
+type expiryListerStub []certexpiry.Expiry
+
+func (s expiryListerStub) List(_ context.Context) ([]certexpiry.Expiry, error) {
+	return s, nil
+}
+
+func TestPrometheusCollector(t *testing.T) {
+	notAfter := time.Unix(2000000000, 0)
+
+	collector := NewPrometheusCollector(
+		expiryListerStub{
+			{OrganizationID: 1, SecretID: "secret", SecretName: "name", SecretType: "tls", NotAfter: notAfter},
+		},
+		common.NoopLogger{},
+	)
+
+	expected := `
+# HELP pipeline_secret_certificate_expiry_timestamp_seconds the expiry of the earliest expiring certificate stored in a secret
+# TYPE pipeline_secret_certificate_expiry_timestamp_seconds gauge
+pipeline_secret_certificate_expiry_timestamp_seconds{organization_id="1",secret_id="secret",secret_name="name",secret_type="tls"} 2e+09
+`
+
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "pipeline_secret_certificate_expiry_timestamp_seconds")
+	require.NoError(t, err)
+}
@@ -0,0 +1,65 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certexpiryadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/secret/certexpiry"
+	pipelinesecret "github.com/banzaicloud/pipeline/src/secret"
+)
+
+// PipelineSecretStore provides access to Pipeline secrets.
+type PipelineSecretStore interface {
+	List(organizationID uint, query *pipelinesecret.ListSecretsQuery) ([]*pipelinesecret.SecretItemResponse, error)
+}
+
+// SecretStore provides access to the Pipeline secrets storing certificates.
+type SecretStore struct {
+	secrets PipelineSecretStore
+}
+
+// NewSecretStore returns a new SecretStore.
+func NewSecretStore(secrets PipelineSecretStore) SecretStore {
+	return SecretStore{
+		secrets: secrets,
+	}
+}
+
+// ListCertificateSecrets lists the secrets of an organization that store certificates.
+func (s SecretStore) ListCertificateSecrets(_ context.Context, organizationID uint) ([]certexpiry.Secret, error) {
+	items, err := s.secrets.List(organizationID, &pipelinesecret.ListSecretsQuery{})
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list secrets", "organizationId", organizationID)
+	}
+
+	var secrets []certexpiry.Secret
+	for _, item := range items {
+		if item.NotAfter == nil {
+			continue
+		}
+
+		secrets = append(secrets, certexpiry.Secret{
+			ID:       item.ID,
+			Name:     item.Name,
+			Type:     item.Type,
+			NotAfter: *item.NotAfter,
+		})
+	}
+
+	return secrets, nil
+}
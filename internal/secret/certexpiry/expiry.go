@@ -0,0 +1,50 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certexpiry
+
+import (
+	"math"
+	"time"
+)
+
+// Expiry describes when the earliest expiring certificate of a secret expires.
+type Expiry struct {
+	OrganizationID uint
+	SecretID       string
+	SecretName     string
+	SecretType     string
+	NotAfter       time.Time
+	CheckedAt      time.Time
+}
+
+// DaysToExpiry returns the number of whole days until the certificate expires.
+// The result is negative if the certificate has already expired.
+func (e Expiry) DaysToExpiry(now time.Time) int {
+	return int(math.Floor(e.NotAfter.Sub(now).Hours() / 24))
+}
+
+// Secret is a Pipeline secret storing certificates.
+type Secret struct {
+	ID       string
+	Name     string
+	Type     string
+	NotAfter time.Time
+}
+
+// Notification is an expiry warning published to the users of an organization.
+type Notification struct {
+	Message  string
+	Priority int8
+}
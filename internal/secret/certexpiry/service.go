@@ -0,0 +1,156 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certexpiry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+// Notification priorities.
+const (
+	WarningPriority int8 = 50
+	ExpiredPriority int8 = 100
+)
+
+// Service tracks the expiry of the certificates stored in secrets.
+type Service interface {
+	// CheckOrganization records the certificate expiries of an organization
+	// and publishes warnings about the certificates expiring within the warning period.
+	CheckOrganization(ctx context.Context, organizationID uint) error
+}
+
+// +testify:mock:testOnly=true
+
+// Store persists certificate expiries.
+type Store interface {
+	// Replace replaces the certificate expiries recorded for an organization.
+	Replace(ctx context.Context, organizationID uint, expiries []Expiry) error
+}
+
+// +testify:mock:testOnly=true
+
+// SecretStore provides access to the Pipeline secrets storing certificates.
+type SecretStore interface {
+	// ListCertificateSecrets lists the secrets of an organization that store certificates.
+	ListCertificateSecrets(ctx context.Context, organizationID uint) ([]Secret, error)
+}
+
+// +testify:mock:testOnly=true
+
+// NotificationPublisher publishes notifications to the users of an organization.
+type NotificationPublisher interface {
+	// PublishNotifications replaces the expiry notifications of an organization.
+	// Notifications are shown until they are replaced or the validUntil time passes.
+	PublishNotifications(ctx context.Context, organizationID uint, notifications []Notification, validUntil time.Time) error
+}
+
+// NewService returns a new Service.
+func NewService(
+	warningPeriod time.Duration,
+	store Store,
+	secrets SecretStore,
+	notifications NotificationPublisher,
+	logger common.Logger,
+) Service {
+	return service{
+		warningPeriod: warningPeriod,
+		store:         store,
+		secrets:       secrets,
+		notifications: notifications,
+		logger:        logger,
+	}
+}
+
+type service struct {
+	warningPeriod time.Duration
+	store         Store
+	secrets       SecretStore
+	notifications NotificationPublisher
+	logger        common.Logger
+}
+
+func (s service) CheckOrganization(ctx context.Context, organizationID uint) error {
+	secrets, err := s.secrets.ListCertificateSecrets(ctx, organizationID)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to list certificate secrets", "organizationId", organizationID)
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		if secrets[i].NotAfter.Equal(secrets[j].NotAfter) {
+			return secrets[i].Name < secrets[j].Name
+		}
+
+		return secrets[i].NotAfter.Before(secrets[j].NotAfter)
+	})
+
+	now := time.Now()
+
+	expiries := make([]Expiry, 0, len(secrets))
+	var notifications []Notification
+
+	for _, secret := range secrets {
+		expiry := Expiry{
+			OrganizationID: organizationID,
+			SecretID:       secret.ID,
+			SecretName:     secret.Name,
+			SecretType:     secret.Type,
+			NotAfter:       secret.NotAfter,
+			CheckedAt:      now,
+		}
+
+		expiries = append(expiries, expiry)
+
+		if !secret.NotAfter.After(now) {
+			notifications = append(notifications, Notification{
+				Message: fmt.Sprintf(
+					"The certificate stored in secret %q expired on %s.",
+					secret.Name, secret.NotAfter.UTC().Format(time.RFC3339),
+				),
+				Priority: ExpiredPriority,
+			})
+		} else if secret.NotAfter.Before(now.Add(s.warningPeriod)) {
+			notifications = append(notifications, Notification{
+				Message: fmt.Sprintf(
+					"The certificate stored in secret %q expires in %d days on %s.",
+					secret.Name, expiry.DaysToExpiry(now), secret.NotAfter.UTC().Format(time.RFC3339),
+				),
+				Priority: WarningPriority,
+			})
+		}
+	}
+
+	if err := s.store.Replace(ctx, organizationID, expiries); err != nil {
+		return err
+	}
+
+	if err := s.notifications.PublishNotifications(ctx, organizationID, notifications, now.Add(s.warningPeriod)); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to publish expiry notifications", "organizationId", organizationID)
+	}
+
+	s.logger.Debug("certificate expiries checked", map[string]interface{}{
+		"organizationId": organizationID,
+		"certificates":   len(expiries),
+		"notifications":  len(notifications),
+	})
+
+	return nil
+}
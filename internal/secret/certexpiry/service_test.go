@@ -0,0 +1,103 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certexpiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func TestExpiry_DaysToExpiry(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 29, Expiry{NotAfter: now.Add(30*24*time.Hour - time.Minute)}.DaysToExpiry(now))
+	assert.Equal(t, 0, Expiry{NotAfter: now.Add(time.Hour)}.DaysToExpiry(now))
+	assert.Equal(t, -1, Expiry{NotAfter: now.Add(-time.Hour)}.DaysToExpiry(now))
+}
+
+func TestService_CheckOrganization(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	secrets := new(MockSecretStore)
+	secrets.On("ListCertificateSecrets", ctx, uint(1)).Return(
+		[]Secret{
+			{ID: "valid", Name: "valid", Type: "tls", NotAfter: now.Add(365 * 24 * time.Hour)},
+			{ID: "expiring", Name: "expiring", Type: "tls", NotAfter: now.Add(10*24*time.Hour + time.Hour)},
+			{ID: "expired", Name: "expired", Type: "pkecert", NotAfter: now.Add(-time.Hour)},
+		},
+		nil,
+	)
+
+	store := new(MockStore)
+	store.On("Replace", ctx, uint(1), mock.MatchedBy(func(expiries []Expiry) bool {
+		return len(expiries) == 3 &&
+			expiries[0].SecretID == "expired" &&
+			expiries[1].SecretID == "expiring" &&
+			expiries[2].SecretID == "valid" &&
+			expiries[2].OrganizationID == 1 &&
+			expiries[2].SecretType == "tls"
+	})).Return(nil)
+
+	var published []Notification
+
+	notifications := new(MockNotificationPublisher)
+	notifications.On("PublishNotifications", ctx, uint(1), mock.Anything, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { published = args.Get(2).([]Notification) }).
+		Return(nil)
+
+	service := NewService(30*24*time.Hour, store, secrets, notifications, common.NoopLogger{})
+
+	err := service.CheckOrganization(ctx, 1)
+	require.NoError(t, err)
+
+	require.Len(t, published, 2)
+	assert.Equal(t, ExpiredPriority, published[0].Priority)
+	assert.Contains(t, published[0].Message, `"expired"`)
+	assert.Equal(t, WarningPriority, published[1].Priority)
+	assert.Contains(t, published[1].Message, `"expiring" expires in 10 days`)
+
+	secrets.AssertExpectations(t)
+	store.AssertExpectations(t)
+	notifications.AssertExpectations(t)
+}
+
+func TestService_CheckOrganization_NoCertificates(t *testing.T) {
+	ctx := context.Background()
+
+	secrets := new(MockSecretStore)
+	secrets.On("ListCertificateSecrets", ctx, uint(1)).Return(nil, nil)
+
+	store := new(MockStore)
+	store.On("Replace", ctx, uint(1), []Expiry{}).Return(nil)
+
+	notifications := new(MockNotificationPublisher)
+	notifications.On("PublishNotifications", ctx, uint(1), []Notification(nil), mock.AnythingOfType("time.Time")).Return(nil)
+
+	service := NewService(30*24*time.Hour, store, secrets, notifications, common.NoopLogger{})
+
+	err := service.CheckOrganization(ctx, 1)
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+	notifications.AssertExpectations(t)
+}
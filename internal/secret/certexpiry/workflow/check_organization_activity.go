@@ -0,0 +1,51 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+)
+
+// CheckOrganizationActivityName is the name the CheckOrganizationActivity is registered under
+const CheckOrganizationActivityName = "secret-certificate-expiry-check-organization"
+
+// OrganizationChecker checks the certificate expiries of an organization.
+type OrganizationChecker interface {
+	// CheckOrganization records the certificate expiries of an organization
+	// and publishes warnings about the certificates expiring within the warning period.
+	CheckOrganization(ctx context.Context, organizationID uint) error
+}
+
+// CheckOrganizationActivityInput defines the inputs of the CheckOrganizationActivity
+type CheckOrganizationActivityInput struct {
+	OrganizationID uint
+}
+
+// CheckOrganizationActivity checks the certificate expiries of an organization
+type CheckOrganizationActivity struct {
+	checker OrganizationChecker
+}
+
+// MakeCheckOrganizationActivity returns a new CheckOrganizationActivity
+func MakeCheckOrganizationActivity(checker OrganizationChecker) CheckOrganizationActivity {
+	return CheckOrganizationActivity{
+		checker: checker,
+	}
+}
+
+// Execute executes the activity
+func (a CheckOrganizationActivity) Execute(ctx context.Context, input CheckOrganizationActivityInput) error {
+	return a.checker.CheckOrganization(ctx, input.OrganizationID)
+}
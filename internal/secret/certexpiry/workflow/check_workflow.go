@@ -0,0 +1,65 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+)
+
+// CheckWorkflowName is the name the CheckWorkflow is registered under
+const CheckWorkflowName = "secret-certificate-expiry"
+
+// CheckWorkflowID is the fixed ID of the scheduled CheckWorkflow
+const CheckWorkflowID = "secret-certificate-expiry"
+
+// CheckWorkflowInput defines the inputs of the CheckWorkflow
+type CheckWorkflowInput struct{}
+
+// CheckWorkflow checks the certificate expiries of every organization.
+func CheckWorkflow(ctx workflow.Context, _ CheckWorkflowInput) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+	})
+
+	logger := workflow.GetLogger(ctx)
+
+	var organizationIDs []uint
+	if err := workflow.ExecuteActivity(ctx, ListOrganizationsActivityName, ListOrganizationsActivityInput{}).Get(ctx, &organizationIDs); err != nil {
+		return err
+	}
+
+	futures := make([]workflow.Future, len(organizationIDs))
+	for i, organizationID := range organizationIDs {
+		futures[i] = workflow.ExecuteActivity(ctx, CheckOrganizationActivityName, CheckOrganizationActivityInput{
+			OrganizationID: organizationID,
+		})
+	}
+
+	for i, future := range futures {
+		if err := future.Get(ctx, nil); err != nil {
+			logger.Error(
+				"failed to check certificate expiries",
+				zap.Uint("organizationId", organizationIDs[i]),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+)
+
+// ListOrganizationsActivityName is the name the ListOrganizationsActivity is registered under
+const ListOrganizationsActivityName = "secret-certificate-expiry-list-organizations"
+
+// OrganizationLister lists organizations.
+type OrganizationLister interface {
+	// ListOrganizationIDs returns the IDs of every organization.
+	ListOrganizationIDs(ctx context.Context) ([]uint, error)
+}
+
+// ListOrganizationsActivityInput defines the inputs of the ListOrganizationsActivity
+type ListOrganizationsActivityInput struct{}
+
+// ListOrganizationsActivity lists the organizations to be checked
+type ListOrganizationsActivity struct {
+	organizations OrganizationLister
+}
+
+// MakeListOrganizationsActivity returns a new ListOrganizationsActivity
+func MakeListOrganizationsActivity(organizations OrganizationLister) ListOrganizationsActivity {
+	return ListOrganizationsActivity{
+		organizations: organizations,
+	}
+}
+
+// Execute executes the activity
+func (a ListOrganizationsActivity) Execute(ctx context.Context, _ ListOrganizationsActivityInput) ([]uint, error) {
+	return a.organizations.ListOrganizationIDs(ctx)
+}
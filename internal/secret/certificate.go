@@ -0,0 +1,86 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"math"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// CertificateExpiry describes when the earliest expiring certificate of a secret expires.
+type CertificateExpiry struct {
+	// Name of the certificate expiring first.
+	Name string
+
+	NotAfter time.Time
+}
+
+// DaysToExpiry returns the number of whole days until the certificate expires.
+// The result is negative if the certificate has already expired.
+func (e CertificateExpiry) DaysToExpiry(now time.Time) int {
+	return int(math.Floor(e.NotAfter.Sub(now).Hours() / 24))
+}
+
+// GetCertificateExpiry returns the expiry of the earliest expiring certificate stored in a secret.
+// The second result is false if the secret type does not store certificates or the secret has none.
+func GetCertificateExpiry(typ Type, data map[string]string) (CertificateExpiry, bool, error) {
+	ct, ok := typ.(CertificateType)
+	if !ok {
+		return CertificateExpiry{}, false, nil
+	}
+
+	certificates, err := ct.Certificates(data)
+	if err != nil {
+		return CertificateExpiry{}, false, err
+	}
+
+	var expiry CertificateExpiry
+	var found bool
+
+	for name, data := range certificates {
+		for len(data) > 0 {
+			var block *pem.Block
+
+			block, data = pem.Decode(data)
+			if block == nil {
+				break
+			}
+
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return CertificateExpiry{}, false, errors.WrapIfWithDetails(err, "failed to parse certificate", "certificate", name)
+			}
+
+			// prefer the name for the same expiry to keep the result stable
+			if !found || cert.NotAfter.Before(expiry.NotAfter) || (cert.NotAfter.Equal(expiry.NotAfter) && name < expiry.Name) {
+				expiry = CertificateExpiry{
+					Name:     name,
+					NotAfter: cert.NotAfter,
+				}
+				found = true
+			}
+		}
+	}
+
+	return expiry, found, nil
+}
@@ -14,6 +14,10 @@
 
 package secret
 
+import (
+	"emperror.dev/errors"
+)
+
 // ValidationError is returned when a request is semantically invalid.
 type ValidationError struct {
 	message    string
@@ -53,3 +57,21 @@ func (ValidationError) Validation() bool {
 func (ValidationError) ServiceError() bool {
 	return true
 }
+
+// IsNotFoundError returns true if the error implements the NotFound behavior and it returns true.
+func IsNotFoundError(err error) bool {
+	var nfe interface {
+		NotFound() bool
+	}
+
+	return errors.As(err, &nfe) && nfe.NotFound()
+}
+
+// IsValidationError returns true if the error implements the Validation behavior and it returns true.
+func IsValidationError(err error) bool {
+	var ve interface {
+		Validation() bool
+	}
+
+	return errors.As(err, &ve) && ve.Validation()
+}
@@ -44,6 +44,9 @@ type secretStore interface {
 	Store(orgID uint, request *secret.CreateSecretRequest) (string, error)
 	Update(orgID uint, secretID string, request *secret.CreateSecretRequest) error
 	Verify(organizationID uint, secretID string) error
+	ListVersions(organizationID uint, secretID string) ([]secret.SecretVersionResponse, error)
+	GetVersion(organizationID uint, secretID string, version int) (*secret.SecretItemResponse, error)
+	RestoreVersion(organizationID uint, secretID string, version int, updatedBy string) error
 }
 
 func (s *restrictedSecretStore) List(orgid uint, query *secret.ListSecretsQuery) ([]*secret.SecretItemResponse, error) {
@@ -87,6 +90,30 @@ func (s *restrictedSecretStore) Verify(organizationID uint, secretID string) err
 	return s.secretStore.Verify(organizationID, secretID)
 }
 
+func (s *restrictedSecretStore) ListVersions(organizationID uint, secretID string) ([]secret.SecretVersionResponse, error) {
+	if err := s.checkForbiddenTags(organizationID, secretID); err != nil {
+		return nil, err
+	}
+
+	return s.secretStore.ListVersions(organizationID, secretID)
+}
+
+func (s *restrictedSecretStore) GetVersion(organizationID uint, secretID string, version int) (*secret.SecretItemResponse, error) {
+	if err := s.checkForbiddenTags(organizationID, secretID); err != nil {
+		return nil, err
+	}
+
+	return s.secretStore.GetVersion(organizationID, secretID, version)
+}
+
+func (s *restrictedSecretStore) RestoreVersion(organizationID uint, secretID string, version int, updatedBy string) error {
+	if err := s.checkBlockingTags(organizationID, secretID); err != nil {
+		return err
+	}
+
+	return s.secretStore.RestoreVersion(organizationID, secretID, version, updatedBy)
+}
+
 func (s *restrictedSecretStore) checkBlockingTags(organizationID uint, secretID string) error {
 	secretItem, err := s.secretStore.Get(organizationID, secretID)
 	if err != nil {
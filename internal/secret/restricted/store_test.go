@@ -105,6 +105,18 @@ func (ss inMemorySecretStore) Verify(organizationID uint, secretID string) error
 	panic("implement me")
 }
 
+func (ss inMemorySecretStore) ListVersions(organizationID uint, secretID string) ([]secret.SecretVersionResponse, error) {
+	panic("implement me")
+}
+
+func (ss inMemorySecretStore) GetVersion(organizationID uint, secretID string, version int) (*secret.SecretItemResponse, error) {
+	panic("implement me")
+}
+
+func (ss inMemorySecretStore) RestoreVersion(organizationID uint, secretID string, version int, updatedBy string) error {
+	panic("implement me")
+}
+
 func (ss inMemorySecretStore) Delete(orgID uint, secretID string) error {
 	if os, ok := ss.secrets[orgID]; ok {
 		delete(os, secretID)
@@ -0,0 +1,123 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretadapter
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+// KeyProvider encrypts and decrypts the data keys protecting the secrets stored in the database (envelope encryption).
+//
+// Implementations can keep the key encryption key locally or delegate to a key management service.
+type KeyProvider interface {
+	// EncryptDataKey encrypts a data key.
+	EncryptDataKey(ctx context.Context, dataKey []byte) ([]byte, error)
+
+	// DecryptDataKey decrypts a data key encrypted by EncryptDataKey.
+	DecryptDataKey(ctx context.Context, encryptedDataKey []byte) ([]byte, error)
+}
+
+// keySize is the size of the AES-256 keys used for encryption.
+const keySize = 32
+
+// NewLocalKeyProviderFromFile returns a new KeyProvider using a base64 encoded, 32 byte key read from a file.
+func NewLocalKeyProviderFromFile(path string) (KeyProvider, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to read secret encryption key file", "path", path)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to decode secret encryption key", "path", path)
+	}
+
+	return NewLocalKeyProvider(key)
+}
+
+// NewLocalKeyProvider returns a new KeyProvider encrypting data keys with a local AES-256 key.
+func NewLocalKeyProvider(key []byte) (KeyProvider, error) {
+	if len(key) != keySize {
+		return nil, errors.Errorf("secret encryption key must be %d bytes long", keySize)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return localKeyProvider{aead: aead}, nil
+}
+
+type localKeyProvider struct {
+	aead cipher.AEAD
+}
+
+func (p localKeyProvider) EncryptDataKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	return seal(p.aead, dataKey, nil)
+}
+
+func (p localKeyProvider) DecryptDataKey(_ context.Context, encryptedDataKey []byte) ([]byte, error) {
+	return open(p.aead, encryptedDataKey, nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create cipher")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create cipher")
+	}
+
+	return aead, nil
+}
+
+// seal encrypts and authenticates plaintext and returns the nonce followed by the ciphertext.
+func seal(aead cipher.AEAD, plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.WrapIf(err, "failed to generate nonce")
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts and authenticates a ciphertext created by seal.
+func open(aead cipher.AEAD, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decrypt data")
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,73 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretadapter
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalKeyProvider(t *testing.T) {
+	keys, err := NewLocalKeyProvider([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	dataKey := []byte("fedcba9876543210fedcba9876543210")
+
+	encrypted, err := keys.EncryptDataKey(context.Background(), dataKey)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(encrypted), string(dataKey))
+
+	decrypted, err := keys.DecryptDataKey(context.Background(), encrypted)
+	require.NoError(t, err)
+
+	assert.Equal(t, dataKey, decrypted)
+
+	otherKeys, err := NewLocalKeyProvider([]byte("abcdef0123456789abcdef0123456789"))
+	require.NoError(t, err)
+
+	_, err = otherKeys.DecryptDataKey(context.Background(), encrypted)
+	assert.Error(t, err)
+}
+
+func TestNewLocalKeyProvider_InvalidKey(t *testing.T) {
+	_, err := NewLocalKeyProvider([]byte("short"))
+	assert.Error(t, err)
+}
+
+func TestNewLocalKeyProviderFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secretadapter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "key")
+	content := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")) + "\n"
+
+	err = ioutil.WriteFile(path, []byte(content), 0600)
+	require.NoError(t, err)
+
+	_, err = NewLocalKeyProviderFromFile(path)
+	require.NoError(t, err)
+
+	_, err = NewLocalKeyProviderFromFile(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,450 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretadapter
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/platform/database"
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+// encryptedSecretModel is a single version of a secret stored in the database.
+type encryptedSecretModel struct {
+	ID               uint `gorm:"primary_key"`
+	CreatedAt        time.Time
+	OrganizationID   uint   `gorm:"unique_index:idx_encrypted_secrets_org_secret_version;not null"`
+	SecretID         string `gorm:"unique_index:idx_encrypted_secrets_org_secret_version;size:255;not null"`
+	Version          int    `gorm:"unique_index:idx_encrypted_secrets_org_secret_version;not null"`
+	EncryptedDataKey []byte `gorm:"not null"`
+	Ciphertext       []byte `gorm:"not null"`
+}
+
+// TableName changes the default table name.
+func (encryptedSecretModel) TableName() string {
+	return "encrypted_secrets"
+}
+
+// encryptedSecretPayload is the encrypted content of a secret version.
+type encryptedSecretPayload struct {
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	Values    map[string]string `json:"values"`
+	Tags      []string          `json:"tags"`
+	UpdatedBy string            `json:"updatedBy"`
+}
+
+// MigrateDatabaseStore executes the table migrations for the database secret store.
+func MigrateDatabaseStore(db *gorm.DB, logger common.Logger) error {
+	tables := []interface{}{
+		&encryptedSecretModel{},
+	}
+
+	var tableNames string
+	for _, table := range tables {
+		tableNames += fmt.Sprintf(" %s", db.NewScope(table).TableName())
+	}
+
+	logger.Info("migrating model tables", map[string]interface{}{"table_names": strings.TrimSpace(tableNames)})
+
+	return db.AutoMigrate(tables...).Error
+}
+
+// NewDatabaseStore returns a new secret store backed by the Pipeline database.
+//
+// Every secret version is encrypted with a unique data key which is encrypted by the key provider.
+func NewDatabaseStore(db *gorm.DB, keys KeyProvider) secret.Store {
+	return databaseStore{
+		db:   db,
+		keys: keys,
+	}
+}
+
+// maxPutAttempts is the number of times a secret update is attempted when racing with concurrent updates.
+const maxPutAttempts = 3
+
+type databaseStore struct {
+	db   *gorm.DB
+	keys KeyProvider
+}
+
+func (s databaseStore) Create(ctx context.Context, organizationID uint, model secret.Model) error {
+	err := s.transaction(func(tx *gorm.DB) error {
+		version, err := latestVersion(tx, organizationID, model.ID)
+		if err != nil {
+			return err
+		}
+
+		if version > 0 {
+			return errors.WithStack(secret.AlreadyExistsError{
+				OrganizationID: organizationID,
+				SecretID:       model.ID,
+			})
+		}
+
+		return s.write(ctx, tx, organizationID, 1, time.Time{}, model)
+	})
+	if database.IsUniqueConstraintError(err) {
+		// The secret was created concurrently
+		return errors.WithStack(secret.AlreadyExistsError{
+			OrganizationID: organizationID,
+			SecretID:       model.ID,
+		})
+	}
+
+	return err
+}
+
+func (s databaseStore) Put(ctx context.Context, organizationID uint, model secret.Model) error {
+	var err error
+
+	// Concurrent writes of the same secret are retried with the next available version
+	for attempt := 0; attempt < maxPutAttempts; attempt++ {
+		err = s.transaction(func(tx *gorm.DB) error {
+			version, err := latestVersion(tx, organizationID, model.ID)
+			if err != nil {
+				return err
+			}
+
+			return s.write(ctx, tx, organizationID, version+1, time.Time{}, model)
+		})
+		if !database.IsUniqueConstraintError(err) {
+			return err
+		}
+	}
+
+	return errors.WrapWithDetails(
+		err, "failed to store secret: too many concurrent updates",
+		"organizationId", organizationID,
+		"secretId", model.ID,
+	)
+}
+
+// ImportVersions writes every version of a new secret keeping their version numbers and timestamps.
+func (s databaseStore) ImportVersions(ctx context.Context, organizationID uint, versions []secret.Model) error {
+	if len(versions) == 0 {
+		return nil
+	}
+
+	id := versions[0].ID
+
+	err := s.transaction(func(tx *gorm.DB) error {
+		version, err := latestVersion(tx, organizationID, id)
+		if err != nil {
+			return err
+		}
+
+		if version > 0 {
+			return errors.WithStack(secret.AlreadyExistsError{
+				OrganizationID: organizationID,
+				SecretID:       id,
+			})
+		}
+
+		for _, model := range versions {
+			if model.ID != id {
+				return errors.NewWithDetails("cannot import versions of different secrets", "secretId", id, "otherSecretId", model.ID)
+			}
+
+			if model.Version < 1 {
+				return errors.NewWithDetails("invalid secret version", "secretId", id, "version", model.Version)
+			}
+
+			if err := s.write(ctx, tx, organizationID, model.Version, model.UpdatedAt, model); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if database.IsUniqueConstraintError(err) {
+		return errors.WithStack(secret.AlreadyExistsError{
+			OrganizationID: organizationID,
+			SecretID:       id,
+		})
+	}
+
+	return err
+}
+
+func (s databaseStore) Get(ctx context.Context, organizationID uint, id string) (secret.Model, error) {
+	var record encryptedSecretModel
+
+	err := s.db.
+		Where("organization_id = ? AND secret_id = ?", organizationID, id).
+		Order("version DESC").
+		First(&record).
+		Error
+	if gorm.IsRecordNotFoundError(err) {
+		return secret.Model{}, errors.WithStack(secret.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       id,
+		})
+	} else if err != nil {
+		return secret.Model{}, errors.Wrap(err, "failed to read secret")
+	}
+
+	return s.decrypt(ctx, record)
+}
+
+func (s databaseStore) List(ctx context.Context, organizationID uint) ([]secret.Model, error) {
+	var records []encryptedSecretModel
+
+	err := s.db.
+		Where("organization_id = ?", organizationID).
+		Where(
+			"version = (SELECT MAX(v.version) FROM encrypted_secrets v WHERE v.organization_id = encrypted_secrets.organization_id AND v.secret_id = encrypted_secrets.secret_id)",
+		).
+		Order("secret_id").
+		Find(&records).
+		Error
+	if err != nil {
+		return nil, errors.WrapWithDetails(err, "failed to list secrets", "organizationId", organizationID)
+	}
+
+	models := make([]secret.Model, 0, len(records))
+
+	for _, record := range records {
+		model, err := s.decrypt(ctx, record)
+		if err != nil {
+			return nil, errors.WithDetails(
+				err,
+				"organizationId", organizationID,
+				"secretId", record.SecretID,
+			)
+		}
+
+		models = append(models, model)
+	}
+
+	return models, nil
+}
+
+func (s databaseStore) Delete(_ context.Context, organizationID uint, id string) error {
+	err := s.db.
+		Where("organization_id = ? AND secret_id = ?", organizationID, id).
+		Delete(&encryptedSecretModel{}).
+		Error
+	if err != nil {
+		return errors.WrapWithDetails(
+			err, "failed to delete secret",
+			"organizationId", organizationID,
+			"secretId", id,
+		)
+	}
+
+	return nil
+}
+
+func (s databaseStore) ListVersions(ctx context.Context, organizationID uint, id string) ([]secret.Model, error) {
+	var records []encryptedSecretModel
+
+	err := s.db.
+		Where("organization_id = ? AND secret_id = ?", organizationID, id).
+		Order("version").
+		Find(&records).
+		Error
+	if err != nil {
+		return nil, errors.WrapWithDetails(
+			err, "failed to list secret versions",
+			"organizationId", organizationID,
+			"secretId", id,
+		)
+	}
+
+	if len(records) == 0 {
+		return nil, errors.WithStack(secret.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       id,
+		})
+	}
+
+	models := make([]secret.Model, 0, len(records))
+
+	for _, record := range records {
+		model, err := s.decrypt(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, model)
+	}
+
+	return models, nil
+}
+
+func (s databaseStore) GetVersion(ctx context.Context, organizationID uint, id string, version int) (secret.Model, error) {
+	var record encryptedSecretModel
+
+	err := s.db.
+		Where("organization_id = ? AND secret_id = ? AND version = ?", organizationID, id, version).
+		First(&record).
+		Error
+	if gorm.IsRecordNotFoundError(err) {
+		return secret.Model{}, errors.WithStack(secret.VersionNotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       id,
+			Version:        version,
+		})
+	} else if err != nil {
+		return secret.Model{}, errors.WrapWithDetails(err, "failed to read secret version", "version", version)
+	}
+
+	return s.decrypt(ctx, record)
+}
+
+func (s databaseStore) transaction(fn func(tx *gorm.DB) error) error {
+	tx := s.db.Begin()
+	if err := tx.Error; err != nil {
+		return errors.WrapIf(err, "failed to begin transaction")
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	return errors.WrapIf(tx.Commit().Error, "failed to commit transaction")
+}
+
+// latestVersion returns the latest version of a secret or 0 if the secret does not exist.
+func latestVersion(db *gorm.DB, organizationID uint, id string) (int, error) {
+	var record encryptedSecretModel
+
+	err := db.
+		Select("version").
+		Where("organization_id = ? AND secret_id = ?", organizationID, id).
+		Order("version DESC").
+		First(&record).
+		Error
+	if gorm.IsRecordNotFoundError(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Wrap(err, "failed to check if secret exists")
+	}
+
+	return record.Version, nil
+}
+
+// write stores a secret version. If createdAt is zero, the current time is used.
+func (s databaseStore) write(ctx context.Context, db *gorm.DB, organizationID uint, version int, createdAt time.Time, model secret.Model) error {
+	sort.Strings(model.Tags)
+
+	payload, err := json.Marshal(encryptedSecretPayload{
+		Name:      model.Name,
+		Type:      model.Type,
+		Values:    model.Values,
+		Tags:      model.Tags,
+		UpdatedBy: model.UpdatedBy,
+	})
+	if err != nil {
+		return errors.WrapWithDetails(
+			err, "failed to encode secret",
+			"secretId", model.ID,
+		)
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return errors.Wrap(err, "failed to generate data key")
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := seal(aead, payload, additionalData(organizationID, model.ID, version))
+	if err != nil {
+		return errors.WithDetails(err, "secretId", model.ID)
+	}
+
+	encryptedDataKey, err := s.keys.EncryptDataKey(ctx, dataKey)
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to encrypt data key", "secretId", model.ID)
+	}
+
+	record := encryptedSecretModel{
+		CreatedAt:        createdAt,
+		OrganizationID:   organizationID,
+		SecretID:         model.ID,
+		Version:          version,
+		EncryptedDataKey: encryptedDataKey,
+		Ciphertext:       ciphertext,
+	}
+
+	if err := db.Create(&record).Error; err != nil {
+		return errors.Wrap(err, "failed to store secret")
+	}
+
+	return nil
+}
+
+func (s databaseStore) decrypt(ctx context.Context, record encryptedSecretModel) (secret.Model, error) {
+	dataKey, err := s.keys.DecryptDataKey(ctx, record.EncryptedDataKey)
+	if err != nil {
+		return secret.Model{}, errors.WrapWithDetails(err, "failed to decrypt data key", "secretId", record.SecretID)
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return secret.Model{}, err
+	}
+
+	plaintext, err := open(aead, record.Ciphertext, additionalData(record.OrganizationID, record.SecretID, record.Version))
+	if err != nil {
+		return secret.Model{}, errors.WithDetails(err, "secretId", record.SecretID)
+	}
+
+	var payload encryptedSecretPayload
+	if err := json.Unmarshal(plaintext, &payload); err != nil {
+		return secret.Model{}, errors.Wrap(err, "failed to parse secret")
+	}
+
+	model := secret.Model{
+		ID:        record.SecretID,
+		Name:      payload.Name,
+		Type:      payload.Type,
+		Values:    payload.Values,
+		Tags:      payload.Tags,
+		Version:   record.Version,
+		UpdatedAt: record.CreatedAt,
+		UpdatedBy: payload.UpdatedBy,
+	}
+
+	if model.Tags == nil {
+		model.Tags = []string{}
+	}
+
+	return model, nil
+}
+
+// additionalData binds a ciphertext to the secret version it belongs to,
+// so that encrypted values cannot be moved between secrets in the database.
+func additionalData(organizationID uint, secretID string, version int) []byte {
+	return []byte(fmt.Sprintf("orgs/%d/%s/%d", organizationID, secretID, version))
+}
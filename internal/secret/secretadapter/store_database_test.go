@@ -0,0 +1,347 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretadapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+func TestDatabaseStore(t *testing.T) {
+	suite.Run(t, new(DatabaseStoreTestSuite))
+}
+
+type DatabaseStoreTestSuite struct {
+	suite.Suite
+
+	db *gorm.DB
+
+	store secret.Store
+}
+
+func (s *DatabaseStoreTestSuite) SetupTest() {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	s.Require().NoError(err)
+
+	err = MigrateDatabaseStore(db, common.NoopLogger{})
+	s.Require().NoError(err)
+
+	keys, err := NewLocalKeyProvider([]byte("0123456789abcdef0123456789abcdef"))
+	s.Require().NoError(err)
+
+	s.db = db
+	s.store = NewDatabaseStore(db, keys)
+}
+
+func (s *DatabaseStoreTestSuite) TearDownTest() {
+	s.Require().NoError(s.db.Close())
+}
+
+func (s *DatabaseStoreTestSuite) TestCreate() {
+	model := secret.Model{
+		ID:   "created-secret-id",
+		Name: "created-secret-name",
+		Type: "example",
+		Values: map[string]string{
+			"key": "value",
+		},
+		Tags:      []string{"tag:value"},
+		UpdatedBy: "user",
+	}
+
+	err := s.store.Create(context.Background(), 1, model)
+	s.Require().NoError(err)
+
+	var record encryptedSecretModel
+	err = s.db.Where("organization_id = ? AND secret_id = ?", 1, model.ID).First(&record).Error
+	s.Require().NoError(err)
+
+	s.Assert().Equal(1, record.Version)
+	s.Assert().NotContains(string(record.Ciphertext), "value")
+
+	actual, err := s.store.Get(context.Background(), 1, model.ID)
+	s.Require().NoError(err)
+
+	model.Version = 1
+	model.UpdatedAt = actual.UpdatedAt
+
+	s.Assert().Equal(model, actual)
+}
+
+func (s *DatabaseStoreTestSuite) TestCreate_AlreadyExists() {
+	model := secret.Model{
+		ID:     "already-existing-secret-id",
+		Name:   "already-existing-secret-name",
+		Type:   "example",
+		Values: map[string]string{"key": "value"},
+	}
+
+	err := s.store.Create(context.Background(), 1, model)
+	s.Require().NoError(err)
+
+	err = s.store.Create(context.Background(), 1, model)
+	s.Require().Error(err)
+
+	var alreadyExistsErr secret.AlreadyExistsError
+	if s.Assert().True(errors.As(err, &alreadyExistsErr)) {
+		s.Assert().Equal(model.ID, alreadyExistsErr.SecretID)
+	}
+}
+
+func (s *DatabaseStoreTestSuite) TestPut() {
+	model := secret.Model{
+		ID:        "updated-secret-id",
+		Name:      "updated-secret-name",
+		Type:      "example",
+		Values:    map[string]string{"key": "value"},
+		Tags:      []string{"tag:value"},
+		UpdatedBy: "user",
+	}
+
+	err := s.store.Put(context.Background(), 1, model)
+	s.Require().NoError(err)
+
+	model.Values = map[string]string{"key": "new-value"}
+	model.Tags = []string{"tag:b", "tag:a"}
+
+	err = s.store.Put(context.Background(), 1, model)
+	s.Require().NoError(err)
+
+	actual, err := s.store.Get(context.Background(), 1, model.ID)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(2, actual.Version)
+	s.Assert().Equal(map[string]string{"key": "new-value"}, actual.Values)
+	s.Assert().Equal([]string{"tag:a", "tag:b"}, actual.Tags)
+}
+
+func (s *DatabaseStoreTestSuite) TestGet_NotFound() {
+	_, err := s.store.Get(context.Background(), 1, "not-found-secret-id")
+	s.Require().Error(err)
+
+	var notFoundErr secret.NotFoundError
+	if s.Assert().True(errors.As(err, &notFoundErr)) {
+		s.Assert().Equal(uint(1), notFoundErr.OrganizationID)
+		s.Assert().Equal("not-found-secret-id", notFoundErr.SecretID)
+	}
+}
+
+func (s *DatabaseStoreTestSuite) TestList() {
+	for _, id := range []string{"secret-b", "secret-a"} {
+		err := s.store.Put(context.Background(), 1, secret.Model{ID: id, Name: id, Type: "example"})
+		s.Require().NoError(err)
+	}
+
+	err := s.store.Put(context.Background(), 1, secret.Model{ID: "secret-a", Name: "secret-a", Type: "example", UpdatedBy: "user"})
+	s.Require().NoError(err)
+
+	err = s.store.Put(context.Background(), 2, secret.Model{ID: "secret-c", Name: "secret-c", Type: "example"})
+	s.Require().NoError(err)
+
+	models, err := s.store.List(context.Background(), 1)
+	s.Require().NoError(err)
+	s.Require().Len(models, 2)
+
+	s.Assert().Equal("secret-a", models[0].ID)
+	s.Assert().Equal(2, models[0].Version)
+	s.Assert().Equal("user", models[0].UpdatedBy)
+	s.Assert().Equal("secret-b", models[1].ID)
+	s.Assert().Equal([]string{}, models[1].Tags)
+}
+
+func (s *DatabaseStoreTestSuite) TestListVersions() {
+	for _, value := range []string{"first", "second"} {
+		err := s.store.Put(context.Background(), 1, secret.Model{
+			ID:     "versioned-secret-id",
+			Name:   "versioned-secret-name",
+			Type:   "example",
+			Values: map[string]string{"key": value},
+		})
+		s.Require().NoError(err)
+	}
+
+	models, err := s.store.ListVersions(context.Background(), 1, "versioned-secret-id")
+	s.Require().NoError(err)
+	s.Require().Len(models, 2)
+
+	s.Assert().Equal(1, models[0].Version)
+	s.Assert().Equal("first", models[0].Values["key"])
+	s.Assert().Equal(2, models[1].Version)
+	s.Assert().Equal("second", models[1].Values["key"])
+
+	model, err := s.store.GetVersion(context.Background(), 1, "versioned-secret-id", 1)
+	s.Require().NoError(err)
+
+	s.Assert().Equal("first", model.Values["key"])
+}
+
+func (s *DatabaseStoreTestSuite) TestListVersions_NotFound() {
+	_, err := s.store.ListVersions(context.Background(), 1, "not-found-secret-id")
+	s.Require().Error(err)
+
+	var notFoundErr secret.NotFoundError
+	if s.Assert().True(errors.As(err, &notFoundErr)) {
+		s.Assert().Equal(uint(1), notFoundErr.OrganizationID)
+		s.Assert().Equal("not-found-secret-id", notFoundErr.SecretID)
+	}
+}
+
+func (s *DatabaseStoreTestSuite) TestGetVersion_NotFound() {
+	err := s.store.Put(context.Background(), 1, secret.Model{ID: "versioned-secret-id", Name: "versioned-secret-name", Type: "example"})
+	s.Require().NoError(err)
+
+	_, err = s.store.GetVersion(context.Background(), 1, "versioned-secret-id", 2)
+	s.Require().Error(err)
+
+	var versionNotFoundErr secret.VersionNotFoundError
+	if s.Assert().True(errors.As(err, &versionNotFoundErr)) {
+		s.Assert().Equal("versioned-secret-id", versionNotFoundErr.SecretID)
+		s.Assert().Equal(2, versionNotFoundErr.Version)
+	}
+}
+
+func (s *DatabaseStoreTestSuite) TestImportVersions() {
+	created := time.Date(2020, time.January, 1, 10, 0, 0, 0, time.UTC)
+	updated := time.Date(2020, time.February, 1, 10, 0, 0, 0, time.UTC)
+
+	err := s.store.(secret.VersionImporter).ImportVersions(context.Background(), 1, []secret.Model{
+		{
+			ID:        "imported-secret-id",
+			Name:      "imported-secret-name",
+			Type:      "example",
+			Values:    map[string]string{"key": "first"},
+			Version:   2,
+			UpdatedAt: created,
+		},
+		{
+			ID:        "imported-secret-id",
+			Name:      "imported-secret-name",
+			Type:      "example",
+			Values:    map[string]string{"key": "second"},
+			Version:   5,
+			UpdatedAt: updated,
+		},
+	})
+	s.Require().NoError(err)
+
+	models, err := s.store.ListVersions(context.Background(), 1, "imported-secret-id")
+	s.Require().NoError(err)
+	s.Require().Len(models, 2)
+
+	s.Assert().Equal(2, models[0].Version)
+	s.Assert().Equal("first", models[0].Values["key"])
+	s.Assert().True(created.Equal(models[0].UpdatedAt))
+	s.Assert().Equal(5, models[1].Version)
+	s.Assert().Equal("second", models[1].Values["key"])
+	s.Assert().True(updated.Equal(models[1].UpdatedAt))
+
+	err = s.store.Put(context.Background(), 1, secret.Model{ID: "imported-secret-id", Name: "imported-secret-name", Type: "example"})
+	s.Require().NoError(err)
+
+	model, err := s.store.Get(context.Background(), 1, "imported-secret-id")
+	s.Require().NoError(err)
+
+	s.Assert().Equal(6, model.Version)
+}
+
+func (s *DatabaseStoreTestSuite) TestImportVersions_AlreadyExists() {
+	model := secret.Model{
+		ID:     "already-existing-secret-id",
+		Name:   "already-existing-secret-name",
+		Type:   "example",
+		Values: map[string]string{"key": "value"},
+	}
+
+	err := s.store.Create(context.Background(), 1, model)
+	s.Require().NoError(err)
+
+	model.Version = 3
+
+	err = s.store.(secret.VersionImporter).ImportVersions(context.Background(), 1, []secret.Model{model})
+	s.Require().Error(err)
+
+	var alreadyExistsErr secret.AlreadyExistsError
+	if s.Assert().True(errors.As(err, &alreadyExistsErr)) {
+		s.Assert().Equal(model.ID, alreadyExistsErr.SecretID)
+	}
+
+	models, err := s.store.ListVersions(context.Background(), 1, model.ID)
+	s.Require().NoError(err)
+
+	s.Assert().Len(models, 1)
+}
+
+func (s *DatabaseStoreTestSuite) TestImportVersions_Rollback() {
+	model := secret.Model{
+		ID:      "rolled-back-secret-id",
+		Name:    "rolled-back-secret-name",
+		Type:    "example",
+		Version: 1,
+	}
+
+	// The duplicate version violates the unique index, so none of the versions are stored
+	err := s.store.(secret.VersionImporter).ImportVersions(context.Background(), 1, []secret.Model{model, model})
+	s.Require().Error(err)
+
+	var alreadyExistsErr secret.AlreadyExistsError
+	s.Assert().True(errors.As(err, &alreadyExistsErr))
+
+	_, err = s.store.Get(context.Background(), 1, model.ID)
+
+	var notFoundErr secret.NotFoundError
+	s.Assert().True(errors.As(err, &notFoundErr))
+}
+
+func (s *DatabaseStoreTestSuite) TestDelete() {
+	for i := 0; i < 2; i++ {
+		err := s.store.Put(context.Background(), 1, secret.Model{ID: "deleted-secret-id", Name: "deleted-secret-name", Type: "example"})
+		s.Require().NoError(err)
+	}
+
+	err := s.store.Delete(context.Background(), 1, "deleted-secret-id")
+	s.Require().NoError(err)
+
+	var count int
+	err = s.db.Model(&encryptedSecretModel{}).Where("secret_id = ?", "deleted-secret-id").Count(&count).Error
+	s.Require().NoError(err)
+
+	s.Assert().Equal(0, count)
+}
+
+func (s *DatabaseStoreTestSuite) TestDelete_Idempotent() {
+	err := s.store.Delete(context.Background(), 1, "not-found-secret-id")
+	s.Require().NoError(err)
+}
+
+func (s *DatabaseStoreTestSuite) TestTamperedCiphertext() {
+	err := s.store.Put(context.Background(), 1, secret.Model{ID: "secret-a", Name: "secret-a", Type: "example"})
+	s.Require().NoError(err)
+
+	// Moving an encrypted value to another secret must be detected
+	err = s.db.Model(&encryptedSecretModel{}).Where("secret_id = ?", "secret-a").Update("secret_id", "secret-b").Error
+	s.Require().NoError(err)
+
+	_, err = s.store.Get(context.Background(), 1, "secret-b")
+	s.Require().Error(err)
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -83,10 +84,7 @@ func (s vaultStore) Put(_ context.Context, organizationID uint, model secret.Mod
 		}
 
 		if vaultSecret != nil {
-			metadata := cast.ToStringMap(vaultSecret.Data["metadata"])
-			v, _ := metadata["version"].(json.Number).Int64()
-
-			version = int(v)
+			version = metadataVersion(cast.ToStringMap(vaultSecret.Data["metadata"]))
 		}
 	}
 
@@ -179,6 +177,103 @@ func (s vaultStore) Delete(_ context.Context, organizationID uint, id string) er
 	return nil
 }
 
+// maxListedSecretVersions caps the number of versions returned by ListVersions.
+// It matches the default max_versions setting of the KV secrets engine.
+const maxListedSecretVersions = 10
+
+// ListVersions lists the newest (at most maxListedSecretVersions) live versions of a secret.
+//
+// Vault only stores the updater in the version data (the KV engine in use has no per-version custom metadata),
+// so listing costs one metadata read plus one data read per returned version.
+func (s vaultStore) ListVersions(ctx context.Context, organizationID uint, id string) ([]secret.Model, error) {
+	path := fmt.Sprintf("%s/metadata/orgs/%d/%s", s.mountPath, organizationID, id)
+
+	vaultMetadata, err := s.client.RawClient().Logical().Read(path)
+	if err != nil {
+		return nil, errors.WrapWithDetails(
+			err, "failed to read secret metadata",
+			"organizationId", organizationID,
+			"secretId", id,
+		)
+	}
+
+	if vaultMetadata == nil {
+		return nil, errors.WithStack(secret.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       id,
+		})
+	}
+
+	var versions []int
+	for key, versionMetadata := range cast.ToStringMap(vaultMetadata.Data["versions"]) {
+		versionMetadata := cast.ToStringMap(versionMetadata)
+
+		// Deleted and destroyed versions cannot be read or restored
+		if deletionTime, _ := versionMetadata["deletion_time"].(string); deletionTime != "" || cast.ToBool(versionMetadata["destroyed"]) {
+			continue
+		}
+
+		version, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, errors.WrapWithDetails(err, "invalid secret version", "secretId", id, "version", key)
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	if len(versions) > maxListedSecretVersions {
+		versions = versions[:maxListedSecretVersions]
+	}
+
+	models := make([]secret.Model, 0, len(versions))
+	for _, version := range versions {
+		model, err := s.GetVersion(ctx, organizationID, id, version)
+		if errors.As(err, &secret.VersionNotFoundError{}) { // Version was removed?
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		// Version content is only returned by GetVersion
+		models = append(models, secret.Model{
+			ID:        id,
+			Version:   model.Version,
+			UpdatedAt: model.UpdatedAt,
+			UpdatedBy: model.UpdatedBy,
+		})
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].Version < models[j].Version
+	})
+
+	return models, nil
+}
+
+func (s vaultStore) GetVersion(_ context.Context, organizationID uint, id string, version int) (secret.Model, error) {
+	path := s.secretDataPath(organizationID, id)
+
+	vaultSecret, err := s.client.RawClient().Logical().ReadWithData(path, map[string][]string{
+		"version": {strconv.Itoa(version)},
+	})
+	if err != nil {
+		return secret.Model{}, errors.WrapWithDetails(err, "failed to read secret version", "version", version)
+	}
+
+	// Deleted versions are returned without data
+	if vaultSecret == nil || vaultSecret.Data["data"] == nil {
+		return secret.Model{}, errors.WithStack(secret.VersionNotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       id,
+			Version:        version,
+		})
+	}
+
+	return parseSecret(id, vaultSecret)
+}
+
 func (s vaultStore) secretDataPath(organizationID uint, secretID string) string {
 	return fmt.Sprintf("%s/data/orgs/%d/%s", s.mountPath, organizationID, secretID)
 }
@@ -207,6 +302,7 @@ func parseSecret(id string, vaultSecret *vaultapi.Secret) (secret.Model, error)
 
 	model := secret.Model{
 		ID:        id,
+		Version:   metadataVersion(metadata),
 		UpdatedAt: updatedAt,
 		Tags:      []string{},
 	}
@@ -217,3 +313,10 @@ func parseSecret(id string, vaultSecret *vaultapi.Secret) (secret.Model, error)
 
 	return model, nil
 }
+
+func metadataVersion(metadata map[string]interface{}) int {
+	number, _ := metadata["version"].(json.Number)
+	version, _ := number.Int64()
+
+	return int(version)
+}
@@ -237,6 +237,7 @@ func (s *VaultStoreTestSuite) TestGet() {
 			"key": "value",
 		},
 		Tags:      []string{"tag:value"},
+		Version:   1,
 		UpdatedBy: "user",
 	}
 
@@ -284,6 +285,7 @@ func (s *VaultStoreTestSuite) TestList() {
 				"key": "value",
 			},
 			Tags:      []string{"tag:value"},
+			Version:   1,
 			UpdatedBy: "user",
 		},
 	}
@@ -297,6 +299,101 @@ func (s *VaultStoreTestSuite) TestList() {
 	s.Assert().Equal(expected, actual)
 }
 
+func (s *VaultStoreTestSuite) TestListVersions() {
+	for i, updatedBy := range []string{"user", "other-user"} {
+		_, err := s.client.RawClient().Logical().Write(
+			fmt.Sprintf("%s/data/orgs/1/versioned-secret-id", s.mountPath),
+			vault.NewData(i, map[string]interface{}{
+				"value": map[string]interface{}{
+					"name":      "versioned-secret-name",
+					"type":      "example",
+					"values":    map[string]interface{}{"key": fmt.Sprintf("value%d", i+1)},
+					"tags":      []interface{}{"tag:value"},
+					"updatedBy": updatedBy,
+				},
+			}),
+		)
+		s.Require().NoError(err)
+	}
+
+	versions, err := s.store.ListVersions(context.Background(), 1, "versioned-secret-id")
+	s.Require().NoError(err)
+
+	s.Require().Len(versions, 2)
+	s.Assert().Equal(1, versions[0].Version)
+	s.Assert().Equal("user", versions[0].UpdatedBy)
+	s.Assert().False(versions[0].UpdatedAt.IsZero())
+	s.Assert().Equal(2, versions[1].Version)
+	s.Assert().Equal("other-user", versions[1].UpdatedBy)
+	s.Assert().False(versions[1].UpdatedAt.IsZero())
+
+	model, err := s.store.GetVersion(context.Background(), 1, "versioned-secret-id", 1)
+	s.Require().NoError(err)
+
+	s.Assert().Equal(1, model.Version)
+	s.Assert().Equal("user", model.UpdatedBy)
+	s.Assert().Equal(map[string]string{"key": "value1"}, model.Values)
+	s.Assert().Equal(versions[0].UpdatedAt, model.UpdatedAt)
+}
+
+func (s *VaultStoreTestSuite) TestListVersions_Limit() {
+	for i := 0; i < maxListedSecretVersions+2; i++ {
+		_, err := s.client.RawClient().Logical().Write(
+			fmt.Sprintf("%s/data/orgs/1/many-versions-secret-id", s.mountPath),
+			vault.NewData(i, map[string]interface{}{
+				"value": map[string]interface{}{
+					"name":      "many-versions-secret-name",
+					"type":      "example",
+					"values":    map[string]interface{}{"key": fmt.Sprintf("value%d", i+1)},
+					"tags":      []interface{}{"tag:value"},
+					"updatedBy": "user",
+				},
+			}),
+		)
+		s.Require().NoError(err)
+	}
+
+	versions, err := s.store.ListVersions(context.Background(), 1, "many-versions-secret-id")
+	s.Require().NoError(err)
+
+	s.Require().Len(versions, maxListedSecretVersions)
+	s.Assert().Equal(3, versions[0].Version)
+	s.Assert().Equal(maxListedSecretVersions+2, versions[len(versions)-1].Version)
+}
+
+func (s *VaultStoreTestSuite) TestListVersions_NotFound() {
+	_, err := s.store.ListVersions(context.Background(), 1, "not-found-secret-id")
+	s.Require().Error(err)
+
+	var notFoundErr secret.NotFoundError
+	s.Assert().True(errors.As(err, &notFoundErr))
+}
+
+func (s *VaultStoreTestSuite) TestGetVersion_NotFound() {
+	_, err := s.client.RawClient().Logical().Write(
+		fmt.Sprintf("%s/data/orgs/1/single-version-secret-id", s.mountPath),
+		vault.NewData(0, map[string]interface{}{
+			"value": map[string]interface{}{
+				"name":      "single-version-secret-name",
+				"type":      "example",
+				"values":    map[string]interface{}{"key": "value"},
+				"tags":      []interface{}{"tag:value"},
+				"updatedBy": "user",
+			},
+		}),
+	)
+	s.Require().NoError(err)
+
+	_, err = s.store.GetVersion(context.Background(), 1, "single-version-secret-id", 2)
+	s.Require().Error(err)
+
+	var versionNotFoundErr secret.VersionNotFoundError
+	if s.Assert().True(errors.As(err, &versionNotFoundErr)) {
+		s.Assert().Equal("single-version-secret-id", versionNotFoundErr.SecretID)
+		s.Assert().Equal(2, versionNotFoundErr.Version)
+	}
+}
+
 func (s *VaultStoreTestSuite) TestDelete() {
 	_, err := s.client.RawClient().Logical().Write(
 		fmt.Sprintf("%s/data/orgs/1/delete-secret-id", s.mountPath),
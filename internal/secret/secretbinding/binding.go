@@ -0,0 +1,201 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretbinding
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+// Secret binding sync statuses
+const (
+	StatusPending = "PENDING"
+	StatusSynced  = "SYNCED"
+	StatusFailed  = "FAILED"
+)
+
+// Binding maps a Pipeline secret (or the secrets matching a tag query) to Kubernetes secrets in one or more clusters.
+type Binding struct {
+	ID             uint
+	OrganizationID uint
+
+	Spec   Spec
+	Status []ClusterStatus
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Spec describes which secrets are synced into which clusters.
+type Spec struct {
+	// SecretID selects a single secret.
+	SecretID string `json:"secretId,omitempty"`
+
+	// SecretTags selects every secret having all of the tags.
+	SecretTags []string `json:"secretTags,omitempty"`
+
+	// ClusterIDs are the clusters the secrets are synced into.
+	ClusterIDs []uint `json:"clusterIds"`
+
+	// Namespace is the namespace of the Kubernetes secrets.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the Kubernetes secret (defaults to the name of the Pipeline secret).
+	// It can only be set when a single secret is selected.
+	Name string `json:"name,omitempty"`
+
+	// Keys maps Kubernetes secret keys to Pipeline secret value keys.
+	// Every (non-opaque) value is synced when empty.
+	Keys map[string]string `json:"keys,omitempty"`
+}
+
+// Validate semantically validates the binding spec.
+func (s Spec) Validate() error {
+	var violations []string
+
+	if s.SecretID == "" && len(s.SecretTags) == 0 {
+		violations = append(violations, "either secret ID or secret tags must be specified")
+	}
+
+	if s.SecretID != "" && len(s.SecretTags) > 0 {
+		violations = append(violations, "secret ID and secret tags are mutually exclusive")
+	}
+
+	if len(s.ClusterIDs) == 0 {
+		violations = append(violations, "at least one cluster must be specified")
+	}
+
+	if s.Namespace == "" {
+		violations = append(violations, "namespace cannot be empty")
+	} else {
+		for _, msg := range validation.IsDNS1123Label(s.Namespace) {
+			violations = append(violations, "invalid namespace: "+msg)
+		}
+	}
+
+	if s.Name != "" {
+		if s.SecretID == "" {
+			violations = append(violations, "name can only be specified for a single secret")
+		}
+
+		for _, msg := range validation.IsDNS1123Subdomain(s.Name) {
+			violations = append(violations, "invalid name: "+msg)
+		}
+	}
+
+	for key, source := range s.Keys {
+		if key == "" || source == "" {
+			violations = append(violations, "key mappings cannot be empty")
+			break
+		}
+	}
+
+	if len(violations) > 0 {
+		return secret.NewValidationError("invalid secret binding", violations)
+	}
+
+	return nil
+}
+
+// selects tells whether the spec selects a secret.
+func (s Spec) selects(secret Secret) bool {
+	if s.SecretID != "" {
+		return s.SecretID == secret.ID
+	}
+
+	return hasTags(secret.Tags, s.SecretTags)
+}
+
+// ClusterStatus describes the state of a binding in a single cluster.
+type ClusterStatus struct {
+	ClusterID uint           `json:"clusterId"`
+	Status    string         `json:"status"`
+	Message   string         `json:"message,omitempty"`
+	Secrets   []SyncedSecret `json:"secrets,omitempty"`
+	SyncedAt  time.Time      `json:"syncedAt"`
+}
+
+// SyncedSecret describes a Pipeline secret synced into a Kubernetes secret.
+type SyncedSecret struct {
+	SecretID string `json:"secretId"`
+	Version  int    `json:"version"`
+	Name     string `json:"name"`
+}
+
+// synced tells whether a secret has been synced by the binding into any of the clusters.
+func (b Binding) synced(secretID string) bool {
+	for _, status := range b.Status {
+		for _, secret := range status.Secrets {
+			if secret.SecretID == secretID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// clusterStatus returns the status of the binding in a cluster.
+func (b Binding) clusterStatus(clusterID uint) ClusterStatus {
+	for _, status := range b.Status {
+		if status.ClusterID == clusterID {
+			return status
+		}
+	}
+
+	return ClusterStatus{ClusterID: clusterID, Status: StatusPending}
+}
+
+// Secret is a Pipeline secret synced by bindings.
+type Secret struct {
+	ID      string
+	Name    string
+	Type    string
+	Values  map[string]string
+	Tags    []string
+	Version int
+}
+
+// KubeSecret is a Kubernetes secret managed by a binding.
+type KubeSecret struct {
+	BindingID uint
+	Namespace string
+	Name      string
+	Type      string
+	Values    map[string]string
+	Keys      map[string]string
+}
+
+func hasTags(tags []string, required []string) bool {
+	if len(required) == 0 {
+		return false
+	}
+
+	tagSet := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tagSet[tag] = true
+	}
+
+	for _, tag := range required {
+		if !tagSet[tag] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,45 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretbindingadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/platform/cadence"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding/workflow"
+)
+
+// ScheduleCadenceReconcile makes sure the secret binding reconcile cron workflow is running with the specified schedule.
+func ScheduleCadenceReconcile(ctx context.Context, cadenceClient client.Client, schedule string, logger common.Logger) error {
+	options := client.StartWorkflowOptions{
+		ID:                           workflow.ReconcileWorkflowID,
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 30 * time.Minute,
+		CronSchedule:                 schedule,
+	}
+
+	if err := cadence.ScheduleCronWorkflow(ctx, cadenceClient, options, workflow.ReconcileWorkflowName, workflow.ReconcileWorkflowInput{}); err != nil {
+		return errors.WrapIf(err, "failed to schedule secret binding reconcile workflow")
+	}
+
+	logger.Info("secret binding reconcile scheduled", map[string]interface{}{"schedule": schedule})
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretbindingadapter
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding"
+)
+
+type eventBus interface {
+	SubscribeAsync(topic string, fn interface{}, transactional bool) error
+}
+
+const (
+	secretChangedTopic  = "secret_changed"
+	clusterDeletedTopic = "cluster_deleted"
+)
+
+// SubscribeEvents keeps secret bindings in sync with secret changes and cluster deletions.
+func SubscribeEvents(eb eventBus, service secretbinding.Service, logger common.Logger) {
+	eb.SubscribeAsync(secretChangedTopic, func(organizationID uint, secretID string) { // nolint: errcheck
+		if err := service.SyncSecret(context.Background(), organizationID, secretID); err != nil {
+			logger.Error("failed to sync secret bindings", map[string]interface{}{
+				"organizationId": organizationID,
+				"secretId":       secretID,
+				"error":          err.Error(),
+			})
+		}
+	}, false)
+
+	eb.SubscribeAsync(clusterDeletedTopic, func(organizationID uint, clusterName string) { // nolint: errcheck
+		if err := service.PruneClusters(context.Background(), organizationID); err != nil {
+			logger.Error("failed to remove deleted cluster from secret bindings", map[string]interface{}{
+				"organizationId": organizationID,
+				"cluster":        clusterName,
+				"error":          err.Error(),
+			})
+		}
+	}, false)
+}
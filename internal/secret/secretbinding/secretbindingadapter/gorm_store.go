@@ -0,0 +1,189 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretbindingadapter
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding"
+)
+
+// bindingModel describes the secret binding model.
+type bindingModel struct {
+	ID             uint `gorm:"primary_key"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	OrganizationID uint   `gorm:"index:idx_secret_bindings_org"`
+	Spec           string `sql:"type:text;"`
+	Status         string `sql:"type:text;"`
+}
+
+// TableName changes the default table name.
+func (bindingModel) TableName() string {
+	return "secret_bindings"
+}
+
+// GormStore is a gorm based secret binding store.
+type GormStore struct {
+	db     *gorm.DB
+	logger common.Logger
+}
+
+// NewGormStore returns a new GormStore.
+func NewGormStore(db *gorm.DB, logger common.Logger) GormStore {
+	return GormStore{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Create creates a new secret binding.
+func (s GormStore) Create(_ context.Context, organizationID uint, spec secretbinding.Spec) (secretbinding.Binding, error) {
+	rawSpec, err := json.Marshal(spec)
+	if err != nil {
+		return secretbinding.Binding{}, errors.WrapIf(err, "failed to encode secret binding spec")
+	}
+
+	model := bindingModel{
+		OrganizationID: organizationID,
+		Spec:           string(rawSpec),
+		Status:         "[]",
+	}
+
+	if err := s.db.Create(&model).Error; err != nil {
+		return secretbinding.Binding{}, errors.WrapIfWithDetails(err, "failed to create secret binding", "orgID", organizationID)
+	}
+
+	s.logger.Debug("created secret binding record", map[string]interface{}{"organizationID": organizationID, "bindingID": model.ID})
+
+	return fromModel(model)
+}
+
+// Get returns a secret binding.
+func (s GormStore) Get(_ context.Context, organizationID uint, bindingID uint) (secretbinding.Binding, error) {
+	var model bindingModel
+	if err := s.db.Where(&bindingModel{ID: bindingID, OrganizationID: organizationID}).First(&model).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return secretbinding.Binding{}, errors.WithStack(secretbinding.NotFoundError{
+				OrganizationID: organizationID,
+				BindingID:      bindingID,
+			})
+		}
+
+		return secretbinding.Binding{}, errors.WrapIfWithDetails(err, "failed to get secret binding", "orgID", organizationID, "bindingID", bindingID)
+	}
+
+	return fromModel(model)
+}
+
+// List lists the secret bindings of an organization.
+func (s GormStore) List(_ context.Context, organizationID uint) ([]secretbinding.Binding, error) {
+	var models []bindingModel
+	if err := s.db.Where(&bindingModel{OrganizationID: organizationID}).Order("id").Find(&models).Error; err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list secret bindings", "orgID", organizationID)
+	}
+
+	return fromModels(models)
+}
+
+// ListAll lists the secret bindings of every organization.
+func (s GormStore) ListAll(_ context.Context) ([]secretbinding.Binding, error) {
+	var models []bindingModel
+	if err := s.db.Order("id").Find(&models).Error; err != nil {
+		return nil, errors.WrapIf(err, "failed to list secret bindings")
+	}
+
+	return fromModels(models)
+}
+
+// Update updates the spec and the status of a secret binding.
+func (s GormStore) Update(_ context.Context, binding secretbinding.Binding) error {
+	rawSpec, err := json.Marshal(binding.Spec)
+	if err != nil {
+		return errors.WrapIf(err, "failed to encode secret binding spec")
+	}
+
+	status := binding.Status
+	if status == nil {
+		status = []secretbinding.ClusterStatus{}
+	}
+
+	rawStatus, err := json.Marshal(status)
+	if err != nil {
+		return errors.WrapIf(err, "failed to encode secret binding status")
+	}
+
+	err = s.db.Model(&bindingModel{ID: binding.ID}).
+		Where(&bindingModel{OrganizationID: binding.OrganizationID}).
+		Updates(map[string]interface{}{"spec": string(rawSpec), "status": string(rawStatus)}).
+		Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update secret binding", "orgID", binding.OrganizationID, "bindingID", binding.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes a secret binding.
+func (s GormStore) Delete(_ context.Context, organizationID uint, bindingID uint) error {
+	if err := s.db.Where(&bindingModel{ID: bindingID, OrganizationID: organizationID}).Delete(&bindingModel{}).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to delete secret binding", "orgID", organizationID, "bindingID", bindingID)
+	}
+
+	s.logger.Debug("deleted secret binding record", map[string]interface{}{"organizationID": organizationID, "bindingID": bindingID})
+
+	return nil
+}
+
+func fromModels(models []bindingModel) ([]secretbinding.Binding, error) {
+	bindings := make([]secretbinding.Binding, 0, len(models))
+	for _, model := range models {
+		binding, err := fromModel(model)
+		if err != nil {
+			return nil, err
+		}
+
+		bindings = append(bindings, binding)
+	}
+
+	return bindings, nil
+}
+
+func fromModel(model bindingModel) (secretbinding.Binding, error) {
+	binding := secretbinding.Binding{
+		ID:             model.ID,
+		OrganizationID: model.OrganizationID,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+
+	if err := json.Unmarshal([]byte(model.Spec), &binding.Spec); err != nil {
+		return secretbinding.Binding{}, errors.WrapIfWithDetails(err, "failed to decode secret binding spec", "bindingID", model.ID)
+	}
+
+	if model.Status != "" {
+		if err := json.Unmarshal([]byte(model.Status), &binding.Status); err != nil {
+			return secretbinding.Binding{}, errors.WrapIfWithDetails(err, "failed to decode secret binding status", "bindingID", model.ID)
+		}
+	}
+
+	return binding, nil
+}
@@ -0,0 +1,93 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretbindingadapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewGormStore(setUpDatabase(t), common.NoopLogger{})
+
+	spec := secretbinding.Spec{
+		SecretTags: []string{"env:prod"},
+		ClusterIDs: []uint{1, 2},
+		Namespace:  "default",
+		Keys:       map[string]string{"PASSWORD": "password"},
+	}
+
+	binding, err := store.Create(ctx, 1, spec)
+	require.NoError(t, err)
+
+	assert.NotZero(t, binding.ID)
+	assert.Equal(t, spec, binding.Spec)
+	assert.Empty(t, binding.Status)
+
+	binding.Spec.ClusterIDs = []uint{1}
+	binding.Status = []secretbinding.ClusterStatus{
+		{
+			ClusterID: 1,
+			Status:    secretbinding.StatusSynced,
+			Secrets:   []secretbinding.SyncedSecret{{SecretID: "secret", Version: 3, Name: "secret"}},
+		},
+	}
+	require.NoError(t, store.Update(ctx, binding))
+
+	actual, err := store.Get(ctx, 1, binding.ID)
+	require.NoError(t, err)
+	assert.Equal(t, binding.Spec, actual.Spec)
+	require.Len(t, actual.Status, 1)
+	assert.Equal(t, binding.Status[0].Secrets, actual.Status[0].Secrets)
+
+	_, err = store.Get(ctx, 2, binding.ID)
+	assert.True(t, secret.IsNotFoundError(err), "bindings are scoped to organizations")
+
+	_, err = store.Create(ctx, 2, spec)
+	require.NoError(t, err)
+
+	bindings, err := store.List(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, bindings, 1)
+
+	bindings, err = store.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, bindings, 2)
+
+	require.NoError(t, store.Delete(ctx, 1, binding.ID))
+
+	_, err = store.Get(ctx, 1, binding.ID)
+	assert.True(t, secret.IsNotFoundError(err))
+}
@@ -0,0 +1,138 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretbindingadapter
+
+import (
+	"context"
+	"strconv"
+
+	"emperror.dev/errors"
+	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/internal/secret/kubesecret"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding"
+	"github.com/banzaicloud/pipeline/pkg/k8sclient"
+	"github.com/banzaicloud/pipeline/pkg/k8sutil"
+)
+
+// bindingLabel marks the Kubernetes secrets managed by a secret binding.
+const bindingLabel = "secret.banzaicloud.io/binding-id"
+
+// KubeConfigGetter returns the kubeconfig of a cluster.
+type KubeConfigGetter interface {
+	// GetKubeConfig returns the kubeconfig of a cluster.
+	GetKubeConfig(ctx context.Context, clusterID uint) ([]byte, error)
+}
+
+// KubeSecretManager manages the Kubernetes secrets of bindings in clusters.
+type KubeSecretManager struct {
+	kubeConfigs KubeConfigGetter
+}
+
+// NewKubeSecretManager returns a new KubeSecretManager.
+func NewKubeSecretManager(kubeConfigs KubeConfigGetter) KubeSecretManager {
+	return KubeSecretManager{
+		kubeConfigs: kubeConfigs,
+	}
+}
+
+// ApplySecret creates or updates a Kubernetes secret in a cluster.
+// Existing secrets not managed by the binding are left untouched.
+func (m KubeSecretManager) ApplySecret(ctx context.Context, clusterID uint, secret secretbinding.KubeSecret) error {
+	kubeSecretRequest := kubesecret.KubeSecretRequest{
+		Name:      secret.Name,
+		Namespace: secret.Namespace,
+		Type:      secret.Type,
+		Values:    secret.Values,
+		Spec:      make(kubesecret.KubeSecretSpec, len(secret.Keys)),
+	}
+
+	for key, source := range secret.Keys {
+		kubeSecretRequest.Spec[key] = kubesecret.KubeSecretSpecItem{Source: source}
+	}
+
+	kubeSecret, err := kubesecret.CreateKubeSecret(kubeSecretRequest)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create kubernetes secret")
+	}
+
+	kubeSecret.ObjectMeta.Labels = map[string]string{bindingLabel: strconv.FormatUint(uint64(secret.BindingID), 10)}
+
+	kubeConfig, err := m.kubeConfigs.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get kubeconfig")
+	}
+
+	client, err := k8sclient.NewClientFromKubeConfig(kubeConfig)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create kubernetes client")
+	}
+
+	if err := k8sutil.EnsureNamespace(client, secret.Namespace); err != nil {
+		return errors.WrapIf(err, "failed to ensure that namespace exists")
+	}
+
+	current, err := client.CoreV1().Secrets(secret.Namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+	if k8sapierrors.IsNotFound(err) {
+		_, err := client.CoreV1().Secrets(secret.Namespace).Create(ctx, &kubeSecret, metav1.CreateOptions{})
+
+		return errors.WrapIf(err, "failed to create kubernetes secret")
+	} else if err != nil {
+		return errors.WrapIf(err, "failed to get kubernetes secret")
+	}
+
+	if current.Labels[bindingLabel] != kubeSecret.Labels[bindingLabel] {
+		return errors.Errorf("kubernetes secret %s/%s already exists and is not managed by the binding", secret.Namespace, secret.Name)
+	}
+
+	current.Data = nil // Clear data so that it is created from string data again
+	current.StringData = kubeSecret.StringData
+
+	_, err = client.CoreV1().Secrets(secret.Namespace).Update(ctx, current, metav1.UpdateOptions{})
+
+	return errors.WrapIf(err, "failed to update kubernetes secret")
+}
+
+// DeleteSecret deletes a Kubernetes secret from a cluster if it is managed by the binding.
+func (m KubeSecretManager) DeleteSecret(ctx context.Context, clusterID uint, bindingID uint, namespace string, name string) error {
+	kubeConfig, err := m.kubeConfigs.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get kubeconfig")
+	}
+
+	client, err := k8sclient.NewClientFromKubeConfig(kubeConfig)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create kubernetes client")
+	}
+
+	current, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if k8sapierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.WrapIf(err, "failed to get kubernetes secret")
+	}
+
+	if current.Labels[bindingLabel] != strconv.FormatUint(uint64(bindingID), 10) {
+		return nil
+	}
+
+	err = client.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !k8sapierrors.IsNotFound(err) {
+		return errors.WrapIf(err, "failed to delete kubernetes secret")
+	}
+
+	return nil
+}
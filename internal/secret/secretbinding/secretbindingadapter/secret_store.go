@@ -0,0 +1,87 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretbindingadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding"
+	pipelinesecret "github.com/banzaicloud/pipeline/src/secret"
+)
+
+// PipelineSecretStore provides access to Pipeline secrets.
+type PipelineSecretStore interface {
+	Get(organizationID uint, secretID string) (*pipelinesecret.SecretItemResponse, error)
+	List(organizationID uint, query *pipelinesecret.ListSecretsQuery) ([]*pipelinesecret.SecretItemResponse, error)
+}
+
+// SecretStore provides access to the Pipeline secrets synced by bindings.
+type SecretStore struct {
+	secrets PipelineSecretStore
+}
+
+// NewSecretStore returns a new SecretStore.
+func NewSecretStore(secrets PipelineSecretStore) SecretStore {
+	return SecretStore{
+		secrets: secrets,
+	}
+}
+
+// GetSecret returns a secret with its values.
+func (s SecretStore) GetSecret(_ context.Context, organizationID uint, secretID string) (secretbinding.Secret, error) {
+	item, err := s.secrets.Get(organizationID, secretID)
+	if err == pipelinesecret.ErrSecretNotExists {
+		return secretbinding.Secret{}, errors.WithStack(secret.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       secretID,
+		})
+	} else if err != nil {
+		return secretbinding.Secret{}, errors.WrapIfWithDetails(err, "failed to get secret", "secretId", secretID)
+	}
+
+	return fromSecretItem(item), nil
+}
+
+// ListSecrets lists the secrets (with their values) having all of the specified tags.
+func (s SecretStore) ListSecrets(_ context.Context, organizationID uint, tags []string) ([]secretbinding.Secret, error) {
+	items, err := s.secrets.List(organizationID, &pipelinesecret.ListSecretsQuery{
+		Tags:   tags,
+		Values: true,
+	})
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list secrets")
+	}
+
+	secrets := make([]secretbinding.Secret, 0, len(items))
+	for _, item := range items {
+		secrets = append(secrets, fromSecretItem(item))
+	}
+
+	return secrets, nil
+}
+
+func fromSecretItem(item *pipelinesecret.SecretItemResponse) secretbinding.Secret {
+	return secretbinding.Secret{
+		ID:      item.ID,
+		Name:    item.Name,
+		Type:    item.Type,
+		Values:  item.Values,
+		Tags:    item.Tags,
+		Version: item.Version,
+	}
+}
@@ -0,0 +1,434 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretbinding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+// Service manages secret bindings.
+type Service interface {
+	// CreateBinding creates a new secret binding and syncs it into the clusters.
+	CreateBinding(ctx context.Context, organizationID uint, spec Spec) (Binding, error)
+
+	// GetBinding returns a secret binding.
+	GetBinding(ctx context.Context, organizationID uint, bindingID uint) (Binding, error)
+
+	// ListBindings lists the secret bindings of an organization.
+	ListBindings(ctx context.Context, organizationID uint) ([]Binding, error)
+
+	// DeleteBinding deletes a secret binding and the Kubernetes secrets managed by it.
+	DeleteBinding(ctx context.Context, organizationID uint, bindingID uint) error
+
+	// SyncBinding (re)applies a secret binding to every cluster it refers to.
+	SyncBinding(ctx context.Context, organizationID uint, bindingID uint) (Binding, error)
+
+	// SyncSecret (re)applies every secret binding affected by a change of a secret.
+	SyncSecret(ctx context.Context, organizationID uint, secretID string) error
+
+	// PruneClusters removes the deleted clusters from the secret bindings of an organization.
+	PruneClusters(ctx context.Context, organizationID uint) error
+}
+
+// +testify:mock:testOnly=true
+
+// Store persists secret bindings.
+type Store interface {
+	// Create creates a new secret binding.
+	Create(ctx context.Context, organizationID uint, spec Spec) (Binding, error)
+
+	// Get returns a secret binding.
+	// Returns a NotFoundError when the binding cannot be found.
+	Get(ctx context.Context, organizationID uint, bindingID uint) (Binding, error)
+
+	// List lists the secret bindings of an organization.
+	List(ctx context.Context, organizationID uint) ([]Binding, error)
+
+	// Update updates the spec and the status of a secret binding.
+	Update(ctx context.Context, binding Binding) error
+
+	// Delete deletes a secret binding.
+	Delete(ctx context.Context, organizationID uint, bindingID uint) error
+}
+
+// +testify:mock:testOnly=true
+
+// SecretStore provides access to the Pipeline secrets synced by bindings.
+type SecretStore interface {
+	// GetSecret returns a secret with its values.
+	// Returns an error with the NotFound behavior when the secret cannot be found.
+	GetSecret(ctx context.Context, organizationID uint, secretID string) (Secret, error)
+
+	// ListSecrets lists the secrets (with their values) having all of the specified tags.
+	ListSecrets(ctx context.Context, organizationID uint, tags []string) ([]Secret, error)
+}
+
+// +testify:mock:testOnly=true
+
+// ClusterStore provides access to the clusters secrets are synced into.
+type ClusterStore interface {
+	// GetCluster returns a generic Cluster.
+	// Returns an error with the NotFound behavior when the cluster cannot be found.
+	GetCluster(ctx context.Context, id uint) (cluster.Cluster, error)
+}
+
+// +testify:mock:testOnly=true
+
+// KubeSecretManager manages the Kubernetes secrets of bindings in clusters.
+type KubeSecretManager interface {
+	// ApplySecret creates or updates a Kubernetes secret in a cluster.
+	ApplySecret(ctx context.Context, clusterID uint, secret KubeSecret) error
+
+	// DeleteSecret deletes a Kubernetes secret from a cluster if it is managed by the binding.
+	DeleteSecret(ctx context.Context, clusterID uint, bindingID uint, namespace string, name string) error
+}
+
+// NewService returns a new Service.
+func NewService(store Store, secrets SecretStore, clusters ClusterStore, kubeSecrets KubeSecretManager, logger common.Logger) Service {
+	return service{
+		store:       store,
+		secrets:     secrets,
+		clusters:    clusters,
+		kubeSecrets: kubeSecrets,
+		logger:      logger,
+	}
+}
+
+type service struct {
+	store       Store
+	secrets     SecretStore
+	clusters    ClusterStore
+	kubeSecrets KubeSecretManager
+	logger      common.Logger
+}
+
+func (s service) CreateBinding(ctx context.Context, organizationID uint, spec Spec) (Binding, error) {
+	if err := spec.Validate(); err != nil {
+		return Binding{}, err
+	}
+
+	var violations []string
+	for _, clusterID := range spec.ClusterIDs {
+		exists, err := s.clusterExists(ctx, organizationID, clusterID)
+		if err != nil {
+			return Binding{}, err
+		}
+
+		if !exists {
+			violations = append(violations, fmt.Sprintf("cluster %d not found", clusterID))
+		}
+	}
+
+	if len(violations) > 0 {
+		return Binding{}, secret.NewValidationError("invalid secret binding", violations)
+	}
+
+	if spec.SecretID != "" {
+		if _, err := s.secrets.GetSecret(ctx, organizationID, spec.SecretID); secret.IsNotFoundError(err) {
+			return Binding{}, secret.NewValidationError("invalid secret binding", []string{"secret not found"})
+		} else if err != nil {
+			return Binding{}, errors.WrapIfWithDetails(err, "failed to get secret", "secretId", spec.SecretID)
+		}
+	}
+
+	binding, err := s.store.Create(ctx, organizationID, spec)
+	if err != nil {
+		return Binding{}, err
+	}
+
+	s.logger.Info("secret binding created", map[string]interface{}{"organizationId": organizationID, "bindingId": binding.ID})
+
+	return s.sync(ctx, binding)
+}
+
+func (s service) GetBinding(ctx context.Context, organizationID uint, bindingID uint) (Binding, error) {
+	return s.store.Get(ctx, organizationID, bindingID)
+}
+
+func (s service) ListBindings(ctx context.Context, organizationID uint) ([]Binding, error) {
+	return s.store.List(ctx, organizationID)
+}
+
+func (s service) DeleteBinding(ctx context.Context, organizationID uint, bindingID uint) error {
+	binding, err := s.store.Get(ctx, organizationID, bindingID)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range binding.Status {
+		for _, syncedSecret := range status.Secrets {
+			err := s.kubeSecrets.DeleteSecret(ctx, status.ClusterID, binding.ID, binding.Spec.Namespace, syncedSecret.Name)
+			if err != nil && !secret.IsNotFoundError(err) {
+				s.logger.Warn("failed to delete kubernetes secret of binding", map[string]interface{}{
+					"bindingId": binding.ID,
+					"clusterId": status.ClusterID,
+					"secret":    syncedSecret.Name,
+					"error":     err.Error(),
+				})
+			}
+		}
+	}
+
+	if err := s.store.Delete(ctx, organizationID, bindingID); err != nil {
+		return err
+	}
+
+	s.logger.Info("secret binding deleted", map[string]interface{}{"organizationId": organizationID, "bindingId": bindingID})
+
+	return nil
+}
+
+func (s service) SyncBinding(ctx context.Context, organizationID uint, bindingID uint) (Binding, error) {
+	binding, err := s.store.Get(ctx, organizationID, bindingID)
+	if err != nil {
+		return Binding{}, err
+	}
+
+	return s.sync(ctx, binding)
+}
+
+func (s service) SyncSecret(ctx context.Context, organizationID uint, secretID string) error {
+	changedSecret, err := s.secrets.GetSecret(ctx, organizationID, secretID)
+	deleted := secret.IsNotFoundError(err)
+	if err != nil && !deleted {
+		return errors.WrapIfWithDetails(err, "failed to get secret", "secretId", secretID)
+	}
+
+	bindings, err := s.store.List(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, binding := range bindings {
+		affected := binding.Spec.SecretID == secretID || binding.synced(secretID) || (!deleted && binding.Spec.selects(changedSecret))
+		if !affected {
+			continue
+		}
+
+		if _, err := s.sync(ctx, binding); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func (s service) PruneClusters(ctx context.Context, organizationID uint) error {
+	bindings, err := s.store.List(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, binding := range bindings {
+		clusterIDs, err := s.existingClusters(ctx, binding)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+
+		if len(clusterIDs) == len(binding.Spec.ClusterIDs) {
+			continue
+		}
+
+		binding.Spec.ClusterIDs = clusterIDs
+
+		statuses := make([]ClusterStatus, 0, len(clusterIDs))
+		for _, clusterID := range clusterIDs {
+			statuses = append(statuses, binding.clusterStatus(clusterID))
+		}
+
+		binding.Status = statuses
+
+		errs = errors.Append(errs, s.save(ctx, binding))
+	}
+
+	return errs
+}
+
+// sync applies a binding to every (existing) cluster it refers to and saves its status.
+func (s service) sync(ctx context.Context, binding Binding) (Binding, error) {
+	secrets, sourceErr := s.selectSecrets(ctx, binding)
+
+	clusterIDs, err := s.existingClusters(ctx, binding)
+	if err != nil {
+		return binding, err
+	}
+
+	statuses := make([]ClusterStatus, 0, len(clusterIDs))
+	for _, clusterID := range clusterIDs {
+		statuses = append(statuses, s.syncCluster(ctx, binding, clusterID, secrets, sourceErr))
+	}
+
+	binding.Spec.ClusterIDs = clusterIDs
+	binding.Status = statuses
+
+	return binding, s.save(ctx, binding)
+}
+
+// save updates a binding or deletes it if none of its clusters exist anymore.
+func (s service) save(ctx context.Context, binding Binding) error {
+	if len(binding.Spec.ClusterIDs) == 0 {
+		s.logger.Info("deleting secret binding without clusters", map[string]interface{}{"organizationId": binding.OrganizationID, "bindingId": binding.ID})
+
+		return s.store.Delete(ctx, binding.OrganizationID, binding.ID)
+	}
+
+	return s.store.Update(ctx, binding)
+}
+
+// selectSecrets returns the secrets selected by a binding.
+// The returned error describes why the secrets cannot be synced.
+func (s service) selectSecrets(ctx context.Context, binding Binding) ([]Secret, error) {
+	if binding.Spec.SecretID != "" {
+		selected, err := s.secrets.GetSecret(ctx, binding.OrganizationID, binding.Spec.SecretID)
+		if secret.IsNotFoundError(err) { // Deleted secrets are pruned from the clusters
+			return []Secret{}, nil
+		} else if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to get secret", "secretId", binding.Spec.SecretID)
+		}
+
+		return []Secret{selected}, nil
+	}
+
+	secrets, err := s.secrets.ListSecrets(ctx, binding.OrganizationID, binding.Spec.SecretTags)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list secrets")
+	}
+
+	return secrets, nil
+}
+
+// syncCluster applies the selected secrets to a cluster and removes the ones that are no longer selected.
+func (s service) syncCluster(ctx context.Context, binding Binding, clusterID uint, secrets []Secret, sourceErr error) ClusterStatus {
+	previous := binding.clusterStatus(clusterID)
+
+	status := ClusterStatus{
+		ClusterID: clusterID,
+		SyncedAt:  time.Now(),
+	}
+
+	if sourceErr != nil {
+		status.Status = StatusFailed
+		status.Message = sourceErr.Error()
+		status.Secrets = previous.Secrets
+
+		return status
+	}
+
+	var errs error
+
+	selected := make(map[string]bool, len(secrets))
+	for _, secret := range secrets {
+		selected[secret.ID] = true
+
+		name := binding.Spec.Name
+		if name == "" {
+			name = secret.Name
+		}
+
+		err := s.kubeSecrets.ApplySecret(ctx, clusterID, KubeSecret{
+			BindingID: binding.ID,
+			Namespace: binding.Spec.Namespace,
+			Name:      name,
+			Type:      secret.Type,
+			Values:    secret.Values,
+			Keys:      binding.Spec.Keys,
+		})
+		if err != nil {
+			errs = errors.Append(errs, errors.WrapIfWithDetails(err, "failed to apply kubernetes secret", "secret", name))
+
+			for _, synced := range previous.Secrets {
+				if synced.SecretID == secret.ID {
+					status.Secrets = append(status.Secrets, synced)
+				}
+			}
+
+			continue
+		}
+
+		status.Secrets = append(status.Secrets, SyncedSecret{
+			SecretID: secret.ID,
+			Version:  secret.Version,
+			Name:     name,
+		})
+	}
+
+	for _, synced := range previous.Secrets {
+		if selected[synced.SecretID] {
+			continue
+		}
+
+		err := s.kubeSecrets.DeleteSecret(ctx, clusterID, binding.ID, binding.Spec.Namespace, synced.Name)
+		if err != nil && !secret.IsNotFoundError(err) {
+			errs = errors.Append(errs, errors.WrapIfWithDetails(err, "failed to delete kubernetes secret", "secret", synced.Name))
+			status.Secrets = append(status.Secrets, synced)
+		}
+	}
+
+	if errs != nil {
+		status.Status = StatusFailed
+		status.Message = errs.Error()
+
+		s.logger.Warn("failed to sync secret binding", map[string]interface{}{
+			"bindingId": binding.ID,
+			"clusterId": clusterID,
+			"error":     status.Message,
+		})
+
+		return status
+	}
+
+	status.Status = StatusSynced
+
+	return status
+}
+
+// existingClusters returns the clusters of a binding that still exist in the organization.
+func (s service) existingClusters(ctx context.Context, binding Binding) ([]uint, error) {
+	clusterIDs := make([]uint, 0, len(binding.Spec.ClusterIDs))
+	for _, clusterID := range binding.Spec.ClusterIDs {
+		exists, err := s.clusterExists(ctx, binding.OrganizationID, clusterID)
+		if err != nil {
+			return nil, err
+		}
+
+		if exists {
+			clusterIDs = append(clusterIDs, clusterID)
+		}
+	}
+
+	return clusterIDs, nil
+}
+
+func (s service) clusterExists(ctx context.Context, organizationID uint, clusterID uint) (bool, error) {
+	c, err := s.clusters.GetCluster(ctx, clusterID)
+	if cluster.IsNotFoundError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.WrapIfWithDetails(err, "failed to get cluster", "clusterId", clusterID)
+	}
+
+	return c.OrganizationID == organizationID, nil
+}
@@ -0,0 +1,374 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretbinding
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+func TestSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  Spec
+		valid bool
+	}{
+		{
+			name:  "SecretID",
+			spec:  Spec{SecretID: "secret", ClusterIDs: []uint{1}, Namespace: "default", Name: "my-secret"},
+			valid: true,
+		},
+		{
+			name:  "SecretTags",
+			spec:  Spec{SecretTags: []string{"env:prod"}, ClusterIDs: []uint{1}, Namespace: "default"},
+			valid: true,
+		},
+		{
+			name: "NoSecret",
+			spec: Spec{ClusterIDs: []uint{1}, Namespace: "default"},
+		},
+		{
+			name: "SecretIDAndTags",
+			spec: Spec{SecretID: "secret", SecretTags: []string{"env:prod"}, ClusterIDs: []uint{1}, Namespace: "default"},
+		},
+		{
+			name: "NoClusters",
+			spec: Spec{SecretID: "secret", Namespace: "default"},
+		},
+		{
+			name: "InvalidNamespace",
+			spec: Spec{SecretID: "secret", ClusterIDs: []uint{1}, Namespace: "Default"},
+		},
+		{
+			name: "NameWithTags",
+			spec: Spec{SecretTags: []string{"env:prod"}, ClusterIDs: []uint{1}, Namespace: "default", Name: "my-secret"},
+		},
+		{
+			name: "EmptyKeyMapping",
+			spec: Spec{SecretID: "secret", ClusterIDs: []uint{1}, Namespace: "default", Keys: map[string]string{"password": ""}},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			err := test.spec.Validate()
+
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, secret.IsValidationError(err))
+			}
+		})
+	}
+}
+
+func TestService_CreateBinding(t *testing.T) {
+	ctx := context.Background()
+
+	spec := Spec{
+		SecretID:   "secret",
+		ClusterIDs: []uint{1},
+		Namespace:  "default",
+		Name:       "db-password",
+		Keys:       map[string]string{"PASSWORD": "password"},
+	}
+
+	secret := Secret{ID: "secret", Name: "password", Type: "password", Values: map[string]string{"password": "s3cr3t"}, Version: 2}
+
+	store := new(MockStore)
+	store.On("Create", ctx, uint(1), spec).Return(Binding{ID: 1, OrganizationID: 1, Spec: spec}, nil)
+	store.On("Update", ctx, mock.MatchedBy(func(b Binding) bool {
+		return b.ID == 1 && len(b.Status) == 1 &&
+			b.Status[0].Status == StatusSynced &&
+			assert.ObjectsAreEqual([]SyncedSecret{{SecretID: "secret", Version: 2, Name: "db-password"}}, b.Status[0].Secrets)
+	})).Return(nil)
+
+	secrets := new(MockSecretStore)
+	secrets.On("GetSecret", ctx, uint(1), "secret").Return(secret, nil)
+
+	clusters := new(MockClusterStore)
+	clusters.On("GetCluster", ctx, uint(1)).Return(cluster.Cluster{ID: 1, OrganizationID: 1}, nil)
+
+	kubeSecrets := new(MockKubeSecretManager)
+	kubeSecrets.On("ApplySecret", ctx, uint(1), KubeSecret{
+		BindingID: 1,
+		Namespace: "default",
+		Name:      "db-password",
+		Type:      "password",
+		Values:    secret.Values,
+		Keys:      spec.Keys,
+	}).Return(nil)
+
+	service := NewService(store, secrets, clusters, kubeSecrets, common.NoopLogger{})
+
+	binding, err := service.CreateBinding(ctx, 1, spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(1), binding.ID)
+	require.Len(t, binding.Status, 1)
+	assert.Equal(t, StatusSynced, binding.Status[0].Status)
+
+	store.AssertExpectations(t)
+	secrets.AssertExpectations(t)
+	clusters.AssertExpectations(t)
+	kubeSecrets.AssertExpectations(t)
+}
+
+func TestService_CreateBinding_ForeignCluster(t *testing.T) {
+	ctx := context.Background()
+
+	clusters := new(MockClusterStore)
+	clusters.On("GetCluster", ctx, uint(1)).Return(cluster.Cluster{ID: 1, OrganizationID: 2}, nil)
+
+	service := NewService(new(MockStore), new(MockSecretStore), clusters, new(MockKubeSecretManager), common.NoopLogger{})
+
+	_, err := service.CreateBinding(ctx, 1, Spec{SecretID: "secret", ClusterIDs: []uint{1}, Namespace: "default"})
+	require.Error(t, err)
+
+	assert.True(t, secret.IsValidationError(err))
+}
+
+func TestService_SyncSecret(t *testing.T) {
+	ctx := context.Background()
+
+	binding := Binding{
+		ID:             1,
+		OrganizationID: 1,
+		Spec: Spec{
+			SecretTags: []string{"env:prod"},
+			ClusterIDs: []uint{1},
+			Namespace:  "default",
+		},
+		Status: []ClusterStatus{
+			{
+				ClusterID: 1,
+				Status:    StatusSynced,
+				Secrets: []SyncedSecret{
+					{SecretID: "secret1", Version: 1, Name: "secret1"},
+					{SecretID: "secret2", Version: 1, Name: "secret2"},
+				},
+			},
+		},
+	}
+
+	unrelated := Binding{
+		ID:             2,
+		OrganizationID: 1,
+		Spec: Spec{
+			SecretID:   "other",
+			ClusterIDs: []uint{1},
+			Namespace:  "default",
+		},
+	}
+
+	// secret2 has been untagged
+	secret1 := Secret{ID: "secret1", Name: "secret1", Type: "generic", Tags: []string{"env:prod"}, Version: 2}
+	secret2 := Secret{ID: "secret2", Name: "secret2", Type: "generic", Version: 2}
+
+	store := new(MockStore)
+	store.On("List", ctx, uint(1)).Return([]Binding{binding, unrelated}, nil)
+	store.On("Update", ctx, mock.MatchedBy(func(b Binding) bool {
+		return b.ID == 1 && len(b.Status) == 1 &&
+			b.Status[0].Status == StatusSynced &&
+			assert.ObjectsAreEqual([]SyncedSecret{{SecretID: "secret1", Version: 2, Name: "secret1"}}, b.Status[0].Secrets)
+	})).Return(nil)
+
+	secrets := new(MockSecretStore)
+	secrets.On("GetSecret", ctx, uint(1), "secret2").Return(secret2, nil)
+	secrets.On("ListSecrets", ctx, uint(1), []string{"env:prod"}).Return([]Secret{secret1}, nil)
+
+	clusters := new(MockClusterStore)
+	clusters.On("GetCluster", ctx, uint(1)).Return(cluster.Cluster{ID: 1, OrganizationID: 1}, nil)
+
+	kubeSecrets := new(MockKubeSecretManager)
+	kubeSecrets.On("ApplySecret", ctx, uint(1), mock.MatchedBy(func(s KubeSecret) bool { return s.Name == "secret1" })).Return(nil)
+	kubeSecrets.On("DeleteSecret", ctx, uint(1), uint(1), "default", "secret2").Return(nil)
+
+	service := NewService(store, secrets, clusters, kubeSecrets, common.NoopLogger{})
+
+	err := service.SyncSecret(ctx, 1, "secret2")
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+	secrets.AssertExpectations(t)
+	kubeSecrets.AssertExpectations(t)
+}
+
+func TestService_SyncSecret_Deleted(t *testing.T) {
+	ctx := context.Background()
+
+	binding := Binding{
+		ID:             1,
+		OrganizationID: 1,
+		Spec: Spec{
+			SecretID:   "secret",
+			ClusterIDs: []uint{1},
+			Namespace:  "default",
+		},
+		Status: []ClusterStatus{
+			{
+				ClusterID: 1,
+				Status:    StatusSynced,
+				Secrets: []SyncedSecret{
+					{SecretID: "secret", Version: 1, Name: "secret"},
+				},
+			},
+		},
+	}
+
+	store := new(MockStore)
+	store.On("List", ctx, uint(1)).Return([]Binding{binding}, nil)
+	store.On("Update", ctx, mock.MatchedBy(func(b Binding) bool {
+		return b.ID == 1 && len(b.Status) == 1 &&
+			b.Status[0].Status == StatusSynced &&
+			len(b.Status[0].Secrets) == 0
+	})).Return(nil)
+
+	notFoundErr := errors.WithStack(secret.NotFoundError{OrganizationID: 1, SecretID: "secret"})
+
+	secrets := new(MockSecretStore)
+	secrets.On("GetSecret", ctx, uint(1), "secret").Return(Secret{}, notFoundErr)
+
+	clusters := new(MockClusterStore)
+	clusters.On("GetCluster", ctx, uint(1)).Return(cluster.Cluster{ID: 1, OrganizationID: 1}, nil)
+
+	kubeSecrets := new(MockKubeSecretManager)
+	kubeSecrets.On("DeleteSecret", ctx, uint(1), uint(1), "default", "secret").Return(nil)
+
+	service := NewService(store, secrets, clusters, kubeSecrets, common.NoopLogger{})
+
+	err := service.SyncSecret(ctx, 1, "secret")
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+	secrets.AssertExpectations(t)
+	kubeSecrets.AssertExpectations(t)
+}
+
+func TestService_SyncBinding_Failed(t *testing.T) {
+	ctx := context.Background()
+
+	binding := Binding{
+		ID:             1,
+		OrganizationID: 1,
+		Spec: Spec{
+			SecretID:   "secret",
+			ClusterIDs: []uint{1},
+			Namespace:  "default",
+		},
+	}
+
+	store := new(MockStore)
+	store.On("Get", ctx, uint(1), uint(1)).Return(binding, nil)
+	store.On("Update", ctx, mock.MatchedBy(func(b Binding) bool {
+		return len(b.Status) == 1 && b.Status[0].Status == StatusFailed && b.Status[0].Message != ""
+	})).Return(nil)
+
+	secrets := new(MockSecretStore)
+	secrets.On("GetSecret", ctx, uint(1), "secret").Return(Secret{ID: "secret", Name: "secret", Type: "generic"}, nil)
+
+	clusters := new(MockClusterStore)
+	clusters.On("GetCluster", ctx, uint(1)).Return(cluster.Cluster{ID: 1, OrganizationID: 1}, nil)
+
+	kubeSecrets := new(MockKubeSecretManager)
+	kubeSecrets.On("ApplySecret", ctx, uint(1), mock.Anything).Return(errors.New("connection refused"))
+
+	service := NewService(store, secrets, clusters, kubeSecrets, common.NoopLogger{})
+
+	binding, err := service.SyncBinding(ctx, 1, 1)
+	require.NoError(t, err)
+
+	require.Len(t, binding.Status, 1)
+	assert.Equal(t, StatusFailed, binding.Status[0].Status)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_PruneClusters(t *testing.T) {
+	ctx := context.Background()
+
+	partial := Binding{
+		ID:             1,
+		OrganizationID: 1,
+		Spec:           Spec{SecretID: "secret", ClusterIDs: []uint{1, 2}, Namespace: "default"},
+		Status: []ClusterStatus{
+			{ClusterID: 1, Status: StatusSynced},
+			{ClusterID: 2, Status: StatusSynced},
+		},
+	}
+
+	orphaned := Binding{
+		ID:             2,
+		OrganizationID: 1,
+		Spec:           Spec{SecretID: "secret", ClusterIDs: []uint{2}, Namespace: "default"},
+	}
+
+	store := new(MockStore)
+	store.On("List", ctx, uint(1)).Return([]Binding{partial, orphaned}, nil)
+	store.On("Update", ctx, mock.MatchedBy(func(b Binding) bool {
+		return b.ID == 1 && assert.ObjectsAreEqual([]uint{1}, b.Spec.ClusterIDs) && len(b.Status) == 1 && b.Status[0].ClusterID == 1
+	})).Return(nil)
+	store.On("Delete", ctx, uint(1), uint(2)).Return(nil)
+
+	clusters := new(MockClusterStore)
+	clusters.On("GetCluster", ctx, uint(1)).Return(cluster.Cluster{ID: 1, OrganizationID: 1}, nil)
+	clusters.On("GetCluster", ctx, uint(2)).Return(cluster.Cluster{}, cluster.NotFoundError{ClusterID: 2})
+
+	service := NewService(store, new(MockSecretStore), clusters, new(MockKubeSecretManager), common.NoopLogger{})
+
+	err := service.PruneClusters(ctx, 1)
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_DeleteBinding(t *testing.T) {
+	ctx := context.Background()
+
+	binding := Binding{
+		ID:             1,
+		OrganizationID: 1,
+		Spec:           Spec{SecretID: "secret", ClusterIDs: []uint{1}, Namespace: "default"},
+		Status: []ClusterStatus{
+			{ClusterID: 1, Status: StatusSynced, Secrets: []SyncedSecret{{SecretID: "secret", Version: 1, Name: "secret"}}},
+		},
+	}
+
+	store := new(MockStore)
+	store.On("Get", ctx, uint(1), uint(1)).Return(binding, nil)
+	store.On("Delete", ctx, uint(1), uint(1)).Return(nil)
+
+	kubeSecrets := new(MockKubeSecretManager)
+	kubeSecrets.On("DeleteSecret", ctx, uint(1), uint(1), "default", "secret").Return(nil)
+
+	service := NewService(store, new(MockSecretStore), new(MockClusterStore), kubeSecrets, common.NoopLogger{})
+
+	err := service.DeleteBinding(ctx, 1, 1)
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+	kubeSecrets.AssertExpectations(t)
+}
@@ -0,0 +1,69 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding"
+)
+
+// ListBindingsActivityName is the name the ListBindingsActivity is registered under
+const ListBindingsActivityName = "secret-binding-list-bindings"
+
+// BindingLister lists the secret bindings of every organization.
+type BindingLister interface {
+	// ListAll lists the secret bindings of every organization.
+	ListAll(ctx context.Context) ([]secretbinding.Binding, error)
+}
+
+// BindingRef identifies a secret binding.
+type BindingRef struct {
+	OrganizationID uint
+	BindingID      uint
+}
+
+// ListBindingsActivityInput defines the inputs of the ListBindingsActivity
+type ListBindingsActivityInput struct{}
+
+// ListBindingsActivity lists the secret bindings to be reconciled
+type ListBindingsActivity struct {
+	bindings BindingLister
+}
+
+// MakeListBindingsActivity returns a new ListBindingsActivity
+func MakeListBindingsActivity(bindings BindingLister) ListBindingsActivity {
+	return ListBindingsActivity{
+		bindings: bindings,
+	}
+}
+
+// Execute executes the activity
+func (a ListBindingsActivity) Execute(ctx context.Context, _ ListBindingsActivityInput) ([]BindingRef, error) {
+	bindings, err := a.bindings.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]BindingRef, 0, len(bindings))
+	for _, binding := range bindings {
+		refs = append(refs, BindingRef{
+			OrganizationID: binding.OrganizationID,
+			BindingID:      binding.ID,
+		})
+	}
+
+	return refs, nil
+}
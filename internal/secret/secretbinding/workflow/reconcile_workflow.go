@@ -0,0 +1,67 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+)
+
+// ReconcileWorkflowName is the name the ReconcileWorkflow is registered under
+const ReconcileWorkflowName = "secret-binding-reconcile"
+
+// ReconcileWorkflowID is the fixed ID of the scheduled ReconcileWorkflow
+const ReconcileWorkflowID = "secret-binding-reconcile"
+
+// ReconcileWorkflowInput defines the inputs of the ReconcileWorkflow
+type ReconcileWorkflowInput struct{}
+
+// ReconcileWorkflow re-applies every secret binding to the clusters it refers to.
+func ReconcileWorkflow(ctx workflow.Context, _ ReconcileWorkflowInput) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+	})
+
+	logger := workflow.GetLogger(ctx)
+
+	var bindings []BindingRef
+	if err := workflow.ExecuteActivity(ctx, ListBindingsActivityName, ListBindingsActivityInput{}).Get(ctx, &bindings); err != nil {
+		return err
+	}
+
+	futures := make([]workflow.Future, len(bindings))
+	for i, binding := range bindings {
+		futures[i] = workflow.ExecuteActivity(ctx, SyncBindingActivityName, SyncBindingActivityInput{
+			OrganizationID: binding.OrganizationID,
+			BindingID:      binding.BindingID,
+		})
+	}
+
+	for i, future := range futures {
+		if err := future.Get(ctx, nil); err != nil {
+			logger.Error(
+				"failed to sync secret binding",
+				zap.Uint("organizationId", bindings[i].OrganizationID),
+				zap.Uint("bindingId", bindings[i].BindingID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding"
+)
+
+// SyncBindingActivityName is the name the SyncBindingActivity is registered under
+const SyncBindingActivityName = "secret-binding-sync-binding"
+
+// BindingSyncer syncs secret bindings.
+type BindingSyncer interface {
+	// SyncBinding (re)applies a secret binding to every cluster it refers to.
+	SyncBinding(ctx context.Context, organizationID uint, bindingID uint) (secretbinding.Binding, error)
+}
+
+// SyncBindingActivityInput defines the inputs of the SyncBindingActivity
+type SyncBindingActivityInput struct {
+	OrganizationID uint
+	BindingID      uint
+}
+
+// SyncBindingActivity re-applies a secret binding
+type SyncBindingActivity struct {
+	bindings BindingSyncer
+}
+
+// MakeSyncBindingActivity returns a new SyncBindingActivity
+func MakeSyncBindingActivity(bindings BindingSyncer) SyncBindingActivity {
+	return SyncBindingActivity{
+		bindings: bindings,
+	}
+}
+
+// Execute executes the activity
+func (a SyncBindingActivity) Execute(ctx context.Context, input SyncBindingActivityInput) error {
+	_, err := a.bindings.SyncBinding(ctx, input.OrganizationID, input.BindingID)
+	if secret.IsNotFoundError(err) {
+		// the binding has been deleted since it was listed
+		return nil
+	}
+
+	return err
+}
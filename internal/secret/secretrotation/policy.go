@@ -0,0 +1,85 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretrotation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+// MinimumInterval is the shortest interval a secret can be rotated with.
+const MinimumInterval = time.Hour
+
+// Policy describes when the values of a secret are regenerated.
+type Policy struct {
+	OrganizationID uint
+	SecretID       string
+
+	// Interval is the time between two rotations.
+	Interval time.Duration
+
+	// NextRotationAt is the time the secret is rotated next.
+	NextRotationAt time.Time
+
+	// LastRotatedAt is the time the secret was last rotated (if ever).
+	LastRotatedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// due tells whether the secret should be rotated.
+func (p Policy) due(now time.Time) bool {
+	return !p.NextRotationAt.After(now)
+}
+
+// PolicySpec describes the desired rotation policy of a secret.
+type PolicySpec struct {
+	// Interval is the time between two rotations.
+	Interval time.Duration
+
+	// NextRotationAt is the time of the first rotation.
+	// Defaults to one interval from now.
+	NextRotationAt time.Time
+}
+
+// Validate validates the spec.
+func (s PolicySpec) Validate() error {
+	var violations []string
+
+	if s.Interval < MinimumInterval {
+		violations = append(violations, fmt.Sprintf("interval must be at least %s", MinimumInterval))
+	}
+
+	if len(violations) > 0 {
+		return secret.NewValidationError("invalid secret rotation policy", violations)
+	}
+
+	return nil
+}
+
+// Secret is a Pipeline secret that can have a rotation policy.
+type Secret struct {
+	ID   string
+	Type string
+
+	// Rotatable tells whether the type of the secret can regenerate its values.
+	Rotatable bool
+
+	// ReadOnly tells whether the secret must not be changed.
+	ReadOnly bool
+}
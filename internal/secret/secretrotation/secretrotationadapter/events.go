@@ -0,0 +1,43 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretrotationadapter
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret/secretrotation"
+)
+
+type eventBus interface {
+	SubscribeAsync(topic string, fn interface{}, transactional bool) error
+}
+
+const (
+	secretChangedTopic = "secret_changed"
+)
+
+// SubscribeEvents removes the rotation policies of deleted secrets.
+func SubscribeEvents(eb eventBus, service secretrotation.Service, logger common.Logger) {
+	eb.SubscribeAsync(secretChangedTopic, func(organizationID uint, secretID string) { // nolint: errcheck
+		if err := service.PruneSecret(context.Background(), organizationID, secretID); err != nil {
+			logger.Error("failed to remove rotation policy of deleted secret", map[string]interface{}{
+				"organizationId": organizationID,
+				"secretId":       secretID,
+				"error":          err.Error(),
+			})
+		}
+	}, false)
+}
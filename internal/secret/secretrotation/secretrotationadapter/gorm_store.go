@@ -0,0 +1,157 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretrotationadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret/secretrotation"
+)
+
+// policyModel describes the secret rotation policy model.
+type policyModel struct {
+	ID              uint `gorm:"primary_key"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	OrganizationID  uint   `gorm:"unique_index:idx_secret_rotation_policies_org_secret"`
+	SecretID        string `gorm:"unique_index:idx_secret_rotation_policies_org_secret;size:255"`
+	IntervalSeconds int64
+	NextRotationAt  time.Time `gorm:"index:idx_secret_rotation_policies_next_rotation"`
+	LastRotatedAt   *time.Time
+}
+
+// TableName changes the default table name.
+func (policyModel) TableName() string {
+	return "secret_rotation_policies"
+}
+
+// GormStore is a gorm based secret rotation policy store.
+type GormStore struct {
+	db     *gorm.DB
+	logger common.Logger
+}
+
+// NewGormStore returns a new GormStore.
+func NewGormStore(db *gorm.DB, logger common.Logger) GormStore {
+	return GormStore{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Get returns the rotation policy of a secret.
+func (s GormStore) Get(_ context.Context, organizationID uint, secretID string) (secretrotation.Policy, error) {
+	var model policyModel
+	if err := s.db.Where(&policyModel{OrganizationID: organizationID, SecretID: secretID}).First(&model).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return secretrotation.Policy{}, errors.WithStack(secretrotation.NotFoundError{
+				OrganizationID: organizationID,
+				SecretID:       secretID,
+			})
+		}
+
+		return secretrotation.Policy{}, errors.WrapIfWithDetails(err, "failed to get secret rotation policy", "orgID", organizationID, "secretID", secretID)
+	}
+
+	return fromModel(model), nil
+}
+
+// List lists the secret rotation policies of an organization.
+func (s GormStore) List(_ context.Context, organizationID uint) ([]secretrotation.Policy, error) {
+	var models []policyModel
+	if err := s.db.Where(&policyModel{OrganizationID: organizationID}).Order("secret_id").Find(&models).Error; err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list secret rotation policies", "orgID", organizationID)
+	}
+
+	return fromModels(models), nil
+}
+
+// ListDue lists the secret rotation policies of every organization that are due at the specified time.
+func (s GormStore) ListDue(_ context.Context, now time.Time) ([]secretrotation.Policy, error) {
+	var models []policyModel
+	if err := s.db.Where("next_rotation_at <= ?", now).Order("next_rotation_at").Find(&models).Error; err != nil {
+		return nil, errors.WrapIf(err, "failed to list due secret rotation policies")
+	}
+
+	return fromModels(models), nil
+}
+
+// Put creates or updates the rotation policy of a secret.
+func (s GormStore) Put(_ context.Context, policy secretrotation.Policy) (secretrotation.Policy, error) {
+	var model policyModel
+	err := s.db.Where(&policyModel{OrganizationID: policy.OrganizationID, SecretID: policy.SecretID}).First(&model).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return secretrotation.Policy{}, errors.WrapIfWithDetails(err, "failed to get secret rotation policy", "orgID", policy.OrganizationID, "secretID", policy.SecretID)
+	}
+
+	model.OrganizationID = policy.OrganizationID
+	model.SecretID = policy.SecretID
+	model.IntervalSeconds = int64(policy.Interval / time.Second)
+	model.NextRotationAt = policy.NextRotationAt
+	model.LastRotatedAt = policy.LastRotatedAt
+
+	if err := s.db.Save(&model).Error; err != nil {
+		return secretrotation.Policy{}, errors.WrapIfWithDetails(err, "failed to save secret rotation policy", "orgID", policy.OrganizationID, "secretID", policy.SecretID)
+	}
+
+	s.logger.Debug("saved secret rotation policy record", map[string]interface{}{"organizationID": policy.OrganizationID, "secretID": policy.SecretID})
+
+	return fromModel(model), nil
+}
+
+// Delete deletes the rotation policy of a secret.
+func (s GormStore) Delete(_ context.Context, organizationID uint, secretID string) error {
+	result := s.db.Where(&policyModel{OrganizationID: organizationID, SecretID: secretID}).Delete(&policyModel{})
+	if err := result.Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to delete secret rotation policy", "orgID", organizationID, "secretID", secretID)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.WithStack(secretrotation.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       secretID,
+		})
+	}
+
+	s.logger.Debug("deleted secret rotation policy record", map[string]interface{}{"organizationID": organizationID, "secretID": secretID})
+
+	return nil
+}
+
+func fromModels(models []policyModel) []secretrotation.Policy {
+	policies := make([]secretrotation.Policy, 0, len(models))
+	for _, model := range models {
+		policies = append(policies, fromModel(model))
+	}
+
+	return policies
+}
+
+func fromModel(model policyModel) secretrotation.Policy {
+	return secretrotation.Policy{
+		OrganizationID: model.OrganizationID,
+		SecretID:       model.SecretID,
+		Interval:       time.Duration(model.IntervalSeconds) * time.Second,
+		NextRotationAt: model.NextRotationAt,
+		LastRotatedAt:  model.LastRotatedAt,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretrotationadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/secretrotation"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewGormStore(setUpDatabase(t), common.NoopLogger{})
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	policy, err := store.Put(ctx, secretrotation.Policy{
+		OrganizationID: 1,
+		SecretID:       "secret",
+		Interval:       24 * time.Hour,
+		NextRotationAt: now.Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 24*time.Hour, policy.Interval)
+	assert.Nil(t, policy.LastRotatedAt)
+
+	_, err = store.Put(ctx, secretrotation.Policy{
+		OrganizationID: 2,
+		SecretID:       "secret",
+		Interval:       time.Hour,
+		NextRotationAt: now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	due, err := store.ListDue(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, uint(1), due[0].OrganizationID)
+
+	policy.LastRotatedAt = &now
+	policy.NextRotationAt = now.Add(policy.Interval)
+
+	_, err = store.Put(ctx, policy)
+	require.NoError(t, err)
+
+	actual, err := store.Get(ctx, 1, "secret")
+	require.NoError(t, err)
+	require.NotNil(t, actual.LastRotatedAt)
+	assert.True(t, now.Equal(*actual.LastRotatedAt))
+	assert.True(t, policy.NextRotationAt.Equal(actual.NextRotationAt))
+
+	policies, err := store.List(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, policies, 1, "a secret has a single rotation policy")
+
+	due, err = store.ListDue(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	require.NoError(t, store.Delete(ctx, 1, "secret"))
+
+	_, err = store.Get(ctx, 1, "secret")
+	assert.True(t, secret.IsNotFoundError(err))
+
+	err = store.Delete(ctx, 1, "secret")
+	assert.True(t, secret.IsNotFoundError(err))
+}
@@ -0,0 +1,85 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretrotationadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/restricted"
+	"github.com/banzaicloud/pipeline/internal/secret/secretrotation"
+	pipelinesecret "github.com/banzaicloud/pipeline/src/secret"
+)
+
+// PipelineSecretStore provides access to Pipeline secrets.
+type PipelineSecretStore interface {
+	Get(organizationID uint, secretID string) (*pipelinesecret.SecretItemResponse, error)
+	IsRotatable(secretType string) bool
+	Rotate(organizationID uint, secretID string, updatedBy string) error
+}
+
+// SecretStore provides access to the Pipeline secrets being rotated.
+type SecretStore struct {
+	secrets PipelineSecretStore
+}
+
+// NewSecretStore returns a new SecretStore.
+func NewSecretStore(secrets PipelineSecretStore) SecretStore {
+	return SecretStore{
+		secrets: secrets,
+	}
+}
+
+// GetSecret returns a secret.
+func (s SecretStore) GetSecret(_ context.Context, organizationID uint, secretID string) (secretrotation.Secret, error) {
+	item, err := s.secrets.Get(organizationID, secretID)
+	if err == pipelinesecret.ErrSecretNotExists {
+		return secretrotation.Secret{}, errors.WithStack(secret.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       secretID,
+		})
+	} else if err != nil {
+		return secretrotation.Secret{}, errors.WrapIfWithDetails(err, "failed to get secret", "secretId", secretID)
+	}
+
+	readOnly := restricted.HasForbiddenTag(item.Tags) != nil
+	for _, tag := range item.Tags {
+		if tag == pipelinesecret.TagBanzaiReadonly {
+			readOnly = true
+		}
+	}
+
+	return secretrotation.Secret{
+		ID:        item.ID,
+		Type:      item.Type,
+		Rotatable: s.secrets.IsRotatable(item.Type),
+		ReadOnly:  readOnly,
+	}, nil
+}
+
+// RotateSecret regenerates the values of a secret and stores them as a new version.
+func (s SecretStore) RotateSecret(_ context.Context, organizationID uint, secretID string, rotatedBy string) error {
+	err := s.secrets.Rotate(organizationID, secretID, rotatedBy)
+	if err == pipelinesecret.ErrSecretNotExists {
+		return errors.WithStack(secret.NotFoundError{
+			OrganizationID: organizationID,
+			SecretID:       secretID,
+		})
+	}
+
+	return err
+}
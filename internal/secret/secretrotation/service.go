@@ -0,0 +1,260 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretrotation
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+// AutomaticRotationUser is recorded as the author of the secret versions created by scheduled rotations.
+const AutomaticRotationUser = "pipeline"
+
+// Service manages secret rotation.
+type Service interface {
+	// SetPolicy creates or updates the rotation policy of a secret.
+	SetPolicy(ctx context.Context, organizationID uint, secretID string, spec PolicySpec) (Policy, error)
+
+	// GetPolicy returns the rotation policy of a secret.
+	GetPolicy(ctx context.Context, organizationID uint, secretID string) (Policy, error)
+
+	// ListPolicies lists the secret rotation policies of an organization.
+	ListPolicies(ctx context.Context, organizationID uint) ([]Policy, error)
+
+	// DeletePolicy deletes the rotation policy of a secret.
+	DeletePolicy(ctx context.Context, organizationID uint, secretID string) error
+
+	// RotateSecret regenerates the values of a secret immediately and reschedules its rotation policy (if any).
+	RotateSecret(ctx context.Context, organizationID uint, secretID string, rotatedBy string) error
+
+	// RotateDueSecret regenerates the values of a secret if its rotation policy is due.
+	RotateDueSecret(ctx context.Context, organizationID uint, secretID string) error
+
+	// PruneSecret deletes the rotation policy of a secret if the secret no longer exists.
+	PruneSecret(ctx context.Context, organizationID uint, secretID string) error
+}
+
+// +testify:mock:testOnly=true
+
+// Store persists secret rotation policies.
+type Store interface {
+	// Get returns the rotation policy of a secret.
+	// Returns a NotFoundError when the policy cannot be found.
+	Get(ctx context.Context, organizationID uint, secretID string) (Policy, error)
+
+	// List lists the secret rotation policies of an organization.
+	List(ctx context.Context, organizationID uint) ([]Policy, error)
+
+	// Put creates or updates the rotation policy of a secret.
+	Put(ctx context.Context, policy Policy) (Policy, error)
+
+	// Delete deletes the rotation policy of a secret.
+	// Returns a NotFoundError when the policy cannot be found.
+	Delete(ctx context.Context, organizationID uint, secretID string) error
+}
+
+// +testify:mock:testOnly=true
+
+// SecretStore provides access to the Pipeline secrets being rotated.
+type SecretStore interface {
+	// GetSecret returns a secret.
+	// Returns an error with the NotFound behavior when the secret cannot be found.
+	GetSecret(ctx context.Context, organizationID uint, secretID string) (Secret, error)
+
+	// RotateSecret regenerates the values of a secret and stores them as a new version.
+	// Returns an error with the NotFound behavior when the secret cannot be found.
+	RotateSecret(ctx context.Context, organizationID uint, secretID string, rotatedBy string) error
+}
+
+// NewService returns a new Service.
+func NewService(store Store, secrets SecretStore, logger common.Logger) Service {
+	return service{
+		store:   store,
+		secrets: secrets,
+		logger:  logger,
+	}
+}
+
+type service struct {
+	store   Store
+	secrets SecretStore
+	logger  common.Logger
+}
+
+func (s service) SetPolicy(ctx context.Context, organizationID uint, secretID string, spec PolicySpec) (Policy, error) {
+	if err := spec.Validate(); err != nil {
+		return Policy{}, err
+	}
+
+	if err := s.checkRotatable(ctx, organizationID, secretID); err != nil {
+		return Policy{}, err
+	}
+
+	policy, err := s.store.Get(ctx, organizationID, secretID)
+	if secret.IsNotFoundError(err) {
+		policy = Policy{
+			OrganizationID: organizationID,
+			SecretID:       secretID,
+		}
+	} else if err != nil {
+		return Policy{}, err
+	}
+
+	policy.Interval = spec.Interval
+	policy.NextRotationAt = spec.NextRotationAt
+
+	if policy.NextRotationAt.IsZero() {
+		policy.NextRotationAt = time.Now().Add(spec.Interval)
+	}
+
+	policy, err = s.store.Put(ctx, policy)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	s.logger.Info("secret rotation policy set", map[string]interface{}{
+		"organizationId": organizationID,
+		"secretId":       secretID,
+		"nextRotationAt": policy.NextRotationAt,
+	})
+
+	return policy, nil
+}
+
+func (s service) GetPolicy(ctx context.Context, organizationID uint, secretID string) (Policy, error) {
+	return s.store.Get(ctx, organizationID, secretID)
+}
+
+func (s service) ListPolicies(ctx context.Context, organizationID uint) ([]Policy, error) {
+	return s.store.List(ctx, organizationID)
+}
+
+func (s service) DeletePolicy(ctx context.Context, organizationID uint, secretID string) error {
+	if err := s.store.Delete(ctx, organizationID, secretID); err != nil {
+		return err
+	}
+
+	s.logger.Info("secret rotation policy deleted", map[string]interface{}{"organizationId": organizationID, "secretId": secretID})
+
+	return nil
+}
+
+func (s service) RotateSecret(ctx context.Context, organizationID uint, secretID string, rotatedBy string) error {
+	if err := s.checkRotatable(ctx, organizationID, secretID); err != nil {
+		return err
+	}
+
+	policy, err := s.store.Get(ctx, organizationID, secretID)
+	if secret.IsNotFoundError(err) {
+		return s.rotate(ctx, organizationID, secretID, rotatedBy)
+	} else if err != nil {
+		return err
+	}
+
+	return s.rotatePolicy(ctx, policy, rotatedBy)
+}
+
+func (s service) RotateDueSecret(ctx context.Context, organizationID uint, secretID string) error {
+	policy, err := s.store.Get(ctx, organizationID, secretID)
+	if err != nil {
+		return err
+	}
+
+	if !policy.due(time.Now()) {
+		// the secret has been rotated since the policy was found to be due
+		return nil
+	}
+
+	if err := s.checkRotatable(ctx, organizationID, secretID); secret.IsNotFoundError(err) {
+		s.logger.Info("deleting rotation policy of deleted secret", map[string]interface{}{"organizationId": organizationID, "secretId": secretID})
+
+		return s.store.Delete(ctx, organizationID, secretID)
+	} else if err != nil {
+		return err
+	}
+
+	return s.rotatePolicy(ctx, policy, AutomaticRotationUser)
+}
+
+func (s service) PruneSecret(ctx context.Context, organizationID uint, secretID string) error {
+	_, err := s.secrets.GetSecret(ctx, organizationID, secretID)
+	if err == nil {
+		return nil
+	} else if !secret.IsNotFoundError(err) {
+		return errors.WrapIfWithDetails(err, "failed to get secret", "secretId", secretID)
+	}
+
+	err = s.store.Delete(ctx, organizationID, secretID)
+	if secret.IsNotFoundError(err) {
+		return nil
+	}
+
+	return err
+}
+
+// checkRotatable returns an error if a secret cannot be rotated.
+func (s service) checkRotatable(ctx context.Context, organizationID uint, secretID string) error {
+	currentSecret, err := s.secrets.GetSecret(ctx, organizationID, secretID)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get secret", "secretId", secretID)
+	}
+
+	var violations []string
+
+	if !currentSecret.Rotatable {
+		violations = append(violations, "secrets of type "+currentSecret.Type+" cannot be rotated")
+	}
+
+	if currentSecret.ReadOnly {
+		violations = append(violations, "secret is read only")
+	}
+
+	if len(violations) > 0 {
+		return secret.NewValidationError("secret cannot be rotated", violations)
+	}
+
+	return nil
+}
+
+// rotatePolicy rotates a secret and schedules its next rotation.
+func (s service) rotatePolicy(ctx context.Context, policy Policy, rotatedBy string) error {
+	if err := s.rotate(ctx, policy.OrganizationID, policy.SecretID, rotatedBy); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	policy.LastRotatedAt = &now
+	policy.NextRotationAt = now.Add(policy.Interval)
+
+	_, err := s.store.Put(ctx, policy)
+
+	return err
+}
+
+func (s service) rotate(ctx context.Context, organizationID uint, secretID string, rotatedBy string) error {
+	if err := s.secrets.RotateSecret(ctx, organizationID, secretID, rotatedBy); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to rotate secret", "secretId", secretID)
+	}
+
+	s.logger.Info("secret rotated", map[string]interface{}{"organizationId": organizationID, "secretId": secretID})
+
+	return nil
+}
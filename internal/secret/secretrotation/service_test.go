@@ -0,0 +1,190 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretrotation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+func TestPolicySpec_Validate(t *testing.T) {
+	assert.NoError(t, PolicySpec{Interval: 24 * time.Hour}.Validate())
+	assert.True(t, secret.IsValidationError(PolicySpec{Interval: time.Minute}.Validate()))
+	assert.True(t, secret.IsValidationError(PolicySpec{}.Validate()))
+}
+
+func TestService_SetPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("Get", ctx, uint(1), "secret").Return(Policy{}, NotFoundError{OrganizationID: 1, SecretID: "secret"})
+	store.On("Put", ctx, mock.MatchedBy(func(p Policy) bool {
+		return p.OrganizationID == 1 && p.SecretID == "secret" && p.Interval == 24*time.Hour &&
+			p.NextRotationAt.After(time.Now().Add(23*time.Hour))
+	})).Return(func(_ context.Context, p Policy) Policy { return p }, nil)
+
+	secrets := new(MockSecretStore)
+	secrets.On("GetSecret", ctx, uint(1), "secret").Return(Secret{ID: "secret", Type: "password", Rotatable: true}, nil)
+
+	service := NewService(store, secrets, common.NoopLogger{})
+
+	policy, err := service.SetPolicy(ctx, 1, "secret", PolicySpec{Interval: 24 * time.Hour})
+	require.NoError(t, err)
+
+	assert.Equal(t, 24*time.Hour, policy.Interval)
+	assert.Nil(t, policy.LastRotatedAt)
+
+	store.AssertExpectations(t)
+	secrets.AssertExpectations(t)
+}
+
+func TestService_SetPolicy_NotRotatable(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		secret Secret
+	}{
+		{
+			name:   "Type",
+			secret: Secret{ID: "secret", Type: "amazon"},
+		},
+		{
+			name:   "ReadOnly",
+			secret: Secret{ID: "secret", Type: "password", Rotatable: true, ReadOnly: true},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			store := new(MockStore)
+
+			secrets := new(MockSecretStore)
+			secrets.On("GetSecret", ctx, uint(1), "secret").Return(test.secret, nil)
+
+			service := NewService(store, secrets, common.NoopLogger{})
+
+			_, err := service.SetPolicy(ctx, 1, "secret", PolicySpec{Interval: 24 * time.Hour})
+			assert.True(t, secret.IsValidationError(err))
+
+			store.AssertExpectations(t)
+			secrets.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_RotateSecret(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("Get", ctx, uint(1), "secret").Return(Policy{OrganizationID: 1, SecretID: "secret", Interval: time.Hour}, nil)
+	store.On("Put", ctx, mock.MatchedBy(func(p Policy) bool {
+		return p.LastRotatedAt != nil && p.NextRotationAt.Equal(p.LastRotatedAt.Add(time.Hour))
+	})).Return(Policy{}, nil)
+
+	secrets := new(MockSecretStore)
+	secrets.On("GetSecret", ctx, uint(1), "secret").Return(Secret{ID: "secret", Type: "tls", Rotatable: true}, nil)
+	secrets.On("RotateSecret", ctx, uint(1), "secret", "john").Return(nil)
+
+	service := NewService(store, secrets, common.NoopLogger{})
+
+	err := service.RotateSecret(ctx, 1, "secret", "john")
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+	secrets.AssertExpectations(t)
+}
+
+func TestService_RotateDueSecret(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Due", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("Get", ctx, uint(1), "secret").Return(Policy{OrganizationID: 1, SecretID: "secret", Interval: time.Hour, NextRotationAt: time.Now().Add(-time.Minute)}, nil)
+		store.On("Put", ctx, mock.AnythingOfType("Policy")).Return(Policy{}, nil)
+
+		secrets := new(MockSecretStore)
+		secrets.On("GetSecret", ctx, uint(1), "secret").Return(Secret{ID: "secret", Type: "htpasswd", Rotatable: true}, nil)
+		secrets.On("RotateSecret", ctx, uint(1), "secret", AutomaticRotationUser).Return(nil)
+
+		service := NewService(store, secrets, common.NoopLogger{})
+
+		err := service.RotateDueSecret(ctx, 1, "secret")
+		require.NoError(t, err)
+
+		store.AssertExpectations(t)
+		secrets.AssertExpectations(t)
+	})
+
+	t.Run("NotDue", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("Get", ctx, uint(1), "secret").Return(Policy{OrganizationID: 1, SecretID: "secret", Interval: time.Hour, NextRotationAt: time.Now().Add(time.Minute)}, nil)
+
+		secrets := new(MockSecretStore)
+
+		service := NewService(store, secrets, common.NoopLogger{})
+
+		err := service.RotateDueSecret(ctx, 1, "secret")
+		require.NoError(t, err)
+
+		store.AssertExpectations(t)
+		secrets.AssertExpectations(t)
+	})
+
+	t.Run("DeletedSecret", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("Get", ctx, uint(1), "secret").Return(Policy{OrganizationID: 1, SecretID: "secret", Interval: time.Hour}, nil)
+		store.On("Delete", ctx, uint(1), "secret").Return(nil)
+
+		secrets := new(MockSecretStore)
+		secrets.On("GetSecret", ctx, uint(1), "secret").Return(Secret{}, secret.NotFoundError{OrganizationID: 1, SecretID: "secret"})
+
+		service := NewService(store, secrets, common.NoopLogger{})
+
+		err := service.RotateDueSecret(ctx, 1, "secret")
+		require.NoError(t, err)
+
+		store.AssertExpectations(t)
+		secrets.AssertExpectations(t)
+	})
+}
+
+func TestService_PruneSecret(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("Delete", ctx, uint(1), "secret").Return(NotFoundError{OrganizationID: 1, SecretID: "secret"})
+
+	secrets := new(MockSecretStore)
+	secrets.On("GetSecret", ctx, uint(1), "secret").Return(Secret{}, secret.NotFoundError{OrganizationID: 1, SecretID: "secret"})
+
+	service := NewService(store, secrets, common.NoopLogger{})
+
+	err := service.PruneSecret(ctx, 1, "secret")
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+	secrets.AssertExpectations(t)
+}
@@ -0,0 +1,70 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/banzaicloud/pipeline/internal/secret/secretrotation"
+)
+
+// ListDueSecretsActivityName is the name the ListDueSecretsActivity is registered under
+const ListDueSecretsActivityName = "secret-rotation-list-due-secrets"
+
+// PolicyLister lists secret rotation policies.
+type PolicyLister interface {
+	// ListDue lists the secret rotation policies of every organization that are due at the specified time.
+	ListDue(ctx context.Context, now time.Time) ([]secretrotation.Policy, error)
+}
+
+// SecretRef identifies a secret.
+type SecretRef struct {
+	OrganizationID uint
+	SecretID       string
+}
+
+// ListDueSecretsActivityInput defines the inputs of the ListDueSecretsActivity
+type ListDueSecretsActivityInput struct{}
+
+// ListDueSecretsActivity lists the secrets to be rotated
+type ListDueSecretsActivity struct {
+	policies PolicyLister
+}
+
+// MakeListDueSecretsActivity returns a new ListDueSecretsActivity
+func MakeListDueSecretsActivity(policies PolicyLister) ListDueSecretsActivity {
+	return ListDueSecretsActivity{
+		policies: policies,
+	}
+}
+
+// Execute executes the activity
+func (a ListDueSecretsActivity) Execute(ctx context.Context, _ ListDueSecretsActivityInput) ([]SecretRef, error) {
+	policies, err := a.policies.ListDue(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]SecretRef, 0, len(policies))
+	for _, policy := range policies {
+		refs = append(refs, SecretRef{
+			OrganizationID: policy.OrganizationID,
+			SecretID:       policy.SecretID,
+		})
+	}
+
+	return refs, nil
+}
@@ -0,0 +1,59 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+// RotateSecretActivityName is the name the RotateSecretActivity is registered under
+const RotateSecretActivityName = "secret-rotation-rotate-secret"
+
+// SecretRotator rotates secrets.
+type SecretRotator interface {
+	// RotateDueSecret regenerates the values of a secret if its rotation policy is due.
+	RotateDueSecret(ctx context.Context, organizationID uint, secretID string) error
+}
+
+// RotateSecretActivityInput defines the inputs of the RotateSecretActivity
+type RotateSecretActivityInput struct {
+	OrganizationID uint
+	SecretID       string
+}
+
+// RotateSecretActivity rotates a secret
+type RotateSecretActivity struct {
+	secrets SecretRotator
+}
+
+// MakeRotateSecretActivity returns a new RotateSecretActivity
+func MakeRotateSecretActivity(secrets SecretRotator) RotateSecretActivity {
+	return RotateSecretActivity{
+		secrets: secrets,
+	}
+}
+
+// Execute executes the activity
+func (a RotateSecretActivity) Execute(ctx context.Context, input RotateSecretActivityInput) error {
+	err := a.secrets.RotateDueSecret(ctx, input.OrganizationID, input.SecretID)
+	if secret.IsNotFoundError(err) {
+		// the policy has been deleted since it was listed
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,67 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+)
+
+// RotationWorkflowName is the name the RotationWorkflow is registered under
+const RotationWorkflowName = "secret-rotation"
+
+// RotationWorkflowID is the fixed ID of the scheduled RotationWorkflow
+const RotationWorkflowID = "secret-rotation"
+
+// RotationWorkflowInput defines the inputs of the RotationWorkflow
+type RotationWorkflowInput struct{}
+
+// RotationWorkflow rotates every secret whose rotation policy is due.
+func RotationWorkflow(ctx workflow.Context, _ RotationWorkflowInput) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+	})
+
+	logger := workflow.GetLogger(ctx)
+
+	var secrets []SecretRef
+	if err := workflow.ExecuteActivity(ctx, ListDueSecretsActivityName, ListDueSecretsActivityInput{}).Get(ctx, &secrets); err != nil {
+		return err
+	}
+
+	futures := make([]workflow.Future, len(secrets))
+	for i, secret := range secrets {
+		futures[i] = workflow.ExecuteActivity(ctx, RotateSecretActivityName, RotateSecretActivityInput{
+			OrganizationID: secret.OrganizationID,
+			SecretID:       secret.SecretID,
+		})
+	}
+
+	for i, future := range futures {
+		if err := future.Get(ctx, nil); err != nil {
+			logger.Error(
+				"failed to rotate secret",
+				zap.Uint("organizationId", secrets[i].OrganizationID),
+				zap.String("secretId", secrets[i].SecretID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
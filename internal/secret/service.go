@@ -47,6 +47,35 @@ func (NotFoundError) ServiceError() bool {
 	return true
 }
 
+// VersionNotFoundError is returned when a secret version cannot be found (or it has been deleted).
+type VersionNotFoundError struct {
+	OrganizationID uint
+	SecretID       string
+	Version        int
+}
+
+// Error implements the error interface.
+func (VersionNotFoundError) Error() string {
+	return "secret version not found"
+}
+
+// Details returns error details.
+func (e VersionNotFoundError) Details() []interface{} {
+	return []interface{}{"organizationId", e.OrganizationID, "secretId", e.SecretID, "version", e.Version}
+}
+
+// NotFound tells a consumer that this error is related to a resource being not found.
+// Can be used to translate the error to the consumer's response format (eg. status codes).
+func (VersionNotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the consumer that this is a business error and it should be returned to the client.
+// Non-service errors are usually translated into "internal" errors.
+func (VersionNotFoundError) ServiceError() bool {
+	return true
+}
+
 // AlreadyExistsError is returned when a secret already exists in the store.
 type AlreadyExistsError struct {
 	OrganizationID uint
@@ -82,6 +111,7 @@ type Model struct {
 	Type      string            `mapstructure:"type"`
 	Values    map[string]string `mapstructure:"values"`
 	Tags      []string          `mapstructure:"tags"`
+	Version   int               `mapstructure:"-"`
 	UpdatedAt time.Time         `mapstructure:"-"`
 	UpdatedBy string            `mapstructure:"updatedBy"`
 }
@@ -104,4 +134,21 @@ type Store interface {
 
 	// Delete deletes a secret from the store.
 	Delete(ctx context.Context, organizationID uint, id string) error
+
+	// ListVersions lists the available versions of a secret (the oldest first).
+	//
+	// The returned models are only guaranteed to contain the version, its update time and its updater.
+	// Use GetVersion to read the content of a version.
+	ListVersions(ctx context.Context, organizationID uint, id string) ([]Model, error)
+
+	// GetVersion retrieves a specific version of a secret from the store.
+	GetVersion(ctx context.Context, organizationID uint, id string, version int) (Model, error)
+}
+
+// VersionImporter is implemented by stores that can import the version history of a secret as is.
+type VersionImporter interface {
+	// ImportVersions writes every version of a new secret (the oldest first) keeping their version numbers and timestamps.
+	//
+	// ImportVersions returns a AlreadyExistsError if the secret already exists.
+	ImportVersions(ctx context.Context, organizationID uint, versions []Model) error
 }
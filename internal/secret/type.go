@@ -56,6 +56,24 @@ type GeneratorType interface {
 	Generate(organizationID uint, secretName string, data map[string]string, tags []string) (map[string]string, error)
 }
 
+// RotatorType can be implemented by a generator type that can regenerate the values of an existing secret.
+//
+// Rotating a secret generates new values from the seed of the current values.
+type RotatorType interface {
+	GeneratorType
+
+	// RotationSeed returns the values Generate should be called with to regenerate an existing secret.
+	RotationSeed(data map[string]string) map[string]string
+}
+
+// CertificateType can be implemented by a secret type that stores X.509 certificates.
+//
+// Certificates are tracked so that clients can be warned about their expiry.
+type CertificateType interface {
+	// Certificates returns the PEM encoded certificates stored in a secret keyed by their name.
+	Certificates(data map[string]string) (map[string][]byte, error)
+}
+
 // ProcessorType can be implemented by a secret type that adds secret processing abilities to the type.
 //
 // Secret processing is done when a secret is created or updated (eg. making sure a secret is in a specific format).
@@ -0,0 +1,28 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// certificateFields returns the non-empty certificate fields of a secret.
+func certificateFields(data map[string]string, fields ...string) map[string][]byte {
+	certificates := make(map[string][]byte, len(fields))
+
+	for _, field := range fields {
+		if value := data[field]; value != "" {
+			certificates[field] = []byte(value)
+		}
+	}
+
+	return certificates
+}
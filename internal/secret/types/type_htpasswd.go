@@ -96,6 +96,13 @@ func (t HtpasswdType) Generate(_ uint, _ string, data map[string]string, _ []str
 	return data, nil
 }
 
+// RotationSeed keeps the username only: the password and the htpasswd file are regenerated.
+func (t HtpasswdType) RotationSeed(data map[string]string) map[string]string {
+	return map[string]string{
+		FieldHtpasswdUsername: data[FieldHtpasswdUsername],
+	}
+}
+
 func (t HtpasswdType) Process(data map[string]string) (map[string]string, error) {
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(data[FieldHtpasswdPassword]), bcrypt.DefaultCost)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 func TestHtpasswdType(t *testing.T) {
 	assert.Implements(t, (*secret.Type)(nil), new(HtpasswdType))
 	assert.Implements(t, (*secret.GeneratorType)(nil), new(HtpasswdType))
+	assert.Implements(t, (*secret.RotatorType)(nil), new(HtpasswdType))
 }
 
 func TestHtpasswdType_Validate(t *testing.T) {
@@ -149,3 +150,22 @@ func TestHtpasswdType_Generate(t *testing.T) {
 func TestHtpasswdType_Process(t *testing.T) {
 	// TODO
 }
+
+func TestHtpasswdType_RotationSeed(t *testing.T) {
+	typ := HtpasswdType{}
+
+	seed := typ.RotationSeed(map[string]string{
+		FieldHtpasswdUsername: "user",
+		FieldHtpasswdPassword: "password",
+		FieldHtpasswdFile:     "user:hash",
+	})
+
+	assert.Equal(t, map[string]string{FieldHtpasswdUsername: "user"}, seed)
+
+	complete, err := typ.ValidateNew(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, complete)
+}
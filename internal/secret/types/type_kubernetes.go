@@ -18,6 +18,7 @@ import (
 	"encoding/base64"
 
 	"emperror.dev/errors"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/banzaicloud/pipeline/internal/secret"
 	"github.com/banzaicloud/pipeline/pkg/k8sclient"
@@ -55,6 +56,32 @@ func (t KubernetesType) Process(data map[string]string) (map[string]string, erro
 	return data, nil
 }
 
+// Certificates returns the CA certificates of the clusters in the Kubernetes config.
+func (KubernetesType) Certificates(data map[string]string) (map[string][]byte, error) {
+	if data[FieldKubernetesConfig] == "" {
+		return nil, nil
+	}
+
+	kubeConfig, err := base64.StdEncoding.DecodeString(data[FieldKubernetesConfig])
+	if err != nil {
+		return nil, errors.WrapIf(err, "can't decode Kubernetes config")
+	}
+
+	config, err := clientcmd.Load(kubeConfig)
+	if err != nil {
+		return nil, errors.WrapIf(err, "can't parse Kubernetes config")
+	}
+
+	certificates := make(map[string][]byte, len(config.Clusters))
+	for name, cluster := range config.Clusters {
+		if len(cluster.CertificateAuthorityData) > 0 {
+			certificates[name] = cluster.CertificateAuthorityData
+		}
+	}
+
+	return certificates, nil
+}
+
 // TODO: rewrite this function!
 func (KubernetesType) Verify(data map[string]string) error {
 	err := kubernetesVerify(data)
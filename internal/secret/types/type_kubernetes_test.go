@@ -15,9 +15,13 @@
 package types
 
 import (
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/banzaicloud/bank-vaults/pkg/sdk/tls"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -28,6 +32,7 @@ func TestKubernetesType(t *testing.T) {
 	assert.Implements(t, (*secret.Type)(nil), new(KubernetesType))
 	assert.Implements(t, (*secret.ProcessorType)(nil), new(KubernetesType))
 	assert.Implements(t, (*secret.VerifierType)(nil), new(KubernetesType))
+	assert.Implements(t, (*secret.CertificateType)(nil), new(KubernetesType))
 }
 
 func TestKubernetesType_Validate(t *testing.T) {
@@ -116,3 +121,35 @@ func TestKubernetesType_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestKubernetesType_Certificates(t *testing.T) {
+	cc, err := tls.GenerateTLS("localhost", "24h")
+	require.NoError(t, err)
+
+	kubeConfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://localhost:6443
+    certificate-authority-data: %s
+`, base64.StdEncoding.EncodeToString([]byte(cc.CACert)))
+
+	typ := KubernetesType{}
+
+	data := map[string]string{
+		FieldKubernetesConfig: base64.StdEncoding.EncodeToString([]byte(kubeConfig)),
+	}
+
+	certificates, err := typ.Certificates(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]byte{"cluster": []byte(cc.CACert)}, certificates)
+
+	expiry, ok, err := secret.GetCertificateExpiry(typ, data)
+	require.NoError(t, err)
+
+	assert.True(t, ok)
+	assert.Equal(t, "cluster", expiry.Name)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), expiry.NotAfter, time.Hour)
+}
@@ -109,6 +109,19 @@ func (t PasswordType) Generate(_ uint, _ string, data map[string]string, _ []str
 	return data, nil
 }
 
+// RotationSeed keeps the username and generates an alphanumeric password with the length of the current one.
+func (t PasswordType) RotationSeed(data map[string]string) map[string]string {
+	password := defaultPasswordFormat
+	if length := len(data[FieldPasswordPassword]); length > 0 {
+		password = fmt.Sprintf("randAlphaNum,%d", length)
+	}
+
+	return map[string]string{
+		FieldPasswordUsername: data[FieldPasswordUsername],
+		FieldPasswordPassword: password,
+	}
+}
+
 // passwordRandomString creates a random string whose length is the number of characters specified.
 // TODO: reuse random function (or use single, struct level password generator in the type?).
 func passwordRandomString(genType string, length int) (res string, err error) {
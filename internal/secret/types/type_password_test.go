@@ -26,6 +26,7 @@ import (
 func TestPasswordType(t *testing.T) {
 	assert.Implements(t, (*secret.Type)(nil), new(PasswordType))
 	assert.Implements(t, (*secret.GeneratorType)(nil), new(PasswordType))
+	assert.Implements(t, (*secret.RotatorType)(nil), new(PasswordType))
 }
 
 func TestPasswordType_Validate(t *testing.T) {
@@ -152,3 +153,29 @@ func TestPasswordType_ValidateNew(t *testing.T) {
 func TestPasswordType_Generate(t *testing.T) {
 	// TODO
 }
+
+func TestPasswordType_RotationSeed(t *testing.T) {
+	typ := PasswordType{}
+
+	seed := typ.RotationSeed(map[string]string{
+		FieldPasswordUsername: "user",
+		FieldPasswordPassword: "0123456789abcdef",
+	})
+
+	assert.Equal(
+		t,
+		map[string]string{
+			FieldPasswordUsername: "user",
+			FieldPasswordPassword: "randAlphaNum,16",
+		},
+		seed,
+	)
+
+	data, err := typ.Generate(0, "", seed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, data[FieldPasswordPassword], 16)
+	assert.NotEqual(t, "0123456789abcdef", data[FieldPasswordPassword])
+}
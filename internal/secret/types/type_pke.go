@@ -93,3 +93,8 @@ func (t PKEType) Cleanup(organizationID uint, _ map[string]string, tags []string
 
 	return nil
 }
+
+// Certificates returns the CA certificates of the cluster.
+func (PKEType) Certificates(data map[string]string) (map[string][]byte, error) {
+	return certificateFields(data, FieldPKECACert, FieldPKEKubernetesCACert, FieldPKEEtcdCACert, FieldPKEFrontProxyCACert), nil
+}
@@ -25,6 +25,7 @@ import (
 func TestPKEType(t *testing.T) {
 	assert.Implements(t, (*secret.Type)(nil), new(PKEType))
 	assert.Implements(t, (*secret.GeneratorType)(nil), new(PKEType))
+	assert.Implements(t, (*secret.CertificateType)(nil), new(PKEType))
 	assert.Implements(t, (*secret.CleanupType)(nil), new(PKEType))
 }
 
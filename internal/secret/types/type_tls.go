@@ -137,3 +137,21 @@ func (t TLSType) Generate(_ uint, _ string, data map[string]string, _ []string)
 
 	return data, nil
 }
+
+// RotationSeed keeps the hosts and the validity: every certificate (including the CA) is regenerated.
+func (t TLSType) RotationSeed(data map[string]string) map[string]string {
+	seed := map[string]string{
+		FieldTLSHosts: data[FieldTLSHosts],
+	}
+
+	if validity, ok := data[FieldTLSValidity]; ok {
+		seed[FieldTLSValidity] = validity
+	}
+
+	return seed
+}
+
+// Certificates returns the CA and the server, client and peer certificates.
+func (t TLSType) Certificates(data map[string]string) (map[string][]byte, error) {
+	return certificateFields(data, FieldTLSCACert, FieldTLSServerCert, FieldTLSClientCert, FieldTLSPeerCert), nil
+}
@@ -17,6 +17,7 @@ package types
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -26,6 +27,8 @@ import (
 func TestTLSType(t *testing.T) {
 	assert.Implements(t, (*secret.Type)(nil), new(TLSType))
 	assert.Implements(t, (*secret.GeneratorType)(nil), new(TLSType))
+	assert.Implements(t, (*secret.RotatorType)(nil), new(TLSType))
+	assert.Implements(t, (*secret.CertificateType)(nil), new(TLSType))
 }
 
 func TestTLSType_Validate(t *testing.T) {
@@ -191,3 +194,59 @@ func TestTLSType_ValidateNew(t *testing.T) {
 func TestTLSType_Generate(t *testing.T) {
 	// TODO
 }
+
+func TestTLSType_RotationSeed(t *testing.T) {
+	typ := TLSType{}
+
+	seed := typ.RotationSeed(map[string]string{
+		FieldTLSHosts:      "localhost",
+		FieldTLSValidity:   "1h",
+		FieldTLSCACert:     "ca",
+		FieldTLSServerKey:  "key",
+		FieldTLSServerCert: "cert",
+	})
+
+	assert.Equal(
+		t,
+		map[string]string{
+			FieldTLSHosts:    "localhost",
+			FieldTLSValidity: "1h",
+		},
+		seed,
+	)
+
+	complete, err := typ.ValidateNew(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, complete)
+}
+
+func TestTLSType_Certificates(t *testing.T) {
+	typ := TLSType{}
+
+	data, err := typ.Generate(0, "", map[string]string{FieldTLSHosts: "localhost", FieldTLSValidity: "48h"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificates, err := typ.Certificates(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, certificates, FieldTLSCACert)
+	assert.Contains(t, certificates, FieldTLSServerCert)
+	assert.NotContains(t, certificates, FieldTLSServerKey)
+
+	expiry, ok, err := secret.GetCertificateExpiry(typ, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(48*time.Hour), expiry.NotAfter, time.Hour)
+	assert.Equal(t, 1, expiry.DaysToExpiry(time.Now()))
+	assert.Equal(t, -1, expiry.DaysToExpiry(time.Now().Add(49*time.Hour)))
+}
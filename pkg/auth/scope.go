@@ -0,0 +1,152 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+// DefaultTokenScope is the scope claim of unrestricted API tokens.
+const DefaultTokenScope = "api:invoke"
+
+// Token access levels
+const (
+	// TokenAccessRead restricts a token to read-only actions.
+	TokenAccessRead = "read"
+
+	// TokenAccessDeploy restricts a token to read-only actions and managing deployments.
+	TokenAccessDeploy = "deploy"
+)
+
+// Scope claim item prefixes
+const (
+	scopeOrganizationPrefix = "org:"
+	scopeClusterPrefix      = "cluster:"
+	scopeAccessPrefix       = "access:"
+)
+
+// TokenScopes restricts what an API token can be used for.
+// The zero value means the token is not restricted.
+type TokenScopes struct {
+	// OrganizationID restricts the token to a single organization.
+	OrganizationID uint `json:"organizationId,omitempty"`
+
+	// Clusters restricts the token to clusters matching any of the listed IDs or name patterns.
+	Clusters []string `json:"clusters,omitempty"`
+
+	// Access restricts the token to read-only or deploy-only actions.
+	Access string `json:"access,omitempty"`
+}
+
+// IsZero tells whether the token is unrestricted.
+func (s TokenScopes) IsZero() bool {
+	return s.OrganizationID == 0 && len(s.Clusters) == 0 && s.Access == ""
+}
+
+// AllowsOrganization tells whether the token can be used in an organization.
+func (s TokenScopes) AllowsOrganization(orgID uint) bool {
+	return s.OrganizationID == 0 || s.OrganizationID == orgID
+}
+
+// MatchesCluster tells whether a cluster (identified by its ID and name) is listed in the token's cluster scope.
+func (s TokenScopes) MatchesCluster(id uint, name string) bool {
+	if len(s.Clusters) == 0 {
+		return true
+	}
+
+	clusterID := strconv.FormatUint(uint64(id), 10)
+
+	for _, ref := range s.Clusters {
+		if ref == clusterID {
+			return true
+		}
+
+		if matched, _ := path.Match(ref, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks the scopes for invalid values.
+func (s TokenScopes) Validate() error {
+	switch s.Access {
+	case "", TokenAccessRead, TokenAccessDeploy:
+	default:
+		return errors.NewWithDetails("invalid token access", "access", s.Access)
+	}
+
+	for _, ref := range s.Clusters {
+		if ref == "" || strings.ContainsAny(ref, " \t\n") {
+			return errors.NewWithDetails("invalid cluster reference in token scope", "cluster", ref)
+		}
+
+		if _, err := path.Match(ref, ""); err != nil {
+			return errors.WrapIfWithDetails(err, "invalid cluster pattern in token scope", "cluster", ref)
+		}
+	}
+
+	return nil
+}
+
+// String encodes the scopes as a space separated scope claim.
+func (s TokenScopes) String() string {
+	items := []string{DefaultTokenScope}
+
+	if s.OrganizationID != 0 {
+		items = append(items, scopeOrganizationPrefix+strconv.FormatUint(uint64(s.OrganizationID), 10))
+	}
+
+	for _, ref := range s.Clusters {
+		items = append(items, scopeClusterPrefix+ref)
+	}
+
+	if s.Access != "" {
+		items = append(items, scopeAccessPrefix+s.Access)
+	}
+
+	return strings.Join(items, " ")
+}
+
+// ParseTokenScopes decodes a space separated scope claim.
+// Unknown scope items are ignored.
+func ParseTokenScopes(scope string) (TokenScopes, error) {
+	var s TokenScopes
+
+	for _, item := range strings.Fields(scope) {
+		switch {
+		case strings.HasPrefix(item, scopeOrganizationPrefix):
+			orgID, err := strconv.ParseUint(strings.TrimPrefix(item, scopeOrganizationPrefix), 10, 32)
+			if err != nil {
+				return TokenScopes{}, errors.WrapIfWithDetails(err, "invalid organization in token scope", "scope", item)
+			}
+
+			s.OrganizationID = uint(orgID)
+
+		case strings.HasPrefix(item, scopeClusterPrefix):
+			s.Clusters = append(s.Clusters, strings.TrimPrefix(item, scopeClusterPrefix))
+
+		case strings.HasPrefix(item, scopeAccessPrefix):
+			s.Access = strings.TrimPrefix(item, scopeAccessPrefix)
+		}
+	}
+
+	return s, s.Validate()
+}
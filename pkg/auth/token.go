@@ -117,6 +117,21 @@ func NewJWTTokenGenerator(issuer string, audience string, signingKey string, opt
 
 // GenerateToken generates a JWT token.
 func (g JWTTokenGenerator) GenerateToken(sub string, expiresAt int64, tokenType string, tokenText string) (string, string, error) {
+	return g.GenerateScopedToken(sub, expiresAt, tokenType, tokenText, TokenScopes{})
+}
+
+// GenerateScopedToken generates a JWT token restricted by scopes.
+func (g JWTTokenGenerator) GenerateScopedToken(
+	sub string,
+	expiresAt int64,
+	tokenType string,
+	tokenText string,
+	scopes TokenScopes,
+) (string, string, error) {
+	if err := scopes.Validate(); err != nil {
+		return "", "", err
+	}
+
 	tokenID := g.idgen.Generate()
 
 	claims := struct {
@@ -136,7 +151,7 @@ func (g JWTTokenGenerator) GenerateToken(sub string, expiresAt int64, tokenType
 			Subject:   sub,
 			Id:        tokenID,
 		},
-		Scope: "api:invoke",
+		Scope: scopes.String(),
 		Type:  tokenType,
 		Text:  tokenText,
 	}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/banzaicloud/bank-vaults/pkg/sdk/auth"
+	"github.com/dgrijalva/jwt-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -116,3 +117,49 @@ func TestTokenManager_GenerateToken_NoStoreSecret(t *testing.T) {
 	assert.Equal(t, tokens[0].Name, "tokenName")
 	assert.Equal(t, tokens[0].Value, "")
 }
+
+func TestJWTTokenGenerator_GenerateScopedToken(t *testing.T) {
+	now := time.Date(2019, time.September, 20, 14, 44, 00, 00, time.UTC)
+
+	generator := NewJWTTokenGenerator(
+		"issuer",
+		"audience",
+		"signingKey",
+		TokenIDGenerator(idGeneratorStub{"id"}),
+		TokenGeneratorClock(clockStub{now}),
+	)
+
+	scopes := TokenScopes{
+		OrganizationID: 1,
+		Clusters:       []string{"42", "prod-*"},
+		Access:         TokenAccessDeploy,
+	}
+
+	_, signedToken, err := generator.GenerateScopedToken("user", NoExpiration, "token", "my_text", scopes)
+	require.NoError(t, err)
+
+	claims := struct {
+		jwt.StandardClaims
+
+		Scope string `json:"scope"`
+	}{}
+
+	_, err = jwt.ParseWithClaims(signedToken, &claims, func(*jwt.Token) (interface{}, error) {
+		return []byte("signingKey"), nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "api:invoke org:1 cluster:42 cluster:prod-* access:deploy", claims.Scope)
+
+	parsedScopes, err := ParseTokenScopes(claims.Scope)
+	require.NoError(t, err)
+
+	assert.Equal(t, scopes, parsedScopes)
+}
+
+func TestJWTTokenGenerator_GenerateScopedToken_Invalid(t *testing.T) {
+	generator := NewJWTTokenGenerator("issuer", "audience", "signingKey")
+
+	_, _, err := generator.GenerateScopedToken("user", NoExpiration, "token", "my_text", TokenScopes{Access: "write"})
+	require.Error(t, err)
+}
@@ -55,6 +55,7 @@ const (
 	Google     = "google"
 	Kubernetes = "kubernetes"
 	Oracle     = "oracle"
+	Simulated  = "simulated" // simulated clusters use the same name as distribution
 	Vsphere    = "vsphere"
 )
 
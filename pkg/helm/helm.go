@@ -96,4 +96,5 @@ type GetDeploymentResponse struct {
 	Updated      time.Time              `json:"updatedAt,omitempty"`
 	Notes        string                 `json:"notes"`
 	Values       map[string]interface{} `json:"values"`
+	Manifest     string                 `json:"manifest,omitempty"`
 }
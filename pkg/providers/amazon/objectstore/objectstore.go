@@ -15,6 +15,7 @@
 package objectstore
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"strings"
@@ -48,6 +49,15 @@ type objectStore struct {
 type Config struct {
 	Region string
 	Opts   []Option
+
+	// Endpoint overrides the default S3 endpoint (for S3 compatible object stores).
+	Endpoint string
+
+	// ForcePathStyle enables path style bucket addressing.
+	ForcePathStyle bool
+
+	// CACertificate is a PEM encoded CA bundle used for verifying the endpoint.
+	CACertificate []byte
 }
 
 // Credentials represents credentials necessary for access
@@ -64,14 +74,29 @@ func NewPlainObjectStore() (*objectStore, error) {
 
 // New returns an Object Store instance that manages Amazon S3 buckets.
 func New(config Config, credentials Credentials) (*objectStore, error) {
-	sess, err := session.NewSession(&aws.Config{
+	awsConfig := aws.Config{
 		Region: aws.String(config.Region),
 		Credentials: awsCredentials.NewStaticCredentials(
 			credentials.AccessKeyID,
 			credentials.SecretAccessKey,
 			"",
 		),
-	})
+		S3ForcePathStyle: aws.Bool(config.ForcePathStyle),
+	}
+
+	if config.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(config.Endpoint)
+	}
+
+	opts := session.Options{
+		Config: awsConfig,
+	}
+
+	if len(config.CACertificate) > 0 {
+		opts.CustomCABundle = bytes.NewReader(config.CACertificate)
+	}
+
+	sess, err := session.NewSessionWithOptions(opts)
 	if err != nil {
 		return nil, errors.WrapIf(err, "cloud not create AWS session")
 	}
@@ -121,6 +121,20 @@ func (cc *CloudConnection) GetActivityLogsClient() *ActivityLogsClient {
 	}
 }
 
+// AgentPoolsClient extends containerservice.AgentPoolsClient
+type AgentPoolsClient struct {
+	containerservice.AgentPoolsClient
+}
+
+// GetAgentPoolsClient returns a AgentPoolsClient instance
+func (cc *CloudConnection) GetAgentPoolsClient() *AgentPoolsClient {
+	return &AgentPoolsClient{
+		containerservice.AgentPoolsClient{
+			BaseClient: *cc.getContainerServiceBaseClient(),
+		},
+	}
+}
+
 // ContainerServicesClient extends containerservice.ContainerServicesClient
 type ContainerServicesClient struct {
 	containerservice.ContainerServicesClient
@@ -317,6 +331,20 @@ func (cc *CloudConnection) GetVirtualMachineScaleSetsClient() *VirtualMachineSca
 	}
 }
 
+// VirtualMachineScaleSetVMsClient extends compute.VirtualMachineScaleSetVMsClient
+type VirtualMachineScaleSetVMsClient struct {
+	compute.VirtualMachineScaleSetVMsClient
+}
+
+// GetVirtualMachineScaleSetVMsClient returns a VirtualMachineScaleSetVMsClient instance
+func (cc *CloudConnection) GetVirtualMachineScaleSetVMsClient() *VirtualMachineScaleSetVMsClient {
+	return &VirtualMachineScaleSetVMsClient{
+		compute.VirtualMachineScaleSetVMsClient{
+			BaseClient: *cc.getComputeBaseClient(),
+		},
+	}
+}
+
 // VirtualMachineSizesClient extends compute.VirtualMachineSizesClient
 type VirtualMachineSizesClient struct {
 	compute.VirtualMachineSizesClient
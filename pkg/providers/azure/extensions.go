@@ -67,6 +67,35 @@ func (client *GroupsClient) ListAll(ctx context.Context, filter string, top *int
 	return
 }
 
+// CreateOrUpdateAndWaitForIt creates or updates the specified agent pool and waits for the operation to finish, returning the resulting agent pool
+func (client *AgentPoolsClient) CreateOrUpdateAndWaitForIt(ctx context.Context, resourceGroupName, clusterName, agentPoolName string, params containerservice.AgentPool) (*containerservice.AgentPool, error) {
+	future, err := client.CreateOrUpdate(ctx, resourceGroupName, clusterName, agentPoolName, params)
+	if err != nil {
+		return nil, err
+	}
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return nil, err
+	}
+	res, err := future.Result(client.AgentPoolsClient)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// DeleteAndWaitForIt deletes the specified agent pool and waits for the operation to finish
+func (client *AgentPoolsClient) DeleteAndWaitForIt(ctx context.Context, resourceGroupName, clusterName, agentPoolName string) error {
+	future, err := client.Delete(ctx, resourceGroupName, clusterName, agentPoolName)
+	if err != nil {
+		return err
+	}
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return err
+	}
+	_, err = future.Result(client.AgentPoolsClient)
+	return err
+}
+
 // CreateOrUpdateAndWaitForIt creates or updates the specified cluster and waits for the operation to finish, returning the resulting cluster
 func (client *ManagedClustersClient) CreateOrUpdateAndWaitForIt(ctx context.Context, resourceGroupName, clusterName string, params *containerservice.ManagedCluster) (*containerservice.ManagedCluster, error) {
 	future, err := client.CreateOrUpdate(ctx, resourceGroupName, clusterName, *params)
@@ -88,6 +88,8 @@ func Enable(helmService helm.UnifiedReleaser) func(c *gin.Context) {
 				StorageAccount: request.StorageAccount,
 				ResourceGroup:  request.ResourceGroup,
 			},
+			OracleBucketProperties: request.OracleBucketProperties,
+			S3BucketProperties:     request.S3BucketProperties,
 		})
 		if err != nil {
 			err = errors.WrapIf(err, "could not persist bucket")
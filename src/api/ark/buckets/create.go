@@ -80,6 +80,12 @@ func Create(c *gin.Context) {
 		BucketName: request.BucketName,
 		Location:   request.Location,
 		SecretID:   request.SecretID,
+		AzureBucketProperties: api.AzureBucketProperties{
+			StorageAccount: request.StorageAccount,
+			ResourceGroup:  request.ResourceGroup,
+		},
+		OracleBucketProperties: request.OracleBucketProperties,
+		S3BucketProperties:     request.S3BucketProperties,
 	})
 	if err != nil {
 		err = errors.WrapIf(err, "could not persist bucket")
@@ -0,0 +1,103 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrations
+
+import (
+	"net/http"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/ark/api"
+	"github.com/banzaicloud/pipeline/internal/ark/migration"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter"
+	"github.com/banzaicloud/pipeline/internal/global"
+	"github.com/banzaicloud/pipeline/internal/platform/gin/correlationid"
+	"github.com/banzaicloud/pipeline/src/api/ark/common"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// Create starts migrating the workloads of the cluster to a target cluster
+func Create(workflowClient client.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logger := correlationid.Logger(common.Log, c)
+		logger.Info("starting cluster migration")
+
+		svc := common.GetARKService(c.Request)
+
+		var request api.CreateMigrationRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			err = errors.WrapIf(err, "could not parse request")
+			common.ErrorHandler.Handle(err)
+			common.ErrorResponse(c, err)
+			return
+		}
+
+		sourceCluster := svc.GetCluster()
+		if request.TargetClusterID == sourceCluster.GetID() {
+			err := errors.New("target cluster must differ from the source cluster")
+			common.ErrorHandler.Handle(err)
+			common.ErrorResponse(c, err)
+			return
+		}
+
+		_, err := svc.GetDeploymentsService().GetActiveDeployment()
+		if err != nil {
+			err = errors.WrapIf(err, "backup service is not enabled on the source cluster")
+			common.ErrorHandler.Handle(err)
+			common.ErrorResponse(c, err)
+			return
+		}
+
+		org := auth.GetCurrentOrganization(c.Request)
+
+		_, err = clusteradapter.NewClusters(global.DB()).FindOneByID(org.ID, request.TargetClusterID)
+		if err != nil {
+			err = errors.WrapIf(err, "could not find target cluster")
+			common.ErrorHandler.Handle(err)
+			common.ErrorResponse(c, err)
+			return
+		}
+
+		backupName := migration.GetBackupName(sourceCluster.GetID(), request.TargetClusterID, time.Now())
+
+		processID, err := migration.StartMigration(c.Request.Context(), workflowClient, migration.MigrateClusterWorkflowInput{
+			OrganizationID:  org.ID,
+			SourceClusterID: sourceCluster.GetID(),
+			TargetClusterID: request.TargetClusterID,
+			BackupName:      backupName,
+			Options: migration.Options{
+				IncludedNamespaces:  request.IncludedNamespaces,
+				ExcludedNamespaces:  request.ExcludedNamespaces,
+				NamespaceMapping:    request.NamespaceMapping,
+				StorageClassMapping: request.StorageClassMapping,
+			},
+		})
+		if err != nil {
+			err = errors.WrapIf(err, "could not start cluster migration")
+			common.ErrorHandler.Handle(err)
+			common.ErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, &api.CreateMigrationResponse{
+			ProcessID:  processID,
+			BackupName: backupName,
+			Status:     http.StatusAccepted,
+		})
+	}
+}
@@ -32,6 +32,7 @@ import (
 	clusterAuth "github.com/banzaicloud/pipeline/internal/cluster/auth"
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter"
 	eksdriver "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/driver"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulateddriver"
 	"github.com/banzaicloud/pipeline/internal/cluster/resourcesummary"
 	"github.com/banzaicloud/pipeline/internal/global"
 	azureDriver "github.com/banzaicloud/pipeline/internal/providers/azure/pke/driver"
@@ -66,12 +67,16 @@ type ClusterAPI struct {
 	helmService        cluster.HelmService
 	authConfig         auth.Config
 	clientSecretGetter clusterAuth.ClusterClientSecretGetter
+	healthChecker      ClusterHealthChecker
 }
 
 type ClusterCreators struct {
 	PKEOnAzure   azureDriver.ClusterCreator
 	EKSAmazon    eksdriver.EksClusterCreator
 	PKEOnVsphere vsphereDriver.VspherePKEClusterCreator
+
+	// Simulated is nil when the simulated distribution is disabled.
+	Simulated *simulateddriver.ClusterCreator
 }
 
 type ClusterDeleters struct {
@@ -100,6 +105,7 @@ func NewClusterAPI(
 	helmService cluster.HelmService,
 	authConfig auth.Config,
 	clientSecretGetter clusterAuth.ClusterClientSecretGetter,
+	healthChecker ClusterHealthChecker,
 ) *ClusterAPI {
 	return &ClusterAPI{
 		clusterManager:          clusterManager,
@@ -115,6 +121,7 @@ func NewClusterAPI(
 		helmService:             helmService,
 		authConfig:              authConfig,
 		clientSecretGetter:      clientSecretGetter,
+		healthChecker:           healthChecker,
 	}
 }
 
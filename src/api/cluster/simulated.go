@@ -0,0 +1,61 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated"
+	"github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+const Simulated = cluster.Simulated
+
+// CreateSimulatedClusterRequest describes a simulated cluster creation request.
+type CreateSimulatedClusterRequest struct {
+	Name              string              `json:"name"`
+	SecretId          string              `json:"secretId,omitempty"`
+	SecretName        string              `json:"secretName,omitempty"`
+	Type              string              `json:"type"`
+	KubernetesVersion string              `json:"kubernetesVersion,omitempty"`
+	Nodepools         []SimulatedNodePool `json:"nodepools"`
+}
+
+// SimulatedNodePool describes a node pool of a simulated cluster creation request.
+type SimulatedNodePool struct {
+	Name         string            `json:"name"`
+	Size         int               `json:"size"`
+	InstanceType string            `json:"instanceType,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+func (req CreateSimulatedClusterRequest) ToNewCluster(organizationID, userID uint) simulated.NewCluster {
+	nodePools := make([]simulated.NewNodePool, 0, len(req.Nodepools))
+	for _, np := range req.Nodepools {
+		nodePools = append(nodePools, simulated.NewNodePool{
+			Name:         np.Name,
+			Labels:       np.Labels,
+			Size:         np.Size,
+			InstanceType: np.InstanceType,
+		})
+	}
+
+	return simulated.NewCluster{
+		Name:              req.Name,
+		OrganizationID:    organizationID,
+		CreatedBy:         userID,
+		SecretID:          req.SecretId,
+		KubernetesVersion: req.KubernetesVersion,
+		NodePools:         nodePools,
+	}
+}
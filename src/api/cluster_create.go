@@ -66,11 +66,19 @@ func isInputValidationError(err error) bool {
 	return errors.As(err, &e) && e.InputValidationError()
 }
 
+func isValidationError(err error) bool {
+	var e interface {
+		Validation() bool
+	}
+
+	return errors.As(err, &e) && e.Validation()
+}
+
 func (a *ClusterAPI) handleCreationError(ctx *gin.Context, err error) {
 	a.errorHandler.Handle(err)
 
 	status := http.StatusInternalServerError
-	if isInputValidationError(err) || isInvalid(err) {
+	if isInputValidationError(err) || isValidationError(err) || isInvalid(err) {
 		status = http.StatusBadRequest
 	}
 	pkgCommon.ErrorResponseWithStatus(ctx, status, err)
@@ -176,6 +184,26 @@ func (a *ClusterAPI) CreateCluster(c *gin.Context) {
 			return
 		}
 		cluster = azurePKECluster
+	case clusterAPI.Simulated:
+		if a.clusterCreators.Simulated == nil {
+			ginutils.ReplyWithErrorResponse(c, &pkgCommon.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "simulated distribution is disabled",
+			})
+			return
+		}
+
+		var req clusterAPI.CreateSimulatedClusterRequest
+		if ok := a.parseRequest(c, requestBody, &req); !ok {
+			return
+		}
+		req.SecretId = secretID
+		simulatedCluster, err := a.clusterCreators.Simulated.Create(ctx, req.ToNewCluster(orgID, userID))
+		if err = errors.WrapIf(err, "failed to create cluster from request"); err != nil {
+			a.handleCreationError(c, err)
+			return
+		}
+		cluster = simulatedCluster
 	default:
 		ginutils.ReplyWithErrorResponse(c, &pkgCommon.ErrorResponse{
 			Code:    http.StatusBadRequest,
@@ -0,0 +1,182 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/gin-gonic/gin"
+
+	"github.com/banzaicloud/pipeline/internal/providers/kubernetes"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/banzaicloud/pipeline/src/cluster"
+)
+
+// ClusterHealthChecker checks the health of imported clusters.
+type ClusterHealthChecker interface {
+	// CheckClusterHealth probes an imported cluster and updates its status based on the result.
+	CheckClusterHealth(ctx context.Context, clusterID uint) (kubernetes.ClusterHealth, error)
+}
+
+// ClusterHealthResponse describes Pipeline's GetClusterHealth API response
+type ClusterHealthResponse struct {
+	Reachable     bool      `json:"reachable"`
+	Version       string    `json:"version,omitempty"`
+	Nodes         int       `json:"nodes"`
+	ReadyNodes    int       `json:"readyNodes"`
+	Error         string    `json:"error,omitempty"`
+	Status        string    `json:"status"`
+	StatusMessage string    `json:"statusMessage"`
+	CheckedAt     time.Time `json:"checkedAt"`
+}
+
+// RotateClusterCredentialsRequest describes Pipeline's RotateClusterCredentials API request
+type RotateClusterCredentialsRequest struct {
+	// Base64 encoded kubeconfig; the kubeconfig stored in the cluster secret is used when empty.
+	KubeConfig string `json:"kubeConfig"`
+}
+
+// GetClusterHealth probes an imported cluster and returns its health.
+func (a *ClusterAPI) GetClusterHealth(c *gin.Context) {
+	commonCluster, ok := a.clusterGetter.GetClusterFromRequest(c)
+	if !ok {
+		return
+	}
+
+	if !a.requireImportedCluster(c, commonCluster, "health checks are only supported for imported clusters") {
+		return
+	}
+
+	a.replyWithClusterHealth(c, commonCluster)
+}
+
+// RotateClusterCredentials replaces the kubeconfig used to access an imported cluster.
+func (a *ClusterAPI) RotateClusterCredentials(c *gin.Context) {
+	commonCluster, ok := a.clusterGetter.GetClusterFromRequest(c)
+	if !ok {
+		return
+	}
+
+	if !a.requireImportedCluster(c, commonCluster, cluster.ErrCredentialRotationNotSupported.Error()) {
+		return
+	}
+
+	var request RotateClusterCredentialsRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Error parsing request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	kubeConfig, err := base64.StdEncoding.DecodeString(request.KubeConfig)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Error decoding kubeconfig",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := a.clusterManager.RotateClusterCredentials(c.Request.Context(), commonCluster, kubeConfig); err != nil {
+		a.errorHandler.Handle(errors.WithDetails(err, "clusterId", commonCluster.GetID()))
+
+		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Error rotating cluster credentials",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	a.replyWithClusterHealth(c, commonCluster)
+}
+
+// DetachCluster removes the components installed by Pipeline from an imported cluster
+// and removes the cluster from Pipeline without deleting it.
+func (a *ClusterAPI) DetachCluster(c *gin.Context) {
+	commonCluster, ok := a.clusterGetter.GetClusterFromRequest(c)
+	if !ok {
+		return
+	}
+
+	if !a.requireImportedCluster(c, commonCluster, cluster.ErrDetachNotSupported.Error()) {
+		return
+	}
+
+	force := c.Query("force") == "true"
+
+	if err := a.clusterManager.DetachCluster(c.Request.Context(), commonCluster, force); err != nil {
+		a.errorHandler.Handle(errors.WithDetails(err, "clusterId", commonCluster.GetID()))
+
+		c.JSON(http.StatusInternalServerError, pkgCommon.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Error detaching cluster",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func (a *ClusterAPI) requireImportedCluster(c *gin.Context, commonCluster cluster.CommonCluster, message string) bool {
+	if commonCluster.GetCloud() == pkgCluster.Kubernetes {
+		return true
+	}
+
+	c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+		Code:    http.StatusBadRequest,
+		Message: message,
+		Error:   message,
+	})
+
+	return false
+}
+
+func (a *ClusterAPI) replyWithClusterHealth(c *gin.Context, commonCluster cluster.CommonCluster) {
+	health, err := a.healthChecker.CheckClusterHealth(c.Request.Context(), commonCluster.GetID())
+	if err != nil {
+		a.errorHandler.Handle(errors.WithDetails(err, "clusterId", commonCluster.GetID()))
+
+		c.JSON(http.StatusInternalServerError, pkgCommon.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Error checking cluster health",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	status, statusMessage := health.Status()
+
+	c.JSON(http.StatusOK, ClusterHealthResponse{
+		Reachable:     health.Reachable,
+		Version:       health.Version,
+		Nodes:         health.Nodes,
+		ReadyNodes:    health.ReadyNodes,
+		Error:         health.Error,
+		Status:        status,
+		StatusMessage: statusMessage,
+		CheckedAt:     health.CheckedAt,
+	})
+}
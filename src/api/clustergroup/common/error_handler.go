@@ -16,12 +16,15 @@ package common
 
 import (
 	"net/http"
+	"strings"
 
 	"emperror.dev/emperror"
+	"emperror.dev/errors"
 	"github.com/gin-gonic/gin"
 
 	cgroup "github.com/banzaicloud/pipeline/internal/clustergroup"
 	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
+	"github.com/banzaicloud/pipeline/internal/helm"
 	ginutils "github.com/banzaicloud/pipeline/internal/platform/gin/utils"
 	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
 	"github.com/banzaicloud/pipeline/src/api"
@@ -80,5 +83,14 @@ func (e ErrorHandler) errorResponseFrom(err error) *pkgCommon.ErrorResponse {
 		}
 	}
 
+	var validationErr helm.ValidationError
+	if errors.As(err, &validationErr) {
+		return &pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+			Message: validationErr.Error() + ": " + strings.Join(validationErr.Violations(), ", "),
+		}
+	}
+
 	return api.ErrorResponseFrom(err)
 }
@@ -104,6 +104,16 @@ func (a *OrganizationAPI) GetOrganizations(c *gin.Context) {
 	organization := auth.Organization{ID: uint(id)}
 	var organizations []auth.Organization
 
+	// Tokens restricted to an organization can list only that organization
+	if user.Scopes != nil && user.Scopes.OrganizationID != 0 {
+		if id != 0 && uint(id) != user.Scopes.OrganizationID {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		organization.ID = user.Scopes.OrganizationID
+	}
+
 	db := global.DB()
 
 	// Virtual users can list only the organization they are belonging to
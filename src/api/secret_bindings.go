@@ -0,0 +1,214 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"emperror.dev/emperror"
+	"emperror.dev/errors"
+	"github.com/gin-gonic/gin"
+
+	ginutils "github.com/banzaicloud/pipeline/internal/platform/gin/utils"
+	"github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/secretbinding"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// SecretBindingAPI implements the secret binding functions
+type SecretBindingAPI struct {
+	service      secretbinding.Service
+	errorHandler emperror.Handler
+}
+
+// NewSecretBindingAPI returns a new SecretBindingAPI instance
+func NewSecretBindingAPI(service secretbinding.Service, errorHandler emperror.Handler) *SecretBindingAPI {
+	return &SecretBindingAPI{
+		service:      service,
+		errorHandler: errorHandler,
+	}
+}
+
+// CreateSecretBindingRequest describes Pipeline's CreateSecretBinding API request
+type CreateSecretBindingRequest struct {
+	SecretID   string            `json:"secretId,omitempty"`
+	SecretTags []string          `json:"secretTags,omitempty"`
+	ClusterIDs []uint            `json:"clusterIds" binding:"required"`
+	Namespace  string            `json:"namespace" binding:"required"`
+	Name       string            `json:"name,omitempty"`
+	Keys       map[string]string `json:"keys,omitempty"`
+}
+
+// SecretBindingResponse describes a secret binding in Pipeline's API responses
+type SecretBindingResponse struct {
+	ID         uint                          `json:"id"`
+	SecretID   string                        `json:"secretId,omitempty"`
+	SecretTags []string                      `json:"secretTags,omitempty"`
+	ClusterIDs []uint                        `json:"clusterIds"`
+	Namespace  string                        `json:"namespace"`
+	Name       string                        `json:"name,omitempty"`
+	Keys       map[string]string             `json:"keys,omitempty"`
+	Status     []secretbinding.ClusterStatus `json:"status"`
+	CreatedAt  time.Time                     `json:"createdAt"`
+	UpdatedAt  time.Time                     `json:"updatedAt"`
+}
+
+// ListSecretBindings lists the secret bindings of an organization
+func (a *SecretBindingAPI) ListSecretBindings(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+
+	bindings, err := a.service.ListBindings(c.Request.Context(), organizationID)
+	if err != nil {
+		a.replyWithError(c, "Error listing secret bindings", err)
+		return
+	}
+
+	response := make([]SecretBindingResponse, 0, len(bindings))
+	for _, binding := range bindings {
+		response = append(response, secretBindingResponse(binding))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateSecretBinding creates a new secret binding and syncs it into the clusters
+func (a *SecretBindingAPI) CreateSecretBinding(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+
+	var request CreateSecretBindingRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Error parsing request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	binding, err := a.service.CreateBinding(c.Request.Context(), organizationID, secretbinding.Spec{
+		SecretID:   request.SecretID,
+		SecretTags: request.SecretTags,
+		ClusterIDs: request.ClusterIDs,
+		Namespace:  request.Namespace,
+		Name:       request.Name,
+		Keys:       request.Keys,
+	})
+	if err != nil {
+		a.replyWithError(c, "Error creating secret binding", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, secretBindingResponse(binding))
+}
+
+// GetSecretBinding returns a secret binding with its status
+func (a *SecretBindingAPI) GetSecretBinding(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+
+	bindingID, ok := ginutils.UintParam(c, "bindingId")
+	if !ok {
+		return
+	}
+
+	binding, err := a.service.GetBinding(c.Request.Context(), organizationID, bindingID)
+	if err != nil {
+		a.replyWithError(c, "Error getting secret binding", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, secretBindingResponse(binding))
+}
+
+// SyncSecretBinding re-applies a secret binding to its clusters
+func (a *SecretBindingAPI) SyncSecretBinding(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+
+	bindingID, ok := ginutils.UintParam(c, "bindingId")
+	if !ok {
+		return
+	}
+
+	binding, err := a.service.SyncBinding(c.Request.Context(), organizationID, bindingID)
+	if err != nil {
+		a.replyWithError(c, "Error syncing secret binding", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, secretBindingResponse(binding))
+}
+
+// DeleteSecretBinding deletes a secret binding and the Kubernetes secrets managed by it
+func (a *SecretBindingAPI) DeleteSecretBinding(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+
+	bindingID, ok := ginutils.UintParam(c, "bindingId")
+	if !ok {
+		return
+	}
+
+	if err := a.service.DeleteBinding(c.Request.Context(), organizationID, bindingID); err != nil {
+		a.replyWithError(c, "Error deleting secret binding", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (a *SecretBindingAPI) replyWithError(c *gin.Context, message string, err error) {
+	response := pkgCommon.ErrorResponse{
+		Code:    http.StatusInternalServerError,
+		Message: message,
+		Error:   err.Error(),
+	}
+
+	var validationErr secret.ValidationError
+
+	switch {
+	case errors.As(err, &validationErr):
+		response.Code = http.StatusBadRequest
+		response.Error = strings.Join(append([]string{err.Error()}, validationErr.Violations()...), "; ")
+
+	case secret.IsNotFoundError(err):
+		response.Code = http.StatusNotFound
+
+	default:
+		a.errorHandler.Handle(err)
+	}
+
+	c.AbortWithStatusJSON(response.Code, response)
+}
+
+func secretBindingResponse(binding secretbinding.Binding) SecretBindingResponse {
+	status := binding.Status
+	if status == nil {
+		status = []secretbinding.ClusterStatus{}
+	}
+
+	return SecretBindingResponse{
+		ID:         binding.ID,
+		SecretID:   binding.Spec.SecretID,
+		SecretTags: binding.Spec.SecretTags,
+		ClusterIDs: binding.Spec.ClusterIDs,
+		Namespace:  binding.Spec.Namespace,
+		Name:       binding.Spec.Name,
+		Keys:       binding.Spec.Keys,
+		Status:     status,
+		CreatedAt:  binding.CreatedAt,
+		UpdatedAt:  binding.UpdatedAt,
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"emperror.dev/emperror"
+	"emperror.dev/errors"
+	"github.com/gin-gonic/gin"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+	"github.com/banzaicloud/pipeline/internal/secret/secretrotation"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// SecretRotationAPI implements the secret rotation functions
+type SecretRotationAPI struct {
+	service      secretrotation.Service
+	errorHandler emperror.Handler
+}
+
+// NewSecretRotationAPI returns a new SecretRotationAPI instance
+func NewSecretRotationAPI(service secretrotation.Service, errorHandler emperror.Handler) *SecretRotationAPI {
+	return &SecretRotationAPI{
+		service:      service,
+		errorHandler: errorHandler,
+	}
+}
+
+// SetSecretRotationPolicyRequest describes Pipeline's SetSecretRotationPolicy API request
+type SetSecretRotationPolicyRequest struct {
+	Interval       string    `json:"interval" binding:"required"`
+	NextRotationAt time.Time `json:"nextRotationAt,omitempty"`
+}
+
+// SecretRotationPolicyResponse describes a secret rotation policy in Pipeline's API responses
+type SecretRotationPolicyResponse struct {
+	SecretID       string     `json:"secretId"`
+	Interval       string     `json:"interval"`
+	NextRotationAt time.Time  `json:"nextRotationAt"`
+	LastRotatedAt  *time.Time `json:"lastRotatedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// ListSecretRotationPolicies lists the secret rotation policies of an organization
+func (a *SecretRotationAPI) ListSecretRotationPolicies(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+
+	policies, err := a.service.ListPolicies(c.Request.Context(), organizationID)
+	if err != nil {
+		a.replyWithError(c, "Error listing secret rotation policies", err)
+		return
+	}
+
+	response := make([]SecretRotationPolicyResponse, 0, len(policies))
+	for _, policy := range policies {
+		response = append(response, secretRotationPolicyResponse(policy))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetSecretRotationPolicy returns the rotation policy of a secret
+func (a *SecretRotationAPI) GetSecretRotationPolicy(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+
+	policy, err := a.service.GetPolicy(c.Request.Context(), organizationID, getSecretID(c))
+	if err != nil {
+		a.replyWithError(c, "Error getting secret rotation policy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, secretRotationPolicyResponse(policy))
+}
+
+// SetSecretRotationPolicy creates or updates the rotation policy of a secret
+func (a *SecretRotationAPI) SetSecretRotationPolicy(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+
+	var request SetSecretRotationPolicyRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Error parsing request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	interval, err := time.ParseDuration(request.Interval)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Error parsing interval",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	policy, err := a.service.SetPolicy(c.Request.Context(), organizationID, getSecretID(c), secretrotation.PolicySpec{
+		Interval:       interval,
+		NextRotationAt: request.NextRotationAt,
+	})
+	if err != nil {
+		a.replyWithError(c, "Error setting secret rotation policy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, secretRotationPolicyResponse(policy))
+}
+
+// DeleteSecretRotationPolicy deletes the rotation policy of a secret
+func (a *SecretRotationAPI) DeleteSecretRotationPolicy(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+
+	if err := a.service.DeletePolicy(c.Request.Context(), organizationID, getSecretID(c)); err != nil {
+		a.replyWithError(c, "Error deleting secret rotation policy", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RotateSecret regenerates the values of a secret immediately
+func (a *SecretRotationAPI) RotateSecret(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+
+	err := a.service.RotateSecret(c.Request.Context(), organizationID, getSecretID(c), auth.GetCurrentUser(c.Request).Login)
+	if err != nil {
+		a.replyWithError(c, "Error rotating secret", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (a *SecretRotationAPI) replyWithError(c *gin.Context, message string, err error) {
+	response := pkgCommon.ErrorResponse{
+		Code:    http.StatusInternalServerError,
+		Message: message,
+		Error:   err.Error(),
+	}
+
+	var validationErr secret.ValidationError
+
+	switch {
+	case errors.As(err, &validationErr):
+		response.Code = http.StatusBadRequest
+		response.Error = strings.Join(append([]string{err.Error()}, validationErr.Violations()...), "; ")
+
+	case secret.IsNotFoundError(err):
+		response.Code = http.StatusNotFound
+
+	default:
+		a.errorHandler.Handle(err)
+	}
+
+	c.AbortWithStatusJSON(response.Code, response)
+}
+
+func secretRotationPolicyResponse(policy secretrotation.Policy) SecretRotationPolicyResponse {
+	return SecretRotationPolicyResponse{
+		SecretID:       policy.SecretID,
+		Interval:       policy.Interval.String(),
+		NextRotationAt: policy.NextRotationAt,
+		LastRotatedAt:  policy.LastRotatedAt,
+		CreatedAt:      policy.CreatedAt,
+		UpdatedAt:      policy.UpdatedAt,
+	}
+}
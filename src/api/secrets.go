@@ -320,6 +320,114 @@ func DeleteSecrets(c *gin.Context) {
 	}
 }
 
+// ListSecretVersions returns the available versions of a secret
+func ListSecretVersions(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+	secretID := getSecretID(c)
+
+	versions, err := restricted.GlobalSecretStore.ListVersions(organizationID, secretID)
+	if err != nil {
+		status := http.StatusBadRequest
+
+		if errors.Is(err, secret.ErrSecretNotExists) {
+			status = http.StatusNotFound
+		}
+
+		log.Errorf("Error during listing secret versions: %s", err.Error())
+		c.AbortWithStatusJSON(status, common.ErrorResponse{
+			Code:    status,
+			Message: "Error during listing secret versions",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, versions)
+}
+
+// GetSecretVersion returns a specific version of a secret (with its opaque values hidden)
+func GetSecretVersion(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+	secretID := getSecretID(c)
+
+	version, ok := getSecretVersion(c)
+	if !ok {
+		return
+	}
+
+	s, err := restricted.GlobalSecretStore.GetVersion(organizationID, secretID, version)
+	if err != nil {
+		status := http.StatusBadRequest
+
+		if errors.Is(err, secret.ErrSecretNotExists) || errors.Is(err, secret.ErrSecretVersionNotExists) {
+			status = http.StatusNotFound
+		}
+
+		log.Errorf("Error during getting secret version: %s", err.Error())
+		c.AbortWithStatusJSON(status, common.ErrorResponse{
+			Code:    status,
+			Message: "Error during getting secret version",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, s)
+}
+
+// RestoreSecretVersion writes a previous version of a secret as its latest version
+func RestoreSecretVersion(c *gin.Context) {
+	organizationID := auth.GetCurrentOrganization(c.Request).ID
+	secretID := getSecretID(c)
+
+	version, ok := getSecretVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := restricted.GlobalSecretStore.RestoreVersion(organizationID, secretID, version, auth.GetCurrentUser(c.Request).Login); err != nil {
+		statusCode := http.StatusInternalServerError
+
+		switch {
+		case errors.Is(err, secret.ErrSecretNotExists), errors.Is(err, secret.ErrSecretVersionNotExists):
+			statusCode = http.StatusNotFound
+		case errors.As(err, &restricted.ReadOnlyError{}), errors.As(err, &restricted.ForbiddenError{}), secret.IsCASError(err):
+			statusCode = http.StatusBadRequest
+		}
+
+		log.Errorf("Error during restoring secret version: %s", err.Error())
+		c.AbortWithStatusJSON(statusCode, common.ErrorResponse{
+			Code:    statusCode,
+			Message: "Error during restoring secret version",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	log.Debugf("Secret %d/%s restored to version %d", organizationID, secretID, version)
+
+	s, err := restricted.GlobalSecretStore.Get(organizationID, secretID)
+	if err != nil {
+		log.Errorf("error during getting secret: %s", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, common.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, pipeline.CreateSecretResponse{
+		Name:      s.Name,
+		Type:      s.Type,
+		Id:        secretID,
+		UpdatedAt: s.UpdatedAt,
+		UpdatedBy: s.UpdatedBy,
+		Version:   int32(s.Version),
+		Tags:      s.Tags,
+	})
+}
+
 // GetSecretTags returns tags of a secret by ID
 func GetSecretTags(c *gin.Context) {
 	organizationID := auth.GetCurrentOrganization(c.Request).ID
@@ -512,3 +620,17 @@ func removeElement(s []string, v string) []string {
 func getSecretID(ctx *gin.Context) string {
 	return ctx.Param("id")
 }
+
+func getSecretVersion(ctx *gin.Context) (int, bool) {
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil || version < 1 {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, common.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid secret version",
+			Error:   fmt.Sprintf("invalid secret version: %q", ctx.Param("version")),
+		})
+		return 0, false
+	}
+
+	return version, true
+}
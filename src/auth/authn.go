@@ -183,11 +183,25 @@ func Init(db *gorm.DB, config Config, tokenStore bauth.TokenStore, tokenManager
 		func(claims *ginauth.ScopedClaims) interface{} {
 			userID, _ := strconv.ParseUint(claims.Subject, 10, 32)
 
-			return &User{
+			user := &User{
 				ID:      uint(userID),
 				Login:   claims.Text, // This is needed for virtual user tokens
 				Virtual: claims.Type == ginauth.TokenType(VirtualUserTokenType),
 			}
+
+			scopes, err := pkgAuth.ParseTokenScopes(claims.Scope)
+			if err != nil {
+				errorHandler.Handle(errors.WrapIfWithDetails(err, "invalid token scope", "tokenId", claims.Id))
+
+				// Never fall back to an unrestricted user
+				return (*User)(nil)
+			}
+
+			if !scopes.IsZero() {
+				user.Scopes = &scopes
+			}
+
+			return user
 		},
 		func(ctx context.Context, value interface{}) context.Context {
 			return context.WithValue(ctx, auth.CurrentUser, value)
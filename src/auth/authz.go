@@ -16,18 +16,20 @@ package auth
 
 import (
 	"context"
-	"net/http"
-	"regexp"
 	"strconv"
 	"strings"
 
 	"emperror.dev/errors"
 	"github.com/jinzhu/gorm"
+
+	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 )
 
-// RbacEnforcer makes authorization decisions based on user roles.
+// RbacEnforcer makes authorization decisions based on user roles and permission policies.
 type RbacEnforcer struct {
 	roleSource            RoleSource
+	policySource          PolicySource
+	clusterSource         ClusterSource
 	serviceAccountService ServiceAccountService
 	logger                Logger
 }
@@ -41,10 +43,35 @@ type RoleSource interface {
 	FindUserRole(ctx context.Context, organizationID uint, userID uint) (string, bool, error)
 }
 
+// +testify:mock:testOnly=true
+
+// PolicySource returns the permissions granted to a user by custom roles.
+type PolicySource interface {
+	// FindUserPermissions returns the permissions granted to a user in a given organization by custom roles.
+	FindUserPermissions(ctx context.Context, organizationID uint, userID uint) ([]Permission, error)
+}
+
+// +testify:mock:testOnly=true
+
+// ClusterSource returns cluster attributes used for evaluating resource selectors.
+type ClusterSource interface {
+	// FindCluster returns a cluster by its reference (ID) in a given organization.
+	// Returns false as the second parameter if the cluster cannot be found.
+	FindCluster(ctx context.Context, organizationID uint, clusterRef string) (ClusterResource, bool, error)
+}
+
 // NewRbacEnforcer returns a new RbacEnforcer.
-func NewRbacEnforcer(roleSource RoleSource, serviceAccountService ServiceAccountService, logger Logger) RbacEnforcer {
+func NewRbacEnforcer(
+	roleSource RoleSource,
+	policySource PolicySource,
+	clusterSource ClusterSource,
+	serviceAccountService ServiceAccountService,
+	logger Logger,
+) RbacEnforcer {
 	return RbacEnforcer{
 		roleSource:            roleSource,
+		policySource:          policySource,
+		clusterSource:         clusterSource,
 		serviceAccountService: serviceAccountService,
 
 		logger: logger,
@@ -53,6 +80,24 @@ func NewRbacEnforcer(roleSource RoleSource, serviceAccountService ServiceAccount
 
 // Enforce makes authorization decisions.
 func (e RbacEnforcer) Enforce(org *Organization, user *User, path, method string) (bool, error) {
+	// Restricted API tokens can never exceed their scopes, not even for non-organizational resources
+	if user != nil && user.Scopes != nil {
+		allowed, err := e.enforceScopes(context.Background(), org, *user.Scopes, path, method)
+		if err != nil {
+			return false, errors.WithDetails(err, "method", method, "path", path)
+		}
+
+		if !allowed {
+			e.logger.Debug("request is out of the token scopes", map[string]interface{}{
+				"userId": user.ID,
+				"method": method,
+				"path":   path,
+			})
+
+			return false, nil
+		}
+	}
+
 	// Non-organizational resources are always allowed.
 	// TODO: this shouldn't be decided here, remove it!
 	if org == nil {
@@ -95,67 +140,240 @@ func (e RbacEnforcer) Enforce(org *Organization, user *User, path, method string
 		return org.Name == orgName, nil
 	}
 
-	role, member, err := e.roleSource.FindUserRole(context.Background(), org.ID, user.ID)
+	request, ok := ParseRequest(path, method)
+	if !ok {
+		// Non-organizational resources are always allowed.
+		request = Request{OrganizationID: org.ID}
+	}
+
+	// Always evaluate against the organization in the context
+	request.OrganizationID = org.ID
+
+	allowed, err := e.evaluate(context.Background(), user.ID, request, ok)
+	if err != nil {
+		return false, errors.WithDetails(err, "method", method, "path", path)
+	}
+
+	return allowed, nil
+}
+
+func (e RbacEnforcer) enforceScopes(ctx context.Context, org *Organization, scopes pkgAuth.TokenScopes, path, method string) (bool, error) {
+	// Restricted tokens can only read non-organizational resources (eg. list their organizations)
+	if org == nil {
+		return actionFromMethod(method) == ActionRead, nil
+	}
+
+	orgID := org.ID
+
+	if !scopes.AllowsOrganization(orgID) {
+		return false, nil
+	}
+
+	request, ok := ParseRequest(path, method)
+	if !ok {
+		request = Request{Action: actionFromMethod(method)}
+	}
+
+	switch scopes.Access {
+	case pkgAuth.TokenAccessRead:
+		if request.Action != ActionRead {
+			return false, nil
+		}
+
+	case pkgAuth.TokenAccessDeploy:
+		if request.Action != ActionRead && request.Resource != ResourceDeployment {
+			return false, nil
+		}
+	}
+
+	if len(scopes.Clusters) == 0 {
+		return true, nil
+	}
+
+	// Tokens restricted to clusters can only read organization level resources
+	if request.ClusterRef == "" {
+		return request.Action == ActionRead, nil
+	}
+
+	cluster, found, err := e.clusterSource.FindCluster(ctx, orgID, request.ClusterRef)
+	if err != nil {
+		return false, errors.WrapIfWithDetails(
+			err, "failed to find cluster",
+			"organizationId", orgID,
+			"cluster", request.ClusterRef,
+		)
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	return scopes.MatchesCluster(cluster.ID, cluster.Name), nil
+}
+
+// IsAllowed decides whether a user is allowed to execute an action on a resource in an organization.
+func (e RbacEnforcer) IsAllowed(ctx context.Context, userID uint, request Request) (bool, error) {
+	return e.evaluate(ctx, userID, request, true)
+}
+
+// UserPermissions returns the permissions granted to a user in an organization by built-in and custom roles.
+// Returns false as the second parameter if the user is not a member of the organization.
+func (e RbacEnforcer) UserPermissions(ctx context.Context, organizationID uint, userID uint) ([]Permission, bool, error) {
+	role, member, err := e.roleSource.FindUserRole(ctx, organizationID, userID)
+	if err != nil {
+		return nil, false, errors.WrapIfWithDetails(
+			err, "failed to check user organization membership",
+			"organizationId", organizationID,
+			"userId", userID,
+		)
+	}
+
+	if !member {
+		return nil, false, nil
+	}
+
+	permissions, ok := BuiltinRolePermissions(role)
+	if !ok {
+		return nil, false, errors.NewWithDetails(
+			"unknown membership role",
+			"userId", userID,
+			"organizationId", organizationID,
+			"role", role,
+		)
+	}
+
+	customPermissions, err := e.policySource.FindUserPermissions(ctx, organizationID, userID)
+	if err != nil {
+		return nil, false, errors.WrapIfWithDetails(
+			err, "failed to find user permissions",
+			"organizationId", organizationID,
+			"userId", userID,
+		)
+	}
+
+	return append(permissions[:len(permissions):len(permissions)], customPermissions...), true, nil
+}
+
+func (e RbacEnforcer) evaluate(ctx context.Context, userID uint, request Request, organizational bool) (bool, error) {
+	role, member, err := e.roleSource.FindUserRole(ctx, request.OrganizationID, userID)
 	if err != nil {
 		return false, errors.WrapIfWithDetails(
 			err, "failed to check user organization membership",
-			"method", method,
-			"path", path,
+			"organizationId", request.OrganizationID,
+			"userId", userID,
 		)
 	}
 
 	if !member {
 		e.logger.Debug("user is not a member of the organization", map[string]interface{}{
-			"organizationId": org.ID,
-			"userId":         user.ID,
+			"organizationId": request.OrganizationID,
+			"userId":         userID,
 		})
 
 		return false, nil
 	}
 
-	switch role {
-	case RoleAdmin:
+	permissions, ok := BuiltinRolePermissions(role)
+	if !ok {
+		return false, errors.NewWithDetails(
+			"unknown membership role",
+			"userId", userID,
+			"organizationId", request.OrganizationID,
+			"role", role,
+		)
+	}
+
+	// Non-organizational resources are always allowed for members.
+	if !organizational {
 		return true, nil
-	case RoleMember:
-		// Members can only read organization resources
-		if ok, err := regexp.MatchString(`^/api/v1/orgs(?:/.*)?$`, path); err != nil || (ok && method != http.MethodGet && method != http.MethodHead) {
-			return false, nil
+	}
+
+	customPermissions, err := e.policySource.FindUserPermissions(ctx, request.OrganizationID, userID)
+	if err != nil {
+		return false, errors.WrapIfWithDetails(
+			err, "failed to find user permissions",
+			"organizationId", request.OrganizationID,
+			"userId", userID,
+		)
+	}
+
+	permissions = append(permissions[:len(permissions):len(permissions)], customPermissions...)
+
+	var cluster *ClusterResource
+
+	for _, permission := range permissions {
+		if !permission.Matches(request.Resource, request.Action) {
+			continue
 		}
 
-		// Members cannot download admin kube config
-		if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/clusters/[^/]+/config$`, path); err != nil || ok {
-			return false, nil
+		if permission.Selector == nil {
+			return true, nil
 		}
 
-		// Members cannot access secrets at all
-		if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/secrets(?:/.*)?$`, path); err != nil || ok {
-			return false, errors.WithStackIf(err)
+		// Selectors only apply to cluster scoped resources.
+		if request.ClusterRef == "" {
+			continue
 		}
 
-		return true, nil
-	default:
-		return false, errors.NewWithDetails(
-			"unknown membership role",
-			"userId", user.ID,
-			"organizationId", org.ID,
-			"role", role,
-			"method", method,
-			"path", path,
-		)
+		if cluster == nil {
+			c, found, err := e.clusterSource.FindCluster(ctx, request.OrganizationID, request.ClusterRef)
+			if err != nil {
+				return false, errors.WrapIfWithDetails(
+					err, "failed to find cluster",
+					"organizationId", request.OrganizationID,
+					"cluster", request.ClusterRef,
+				)
+			}
+
+			if !found {
+				return false, nil
+			}
+
+			cluster = &c
+		}
+
+		if permission.Selector.Matches(request.OrganizationID, *cluster) {
+			return true, nil
+		}
+	}
+
+	e.logger.Debug("no permission matches the request", map[string]interface{}{
+		"organizationId": request.OrganizationID,
+		"userId":         userID,
+		"resource":       request.Resource,
+		"action":         request.Action,
+	})
+
+	return false, nil
+}
+
+// PermissionGrant describes a set of permissions granted to a custom role in an organization.
+type PermissionGrant struct {
+	OrganizationID uint
+	Permissions    []Permission
+}
+
+func coveredBy(requested Permission, permissions []Permission) bool {
+	for _, permission := range permissions {
+		if permission.Covers(requested) {
+			return true
+		}
 	}
+
+	return false
 }
 
 // Authorizer checks if a context has permission to execute an action.
 type Authorizer struct {
-	db         *gorm.DB
-	roleSource RoleSource
+	db       *gorm.DB
+	enforcer RbacEnforcer
 }
 
 // NewAuthorizer returns a new Authorizer.
-func NewAuthorizer(db *gorm.DB, roleSource RoleSource) Authorizer {
+func NewAuthorizer(db *gorm.DB, enforcer RbacEnforcer) Authorizer {
 	return Authorizer{
-		db:         db,
-		roleSource: roleSource,
+		db:       db,
+		enforcer: enforcer,
 	}
 }
 
@@ -180,15 +398,53 @@ func (a Authorizer) Authorize(ctx context.Context, action string, object interfa
 			return false, errors.New("user not found in the context")
 		}
 
-		role, member, err := a.roleSource.FindUserRole(ctx, organization.ID, userID)
+		allowed, err := a.enforcer.IsAllowed(ctx, userID, Request{
+			OrganizationID: organization.ID,
+			Resource:       ResourceVirtualUser,
+			Action:         ActionCreate,
+		})
+		if err != nil {
+			return false, errors.WithMessage(err, "failed to authorize virtual user")
+		}
+
+		return allowed, nil
+	}
+
+	// Users can only grant permissions to custom roles that they have themselves
+	if action == "role.grant" {
+		grant, ok := object.(PermissionGrant)
+		if !ok {
+			return false, errors.NewWithDetails("invalid object for action", "action", action, "object", object)
+		}
+
+		userID, ok := UserExtractor{}.GetUserID(ctx)
+		if !ok {
+			return false, errors.New("user not found in the context")
+		}
+
+		permissions, member, err := a.enforcer.UserPermissions(ctx, grant.OrganizationID, userID)
 		if err != nil {
-			return false, errors.WithMessage(err, "failed to query organization membership for virtual user")
+			return false, errors.WithMessage(err, "failed to authorize granting permissions")
 		}
 
-		// TODO: implement better authorization here
-		if !member || role != RoleAdmin {
+		if !member {
 			return false, nil
 		}
+
+		for _, requested := range grant.Permissions {
+			if !coveredBy(requested, permissions) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}
+
+	// Restricted API tokens cannot be used to mint new tokens
+	if action == "token.create" {
+		_, scoped := UserExtractor{}.GetUserScopes(ctx)
+
+		return !scoped, nil
 	}
 
 	return true, nil
@@ -22,10 +22,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/banzaicloud/pipeline/internal/common"
+	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 )
 
 func TestRbacEnforcer_Enforce_NoOrgIsAllowed(t *testing.T) {
-	enforcer := NewRbacEnforcer(nil, NewServiceAccountService(), common.NoopLogger{})
+	enforcer := NewRbacEnforcer(nil, nil, nil, NewServiceAccountService(), common.NoopLogger{})
 
 	ok, err := enforcer.Enforce(nil, &User{}, "/", "GET")
 	require.NoError(t, err)
@@ -33,8 +34,67 @@ func TestRbacEnforcer_Enforce_NoOrgIsAllowed(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestRbacEnforcer_Enforce_NoOrgScopedToken(t *testing.T) {
+	tests := []struct {
+		scopes   pkgAuth.TokenScopes
+		path     string
+		method   string
+		expected bool
+	}{
+		{
+			scopes:   pkgAuth.TokenScopes{OrganizationID: 1},
+			path:     "/api/v1/orgs",
+			method:   "GET",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{OrganizationID: 1},
+			path:     "/api/v1/orgs",
+			method:   "PUT",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Access: pkgAuth.TokenAccessRead},
+			path:     "/api/v1/tokens",
+			method:   "GET",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Access: pkgAuth.TokenAccessRead},
+			path:     "/api/v1/tokens/abcd",
+			method:   "DELETE",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Access: pkgAuth.TokenAccessDeploy},
+			path:     "/api/v1/tokens/abcd",
+			method:   "DELETE",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Clusters: []string{"prod-*"}},
+			path:     "/api/v1/orgs",
+			method:   "PUT",
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run("", func(t *testing.T) {
+			enforcer := NewRbacEnforcer(nil, nil, nil, NewServiceAccountService(), common.NoopLogger{})
+
+			ok, err := enforcer.Enforce(nil, &User{ID: 1, Scopes: &test.scopes}, test.path, test.method)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, ok)
+		})
+	}
+}
+
 func TestRbacEnforcer_Enforce_NoUserIsNotAllowed(t *testing.T) {
-	enforcer := NewRbacEnforcer(nil, NewServiceAccountService(), common.NoopLogger{})
+	enforcer := NewRbacEnforcer(nil, nil, nil, NewServiceAccountService(), common.NoopLogger{})
 
 	ok, err := enforcer.Enforce(&Organization{}, nil, "/", "GET")
 	require.NoError(t, err)
@@ -84,7 +144,7 @@ func TestRbacEnforcer_Enforce_VirtualUser(t *testing.T) {
 		test := test
 
 		t.Run("", func(t *testing.T) {
-			enforcer := NewRbacEnforcer(nil, NewServiceAccountService(), common.NoopLogger{})
+			enforcer := NewRbacEnforcer(nil, nil, nil, NewServiceAccountService(), common.NoopLogger{})
 
 			ok, err := enforcer.Enforce(&test.organization, &test.user, "/", "GET")
 			require.NoError(t, err)
@@ -127,7 +187,7 @@ func TestRbacEnforcer_Enforce_VirtualUser_Invalid(t *testing.T) {
 		test := test
 
 		t.Run("", func(t *testing.T) {
-			enforcer := NewRbacEnforcer(nil, NewServiceAccountService(), common.NoopLogger{})
+			enforcer := NewRbacEnforcer(nil, nil, nil, NewServiceAccountService(), common.NoopLogger{})
 
 			ok, err := enforcer.Enforce(&test.organization, &test.user, "/", "GET")
 			if test.error {
@@ -153,7 +213,7 @@ func TestRbacEnforcer_Enforce_NotAMember(t *testing.T) {
 	roleSource := &MockRoleSource{}
 	roleSource.On("FindUserRole", mock.Anything, org.ID, user.ID).Return("", false, nil)
 
-	enforcer := NewRbacEnforcer(roleSource, NewServiceAccountService(), common.NoopLogger{})
+	enforcer := NewRbacEnforcer(roleSource, nil, nil, NewServiceAccountService(), common.NoopLogger{})
 
 	ok, err := enforcer.Enforce(&org, &user, "/", "GET")
 	require.NoError(t, err)
@@ -253,7 +313,249 @@ func TestRbacEnforcer_Enforce(t *testing.T) {
 			roleSource := &MockRoleSource{}
 			roleSource.On("FindUserRole", mock.Anything, org.ID, user.ID).Return(test.role, true, nil)
 
-			enforcer := NewRbacEnforcer(roleSource, NewServiceAccountService(), common.NoopLogger{})
+			policySource := &MockPolicySource{}
+			policySource.On("FindUserPermissions", mock.Anything, org.ID, user.ID).Return(nil, nil)
+
+			enforcer := NewRbacEnforcer(roleSource, policySource, nil, NewServiceAccountService(), common.NoopLogger{})
+
+			ok, err := enforcer.Enforce(&org, &user, test.path, test.method)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, ok)
+		})
+	}
+}
+
+func TestRbacEnforcer_Enforce_CustomRole(t *testing.T) {
+	org := Organization{
+		ID:   1,
+		Name: "example",
+	}
+
+	user := User{
+		ID:    1,
+		Login: "john.doe",
+	}
+
+	permissions := []Permission{
+		{
+			Resource: ResourceNodePool,
+			Action:   ActionAny,
+			Selector: &ResourceSelector{
+				ClusterNames: []string{"prod-*"},
+			},
+		},
+		{
+			Resource: ResourceDeployment,
+			Action:   ActionCreate,
+			Selector: &ResourceSelector{
+				ClusterTags: map[string]string{"team": "helm"},
+			},
+		},
+		{
+			Resource: ResourceIntegratedService,
+			Action:   ActionUpdate,
+			Selector: &ResourceSelector{
+				BRNs: []string{"brn:1:cluster:3"},
+			},
+		},
+	}
+
+	clusters := map[string]ClusterResource{
+		"1": {ID: 1, Name: "prod-eu"},
+		"2": {ID: 2, Name: "dev", Tags: map[string]string{"team": "helm"}},
+		"3": {ID: 3, Name: "staging"},
+	}
+
+	tests := []struct {
+		path     string
+		method   string
+		expected bool
+	}{
+		{
+			path:     "/api/v1/orgs/1/clusters/1/nodepools",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			path:     "/api/v1/orgs/1/clusters/1/nodepools/pool1/update",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			path:     "/api/v1/orgs/1/clusters/2/nodepools",
+			method:   "POST",
+			expected: false,
+		},
+		{
+			path:     "/api/v1/orgs/1/clusters/2/deployments",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			path:     "/api/v1/orgs/1/clusters/1/deployments",
+			method:   "POST",
+			expected: false,
+		},
+		{
+			path:     "/api/v1/orgs/1/clusters/3/services/monitoring",
+			method:   "PUT",
+			expected: true,
+		},
+		{
+			path:     "/api/v1/orgs/1/clusters/2/services/monitoring",
+			method:   "PUT",
+			expected: false,
+		},
+		{
+			path:     "/api/v1/orgs/1/clusters/1/secrets",
+			method:   "POST",
+			expected: false,
+		},
+		{
+			path:     "/api/v1/orgs/1/secrets",
+			method:   "GET",
+			expected: false,
+		},
+		{
+			path:     "/api/v1/orgs/1/clusters",
+			method:   "GET",
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run("", func(t *testing.T) {
+			roleSource := &MockRoleSource{}
+			roleSource.On("FindUserRole", mock.Anything, org.ID, user.ID).Return(RoleMember, true, nil)
+
+			policySource := &MockPolicySource{}
+			policySource.On("FindUserPermissions", mock.Anything, org.ID, user.ID).Return(permissions, nil)
+
+			clusterSource := &MockClusterSource{}
+			for ref, cluster := range clusters {
+				clusterSource.On("FindCluster", mock.Anything, org.ID, ref).Return(cluster, true, nil)
+			}
+
+			enforcer := NewRbacEnforcer(roleSource, policySource, clusterSource, NewServiceAccountService(), common.NoopLogger{})
+
+			ok, err := enforcer.Enforce(&org, &user, test.path, test.method)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, ok)
+		})
+	}
+}
+
+func TestRbacEnforcer_Enforce_ScopedToken(t *testing.T) {
+	org := Organization{
+		ID:   1,
+		Name: "example",
+	}
+
+	clusters := map[string]ClusterResource{
+		"1": {ID: 1, Name: "prod-eu"},
+		"2": {ID: 2, Name: "dev"},
+	}
+
+	tests := []struct {
+		scopes   pkgAuth.TokenScopes
+		path     string
+		method   string
+		expected bool
+	}{
+		{
+			scopes:   pkgAuth.TokenScopes{OrganizationID: 2},
+			path:     "/api/v1/orgs/1/clusters",
+			method:   "GET",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{OrganizationID: 1},
+			path:     "/api/v1/orgs/1/clusters",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Access: pkgAuth.TokenAccessRead},
+			path:     "/api/v1/orgs/1/clusters/1",
+			method:   "GET",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Access: pkgAuth.TokenAccessRead},
+			path:     "/api/v1/orgs/1/clusters/1",
+			method:   "DELETE",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Access: pkgAuth.TokenAccessDeploy},
+			path:     "/api/v1/orgs/1/clusters/1/deployments",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Access: pkgAuth.TokenAccessDeploy},
+			path:     "/api/v1/orgs/1/clusters/1/nodepools",
+			method:   "POST",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Clusters: []string{"prod-*"}},
+			path:     "/api/v1/orgs/1/clusters/1/deployments",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Clusters: []string{"2"}},
+			path:     "/api/v1/orgs/1/clusters/2/deployments",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Clusters: []string{"prod-*"}},
+			path:     "/api/v1/orgs/1/clusters/2/deployments",
+			method:   "POST",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Clusters: []string{"prod-*"}},
+			path:     "/api/v1/orgs/1/clusters",
+			method:   "GET",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Clusters: []string{"prod-*"}},
+			path:     "/api/v1/orgs/1/clusters",
+			method:   "POST",
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run("", func(t *testing.T) {
+			user := User{
+				ID:     1,
+				Login:  "john.doe",
+				Scopes: &test.scopes,
+			}
+
+			roleSource := &MockRoleSource{}
+			roleSource.On("FindUserRole", mock.Anything, org.ID, user.ID).Return(RoleAdmin, true, nil)
+
+			policySource := &MockPolicySource{}
+			policySource.On("FindUserPermissions", mock.Anything, org.ID, user.ID).Return(nil, nil)
+
+			clusterSource := &MockClusterSource{}
+			for ref, cluster := range clusters {
+				clusterSource.On("FindCluster", mock.Anything, org.ID, ref).Return(cluster, true, nil)
+			}
+
+			enforcer := NewRbacEnforcer(roleSource, policySource, clusterSource, NewServiceAccountService(), common.NoopLogger{})
 
 			ok, err := enforcer.Enforce(&org, &user, test.path, test.method)
 			require.NoError(t, err)
@@ -0,0 +1,351 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/banzaicloud/pipeline/pkg/brn"
+)
+
+// Resource types that permissions can be granted on.
+const (
+	ResourceAny               = "*"
+	ResourceOrganization      = "organization"
+	ResourceCluster           = "cluster"
+	ResourceKubeConfig        = "kubeconfig"
+	ResourceNodePool          = "nodepool"
+	ResourceClusterSecret     = "clustersecret"
+	ResourceDeployment        = "deployment"
+	ResourceIntegratedService = "integratedservice"
+	ResourceBackup            = "backup"
+	ResourceSecret            = "secret"
+	ResourceHelm              = "helm"
+	ResourceBucket            = "bucket"
+	ResourceClusterGroup      = "clustergroup"
+	ResourceProcess           = "process"
+	ResourceUser              = "user"
+	ResourceRole              = "role"
+	ResourceVirtualUser       = "virtualuser"
+	ResourceCloud             = "cloud"
+)
+
+// Actions that can be granted on a resource.
+const (
+	ActionAny    = "*"
+	ActionRead   = "read"
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// nolint: gochecknoglobals
+var resourceTypes = []string{
+	ResourceAny,
+	ResourceOrganization,
+	ResourceCluster,
+	ResourceKubeConfig,
+	ResourceNodePool,
+	ResourceClusterSecret,
+	ResourceDeployment,
+	ResourceIntegratedService,
+	ResourceBackup,
+	ResourceSecret,
+	ResourceHelm,
+	ResourceBucket,
+	ResourceClusterGroup,
+	ResourceProcess,
+	ResourceUser,
+	ResourceRole,
+	ResourceVirtualUser,
+	ResourceCloud,
+}
+
+// nolint: gochecknoglobals
+var actions = []string{
+	ActionAny,
+	ActionRead,
+	ActionCreate,
+	ActionUpdate,
+	ActionDelete,
+}
+
+// IsValidResourceType checks whether a resource type is known.
+func IsValidResourceType(resourceType string) bool {
+	for _, r := range resourceTypes {
+		if r == resourceType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsValidAction checks whether an action is known.
+func IsValidAction(action string) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Permission grants an action on a resource type, optionally restricted to a set of clusters.
+type Permission struct {
+	// Resource is the type of the resource the permission applies to (or * for every resource).
+	Resource string `json:"resource"`
+
+	// Action is the action allowed on the resource (or * for every action).
+	Action string `json:"action"`
+
+	// Selector optionally restricts the permission to a subset of clusters.
+	Selector *ResourceSelector `json:"selector,omitempty"`
+}
+
+// ResourceSelector restricts a permission to clusters matching any of the criteria.
+type ResourceSelector struct {
+	// ClusterNames is a list of cluster name patterns (path.Match syntax).
+	ClusterNames []string `json:"clusterNames,omitempty"`
+
+	// ClusterTags is a set of cluster tags that all need to match.
+	ClusterTags map[string]string `json:"clusterTags,omitempty"`
+
+	// BRNs is a list of resource names (eg. brn:1:cluster:42).
+	BRNs []string `json:"brns,omitempty"`
+}
+
+// ClusterResource contains the cluster attributes that resource selectors can match against.
+type ClusterResource struct {
+	ID   uint
+	Name string
+	Tags map[string]string
+}
+
+// Request describes an action on a resource in an organization.
+type Request struct {
+	OrganizationID uint
+	Resource       string
+	Action         string
+
+	// ClusterRef is the cluster identifier in the request path (empty if the request is not cluster scoped).
+	ClusterRef string
+}
+
+// Matches checks whether the permission grants the requested action on the requested resource type.
+func (p Permission) Matches(resource string, action string) bool {
+	if p.Resource != ResourceAny && p.Resource != resource {
+		return false
+	}
+
+	return p.Action == ActionAny || p.Action == action
+}
+
+// Covers checks whether the permission grants everything another permission grants.
+func (p Permission) Covers(other Permission) bool {
+	if !p.Matches(other.Resource, other.Action) {
+		return false
+	}
+
+	if p.Selector == nil {
+		return true
+	}
+
+	return other.Selector != nil && reflect.DeepEqual(*p.Selector, *other.Selector)
+}
+
+// Matches checks whether a cluster matches the selector.
+func (s ResourceSelector) Matches(orgID uint, cluster ClusterResource) bool {
+	for _, pattern := range s.ClusterNames {
+		if ok, _ := path.Match(pattern, cluster.Name); ok {
+			return true
+		}
+	}
+
+	if len(s.ClusterTags) > 0 {
+		matches := true
+
+		for key, value := range s.ClusterTags {
+			if v, ok := cluster.Tags[key]; !ok || v != value {
+				matches = false
+
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	for _, rawBRN := range s.BRNs {
+		rn, err := brn.ParseAs(rawBRN, brn.ClusterResourceType)
+		if err != nil {
+			continue
+		}
+
+		if rn.OrganizationID != 0 && rn.OrganizationID != orgID {
+			continue
+		}
+
+		if rn.ResourceID == "*" || rn.ResourceID == strconv.FormatUint(uint64(cluster.ID), 10) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nolint: gochecknoglobals
+var builtinPermissions = map[string][]Permission{
+	RoleAdmin: {
+		{Resource: ResourceAny, Action: ActionAny},
+	},
+	RoleMember: memberPermissions(),
+}
+
+// memberPermissions returns the permissions of the built-in member role:
+// members can read every organization resource except secrets and admin kube configs.
+func memberPermissions() []Permission {
+	permissions := make([]Permission, 0, len(resourceTypes))
+
+	for _, resource := range resourceTypes {
+		switch resource {
+		case ResourceAny, ResourceSecret, ResourceKubeConfig, ResourceVirtualUser:
+			continue
+		}
+
+		permissions = append(permissions, Permission{Resource: resource, Action: ActionRead})
+	}
+
+	return permissions
+}
+
+// BuiltinRolePermissions returns the permissions of a built-in role.
+// Returns false as the second parameter if the role is not a built-in one.
+func BuiltinRolePermissions(role string) ([]Permission, bool) {
+	permissions, ok := builtinPermissions[role]
+
+	return permissions, ok
+}
+
+// nolint: gochecknoglobals
+var clusterSubresources = map[string]string{
+	"config":          ResourceKubeConfig,
+	"nodepools":       ResourceNodePool,
+	"secrets":         ResourceClusterSecret,
+	"deployments":     ResourceDeployment,
+	"services":        ResourceIntegratedService,
+	"features":        ResourceIntegratedService,
+	"backups":         ResourceBackup,
+	"backupservice":   ResourceBackup,
+	"restores":        ResourceBackup,
+	"schedules":       ResourceBackup,
+	"hpa":             ResourceDeployment,
+	"namespaces":      ResourceDeployment,
+	"images":          ResourceDeployment,
+	"endpoints":       ResourceDeployment,
+	"nodepool-labels": ResourceNodePool,
+}
+
+// nolint: gochecknoglobals
+var organizationResources = map[string]string{
+	"clusters":        ResourceCluster,
+	"secrets":         ResourceSecret,
+	"secretbindings":  ResourceSecret,
+	"secretrotations": ResourceSecret,
+	"helm":            ResourceHelm,
+	"buckets":         ResourceBucket,
+	"backupbuckets":   ResourceBucket,
+	"backups":         ResourceBackup,
+	"clustergroups":   ResourceClusterGroup,
+	"processes":       ResourceProcess,
+	"users":           ResourceUser,
+	"roles":           ResourceRole,
+	"networks":        ResourceCloud,
+	"azure":           ResourceCloud,
+	"cloud":           ResourceCloud,
+	"notifications":   ResourceOrganization,
+}
+
+// ParseRequest maps an API path and HTTP method to a resource request.
+// Returns false as the second parameter if the path is not an organization resource.
+func ParseRequest(p string, method string) (Request, bool) {
+	const prefix = "/api/v1/orgs/"
+
+	if !strings.HasPrefix(p, prefix) {
+		return Request{}, false
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(p, prefix), "/"), "/")
+
+	orgID, err := strconv.ParseUint(segments[0], 10, 64)
+	if err != nil {
+		return Request{}, false
+	}
+
+	req := Request{
+		OrganizationID: uint(orgID),
+		Resource:       ResourceOrganization,
+		Action:         actionFromMethod(method),
+	}
+
+	if len(segments) < 2 {
+		return req, true
+	}
+
+	// Unknown resources are treated as organization level resources
+	if resource, ok := organizationResources[segments[1]]; ok {
+		req.Resource = resource
+	}
+
+	// Cluster scoped resources
+	if segments[1] == "clusters" && len(segments) > 2 {
+		req.ClusterRef = segments[2]
+
+		if len(segments) > 3 {
+			if resource, ok := clusterSubresources[segments[3]]; ok {
+				req.Resource = resource
+			}
+		}
+	}
+
+	// Node pool updates are triggered by a POST request
+	if req.Resource == ResourceNodePool && len(segments) > 5 && segments[5] == "update" {
+		req.Action = ActionUpdate
+	}
+
+	return req, true
+}
+
+func actionFromMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ActionRead
+	case http.MethodPost:
+		return ActionCreate
+	case http.MethodPut, http.MethodPatch:
+		return ActionUpdate
+	case http.MethodDelete:
+		return ActionDelete
+	default:
+		return ActionAny
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRequest_MemberCanReadRegisteredRoutes(t *testing.T) {
+	// GET routes registered under /api/v1/orgs/:orgid in cmd/pipeline
+	routes := []string{
+		"/api/v1/orgs/1",
+		"/api/v1/orgs/1/clusters",
+		"/api/v1/orgs/1/clusters/1",
+		"/api/v1/orgs/1/clusters/1/pods",
+		"/api/v1/orgs/1/clusters/1/bootstrap",
+		"/api/v1/orgs/1/clusters/1/health",
+		"/api/v1/orgs/1/clusters/1/proxy/api/v1/namespaces",
+		"/api/v1/orgs/1/clusters/1/nodes",
+		"/api/v1/orgs/1/clusters/1/secrets",
+		"/api/v1/orgs/1/clusters/1/deployments",
+		"/api/v1/orgs/1/clusters/1/deployments/release",
+		"/api/v1/orgs/1/clusters/1/deployments/release/resources",
+		"/api/v1/orgs/1/clusters/1/deployments/release/history",
+		"/api/v1/orgs/1/clusters/1/deployments/release/images",
+		"/api/v1/orgs/1/clusters/1/endpoints",
+		"/api/v1/orgs/1/clusters/1/images",
+		"/api/v1/orgs/1/clusters/1/images/sha256:abcd/deployments",
+		"/api/v1/orgs/1/clusters/1/nodepool-labels",
+		"/api/v1/orgs/1/clusters/1/nodepools",
+		"/api/v1/orgs/1/clusters/1/nodepools/pool1",
+		"/api/v1/orgs/1/clusters/1/anchore/images",
+		"/api/v1/orgs/1/clusters/1/scanlog",
+		"/api/v1/orgs/1/clusters/1/scanlog/release",
+		"/api/v1/orgs/1/clusters/1/whitelists",
+		"/api/v1/orgs/1/clusters/1/services",
+		"/api/v1/orgs/1/clusters/1/services/logging",
+		"/api/v1/orgs/1/clusters/1/services/logging/revisions",
+		"/api/v1/orgs/1/clusters/1/features",
+		"/api/v1/orgs/1/clusters/1/features/logging",
+		"/api/v1/orgs/1/clusters/1/hpa",
+		"/api/v1/orgs/1/clusters/1/namespaces",
+		"/api/v1/orgs/1/clusters/1/pke/commands",
+		"/api/v1/orgs/1/clusters/1/pke/ready",
+		"/api/v1/orgs/1/clusters/1/pke/leader",
+		"/api/v1/orgs/1/clusters/1/oidcconfig",
+		"/api/v1/orgs/1/clusters/1/backups",
+		"/api/v1/orgs/1/clusters/1/backups/1",
+		"/api/v1/orgs/1/clusters/1/backups/1/download",
+		"/api/v1/orgs/1/clusters/1/backups/1/logs",
+		"/api/v1/orgs/1/clusters/1/backupservice/status",
+		"/api/v1/orgs/1/clusters/1/restores",
+		"/api/v1/orgs/1/clusters/1/restores/1",
+		"/api/v1/orgs/1/clusters/1/restores/1/logs",
+		"/api/v1/orgs/1/clusters/1/restores/1/results",
+		"/api/v1/orgs/1/clusters/1/schedules",
+		"/api/v1/orgs/1/clusters/1/schedules/daily",
+		"/api/v1/orgs/1/clustergroups",
+		"/api/v1/orgs/1/clustergroups/1",
+		"/api/v1/orgs/1/clustergroups/1/features",
+		"/api/v1/orgs/1/clustergroups/1/features/feature",
+		"/api/v1/orgs/1/clustergroups/1/deployments",
+		"/api/v1/orgs/1/clustergroups/1/deployments/release",
+		"/api/v1/orgs/1/helm/repos",
+		"/api/v1/orgs/1/helm/charts",
+		"/api/v1/orgs/1/helm/chart/stable/mysql",
+		"/api/v1/orgs/1/helm/policy",
+		"/api/v1/orgs/1/users",
+		"/api/v1/orgs/1/users/1",
+		"/api/v1/orgs/1/buckets",
+		"/api/v1/orgs/1/buckets/bucket",
+		"/api/v1/orgs/1/networks",
+		"/api/v1/orgs/1/networks/vpc/subnets",
+		"/api/v1/orgs/1/networks/vpc/routeTables",
+		"/api/v1/orgs/1/azure/resourcegroups",
+		"/api/v1/orgs/1/cloud/google/projects",
+		"/api/v1/orgs/1/roles",
+		"/api/v1/orgs/1/roles/developer",
+		"/api/v1/orgs/1/notifications",
+		"/api/v1/orgs/1/processes",
+		"/api/v1/orgs/1/processes/1",
+		"/api/v1/orgs/1/processes/1/events",
+		"/api/v1/orgs/1/backupbuckets",
+		"/api/v1/orgs/1/backupbuckets/1",
+		"/api/v1/orgs/1/backups",
+		"/api/v1/orgs/1/unknown/resource",
+	}
+
+	// Members cannot read secrets and admin kube configs
+	denied := []string{
+		"/api/v1/orgs/1/clusters/1/config",
+		"/api/v1/orgs/1/secrets",
+		"/api/v1/orgs/1/secrets/abcd",
+		"/api/v1/orgs/1/secrets/abcd/versions",
+		"/api/v1/orgs/1/secrets/abcd/versions/1",
+		"/api/v1/orgs/1/secrets/abcd/tags",
+		"/api/v1/orgs/1/secrets/abcd/validate",
+	}
+
+	permissions, ok := BuiltinRolePermissions(RoleMember)
+	assert.True(t, ok)
+
+	allowed := func(path string) bool {
+		request, ok := ParseRequest(path, "GET")
+		if !ok {
+			t.Fatalf("path is not an organization resource: %s", path)
+		}
+
+		for _, permission := range permissions {
+			if permission.Matches(request.Resource, request.Action) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, route := range routes {
+		assert.True(t, allowed(route), route)
+	}
+
+	for _, route := range denied {
+		assert.False(t, allowed(route), route)
+	}
+}
+
+func TestPermission_Covers(t *testing.T) {
+	selector := &ResourceSelector{ClusterNames: []string{"prod-*"}}
+
+	tests := []struct {
+		permission Permission
+		other      Permission
+		expected   bool
+	}{
+		{
+			permission: Permission{Resource: ResourceAny, Action: ActionAny},
+			other:      Permission{Resource: ResourceAny, Action: ActionAny},
+			expected:   true,
+		},
+		{
+			permission: Permission{Resource: ResourceCluster, Action: ActionAny},
+			other:      Permission{Resource: ResourceCluster, Action: ActionRead},
+			expected:   true,
+		},
+		{
+			permission: Permission{Resource: ResourceCluster, Action: ActionAny},
+			other:      Permission{Resource: ResourceAny, Action: ActionAny},
+			expected:   false,
+		},
+		{
+			permission: Permission{Resource: ResourceRole, Action: ActionRead},
+			other:      Permission{Resource: ResourceRole, Action: ActionAny},
+			expected:   false,
+		},
+		{
+			permission: Permission{Resource: ResourceCluster, Action: ActionRead, Selector: selector},
+			other:      Permission{Resource: ResourceCluster, Action: ActionRead},
+			expected:   false,
+		},
+		{
+			permission: Permission{Resource: ResourceCluster, Action: ActionRead, Selector: selector},
+			other:      Permission{Resource: ResourceCluster, Action: ActionRead, Selector: &ResourceSelector{ClusterNames: []string{"prod-*"}}},
+			expected:   true,
+		},
+		{
+			permission: Permission{Resource: ResourceCluster, Action: ActionRead},
+			other:      Permission{Resource: ResourceCluster, Action: ActionRead, Selector: selector},
+			expected:   true,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.permission.Covers(test.other))
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/qor/qor/utils"
 
 	"github.com/banzaicloud/pipeline/internal/global"
+	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 )
 
 const (
@@ -68,6 +69,9 @@ type User struct {
 	Virtual        bool           `json:"-" gorm:"-"` // Used only internally
 	APIToken       string         `json:"-" gorm:"-"` // Used only internally
 	ServiceAccount bool           `json:"-" gorm:"-"` // Used only internally
+
+	// Scopes restrict what the user's API token can be used for (nil means unrestricted)
+	Scopes *pkgAuth.TokenScopes `json:"-" gorm:"-"`
 }
 
 // CICDUser struct
@@ -116,6 +120,16 @@ func (e UserExtractor) GetUserID(ctx context.Context) (uint, bool) {
 	return 0, false
 }
 
+// GetUserScopes returns the scopes of the token the current user authenticated with.
+// If the token is not restricted, it returns false as the second return value.
+func (e UserExtractor) GetUserScopes(ctx context.Context) (*pkgAuth.TokenScopes, bool) {
+	if user, ok := ctx.Value(auth.CurrentUser).(*User); ok && user != nil && user.Scopes != nil {
+		return user.Scopes, true
+	}
+
+	return nil, false
+}
+
 func (e UserExtractor) GetUserLogin(ctx context.Context) (string, bool) {
 	if user, ok := ctx.Value(auth.CurrentUser).(*User); ok {
 		return user.Login, true
@@ -42,6 +42,67 @@ func (_m *MockRoleSource) FindUserRole(ctx context.Context, organizationID uint,
 	return r0, r1, r2
 }
 
+// MockPolicySource is an autogenerated mock for the PolicySource type.
+type MockPolicySource struct {
+	mock.Mock
+}
+
+// FindUserPermissions provides a mock function.
+func (_m *MockPolicySource) FindUserPermissions(ctx context.Context, organizationID uint, userID uint) (_result_0 []Permission, _result_1 error) {
+	ret := _m.Called(ctx, organizationID, userID)
+
+	var r0 []Permission
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []Permission); ok {
+		r0 = rf(ctx, organizationID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Permission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, organizationID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClusterSource is an autogenerated mock for the ClusterSource type.
+type MockClusterSource struct {
+	mock.Mock
+}
+
+// FindCluster provides a mock function.
+func (_m *MockClusterSource) FindCluster(ctx context.Context, organizationID uint, clusterRef string) (_result_0 ClusterResource, _result_1 bool, _result_2 error) {
+	ret := _m.Called(ctx, organizationID, clusterRef)
+
+	var r0 ClusterResource
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) ClusterResource); ok {
+		r0 = rf(ctx, organizationID, clusterRef)
+	} else {
+		r0 = ret.Get(0).(ClusterResource)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) bool); ok {
+		r1 = rf(ctx, organizationID, clusterRef)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, uint, string) error); ok {
+		r2 = rf(ctx, organizationID, clusterRef)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // MockOIDCOrganizationSyncer is an autogenerated mock for the OIDCOrganizationSyncer type.
 type MockOIDCOrganizationSyncer struct {
 	mock.Mock
@@ -27,6 +27,8 @@ import (
 	logrusadapter "logur.dev/adapter/logrus"
 
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter/clustermodel"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulatedadapter"
+	simulatedCommonCluster "github.com/banzaicloud/pipeline/internal/cluster/distribution/simulated/simulateddriver/commoncluster"
 	"github.com/banzaicloud/pipeline/internal/common/commonadapter"
 	"github.com/banzaicloud/pipeline/internal/global"
 	"github.com/banzaicloud/pipeline/internal/platform/database"
@@ -384,6 +386,9 @@ func GetCommonClusterFromModel(modelCluster *model.ClusterModel) (CommonCluster,
 		err = db.Where(modelOracle.Cluster{ClusterModelID: okeCluster.modelCluster.ID}).Preload("NodePools.Subnets").First(&okeCluster.modelCluster.OKE).Error
 
 		return okeCluster, err
+
+	case pkgCluster.Simulated:
+		return simulatedCommonCluster.MakeCommonClusterGetter(secret.Store, simulatedadapter.NewClusterStore(db)).GetByID(modelCluster.ID)
 	}
 
 	return nil, pkgErrors.ErrorNotSupportedCloudType
@@ -19,7 +19,6 @@ import (
 
 	"github.com/banzaicloud/pipeline/internal/providers/google"
 	pkgClusterGoogle "github.com/banzaicloud/pipeline/pkg/cluster/gke"
-	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
 )
 
@@ -60,43 +59,8 @@ func createNodePoolsFromClusterModel(clusterModel *google.GKEClusterModel) ([]*g
 
 	nodePools := make([]*gke.NodePool, nodePoolsCount)
 
-	for i := 0; i < nodePoolsCount; i++ {
-		nodePoolModel := clusterModel.NodePools[i]
-
-		// only add node pool name label, other are added by NodeLabelController
-		labels := map[string]string{
-			pkgCommon.LabelKey: nodePoolModel.Name,
-		}
-
-		nodePools[i] = &gke.NodePool{
-			Name: nodePoolModel.Name,
-			Config: &gke.NodeConfig{
-				Labels:      labels,
-				MachineType: nodePoolModel.NodeInstanceType,
-				OauthScopes: []string{
-					"https://www.googleapis.com/auth/logging.write",
-					"https://www.googleapis.com/auth/monitoring",
-					"https://www.googleapis.com/auth/devstorage.read_write",
-					"https://www.googleapis.com/auth/cloud-platform",
-					"https://www.googleapis.com/auth/compute",
-				},
-				Preemptible: nodePoolModel.Preemptible,
-			},
-			InitialNodeCount: int64(nodePoolModel.NodeCount),
-			Version:          clusterModel.NodeVersion,
-		}
-
-		if nodePoolModel.Autoscaling {
-			nodePools[i].Autoscaling = &gke.NodePoolAutoscaling{
-				Enabled:      true,
-				MinNodeCount: int64(nodePoolModel.NodeMinCount),
-				MaxNodeCount: int64(nodePoolModel.NodeMaxCount),
-			}
-		} else {
-			nodePools[i].Autoscaling = &gke.NodePoolAutoscaling{
-				Enabled: false,
-			}
-		}
+	for i, nodePoolModel := range clusterModel.NodePools {
+		nodePools[i] = google.NewGKENodePool(*nodePoolModel, clusterModel.NodeVersion)
 	}
 
 	return nodePools, nil
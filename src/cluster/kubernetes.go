@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/base64"
 	"strings"
+	"time"
 
 	"emperror.dev/errors"
 	"github.com/sirupsen/logrus"
@@ -38,6 +39,9 @@ import (
 
 const RBAC_API_VERSION = "rbac.authorization.k8s.io"
 
+// previousCredentialsTimeout is the time given to the replaced credentials to prove the cluster identity during a credential rotation.
+const previousCredentialsTimeout = 10 * time.Second
+
 // CreateKubernetesClusterFromRequest creates ClusterModel struct from the request
 func CreateKubernetesClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgId uint, userId uint) (*KubeCluster, error) {
 	cluster := KubeCluster{
@@ -349,3 +353,103 @@ func (c *KubeCluster) isRBACEnabled(client *kubernetes.Clientset) (bool, error)
 
 	return false, nil
 }
+
+// RotateCredentials replaces the kubeconfig used to access the imported cluster.
+// When kubeConfig is empty, the kubeconfig currently stored in the cluster secret is used
+// (eg. after the secret has been updated through the secret API).
+// The new credentials are rejected if the replaced ones still grant access to a different cluster.
+func (c *KubeCluster) RotateCredentials(ctx context.Context, kubeConfig []byte) error {
+	secretItem, err := getSecret(c.GetOrganizationId(), c.GetSecretId())
+	if err != nil {
+		return errors.WrapIf(err, "failed to get cluster secret")
+	}
+
+	if err := secret.ValidateSecretType(secretItem, c.GetCloud()); err != nil {
+		return err
+	}
+
+	// the replaced credentials are used to make sure that the new ones grant access to the same cluster
+	previousConfig, err := c.getConfig(c)
+	if err != nil && len(kubeConfig) > 0 {
+		previousConfig, err = base64.StdEncoding.DecodeString(secretItem.Values[secrettype.K8SConfig])
+	}
+	if err != nil {
+		previousConfig = nil
+	}
+
+	if len(kubeConfig) == 0 {
+		kubeConfig, err = base64.StdEncoding.DecodeString(secretItem.Values[secrettype.K8SConfig])
+		if err != nil {
+			return errors.WrapIf(err, "can't decode Kubernetes config")
+		}
+	}
+
+	client, err := k8sclient.NewClientFromKubeConfig(kubeConfig)
+	if err != nil {
+		return errors.WrapIf(err, "couldn't create Kubernetes client")
+	}
+
+	clusterIdentity, err := getClusterIdentity(ctx, client)
+	if err != nil {
+		return errors.WrapIf(err, "couldn't access the cluster with the new credentials")
+	}
+
+	if previousConfig != nil {
+		if previousClient, err := k8sclient.NewClientFromKubeConfigWithTimeout(previousConfig, previousCredentialsTimeout); err == nil {
+			if previousIdentity, err := getClusterIdentity(ctx, previousClient); err == nil && previousIdentity != clusterIdentity {
+				return errors.NewWithDetails("the new credentials belong to a different cluster", "cluster", c.GetName())
+			}
+		}
+	}
+
+	encodedConfig := base64.StdEncoding.EncodeToString(kubeConfig)
+	if encodedConfig != secretItem.Values[secrettype.K8SConfig] {
+		err := secret.Store.Update(c.GetOrganizationId(), c.GetSecretId(), &secret.CreateSecretRequest{
+			Name: secretItem.Name,
+			Type: secretItem.Type,
+			Values: map[string]string{
+				secrettype.K8SConfig: encodedConfig,
+			},
+			Tags: secretItem.Tags,
+		})
+		if err != nil {
+			return errors.WrapIf(err, "failed to update cluster secret")
+		}
+	}
+
+	c.secret = nil
+	c.config = nil
+	c.k8sConfig = kubeConfig
+
+	if err := StoreKubernetesConfig(c, kubeConfig); err != nil {
+		return errors.WrapIf(err, "failed to store Kubernetes config")
+	}
+
+	rbacEnabled, err := c.isRBACEnabled(client)
+	if err != nil {
+		return errors.WrapIf(err, "couldn't determine if RBAC is enabled on the cluster")
+	}
+
+	if rbacEnabled != c.modelCluster.RbacEnabled {
+		c.modelCluster.RbacEnabled = rbacEnabled
+
+		if err := c.modelCluster.Save(); err != nil {
+			return errors.WrapIf(err, "failed to persist cluster")
+		}
+	}
+
+	log.WithField("cluster", c.GetName()).Info("cluster credentials rotated")
+
+	return nil
+}
+
+// getClusterIdentity returns an identifier unique to the cluster accessible with the client:
+// the UID of the kube-system namespace (which cannot be deleted).
+func getClusterIdentity(ctx context.Context, client kubernetes.Interface) (string, error) {
+	namespace, err := client.CoreV1().Namespaces().Get(ctx, metav1.NamespaceSystem, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.WrapIf(err, "couldn't get kube-system namespace")
+	}
+
+	return string(namespace.UID), nil
+}
@@ -0,0 +1,44 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+// ErrCredentialRotationNotSupported is returned when the credentials of a cluster cannot be rotated.
+var ErrCredentialRotationNotSupported = errors.New("credential rotation is only supported for imported clusters")
+
+// RotateClusterCredentials replaces the kubeconfig used to access an imported cluster.
+// When kubeConfig is empty, the kubeconfig currently stored in the cluster secret is used.
+func (m *Manager) RotateClusterCredentials(ctx context.Context, cluster CommonCluster, kubeConfig []byte) error {
+	rotator, ok := cluster.(interface {
+		RotateCredentials(ctx context.Context, kubeConfig []byte) error
+	})
+	if !ok {
+		return errors.WithStack(ErrCredentialRotationNotSupported)
+	}
+
+	if err := rotator.RotateCredentials(ctx, kubeConfig); err != nil {
+		return err
+	}
+
+	// the cached proxy still uses the replaced credentials
+	m.DeleteKubeProxy(cluster)
+
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+
+	"emperror.dev/emperror"
+	"emperror.dev/errors"
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/internal/global"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	"github.com/banzaicloud/pipeline/pkg/k8sclient"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// DetachingMessage is the status message of clusters being detached.
+const DetachingMessage = "Detach is in progress"
+
+// ErrDetachNotSupported is returned when a cluster other than an imported one is detached.
+var ErrDetachNotSupported = errors.New("only imported clusters can be detached")
+
+// DetachCluster removes the components installed by Pipeline from an imported cluster
+// and removes the cluster from Pipeline without deleting it.
+func (m *Manager) DetachCluster(ctx context.Context, cluster CommonCluster, force bool) error {
+	if cluster.GetCloud() != pkgCluster.Kubernetes {
+		return errors.WithStack(ErrDetachNotSupported)
+	}
+
+	errorHandler := m.getClusterErrorHandler(ctx, cluster)
+
+	go func() {
+		defer emperror.HandleRecover(errorHandler.WithStatus(pkgCluster.Error, "internal error while detaching cluster"))
+
+		err := m.detachCluster(context.Background(), cluster, force)
+		if err != nil {
+			errorHandler.Handle(err)
+		}
+	}()
+
+	return nil
+}
+
+func (m *Manager) detachCluster(ctx context.Context, cluster CommonCluster, force bool) error {
+	logger := m.getLogger(ctx).WithFields(logrus.Fields{
+		"organization": cluster.GetOrganizationId(),
+		"cluster":      cluster.GetName(),
+		"force":        force,
+	})
+
+	logger.Info("detaching cluster")
+
+	if err := cluster.SetStatus(pkgCluster.Deleting, DetachingMessage); err != nil {
+		return errors.WrapIfWithDetails(err, "cluster status update failed", "cluster_id", cluster.GetID())
+	}
+
+	// failures are tolerated in force mode: the cluster is removed from Pipeline anyway
+	handleErr := func(err error) error {
+		if !force {
+			_ = cluster.SetStatus(pkgCluster.Error, err.Error())
+
+			return err
+		}
+
+		logger.Error(err)
+
+		return nil
+	}
+
+	config, err := cluster.GetK8sConfig()
+	if err != nil {
+		if err := handleErr(errors.WrapIf(err, "cannot access Kubernetes cluster")); err != nil {
+			return err
+		}
+	} else if err := m.deletePipelineComponents(ctx, cluster.GetOrganizationId(), config); err != nil {
+		if err := handleErr(err); err != nil {
+			return err
+		}
+	}
+
+	if err := deleteDnsRecordsOwnedByCluster(cluster); err != nil {
+		logger.Error(errors.WrapIf(err, "failed to delete cluster's DNS records"))
+	}
+
+	m.DeleteKubeProxy(cluster)
+
+	// the secret holding the imported kubeconfig is kept, so that the cluster can be imported again
+	logger.Info("deleting unused cluster secrets")
+	if err := secret.Store.DeleteByClusterUID(cluster.GetOrganizationId(), cluster.GetUID()); err != nil {
+		if err := handleErr(errors.WrapIf(err, "failed to delete unused cluster secrets")); err != nil {
+			return err
+		}
+	}
+
+	orgID := cluster.GetOrganizationId()
+	clusterName := cluster.GetName()
+	if err := cluster.DeleteFromDatabase(); err != nil {
+		if err := handleErr(errors.WrapIf(err, "failed to delete from the database")); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("cluster detached successfully")
+
+	if m.events != nil {
+		m.events.ClusterDeleted(orgID, clusterName)
+	}
+
+	return nil
+}
+
+// deletePipelineComponents uninstalls the releases installed by Pipeline and deletes the Pipeline namespace.
+// User namespaces and workloads are left intact.
+func (m *Manager) deletePipelineComponents(ctx context.Context, organizationID uint, kubeConfig []byte) error {
+	namespaces := []string{global.Config.Cluster.Namespace}
+	if ns := global.Config.Cluster.Labels.Namespace; ns != "" && ns != global.Config.Cluster.Namespace {
+		namespaces = append(namespaces, ns)
+	}
+
+	if err := m.releaseDeleter.DeleteReleases(ctx, organizationID, kubeConfig, namespaces); err != nil {
+		return errors.WrapIf(err, "failed to delete Pipeline deployments")
+	}
+
+	client, err := k8sclient.NewClientFromKubeConfig(kubeConfig)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get Kubernetes clientset from kubeconfig")
+	}
+
+	err = client.CoreV1().Namespaces().Delete(ctx, global.Config.Cluster.Namespace, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.WrapIfWithDetails(err, "failed to delete Pipeline namespace", "namespace", global.Config.Cluster.Namespace)
+	}
+
+	return nil
+}
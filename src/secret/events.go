@@ -0,0 +1,63 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+type secretEvents interface {
+	// SecretChanged event is emitted when a secret is created, updated, restored or deleted.
+	SecretChanged(organizationID uint, secretID string)
+
+	// SecretRotated event is emitted when the values of a secret are regenerated.
+	SecretRotated(organizationID uint, secretID string)
+}
+
+type nopSecretEvents struct {
+}
+
+func NewNopSecretEvents() *nopSecretEvents {
+	return &nopSecretEvents{}
+}
+
+func (*nopSecretEvents) SecretChanged(organizationID uint, secretID string) {
+}
+
+func (*nopSecretEvents) SecretRotated(organizationID uint, secretID string) {
+}
+
+type eventBus interface {
+	Publish(topic string, args ...interface{})
+}
+
+type secretEventBus struct {
+	eb eventBus
+}
+
+const (
+	secretChangedTopic = "secret_changed"
+	secretRotatedTopic = "secret_rotated"
+)
+
+func NewSecretEvents(eb eventBus) *secretEventBus {
+	return &secretEventBus{
+		eb: eb,
+	}
+}
+
+func (s *secretEventBus) SecretChanged(organizationID uint, secretID string) {
+	s.eb.Publish(secretChangedTopic, organizationID, secretID)
+}
+
+func (s *secretEventBus) SecretRotated(organizationID uint, secretID string) {
+	s.eb.Publish(secretRotatedTopic, organizationID, secretID)
+}
@@ -20,4 +20,7 @@ type ListSecretsQuery struct {
 	IDs    []string `form:"ids" json:"ids"`
 	Tags   []string `form:"tags" json:"tags"`
 	Values bool     `form:"values" json:"values"`
+
+	// ExpiringWithin selects the secrets storing certificates that expire within the specified number of days
+	ExpiringWithin *int `form:"expiringWithin" json:"expiringWithin,omitempty"`
 }
@@ -39,17 +39,32 @@ var Store *secretStore
 // nolint: gochecknoglobals
 var ErrSecretNotExists = fmt.Errorf("There's no secret with this ID")
 
+// ErrSecretVersionNotExists denotes 'Not Found' errors for secret versions
+// nolint: gochecknoglobals
+var ErrSecretVersionNotExists = fmt.Errorf("There's no secret version with this number")
+
+// ErrSecretNotRotatable denotes that the type of a secret cannot regenerate its values
+// nolint: gochecknoglobals
+var ErrSecretNotRotatable = fmt.Errorf("The type of this secret does not support rotation")
+
 // InitSecretStore initializes the global secret store.
 func InitSecretStore(store secret.Store, types secret.TypeList) {
 	Store = &secretStore{
 		SecretStore: store,
 		Types:       types,
+		Events:      NewNopSecretEvents(),
 	}
 }
 
 type secretStore struct {
 	SecretStore secret.Store
 	Types       secret.TypeList
+	Events      secretEvents
+}
+
+// SetEvents sets the listener notified about secret changes.
+func (ss *secretStore) SetEvents(events secretEvents) {
+	ss.Events = events
 }
 
 // CreateSecretRequest param for secretStore.Store
@@ -85,6 +100,17 @@ type SecretItemResponse struct {
 	Version   int               `json:"version"`
 	UpdatedAt time.Time         `json:"updatedAt"`
 	UpdatedBy string            `json:"updatedBy,omitempty" mapstructure:"updatedBy"`
+
+	// NotAfter is the expiry of the earliest expiring certificate stored in the secret (if any).
+	NotAfter     *time.Time `json:"notAfter,omitempty"`
+	DaysToExpiry *int       `json:"daysToExpiry,omitempty"`
+}
+
+// SecretVersionResponse describes a version of a secret
+type SecretVersionResponse struct {
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
 }
 
 // ValidateSecretType validates the secret type
@@ -162,6 +188,8 @@ func (ss *secretStore) Delete(organizationID uint, secretID string) error {
 		return err
 	}
 
+	ss.Events.SecretChanged(organizationID, secretID)
+
 	if ct, ok := secretType.(secret.CleanupType); ok {
 		err := ct.Cleanup(organizationID, s.Values, s.Tags)
 		if err != nil {
@@ -238,6 +266,8 @@ func (ss *secretStore) Store(organizationID uint, request *CreateSecretRequest)
 		return "", err
 	}
 
+	ss.Events.SecretChanged(organizationID, secretID)
+
 	return secretID, nil
 }
 
@@ -293,6 +323,8 @@ func (ss *secretStore) Update(organizationID uint, secretID string, request *Cre
 		return err
 	}
 
+	ss.Events.SecretChanged(organizationID, secretID)
+
 	return nil
 }
 
@@ -349,16 +381,20 @@ func (ss *secretStore) Get(organizationID uint, secretID string) (*SecretItemRes
 		return nil, err
 	}
 
-	return &SecretItemResponse{
+	item := &SecretItemResponse{
 		ID:        model.ID,
 		Name:      model.Name,
 		Type:      model.Type,
 		Values:    model.Values,
 		Tags:      model.Tags,
-		Version:   1,
+		Version:   model.Version,
 		UpdatedAt: model.UpdatedAt,
 		UpdatedBy: model.UpdatedBy,
-	}, nil
+	}
+
+	ss.setCertificateExpiry(item, model)
+
+	return item, nil
 }
 
 // Retrieve secret by secret Name secret/orgs/:orgid:/:id: scope
@@ -415,11 +451,17 @@ func (ss *secretStore) List(orgid uint, query *ListSecretsQuery) ([]*SecretItemR
 			Type:      model.Type,
 			Values:    model.Values,
 			Tags:      model.Tags,
-			Version:   1,
+			Version:   model.Version,
 			UpdatedAt: model.UpdatedAt,
 			UpdatedBy: model.UpdatedBy,
 		}
 
+		ss.setCertificateExpiry(responseItem, model)
+
+		if query.ExpiringWithin != nil && (responseItem.DaysToExpiry == nil || *responseItem.DaysToExpiry >= *query.ExpiringWithin) {
+			continue
+		}
+
 		if !query.Values {
 			// Clear the values otherwise
 			for k := range responseItem.Values {
@@ -433,6 +475,178 @@ func (ss *secretStore) List(orgid uint, query *ListSecretsQuery) ([]*SecretItemR
 	return responseItems, nil
 }
 
+// ListVersions lists the available versions of a secret secret/orgs/:orgid:/:id: scope
+func (ss *secretStore) ListVersions(organizationID uint, secretID string) ([]SecretVersionResponse, error) {
+	models, err := ss.SecretStore.ListVersions(context.Background(), organizationID, secretID)
+	if err != nil && errors.As(err, &secret.NotFoundError{}) {
+		return nil, ErrSecretNotExists
+	} else if err != nil {
+		return nil, err
+	}
+
+	versions := make([]SecretVersionResponse, 0, len(models))
+	for _, model := range models {
+		versions = append(versions, SecretVersionResponse{
+			Version:   model.Version,
+			UpdatedAt: model.UpdatedAt,
+			UpdatedBy: model.UpdatedBy,
+		})
+	}
+
+	return versions, nil
+}
+
+// GetVersion retrieves a specific version of a secret with its opaque values hidden secret/orgs/:orgid:/:id: scope
+func (ss *secretStore) GetVersion(organizationID uint, secretID string, version int) (*SecretItemResponse, error) {
+	model, err := ss.getVersion(organizationID, secretID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(model.Values))
+	for k, v := range model.Values {
+		values[k] = v
+	}
+
+	if secretType := ss.Types.Type(model.Type); secretType != nil {
+		for _, field := range secretType.Definition().Fields {
+			if _, ok := values[field.Name]; ok && field.Opaque {
+				values[field.Name] = "<hidden>"
+			}
+		}
+	}
+
+	item := &SecretItemResponse{
+		ID:        model.ID,
+		Name:      model.Name,
+		Type:      model.Type,
+		Values:    values,
+		Tags:      model.Tags,
+		Version:   model.Version,
+		UpdatedAt: model.UpdatedAt,
+		UpdatedBy: model.UpdatedBy,
+	}
+
+	ss.setCertificateExpiry(item, model)
+
+	return item, nil
+}
+
+// RestoreVersion writes the values of a previous version of a secret as its latest version secret/orgs/:orgid:/:id: scope
+func (ss *secretStore) RestoreVersion(organizationID uint, secretID string, version int, updatedBy string) error {
+	model, err := ss.getVersion(organizationID, secretID, version)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(logrus.Fields{
+		"organizationId": organizationID,
+		"secretId":       secretID,
+		"version":        version,
+	}).Debugln("restoring secret version")
+
+	model.UpdatedBy = updatedBy
+
+	if err := ss.SecretStore.Put(context.Background(), organizationID, model); err != nil {
+		return err
+	}
+
+	ss.Events.SecretChanged(organizationID, secretID)
+
+	return nil
+}
+
+// setCertificateExpiry sets the expiry of the certificates stored in a secret (if any) on a response item.
+func (ss *secretStore) setCertificateExpiry(item *SecretItemResponse, model secret.Model) {
+	secretType := ss.Types.Type(model.Type)
+	if secretType == nil {
+		return
+	}
+
+	expiry, ok, err := secret.GetCertificateExpiry(secretType, model.Values)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"secretId": model.ID,
+			"error":    err.Error(),
+		}).Debugln("failed to determine certificate expiry")
+
+		return
+	}
+
+	if !ok {
+		return
+	}
+
+	daysToExpiry := expiry.DaysToExpiry(time.Now())
+
+	item.NotAfter = &expiry.NotAfter
+	item.DaysToExpiry = &daysToExpiry
+}
+
+// IsRotatable tells whether the values of secrets with the given type can be regenerated.
+func (ss *secretStore) IsRotatable(secretType string) bool {
+	_, ok := ss.Types.Type(secretType).(secret.RotatorType)
+
+	return ok
+}
+
+// Rotate regenerates the values of a secret and writes them as its latest version secret/orgs/:orgid:/:id: scope
+func (ss *secretStore) Rotate(organizationID uint, secretID string, updatedBy string) error {
+	model, err := ss.SecretStore.Get(context.Background(), organizationID, secretID)
+	if err != nil && errors.As(err, &secret.NotFoundError{}) {
+		return ErrSecretNotExists
+	} else if err != nil {
+		return err
+	}
+
+	secretType := ss.Types.Type(model.Type)
+
+	rt, ok := secretType.(secret.RotatorType)
+	if !ok {
+		return ErrSecretNotRotatable
+	}
+
+	values, err := rt.Generate(organizationID, model.Name, rt.RotationSeed(model.Values), model.Tags)
+	if err != nil {
+		return err
+	}
+
+	if pt, ok := secretType.(secret.ProcessorType); ok {
+		values, err = pt.Process(values)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"organizationId": organizationID,
+		"secretId":       secretID,
+	}).Debugln("rotating secret")
+
+	model.Values = values
+	model.UpdatedBy = updatedBy
+
+	if err := ss.SecretStore.Put(context.Background(), organizationID, model); err != nil {
+		return err
+	}
+
+	ss.Events.SecretChanged(organizationID, secretID)
+	ss.Events.SecretRotated(organizationID, secretID)
+
+	return nil
+}
+
+func (ss *secretStore) getVersion(organizationID uint, secretID string, version int) (secret.Model, error) {
+	model, err := ss.SecretStore.GetVersion(context.Background(), organizationID, secretID, version)
+	if err != nil && errors.As(err, &secret.VersionNotFoundError{}) {
+		return secret.Model{}, ErrSecretVersionNotExists
+	} else if err != nil {
+		return secret.Model{}, err
+	}
+
+	return model, nil
+}
+
 // Verify secret secret/orgs/:orgid:/:id: scope
 func (ss *secretStore) Verify(organizationID uint, secretID string) error {
 	s, err := ss.Get(organizationID, secretID)